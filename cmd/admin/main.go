@@ -1,10 +1,16 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"os"
+
 	_ "github.com/ares-cloud/ares-ddd-admin/docs/admin"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/handlers"
 	"github.com/ares-cloud/ares-ddd-admin/internal/infrastructure/configs"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
 	"github.com/hertz-contrib/swagger"
 	swaggerFiles "github.com/swaggo/files"
 )
@@ -28,15 +34,27 @@ func init() {
 }
 
 type app struct {
-	server *hserver.Serve
+	server   *hserver.Serve
+	seed     *handlers.PermissionSeedHandler
+	logChain *handlers.LogChainHandler
+	db       database.IDataBase
 }
 
-func newApp(server *hserver.Serve) *app {
+func newApp(server *hserver.Serve, seed *handlers.PermissionSeedHandler, logChain *handlers.LogChainHandler, db database.IDataBase) *app {
 	return &app{
-		server: server,
+		server:   server,
+		seed:     seed,
+		logChain: logChain,
+		db:       db,
 	}
 }
 
+// subcommands 子命令, 返回进程退出码
+var subcommands = map[string]func(a *app, args []string) int{
+	"perm-seed": runPermSeed,
+	"log-chain": runLogChain,
+}
+
 // @title ares-ddd-admin
 // @version 1.0
 // @description This is a demo using go-server-template-admin.
@@ -61,6 +79,16 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	// 所有仓储登记完租户业务表后, 迁移独立Schema/数据库租户的表结构
+	if err := application.db.MigrateTenants(context.Background()); err != nil {
+		hlog.Errorf("migrate isolated tenants error: %v", err)
+	}
+	// 子命令: 执行后退出, 不启动服务
+	if run, ok := subcommands[flag.Arg(0)]; ok {
+		code := run(application, flag.Args()[1:])
+		cleanup()
+		os.Exit(code)
+	}
 	url := swagger.URL("/swagger/doc.json") // The url pointing to API definition
 	application.server.GetHertz().GET("/swagger/*any", swagger.WrapHandler(swaggerFiles.Handler, url))
 	defer cleanup()
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/handlers"
+)
+
+const permSeedUsage = `usage:
+  admin [-conf path] perm-seed export [-o permissions.yaml]
+  admin [-conf path] perm-seed diff -f permissions.yaml [-prune]
+  admin [-conf path] perm-seed import -f permissions.yaml [-prune]`
+
+// runPermSeed 权限种子子命令, 返回进程退出码
+func runPermSeed(a *app, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, permSeedUsage)
+		return 2
+	}
+	fs := flag.NewFlagSet("perm-seed "+args[0], flag.ContinueOnError)
+	file := fs.String("f", "", "seed file, .yaml/.yml/.json")
+	out := fs.String("o", "", "output file, default stdout")
+	format := fs.String("format", handlers.PermissionSeedYAML, "export format when writing to stdout: yaml/json")
+	prune := fs.Bool("prune", false, "delete permissions not present in the seed")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "export":
+		f := *format
+		if *out != "" {
+			if f = handlers.PermissionSeedFormat(*out); f == "" {
+				fmt.Fprintln(os.Stderr, "unsupported output file, use .yaml/.yml/.json")
+				return 2
+			}
+		}
+		content, herr := a.seed.HandleExport(ctx, f)
+		if herr != nil {
+			fmt.Fprintln(os.Stderr, herr)
+			return 1
+		}
+		if *out == "" {
+			_, _ = os.Stdout.Write(content)
+			return 0
+		}
+		if err := os.WriteFile(*out, content, 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		return 0
+	case "diff", "import":
+		f := handlers.PermissionSeedFormat(*file)
+		if f == "" {
+			fmt.Fprintln(os.Stderr, permSeedUsage)
+			return 2
+		}
+		content, err := os.ReadFile(*file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		result, herr := a.seed.HandleImport(ctx, &commands.ImportPermissionSeedCommand{
+			Format:  f,
+			DryRun:  args[0] == "diff",
+			Prune:   *prune,
+			Content: content,
+		})
+		if herr != nil {
+			fmt.Fprintln(os.Stderr, herr)
+			return 1
+		}
+		report, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(report))
+		return 0
+	default:
+		fmt.Fprintln(os.Stderr, permSeedUsage)
+		return 2
+	}
+}
@@ -14,8 +14,10 @@ import (
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/cors"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/oplog"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/sql_injection"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/tenant"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/token"
 	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
 	"github.com/google/wire"
 	"github.com/hertz-contrib/gzip"
 	"golang.org/x/text/language"
@@ -36,6 +38,7 @@ func NewServer(config *configs.Bootstrap, hc *h_redis.RedisClient,
 	bas *base.BaseServer,
 	ms *monitoring.Server,
 	sms *storage.Server,
+	tenantResolver tenant.ITenantResolver,
 ) *hserver.Serve {
 	tk := token.NewRdbToken(hc.GetClient(), config.JWT.Issuer, config.JWT.SigningKey, config.JWT.ExpirationToken, config.JWT.ExpirationRefresh, true)
 	svr := hserver.NewServe(&hserver.ServerConfig{
@@ -45,7 +48,13 @@ func NewServer(config *configs.Bootstrap, hc *h_redis.RedisClient,
 		Name:               config.Server.Name,
 		MaxRequestBodySize: config.Server.MaxRequestBodySize,
 	}, hserver.WithTokenizer(tk))
-	registerMiddleware(config, svr.GetHertz(), oplDbWriter)
+	registerMiddleware(config, svr.GetHertz(), oplDbWriter, tenantResolver)
+	// 停机时等请求处理完后写完队列中的操作日志
+	svr.OnStop(func() {
+		if err := oplog.GetLogger().Close(); err != nil {
+			hlog.Errorf("close operation log writer error: %v", err)
+		}
+	})
 	//创建基础路由
 	rg := svr.GetHertz().Group(baseUrl)
 	bas.Init(rg, tk)
@@ -54,15 +63,15 @@ func NewServer(config *configs.Bootstrap, hc *h_redis.RedisClient,
 	return svr
 }
 
-func NewCasBinEnforcer(hc *h_redis.RedisClient, pr psb.IPermissionsRepository) (*psb.Enforcer, error) {
-	enforcer, err := psb.NewEnforcer(pr, hc, baseUrl)
+func NewCasBinEnforcer(config *configs.Bootstrap, hc *h_redis.RedisClient, pr psb.IPermissionsRepository, ta psb.ITenantAttributeProvider) (*psb.Enforcer, error) {
+	enforcer, err := psb.NewEnforcer(pr, ta, hc, baseUrl, config.Tenant.GetPolicyCacheSize())
 	if err != nil {
 		return nil, err
 	}
 	return enforcer, nil
 }
 
-func registerMiddleware(con *configs.Bootstrap, server *server.Hertz, oplDbWriter oplog.IDbOperationLogWrite) {
+func registerMiddleware(con *configs.Bootstrap, server *server.Hertz, oplDbWriter oplog.IDbOperationLogWrite, tenantResolver tenant.ITenantResolver) {
 	// Set up cross domain and flow limiting middleware
 	server.Use(cors.Handler())
 	//Use compression
@@ -74,11 +83,24 @@ func registerMiddleware(con *configs.Bootstrap, server *server.Hertz, oplDbWrite
 	}
 	// server.Use(ratelimit.RateLimitMiddleware(10))
 	// 防止sql注入
-	server.Use(sql_injection.PreventSQLInjection())
+	server.Use(sql_injection.PreventSQLInjection(baseUrl + "/scim/"))
+	// 租户解析
+	server.Use(tenant.ResolveHandler(tenantResolver, buildTenantResolveConfig(con.Tenant)))
 
 	// 操作日志
 	//initOpLog(con.Log)
-	initDbOpLog(oplDbWriter)
+	initDbOpLog(con.OpLog, oplDbWriter)
+}
+
+func buildTenantResolveConfig(con *configs.Tenant) *tenant.ResolveConfig {
+	if con == nil {
+		return &tenant.ResolveConfig{}
+	}
+	return &tenant.ResolveConfig{
+		Header:     con.Header,
+		BaseDomain: con.BaseDomain,
+		Required:   con.Required,
+	}
 }
 
 //func initOpLog(con *configs.Log) {
@@ -90,7 +112,15 @@ func registerMiddleware(con *configs.Bootstrap, server *server.Hertz, oplDbWrite
 //	oplog.Init(writer)
 //}
 
-func initDbOpLog(oplDbWriter oplog.IDbOperationLogWrite) {
-	writer := oplog.NewDBWriter(oplDbWriter)
-	oplog.Init(writer)
+func initDbOpLog(con *configs.OpLog, oplDbWriter oplog.IDbOperationLogWrite) {
+	opt := oplog.BatchOption{
+		QueueSize:     con.GetQueueSize(),
+		BatchSize:     con.GetBatchSize(),
+		FlushInterval: con.GetFlushInterval(),
+		CloseTimeout:  con.GetCloseTimeout(),
+	}
+	if dir := con.GetFallbackDir(); dir != "" {
+		opt.Fallback = oplog.NewFileWriter(dir)
+	}
+	oplog.Init(oplog.NewBatchWriter(oplDbWriter, opt))
 }
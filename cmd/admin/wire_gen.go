@@ -11,12 +11,19 @@ import (
 	"github.com/ares-cloud/ares-ddd-admin/internal/base"
 	handlers2 "github.com/ares-cloud/ares-ddd-admin/internal/base/application/handlers"
 	service2 "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/avatar"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/base/casbin"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/base/oplog"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/base/tenant"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/cleaner"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/converter"
 	handlers4 "github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/handlers"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/invitation"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/notify"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/offboard"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/data"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/provision"
 	cache2 "github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query/cache"
 	handlers3 "github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query/cache/handlers"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query/impl"
@@ -31,14 +38,17 @@ import (
 	storage2 "github.com/ares-cloud/ares-ddd-admin/internal/storage"
 	handlers5 "github.com/ares-cloud/ares-ddd-admin/internal/storage/application/handlers"
 	service3 "github.com/ares-cloud/ares-ddd-admin/internal/storage/domain/service"
-	"github.com/ares-cloud/ares-ddd-admin/internal/storage/infrastructure/cleaner"
+	cleaner2 "github.com/ares-cloud/ares-ddd-admin/internal/storage/infrastructure/cleaner"
+	offboard2 "github.com/ares-cloud/ares-ddd-admin/internal/storage/infrastructure/offboard"
 	data2 "github.com/ares-cloud/ares-ddd-admin/internal/storage/infrastructure/persistence/data"
 	repository2 "github.com/ares-cloud/ares-ddd-admin/internal/storage/infrastructure/persistence/repository"
+	provision2 "github.com/ares-cloud/ares-ddd-admin/internal/storage/infrastructure/provision"
 	impl2 "github.com/ares-cloud/ares-ddd-admin/internal/storage/infrastructure/query/impl"
 	"github.com/ares-cloud/ares-ddd-admin/internal/storage/infrastructure/storage"
 	rest3 "github.com/ares-cloud/ares-ddd-admin/internal/storage/interfaces/rest"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database/snowflake_id"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/events"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/tenantdata"
 )
 
 import (
@@ -62,13 +72,22 @@ func wireApp(bootstrap *configs.Bootstrap, configsData *configs.Data, storageCon
 		cleanup()
 		return nil, nil, err
 	}
-	iOperationLogRepo := repository.NewOperationLogRepository(iDataBase)
+	iLogChainRepo := repository.NewLogChainRepository(iDataBase)
+	iOperationLogRepo := repository.NewOperationLogRepository(iDataBase, iLogChainRepo)
+	iAuditLogRepo := repository.NewAuditLogRepository(iDataBase)
 	iDbOperationLogWrite := oplog.NewDbOperationLogWriter(iOperationLogRepo)
 	iSysRoleRepo := data.NewSysRoleRepo(iDataBase)
 	iPermissionsRepo := data.NewSysMenuRepo(iDataBase)
 	iRoleRepository := repository.NewRoleRepository(iSysRoleRepo, iPermissionsRepo)
 	iEventBus := events.NewEventBus()
-	roleCommandService := service2.NewRoleCommandService(iRoleRepository, iEventBus)
+	iSysRecycleBinRepo := data.NewSysRecycleBinRepo(iDataBase)
+	iRecycleBinRepository := repository.NewRecycleBinRepository(iSysRecycleBinRepo)
+	iSysRoleGrantRepo := data.NewSysRoleGrantRepo(iDataBase)
+	iRoleGrantRepository := repository.NewRoleGrantRepository(iSysRoleGrantRepo)
+	iSysRoleConstraintRepo := data.NewSysRoleConstraintRepo(iDataBase)
+	iRoleConstraintRepository := repository.NewRoleConstraintRepository(iSysRoleConstraintRepo)
+	roleConstraintService := service2.NewRoleConstraintService(iRoleConstraintRepository, iRoleRepository)
+	roleCommandService := service2.NewRoleCommandService(iRoleRepository, iRecycleBinRepository, iEventBus)
 	roleCommandHandler := handlers2.NewRoleCommandHandler(roleCommandService)
 	roleConverter := converter.NewRoleConverter()
 	userConverter := converter.NewUserConverter()
@@ -80,89 +99,164 @@ func wireApp(bootstrap *configs.Bootstrap, configsData *configs.Data, storageCon
 	roleQueryCache := cache2.NewRoleQueryCache(roleQueryService, cacheDecorator)
 	roleQueryHandler := handlers2.NewRoleQueryHandler(roleQueryCache, roleConverter)
 	iPermissionsRepository := casbin.NewRepositoryImpl(iSysRoleRepo, iPermissionsRepo)
-	enforcer, err := server.NewCasBinEnforcer(redisClient, iPermissionsRepository)
+	iSysTenantRepo := data.NewSysTenantRepo(iDataBase)
+	iTenantAttributeProvider := casbin.NewTenantAttributeProviderImpl(iSysTenantRepo)
+	enforcer, err := server.NewCasBinEnforcer(bootstrap, redisClient, iPermissionsRepository, iTenantAttributeProvider)
 	if err != nil {
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	sysRoleController := rest2.NewSysRoleController(roleCommandHandler, roleQueryHandler, enforcer)
 	iSysUserRepo := data.NewSysUserRepo(iDataBase)
 	iUserRepository := repository.NewUserRepository(iSysUserRepo, iSysRoleRepo)
-	userCommandService := service2.NewUserCommandService(iUserRepository, iEventBus)
-	userCommandHandler := handlers2.NewUserCommandHandler(userCommandService)
+	registry := tenantdata.NewRegistry()
+	iTenantRepository := repository.NewTenantRepository(iSysTenantRepo, iSysUserRepo, registry)
+	userCommandService := service2.NewUserCommandService(iUserRepository, iTenantRepository, iRecycleBinRepository, iRoleRepository, iRoleGrantRepository, roleConstraintService, iEventBus)
 	iSysDepartmentRepo := data.NewSysDepartmentRepo(iDataBase)
+	iDepartmentRepository := repository.NewDepartmentRepository(iSysDepartmentRepo)
+	recycleBinService := service2.NewRecycleBinService(iRecycleBinRepository, iUserRepository, iRoleRepository, iDepartmentRepository, iRoleGrantRepository, roleConstraintService, iEventBus)
+	recycleBinQueryService := impl.NewRecycleBinQueryService(iSysRecycleBinRepo, bootstrap)
+	recycleBinHandler := handlers2.NewRecycleBinHandler(recycleBinService, recycleBinQueryService)
+	roleGrantService := service2.NewRoleGrantService(iRoleGrantRepository, iRoleRepository, iUserRepository, roleConstraintService, iEventBus)
+	roleGrantQueryService := impl.NewRoleGrantQueryService(iSysRoleGrantRepo, iSysRoleRepo)
+	roleGrantHandler := handlers2.NewRoleGrantHandler(roleGrantService, roleGrantQueryService)
+	roleConstraintQueryService := impl.NewRoleConstraintQueryService(iSysRoleConstraintRepo, iSysRoleRepo)
+	roleConstraintHandler := handlers2.NewRoleConstraintHandler(roleConstraintService, roleConstraintQueryService)
+	sysRoleController := rest2.NewSysRoleController(roleCommandHandler, roleQueryHandler, recycleBinHandler, roleConstraintHandler, enforcer)
+	userImportService := service2.NewUserImportService(iUserRepository, iRoleRepository, iDepartmentRepository, iRoleGrantRepository, roleConstraintService, iEventBus)
+	userCommandHandler := handlers2.NewUserCommandHandler(userCommandService, userImportService)
+	iInvitationTokenProvider := invitation.NewTokenProvider(bootstrap)
+	userInvitationService := service2.NewUserInvitationService(iUserRepository, iRoleRepository, iDepartmentRepository, iRoleGrantRepository, iInvitationTokenProvider, roleConstraintService, iEventBus)
 	departmentConverter := converter.NewDepartmentConverter()
-	iSysTenantRepo := data.NewSysTenantRepo(iDataBase)
 	userQueryService := impl.NewUserQueryService(iSysUserRepo, iSysRoleRepo, iPermissionsRepo, userConverter, roleConverter, permissionsConverter, iSysDepartmentRepo, departmentConverter, iSysTenantRepo, bootstrap)
 	userQueryCache := cache2.NewUserQueryCache(userQueryService, cacheDecorator)
-	userQueryHandler := handlers2.NewUserQueryHandler(userQueryCache)
-	sysUserController := rest2.NewSysUserController(userCommandHandler, userQueryHandler, enforcer)
-	iTenantRepository := repository.NewTenantRepository(iSysTenantRepo, iSysUserRepo)
+	iSysPositionRepo := data.NewSysPositionRepo(iDataBase)
+	positionConverter := converter.NewPositionConverter()
+	positionQueryService := impl.NewPositionQueryService(iSysPositionRepo, positionConverter)
+	positionQueryCache := cache2.NewPositionQueryCache(positionQueryService, cacheDecorator)
+	userQueryHandler := handlers2.NewUserQueryHandler(userQueryCache, positionQueryCache)
+	userInvitationHandler := handlers2.NewUserInvitationHandler(userInvitationService, userQueryCache)
+	iAuthRepository := repository.NewAuthRepository(iUserRepository, redisClient)
+	iLoginLogRepo := data.NewLoginLogRepo(iDataBase, iLogChainRepo)
+	iLoginLogRepository := repository.NewLoginLogRepository(iLoginLogRepo)
+	iSysImpersonationRepo := data.NewSysImpersonationRepo(iDataBase)
+	iImpersonationRepository := repository.NewImpersonationRepository(iSysImpersonationRepo)
+	impersonationService := service2.NewImpersonationService(iImpersonationRepository, iUserRepository, iEventBus)
+	authHandler := handlers2.NewAuthHandler(bootstrap, iAuthRepository, userQueryCache, iLoginLogRepository, impersonationService)
+	sysUserController := rest2.NewSysUserController(userCommandHandler, userQueryHandler, userInvitationHandler, recycleBinHandler, roleGrantHandler, authHandler, enforcer)
 	tenantCommandService := service2.NewTenantCommandService(iTenantRepository, iEventBus)
-	tenantCommandHandler := handlers2.NewTenantCommandHandler(tenantCommandService)
+	iSysTenantTemplateRepo := data.NewSysTenantTemplateRepo(iDataBase)
+	baseProvisioner := provision.NewBaseProvisioner(iDataBase)
+	iTenantTemplateRepository := repository.NewTenantTemplateRepository(iSysTenantTemplateRepo, registry, baseProvisioner)
+	tenantTemplateService := service2.NewTenantTemplateService(iTenantRepository, iTenantTemplateRepository)
+	tenantCommandHandler := handlers2.NewTenantCommandHandler(tenantCommandService, tenantTemplateService, enforcer)
 	tenantConverter := converter.NewTenantConverter(userConverter)
-	tenantQueryService := impl.NewTenantQueryService(iSysTenantRepo, iSysUserRepo, iPermissionsRepo, tenantConverter, permissionsConverter)
+	iSysTenantJobRepo := data.NewSysTenantJobRepo(iDataBase)
+	tenantQueryService := impl.NewTenantQueryService(iSysTenantRepo, iSysUserRepo, iPermissionsRepo, tenantConverter, permissionsConverter, iSysTenantJobRepo, iSysTenantTemplateRepo)
 	tenantQueryCache := cache2.NewTenantQueryCache(tenantQueryService, cacheDecorator)
 	tenantQueryHandler := handlers2.NewTenantQueryHandler(tenantQueryCache)
-	sysTenantController := rest2.NewSysTenantController(tenantCommandHandler, tenantQueryHandler, enforcer)
+	iTenantJobRepository := repository.NewTenantJobRepository(iSysTenantJobRepo)
+	tenantJobService := service2.NewTenantJobService(iTenantRepository, iTenantJobRepository, iEventBus)
+	tenantJobHandler := handlers2.NewTenantJobHandler(tenantJobService, tenantQueryCache)
+	tenantTemplateHandler := handlers2.NewTenantTemplateHandler(tenantTemplateService, tenantQueryCache)
+	sysTenantController := rest2.NewSysTenantController(tenantCommandHandler, tenantQueryHandler, tenantJobHandler, tenantTemplateHandler, enforcer)
 	repositoryIPermissionsRepository := repository.NewPermissionsRepository(iPermissionsRepo)
 	permissionService := service2.NewPermissionService(repositoryIPermissionsRepository, iEventBus)
 	permissionsCommandHandler := handlers2.NewPermissionsCommandHandler(permissionService, enforcer)
 	permissionsQueryService := impl.NewPermissionsQueryService(iPermissionsRepo, iSysTenantRepo, permissionsConverter)
 	permissionsQueryCache := cache2.NewPermissionsQueryCache(permissionsQueryService, cacheDecorator)
-	permissionsQueryHandler := handlers2.NewPermissionsQueryHandler(permissionsQueryCache)
-	sysPermissionsController := rest2.NewSysPermissionsController(permissionsCommandHandler, permissionsQueryHandler, enforcer)
-	iAuthRepository := repository.NewAuthRepository(iUserRepository, redisClient)
-	iLoginLogRepo := data.NewLoginLogRepo(iDataBase)
-	iLoginLogRepository := repository.NewLoginLogRepository(iLoginLogRepo)
-	authHandler := handlers2.NewAuthHandler(bootstrap, iAuthRepository, userQueryCache, iLoginLogRepository)
-	authController := rest2.NewAuthController(authHandler)
+	permissionsQueryHandler := handlers2.NewPermissionsQueryHandler(permissionsQueryCache, userQueryCache, enforcer)
+	permissionSeedHandler := handlers2.NewPermissionSeedHandler(permissionService, enforcer)
+	sysPermissionsController := rest2.NewSysPermissionsController(permissionsCommandHandler, permissionsQueryHandler, permissionSeedHandler, enforcer)
+	authController := rest2.NewAuthController(authHandler, userInvitationHandler)
 	loginLogQueryService := impl.NewLoginLogQueryService(iLoginLogRepo)
 	loginLogQueryHandler := handlers2.NewLoginLogQueryHandler(loginLogQueryService)
 	loginLogController := rest2.NewLoginLogController(loginLogQueryHandler, enforcer)
 	operationLogQueryService := impl.NewOperationLogQueryService(iOperationLogRepo)
-	operationLogQueryHandler := handlers2.NewOperationLogQueryHandler(operationLogQueryService)
-	operationLogController := rest2.NewOperationLogController(operationLogQueryHandler, enforcer)
-	iDepartmentRepository := repository.NewDepartmentRepository(iSysDepartmentRepo)
-	departmentService := service2.NewDepartmentService(iDepartmentRepository, iUserRepository, iEventBus)
+	auditLogQueryService := impl.NewAuditLogQueryService(iAuditLogRepo)
+	operationLogQueryHandler := handlers2.NewOperationLogQueryHandler(operationLogQueryService, auditLogQueryService)
+	logChainQueryService := impl.NewLogChainQueryService(iLogChainRepo, iOperationLogRepo, iLoginLogRepo)
+	logChainHandler := handlers2.NewLogChainHandler(logChainQueryService, bootstrap)
+	operationLogController := rest2.NewOperationLogController(operationLogQueryHandler, logChainHandler, enforcer)
+	departmentService := service2.NewDepartmentService(iDepartmentRepository, iUserRepository, iRecycleBinRepository, iEventBus)
 	departmentCommandHandler := handlers2.NewDepartmentCommandHandler(departmentService)
 	departmentQueryService := impl.NewDepartmentQueryService(iSysDepartmentRepo, iSysUserRepo, departmentConverter, userConverter)
 	departmentQueryCache := cache2.NewDepartmentQueryCache(departmentQueryService, cacheDecorator)
 	departmentQueryHandler := handlers2.NewDepartmentQueryHandler(departmentQueryCache)
-	departmentController := rest2.NewDepartmentController(departmentCommandHandler, departmentQueryHandler, enforcer)
+	departmentController := rest2.NewDepartmentController(departmentCommandHandler, departmentQueryHandler, recycleBinHandler, enforcer)
+	iPositionRepository := repository.NewPositionRepository(iSysPositionRepo)
+	positionService := service2.NewPositionService(iPositionRepository, iUserRepository, iEventBus)
+	positionCommandHandler := handlers2.NewPositionCommandHandler(positionService)
+	positionQueryHandler := handlers2.NewPositionQueryHandler(positionQueryCache)
+	positionController := rest2.NewPositionController(positionCommandHandler, positionQueryHandler, enforcer)
+	scimHandler := handlers2.NewScimHandler(userCommandService, departmentService, iRoleRepository, userQueryCache, departmentQueryCache)
+	iSysScimTokenRepo := data.NewSysScimTokenRepo(iDataBase)
+	iScimTokenRepository := repository.NewScimTokenRepository(iSysScimTokenRepo)
+	scimTokenService := service2.NewScimTokenService(iScimTokenRepository, iTenantRepository)
+	scimTokenHandler := handlers2.NewScimTokenHandler(scimTokenService)
+	scimController := rest2.NewScimController(scimHandler, scimTokenHandler, enforcer)
 	iDataPermissionRepo := data.NewDataPermissionRepo(iDataBase)
 	iDataPermissionRepository := repository.NewDataPermissionRepository(iDataPermissionRepo)
-	dataPermissionService := service2.NewDataPermissionService(iDataPermissionRepository, iRoleRepository, iEventBus)
+	dataPermissionService := service2.NewDataPermissionService(iDataPermissionRepository, iRoleRepository, iUserRepository, iDepartmentRepository, iEventBus)
 	dataPermissionCommandHandler := handlers2.NewDataPermissionCommandHandler(dataPermissionService)
 	dataPermissionConverter := converter.NewDataPermissionConverter()
 	dataPermissionQueryService := impl.NewDataPermissionQueryService(iDataPermissionRepo, dataPermissionConverter)
 	dataPermissionQueryCache := cache2.NewDataPermissionQueryCache(dataPermissionQueryService, cacheDecorator)
-	dataPermissionQueryHandler := handlers2.NewDataPermissionQueryHandler(dataPermissionQueryCache)
+	dataPermissionQueryHandler := handlers2.NewDataPermissionQueryHandler(dataPermissionQueryCache, dataPermissionService)
 	dataPermissionController := rest2.NewDataPermissionController(dataPermissionCommandHandler, dataPermissionQueryHandler)
-	eventHandler := handlers3.NewCacheEventHandler(userQueryCache, roleQueryCache, departmentQueryCache, permissionsQueryCache, dataPermissionQueryCache, tenantQueryCache)
-	userEventHandler := handlers4.NewUserEventHandler()
-	handlerEvent := handlers4.NewHandlerEvent(iEventBus, eventHandler, userEventHandler)
-	baseServer := base.NewBaseServer(sysRoleController, sysUserController, sysTenantController, sysPermissionsController, authController, loginLogController, operationLogController, departmentController, dataPermissionController, handlerEvent)
-	monitoringServer := monitoring.NewServer(metricsController)
+	eventHandler := handlers3.NewCacheEventHandler(userQueryCache, roleQueryCache, departmentQueryCache, positionQueryCache, permissionsQueryCache, dataPermissionQueryCache, tenantQueryCache)
+	iInvitationSender := notify.NewInvitationSender(bootstrap)
+	userEventHandler := handlers4.NewUserEventHandler(iInvitationSender)
+	tenantJobRunner := offboard.NewTenantJobRunner(bootstrap, iDataBase, iTenantJobRepository, iSysTenantJobRepo, iSysTenantRepo, registry, iEventBus)
+	policyEventHandler := handlers4.NewPolicyEventHandler(enforcer)
+	resolverImpl := tenant.NewResolverImpl(iSysTenantRepo)
+	cachedResolver := tenant.NewCachedResolver(resolverImpl, bootstrap)
+	tenantResolveEventHandler := handlers4.NewTenantResolveEventHandler(cachedResolver)
+	handlerEvent := handlers4.NewHandlerEvent(iEventBus, eventHandler, userEventHandler, policyEventHandler, tenantResolveEventHandler, tenantJobRunner)
+	recycleCleaner := cleaner.NewRecycleCleaner(recycleBinService, iSysTenantRepo, bootstrap)
+	roleGrantExpirer := cleaner.NewRoleGrantExpirer(roleGrantService, iSysTenantRepo, bootstrap)
+	logCheckpointExporter := cleaner.NewLogCheckpointExporter(logChainQueryService, bootstrap)
+	iVerifyCodeSender := notify.NewCodeSender(bootstrap)
 	iStorageRepos := data2.NewStorageRepo(iDataBase)
 	storageFactory := storage.NewStorageFactory(storageConfig, redisClient)
-	iStorageQueryService := impl2.NewStorageQueryService(iStorageRepos, storageFactory)
-	storageQueryHandler := handlers5.NewStorageQueryHandler(iStorageQueryService)
 	iStorageRepository := repository2.NewStorageRepository(iDataBase, iStorageRepos)
 	storageService := service3.NewStorageService(iStorageRepository, storageFactory)
+	iAvatarStorage := avatar.NewStorageAvatar(storageService)
+	profileService := service2.NewProfileService(iUserRepository, iAuthRepository, iVerifyCodeSender, iAvatarStorage, iEventBus)
+	authService := service2.NewAuthService(iUserRepository, iEventBus, userQueryCache)
+	profileHandler := handlers2.NewProfileHandler(profileService, authService, userCommandService, userQueryCache, loginLogQueryService)
+	profileController := rest2.NewProfileController(profileHandler)
+	iSysAccessReviewRepo := data.NewSysAccessReviewRepo(iDataBase)
+	iAccessReviewRepository := repository.NewAccessReviewRepository(iSysAccessReviewRepo)
+	accessReviewService := service2.NewAccessReviewService(iAccessReviewRepository, iDepartmentRepository, iEventBus)
+	accessReviewQueryService := impl.NewAccessReviewQueryService(iSysAccessReviewRepo, iSysUserRepo, iSysRoleRepo, iPermissionsRepo)
+	accessReviewHandler := handlers2.NewAccessReviewHandler(bootstrap, accessReviewService, accessReviewQueryService)
+	accessReviewController := rest2.NewAccessReviewController(accessReviewHandler, enforcer)
+	baseServer, cleanup3, err := base.NewBaseServer(sysRoleController, sysUserController, sysTenantController, sysPermissionsController, authController, loginLogController, operationLogController, departmentController, positionController, dataPermissionController, scimController, profileController, accessReviewController, handlerEvent, recycleCleaner, roleGrantExpirer, logCheckpointExporter)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	monitoringServer := monitoring.NewServer(metricsController)
+	iStorageQueryService := impl2.NewStorageQueryService(iStorageRepos, storageFactory)
+	storageQueryHandler := handlers5.NewStorageQueryHandler(iStorageQueryService)
 	storageCommandHandler := handlers5.NewStorageCommandHandler(storageService)
 	storageController := rest3.NewStorageController(storageQueryHandler, storageCommandHandler)
-	recycleCleaner := cleaner.NewRecycleCleaner(iStorageRepos, storageService, storageConfig)
-	storageServer, cleanup3, err := storage2.NewServer(storageController, recycleCleaner)
+	cleanerRecycleCleaner := cleaner2.NewRecycleCleaner(iStorageRepos, storageService, storageConfig)
+	storageSection := offboard2.NewStorageSection(iDataBase, storageFactory)
+	folderProvisioner := provision2.NewFolderProvisioner(storageService, iStorageRepos)
+	storageServer, cleanup4, err := storage2.NewServer(storageController, cleanerRecycleCleaner, registry, storageSection, folderProvisioner)
 	if err != nil {
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	serve := server.NewServer(bootstrap, redisClient, metricsController, iDbOperationLogWrite, baseServer, monitoringServer, storageServer)
-	mainApp := newApp(serve)
+	serve := server.NewServer(bootstrap, redisClient, metricsController, iDbOperationLogWrite, baseServer, monitoringServer, storageServer, cachedResolver)
+	mainApp := newApp(serve, permissionSeedHandler, logChainHandler, iDataBase)
 	return mainApp, func() {
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/validator"
+)
+
+// StartAccessReviewCommand 发起权限复核活动
+type StartAccessReviewCommand struct {
+	Name      string  `json:"name" validate:"required,max=128" label:"活动名称"`
+	ScopeType int8    `json:"scopeType" validate:"required,oneof=1 2" label:"范围类型"` // 1:部门及其下级部门 2:角色集合
+	DeptID    string  `json:"deptId" label:"部门ID"`                                  // 部门范围的根部门
+	RoleIDs   []int64 `json:"roleIds" label:"角色"`                                   // 角色范围的角色集合
+	DueAt     int64   `json:"dueAt" validate:"gte=0" label:"截止时间"`                  // 0 表示不限
+}
+
+func (c *StartAccessReviewCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// DecideAccessReviewItemCommand 复核人记录复核结果
+type DecideAccessReviewItemCommand struct {
+	ID       string `json:"id" path:"id" validate:"required" label:"复核项ID"`
+	Decision int8   `json:"decision" validate:"required,oneof=1 2" label:"复核结果"` // 1:保留 2:收回
+	Comment  string `json:"comment" validate:"max=512" label:"复核意见"`
+}
+
+func (c *DecideAccessReviewItemCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
@@ -38,3 +38,32 @@ type RefreshTokenCommand struct {
 func (c *RefreshTokenCommand) Validate() herrors.Herr {
 	return validator.Validate(c)
 }
+
+// SwitchTenantCommand 切换租户命令
+type SwitchTenantCommand struct {
+	TenantId string `json:"tenantId" validate:"required" label:"租户ID"`
+}
+
+func (c *SwitchTenantCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// ImpersonateUserCommand 模拟登录命令
+type ImpersonateUserCommand struct {
+	UserID  string `json:"userId" path:"id" validate:"required" label:"用户ID"`
+	Reason  string `json:"reason" validate:"required,max=512" label:"模拟原因"`
+	Minutes int    `json:"minutes" validate:"gte=0,lte=240" label:"有效分钟数"` // 0 表示默认30分钟
+}
+
+func (c *ImpersonateUserCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// StopImpersonationCommand 结束模拟登录命令
+type StopImpersonationCommand struct {
+	ID string `json:"id" validate:"required" label:"模拟登录会话ID"`
+}
+
+func (c *StopImpersonationCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
@@ -7,9 +7,10 @@ import (
 
 // AssignDataPermissionCommand 分配数据权限命令
 type AssignDataPermissionCommand struct {
-	RoleID  int64    `json:"roleId" validate:"required" label:"角色ID"`                 // 修改为int64
-	Scope   int8     `json:"scope" validate:"omitempty,oneof=1 2 3 4 5" label:"数据范围"` // 数据范围
-	DeptIDs []string `json:"deptIds" validate:"required_if=Scope 5" label:"部门ID列表"`   // 部门ID列表(自定义数据权限时使用)
+	RoleID      int64    `json:"roleId" validate:"required" label:"角色ID"`                   // 修改为int64
+	Scope       int8     `json:"scope" validate:"omitempty,oneof=1 2 3 4 5 6" label:"数据范围"` // 数据范围
+	DeptIDs     []string `json:"deptIds" validate:"required_if=Scope 5" label:"部门ID列表"`     // 部门ID列表(自定义数据权限时使用)
+	PositionIDs []string `json:"positionIds" validate:"required_if=Scope 6" label:"岗位ID列表"` // 岗位ID列表(岗位数据权限时使用)
 }
 
 func (c *AssignDataPermissionCommand) Validate() herrors.Herr {
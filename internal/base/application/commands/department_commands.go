@@ -60,6 +60,16 @@ func (c *MoveDepartmentCommand) Validate() herrors.Herr {
 	return validator.Validate(c)
 }
 
+// MergeDepartmentCommand 合并部门命令
+type MergeDepartmentCommand struct {
+	SourceID string `json:"sourceId" validate:"required" label:"源部门ID"`
+	TargetID string `json:"targetId" validate:"required,nefield=SourceID" label:"目标部门ID"`
+}
+
+func (c *MergeDepartmentCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
 // SetDepartmentAdminCommand 设置部门管理员命令
 type SetDepartmentAdminCommand struct {
 	DeptID  string `json:"deptId" validate:"required" label:"部门ID"`
@@ -101,3 +111,13 @@ type TransferUserCommand struct {
 func (c *TransferUserCommand) Validate() herrors.Herr {
 	return validator.Validate(c)
 }
+
+// SetPrimaryDepartmentCommand 设置用户主部门命令
+type SetPrimaryDepartmentCommand struct {
+	UserID string `json:"userId" validate:"required" label:"用户ID"`
+	DeptID string `json:"deptId" validate:"required" label:"部门ID"`
+}
+
+func (c *SetPrimaryDepartmentCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
@@ -26,8 +26,9 @@ func (c *CreatePermissionsCommand) Validate() herrors.Herr {
 
 // CreatePermissionsResourceCommand 创建权限资源命令
 type CreatePermissionsResourceCommand struct {
-	Method string `json:"method" validate:"required,oneof=GET POST PUT DELETE" label:"请求方法"`
-	Path   string `json:"path" validate:"required" label:"资源路径"`
+	Method    string `json:"method" validate:"required,oneof=GET POST PUT DELETE" label:"请求方法"`
+	Path      string `json:"path" validate:"required" label:"资源路径"`
+	Condition string `json:"condition" validate:"omitempty,max=512" label:"条件表达式"` // 例如 ipIn(ip, "10.0.0.0/8") && clock >= "09:00"
 }
 
 func (c *CreatePermissionsResourceCommand) Validate() herrors.Herr {
@@ -62,3 +63,15 @@ type DeletePermissionsCommand struct {
 func (c *DeletePermissionsCommand) Validate() herrors.Herr {
 	return validator.Validate(c)
 }
+
+// ImportPermissionSeedCommand 导入权限种子命令
+type ImportPermissionSeedCommand struct {
+	Format  string `json:"format" validate:"required,oneof=yaml json" label:"文件格式"`
+	DryRun  bool   `json:"dryRun" label:"仅比较"`
+	Prune   bool   `json:"prune" label:"删除种子中没有的权限"`
+	Content []byte `json:"-" validate:"required" label:"文件内容"`
+}
+
+func (c *ImportPermissionSeedCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/validator"
+)
+
+// CreatePositionCommand 创建岗位命令
+type CreatePositionCommand struct {
+	Code        string `json:"code" validate:"required,min=2,max=50" label:"岗位编码"`  // 岗位编码
+	Name        string `json:"name" validate:"required,max=100" label:"岗位名称"`       // 岗位名称
+	Sort        int32  `json:"sort" validate:"gte=0,lte=999" label:"显示顺序"`          // 排序
+	Status      int8   `json:"status" validate:"oneof=0 1" label:"岗位状态"`            // 岗位状态(0停用 1启用)
+	Description string `json:"description" validate:"omitempty,max=200" label:"描述"` // 描述
+}
+
+func (c *CreatePositionCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// UpdatePositionCommand 更新岗位命令
+type UpdatePositionCommand struct {
+	ID          string `json:"id" validate:"required" label:"岗位ID"`
+	Code        string `json:"code" validate:"required,min=2,max=50" label:"岗位编码"`
+	Name        string `json:"name" validate:"required,max=100" label:"岗位名称"`
+	Sort        int32  `json:"sort" validate:"gte=0,lte=999" label:"显示顺序"`
+	Status      int8   `json:"status" validate:"oneof=0 1" label:"岗位状态"`
+	Description string `json:"description" validate:"omitempty,max=200" label:"描述"`
+}
+
+func (c *UpdatePositionCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// DeletePositionCommand 删除岗位命令
+type DeletePositionCommand struct {
+	ID string `json:"id" validate:"required" label:"岗位ID"`
+}
+
+func (c *DeletePositionCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// SetUserPositionsCommand 设置用户任职岗位命令
+type SetUserPositionsCommand struct {
+	UserID      string   `json:"userId" validate:"required" label:"用户ID"`
+	PositionIDs []string `json:"positionIds" validate:"omitempty,dive,required" label:"岗位ID列表"` // 为空则清空任职岗位
+	PrimaryID   string   `json:"primaryId" label:"主岗ID"`                                        // 为空则第一个岗位为主岗
+}
+
+func (c *SetUserPositionsCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
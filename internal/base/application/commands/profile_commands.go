@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/validator"
+)
+
+// UpdateProfileCommand 修改个人基本信息
+type UpdateProfileCommand struct {
+	Name     string `json:"name" validate:"omitempty,max=50" label:"名称"`     // 名称
+	Nickname string `json:"nickname" validate:"omitempty,max=50" label:"昵称"` // 昵称
+	Remark   string `json:"remark" validate:"omitempty,max=255" label:"备注"`  // 备注
+}
+
+func (c *UpdateProfileCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// ChangePasswordCommand 修改个人密码
+type ChangePasswordCommand struct {
+	OldPassword string `json:"oldPassword" validate:"required" label:"原密码"`              // 原密码
+	NewPassword string `json:"newPassword" validate:"required,min=6,max=32" label:"新密码"` // 新密码
+}
+
+func (c *ChangePasswordCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// SendVerifyCodeCommand 发送邮箱/手机验证码
+type SendVerifyCodeCommand struct {
+	Type   string `json:"type" validate:"required,oneof=email phone" label:"类型"` // 类型 email/phone
+	Target string `json:"target" validate:"required" label:"邮箱或手机号"`             // 新的邮箱或手机号
+}
+
+func (c *SendVerifyCodeCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// ChangeContactCommand 验证后修改邮箱/手机号
+type ChangeContactCommand struct {
+	Type   string `json:"type" validate:"required,oneof=email phone" label:"类型"` // 类型 email/phone
+	Target string `json:"target" validate:"required" label:"邮箱或手机号"`             // 新的邮箱或手机号
+	Code   string `json:"code" validate:"required,len=6" label:"验证码"`            // 验证码
+}
+
+func (c *ChangeContactCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
@@ -35,12 +35,13 @@ func (c *AssignRolePermissionsCommand) Validate() herrors.Herr {
 
 // CreateRoleCommand 创建角色命令
 type CreateRoleCommand struct {
-	Code        string `json:"code" binding:"required"` // 角色编码
-	Name        string `json:"name" binding:"required"` // 角色名称
-	Type        int8   `json:"type" binding:"required"` // 角色类型
-	Localize    string `json:"localize"`                // 多语言标识
-	Description string `json:"description"`             // 描述
-	Sequence    int    `json:"sequence"`                // 排序
+	Code             string `json:"code" binding:"required"` // 角色编码
+	Name             string `json:"name" binding:"required"` // 角色名称
+	Type             int8   `json:"type" binding:"required"` // 角色类型
+	Localize         string `json:"localize"`                // 多语言标识
+	Description      string `json:"description"`             // 描述
+	Sequence         int    `json:"sequence"`                // 排序
+	RequiresApproval bool   `json:"requires_approval"`       // 分配给用户时是否需要审批
 }
 
 // Validate 验证命令
@@ -71,12 +72,13 @@ func (c *CreateRoleCommand) Validate() herrors.Herr {
 
 // UpdateRoleCommand 更新角色命令
 type UpdateRoleCommand struct {
-	ID          int64  `json:"id" binding:"required"`   // 角色ID
-	Name        string `json:"name" binding:"required"` // 角色名称
-	Localize    string `json:"localize"`                // 多语言标识
-	Description string `json:"description"`             // 描述
-	Sequence    int    `json:"sequence"`                // 排序
-	Status      int8   `json:"status"`                  // 状态
+	ID               int64  `json:"id" binding:"required"`   // 角色ID
+	Name             string `json:"name" binding:"required"` // 角色名称
+	Localize         string `json:"localize"`                // 多语言标识
+	Description      string `json:"description"`             // 描述
+	Sequence         int    `json:"sequence"`                // 排序
+	Status           int8   `json:"status"`                  // 状态
+	RequiresApproval *bool  `json:"requires_approval"`       // 分配给用户时是否需要审批, 为空时不修改
 }
 
 // Validate 验证命令
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/validator"
+)
+
+// CreateRoleConstraintCommand 创建职责分离约束
+type CreateRoleConstraintCommand struct {
+	Name        string  `json:"name" validate:"required,max=128" label:"约束名称"`
+	Type        int8    `json:"type" validate:"required,oneof=1 2" label:"约束类型"` // 1:互斥 2:基数
+	RoleIDs     []int64 `json:"roleIds" validate:"required,min=1" label:"角色"`    // 约束的角色集合
+	Limit       int     `json:"limit" validate:"gte=0" label:"上限"`               // 互斥为单个用户最多角色数(默认1), 基数为最多用户数
+	Description string  `json:"description" validate:"max=512" label:"描述"`
+}
+
+func (c *CreateRoleConstraintCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// UpdateRoleConstraintCommand 更新职责分离约束, 约束类型不可修改
+type UpdateRoleConstraintCommand struct {
+	ID          int64   `json:"id" validate:"required,gt=0" label:"约束ID"`
+	Name        string  `json:"name" validate:"required,max=128" label:"约束名称"`
+	RoleIDs     []int64 `json:"roleIds" validate:"required,min=1" label:"角色"`
+	Limit       int     `json:"limit" validate:"gt=0" label:"上限"`
+	Description string  `json:"description" validate:"max=512" label:"描述"`
+}
+
+func (c *UpdateRoleConstraintCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/validator"
+)
+
+// GrantRoleCommand 在有效期内授予用户角色, 需要审批的角色创建角色申请
+type GrantRoleCommand struct {
+	UserID     string `json:"userId" validate:"required" label:"用户ID"`
+	RoleID     int64  `json:"roleId" validate:"required,gt=0" label:"角色ID"`
+	StartTime  int64  `json:"startTime" validate:"gte=0" label:"生效时间"`  // 0 表示立即生效
+	ExpireTime int64  `json:"expireTime" validate:"gte=0" label:"过期时间"` // 0 表示永久有效
+	Reason     string `json:"reason" validate:"max=512" label:"申请理由"`
+}
+
+func (c *GrantRoleCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// RevokeRoleGrantCommand 收回用户角色
+type RevokeRoleGrantCommand struct {
+	UserID string `json:"userId" validate:"required" label:"用户ID"`
+	RoleID int64  `json:"roleId" validate:"required,gt=0" label:"角色ID"`
+}
+
+func (c *RevokeRoleGrantCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// ReviewRoleRequestCommand 审批角色申请
+type ReviewRoleRequestCommand struct {
+	ID      string `json:"id" path:"id" validate:"required" label:"申请ID"`
+	Comment string `json:"comment" validate:"max=512" label:"审批意见"`
+}
+
+func (c *ReviewRoleRequestCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
@@ -0,0 +1,16 @@
+package commands
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/validator"
+)
+
+// CreateScimTokenCommand 创建 SCIM 令牌命令
+type CreateScimTokenCommand struct {
+	Name     string `json:"name" validate:"required,max=64" label:"名称"`
+	ExpireAt int64  `json:"expireAt" validate:"omitempty,gt=0" label:"过期时间"` // 过期时间戳(秒), 为空时不过期
+}
+
+func (c *CreateScimTokenCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
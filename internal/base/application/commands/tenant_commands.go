@@ -7,12 +7,18 @@ import (
 
 // CreateTenantCommand 创建租户命令
 type CreateTenantCommand struct {
-	Code        string            `json:"code" validate:"required" label:"租户编码"`
-	Name        string            `json:"name" validate:"required" label:"租户名称"`
-	Description string            `json:"description" validate:"omitempty,max=200" label:"描述"`
-	IsDefault   int8              `json:"isDefault" validate:"oneof=0 1" label:"是否默认租户"`
-	ExpireTime  int64             `json:"expireTime" validate:"omitempty" label:"过期时间"`
-	AdminUser   CreateUserCommand `json:"adminUser" validate:"required" label:"管理员信息"`
+	Code        string `json:"code" validate:"required" label:"租户编码"`
+	Name        string `json:"name" validate:"required" label:"租户名称"`
+	Domain      string `json:"domain" validate:"omitempty,max=255" label:"租户域名"`
+	Description string `json:"description" validate:"omitempty,max=200" label:"描述"`
+	IsDefault   int8   `json:"isDefault" validate:"oneof=0 1" label:"是否默认租户"`
+	ExpireTime  int64  `json:"expireTime" validate:"omitempty" label:"过期时间"`
+	// 数据隔离模式, 默认共享表
+	IsolationMode string            `json:"isolationMode" validate:"omitempty,oneof=shared schema database" label:"数据隔离模式"`
+	DbSource      string            `json:"dbSource" validate:"required_if=IsolationMode database" label:"独立数据库连接串"`
+	AdminUser     CreateUserCommand `json:"adminUser" validate:"required" label:"管理员信息"`
+	// 开通模板, 创建时按模板初始化部门、角色、字典等数据
+	TemplateID string `json:"templateId" validate:"omitempty" label:"开通模板ID"`
 }
 
 func (c *CreateTenantCommand) Validate() herrors.Herr {
@@ -23,6 +29,7 @@ func (c *CreateTenantCommand) Validate() herrors.Herr {
 type UpdateTenantCommand struct {
 	ID          string `json:"id" validate:"required" label:"租户ID"`
 	Name        string `json:"name" validate:"omitempty" label:"租户名称"`
+	Domain      string `json:"domain" validate:"omitempty,max=255" label:"租户域名"`
 	Description string `json:"description" validate:"omitempty,max=200" label:"描述"`
 	IsDefault   int8   `json:"isDefault" validate:"omitempty,oneof=0 1" label:"是否默认租户"`
 	ExpireTime  int64  `json:"expireTime" validate:"omitempty" label:"过期时间"`
@@ -50,3 +57,49 @@ type AssignTenantPermissionsCommand struct {
 func (c *AssignTenantPermissionsCommand) Validate() herrors.Herr {
 	return validator.Validate(c)
 }
+
+// SubmitTenantJobCommand 提交租户导出/清除任务命令
+type SubmitTenantJobCommand struct {
+	TenantID string `json:"tenantId" validate:"required" label:"租户ID"`
+}
+
+func (c *SubmitTenantJobCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// CreateTenantTemplateCommand 创建租户开通模板命令
+type CreateTenantTemplateCommand struct {
+	Name        string `json:"name" validate:"required,max=64" label:"模板名称"`
+	Description string `json:"description" validate:"omitempty,max=512" label:"描述"`
+	Format      string `json:"format" validate:"omitempty,oneof=yaml json" label:"模板格式"`
+	Content     string `json:"content" validate:"required" label:"模板内容"`
+}
+
+func (c *CreateTenantTemplateCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// UpdateTenantTemplateCommand 更新租户开通模板命令
+type UpdateTenantTemplateCommand struct {
+	ID          string `json:"id" validate:"required" label:"模板ID"`
+	Name        string `json:"name" validate:"required,max=64" label:"模板名称"`
+	Description string `json:"description" validate:"omitempty,max=512" label:"描述"`
+	Format      string `json:"format" validate:"omitempty,oneof=yaml json" label:"模板格式"`
+	Content     string `json:"content" validate:"required" label:"模板内容"`
+}
+
+func (c *UpdateTenantTemplateCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// CloneTenantTemplateCommand 从已有租户生成开通模板命令
+type CloneTenantTemplateCommand struct {
+	TenantID    string `json:"tenantId" validate:"required" label:"来源租户ID"`
+	Name        string `json:"name" validate:"required,max=64" label:"模板名称"`
+	Description string `json:"description" validate:"omitempty,max=512" label:"描述"`
+	Format      string `json:"format" validate:"omitempty,oneof=yaml json" label:"模板格式"`
+}
+
+func (c *CloneTenantTemplateCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
@@ -64,3 +64,48 @@ type AssignUserRoleCommand struct {
 func (a *AssignUserRoleCommand) Validate() herrors.Herr {
 	return validator.Validate(a)
 }
+
+// AddTenantMemberCommand 邀请其他租户的用户加入当前租户
+type AddTenantMemberCommand struct {
+	TenantCode string `json:"tenantCode" validate:"required" label:"用户归属租户编码"`
+	Username   string `json:"username" validate:"required" label:"用户名"`
+}
+
+func (c *AddTenantMemberCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// ImportUsersCommand 批量导入用户命令
+type ImportUsersCommand struct {
+	Format  string `json:"format" validate:"required,oneof=csv xlsx" label:"文件格式"`
+	DryRun  bool   `json:"dryRun" label:"仅校验"`
+	Content []byte `json:"-" validate:"required" label:"文件内容"`
+}
+
+func (c *ImportUsersCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// InviteUserCommand 邀请用户命令
+type InviteUserCommand struct {
+	Username string  `json:"username" validate:"required" label:"用户名"`
+	Email    string  `json:"email" validate:"required,email" label:"邮箱"`
+	Name     string  `json:"name" label:"名称"`
+	Nickname string  `json:"nickname" label:"昵称"`
+	RoleIDs  []int64 `json:"roleIds" validate:"omitempty,dive,gt=0" label:"角色"`
+	DeptID   string  `json:"deptId" label:"部门"`
+}
+
+func (c *InviteUserCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
+
+// AcceptInvitationCommand 接受邀请命令
+type AcceptInvitationCommand struct {
+	Token    string `json:"token" validate:"required" label:"邀请令牌"`
+	Password string `json:"password" validate:"required,min=6" label:"密码"`
+}
+
+func (c *AcceptInvitationCommand) Validate() herrors.Herr {
+	return validator.Validate(c)
+}
@@ -1,15 +1,22 @@
 package dto
 
-import "github.com/ares-cloud/ares-ddd-admin/pkg/token"
+import (
+	idto "github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/token"
+)
 
 type Token struct {
 }
 
 type AuthDto struct {
-	AccessToken           string `json:"access_token"`
-	ExpiresIn             int64  `json:"expires_in"`
-	RefreshToken          string `json:"refresh_token"`
-	RefreshTokenExpiresIn int64  `json:"refresh_token_expires_in"`
+	AccessToken           string                `json:"access_token"`
+	ExpiresIn             int64                 `json:"expires_in"`
+	RefreshToken          string                `json:"refresh_token"`
+	RefreshTokenExpiresIn int64                 `json:"refresh_token_expires_in"`
+	TenantId              string                `json:"tenant_id"`                        // 当前租户ID
+	Tenants               []*idto.UserTenantDto `json:"tenants"`                          // 可切换的租户列表
+	ImpersonationId       string                `json:"impersonation_id,omitempty"`       // 模拟登录会话ID, 结束模拟时使用
+	ImpersonateExpiresAt  int64                 `json:"impersonate_expires_at,omitempty"` // 模拟登录结束时间
 }
 
 func ToAuthDto(t *token.Token) *AuthDto {
@@ -0,0 +1,15 @@
+package dto
+
+// InvitationDto 邀请链接
+type InvitationDto struct {
+	UserID string `json:"userId"` // 被邀请用户ID
+	Link   string `json:"link"`   // 邀请链接, 未配置接受邀请页面地址时为邀请令牌
+}
+
+// InvitationPreviewDto 接受邀请页面展示的被邀请人信息
+type InvitationPreviewDto struct {
+	Username string `json:"username"` // 用户名
+	Name     string `json:"name"`     // 姓名
+	Email    string `json:"email"`    // 邮箱
+	ExpireAt int64  `json:"expireAt"` // 邀请过期时间
+}
@@ -0,0 +1,131 @@
+package dto
+
+import "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+
+// PermissionSeedFileDto 权限种子文件
+type PermissionSeedFileDto struct {
+	Permissions []*PermissionSeedDto `json:"permissions" yaml:"permissions"`
+}
+
+// PermissionSeedDto 种子中的权限, 以编码标识, 层级由 children 嵌套表示
+type PermissionSeedDto struct {
+	Code        string                       `json:"code" yaml:"code"`
+	Name        string                       `json:"name" yaml:"name"`
+	Type        int8                         `json:"type" yaml:"type"` // 1页面 2按钮 3接口
+	Localize    string                       `json:"localize,omitempty" yaml:"localize,omitempty"`
+	Icon        string                       `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Description string                       `json:"description,omitempty" yaml:"description,omitempty"`
+	Sequence    int                          `json:"sequence,omitempty" yaml:"sequence,omitempty"`
+	Path        string                       `json:"path,omitempty" yaml:"path,omitempty"`
+	Properties  string                       `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Status      *int8                        `json:"status,omitempty" yaml:"status,omitempty"` // 缺省为启用
+	Resources   []*PermissionSeedResourceDto `json:"resources,omitempty" yaml:"resources,omitempty"`
+	Children    []*PermissionSeedDto         `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// PermissionSeedResourceDto 种子中的接口资源
+type PermissionSeedResourceDto struct {
+	Method    string `json:"method" yaml:"method"`
+	Path      string `json:"path" yaml:"path"`
+	Condition string `json:"condition,omitempty" yaml:"condition,omitempty"` // 条件表达式
+}
+
+// PermissionSeedDiffDto 种子导入结果
+type PermissionSeedDiffDto struct {
+	DryRun  bool                       `json:"dryRun"`  // 仅比较, 未写入
+	Pruned  bool                       `json:"pruned"`  // 是否删除了种子中没有的权限
+	Added   []*PermissionSeedChangeDto `json:"added"`   // 新增
+	Changed []*PermissionSeedChangeDto `json:"changed"` // 修改
+	Removed []*PermissionSeedChangeDto `json:"removed"` // 种子中没有的权限, 仅 pruned 时删除
+}
+
+// PermissionSeedChangeDto 单个权限的差异
+type PermissionSeedChangeDto struct {
+	Code       string   `json:"code"`
+	Name       string   `json:"name"`
+	ParentCode string   `json:"parentCode,omitempty"`
+	Fields     []string `json:"fields,omitempty"` // 变化的字段
+}
+
+// ToPermissionSeeds 权限树转为种子
+func ToPermissionSeeds(perms []*model.Permissions) []*PermissionSeedDto {
+	result := make([]*PermissionSeedDto, 0, len(perms))
+	for _, p := range perms {
+		status := p.Status
+		seed := &PermissionSeedDto{
+			Code:        p.Code,
+			Name:        p.Name,
+			Type:        p.Type,
+			Localize:    p.Localize,
+			Icon:        p.Icon,
+			Description: p.Description,
+			Sequence:    p.Sequence,
+			Path:        p.Path,
+			Properties:  p.Properties,
+			Children:    ToPermissionSeeds(p.Children),
+		}
+		if !p.IsEnabled() {
+			seed.Status = &status
+		}
+		for _, r := range p.Resources {
+			seed.Resources = append(seed.Resources, &PermissionSeedResourceDto{Method: r.Method, Path: r.Path, Condition: r.Condition})
+		}
+		if len(seed.Children) == 0 {
+			seed.Children = nil
+		}
+		result = append(result, seed)
+	}
+	return result
+}
+
+// ToPermissionModels 种子转为权限树
+func ToPermissionModels(seeds []*PermissionSeedDto) []*model.Permissions {
+	result := make([]*model.Permissions, 0, len(seeds))
+	for _, s := range seeds {
+		p := model.NewPermissions(s.Code, s.Name, s.Type, s.Sequence)
+		p.Localize = s.Localize
+		p.Icon = s.Icon
+		p.Description = s.Description
+		p.Path = s.Path
+		p.Properties = s.Properties
+		if s.Status != nil {
+			p.Status = *s.Status
+		}
+		for _, r := range s.Resources {
+			p.Resources = append(p.Resources, &model.PermissionsResource{Method: r.Method, Path: r.Path, Condition: r.Condition})
+		}
+		p.Children = ToPermissionModels(s.Children)
+		result = append(result, p)
+	}
+	return result
+}
+
+// ToPermissionSeedDiffDto 差异转为DTO
+func ToPermissionSeedDiffDto(diff *model.PermissionSeedDiff, dryRun, prune bool) *PermissionSeedDiffDto {
+	return &PermissionSeedDiffDto{
+		DryRun:  dryRun,
+		Pruned:  prune && !dryRun,
+		Added:   toPermissionSeedChanges(diff.Added),
+		Changed: toPermissionSeedChanges(diff.Changed),
+		Removed: toPermissionSeedChanges(diff.Removed),
+	}
+}
+
+func toPermissionSeedChanges(changes []*model.PermissionSeedChange) []*PermissionSeedChangeDto {
+	result := make([]*PermissionSeedChangeDto, 0, len(changes))
+	for _, c := range changes {
+		name := ""
+		if c.Target != nil {
+			name = c.Target.Name
+		} else if c.Current != nil {
+			name = c.Current.Name
+		}
+		result = append(result, &PermissionSeedChangeDto{
+			Code:       c.Code,
+			Name:       name,
+			ParentCode: c.ParentCode,
+			Fields:     c.Fields,
+		})
+	}
+	return result
+}
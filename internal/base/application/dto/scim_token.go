@@ -0,0 +1,11 @@
+package dto
+
+// ScimTokenDto SCIM 令牌
+type ScimTokenDto struct {
+	ID         string `json:"id"`              // 令牌ID
+	Name       string `json:"name"`            // 名称
+	Token      string `json:"token,omitempty"` // 令牌明文, 仅创建时返回
+	ExpireAt   int64  `json:"expireAt"`        // 过期时间, 0 为不过期
+	LastUsedAt int64  `json:"lastUsedAt"`      // 最近使用时间
+	CreatedAt  int64  `json:"createdAt"`       // 创建时间
+}
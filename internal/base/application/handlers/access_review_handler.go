@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
+	"github.com/ares-cloud/ares-ddd-admin/internal/infrastructure/configs"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/models"
+)
+
+// AccessReviewHandler 权限复核处理器
+type AccessReviewHandler struct {
+	reviewService *service.AccessReviewService
+	query         query.IAccessReviewQuery
+	secret        string
+}
+
+func NewAccessReviewHandler(conf *configs.Bootstrap, reviewService *service.AccessReviewService, query query.IAccessReviewQuery) *AccessReviewHandler {
+	h := &AccessReviewHandler{
+		reviewService: reviewService,
+		query:         query,
+	}
+	// 与租户导出报告使用同一签名密钥
+	if conf.JWT != nil {
+		h.secret = conf.JWT.SigningKey
+	}
+	if conf.Tenant != nil && conf.Tenant.ReportSecret != "" {
+		h.secret = conf.Tenant.ReportSecret
+	}
+	return h
+}
+
+// HandleStart 发起复核活动, 发起人为当前用户
+func (h *AccessReviewHandler) HandleStart(ctx context.Context, cmd *commands.StartAccessReviewCommand) (*dto.AccessReviewStartedDto, herrors.Herr) {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", hr)
+		return nil, hr
+	}
+	campaign, hr := model.NewAccessReviewCampaign(actx.GetTenantId(ctx), cmd.Name, cmd.ScopeType, cmd.DeptID, cmd.RoleIDs, cmd.DueAt, actx.GetUserId(ctx))
+	if herrors.HaveError(hr) {
+		return nil, hr
+	}
+	count, hr := h.reviewService.Start(ctx, campaign)
+	if herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to start access review: %s", hr)
+		return nil, hr
+	}
+	return &dto.AccessReviewStartedDto{ID: campaign.ID, Items: count}, nil
+}
+
+// HandleDecide 当前用户作为复核人记录复核结果
+func (h *AccessReviewHandler) HandleDecide(ctx context.Context, cmd *commands.DecideAccessReviewItemCommand) herrors.Herr {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", hr)
+		return hr
+	}
+	if hr := h.reviewService.Decide(ctx, cmd.ID, actx.GetUserId(ctx), cmd.Decision, cmd.Comment); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to decide access review item: %s", hr)
+		return hr
+	}
+	return nil
+}
+
+// HandleClose 关闭复核活动并收回决定收回的授权
+func (h *AccessReviewHandler) HandleClose(ctx context.Context, id string) (*dto.AccessReviewSummaryDto, herrors.Herr) {
+	summary, hr := h.reviewService.Close(ctx, id, actx.GetUserId(ctx))
+	if herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to close access review: %s", hr)
+		return nil, hr
+	}
+	return toAccessReviewSummaryDto(summary), nil
+}
+
+// HandleList 分页查询复核活动
+func (h *AccessReviewHandler) HandleList(ctx context.Context, q *queries.ListAccessReviewsQuery) (*models.PageRes[dto.AccessReviewDto], herrors.Herr) {
+	qb := db_query.NewQueryBuilder()
+	if q.Name != "" {
+		qb.Where("name", db_query.Like, "%"+q.Name+"%")
+	}
+	if q.Status > 0 {
+		qb.Where("status", db_query.Eq, q.Status)
+	}
+	qb.OrderBy("created_at", false)
+	qb.WithPage(&q.Page)
+
+	total, err := h.query.Count(ctx, qb)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	list, err := h.query.Find(ctx, qb)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	return &models.PageRes[dto.AccessReviewDto]{
+		List:  list,
+		Total: total,
+	}, nil
+}
+
+// HandleListItems 复核活动的全部复核项
+func (h *AccessReviewHandler) HandleListItems(ctx context.Context, id string) ([]*dto.AccessReviewItemDto, herrors.Herr) {
+	if _, hr := h.find(ctx, id); herrors.HaveError(hr) {
+		return nil, hr
+	}
+	items, err := h.query.FindItems(ctx, id)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	return items, nil
+}
+
+// HandleListMyItems 进行中的复核活动里分配给当前用户的复核项
+func (h *AccessReviewHandler) HandleListMyItems(ctx context.Context) ([]*dto.AccessReviewItemDto, herrors.Herr) {
+	items, err := h.query.FindOpenItemsByReviewer(ctx, actx.GetUserId(ctx))
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	return items, nil
+}
+
+// HandleExportReport 导出签名的复核报告
+func (h *AccessReviewHandler) HandleExportReport(ctx context.Context, id string) (*dto.AccessReviewReportDto, herrors.Herr) {
+	campaign, hr := h.find(ctx, id)
+	if herrors.HaveError(hr) {
+		return nil, hr
+	}
+	items, err := h.query.FindItems(ctx, id)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	summary := &dto.AccessReviewSummaryDto{Total: len(items)}
+	for _, item := range items {
+		switch item.Decision {
+		case model.AccessReviewKeep:
+			summary.Keep++
+		case model.AccessReviewRevoke:
+			summary.Revoke++
+		default:
+			summary.Pending++
+		}
+	}
+	report := &dto.AccessReviewReportDto{
+		Campaign:    campaign,
+		Summary:     summary,
+		Items:       items,
+		GeneratedAt: time.Now().Unix(),
+	}
+	if err := h.sign(report); err != nil {
+		hlog.CtxErrorf(ctx, "failed to sign access review report: %s", err)
+		return nil, herrors.NewServerHError(err)
+	}
+	return report, nil
+}
+
+func (h *AccessReviewHandler) find(ctx context.Context, id string) (*dto.AccessReviewDto, herrors.Herr) {
+	campaign, err := h.query.GetByID(ctx, id)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	if campaign == nil {
+		return nil, errors.AccessReviewNotFound(id)
+	}
+	return campaign, nil
+}
+
+// sign 对去掉签名字段的报告 JSON 做 HMAC-SHA256 签名
+func (h *AccessReviewHandler) sign(report *dto.AccessReviewReportDto) error {
+	if h.secret == "" {
+		return fmt.Errorf("report secret is not configured")
+	}
+	report.Signature = ""
+	payload, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, []byte(h.secret))
+	mac.Write(payload)
+	report.Signature = hex.EncodeToString(mac.Sum(nil))
+	return nil
+}
+
+func toAccessReviewSummaryDto(s *model.AccessReviewSummary) *dto.AccessReviewSummaryDto {
+	return &dto.AccessReviewSummaryDto{
+		Total:   s.Total,
+		Pending: s.Pending,
+		Keep:    s.Keep,
+		Revoke:  s.Revoke,
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
 	"github.com/ares-cloud/ares-ddd-admin/internal/infrastructure/configs"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/constant"
@@ -15,25 +16,30 @@ import (
 	"github.com/cloudwego/hertz/pkg/common/hlog"
 	"time"
 
+	derrors "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	idto "github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
 	iQuery "github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/captcha"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/plugin"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/token"
 )
 
 type AuthHandler struct {
-	conf     *configs.Bootstrap
-	authRepo repository.IAuthRepository
-	uds      iQuery.IUserQueryService
-	llr      repository.ILoginLogRepository
+	conf          *configs.Bootstrap
+	authRepo      repository.IAuthRepository
+	uds           iQuery.IUserQueryService
+	llr           repository.ILoginLogRepository
+	impersonation *service.ImpersonationService
 }
 
-func NewAuthHandler(conf *configs.Bootstrap, authRepo repository.IAuthRepository, uds iQuery.IUserQueryService, llr repository.ILoginLogRepository) *AuthHandler {
+func NewAuthHandler(conf *configs.Bootstrap, authRepo repository.IAuthRepository, uds iQuery.IUserQueryService, llr repository.ILoginLogRepository, impersonation *service.ImpersonationService) *AuthHandler {
 	return &AuthHandler{
-		conf:     conf,
-		authRepo: authRepo,
-		uds:      uds,
-		llr:      llr,
+		conf:          conf,
+		authRepo:      authRepo,
+		uds:           uds,
+		llr:           llr,
+		impersonation: impersonation,
 	}
 }
 
@@ -84,17 +90,28 @@ func (h *AuthHandler) HandleLogin(ctx context.Context, cmd commands.LoginCommand
 	if err1 := auth.Login(cmd.Password, valid); herrors.HaveError(err1) {
 		return nil, err1
 	}
-	ctx = actx.WithTenantId(ctx, auth.User.TenantID)
+	// 按请求解析出的租户登录, 未解析时登录到用户归属租户
+	tenantID := plugin.GetCtxTenantID(ctx)
+	if tenantID == "" {
+		tenantID = auth.User.TenantID
+	}
+	ctx = actx.WithTenantId(ctx, tenantID)
 	roles, e := h.uds.GetUserRolesCode(ctx, auth.User.ID)
 	if e != nil {
 		hlog.CtxErrorf(ctx, "get user roles failed: %v", e)
 		return nil, herrors.QueryFail(e)
 	}
+	deptID, e := h.primaryDeptID(ctx, auth.User.ID)
+	if e != nil {
+		hlog.CtxErrorf(ctx, "get user departments failed: %v", e)
+		return nil, herrors.QueryFail(e)
+	}
 
 	// 生成token
 	tokenData, err := tk.GenerateToken(auth.User.ID, &token.AccessToken{
 		UserId:   auth.User.ID,
-		TenantId: auth.User.TenantID,
+		TenantId: tenantID,
+		DeptId:   deptID,
 		Roles:    roles,
 		Platform: cmd.Platform,
 		UserName: auth.User.Username,
@@ -104,7 +121,66 @@ func (h *AuthHandler) HandleLogin(ctx context.Context, cmd commands.LoginCommand
 	}
 	// 记录登录失败日志
 	go h.recordLoginLog(ctx, auth.User, cmd, nil)
-	return dto.ToAuthDto(tokenData), nil
+	res := dto.ToAuthDto(tokenData)
+	res.TenantId = tenantID
+	res.Tenants, e = h.uds.GetUserTenants(ctx, auth.User.ID)
+	if e != nil {
+		hlog.CtxErrorf(ctx, "get user tenants failed: %v", e)
+	}
+	return res, nil
+}
+
+// HandleSwitchTenant 切换到用户所属的其他租户, 重新签发token
+func (h *AuthHandler) HandleSwitchTenant(ctx context.Context, cmd commands.SwitchTenantCommand, tk token.IToken) (*dto.AuthDto, herrors.Herr) {
+	userID := actx.GetUserId(ctx)
+	tenants, err := h.uds.GetUserTenants(ctx, userID)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "get user tenants failed: %v", err)
+		return nil, herrors.QueryFail(err)
+	}
+	var target *idto.UserTenantDto
+	for _, t := range tenants {
+		if t.TenantID == cmd.TenantId {
+			target = t
+			break
+		}
+	}
+	if target == nil {
+		return nil, derrors.UserNotMember(userID, cmd.TenantId)
+	}
+	if target.Status == model.StatusDisabled {
+		return nil, derrors.TenantDisabled(target.Name)
+	}
+	if target.ExpireTime > 0 && time.Now().Unix() > target.ExpireTime {
+		return nil, derrors.TenantExpired()
+	}
+
+	tctx := actx.BuildTenantCtx(ctx, target.TenantID)
+	roles, err := h.uds.GetUserRolesCode(tctx, userID)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "get user roles failed: %v", err)
+		return nil, herrors.QueryFail(err)
+	}
+	deptID, err := h.primaryDeptID(tctx, userID)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "get user departments failed: %v", err)
+		return nil, herrors.QueryFail(err)
+	}
+	tokenData, err := tk.GenerateToken(userID, &token.AccessToken{
+		UserId:   userID,
+		TenantId: target.TenantID,
+		DeptId:   deptID,
+		Roles:    roles,
+		Platform: actx.GetPlatform(ctx),
+		UserName: actx.GetUsername(ctx),
+	})
+	if err != nil {
+		return nil, herrors.NewErr(err)
+	}
+	res := dto.ToAuthDto(tokenData)
+	res.TenantId = target.TenantID
+	res.Tenants = tenants
+	return res, nil
 }
 
 // recordLoginLog 记录登录日志
@@ -143,12 +219,25 @@ func (h *AuthHandler) HandleRefreshToken(ctx context.Context, cmd commands.Refre
 	if err != nil {
 		return nil, herrors.NewErr(err)
 	}
+	// 模拟登录令牌不能续期, 到期后需要重新发起
+	if accessToken.IsImpersonated() {
+		return nil, derrors.ImpersonationNotAllowed("impersonation token cannot be refreshed")
+	}
+	// 重新获取角色, 过期的限时角色不会随刷新延续
+	tctx := actx.BuildTenantCtx(ctx, accessToken.TenantId)
+	roles, err := h.uds.GetUserRolesCode(tctx, accessToken.UserId)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "get user roles failed: %v", err)
+		return nil, herrors.QueryFail(err)
+	}
 	// 生成新token
 	tokenData, err := tk.GenerateToken(accessToken.UserId, &token.AccessToken{
 		UserId:   accessToken.UserId,
 		TenantId: accessToken.TenantId,
-		Roles:    accessToken.Roles,
+		DeptId:   accessToken.DeptId,
+		Roles:    roles,
 		Platform: accessToken.Platform,
+		UserName: accessToken.UserName,
 	})
 	if err != nil {
 		return nil, herrors.NewErr(err)
@@ -157,6 +246,88 @@ func (h *AuthHandler) HandleRefreshToken(ctx context.Context, cmd commands.Refre
 	return dto.ToAuthDto(tokenData), nil
 }
 
+// HandleImpersonate 以目标用户身份签发模拟登录令牌
+// 令牌以会话ID为键存储, 不会挤掉目标用户自己的登录; 令牌不返回刷新令牌, 到会话结束时间失效
+func (h *AuthHandler) HandleImpersonate(ctx context.Context, cmd commands.ImpersonateUserCommand, tk token.IToken) (*dto.AuthDto, herrors.Herr) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+	session, hr := h.impersonation.Start(ctx, cmd.UserID, cmd.Reason, cmd.Minutes)
+	if hr != nil {
+		return nil, hr
+	}
+
+	tctx := actx.BuildTenantCtx(ctx, session.TenantID)
+	roles, err := h.uds.GetUserRolesCode(tctx, session.UserID)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "get user roles failed: %v", err)
+		return nil, herrors.QueryFail(err)
+	}
+	deptID, err := h.primaryDeptID(tctx, session.UserID)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "get user departments failed: %v", err)
+		return nil, herrors.QueryFail(err)
+	}
+	tokenData, err := tk.GenerateToken(session.ID, &token.AccessToken{
+		UserId:               session.UserID,
+		TenantId:             session.TenantID,
+		DeptId:               deptID,
+		Roles:                roles,
+		Platform:             actx.GetPlatform(ctx),
+		UserName:             session.Username,
+		ActorId:              session.ActorID,
+		ActorName:            session.ActorName,
+		ImpersonationId:      session.ID,
+		ImpersonateExpiresAt: session.ExpiresAt,
+	})
+	if err != nil {
+		return nil, herrors.NewErr(err)
+	}
+	res := dto.ToAuthDto(tokenData)
+	res.RefreshToken = ""
+	res.RefreshTokenExpiresIn = 0
+	if remain := session.ExpiresAt - time.Now().Unix(); remain < res.ExpiresIn {
+		res.ExpiresIn = remain
+	}
+	res.TenantId = session.TenantID
+	res.ImpersonationId = session.ID
+	res.ImpersonateExpiresAt = session.ExpiresAt
+	return res, nil
+}
+
+// HandleStopImpersonation 结束模拟登录并注销模拟令牌
+// 可以用模拟令牌调用, 也可以由管理员用自己的令牌调用
+func (h *AuthHandler) HandleStopImpersonation(ctx context.Context, cmd commands.StopImpersonationCommand, tk token.IToken) herrors.Herr {
+	if err := cmd.Validate(); err != nil {
+		return err
+	}
+	actorID := actx.GetActorId(ctx)
+	if actorID == "" {
+		actorID = actx.GetUserId(ctx)
+	}
+	if hr := h.impersonation.End(ctx, cmd.ID, actorID); hr != nil {
+		return hr
+	}
+	if err := tk.DelUserToken(cmd.ID); err != nil {
+		hlog.CtxErrorf(ctx, "delete impersonation token failed: %v", err)
+	}
+	return nil
+}
+
+// primaryDeptID 获取用户在当前租户的主部门, 未分配部门时返回空
+func (h *AuthHandler) primaryDeptID(ctx context.Context, userID string) (string, error) {
+	depts, err := h.uds.GetUserDepartments(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	for _, dept := range depts {
+		if dept.IsPrimary {
+			return dept.ID, nil
+		}
+	}
+	return "", nil
+}
+
 // HandleGetCaptcha 处理获取验证码请求
 func (h *AuthHandler) HandleGetCaptcha(ctx context.Context, query queries.GetCaptchaQuery) (*dto.CaptchaDto, herrors.Herr) {
 	// 生成验证码
@@ -24,10 +24,11 @@ func NewDataPermissionCommandHandler(permService *service.DataPermissionService)
 func (h *DataPermissionCommandHandler) HandleAssign(ctx context.Context, cmd *commands.AssignDataPermissionCommand) herrors.Herr {
 	// 1. 构建数据权限领域模型
 	perm := &model.DataPermission{
-		RoleID:   cmd.RoleID,
-		Scope:    model.DataScope(cmd.Scope),
-		DeptIDs:  cmd.DeptIDs,
-		TenantID: actx.GetTenantId(ctx),
+		RoleID:      cmd.RoleID,
+		Scope:       model.DataScope(cmd.Scope),
+		DeptIDs:     cmd.DeptIDs,
+		PositionIDs: cmd.PositionIDs,
+		TenantID:    actx.GetTenantId(ctx),
 	}
 
 	// 2. 调用领域服务分配数据权限
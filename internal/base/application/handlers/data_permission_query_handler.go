@@ -5,17 +5,22 @@ import (
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
 )
 
 type DataPermissionQueryHandler struct {
-	permQuery query.IDataPermissionQuery
+	permQuery   query.IDataPermissionQuery
+	permService *service.DataPermissionService
 }
 
-func NewDataPermissionQueryHandler(permQuery query.IDataPermissionQuery) *DataPermissionQueryHandler {
+func NewDataPermissionQueryHandler(permQuery query.IDataPermissionQuery, permService *service.DataPermissionService) *DataPermissionQueryHandler {
 	return &DataPermissionQueryHandler{
-		permQuery: permQuery,
+		permQuery:   permQuery,
+		permService: permService,
 	}
 }
 
@@ -23,3 +28,11 @@ func NewDataPermissionQueryHandler(permQuery query.IDataPermissionQuery) *DataPe
 func (h *DataPermissionQueryHandler) HandleGetByRoleID(ctx context.Context, query queries.GetDataPermissionQuery) (*dto.DataPermissionDto, herrors.Herr) {
 	return h.permQuery.GetByRoleID(ctx, query.RoleID)
 }
+
+// HandleGetCurrentScope 获取当前用户生效的数据范围
+func (h *DataPermissionQueryHandler) HandleGetCurrentScope(ctx context.Context) (*model.UserDataScope, herrors.Herr) {
+	if actx.IsSuperAdmin(ctx) {
+		return &model.UserDataScope{All: true, DeptIDs: []string{}, PositionIDs: []string{}}, nil
+	}
+	return h.permService.ResolveUserDataScope(ctx, actx.GetUserId(ctx))
+}
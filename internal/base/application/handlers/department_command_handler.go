@@ -111,6 +111,19 @@ func (h *DepartmentCommandHandler) HandleMove(ctx context.Context, cmd *commands
 	return nil
 }
 
+// HandleMerge 处理合并部门命令
+func (h *DepartmentCommandHandler) HandleMerge(ctx context.Context, cmd *commands.MergeDepartmentCommand) herrors.Herr {
+	if validate := cmd.Validate(); herrors.HaveError(validate) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", validate)
+		return validate
+	}
+	if hr := h.deptService.MergeDepartment(ctx, cmd.SourceID, cmd.TargetID); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to merge department: %s", hr)
+		return hr
+	}
+	return nil
+}
+
 // HandleSetAdmin 处理设置部门管理员
 func (h *DepartmentCommandHandler) HandleSetAdmin(ctx context.Context, cmd *commands.SetDepartmentAdminCommand) herrors.Herr {
 	if hr := h.deptService.SetDepartmentAdmin(ctx, cmd.DeptID, cmd.AdminID); herrors.HaveError(hr) {
@@ -158,3 +171,18 @@ func (h *DepartmentCommandHandler) HandleTransferUser(ctx context.Context, cmd *
 
 	return nil
 }
+
+// HandleSetPrimary 处理设置用户主部门
+func (h *DepartmentCommandHandler) HandleSetPrimary(ctx context.Context, cmd *commands.SetPrimaryDepartmentCommand) herrors.Herr {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", hr)
+		return hr
+	}
+
+	if hr := h.deptService.SetPrimaryDepartment(ctx, cmd.UserID, cmd.DeptID); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to set primary department: %s", hr)
+		return hr
+	}
+
+	return nil
+}
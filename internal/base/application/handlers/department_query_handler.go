@@ -2,8 +2,14 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/sheet"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
 
@@ -110,6 +116,38 @@ func (h *DepartmentQueryHandler) HandleGetTree(ctx context.Context, query *queri
 	return tree, nil
 }
 
+// HandleGetAncestors 处理获取上级部门查询
+func (h *DepartmentQueryHandler) HandleGetAncestors(ctx context.Context, query *queries.GetDepartmentQuery) ([]*dto.DepartmentDto, herrors.Herr) {
+	if validate := query.Validate(); herrors.HaveError(validate) {
+		hlog.CtxErrorf(ctx, "Query validation error: %s", validate)
+		return nil, validate
+	}
+
+	depts, err := h.queryService.GetDepartmentAncestors(ctx, query.ID)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "failed to get department ancestors: %s", err)
+		return nil, herrors.QueryFail(err)
+	}
+
+	return depts, nil
+}
+
+// HandleCountSubtreeUsers 处理统计部门及下级部门用户数
+func (h *DepartmentQueryHandler) HandleCountSubtreeUsers(ctx context.Context, query *queries.GetDepartmentQuery) (int64, herrors.Herr) {
+	if validate := query.Validate(); herrors.HaveError(validate) {
+		hlog.CtxErrorf(ctx, "Query validation error: %s", validate)
+		return 0, validate
+	}
+
+	count, err := h.queryService.CountSubtreeUsers(ctx, query.ID)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "failed to count subtree users: %s", err)
+		return 0, herrors.QueryFail(err)
+	}
+
+	return count, nil
+}
+
 // HandleGetUserDepartments 处理获取用户部门查询
 func (h *DepartmentQueryHandler) HandleGetUserDepartments(ctx context.Context, query *queries.GetUserDepartmentsQuery) ([]*dto.DepartmentDto, herrors.Herr) {
 	if validate := query.Validate(); herrors.HaveError(validate) {
@@ -190,3 +228,95 @@ func (h *DepartmentQueryHandler) HandleGetUnassignedUsers(ctx context.Context, r
 		Total: total,
 	}, nil
 }
+
+// HandleGetOrgChart 处理获取组织架构图
+func (h *DepartmentQueryHandler) HandleGetOrgChart(ctx context.Context, query *queries.ExportOrgChartQuery) ([]*dto.OrgChartNodeDto, herrors.Herr) {
+	if validate := query.Validate(); herrors.HaveError(validate) {
+		hlog.CtxErrorf(ctx, "Query validation error: %s", validate)
+		return nil, validate
+	}
+
+	chart, err := h.queryService.GetOrgChart(ctx, query.RootID)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "failed to get org chart: %s", err)
+		return nil, herrors.QueryFail(err)
+	}
+
+	return chart, nil
+}
+
+// orgChartColumns 组织架构图 CSV 列
+var orgChartColumns = []string{"id", "parent_id", "code", "name", "level", "leader", "admin", "headcount", "total_headcount"}
+
+// WriteOrgChart 按格式输出组织架构图
+func WriteOrgChart(w io.Writer, chart []*dto.OrgChartNodeDto, format string) error {
+	switch format {
+	case queries.OrgChartFormatCSV:
+		sw, err := sheet.NewWriter(w, sheet.FormatCSV)
+		if err != nil {
+			return err
+		}
+		if err := sw.Write(orgChartColumns); err != nil {
+			return err
+		}
+		if err := writeOrgChartRows(sw, chart, 1); err != nil {
+			return err
+		}
+		return sw.Close()
+	case queries.OrgChartFormatDOT:
+		var b strings.Builder
+		b.WriteString("digraph orgchart {\n")
+		b.WriteString("\tnode [shape=box];\n")
+		writeOrgChartDot(&b, chart)
+		b.WriteString("}\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	default:
+		return json.NewEncoder(w).Encode(chart)
+	}
+}
+
+// writeOrgChartRows 按先序遍历逐行写出部门
+func writeOrgChartRows(w sheet.Writer, nodes []*dto.OrgChartNodeDto, level int) error {
+	for _, node := range nodes {
+		row := []string{
+			node.ID,
+			node.ParentID,
+			node.Code,
+			node.Name,
+			strconv.Itoa(level),
+			node.Leader,
+			node.AdminName,
+			strconv.FormatInt(node.Headcount, 10),
+			strconv.FormatInt(node.TotalHeadcount, 10),
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+		if err := writeOrgChartRows(w, node.Children, level+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeOrgChartDot 写出 Graphviz 节点和上下级连线
+func writeOrgChartDot(b *strings.Builder, nodes []*dto.OrgChartNodeDto) {
+	for _, node := range nodes {
+		lines := []string{dotEscape(node.Name)}
+		if node.Leader != "" {
+			lines = append(lines, "负责人: "+dotEscape(node.Leader))
+		}
+		lines = append(lines, fmt.Sprintf("人数: %d/%d", node.Headcount, node.TotalHeadcount))
+		fmt.Fprintf(b, "\t\"%s\" [label=\"%s\"];\n", dotEscape(node.ID), strings.Join(lines, `\n`))
+		for _, child := range node.Children {
+			fmt.Fprintf(b, "\t\"%s\" -> \"%s\";\n", dotEscape(node.ID), dotEscape(child.ID))
+		}
+		writeOrgChartDot(b, node.Children)
+	}
+}
+
+// dotEscape 转义 DOT 双引号字符串中的反斜杠和引号
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"crypto/ed25519"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
+	"github.com/ares-cloud/ares-ddd-admin/internal/infrastructure/configs"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hashchain"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+// LogChainHandler 操作日志和登录日志哈希链校验及检查点导出, 供管理接口和命令行共用
+type LogChainHandler struct {
+	query query.ILogChainQuery
+	conf  *configs.Bootstrap
+}
+
+func NewLogChainHandler(query query.ILogChainQuery, conf *configs.Bootstrap) *LogChainHandler {
+	return &LogChainHandler{
+		query: query,
+		conf:  conf,
+	}
+}
+
+// HandleVerify 校验当前租户的日志链, 超级管理员可以指定租户
+func (h *LogChainHandler) HandleVerify(ctx context.Context, q *queries.VerifyLogChainQuery) (*dto.LogChainVerifyDto, herrors.Herr) {
+	if hr := q.Validate(); herrors.HaveError(hr) {
+		return nil, hr
+	}
+	tenantID := actx.GetTenantId(ctx)
+	if q.TenantID != "" && actx.IsSuperAdmin(ctx) {
+		tenantID = q.TenantID
+	}
+	result, err := h.query.Verify(ctx, q.LogType, tenantID, nil)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "verify log chain error: %v", err)
+		return nil, herrors.NewServerHError(err)
+	}
+	return result, nil
+}
+
+// HandleVerifyAll 校验全部日志链, 检查点中的哈希用于发现整体重算过的链
+// 配置了签名私钥时检查点必须由对应的公钥签名
+func (h *LogChainHandler) HandleVerifyAll(ctx context.Context, checkpoints []*hashchain.Checkpoint) ([]*dto.LogChainVerifyDto, herrors.Herr) {
+	var trusted ed25519.PublicKey
+	if signingKey := h.conf.LogChain.GetSigningKey(); signingKey != "" {
+		key, err := hashchain.ParseSigningKey(signingKey)
+		if err != nil {
+			return nil, herrors.NewBadReqHError(err)
+		}
+		trusted = key.Public().(ed25519.PublicKey)
+	}
+	for _, cp := range checkpoints {
+		if err := cp.Verify(trusted); err != nil {
+			return nil, herrors.NewBadReqHError(err)
+		}
+	}
+	heads, err := h.query.Heads(ctx)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	results := make([]*dto.LogChainVerifyDto, 0, len(heads))
+	for _, head := range heads {
+		result, err := h.query.Verify(ctx, head.LogType, head.TenantID, hashchain.Anchors(checkpoints, head.TenantID, head.LogType))
+		if err != nil {
+			return nil, herrors.NewServerHError(err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// HandleCheckpoint 生成全部链尾的签名检查点
+func (h *LogChainHandler) HandleCheckpoint(ctx context.Context) (*hashchain.Checkpoint, herrors.Herr) {
+	signingKey := h.conf.LogChain.GetSigningKey()
+	if signingKey == "" {
+		return nil, herrors.NewBadReqError("log_chain.signing_key is not configured")
+	}
+	key, err := hashchain.ParseSigningKey(signingKey)
+	if err != nil {
+		return nil, herrors.NewBadReqHError(err)
+	}
+	cp, err := h.query.Checkpoint(ctx, key)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	return cp, nil
+}
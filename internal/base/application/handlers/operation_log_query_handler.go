@@ -15,12 +15,14 @@ import (
 )
 
 type OperationLogQueryHandler struct {
-	query query.IOperationLogQuery
+	query      query.IOperationLogQuery
+	auditQuery query.IAuditLogQuery
 }
 
-func NewOperationLogQueryHandler(query query.IOperationLogQuery) *OperationLogQueryHandler {
+func NewOperationLogQueryHandler(query query.IOperationLogQuery, auditQuery query.IAuditLogQuery) *OperationLogQueryHandler {
 	return &OperationLogQueryHandler{
-		query: query,
+		query:      query,
+		auditQuery: auditQuery,
 	}
 }
 
@@ -53,6 +55,9 @@ func (h *OperationLogQueryHandler) HandleList(ctx context.Context, q *queries.Li
 	if q.EndTime > 0 {
 		qb.Where("login_time", db_query.Lte, time.Unix(q.EndTime, 0))
 	}
+	if q.RequestID != "" {
+		qb.Where("request_id", db_query.Eq, q.RequestID)
+	}
 
 	// 设置排序
 	qb.OrderBy("created_at", false)
@@ -78,3 +83,38 @@ func (h *OperationLogQueryHandler) HandleList(ctx context.Context, q *queries.Li
 		Total: total,
 	}, nil
 }
+
+// HandleListAudit 查询数据变更记录, 按实体查询变更历史或按请求ID查询一次操作的全部变更
+func (h *OperationLogQueryHandler) HandleListAudit(ctx context.Context, q *queries.ListAuditLogQuery) (*models.PageRes[dto.AuditLogDto], herrors.Herr) {
+	if hr := q.Validate(); herrors.HaveError(hr) {
+		return nil, hr
+	}
+	qb := db_query.NewQueryBuilder()
+	if q.EntityType != "" {
+		qb.Where("entity_type", db_query.Eq, q.EntityType)
+	}
+	if q.EntityID != "" {
+		qb.Where("entity_id", db_query.Eq, q.EntityID)
+	}
+	if q.RequestID != "" {
+		qb.Where("request_id", db_query.Eq, q.RequestID)
+	}
+	if q.Action != "" {
+		qb.Where("action", db_query.Eq, q.Action)
+	}
+	qb.OrderBy("id", false)
+	qb.WithPage(&q.Page)
+
+	total, err := h.auditQuery.Count(ctx, qb)
+	if err != nil {
+		return nil, herrors.NewErr(err)
+	}
+	logs, err := h.auditQuery.Find(ctx, qb)
+	if err != nil {
+		return nil, herrors.NewErr(err)
+	}
+	return &models.PageRes[dto.AuditLogDto]{
+		List:  logs,
+		Total: total,
+	}, nil
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/casbin"
+)
+
+const (
+	PermissionSeedYAML = "yaml"
+	PermissionSeedJSON = "json"
+)
+
+// PermissionSeedFormat 按文件扩展名识别种子格式, 不支持时返回空
+func PermissionSeedFormat(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		return PermissionSeedYAML
+	case ".json":
+		return PermissionSeedJSON
+	}
+	return ""
+}
+
+// PermissionSeedHandler 权限种子导入导出, 供管理接口和命令行共用
+type PermissionSeedHandler struct {
+	permService *service.PermissionService
+	ef          *casbin.Enforcer
+}
+
+func NewPermissionSeedHandler(permService *service.PermissionService, ef *casbin.Enforcer) *PermissionSeedHandler {
+	return &PermissionSeedHandler{
+		permService: permService,
+		ef:          ef,
+	}
+}
+
+// HandleExport 导出全部权限为种子文件
+func (h *PermissionSeedHandler) HandleExport(ctx context.Context, format string) ([]byte, herrors.Herr) {
+	perms, hr := h.permService.ExportSeed(ctx)
+	if hr != nil {
+		return nil, hr
+	}
+	file := &dto.PermissionSeedFileDto{Permissions: dto.ToPermissionSeeds(perms)}
+	var (
+		content []byte
+		err     error
+	)
+	if format == PermissionSeedJSON {
+		content, err = json.MarshalIndent(file, "", "  ")
+	} else {
+		content, err = yaml.Marshal(file)
+	}
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	return content, nil
+}
+
+// HandleImport 比较或导入种子文件, 写入后通知各实例重新加载访问策略
+func (h *PermissionSeedHandler) HandleImport(ctx context.Context, cmd *commands.ImportPermissionSeedCommand) (*dto.PermissionSeedDiffDto, herrors.Herr) {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", hr)
+		return nil, hr
+	}
+	var file dto.PermissionSeedFileDto
+	var err error
+	if cmd.Format == PermissionSeedJSON {
+		err = json.Unmarshal(cmd.Content, &file)
+	} else {
+		err = yaml.Unmarshal(cmd.Content, &file)
+	}
+	if err != nil {
+		return nil, herrors.NewBadReqHError(err)
+	}
+	seeds := dto.ToPermissionModels(file.Permissions)
+
+	if cmd.DryRun {
+		diff, hr := h.permService.DiffSeed(ctx, seeds)
+		if hr != nil {
+			return nil, hr
+		}
+		return dto.ToPermissionSeedDiffDto(diff, true, cmd.Prune), nil
+	}
+	diff, hr := h.permService.ApplySeed(ctx, seeds, cmd.Prune)
+	if hr != nil {
+		hlog.CtxErrorf(ctx, "failed to import permission seed: %s", hr)
+		return nil, hr
+	}
+	if diff.NeedApply(cmd.Prune) {
+		if err := h.ef.PublishUpdate(ctx); err != nil {
+			hlog.CtxErrorf(ctx, "publish permission update error: %v", err)
+		}
+	}
+	return dto.ToPermissionSeedDiffDto(diff, false, cmd.Prune), nil
+}
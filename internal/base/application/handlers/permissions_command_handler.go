@@ -37,7 +37,7 @@ func (h *PermissionsCommandHandler) HandleCreate(ctx context.Context, cmd comman
 
 	// 添加资源
 	for _, resource := range cmd.Resources {
-		if err := perm.AddResource(resource.Method, resource.Path); err != nil {
+		if err := perm.AddResource(resource.Method, resource.Path, resource.Condition); err != nil {
 			hlog.CtxErrorf(ctx, "add resource failed: %s", err)
 			return err
 		}
@@ -89,8 +89,9 @@ func (h *PermissionsCommandHandler) HandleUpdate(ctx context.Context, cmd comman
 		resources := make([]*model.PermissionsResource, len(cmd.Resources))
 		for i, r := range cmd.Resources {
 			resources[i] = &model.PermissionsResource{
-				Method: r.Method,
-				Path:   r.Path,
+				Method:    r.Method,
+				Path:      r.Path,
+				Condition: r.Condition,
 			}
 		}
 		if err := perm.UpdateResources(resources); err != nil {
@@ -2,24 +2,37 @@ package handlers
 
 import (
 	"context"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/constant"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/casbin"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/models"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
 )
 
 type PermissionsQueryHandler struct {
 	permQuery query.IPermissionsQuery
+	userQuery query.IUserQueryService
+	ef        *casbin.Enforcer
 }
 
 func NewPermissionsQueryHandler(
 	permQuery query.IPermissionsQuery,
+	userQuery query.IUserQueryService,
+	ef *casbin.Enforcer,
 ) *PermissionsQueryHandler {
 	return &PermissionsQueryHandler{
 		permQuery: permQuery,
+		userQuery: userQuery,
+		ef:        ef,
 	}
 }
 
@@ -81,3 +94,65 @@ func (h *PermissionsQueryHandler) HandleGetAllEnabled(ctx context.Context) ([]*d
 func (h *PermissionsQueryHandler) HandleGetPermissionsTree(ctx context.Context) (*dto.PermissionsTreeResult, herrors.Herr) {
 	return h.permQuery.GetSimplePermissionsTree(ctx)
 }
+
+// HandleExplain 解释用户在当前租户下对接口的访问结果, 列出匹配的策略和条件求值结果
+func (h *PermissionsQueryHandler) HandleExplain(ctx context.Context, q *queries.ExplainPermissionQuery) (*dto.PermissionExplainDto, herrors.Herr) {
+	if hr := q.Validate(); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "Query validation error: %s", hr)
+		return nil, hr
+	}
+	roles, err := h.userQuery.GetUserRolesCode(ctx, q.UserID)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+
+	// 未指定的请求属性取当前请求的值
+	method := strings.ToUpper(q.Method)
+	env := h.ef.BuildEnv(ctx, method, q.Path)
+	if q.At > 0 {
+		env.Time = time.Unix(q.At, 0)
+	}
+	if q.IP != "" {
+		env.IP = q.IP
+	}
+	if q.Platform != "" {
+		env.Platform = q.Platform
+	}
+	if q.Device != "" {
+		env.Device = q.Device
+	}
+
+	tenantID := actx.GetTenantId(ctx)
+	policies, err := h.ef.Explain(roles, tenantID, method, q.Path, env)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	result := &dto.PermissionExplainDto{
+		SuperAdmin: slices.Contains(roles, constant.RoleSuperAdmin),
+		Roles:      roles,
+		Env: &dto.PermissionExplainEnv{
+			Time:     env.Time.Unix(),
+			IP:       env.IP,
+			Tenant:   env.TenantID,
+			Platform: env.Platform,
+			Device:   env.Device,
+			Attrs:    env.Tenant,
+		},
+		Policies: make([]*dto.PolicyExplainDto, 0, len(policies)),
+	}
+	result.Allowed = result.SuperAdmin
+	for _, p := range policies {
+		result.Policies = append(result.Policies, &dto.PolicyExplainDto{
+			Role:      p.Role,
+			Method:    p.Method,
+			Path:      p.Path,
+			Condition: p.Condition,
+			Satisfied: p.Satisfied,
+			Error:     p.Error,
+		})
+		if p.Satisfied {
+			result.Allowed = true
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+type PositionCommandHandler struct {
+	positionService *service.PositionService
+}
+
+func NewPositionCommandHandler(positionService *service.PositionService) *PositionCommandHandler {
+	return &PositionCommandHandler{
+		positionService: positionService,
+	}
+}
+
+// HandleCreate 处理创建岗位命令
+func (h *PositionCommandHandler) HandleCreate(ctx context.Context, cmd *commands.CreatePositionCommand) herrors.Herr {
+	if validate := cmd.Validate(); herrors.HaveError(validate) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", validate)
+		return validate
+	}
+
+	position := model.NewPosition(cmd.Code, cmd.Name, cmd.Sort)
+	position.Status = cmd.Status
+	position.Description = cmd.Description
+	position.TenantID = actx.GetTenantId(ctx)
+
+	if err := h.positionService.CreatePosition(ctx, position); err != nil {
+		hlog.CtxErrorf(ctx, "failed to create position: %s", err)
+		return err
+	}
+	return nil
+}
+
+// HandleUpdate 处理更新岗位命令
+func (h *PositionCommandHandler) HandleUpdate(ctx context.Context, cmd *commands.UpdatePositionCommand) herrors.Herr {
+	if validate := cmd.Validate(); herrors.HaveError(validate) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", validate)
+		return validate
+	}
+
+	position, err := h.positionService.GetByID(ctx, cmd.ID)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "failed to find position: %s", err)
+		return err
+	}
+
+	position.UpdateBasicInfo(cmd.Code, cmd.Name, cmd.Sort, cmd.Description)
+	position.UpdateStatus(cmd.Status)
+
+	if err := h.positionService.UpdatePosition(ctx, position); err != nil {
+		hlog.CtxErrorf(ctx, "failed to update position: %s", err)
+		return err
+	}
+	return nil
+}
+
+// HandleDelete 处理删除岗位命令
+func (h *PositionCommandHandler) HandleDelete(ctx context.Context, cmd *commands.DeletePositionCommand) herrors.Herr {
+	if validate := cmd.Validate(); herrors.HaveError(validate) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", validate)
+		return validate
+	}
+
+	if err := h.positionService.DeletePosition(ctx, cmd.ID); err != nil {
+		hlog.CtxErrorf(ctx, "failed to delete position: %s", err)
+		return err
+	}
+	return nil
+}
+
+// HandleSetUserPositions 处理设置用户任职岗位命令
+func (h *PositionCommandHandler) HandleSetUserPositions(ctx context.Context, cmd *commands.SetUserPositionsCommand) herrors.Herr {
+	if validate := cmd.Validate(); herrors.HaveError(validate) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", validate)
+		return validate
+	}
+
+	if err := h.positionService.SetUserPositions(ctx, cmd.UserID, cmd.PositionIDs, cmd.PrimaryID); err != nil {
+		hlog.CtxErrorf(ctx, "failed to set user positions: %s", err)
+		return err
+	}
+	return nil
+}
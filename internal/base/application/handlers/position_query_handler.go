@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/models"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+type PositionQueryHandler struct {
+	queryService query.IPositionQueryService
+}
+
+func NewPositionQueryHandler(
+	queryService query.IPositionQueryService,
+) *PositionQueryHandler {
+	return &PositionQueryHandler{
+		queryService: queryService,
+	}
+}
+
+// HandleList 处理岗位列表查询
+func (h *PositionQueryHandler) HandleList(ctx context.Context, req *queries.ListPositionsQuery) (*models.PageRes[dto.PositionDto], herrors.Herr) {
+	if validate := req.Validate(); herrors.HaveError(validate) {
+		hlog.CtxErrorf(ctx, "Query validation error: %s", validate)
+		return nil, validate
+	}
+
+	// 构建查询条件
+	qb := db_query.NewQueryBuilder()
+	if req.Name != "" {
+		qb.Where("name", db_query.Like, "%"+req.Name+"%")
+	}
+	if req.Code != "" {
+		qb.Where("code", db_query.Like, "%"+req.Code+"%")
+	}
+	if req.Status != nil {
+		qb.Where("status", db_query.Eq, *req.Status)
+	}
+	qb.OrderBy("sequence", false)
+	qb.WithPage(&req.Page)
+
+	// 查询总数
+	total, err := h.queryService.CountPositions(ctx, qb)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "failed to count positions: %s", err)
+		return nil, herrors.QueryFail(err)
+	}
+
+	// 查询列表数据
+	positions, err := h.queryService.FindPositions(ctx, qb)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "failed to list positions: %s", err)
+		return nil, herrors.QueryFail(err)
+	}
+
+	return &models.PageRes[dto.PositionDto]{
+		Total: total,
+		List:  positions,
+	}, nil
+}
+
+// HandleGet 处理获取岗位查询
+func (h *PositionQueryHandler) HandleGet(ctx context.Context, query *queries.GetPositionQuery) (*dto.PositionDto, herrors.Herr) {
+	if validate := query.Validate(); herrors.HaveError(validate) {
+		hlog.CtxErrorf(ctx, "Query validation error: %s", validate)
+		return nil, validate
+	}
+
+	position, err := h.queryService.GetPosition(ctx, query.ID)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, errors.PositionNotFound(query.ID)
+		}
+		hlog.CtxErrorf(ctx, "failed to get position: %s", err)
+		return nil, herrors.QueryFail(err)
+	}
+	return position, nil
+}
+
+// HandleGetUserPositions 处理获取用户岗位查询
+func (h *PositionQueryHandler) HandleGetUserPositions(ctx context.Context, query *queries.GetUserPositionsQuery) ([]*dto.UserPositionDto, herrors.Herr) {
+	if validate := query.Validate(); herrors.HaveError(validate) {
+		hlog.CtxErrorf(ctx, "Query validation error: %s", validate)
+		return nil, validate
+	}
+
+	positions, err := h.queryService.GetUserPositions(ctx, query.UserID)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "failed to get user positions: %s", err)
+		return nil, herrors.QueryFail(err)
+	}
+	return positions, nil
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
+	derrors "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/plugin"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/models"
+)
+
+// ProfileHandler 个人中心
+type ProfileHandler struct {
+	profileService *service.ProfileService
+	authService    *service.AuthService
+	userService    *service.UserCommandService
+	userQuery      query.IUserQueryService
+	loginLogQuery  query.ILoginLogQuery
+}
+
+func NewProfileHandler(
+	profileService *service.ProfileService,
+	authService *service.AuthService,
+	userService *service.UserCommandService,
+	userQuery query.IUserQueryService,
+	loginLogQuery query.ILoginLogQuery,
+) *ProfileHandler {
+	return &ProfileHandler{
+		profileService: profileService,
+		authService:    authService,
+		userService:    userService,
+		userQuery:      userQuery,
+		loginLogQuery:  loginLogQuery,
+	}
+}
+
+// HandleGet 获取本人信息, 当前在加入的其他租户时个人信息从归属租户读取, 角色为当前租户的角色
+func (h *ProfileHandler) HandleGet(ctx context.Context) (*dto.UserDto, herrors.Herr) {
+	user, err := h.userQuery.GetUser(ctx, actx.GetUserId(ctx))
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	if user == nil || user.TenantID == plugin.GetCtxTenantID(ctx) {
+		return user, nil
+	}
+	home, err := h.userQuery.GetUser(actx.BuildTenantCtx(ctx, user.TenantID), user.ID)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	if home == nil {
+		return user, nil
+	}
+	profile := *home
+	profile.RoleIds = user.RoleIds
+	return &profile, nil
+}
+
+// HandleTenantInvitations 查询邀请本人加入且尚未接受的租户
+func (h *ProfileHandler) HandleTenantInvitations(ctx context.Context) ([]*dto.UserTenantDto, herrors.Herr) {
+	tenants, err := h.userQuery.GetUserTenantInvitations(ctx, actx.GetUserId(ctx))
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	return tenants, nil
+}
+
+// HandleAcceptTenantInvitation 接受加入租户的邀请
+func (h *ProfileHandler) HandleAcceptTenantInvitation(ctx context.Context, tenantID string) herrors.Herr {
+	return h.userService.AcceptTenantInvitation(ctx, actx.GetUserId(ctx), tenantID)
+}
+
+// HandleDeclineTenantInvitation 拒绝加入租户的邀请
+func (h *ProfileHandler) HandleDeclineTenantInvitation(ctx context.Context, tenantID string) herrors.Herr {
+	return h.userService.DeclineTenantInvitation(ctx, actx.GetUserId(ctx), tenantID)
+}
+
+// HandleUpdate 修改本人基本信息
+func (h *ProfileHandler) HandleUpdate(ctx context.Context, cmd *commands.UpdateProfileCommand) herrors.Herr {
+	return h.profileService.UpdateProfile(ctx, actx.GetUserId(ctx), cmd.Name, cmd.Nickname, cmd.Remark)
+}
+
+// HandleChangePassword 修改本人密码
+func (h *ProfileHandler) HandleChangePassword(ctx context.Context, cmd *commands.ChangePasswordCommand) herrors.Herr {
+	err := h.authService.ChangePassword(ctx, actx.GetUserId(ctx), cmd.OldPassword, cmd.NewPassword)
+	if errors.Is(err, derrors.ErrInvalidCredentials) {
+		return derrors.OldPasswordInvalid()
+	}
+	return herrors.TohError(err)
+}
+
+// HandleSendVerifyCode 发送邮箱/手机验证码
+func (h *ProfileHandler) HandleSendVerifyCode(ctx context.Context, cmd *commands.SendVerifyCodeCommand) herrors.Herr {
+	return h.profileService.SendVerifyCode(ctx, actx.GetUserId(ctx), cmd.Type, cmd.Target)
+}
+
+// HandleChangeContact 验证后修改邮箱/手机号
+func (h *ProfileHandler) HandleChangeContact(ctx context.Context, cmd *commands.ChangeContactCommand) herrors.Herr {
+	return h.profileService.ChangeContact(ctx, actx.GetUserId(ctx), cmd.Type, cmd.Target, cmd.Code)
+}
+
+// HandleUploadAvatar 上传头像
+func (h *ProfileHandler) HandleUploadAvatar(ctx context.Context, fileName string, reader io.Reader, size int64) (string, herrors.Herr) {
+	return h.profileService.UploadAvatar(ctx, actx.GetUserId(ctx), fileName, reader, size)
+}
+
+// HandleLoginLogs 查询本人登录记录
+func (h *ProfileHandler) HandleLoginLogs(ctx context.Context, q *queries.ListProfileLoginLogsQuery) (*models.PageRes[dto.LoginLogDto], herrors.Herr) {
+	tm := time.Now()
+	if q.Month != "" {
+		month, err := time.Parse("200601", q.Month)
+		if err != nil {
+			return nil, herrors.NewBadReqError("invalid month format")
+		}
+		tm = month
+	}
+
+	qb := db_query.NewQueryBuilder()
+	qb.Where("user_id", db_query.Eq, actx.GetUserId(ctx))
+	if q.Status != 0 {
+		qb.Where("status", db_query.Eq, q.Status)
+	}
+	qb.OrderBy("login_time", false)
+	qb.WithPage(&q.Page)
+
+	tenant := actx.GetTenantId(ctx)
+	total, err := h.loginLogQuery.Count(ctx, tenant, tm, qb)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	logs, err := h.loginLogQuery.Find(ctx, tenant, tm, qb)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	return &models.PageRes[dto.LoginLogDto]{
+		List:  logs,
+		Total: total,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/models"
+)
+
+type RecycleBinHandler struct {
+	recycleService *service.RecycleBinService
+	query          query.IRecycleBinQuery
+}
+
+func NewRecycleBinHandler(recycleService *service.RecycleBinService, query query.IRecycleBinQuery) *RecycleBinHandler {
+	return &RecycleBinHandler{
+		recycleService: recycleService,
+		query:          query,
+	}
+}
+
+// HandleList 查询指定类型的回收站记录
+func (h *RecycleBinHandler) HandleList(ctx context.Context, entityType string, q *queries.ListRecycleBinQuery) (*models.PageRes[dto.RecycleItemDto], herrors.Herr) {
+	qb := db_query.NewQueryBuilder()
+	qb.Where("entity_type", db_query.Eq, entityType)
+	if q.Code != "" {
+		qb.Where("code", db_query.Like, "%"+q.Code+"%")
+	}
+	if q.Name != "" {
+		qb.Where("name", db_query.Like, "%"+q.Name+"%")
+	}
+	qb.OrderBy("deleted_at", false)
+	qb.WithPage(&q.Page)
+
+	total, err := h.query.Count(ctx, qb)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	list, err := h.query.Find(ctx, qb)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	return &models.PageRes[dto.RecycleItemDto]{
+		List:  list,
+		Total: total,
+	}, nil
+}
+
+// HandleRestore 从回收站恢复
+func (h *RecycleBinHandler) HandleRestore(ctx context.Context, entityType, entityID string) herrors.Herr {
+	return h.recycleService.Restore(ctx, entityType, entityID)
+}
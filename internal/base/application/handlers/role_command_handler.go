@@ -35,6 +35,7 @@ func (h *RoleCommandHandler) HandleCreate(ctx context.Context, cmd *commands.Cre
 	role.Localize = cmd.Localize
 	role.Sequence = cmd.Sequence
 	role.Type = cmd.Type
+	role.RequiresApproval = cmd.RequiresApproval
 
 	// 创建角色
 	return h.roleService.CreateRole(ctx, role)
@@ -56,6 +57,9 @@ func (h *RoleCommandHandler) HandleUpdate(ctx context.Context, cmd *commands.Upd
 
 	// 更新基本信息
 	role.UpdateBasicInfo(cmd.Name, cmd.Localize, cmd.Description, cmd.Sequence)
+	if cmd.RequiresApproval != nil {
+		role.RequiresApproval = *cmd.RequiresApproval
+	}
 	if cmd.Status != 0 {
 		if hr := role.UpdateStatus(cmd.Status); herrors.HaveError(hr) {
 			return hr
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+// RoleConstraintHandler 职责分离约束处理器
+type RoleConstraintHandler struct {
+	constraintService *service.RoleConstraintService
+	query             query.IRoleConstraintQuery
+}
+
+func NewRoleConstraintHandler(constraintService *service.RoleConstraintService, query query.IRoleConstraintQuery) *RoleConstraintHandler {
+	return &RoleConstraintHandler{
+		constraintService: constraintService,
+		query:             query,
+	}
+}
+
+// HandleCreate 创建约束
+func (h *RoleConstraintHandler) HandleCreate(ctx context.Context, cmd *commands.CreateRoleConstraintCommand) herrors.Herr {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", hr)
+		return hr
+	}
+	constraint := model.NewRoleConstraint(cmd.Name, cmd.Type, cmd.RoleIDs, cmd.Limit, cmd.Description)
+	constraint.TenantID = actx.GetTenantId(ctx)
+	if hr := h.constraintService.Create(ctx, constraint); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to create role constraint: %s", hr)
+		return hr
+	}
+	return nil
+}
+
+// HandleUpdate 更新约束
+func (h *RoleConstraintHandler) HandleUpdate(ctx context.Context, cmd *commands.UpdateRoleConstraintCommand) herrors.Herr {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", hr)
+		return hr
+	}
+	if hr := h.constraintService.Update(ctx, cmd.ID, cmd.Name, cmd.RoleIDs, cmd.Limit, cmd.Description); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to update role constraint: %s", hr)
+		return hr
+	}
+	return nil
+}
+
+// HandleDelete 删除约束
+func (h *RoleConstraintHandler) HandleDelete(ctx context.Context, id int64) herrors.Herr {
+	if hr := h.constraintService.Delete(ctx, id); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to delete role constraint: %s", hr)
+		return hr
+	}
+	return nil
+}
+
+// HandleList 当前租户的全部约束
+func (h *RoleConstraintHandler) HandleList(ctx context.Context) ([]*dto.RoleConstraintDto, herrors.Herr) {
+	list, err := h.query.FindAll(ctx)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	return list, nil
+}
+
+// HandleViolations 违反约束的现有分配
+func (h *RoleConstraintHandler) HandleViolations(ctx context.Context) ([]*dto.RoleConstraintViolationDto, herrors.Herr) {
+	violations, hr := h.constraintService.Violations(ctx)
+	if herrors.HaveError(hr) {
+		return nil, hr
+	}
+	result := make([]*dto.RoleConstraintViolationDto, 0, len(violations))
+	for _, v := range violations {
+		result = append(result, &dto.RoleConstraintViolationDto{
+			ConstraintID:   v.Constraint.ID,
+			ConstraintName: v.Constraint.Name,
+			Type:           v.Constraint.Type,
+			Limit:          v.Constraint.Limit,
+			UserIDs:        v.UserIDs,
+			RoleIDs:        v.RoleIDs,
+			Count:          v.Count,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/models"
+)
+
+// RoleGrantHandler 限时角色与角色申请处理器
+type RoleGrantHandler struct {
+	grantService *service.RoleGrantService
+	query        query.IRoleGrantQuery
+}
+
+func NewRoleGrantHandler(grantService *service.RoleGrantService, query query.IRoleGrantQuery) *RoleGrantHandler {
+	return &RoleGrantHandler{
+		grantService: grantService,
+		query:        query,
+	}
+}
+
+// HandleGrant 授予用户角色, 角色需要审批时返回创建的申请ID
+func (h *RoleGrantHandler) HandleGrant(ctx context.Context, cmd *commands.GrantRoleCommand) (string, herrors.Herr) {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", hr)
+		return "", hr
+	}
+	req, hr := h.grantService.Grant(ctx, cmd.UserID, cmd.RoleID, cmd.StartTime, cmd.ExpireTime, cmd.Reason)
+	if herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to grant role: %s", hr)
+		return "", hr
+	}
+	if req != nil {
+		return req.ID, nil
+	}
+	return "", nil
+}
+
+// HandleRevoke 收回用户角色
+func (h *RoleGrantHandler) HandleRevoke(ctx context.Context, cmd *commands.RevokeRoleGrantCommand) herrors.Herr {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", hr)
+		return hr
+	}
+	if hr := h.grantService.Revoke(ctx, cmd.UserID, cmd.RoleID); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to revoke role: %s", hr)
+		return hr
+	}
+	return nil
+}
+
+// HandleApprove 审批通过角色申请, 审批人为当前用户
+func (h *RoleGrantHandler) HandleApprove(ctx context.Context, cmd *commands.ReviewRoleRequestCommand) herrors.Herr {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", hr)
+		return hr
+	}
+	if hr := h.grantService.Approve(ctx, cmd.ID, actx.GetUserId(ctx), cmd.Comment); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to approve role request: %s", hr)
+		return hr
+	}
+	return nil
+}
+
+// HandleReject 拒绝角色申请, 审批人为当前用户
+func (h *RoleGrantHandler) HandleReject(ctx context.Context, cmd *commands.ReviewRoleRequestCommand) herrors.Herr {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", hr)
+		return hr
+	}
+	if hr := h.grantService.Reject(ctx, cmd.ID, actx.GetUserId(ctx), cmd.Comment); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to reject role request: %s", hr)
+		return hr
+	}
+	return nil
+}
+
+// HandleGetUserGrants 用户的角色授予及有效期
+func (h *RoleGrantHandler) HandleGetUserGrants(ctx context.Context, userID string) ([]*dto.RoleGrantDto, herrors.Herr) {
+	grants, err := h.query.GetUserGrants(ctx, userID)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	return grants, nil
+}
+
+// HandleListRequests 分页查询角色申请
+func (h *RoleGrantHandler) HandleListRequests(ctx context.Context, q *queries.ListRoleRequestsQuery) (*models.PageRes[dto.RoleRequestDto], herrors.Herr) {
+	qb := db_query.NewQueryBuilder()
+	if q.UserID != "" {
+		qb.Where("user_id", db_query.Eq, q.UserID)
+	}
+	if q.RoleID > 0 {
+		qb.Where("role_id", db_query.Eq, q.RoleID)
+	}
+	if q.Status > 0 {
+		qb.Where("status", db_query.Eq, q.Status)
+	}
+	qb.OrderBy("created_at", false)
+	qb.WithPage(&q.Page)
+
+	total, err := h.query.CountRequests(ctx, qb)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	list, err := h.query.FindRequests(ctx, qb)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	return &models.PageRes[dto.RoleRequestDto]{
+		List:  list,
+		Total: total,
+	}, nil
+}
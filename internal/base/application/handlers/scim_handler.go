@@ -0,0 +1,906 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	derrors "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
+	idto "github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	iQuery "github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/scim"
+)
+
+// maxDepartmentCodeLength 部门编码最大长度, Group 的 externalId 作为部门编码
+const maxDepartmentCodeLength = 50
+
+// scimUserColumns 用户可过滤属性与字段的对应关系
+var scimUserColumns = map[string]string{
+	"id":                 "id",
+	"username":           "username",
+	"displayname":        "name",
+	"name.formatted":     "name",
+	"nickname":           "nickname",
+	"emails":             "email",
+	"emails.value":       "email",
+	"phonenumbers":       "phone",
+	"phonenumbers.value": "phone",
+	"active":             "status",
+}
+
+// scimGroupColumns 组可过滤属性与字段的对应关系
+var scimGroupColumns = map[string]string{
+	"id":          "id",
+	"displayname": "name",
+	"externalid":  "code",
+}
+
+// ScimHandler SCIM 2.0 用户和组的同步
+// User 对应用户, roles 属性对应角色编码; Group 对应部门。写操作经由 UserCommandService 和 DepartmentService,
+// 与管理端接口一样发布领域事件并清理缓存
+type ScimHandler struct {
+	userService *service.UserCommandService
+	deptService *service.DepartmentService
+	roleRepo    repository.IRoleRepository
+	userQuery   iQuery.IUserQueryService
+	deptQuery   iQuery.IDepartmentQueryService
+}
+
+func NewScimHandler(
+	userService *service.UserCommandService,
+	deptService *service.DepartmentService,
+	roleRepo repository.IRoleRepository,
+	userQuery iQuery.IUserQueryService,
+	deptQuery iQuery.IDepartmentQueryService,
+) *ScimHandler {
+	return &ScimHandler{
+		userService: userService,
+		deptService: deptService,
+		roleRepo:    roleRepo,
+		userQuery:   userQuery,
+		deptQuery:   deptQuery,
+	}
+}
+
+// ListUsers 按过滤条件分页查询用户, 列表中不返回 groups
+func (h *ScimHandler) ListUsers(ctx context.Context, filter string, page scim.Pagination) (*scim.ListResponse, herrors.Herr) {
+	qb, hr := scimQuery(filter, scimUserColumns)
+	if hr != nil {
+		return nil, hr
+	}
+	total, err := h.userQuery.CountUsers(ctx, qb)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	resources := make([]*scim.User, 0)
+	if page.Count > 0 {
+		qb.OrderBy("created_at", true).OrderBy("id", true).WithOffset(page.Offset(), page.Count)
+		users, err := h.userQuery.FindUsersForExport(ctx, qb)
+		if err != nil {
+			return nil, herrors.QueryFail(err)
+		}
+		for _, u := range users {
+			resources = append(resources, toScimUser(u.UserDto, u.RoleCodes))
+		}
+	}
+	return scim.NewListResponse(total, page.StartIndex, len(resources), resources), nil
+}
+
+// GetUser 获取用户
+func (h *ScimHandler) GetUser(ctx context.Context, id string) (*scim.User, herrors.Herr) {
+	if _, hr := h.userService.GetUser(ctx, id); hr != nil {
+		return nil, hr
+	}
+	user, err := h.userQuery.GetUser(ctx, id)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	roleCodes, err := h.userQuery.GetUserRolesCode(ctx, id)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	depts, err := h.deptQuery.GetUserDepartments(ctx, id)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	result := toScimUser(user, roleCodes)
+	for _, dept := range depts {
+		result.Groups = append(result.Groups, &scim.MultiValue{Value: dept.ID, Display: dept.Name})
+	}
+	return result, nil
+}
+
+// CreateUser 创建用户
+func (h *ScimHandler) CreateUser(ctx context.Context, in *scim.User) (*scim.User, herrors.Herr) {
+	if in.UserName == "" {
+		return nil, scimError(http.StatusBadRequest, scim.ErrInvalidValue, "userName is required")
+	}
+	user, hr := model.NewExternalUser(actx.GetTenantId(ctx), in.UserName, in.Password)
+	if hr != nil {
+		return nil, hr
+	}
+	state := newScimUserState(user)
+	state.applyResource(in)
+	if hr := state.apply(user); hr != nil {
+		return nil, hr
+	}
+	if hr := h.userService.CreateUser(ctx, user); hr != nil {
+		return nil, scimConflict(hr)
+	}
+	if len(in.Roles) > 0 {
+		if hr := h.assignRoles(ctx, user.ID, multiValues(in.Roles)); hr != nil {
+			return nil, hr
+		}
+	}
+	return h.GetUser(ctx, user.ID)
+}
+
+// ReplaceUser 替换用户属性, 请求中没有 roles 属性时保留现有角色
+func (h *ScimHandler) ReplaceUser(ctx context.Context, id string, in *scim.User) (*scim.User, herrors.Herr) {
+	user, hr := h.userService.GetUser(ctx, id)
+	if hr != nil {
+		return nil, hr
+	}
+	if in.UserName != "" && in.UserName != user.Username {
+		return nil, scimError(http.StatusBadRequest, scim.ErrMutability, "userName cannot be changed")
+	}
+	state := newScimUserState(user)
+	state.name, state.nickname, state.email, state.phone, state.avatar = "", "", "", "", ""
+	state.applyResource(in)
+	if in.Password != "" {
+		state.password = in.Password
+	}
+	if hr := h.saveUser(ctx, user, state); hr != nil {
+		return nil, hr
+	}
+	if in.Roles != nil {
+		if hr := h.assignRoles(ctx, id, multiValues(in.Roles)); hr != nil {
+			return nil, hr
+		}
+	}
+	return h.GetUser(ctx, id)
+}
+
+// PatchUser 按 PATCH 操作修改用户
+func (h *ScimHandler) PatchUser(ctx context.Context, id string, req *scim.PatchRequest) (*scim.User, herrors.Herr) {
+	if err := req.Validate(); err != nil {
+		return nil, scimError(http.StatusBadRequest, scim.ErrInvalidSyntax, err.Error())
+	}
+	user, hr := h.userService.GetUser(ctx, id)
+	if hr != nil {
+		return nil, hr
+	}
+	state := newScimUserState(user)
+	for _, op := range req.Operations {
+		if op.Path != "" {
+			path, err := scim.ParsePath(op.Path)
+			if err != nil {
+				return nil, scimError(http.StatusBadRequest, scim.ErrInvalidPath, err.Error())
+			}
+			if hr := state.patch(op.Name(), path, op.Value); hr != nil {
+				return nil, hr
+			}
+			continue
+		}
+		values, err := op.ValueMap()
+		if err != nil {
+			return nil, scimError(http.StatusBadRequest, scim.ErrInvalidSyntax, err.Error())
+		}
+		for attr, value := range values {
+			path, err := scim.ParsePath(attr)
+			if err != nil {
+				return nil, scimError(http.StatusBadRequest, scim.ErrInvalidPath, err.Error())
+			}
+			if hr := state.patch(op.Name(), path, value); hr != nil {
+				return nil, hr
+			}
+		}
+	}
+	if hr := h.saveUser(ctx, user, state); hr != nil {
+		return nil, hr
+	}
+	if state.rolesChanged {
+		if hr := h.assignRoles(ctx, id, state.roles); hr != nil {
+			return nil, hr
+		}
+	}
+	return h.GetUser(ctx, id)
+}
+
+// DeleteUser 删除用户
+func (h *ScimHandler) DeleteUser(ctx context.Context, id string) herrors.Herr {
+	if hr := h.userService.DeleteUser(ctx, id); hr != nil {
+		hlog.CtxErrorf(ctx, "scim delete user %s error: %s", id, hr)
+		return hr
+	}
+	return nil
+}
+
+func (h *ScimHandler) saveUser(ctx context.Context, user *model.User, state *scimUserState) herrors.Herr {
+	if state.userName != "" && state.userName != user.Username {
+		return scimError(http.StatusBadRequest, scim.ErrMutability, "userName cannot be changed")
+	}
+	if hr := state.apply(user); hr != nil {
+		return hr
+	}
+	return h.userService.UpdateUser(ctx, user)
+}
+
+// assignRoles 按角色编码分配角色, 编码不存在时报错
+func (h *ScimHandler) assignRoles(ctx context.Context, userID string, codes []string) herrors.Herr {
+	roleIDs := make([]int64, 0, len(codes))
+	if len(codes) > 0 {
+		roles, err := h.roleRepo.FindByCodes(ctx, codes)
+		if err != nil {
+			return herrors.QueryFail(err)
+		}
+		found := make(map[string]int64, len(roles))
+		for _, role := range roles {
+			found[role.Code] = role.ID
+		}
+		for _, code := range codes {
+			id, ok := found[code]
+			if !ok {
+				return scimError(http.StatusBadRequest, scim.ErrInvalidValue, fmt.Sprintf("role not found: %s", code))
+			}
+			roleIDs = append(roleIDs, id)
+		}
+	}
+	return h.userService.AssignRoles(ctx, userID, roleIDs)
+}
+
+// ListGroups 按过滤条件分页查询组
+func (h *ScimHandler) ListGroups(ctx context.Context, filter string, page scim.Pagination, withMembers bool) (*scim.ListResponse, herrors.Herr) {
+	qb, hr := scimQuery(filter, scimGroupColumns)
+	if hr != nil {
+		return nil, hr
+	}
+	total, err := h.deptQuery.CountDepartments(ctx, qb)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	resources := make([]*scim.Group, 0)
+	if page.Count > 0 {
+		qb.OrderBy("id", true).WithOffset(page.Offset(), page.Count)
+		depts, err := h.deptQuery.FindDepartments(ctx, qb)
+		if err != nil {
+			return nil, herrors.QueryFail(err)
+		}
+		for _, dept := range depts {
+			group := toScimGroup(dept.ID, dept.Name, dept.Code)
+			if withMembers {
+				if hr := h.fillMembers(ctx, group); hr != nil {
+					return nil, hr
+				}
+			}
+			resources = append(resources, group)
+		}
+	}
+	return scim.NewListResponse(total, page.StartIndex, len(resources), resources), nil
+}
+
+// GetGroup 获取组
+func (h *ScimHandler) GetGroup(ctx context.Context, id string, withMembers bool) (*scim.Group, herrors.Herr) {
+	dept, hr := h.deptService.GetByID(ctx, id)
+	if hr != nil {
+		return nil, hr
+	}
+	group := toScimGroup(dept.ID, dept.Name, dept.Code)
+	if withMembers {
+		if hr := h.fillMembers(ctx, group); hr != nil {
+			return nil, hr
+		}
+	}
+	return group, nil
+}
+
+// CreateGroup 创建组, externalId 作为部门编码, 新部门位于顶级
+func (h *ScimHandler) CreateGroup(ctx context.Context, in *scim.Group) (*scim.Group, herrors.Herr) {
+	if in.DisplayName == "" {
+		return nil, scimError(http.StatusBadRequest, scim.ErrInvalidValue, "displayName is required")
+	}
+	if len(in.ExternalID) > maxDepartmentCodeLength {
+		return nil, scimError(http.StatusBadRequest, scim.ErrInvalidValue, "externalId is too long")
+	}
+	dept, hr := model.NewExternalDepartment(in.ExternalID, in.DisplayName)
+	if hr != nil {
+		return nil, hr
+	}
+	dept.TenantID = actx.GetTenantId(ctx)
+	memberIDs := multiValues(in.Members)
+	if hr := h.checkUsers(ctx, memberIDs); hr != nil {
+		return nil, hr
+	}
+	if hr := h.deptService.CreateDepartment(ctx, dept); hr != nil {
+		return nil, scimConflict(hr)
+	}
+	if len(memberIDs) > 0 {
+		if hr := h.deptService.AssignUsers(ctx, dept.ID, memberIDs); hr != nil {
+			return nil, hr
+		}
+	}
+	return h.GetGroup(ctx, dept.ID, true)
+}
+
+// ReplaceGroup 替换组名称和成员, 请求中没有 members 属性时保留现有成员
+func (h *ScimHandler) ReplaceGroup(ctx context.Context, id string, in *scim.Group) (*scim.Group, herrors.Herr) {
+	if in.DisplayName == "" {
+		return nil, scimError(http.StatusBadRequest, scim.ErrInvalidValue, "displayName is required")
+	}
+	state := &scimGroupState{name: in.DisplayName, code: in.ExternalID}
+	if in.Members != nil {
+		state.members = multiValues(in.Members)
+		state.membersChanged = true
+	}
+	if hr := h.saveGroup(ctx, id, state); hr != nil {
+		return nil, hr
+	}
+	return h.GetGroup(ctx, id, true)
+}
+
+// PatchGroup 按 PATCH 操作修改组名称和成员
+func (h *ScimHandler) PatchGroup(ctx context.Context, id string, req *scim.PatchRequest) herrors.Herr {
+	if err := req.Validate(); err != nil {
+		return scimError(http.StatusBadRequest, scim.ErrInvalidSyntax, err.Error())
+	}
+	members, hr := h.memberIDs(ctx, id)
+	if hr != nil {
+		return hr
+	}
+	state := &scimGroupState{members: members}
+	for _, op := range req.Operations {
+		if op.Path != "" {
+			path, err := scim.ParsePath(op.Path)
+			if err != nil {
+				return scimError(http.StatusBadRequest, scim.ErrInvalidPath, err.Error())
+			}
+			if hr := state.patch(op.Name(), path, op.Value); hr != nil {
+				return hr
+			}
+			continue
+		}
+		values, err := op.ValueMap()
+		if err != nil {
+			return scimError(http.StatusBadRequest, scim.ErrInvalidSyntax, err.Error())
+		}
+		for attr, value := range values {
+			if hr := state.patch(op.Name(), &scim.Path{Attr: attr}, value); hr != nil {
+				return hr
+			}
+		}
+	}
+	return h.saveGroup(ctx, id, state)
+}
+
+// DeleteGroup 删除组, 部门连同成员关系移入回收站, 有子部门时拒绝删除
+func (h *ScimHandler) DeleteGroup(ctx context.Context, id string) herrors.Herr {
+	return h.deptService.DeleteDepartment(ctx, id)
+}
+
+func (h *ScimHandler) saveGroup(ctx context.Context, id string, state *scimGroupState) herrors.Herr {
+	dept, hr := h.deptService.GetByID(ctx, id)
+	if hr != nil {
+		return hr
+	}
+	if len(state.code) > maxDepartmentCodeLength {
+		return scimError(http.StatusBadRequest, scim.ErrInvalidValue, "externalId is too long")
+	}
+	if (state.name != "" && state.name != dept.Name) || (state.code != "" && state.code != dept.Code) {
+		if state.name != "" {
+			dept.Name = state.name
+		}
+		if state.code != "" {
+			dept.Code = state.code
+		}
+		if hr := h.deptService.UpdateDepartment(ctx, dept); hr != nil {
+			return scimConflict(hr)
+		}
+	}
+	if !state.membersChanged {
+		return nil
+	}
+
+	current, hr := h.memberIDs(ctx, id)
+	if hr != nil {
+		return hr
+	}
+	added, removed := diffIDs(current, state.members)
+	if hr := h.checkUsers(ctx, added); hr != nil {
+		return hr
+	}
+	if len(removed) > 0 {
+		if hr := h.deptService.RemoveUsers(ctx, id, removed); hr != nil {
+			return hr
+		}
+	}
+	if len(added) > 0 {
+		if hr := h.deptService.AssignUsers(ctx, id, added); hr != nil {
+			return hr
+		}
+	}
+	return nil
+}
+
+func (h *ScimHandler) memberIDs(ctx context.Context, deptID string) ([]string, herrors.Herr) {
+	users, err := h.deptQuery.GetDepartmentUsers(ctx, deptID, "", db_query.NewQueryBuilder())
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	return ids, nil
+}
+
+func (h *ScimHandler) fillMembers(ctx context.Context, group *scim.Group) herrors.Herr {
+	users, err := h.deptQuery.GetDepartmentUsers(ctx, group.ID, "", db_query.NewQueryBuilder())
+	if err != nil {
+		return herrors.QueryFail(err)
+	}
+	group.Members = make([]*scim.MultiValue, 0, len(users))
+	for _, u := range users {
+		group.Members = append(group.Members, &scim.MultiValue{Value: u.ID, Display: u.Username, Type: scim.ResourceTypeUser})
+	}
+	return nil
+}
+
+// checkUsers 校验成员用户存在
+func (h *ScimHandler) checkUsers(ctx context.Context, ids []string) herrors.Herr {
+	if len(ids) == 0 {
+		return nil
+	}
+	qb := db_query.NewQueryBuilder().Where("id", db_query.In, ids)
+	count, err := h.userQuery.CountUsers(ctx, qb)
+	if err != nil {
+		return herrors.QueryFail(err)
+	}
+	if int(count) != len(ids) {
+		return scimError(http.StatusBadRequest, scim.ErrInvalidValue, "group members contain unknown users")
+	}
+	return nil
+}
+
+// scimUserState PATCH/PUT 过程中的用户属性
+type scimUserState struct {
+	userName     string
+	name         string
+	givenName    string
+	familyName   string
+	nickname     string
+	email        string
+	phone        string
+	avatar       string
+	password     string
+	active       *bool
+	roles        []string
+	rolesChanged bool
+	rolesLoaded  bool
+	user         *model.User
+}
+
+func newScimUserState(user *model.User) *scimUserState {
+	return &scimUserState{
+		name:     user.Name,
+		nickname: user.Nickname,
+		email:    user.Email,
+		phone:    user.Phone,
+		avatar:   user.Avatar,
+		user:     user,
+	}
+}
+
+// applyResource 使用完整的用户资源覆盖属性
+func (s *scimUserState) applyResource(in *scim.User) {
+	if name := in.Name.FullName(); name != "" {
+		s.name = name
+	} else if in.DisplayName != "" {
+		s.name = in.DisplayName
+	}
+	if in.NickName != "" {
+		s.nickname = in.NickName
+	}
+	s.email = scim.PrimaryValue(in.Emails)
+	s.phone = scim.PrimaryValue(in.PhoneNumbers)
+	s.avatar = scim.PrimaryValue(in.Photos)
+	s.active = in.Active
+}
+
+// apply 将属性写回用户模型
+func (s *scimUserState) apply(user *model.User) herrors.Herr {
+	if s.givenName != "" || s.familyName != "" {
+		s.name = (&scim.Name{GivenName: s.givenName, FamilyName: s.familyName}).FullName()
+	}
+	name, nickname := s.name, s.nickname
+	if name == "" {
+		name = user.Username
+	}
+	if nickname == "" {
+		nickname = name
+	}
+	user.UpdateBasicInfo(name, nickname, s.phone, s.email, s.avatar, user.Remark)
+	if s.active != nil {
+		status := int8(model.UserStatusDisabled)
+		if *s.active {
+			status = model.UserStatusEnabled
+		}
+		// 待激活用户停用时保持待激活状态
+		if status != user.Status && !(user.IsPending() && status == model.UserStatusDisabled) {
+			if user.ID == "" {
+				user.Status = status
+			} else if hr := user.UpdateStatus(status); hr != nil {
+				return hr
+			}
+		}
+	}
+	if s.password != "" {
+		user.Password = s.password
+		if hr := user.HashPassword(); hr != nil {
+			return hr
+		}
+	}
+	return nil
+}
+
+// patch 执行单个 PATCH 操作, 扩展 schema 的属性忽略
+func (s *scimUserState) patch(op string, path *scim.Path, value json.RawMessage) herrors.Herr {
+	remove := op == scim.PatchRemove
+	str := func() (string, herrors.Herr) {
+		if remove {
+			return "", nil
+		}
+		v, err := scim.DecodeString(value)
+		if err != nil {
+			return "", scimError(http.StatusBadRequest, scim.ErrInvalidValue, fmt.Sprintf("%s: %s", path, err))
+		}
+		return v, nil
+	}
+	multi := func() (string, herrors.Herr) {
+		if remove {
+			return "", nil
+		}
+		if path.SubAttr != "" {
+			return str()
+		}
+		values, err := scim.DecodeMultiValues(value)
+		if err != nil {
+			return "", scimError(http.StatusBadRequest, scim.ErrInvalidValue, fmt.Sprintf("%s: %s", path, err))
+		}
+		return scim.PrimaryValue(values), nil
+	}
+
+	var hr herrors.Herr
+	switch path.String() {
+	case "username":
+		s.userName, hr = str()
+	case "displayname", "name.formatted":
+		s.name, hr = str()
+	case "name.givenname":
+		s.givenName, hr = str()
+	case "name.familyname":
+		s.familyName, hr = str()
+	case "name":
+		if remove {
+			s.name = ""
+			return nil
+		}
+		var name scim.Name
+		if err := json.Unmarshal(value, &name); err != nil {
+			return scimError(http.StatusBadRequest, scim.ErrInvalidValue, "name: object expected")
+		}
+		s.name = name.FullName()
+	case "nickname":
+		s.nickname, hr = str()
+	case "password":
+		if remove {
+			return scimError(http.StatusBadRequest, scim.ErrMutability, "password cannot be removed")
+		}
+		s.password, hr = str()
+	case "active":
+		if remove {
+			return scimError(http.StatusBadRequest, scim.ErrMutability, "active cannot be removed")
+		}
+		active, err := scim.DecodeBool(value)
+		if err != nil {
+			return scimError(http.StatusBadRequest, scim.ErrInvalidValue, fmt.Sprintf("active: %s", err))
+		}
+		s.active = &active
+	case "externalid", "id", "meta", "schemas":
+		// externalId 不保存, id 和元数据只读
+	case "groups":
+		return scimError(http.StatusBadRequest, scim.ErrMutability, "groups is read only, use Group membership instead")
+	default:
+		switch path.Attr {
+		case "emails":
+			s.email, hr = multi()
+		case "phonenumbers":
+			s.phone, hr = multi()
+		case "photos":
+			s.avatar, hr = multi()
+		case "roles":
+			hr = s.patchRoles(op, path, value)
+		default:
+			if isExtensionAttr(path.Attr) {
+				return nil
+			}
+			return scimError(http.StatusBadRequest, scim.ErrInvalidPath, fmt.Sprintf("unsupported attribute: %s", path))
+		}
+	}
+	return hr
+}
+
+// patchRoles 角色按编码增删, 首次修改时以用户当前角色为基础
+func (s *scimUserState) patchRoles(op string, path *scim.Path, value json.RawMessage) herrors.Herr {
+	if !s.rolesLoaded {
+		for _, role := range s.user.Roles {
+			s.roles = append(s.roles, role.Code)
+		}
+		s.rolesLoaded = true
+	}
+	s.rolesChanged = true
+
+	var values []string
+	if len(value) > 0 {
+		mv, err := scim.DecodeMultiValues(value)
+		if err != nil {
+			return scimError(http.StatusBadRequest, scim.ErrInvalidValue, fmt.Sprintf("roles: %s", err))
+		}
+		values = multiValues(mv)
+	}
+	switch op {
+	case scim.PatchAdd:
+		s.roles = unionIDs(s.roles, values)
+	case scim.PatchReplace:
+		s.roles = values
+	case scim.PatchRemove:
+		if code, ok := path.Filter.EqValue("value"); ok {
+			values = append(values, code)
+		}
+		if len(values) == 0 {
+			s.roles = nil
+			return nil
+		}
+		_, s.roles = diffIDs(values, s.roles)
+	}
+	return nil
+}
+
+// scimGroupState PATCH/PUT 过程中的组属性
+type scimGroupState struct {
+	name           string
+	code           string
+	members        []string
+	membersChanged bool
+}
+
+func (s *scimGroupState) patch(op string, path *scim.Path, value json.RawMessage) herrors.Herr {
+	switch path.String() {
+	case "displayname":
+		if op == scim.PatchRemove {
+			return scimError(http.StatusBadRequest, scim.ErrMutability, "displayName cannot be removed")
+		}
+		v, err := scim.DecodeString(value)
+		if err != nil {
+			return scimError(http.StatusBadRequest, scim.ErrInvalidValue, fmt.Sprintf("displayName: %s", err))
+		}
+		s.name = v
+	case "externalid":
+		if op == scim.PatchRemove {
+			return nil
+		}
+		v, err := scim.DecodeString(value)
+		if err != nil {
+			return scimError(http.StatusBadRequest, scim.ErrInvalidValue, fmt.Sprintf("externalId: %s", err))
+		}
+		s.code = v
+	case "id", "meta", "schemas":
+	case "members", "members.value":
+		s.membersChanged = true
+		var values []string
+		if len(value) > 0 {
+			mv, err := scim.DecodeMultiValues(value)
+			if err != nil {
+				return scimError(http.StatusBadRequest, scim.ErrInvalidValue, fmt.Sprintf("members: %s", err))
+			}
+			values = multiValues(mv)
+		}
+		switch op {
+		case scim.PatchAdd:
+			s.members = unionIDs(s.members, values)
+		case scim.PatchReplace:
+			s.members = values
+		case scim.PatchRemove:
+			if id, ok := path.Filter.EqValue("value"); ok {
+				values = append(values, id)
+			}
+			if len(values) == 0 {
+				s.members = nil
+				return nil
+			}
+			_, s.members = diffIDs(values, s.members)
+		}
+	default:
+		if isExtensionAttr(path.Attr) {
+			return nil
+		}
+		return scimError(http.StatusBadRequest, scim.ErrInvalidPath, fmt.Sprintf("unsupported attribute: %s", path))
+	}
+	return nil
+}
+
+// scimQuery 将过滤表达式转换为查询条件
+func scimQuery(filter string, columns map[string]string) (*db_query.QueryBuilder, herrors.Herr) {
+	f, err := scim.ParseFilter(filter)
+	if err != nil {
+		return nil, scimError(http.StatusBadRequest, scim.ErrInvalidFilter, err.Error())
+	}
+	qb := db_query.NewQueryBuilder()
+	for _, cmp := range f {
+		column, ok := columns[cmp.Attr]
+		if !ok {
+			return nil, scimError(http.StatusBadRequest, scim.ErrInvalidFilter, fmt.Sprintf("unsupported filter attribute: %s", cmp.Attr))
+		}
+		if column == "status" {
+			active, ok := cmp.Value.(bool)
+			if !ok || (cmp.Op != scim.OpEq && cmp.Op != scim.OpNe) {
+				return nil, scimError(http.StatusBadRequest, scim.ErrInvalidFilter, "active only supports eq and ne with a boolean value")
+			}
+			if active == (cmp.Op == scim.OpEq) {
+				qb.Where(column, db_query.Eq, model.UserStatusEnabled)
+			} else {
+				qb.Where(column, db_query.Neq, model.UserStatusEnabled)
+			}
+			continue
+		}
+		value := cmp.StringValue()
+		switch cmp.Op {
+		case scim.OpEq:
+			qb.Where(column, db_query.Eq, value)
+		case scim.OpNe:
+			qb.Where(column, db_query.Neq, value)
+		case scim.OpCo:
+			qb.Where(column, db_query.Like, "%"+value+"%")
+		case scim.OpSw:
+			qb.Where(column, db_query.Like, value+"%")
+		case scim.OpEw:
+			qb.Where(column, db_query.Like, "%"+value)
+		case scim.OpPr:
+			qb.Where(column, db_query.Neq, "")
+		case scim.OpGt:
+			qb.Where(column, db_query.Gt, value)
+		case scim.OpGe:
+			qb.Where(column, db_query.Gte, value)
+		case scim.OpLt:
+			qb.Where(column, db_query.Lt, value)
+		case scim.OpLe:
+			qb.Where(column, db_query.Lte, value)
+		}
+	}
+	return qb, nil
+}
+
+func toScimUser(u *idto.UserDto, roleCodes []string) *scim.User {
+	active := u.Status == model.UserStatusEnabled
+	user := &scim.User{
+		Schemas:     []string{scim.SchemaUser},
+		ID:          u.ID,
+		UserName:    u.Username,
+		Name:        &scim.Name{Formatted: u.Name},
+		DisplayName: u.Name,
+		NickName:    u.Nickname,
+		Active:      &active,
+		Meta: &scim.Meta{
+			ResourceType: scim.ResourceTypeUser,
+			Created:      scimTime(u.CreatedAt),
+			LastModified: scimTime(u.UpdatedAt),
+		},
+	}
+	if u.Email != "" {
+		user.Emails = []*scim.MultiValue{{Value: u.Email, Type: "work", Primary: true}}
+	}
+	if u.Phone != "" {
+		user.PhoneNumbers = []*scim.MultiValue{{Value: u.Phone, Type: "mobile", Primary: true}}
+	}
+	if u.Avatar != "" {
+		user.Photos = []*scim.MultiValue{{Value: u.Avatar, Type: "photo", Primary: true}}
+	}
+	for _, code := range roleCodes {
+		user.Roles = append(user.Roles, &scim.MultiValue{Value: code})
+	}
+	return user
+}
+
+func toScimGroup(id, name, code string) *scim.Group {
+	return &scim.Group{
+		Schemas:     []string{scim.SchemaGroup},
+		ID:          id,
+		ExternalID:  code,
+		DisplayName: name,
+		Meta:        &scim.Meta{ResourceType: scim.ResourceTypeGroup},
+	}
+}
+
+func scimTime(ts int64) string {
+	if ts <= 0 {
+		return ""
+	}
+	return time.Unix(ts, 0).UTC().Format(time.RFC3339)
+}
+
+// scimError SCIM 错误, reason 为 scimType
+func scimError(status int, scimType, detail string) herrors.Herr {
+	return herrors.New(status, scimType, detail)
+}
+
+// scimConflict 用户名或部门编码重复时返回 409 uniqueness
+func scimConflict(hr herrors.Herr) herrors.Herr {
+	switch hr.Reason {
+	case derrors.ReasonUserExists, derrors.ReasonDepartmentExists:
+		return scimError(http.StatusConflict, scim.ErrUniqueness, hr.DefMessage)
+	}
+	return hr
+}
+
+func isExtensionAttr(attr string) bool {
+	for _, c := range attr {
+		if c == ':' {
+			return true
+		}
+	}
+	return false
+}
+
+func multiValues(values []*scim.MultiValue) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != nil && v.Value != "" {
+			result = append(result, v.Value)
+		}
+	}
+	return result
+}
+
+func unionIDs(a, b []string) []string {
+	seen := make(map[string]bool, len(a))
+	for _, id := range a {
+		seen[id] = true
+	}
+	for _, id := range b {
+		if !seen[id] {
+			seen[id] = true
+			a = append(a, id)
+		}
+	}
+	return a
+}
+
+// diffIDs 返回 target 相对 current 新增和缺少的ID
+func diffIDs(current, target []string) (added, removed []string) {
+	inCurrent := make(map[string]bool, len(current))
+	for _, id := range current {
+		inCurrent[id] = true
+	}
+	inTarget := make(map[string]bool, len(target))
+	for _, id := range target {
+		inTarget[id] = true
+		if !inCurrent[id] {
+			added = append(added, id)
+		}
+	}
+	for _, id := range current {
+		if !inTarget[id] {
+			removed = append(removed, id)
+		}
+	}
+	return added, removed
+}
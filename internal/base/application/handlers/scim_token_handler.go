@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+// ScimTokenHandler SCIM 令牌处理器
+type ScimTokenHandler struct {
+	tokenService *service.ScimTokenService
+}
+
+func NewScimTokenHandler(tokenService *service.ScimTokenService) *ScimTokenHandler {
+	return &ScimTokenHandler{tokenService: tokenService}
+}
+
+// HandleCreate 创建当前租户的令牌, 令牌明文只在创建时返回
+func (h *ScimTokenHandler) HandleCreate(ctx context.Context, cmd *commands.CreateScimTokenCommand) (*dto.ScimTokenDto, herrors.Herr) {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", hr)
+		return nil, hr
+	}
+	token, raw, hr := h.tokenService.Create(ctx, actx.GetTenantId(ctx), cmd.Name, cmd.ExpireAt)
+	if herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to create scim token: %s", hr)
+		return nil, hr
+	}
+	result := toScimTokenDto(token)
+	result.Token = raw
+	return result, nil
+}
+
+// HandleList 当前租户的令牌列表
+func (h *ScimTokenHandler) HandleList(ctx context.Context) ([]*dto.ScimTokenDto, herrors.Herr) {
+	tokens, hr := h.tokenService.List(ctx, actx.GetTenantId(ctx))
+	if herrors.HaveError(hr) {
+		return nil, hr
+	}
+	result := make([]*dto.ScimTokenDto, 0, len(tokens))
+	for _, token := range tokens {
+		result = append(result, toScimTokenDto(token))
+	}
+	return result, nil
+}
+
+// HandleDelete 删除令牌
+func (h *ScimTokenHandler) HandleDelete(ctx context.Context, id string) herrors.Herr {
+	if hr := h.tokenService.Delete(ctx, actx.GetTenantId(ctx), id); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to delete scim token: %s", hr)
+		return hr
+	}
+	return nil
+}
+
+// HandleAuthenticate 校验 SCIM 请求携带的令牌
+func (h *ScimTokenHandler) HandleAuthenticate(ctx context.Context, raw string) (*model.ScimToken, herrors.Herr) {
+	return h.tokenService.Authenticate(actx.BuildIgnoreTenantCtx(ctx), raw)
+}
+
+func toScimTokenDto(token *model.ScimToken) *dto.ScimTokenDto {
+	return &dto.ScimTokenDto{
+		ID:         token.ID,
+		Name:       token.Name,
+		ExpireAt:   token.ExpireAt,
+		LastUsedAt: token.LastUsedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
@@ -2,22 +2,32 @@ package handlers
 
 import (
 	"context"
+	"strings"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/casbin"
 	"github.com/cloudwego/hertz/pkg/common/hlog"
 )
 
 type TenantCommandHandler struct {
-	tenantService *service.TenantCommandService
+	tenantService   *service.TenantCommandService
+	templateService *service.TenantTemplateService
+	ef              *casbin.Enforcer
 }
 
-func NewTenantCommandHandler(tenantService *service.TenantCommandService) *TenantCommandHandler {
+func NewTenantCommandHandler(
+	tenantService *service.TenantCommandService,
+	templateService *service.TenantTemplateService,
+	ef *casbin.Enforcer,
+) *TenantCommandHandler {
 	return &TenantCommandHandler{
-		tenantService: tenantService,
+		tenantService:   tenantService,
+		templateService: templateService,
+		ef:              ef,
 	}
 }
 
@@ -44,15 +54,33 @@ func (h *TenantCommandHandler) HandleCreate(ctx context.Context, cmd *commands.C
 	// 创建租户
 	tenant := model.NewTenant(cmd.Code, cmd.Name, adminUser)
 	tenant.Description = cmd.Description
+	tenant.Domain = strings.ToLower(cmd.Domain)
+	tenant.SetIsolation(cmd.IsolationMode, cmd.DbSource)
 	tenant.IsDefault = cmd.IsDefault
 	if cmd.ExpireTime > 0 {
 		tenant.ExpireTime = cmd.ExpireTime
 	}
 
+	// 开通模板
+	if cmd.TemplateID != "" {
+		tpl, err := h.templateService.GetTemplate(ctx, cmd.TemplateID)
+		if err != nil {
+			return err
+		}
+		tenant.Template = tpl.Spec
+	}
+
 	if err := h.tenantService.CreateTenant(ctx, tenant); err != nil {
 		hlog.CtxErrorf(ctx, "create tenant err: %v", err)
 		return err
 	}
+
+	// 模板创建了角色权限, 发布租户策略更新消息
+	if tenant.Template != nil && len(tenant.Template.Roles) > 0 {
+		if err := h.ef.PublishTenantUpdate(ctx, tenant.ID); err != nil {
+			hlog.CtxErrorf(ctx, "publish permission update error: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -65,6 +93,7 @@ func (h *TenantCommandHandler) HandleUpdate(ctx context.Context, cmd commands.Up
 
 	// 更新基本信息
 	tenant.UpdateBasicInfo(cmd.Name, cmd.Description)
+	tenant.Domain = strings.ToLower(cmd.Domain)
 
 	// 更新过期时间
 	if cmd.ExpireTime > 0 {
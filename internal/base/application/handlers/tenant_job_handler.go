@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// TenantJobHandler 租户下线任务(数据导出/清除)处理器
+type TenantJobHandler struct {
+	jobService   *service.TenantJobService
+	queryService query.ITenantQueryService
+}
+
+func NewTenantJobHandler(jobService *service.TenantJobService, queryService query.ITenantQueryService) *TenantJobHandler {
+	return &TenantJobHandler{
+		jobService:   jobService,
+		queryService: queryService,
+	}
+}
+
+// HandleExport 提交租户数据导出任务
+func (h *TenantJobHandler) HandleExport(ctx context.Context, cmd commands.SubmitTenantJobCommand) (*dto.TenantJobDto, herrors.Herr) {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		return nil, hr
+	}
+	job, hr := h.jobService.SubmitExport(ctx, cmd.TenantID)
+	if herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "submit tenant export job error: %s", hr)
+		return nil, hr
+	}
+	return h.HandleGetJob(ctx, job.ID)
+}
+
+// HandlePurge 提交租户数据清除任务
+func (h *TenantJobHandler) HandlePurge(ctx context.Context, cmd commands.SubmitTenantJobCommand) (*dto.TenantJobDto, herrors.Herr) {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		return nil, hr
+	}
+	job, hr := h.jobService.SubmitPurge(ctx, cmd.TenantID)
+	if herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "submit tenant purge job error: %s", hr)
+		return nil, hr
+	}
+	return h.HandleGetJob(ctx, job.ID)
+}
+
+// HandleListJobs 获取租户下线任务列表
+func (h *TenantJobHandler) HandleListJobs(ctx context.Context, q queries.ListTenantJobsQuery) ([]*dto.TenantJobDto, herrors.Herr) {
+	jobs, err := h.queryService.ListTenantJobs(ctx, q.TenantID)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	return jobs, nil
+}
+
+// HandleGetJob 获取任务详情及进度
+func (h *TenantJobHandler) HandleGetJob(ctx context.Context, id string) (*dto.TenantJobDto, herrors.Herr) {
+	job, err := h.queryService.GetTenantJob(ctx, id)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	if job == nil {
+		return nil, errors.TenantJobNotFound(id)
+	}
+	return job, nil
+}
+
+// HandleGetArchive 获取导出归档文件路径和下载文件名
+func (h *TenantJobHandler) HandleGetArchive(ctx context.Context, id string) (string, string, herrors.Herr) {
+	job, hr := h.HandleGetJob(ctx, id)
+	if herrors.HaveError(hr) {
+		return "", "", hr
+	}
+	if !job.Downloadable {
+		return "", "", errors.TenantJobNotFound(id)
+	}
+	return job.FilePath, filepath.Base(job.FilePath), nil
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// TenantTemplateHandler 租户开通模板处理器
+type TenantTemplateHandler struct {
+	templateService *service.TenantTemplateService
+	queryService    query.ITenantQueryService
+}
+
+func NewTenantTemplateHandler(templateService *service.TenantTemplateService, queryService query.ITenantQueryService) *TenantTemplateHandler {
+	return &TenantTemplateHandler{
+		templateService: templateService,
+		queryService:    queryService,
+	}
+}
+
+// HandleCreate 创建模板
+func (h *TenantTemplateHandler) HandleCreate(ctx context.Context, cmd commands.CreateTenantTemplateCommand) herrors.Herr {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		return hr
+	}
+	tpl, hr := model.NewTenantTemplate(cmd.Name, cmd.Description, cmd.Format, cmd.Content)
+	if hr != nil {
+		return hr
+	}
+	if hr := h.templateService.CreateTemplate(ctx, tpl); hr != nil {
+		hlog.CtxErrorf(ctx, "create tenant template error: %s", hr)
+		return hr
+	}
+	return nil
+}
+
+// HandleUpdate 更新模板
+func (h *TenantTemplateHandler) HandleUpdate(ctx context.Context, cmd commands.UpdateTenantTemplateCommand) herrors.Herr {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		return hr
+	}
+	if hr := h.templateService.UpdateTemplate(ctx, cmd.ID, cmd.Name, cmd.Description, cmd.Format, cmd.Content); hr != nil {
+		hlog.CtxErrorf(ctx, "update tenant template error: %s", hr)
+		return hr
+	}
+	return nil
+}
+
+// HandleDelete 删除模板
+func (h *TenantTemplateHandler) HandleDelete(ctx context.Context, id string) herrors.Herr {
+	return h.templateService.DeleteTemplate(ctx, id)
+}
+
+// HandleClone 从已有租户生成模板
+func (h *TenantTemplateHandler) HandleClone(ctx context.Context, cmd commands.CloneTenantTemplateCommand) (*dto.TenantTemplateDto, herrors.Herr) {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		return nil, hr
+	}
+	tpl, hr := h.templateService.CloneFromTenant(ctx, cmd.TenantID, cmd.Name, cmd.Description, cmd.Format)
+	if hr != nil {
+		hlog.CtxErrorf(ctx, "clone tenant template error: %s", hr)
+		return nil, hr
+	}
+	return h.HandleGet(ctx, tpl.ID)
+}
+
+// HandleList 获取模板列表
+func (h *TenantTemplateHandler) HandleList(ctx context.Context, q queries.ListTenantTemplatesQuery) ([]*dto.TenantTemplateDto, herrors.Herr) {
+	list, err := h.queryService.ListTenantTemplates(ctx, q.Name)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	return list, nil
+}
+
+// HandleGet 获取模板详情
+func (h *TenantTemplateHandler) HandleGet(ctx context.Context, id string) (*dto.TenantTemplateDto, herrors.Herr) {
+	tpl, err := h.queryService.GetTenantTemplate(ctx, id)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	if tpl == nil {
+		return nil, errors.TenantTemplateNotFound(id)
+	}
+	return tpl, nil
+}
@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/plugin"
+	"strings"
 
 	"github.com/cloudwego/hertz/pkg/common/hlog"
 
@@ -10,17 +14,21 @@ import (
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/sheet"
 )
 
 type UserCommandHandler struct {
-	userService *service.UserCommandService
+	userService   *service.UserCommandService
+	importService *service.UserImportService
 }
 
 func NewUserCommandHandler(
 	userService *service.UserCommandService,
+	importService *service.UserImportService,
 ) *UserCommandHandler {
 	return &UserCommandHandler{
-		userService: userService,
+		userService:   userService,
+		importService: importService,
 	}
 }
 
@@ -150,3 +158,53 @@ func (h *UserCommandHandler) HandleAssignUserRole(ctx context.Context, cmd comma
 	}
 	return nil
 }
+
+// HandleAddTenantMember 处理邀请其他租户用户加入当前租户
+func (h *UserCommandHandler) HandleAddTenantMember(ctx context.Context, cmd commands.AddTenantMemberCommand) herrors.Herr {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", hr)
+		return hr
+	}
+	tenantID := plugin.GetCtxTenantID(ctx)
+	if tenantID == "" {
+		return herrors.NewBadReqError("tenant is required")
+	}
+	if hr := h.userService.AddTenantMember(ctx, tenantID, cmd.TenantCode, cmd.Username); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to add tenant member: %s", hr)
+		return hr
+	}
+	return nil
+}
+
+// HandleImport 处理批量导入用户请求, 首行为表头, 列布局见 model.UserSheetColumns
+func (h *UserCommandHandler) HandleImport(ctx context.Context, cmd *commands.ImportUsersCommand) (*model.UserImportResult, herrors.Herr) {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", hr)
+		return nil, hr
+	}
+	records, err := sheet.ReadAll(bytes.NewReader(cmd.Content), cmd.Format)
+	if err != nil {
+		return nil, herrors.NewBadReqHError(err)
+	}
+	if len(records) == 0 {
+		return nil, herrors.NewBadReqError("empty file")
+	}
+	header := records[0]
+	for i, column := range model.UserSheetColumns {
+		if i >= len(header) || strings.TrimSpace(strings.ToLower(header[i])) != column {
+			return nil, herrors.NewBadReqError(fmt.Sprintf("invalid header, expected columns: %s", strings.Join(model.UserSheetColumns, ",")))
+		}
+	}
+
+	rows := make([]*model.UserImportRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		// 行号从表头开始计算, 与表格软件中显示的行号一致
+		rows = append(rows, model.NewUserImportRow(i+2, record))
+	}
+	result, hr := h.importService.Import(ctx, actx.GetTenantId(ctx), rows, cmd.DryRun)
+	if herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to import users: %s", hr)
+		return nil, hr
+	}
+	return result, nil
+}
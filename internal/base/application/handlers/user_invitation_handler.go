@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
+	idto "github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	iQuery "github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/models"
+)
+
+// UserInvitationHandler 用户邀请处理器
+type UserInvitationHandler struct {
+	invitationService *service.UserInvitationService
+	queryService      iQuery.IUserQueryService
+}
+
+func NewUserInvitationHandler(invitationService *service.UserInvitationService, queryService iQuery.IUserQueryService) *UserInvitationHandler {
+	return &UserInvitationHandler{
+		invitationService: invitationService,
+		queryService:      queryService,
+	}
+}
+
+// HandleInvite 邀请用户
+func (h *UserInvitationHandler) HandleInvite(ctx context.Context, cmd *commands.InviteUserCommand) (*dto.InvitationDto, herrors.Herr) {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "Command validation error: %s", hr)
+		return nil, hr
+	}
+	user, hr := model.NewInvitedUser(actx.GetTenantId(ctx), cmd.Username, cmd.Email)
+	if hr != nil {
+		return nil, hr
+	}
+	if cmd.Name != "" {
+		user.Name = cmd.Name
+	}
+	if cmd.Nickname != "" {
+		user.Nickname = cmd.Nickname
+	}
+	link, hr := h.invitationService.Invite(ctx, user, cmd.RoleIDs, cmd.DeptID)
+	if herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to invite user: %s", hr)
+		return nil, hr
+	}
+	return &dto.InvitationDto{UserID: user.ID, Link: link}, nil
+}
+
+// HandleResend 重新发送邀请
+func (h *UserInvitationHandler) HandleResend(ctx context.Context, userID string) (*dto.InvitationDto, herrors.Herr) {
+	link, hr := h.invitationService.Resend(ctx, userID)
+	if herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to resend invitation: %s", hr)
+		return nil, hr
+	}
+	return &dto.InvitationDto{UserID: userID, Link: link}, nil
+}
+
+// HandleRevoke 撤销邀请
+func (h *UserInvitationHandler) HandleRevoke(ctx context.Context, userID string) herrors.Herr {
+	if hr := h.invitationService.Revoke(ctx, userID); herrors.HaveError(hr) {
+		hlog.CtxErrorf(ctx, "failed to revoke invitation: %s", hr)
+		return hr
+	}
+	return nil
+}
+
+// HandleList 待接受邀请列表, 即待激活用户
+func (h *UserInvitationHandler) HandleList(ctx context.Context, q *queries.ListInvitationsQuery) (*models.PageRes[idto.UserDto], herrors.Herr) {
+	qb := userListFilter(q.Username, "", "", q.Email, model.UserStatusPending)
+	qb.WithPage(&q.Page)
+
+	total, err := h.queryService.CountUsers(ctx, qb)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	users, err := h.queryService.FindUsers(ctx, qb)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	return &models.PageRes[idto.UserDto]{
+		List:  users,
+		Total: total,
+	}, nil
+}
+
+// HandlePreview 校验邀请令牌, 返回被邀请人信息
+func (h *UserInvitationHandler) HandlePreview(ctx context.Context, q *queries.PreviewInvitationQuery) (*dto.InvitationPreviewDto, herrors.Herr) {
+	user, hr := h.invitationService.Preview(ctx, q.Token)
+	if hr != nil {
+		return nil, hr
+	}
+	return &dto.InvitationPreviewDto{
+		Username: user.Username,
+		Name:     user.Name,
+		Email:    user.Email,
+		ExpireAt: user.InvitationExp,
+	}, nil
+}
+
+// HandleAccept 接受邀请并设置密码
+func (h *UserInvitationHandler) HandleAccept(ctx context.Context, cmd *commands.AcceptInvitationCommand) herrors.Herr {
+	if hr := cmd.Validate(); herrors.HaveError(hr) {
+		return hr
+	}
+	return h.invitationService.Accept(ctx, cmd.Token, cmd.Password)
+}
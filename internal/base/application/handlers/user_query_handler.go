@@ -2,23 +2,36 @@ package handlers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/sheet"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
 	iQuery "github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/i18n"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/models"
 )
 
 type UserQueryHandler struct {
-	queryService iQuery.IUserQueryService
+	queryService  iQuery.IUserQueryService
+	positionQuery iQuery.IPositionQueryService
 }
 
-func NewUserQueryHandler(queryService iQuery.IUserQueryService) *UserQueryHandler {
+func NewUserQueryHandler(queryService iQuery.IUserQueryService, positionQuery iQuery.IPositionQueryService) *UserQueryHandler {
 	return &UserQueryHandler{
-		queryService: queryService,
+		queryService:  queryService,
+		positionQuery: positionQuery,
 	}
 }
 
@@ -34,21 +47,13 @@ func (h *UserQueryHandler) HandleGet(ctx context.Context, q queries.GetUserQuery
 // HandleList 处理用户列表查询
 func (h *UserQueryHandler) HandleList(ctx context.Context, q *queries.ListUsersQuery) (*models.PageRes[dto.UserDto], herrors.Herr) {
 	// 构建查询条件
-	qb := db_query.NewQueryBuilder()
-	if q.Username != "" {
-		qb.Where("username", db_query.Like, "%"+q.Username+"%")
-	}
-	if q.Name != "" {
-		qb.Where("name", db_query.Like, "%"+q.Name+"%")
+	qb := userListFilter(q.Username, q.Name, q.Phone, q.Email, q.Status)
+	matched, herr := h.positionFilter(ctx, qb, q.PositionID)
+	if herr != nil {
+		return nil, herr
 	}
-	if q.Phone != "" {
-		qb.Where("phone", db_query.Like, "%"+q.Phone+"%")
-	}
-	if q.Email != "" {
-		qb.Where("email", db_query.Like, "%"+q.Email+"%")
-	}
-	if q.Status != 0 {
-		qb.Where("status", db_query.Eq, q.Status)
+	if !matched {
+		return &models.PageRes[dto.UserDto]{List: make([]*dto.UserDto, 0)}, nil
 	}
 	qb.WithPage(&q.Page)
 
@@ -101,12 +106,19 @@ func (h *UserQueryHandler) HandleGetUserInfo(ctx context.Context, q queries.GetU
 		roleCodes = append(roleCodes, role.Code)
 	}
 	// 4. 构建用户信息DTO
-	return &dto.UserInfoDto{
+	info := &dto.UserInfoDto{
 		User:        user,
 		Roles:       roleCodes,
 		HomePage:    "User",
 		Permissions: permissions,
-	}, nil
+	}
+	if actx.IsImpersonating(ctx) {
+		info.Impersonation = &dto.ImpersonationInfoDto{
+			ActorId:   actx.GetActorId(ctx),
+			ActorName: actx.GetActorName(ctx),
+		}
+	}
+	return info, nil
 }
 
 // HandleGetUserMenus 处理获取用户菜单查询
@@ -120,3 +132,150 @@ func (h *UserQueryHandler) HandleGetUserMenus(ctx context.Context, q queries.Get
 	// 转换为树形结构DTO
 	return menus, nil
 }
+
+// HandleGetUserRoutes 获取当前用户的前端路由树和按钮权限
+// 路由的组件、重定向、隐藏和缓存配置取自权限的扩展属性(JSON), 标题按请求语言翻译
+func (h *UserQueryHandler) HandleGetUserRoutes(ctx context.Context, q queries.GetUserMenusQuery) (*dto.UserRoutesDto, herrors.Herr) {
+	menus, err := h.queryService.GetUserTreeMenus(ctx, q.UserID)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	buttons, err := h.queryService.GetUserButtons(ctx, q.UserID)
+	if err != nil {
+		return nil, herrors.QueryFail(err)
+	}
+	res := &dto.UserRoutesDto{
+		Routes:  toRoutes(ctx, menus),
+		Buttons: buttons,
+	}
+	body, err := json.Marshal(res)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	sum := sha256.Sum256(body)
+	res.Version = hex.EncodeToString(sum[:16])
+	return res, nil
+}
+
+// routeProperties 权限扩展属性中与前端路由相关的配置
+type routeProperties struct {
+	Component string `json:"component"`
+	Redirect  string `json:"redirect"`
+	Hidden    bool   `json:"hidden"`
+	KeepAlive bool   `json:"keepAlive"`
+}
+
+// toRoutes 菜单树转为路由树, 跳过已禁用的菜单及其下级
+func toRoutes(ctx context.Context, menus []*dto.PermissionsTreeDto) []*dto.RouteDto {
+	routes := make([]*dto.RouteDto, 0, len(menus))
+	for _, m := range menus {
+		if m.Status != 1 {
+			continue
+		}
+		var props routeProperties
+		if m.Properties != "" {
+			if err := json.Unmarshal([]byte(m.Properties), &props); err != nil {
+				hlog.CtxWarnf(ctx, "invalid properties of permission %s: %v", m.Code, err)
+			}
+		}
+		routes = append(routes, &dto.RouteDto{
+			Name:      m.Code,
+			Path:      m.Path,
+			Component: props.Component,
+			Redirect:  props.Redirect,
+			Meta: dto.RouteMetaDto{
+				Title:     i18n.Translate(ctx, m.Localize, m.Name),
+				I18nKey:   m.Localize,
+				Icon:      m.Icon,
+				Order:     m.Sequence,
+				Hidden:    props.Hidden,
+				KeepAlive: props.KeepAlive,
+			},
+			Children: toRoutes(ctx, m.Children),
+		})
+	}
+	return routes
+}
+
+// exportPageSize 导出时每次查询的行数
+const exportPageSize = 500
+
+// HandleExport 按列表过滤条件分页读取用户并逐行写出, 列布局与导入一致
+func (h *UserQueryHandler) HandleExport(ctx context.Context, q *queries.ExportUsersQuery, w sheet.Writer) herrors.Herr {
+	if err := w.Write(model.UserSheetColumns); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	page := &db_query.Page{Current: 1, Size: exportPageSize}
+	for {
+		qb := userListFilter(q.Username, q.Name, q.Phone, q.Email, q.Status)
+		matched, herr := h.positionFilter(ctx, qb, q.PositionID)
+		if herr != nil {
+			return herr
+		}
+		if !matched {
+			return nil
+		}
+		qb.OrderBy("created_at", true).OrderBy("id", true).WithPage(page)
+		users, err := h.queryService.FindUsersForExport(ctx, qb)
+		if err != nil {
+			return herrors.QueryFail(err)
+		}
+		for _, user := range users {
+			row := []string{
+				user.Username,
+				"",
+				user.Name,
+				user.Nickname,
+				user.Phone,
+				user.Email,
+				strconv.Itoa(int(user.Status)),
+				strings.Join(user.DeptCodes, model.UserSheetValueSep),
+				strings.Join(user.RoleCodes, model.UserSheetValueSep),
+			}
+			if err := w.Write(row); err != nil {
+				return herrors.NewServerHError(err)
+			}
+		}
+		if len(users) < exportPageSize {
+			return nil
+		}
+		page.Current++
+	}
+}
+
+// userListFilter 用户列表和导出共用的过滤条件
+func userListFilter(username, name, phone, email string, status int) *db_query.QueryBuilder {
+	qb := db_query.NewQueryBuilder()
+	if username != "" {
+		qb.Where("username", db_query.Like, "%"+username+"%")
+	}
+	if name != "" {
+		qb.Where("name", db_query.Like, "%"+name+"%")
+	}
+	if phone != "" {
+		qb.Where("phone", db_query.Like, "%"+phone+"%")
+	}
+	if email != "" {
+		qb.Where("email", db_query.Like, "%"+email+"%")
+	}
+	if status != 0 {
+		qb.Where("status", db_query.Eq, status)
+	}
+	return qb
+}
+
+// positionFilter 按岗位过滤用户, 岗位下没有用户时返回 false
+func (h *UserQueryHandler) positionFilter(ctx context.Context, qb *db_query.QueryBuilder, positionID string) (bool, herrors.Herr) {
+	if positionID == "" {
+		return true, nil
+	}
+	userIDs, err := h.positionQuery.GetPositionUserIDs(ctx, []string{positionID})
+	if err != nil {
+		return false, herrors.QueryFail(err)
+	}
+	if len(userIDs) == 0 {
+		return false, nil
+	}
+	qb.Where("id", db_query.In, userIDs)
+	return true, nil
+}
@@ -5,17 +5,31 @@ import "github.com/google/wire"
 var ProviderSet = wire.NewSet(
 	NewUserCommandHandler,
 	NewUserQueryHandler,
+	NewUserInvitationHandler,
+	NewProfileHandler,
+	NewScimHandler,
+	NewScimTokenHandler,
 	NewRoleCommandHandler,
 	NewRoleQueryHandler,
 	NewPermissionsCommandHandler,
 	NewPermissionsQueryHandler,
 	NewTenantCommandHandler,
 	NewTenantQueryHandler,
+	NewTenantJobHandler,
+	NewTenantTemplateHandler,
 	NewAuthHandler,
 	NewLoginLogQueryHandler,
 	NewOperationLogQueryHandler,
 	NewDepartmentCommandHandler,
 	NewDepartmentQueryHandler,
+	NewPositionCommandHandler,
+	NewPositionQueryHandler,
 	NewDataPermissionCommandHandler,
 	NewDataPermissionQueryHandler,
+	NewRecycleBinHandler,
+	NewRoleGrantHandler,
+	NewRoleConstraintHandler,
+	NewAccessReviewHandler,
+	NewPermissionSeedHandler,
+	NewLogChainHandler,
 )
@@ -0,0 +1,12 @@
+package queries
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+)
+
+// ListAccessReviewsQuery 权限复核活动列表查询
+type ListAccessReviewsQuery struct {
+	db_query.Page
+	Name   string `json:"name" query:"name"`     // 活动名称
+	Status int8   `json:"status" query:"status"` // 状态(1:进行中 2:已关闭)
+}
@@ -68,3 +68,20 @@ type GetUnassignedUsersQuery struct {
 func (q *GetUnassignedUsersQuery) Validate() herrors.Herr {
 	return validator.Validate(q)
 }
+
+// 组织架构图导出格式
+const (
+	OrgChartFormatJSON = "json"
+	OrgChartFormatCSV  = "csv"
+	OrgChartFormatDOT  = "dot"
+)
+
+// ExportOrgChartQuery 组织架构图导出查询
+type ExportOrgChartQuery struct {
+	RootID string `json:"rootId" query:"rootId" label:"根部门ID"`                                        // 根部门ID,为空则导出全部
+	Format string `json:"format" query:"format" validate:"omitempty,oneof=json csv dot" label:"导出格式"` // 导出格式 json/csv/dot, 默认 json
+}
+
+func (q *ExportOrgChartQuery) Validate() herrors.Herr {
+	return validator.Validate(q)
+}
@@ -1,6 +1,10 @@
 package queries
 
-import "github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+import (
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/validator"
+)
 
 // ListOperationLogQuery 查询操作日志列表
 type ListOperationLogQuery struct {
@@ -12,4 +16,31 @@ type ListOperationLogQuery struct {
 	Action    string `json:"action" query:"action"`         // 操作类型
 	StartTime int64  `json:"start_time" query:"start_time"` // 开始时间
 	EndTime   int64  `json:"end_time" query:"end_time"`     // 结束时间
+	RequestID string `json:"request_id" query:"request_id"` // 请求ID
+}
+
+// ListAuditLogQuery 查询数据变更记录, 按实体或按请求查询
+type ListAuditLogQuery struct {
+	db_query.Page
+	EntityType string `json:"entity_type" query:"entity_type"` // 实体类型, 如 user、role
+	EntityID   string `json:"entity_id" query:"entity_id"`     // 实体ID
+	RequestID  string `json:"request_id" query:"request_id"`   // 请求ID, 对应操作日志的 request_id
+	Action     string `json:"action" query:"action"`           // 变更类型(create/update/delete)
+}
+
+func (q *ListAuditLogQuery) Validate() herrors.Herr {
+	if q.RequestID == "" && (q.EntityType == "" || q.EntityID == "") {
+		return herrors.NewBadReqError("entity_type and entity_id or request_id is required")
+	}
+	return nil
+}
+
+// VerifyLogChainQuery 校验日志哈希链
+type VerifyLogChainQuery struct {
+	LogType  string `json:"log_type" query:"log_type" validate:"required,oneof=oplog login" label:"日志类型"` // 日志类型(oplog/login)
+	TenantID string `json:"tenant_id" query:"tenant_id"`                                                  // 租户ID, 仅超级管理员可指定
+}
+
+func (q *VerifyLogChainQuery) Validate() herrors.Herr {
+	return validator.Validate(q)
 }
@@ -1,6 +1,10 @@
 package queries
 
-import "github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+import (
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/validator"
+)
 
 type GetPermissionsQuery struct {
 	Id int64 `json:"id" query:"id"` // 权限ID
@@ -16,3 +20,23 @@ type ListPermissionsQuery struct {
 type GetPermissionsTreeQuery struct {
 	Type int8 `json:"type" query:"type"` // 权限类型
 }
+
+// ExportPermissionSeedQuery 导出权限种子
+type ExportPermissionSeedQuery struct {
+	Format string `json:"format" query:"format" validate:"omitempty,oneof=yaml json"` // 默认 yaml
+}
+
+// ExplainPermissionQuery 解释用户对接口的访问结果, 未指定的请求属性取当前请求的值
+type ExplainPermissionQuery struct {
+	UserID   string `json:"userId" query:"userId" validate:"required" label:"用户ID"`                                 // 用户ID
+	Method   string `json:"method" query:"method" validate:"required,oneof=GET POST PUT DELETE PATCH" label:"请求方法"` // 请求方法
+	Path     string `json:"path" query:"path" validate:"required" label:"请求路径"`                                     // 请求路径
+	IP       string `json:"ip" query:"ip" validate:"omitempty,ip" label:"客户端IP"`                                    // 客户端IP
+	Platform string `json:"platform" query:"platform" label:"登录平台"`                                                 // 登录平台
+	Device   string `json:"device" query:"device" label:"设备名称"`                                                     // 设备名称
+	At       int64  `json:"at" query:"at" validate:"omitempty,gt=0" label:"请求时间"`                                   // 请求时间, unix 秒
+}
+
+func (q *ExplainPermissionQuery) Validate() herrors.Herr {
+	return validator.Validate(q)
+}
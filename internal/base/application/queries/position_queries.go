@@ -0,0 +1,37 @@
+package queries
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/validator"
+)
+
+// ListPositionsQuery 岗位列表查询
+type ListPositionsQuery struct {
+	db_query.Page
+	Name   string `json:"name" query:"name" validate:"omitempty,max=100" label:"岗位名称"`     // 岗位名称
+	Code   string `json:"code" query:"code" validate:"omitempty,max=50" label:"岗位编码"`      // 岗位编码
+	Status *int8  `json:"status" query:"status" validate:"omitempty,oneof=0 1" label:"状态"` // 岗位状态
+}
+
+func (q *ListPositionsQuery) Validate() herrors.Herr {
+	return validator.Validate(q)
+}
+
+// GetPositionQuery 获取岗位查询
+type GetPositionQuery struct {
+	ID string `json:"id" query:"id" validate:"required" label:"岗位ID"` // 岗位ID
+}
+
+func (q *GetPositionQuery) Validate() herrors.Herr {
+	return validator.Validate(q)
+}
+
+// GetUserPositionsQuery 获取用户岗位查询
+type GetUserPositionsQuery struct {
+	UserID string `json:"userId" query:"userId" validate:"required" label:"用户ID"` // 用户ID
+}
+
+func (q *GetUserPositionsQuery) Validate() herrors.Herr {
+	return validator.Validate(q)
+}
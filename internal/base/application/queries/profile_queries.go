@@ -0,0 +1,12 @@
+package queries
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+)
+
+// ListProfileLoginLogsQuery 查询本人登录记录
+type ListProfileLoginLogsQuery struct {
+	db_query.Page
+	Month  string `json:"month" query:"month"`   // 查询月份(格式:202403)
+	Status int8   `json:"status" query:"status"` // 登录状态
+}
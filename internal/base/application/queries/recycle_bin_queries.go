@@ -0,0 +1,12 @@
+package queries
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+)
+
+// ListRecycleBinQuery 回收站列表查询
+type ListRecycleBinQuery struct {
+	db_query.Page
+	Code string `json:"code" query:"code"` // 用户名或编码
+	Name string `json:"name" query:"name"` // 名称
+}
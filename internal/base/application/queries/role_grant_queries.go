@@ -0,0 +1,13 @@
+package queries
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+)
+
+// ListRoleRequestsQuery 角色申请列表查询
+type ListRoleRequestsQuery struct {
+	db_query.Page
+	UserID string `json:"userId" query:"userId"` // 被授予角色的用户
+	RoleID int64  `json:"roleId" query:"roleId"` // 角色ID
+	Status int8   `json:"status" query:"status"` // 状态(1:待审批 2:已通过 3:已拒绝)
+}
@@ -17,3 +17,13 @@ type ListTenantsQuery struct {
 type GetTenantPermissionsQuery struct {
 	TenantID string `json:"tenant_id" query:"tenant_id"`
 }
+
+// ListTenantJobsQuery 获取租户下线任务列表
+type ListTenantJobsQuery struct {
+	TenantID string `json:"tenant_id" query:"tenant_id"`
+}
+
+// ListTenantTemplatesQuery 获取租户开通模板列表
+type ListTenantTemplatesQuery struct {
+	Name string `json:"name" query:"name"` // 模板名称
+}
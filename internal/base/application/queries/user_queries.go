@@ -10,11 +10,23 @@ type GetUserQuery struct {
 // ListUsersQuery 用户列表查询
 type ListUsersQuery struct {
 	db_query.Page
-	Username string
-	Name     string
-	Phone    string
-	Email    string
-	Status   int
+	Username   string
+	Name       string
+	Phone      string
+	Email      string
+	Status     int
+	PositionID string // 岗位ID, 仅返回任职该岗位的用户
+}
+
+// ExportUsersQuery 用户导出查询, 过滤条件与用户列表一致
+type ExportUsersQuery struct {
+	Username   string
+	Name       string
+	Phone      string
+	Email      string
+	Status     int
+	PositionID string // 岗位ID
+	Format     string // 导出格式 csv/xlsx, 默认 csv
 }
 
 // GetUserPermissionsQuery 获取用户权限查询
@@ -47,3 +59,15 @@ type ListUnassignedUsersQuery struct {
 	Username string
 	Name     string
 }
+
+// ListInvitationsQuery 待接受邀请列表查询
+type ListInvitationsQuery struct {
+	db_query.Page
+	Username string
+	Email    string
+}
+
+// PreviewInvitationQuery 邀请令牌预览查询
+type PreviewInvitationQuery struct {
+	Token string `query:"token"`
+}
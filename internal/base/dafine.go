@@ -1,9 +1,11 @@
 package base
 
 import (
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/cleaner"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/handlers"
 	baserest "github.com/ares-cloud/ares-ddd-admin/internal/base/interfaces/rest"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/token"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
 	"github.com/cloudwego/hertz/pkg/route"
 )
 
@@ -16,8 +18,15 @@ type BaseServer struct {
 	lls          *baserest.LoginLogController
 	ols          *baserest.OperationLogController
 	des          *baserest.DepartmentController
+	pos          *baserest.PositionController
 	dps          *baserest.DataPermissionController
+	scs          *baserest.ScimController
+	prs          *baserest.ProfileController
+	ars          *baserest.AccessReviewController
 	handlerEvent *handlers.HandlerEvent
+	cleaner      *cleaner.RecycleCleaner
+	expirer      *cleaner.RoleGrantExpirer
+	checkpoint   *cleaner.LogCheckpointExporter
 }
 
 func NewBaseServer(
@@ -29,10 +38,17 @@ func NewBaseServer(
 	lls *baserest.LoginLogController,
 	ols *baserest.OperationLogController,
 	des *baserest.DepartmentController,
+	pos *baserest.PositionController,
 	dps *baserest.DataPermissionController,
+	scs *baserest.ScimController,
+	prs *baserest.ProfileController,
+	ars *baserest.AccessReviewController,
 	handlerEvent *handlers.HandlerEvent,
-) *BaseServer {
-	return &BaseServer{
+	cleaner *cleaner.RecycleCleaner,
+	expirer *cleaner.RoleGrantExpirer,
+	checkpoint *cleaner.LogCheckpointExporter,
+) (*BaseServer, func(), error) {
+	s := &BaseServer{
 		rc:           rc,
 		uc:           uc,
 		ts:           ts,
@@ -41,9 +57,25 @@ func NewBaseServer(
 		lls:          lls,
 		ols:          ols,
 		des:          des,
+		pos:          pos,
 		dps:          dps,
+		scs:          scs,
+		prs:          prs,
+		ars:          ars,
 		handlerEvent: handlerEvent,
+		cleaner:      cleaner,
+		expirer:      expirer,
+		checkpoint:   checkpoint,
 	}
+	cleanup := func() {
+		hlog.Info("stopping the recycle bin cleaner")
+		s.cleaner.Stop()
+		hlog.Info("stopping the role grant expirer")
+		s.expirer.Stop()
+		hlog.Info("stopping the log chain checkpoint exporter")
+		s.checkpoint.Stop()
+	}
+	return s, cleanup, nil
 }
 
 func (s *BaseServer) Init(rg *route.RouterGroup, tk token.IToken) {
@@ -55,6 +87,13 @@ func (s *BaseServer) Init(rg *route.RouterGroup, tk token.IToken) {
 	s.lls.RegisterRouter(rg, tk)
 	s.ols.RegisterRouter(rg, tk)
 	s.des.RegisterRouter(rg, tk)
+	s.pos.RegisterRouter(rg, tk)
 	s.dps.RegisterRouter(rg, tk)
+	s.scs.RegisterRouter(rg, tk)
+	s.prs.RegisterRouter(rg, tk)
+	s.ars.RegisterRouter(rg, tk)
 	s.handlerEvent.Register()
+	s.cleaner.Start()    // 启动回收站清理任务
+	s.expirer.Start(tk)  // 启动限时角色到期任务
+	s.checkpoint.Start() // 启动日志哈希链检查点导出任务
 }
@@ -0,0 +1,53 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+const (
+	ReasonAccessReviewNotFound     = "ACCESS_REVIEW_NOT_FOUND"
+	ReasonAccessReviewInvalid      = "ACCESS_REVIEW_INVALID"
+	ReasonAccessReviewClosed       = "ACCESS_REVIEW_CLOSED"
+	ReasonAccessReviewEmpty        = "ACCESS_REVIEW_EMPTY"
+	ReasonAccessReviewItemNotFound = "ACCESS_REVIEW_ITEM_NOT_FOUND"
+	ReasonAccessReviewNotReviewer  = "ACCESS_REVIEW_NOT_REVIEWER"
+)
+
+// AccessReviewNotFound 复核活动不存在
+func AccessReviewNotFound(id string) herrors.Herr {
+	return herrors.New(http.StatusNotFound, ReasonAccessReviewNotFound,
+		fmt.Sprintf("access review campaign not found: %s", id))
+}
+
+// AccessReviewInvalid 复核活动参数无效
+func AccessReviewInvalid(reason string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonAccessReviewInvalid,
+		fmt.Sprintf("invalid access review: %s", reason))
+}
+
+// AccessReviewClosed 复核活动已关闭
+func AccessReviewClosed(id string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonAccessReviewClosed,
+		fmt.Sprintf("access review campaign already closed: %s", id))
+}
+
+// AccessReviewEmpty 范围内没有可复核的授权
+func AccessReviewEmpty() herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonAccessReviewEmpty,
+		"no grants found in the access review scope")
+}
+
+// AccessReviewItemNotFound 复核项不存在
+func AccessReviewItemNotFound(id string) herrors.Herr {
+	return herrors.New(http.StatusNotFound, ReasonAccessReviewItemNotFound,
+		fmt.Sprintf("access review item not found: %s", id))
+}
+
+// AccessReviewNotReviewer 只有分配的复核人可以复核
+func AccessReviewNotReviewer(id string) herrors.Herr {
+	return herrors.New(http.StatusForbidden, ReasonAccessReviewNotReviewer,
+		fmt.Sprintf("access review item %s is assigned to another reviewer", id))
+}
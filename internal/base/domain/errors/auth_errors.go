@@ -7,4 +7,5 @@ var (
 	ErrPasswordMismatch   = New("password mismatch")
 	ErrTokenInvalid       = New("invalid token")
 	ErrTokenExpired       = New("token expired")
+	ErrTenantRequired     = New("username exists in multiple tenants, please login with tenant")
 )
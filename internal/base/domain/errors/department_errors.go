@@ -73,6 +73,12 @@ func HasChildDepartment(id string) herrors.Herr {
 		fmt.Errorf("department has children: %s", id))
 }
 
+// DepartmentCycle 不能移动到自身或下级部门下
+func DepartmentCycle(id, parentID string) herrors.Herr {
+	return herrors.NewBadRequestHError(ReasonParentDepartmentInvalid,
+		fmt.Errorf("department %s cannot be moved under itself or its descendant %s", id, parentID))
+}
+
 // DepartmentInvalidOperation 部门操作无效
 func DepartmentInvalidOperation(reason string) herrors.Herr {
 	return herrors.NewBadRequestHError(ReasonDepartmentInvalidOp,
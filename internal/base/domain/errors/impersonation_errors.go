@@ -0,0 +1,32 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+const (
+	ReasonImpersonationNotAllowed = "IMPERSONATION_NOT_ALLOWED"
+	ReasonImpersonationInvalid    = "IMPERSONATION_INVALID"
+	ReasonImpersonationNotFound   = "IMPERSONATION_NOT_FOUND"
+)
+
+// ImpersonationNotAllowed 不允许模拟该用户
+func ImpersonationNotAllowed(reason string) herrors.Herr {
+	return herrors.New(http.StatusForbidden, ReasonImpersonationNotAllowed,
+		fmt.Sprintf("impersonation not allowed: %s", reason))
+}
+
+// ImpersonationInvalid 模拟登录参数无效
+func ImpersonationInvalid(reason string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonImpersonationInvalid,
+		fmt.Sprintf("invalid impersonation: %s", reason))
+}
+
+// ImpersonationNotFound 模拟登录会话不存在
+func ImpersonationNotFound(id string) herrors.Herr {
+	return herrors.New(http.StatusNotFound, ReasonImpersonationNotFound,
+		fmt.Sprintf("impersonation session not found: %s", id))
+}
@@ -0,0 +1,60 @@
+package errors
+
+import (
+	"fmt"
+
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+// 岗位错误码定义
+const (
+	ReasonPositionNotFound       = "POSITION_NOT_FOUND"
+	ReasonPositionExists         = "POSITION_EXISTS"
+	ReasonPositionInvalid        = "POSITION_INVALID"
+	ReasonPositionStatusInvalid  = "POSITION_STATUS_INVALID"
+	ReasonPositionDisabled       = "POSITION_DISABLED"
+	ReasonPositionInUse          = "POSITION_IN_USE"
+	ReasonPrimaryPositionInvalid = "PRIMARY_POSITION_INVALID"
+)
+
+// PositionNotFound 岗位不存在
+func PositionNotFound(id string) herrors.Herr {
+	return herrors.NewNotFoundHError(ReasonPositionNotFound,
+		fmt.Errorf("position not found: %s", id))
+}
+
+// PositionExists 岗位已存在
+func PositionExists(code string) herrors.Herr {
+	return herrors.NewBadRequestHError(ReasonPositionExists,
+		fmt.Errorf("position already exists: %s", code))
+}
+
+// PositionInvalidField 岗位字段无效
+func PositionInvalidField(field, reason string) herrors.Herr {
+	return herrors.NewBadRequestHError(ReasonPositionInvalid,
+		fmt.Errorf("invalid position field %s: %s", field, reason))
+}
+
+// PositionStatusInvalid 岗位状态无效
+func PositionStatusInvalid(status int8) herrors.Herr {
+	return herrors.NewBadRequestHError(ReasonPositionStatusInvalid,
+		fmt.Errorf("invalid position status: %d", status))
+}
+
+// PositionDisabled 岗位已停用
+func PositionDisabled(id string) herrors.Herr {
+	return herrors.NewBadRequestHError(ReasonPositionDisabled,
+		fmt.Errorf("position is disabled: %s", id))
+}
+
+// PositionInUse 岗位仍有任职人员
+func PositionInUse(id string) herrors.Herr {
+	return herrors.NewBadRequestHError(ReasonPositionInUse,
+		fmt.Errorf("position still has users: %s", id))
+}
+
+// PrimaryPositionInvalid 主岗不在任职岗位中
+func PrimaryPositionInvalid(id string) herrors.Herr {
+	return herrors.NewBadRequestHError(ReasonPrimaryPositionInvalid,
+		fmt.Errorf("primary position must be one of the assigned positions: %s", id))
+}
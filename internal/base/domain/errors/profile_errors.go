@@ -0,0 +1,46 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+const (
+	ReasonVerifyCodeInvalid  = "PROFILE_VERIFY_CODE_INVALID"
+	ReasonVerifyCodeFrequent = "PROFILE_VERIFY_CODE_FREQUENT"
+	ReasonContactUnchanged   = "PROFILE_CONTACT_UNCHANGED"
+	ReasonAvatarInvalid      = "PROFILE_AVATAR_INVALID"
+	ReasonOldPasswordInvalid = "PROFILE_OLD_PASSWORD_INVALID"
+)
+
+// VerifyCodeInvalid 验证码错误或已过期
+func VerifyCodeInvalid() herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonVerifyCodeInvalid,
+		"verify code is invalid or expired")
+}
+
+// VerifyCodeFrequent 验证码发送过于频繁
+func VerifyCodeFrequent(interval int) herrors.Herr {
+	return herrors.New(http.StatusTooManyRequests, ReasonVerifyCodeFrequent,
+		fmt.Sprintf("verify code sent too frequently, retry after %d seconds", interval))
+}
+
+// ContactUnchanged 新的邮箱或手机号与当前相同
+func ContactUnchanged(contactType string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonContactUnchanged,
+		fmt.Sprintf("new %s is the same as current", contactType))
+}
+
+// AvatarInvalid 头像文件不合法
+func AvatarInvalid(reason string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonAvatarInvalid,
+		fmt.Sprintf("invalid avatar: %s", reason))
+}
+
+// OldPasswordInvalid 原密码错误
+func OldPasswordInvalid() herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonOldPasswordInvalid,
+		"old password is incorrect")
+}
@@ -0,0 +1,18 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+const (
+	ReasonRecycleItemNotFound = "RECYCLE_ITEM_NOT_FOUND"
+)
+
+// RecycleItemNotFound 回收站中不存在该记录
+func RecycleItemNotFound(entityType, id string) herrors.Herr {
+	return herrors.New(http.StatusNotFound, ReasonRecycleItemNotFound,
+		fmt.Sprintf("%s not found in recycle bin: %s", entityType, id))
+}
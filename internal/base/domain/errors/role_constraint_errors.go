@@ -0,0 +1,39 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+const (
+	ReasonRoleConstraintNotFound  = "ROLE_CONSTRAINT_NOT_FOUND"
+	ReasonRoleConstraintInvalid   = "ROLE_CONSTRAINT_INVALID"
+	ReasonRoleMutuallyExclusive   = "ROLE_MUTUALLY_EXCLUSIVE"
+	ReasonRoleCardinalityExceeded = "ROLE_CARDINALITY_EXCEEDED"
+)
+
+// RoleConstraintNotFound 约束不存在
+func RoleConstraintNotFound(id int64) herrors.Herr {
+	return herrors.New(http.StatusNotFound, ReasonRoleConstraintNotFound,
+		fmt.Sprintf("role constraint not found: %d", id))
+}
+
+// RoleConstraintInvalid 约束参数无效
+func RoleConstraintInvalid(reason string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonRoleConstraintInvalid,
+		fmt.Sprintf("invalid role constraint: %s", reason))
+}
+
+// RoleMutuallyExclusive 用户持有的互斥角色超过上限
+func RoleMutuallyExclusive(name, userID string, roleIDs []int64, limit int) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonRoleMutuallyExclusive,
+		fmt.Sprintf("user %s cannot hold roles %v together, constraint %s allows at most %d", userID, roleIDs, name, limit))
+}
+
+// RoleCardinalityExceeded 持有角色的用户数超过上限
+func RoleCardinalityExceeded(name string, holders, limit int) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonRoleCardinalityExceeded,
+		fmt.Sprintf("constraint %s allows at most %d users, got %d", name, limit, holders))
+}
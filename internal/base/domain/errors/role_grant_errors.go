@@ -0,0 +1,53 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+const (
+	ReasonRoleGrantInvalid      = "ROLE_GRANT_INVALID"
+	ReasonRoleRequestNotFound   = "ROLE_REQUEST_NOT_FOUND"
+	ReasonRoleRequestExists     = "ROLE_REQUEST_EXISTS"
+	ReasonRoleRequestReviewed   = "ROLE_REQUEST_REVIEWED"
+	ReasonRoleRequestSelfReview = "ROLE_REQUEST_SELF_REVIEW"
+	ReasonRoleRequestExpired    = "ROLE_REQUEST_EXPIRED"
+)
+
+// RoleGrantInvalid 角色授予参数无效
+func RoleGrantInvalid(reason string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonRoleGrantInvalid,
+		fmt.Sprintf("invalid role grant: %s", reason))
+}
+
+// RoleRequestNotFound 角色申请不存在
+func RoleRequestNotFound(id string) herrors.Herr {
+	return herrors.New(http.StatusNotFound, ReasonRoleRequestNotFound,
+		fmt.Sprintf("role request not found: %s", id))
+}
+
+// RoleRequestExists 已有待审批的相同申请
+func RoleRequestExists(userID string, roleID int64) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonRoleRequestExists,
+		fmt.Sprintf("role %d for user %s is already pending approval", roleID, userID))
+}
+
+// RoleRequestReviewed 申请已审批
+func RoleRequestReviewed(id string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonRoleRequestReviewed,
+		fmt.Sprintf("role request already reviewed: %s", id))
+}
+
+// RoleRequestSelfReview 申请人或被授予人不能审批自己的申请
+func RoleRequestSelfReview(id string) herrors.Herr {
+	return herrors.New(http.StatusForbidden, ReasonRoleRequestSelfReview,
+		fmt.Sprintf("role request %s must be reviewed by another user", id))
+}
+
+// RoleRequestExpired 申请的有效期已过
+func RoleRequestExpired(id string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonRoleRequestExpired,
+		fmt.Sprintf("role request expired: %s", id))
+}
@@ -0,0 +1,25 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+const (
+	ReasonScimTokenInvalid  = "SCIM_TOKEN_INVALID"
+	ReasonScimTokenNotFound = "SCIM_TOKEN_NOT_FOUND"
+)
+
+// ScimTokenInvalid SCIM 令牌无效或已过期
+func ScimTokenInvalid(reason string) herrors.Herr {
+	return herrors.New(http.StatusUnauthorized, ReasonScimTokenInvalid,
+		fmt.Sprintf("invalid scim token: %s", reason))
+}
+
+// ScimTokenNotFound SCIM 令牌不存在
+func ScimTokenNotFound(id string) herrors.Herr {
+	return herrors.New(http.StatusNotFound, ReasonScimTokenNotFound,
+		fmt.Sprintf("scim token not found: %s", id))
+}
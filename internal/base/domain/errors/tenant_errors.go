@@ -18,7 +18,14 @@ const (
 	ReasonTenantDisabled      = "TENANT_DISABLED"
 	ReasonTenantAdminInvalid  = "TENANT_ADMIN_INVALID"
 	ReasonTenantDomainInvalid = "TENANT_DOMAIN_INVALID"
+	ReasonTenantDomainExists  = "TENANT_DOMAIN_EXISTS"
 	ReasonTenantQuotaExceeded = "TENANT_QUOTA_EXCEEDED"
+	ReasonTenantJobRunning    = "TENANT_JOB_RUNNING"
+	ReasonTenantJobNotFound   = "TENANT_JOB_NOT_FOUND"
+	ReasonTenantNotLocked     = "TENANT_NOT_LOCKED"
+	ReasonTemplateNotFound    = "TENANT_TEMPLATE_NOT_FOUND"
+	ReasonTemplateNameExists  = "TENANT_TEMPLATE_NAME_EXISTS"
+	ReasonTemplateInvalid     = "TENANT_TEMPLATE_INVALID"
 )
 
 // TenantNotFound 租户不存在
@@ -81,8 +88,50 @@ func TenantDomainInvalid(domain, reason string) herrors.Herr {
 		fmt.Sprintf("invalid tenant domain %s: %s", domain, reason))
 }
 
+// TenantDomainExists 域名已被其他租户使用
+func TenantDomainExists(domain string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonTenantDomainExists,
+		fmt.Sprintf("tenant domain already exists: %s", domain))
+}
+
 // TenantQuotaExceeded 超出配额限制
 func TenantQuotaExceeded(resource string, limit int64) herrors.Herr {
 	return herrors.New(http.StatusForbidden, ReasonTenantQuotaExceeded,
 		fmt.Sprintf("tenant quota exceeded for %s, limit: %d", resource, limit))
 }
+
+// TenantJobRunning 租户已有未结束的导出/清除任务
+func TenantJobRunning(tenantID string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonTenantJobRunning,
+		fmt.Sprintf("tenant %s has an unfinished job", tenantID))
+}
+
+// TenantJobNotFound 租户任务不存在
+func TenantJobNotFound(id string) herrors.Herr {
+	return herrors.New(http.StatusNotFound, ReasonTenantJobNotFound,
+		fmt.Sprintf("tenant job not found: %s", id))
+}
+
+// TenantNotLocked 租户未锁定, 清除数据前必须先锁定租户
+func TenantNotLocked(tenantID string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonTenantNotLocked,
+		fmt.Sprintf("tenant %s must be locked before purge", tenantID))
+}
+
+// TenantTemplateNotFound 租户模板不存在
+func TenantTemplateNotFound(id string) herrors.Herr {
+	return herrors.New(http.StatusNotFound, ReasonTemplateNotFound,
+		fmt.Sprintf("tenant template not found: %s", id))
+}
+
+// TenantTemplateNameExists 租户模板名称已存在
+func TenantTemplateNameExists(name string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonTemplateNameExists,
+		fmt.Sprintf("tenant template name already exists: %s", name))
+}
+
+// TenantTemplateInvalid 租户模板内容无效
+func TenantTemplateInvalid(reason string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonTemplateInvalid,
+		fmt.Sprintf("invalid tenant template: %s", reason))
+}
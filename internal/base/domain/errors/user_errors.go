@@ -13,8 +13,19 @@ const (
 	ReasonUserInvalid       = "USER_INVALID"
 	ReasonUserStatusInvalid = "USER_STATUS_INVALID"
 	ReasonUserDisabled      = "USER_DISABLED"
+	ReasonUserAlreadyMember = "USER_ALREADY_MEMBER"
+	ReasonUserNotMember     = "USER_NOT_MEMBER"
+	ReasonUserNotActivated  = "USER_NOT_ACTIVATED"
+	ReasonUserNotPending    = "USER_NOT_PENDING"
+	ReasonInvitationInvalid = "USER_INVITATION_INVALID"
+	ReasonUserNotHomeTenant = "USER_NOT_HOME_TENANT"
+	ReasonMemberInvited     = "USER_MEMBER_INVITED"
+	ReasonMemberNotInvited  = "USER_MEMBER_NOT_INVITED"
 )
 
+// ErrNotHomeTenant 在非归属租户下修改或删除用户
+var ErrNotHomeTenant = New("user can only be modified in its home tenant")
+
 // UserNotFound 用户不存在
 func UserNotFound(id string) herrors.Herr {
 	return herrors.New(http.StatusNotFound, ReasonUserNotFound,
@@ -56,3 +67,51 @@ func UserDepartmentNotFound(userID string, deptID string) herrors.Herr {
 	return herrors.New(http.StatusNotFound, ReasonUserNotFound,
 		fmt.Sprintf("user[%s] does not belong to department[%s]", userID, deptID))
 }
+
+// UserAlreadyMember 用户已属于该租户
+func UserAlreadyMember(userID, tenantID string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonUserAlreadyMember,
+		fmt.Sprintf("user %s already belongs to tenant %s", userID, tenantID))
+}
+
+// UserNotMember 用户不属于该租户
+func UserNotMember(userID, tenantID string) herrors.Herr {
+	return herrors.New(http.StatusForbidden, ReasonUserNotMember,
+		fmt.Sprintf("user %s is not a member of tenant %s", userID, tenantID))
+}
+
+// UserNotActivated 用户尚未接受邀请
+func UserNotActivated(userID string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonUserNotActivated,
+		fmt.Sprintf("user %s has not accepted the invitation", userID))
+}
+
+// UserNotPending 用户不是待激活状态
+func UserNotPending(userID string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonUserNotPending,
+		fmt.Sprintf("user %s is not a pending invitation", userID))
+}
+
+// UserInvitationInvalid 邀请无效
+func UserInvitationInvalid(reason string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonInvitationInvalid,
+		fmt.Sprintf("invalid invitation: %s", reason))
+}
+
+// UserNotHomeTenant 其他租户的成员只能在归属租户下修改
+func UserNotHomeTenant(userID string) herrors.Herr {
+	return herrors.New(http.StatusForbidden, ReasonUserNotHomeTenant,
+		fmt.Sprintf("user %s can only be modified in its home tenant", userID))
+}
+
+// TenantMemberInvited 已邀请用户加入该租户, 等待用户接受
+func TenantMemberInvited(userID, tenantID string) herrors.Herr {
+	return herrors.New(http.StatusBadRequest, ReasonMemberInvited,
+		fmt.Sprintf("user %s has already been invited to tenant %s", userID, tenantID))
+}
+
+// TenantMemberNotInvited 没有待接受的租户邀请
+func TenantMemberNotInvited(userID, tenantID string) herrors.Herr {
+	return herrors.New(http.StatusNotFound, ReasonMemberNotInvited,
+		fmt.Sprintf("user %s has no pending invitation to tenant %s", userID, tenantID))
+}
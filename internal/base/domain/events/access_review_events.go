@@ -0,0 +1,31 @@
+package events
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/pkg/events"
+)
+
+// 权限复核事件类型定义
+const (
+	AccessReviewStarted = "access_review.started"
+	AccessReviewClosed  = "access_review.closed"
+)
+
+// AccessReviewEvent 权限复核事件, 开始时由订阅方通知复核人
+type AccessReviewEvent struct {
+	events.BaseEvent
+	TenantID    string   `json:"tenant_id"`
+	CampaignID  string   `json:"campaign_id"`
+	ReviewerIDs []string `json:"reviewer_ids"`
+	Revoked     int      `json:"revoked"` // 关闭时实际收回的授权数
+}
+
+// NewAccessReviewEvent 创建权限复核事件
+func NewAccessReviewEvent(tenantID, campaignID string, reviewerIDs []string, revoked int, eventName string) *AccessReviewEvent {
+	return &AccessReviewEvent{
+		BaseEvent:   events.NewBaseEvent(eventName),
+		TenantID:    tenantID,
+		CampaignID:  campaignID,
+		ReviewerIDs: reviewerIDs,
+		Revoked:     revoked,
+	}
+}
@@ -6,13 +6,15 @@ import (
 
 // 部门事件类型定义
 const (
-	DepartmentCreated = "department.created"
-	DepartmentUpdated = "department.updated"
-	DepartmentDeleted = "department.deleted"
-	DepartmentMoved   = "department.moved"
-	UserAssigned      = "department.user.assigned"
-	UserRemoved       = "department.user.removed"
-	UserTransferred   = "department.user.transferred"
+	DepartmentCreated      = "department.created"
+	DepartmentUpdated      = "department.updated"
+	DepartmentDeleted      = "department.deleted"
+	DepartmentMoved        = "department.moved"
+	DepartmentMerged       = "department.merged"
+	UserAssigned           = "department.user.assigned"
+	UserRemoved            = "department.user.removed"
+	UserTransferred        = "department.user.transferred"
+	UserPrimaryDeptChanged = "department.user.primary_changed"
 )
 
 // DepartmentEvent 部门事件基类
@@ -47,6 +49,26 @@ func NewDepartmentMovedEvent(tenantID string, deptID string, fromParentID string
 	}
 }
 
+// DepartmentMergedEvent 部门合并事件, DeptID 为目标部门
+type DepartmentMergedEvent struct {
+	DepartmentEvent
+	SourceDeptID string   `json:"source_dept_id"`
+	UserIDs      []string `json:"user_ids"`
+	ChildIDs     []string `json:"child_ids"`
+	RoleIDs      []int64  `json:"role_ids"`
+}
+
+// NewDepartmentMergedEvent 创建部门合并事件
+func NewDepartmentMergedEvent(tenantID, sourceDeptID, targetDeptID string, userIDs, childIDs []string, roleIDs []int64) *DepartmentMergedEvent {
+	return &DepartmentMergedEvent{
+		DepartmentEvent: *NewDepartmentEvent(tenantID, targetDeptID, DepartmentMerged),
+		SourceDeptID:    sourceDeptID,
+		UserIDs:         userIDs,
+		ChildIDs:        childIDs,
+		RoleIDs:         roleIDs,
+	}
+}
+
 // UserAssignedEvent 用户分配事件
 type UserAssignedEvent struct {
 	DepartmentEvent
@@ -92,3 +114,19 @@ func NewUserTransferredEvent(tenantID, userID, fromDeptID, toDeptID string) *Use
 		ToDeptID:        toDeptID,
 	}
 }
+
+// UserPrimaryDeptChangedEvent 用户主部门变更事件
+type UserPrimaryDeptChangedEvent struct {
+	DepartmentEvent
+	UserID     string `json:"user_id"`
+	FromDeptID string `json:"from_dept_id"`
+}
+
+// NewUserPrimaryDeptChangedEvent 创建用户主部门变更事件
+func NewUserPrimaryDeptChangedEvent(tenantID, userID, fromDeptID, toDeptID string) *UserPrimaryDeptChangedEvent {
+	return &UserPrimaryDeptChangedEvent{
+		DepartmentEvent: *NewDepartmentEvent(tenantID, toDeptID, UserPrimaryDeptChanged),
+		UserID:          userID,
+		FromDeptID:      fromDeptID,
+	}
+}
@@ -0,0 +1,47 @@
+package events
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/pkg/events"
+)
+
+// 岗位事件类型定义
+const (
+	PositionCreated     = "position.created"
+	PositionUpdated     = "position.updated"
+	PositionDeleted     = "position.deleted"
+	UserPositionChanged = "position.user.changed"
+)
+
+// PositionEvent 岗位事件
+type PositionEvent struct {
+	events.BaseEvent
+	TenantID   string `json:"tenant_id"`
+	PositionID string `json:"position_id"`
+}
+
+// NewPositionEvent 创建岗位事件
+func NewPositionEvent(tenantID, positionID string, eventName string) *PositionEvent {
+	return &PositionEvent{
+		BaseEvent:  events.NewBaseEvent(eventName),
+		TenantID:   tenantID,
+		PositionID: positionID,
+	}
+}
+
+// UserPositionChangedEvent 用户任职岗位变更事件
+type UserPositionChangedEvent struct {
+	events.BaseEvent
+	TenantID    string   `json:"tenant_id"`
+	UserID      string   `json:"user_id"`
+	PositionIDs []string `json:"position_ids"` // 变更前后涉及的岗位
+}
+
+// NewUserPositionChangedEvent 创建用户任职岗位变更事件
+func NewUserPositionChangedEvent(tenantID, userID string, positionIDs []string) *UserPositionChangedEvent {
+	return &UserPositionChangedEvent{
+		BaseEvent:   events.NewBaseEvent(UserPositionChanged),
+		TenantID:    tenantID,
+		UserID:      userID,
+		PositionIDs: positionIDs,
+	}
+}
@@ -9,6 +9,10 @@ const (
 	RoleUpdated            = "role.updated"
 	RoleDeleted            = "role.deleted"
 	RolePermissionsChanged = "role.permissions.changed"
+
+	RoleRequestCreated  = "role.request.created"
+	RoleRequestApproved = "role.request.approved"
+	RoleRequestRejected = "role.request.rejected"
 )
 
 // RoleEvent 角色事件基类
@@ -33,9 +37,29 @@ type RolePermissionsAssignedEvent struct {
 	PermissionIDs []int64 `json:"permission_ids"`
 }
 
-func NewRolePermissionsAssignedEvent(roleID int64, permissionIDs []int64) *RolePermissionsAssignedEvent {
+func NewRolePermissionsAssignedEvent(tenantID string, roleID int64, permissionIDs []int64) *RolePermissionsAssignedEvent {
 	return &RolePermissionsAssignedEvent{
-		RoleEvent:     NewRoleEvent("", roleID, RolePermissionsChanged),
+		RoleEvent:     NewRoleEvent(tenantID, roleID, RolePermissionsChanged),
 		PermissionIDs: permissionIDs,
 	}
 }
+
+// RoleRequestEvent 角色申请事件, 创建时由订阅方通知审批人, 审批后通知申请人
+type RoleRequestEvent struct {
+	*RoleEvent
+	RequestID   string `json:"request_id"`
+	UserID      string `json:"user_id"`
+	RequestedBy string `json:"requested_by"`
+	ReviewedBy  string `json:"reviewed_by"`
+}
+
+// NewRoleRequestEvent 创建角色申请事件
+func NewRoleRequestEvent(tenantID string, roleID int64, requestID, userID, requestedBy, reviewedBy, eventType string) *RoleRequestEvent {
+	return &RoleRequestEvent{
+		RoleEvent:   NewRoleEvent(tenantID, roleID, eventType),
+		RequestID:   requestID,
+		UserID:      userID,
+		RequestedBy: requestedBy,
+		ReviewedBy:  reviewedBy,
+	}
+}
@@ -10,6 +10,7 @@ const (
 	TenantDeleted  = "tenant.deleted"
 	TenantLocked   = "tenant.locked"
 	TenantUnlocked = "tenant.unlocked"
+	TenantJobAdded = "tenant.job.added"
 )
 
 // TenantEvent 租户事件基类
@@ -38,3 +39,18 @@ func NewTenantPermissionEvent(tenantID string, permissionIDs []int64) *TenantPer
 		PermissionIDs: permissionIDs,
 	}
 }
+
+// TenantJobEvent 租户下线任务事件
+type TenantJobEvent struct {
+	*TenantEvent
+	JobID   string `json:"job_id"`
+	JobType string `json:"job_type"`
+}
+
+func NewTenantJobEvent(tenantID, jobID, jobType string) *TenantJobEvent {
+	return &TenantJobEvent{
+		TenantEvent: NewTenantEvent(tenantID, TenantJobAdded),
+		JobID:       jobID,
+		JobType:     jobType,
+	}
+}
@@ -11,6 +11,16 @@ const (
 	UserDeleted     = "user.deleted"
 	UserRoleChanged = "user.role.changed"
 	UserLoggedIn    = "user.logged_in"
+	UserJoinTenant  = "user.tenant.joined"
+	UserLeaveTenant = "user.tenant.left"
+
+	UserInvited            = "user.invitation.sent"
+	UserInvitationResent   = "user.invitation.resent"
+	UserInvitationRevoked  = "user.invitation.revoked"
+	UserInvitationAccepted = "user.invitation.accepted"
+
+	UserImpersonationStarted = "user.impersonation.started"
+	UserImpersonationEnded   = "user.impersonation.ended"
 )
 
 // UserEvent 用户事件
@@ -28,3 +38,39 @@ func NewUserEvent(tenantID, userID string, eventName string) *UserEvent {
 		UserID:    userID,
 	}
 }
+
+// UserInvitationEvent 用户邀请事件, 发送和重新发送时带有邀请链接, 由订阅方投递给被邀请人
+type UserInvitationEvent struct {
+	UserEvent
+	Email    string `json:"email"`     // 被邀请人邮箱
+	Link     string `json:"link"`      // 邀请链接
+	ExpireAt int64  `json:"expire_at"` // 过期时间
+}
+
+// NewUserInvitationEvent 创建用户邀请事件
+func NewUserInvitationEvent(tenantID, userID, email, link string, expireAt int64, eventName string) *UserInvitationEvent {
+	return &UserInvitationEvent{
+		UserEvent: *NewUserEvent(tenantID, userID, eventName),
+		Email:     email,
+		Link:      link,
+		ExpireAt:  expireAt,
+	}
+}
+
+// UserImpersonationEvent 模拟登录事件, UserID 为被模拟的用户
+type UserImpersonationEvent struct {
+	UserEvent
+	SessionID string `json:"session_id"` // 模拟登录会话ID
+	ActorID   string `json:"actor_id"`   // 实际操作的管理员
+	Reason    string `json:"reason"`     // 模拟原因
+}
+
+// NewUserImpersonationEvent 创建模拟登录事件
+func NewUserImpersonationEvent(tenantID, userID, sessionID, actorID, reason string, eventName string) *UserImpersonationEvent {
+	return &UserImpersonationEvent{
+		UserEvent: *NewUserEvent(tenantID, userID, eventName),
+		SessionID: sessionID,
+		ActorID:   actorID,
+		Reason:    reason,
+	}
+}
@@ -8,8 +8,9 @@ import (
 )
 
 var (
-	ErrInvalidPassword = herrors.NewBadReqError("PasswordError")
-	ErrInvalidCaptcha  = herrors.NewBadReqError("IncorrectVerificationCode")
+	ErrInvalidPassword  = herrors.NewBadReqError("PasswordError")
+	ErrInvalidCaptcha   = herrors.NewBadReqError("IncorrectVerificationCode")
+	ErrUserNotActivated = herrors.NewBadReqError("UserNotActivated")
 )
 
 // Auth 认证领域模型
@@ -35,6 +36,9 @@ func (a *Auth) Login(plainPwd string, captchaValid bool) herrors.Herr {
 	if !captchaValid {
 		return ErrInvalidCaptcha
 	}
+	if a.User.IsPending() {
+		return ErrUserNotActivated
+	}
 	if !password.CheckPasswordHash(plainPwd, a.User.Password) {
 		return ErrInvalidPassword
 	}
@@ -13,17 +13,19 @@ const (
 	DataScopeDept     DataScope = 3 // 本部门数据
 	DataScopeCustom   DataScope = 4 // 自定义部门数据
 	DataScopeSelf     DataScope = 5 // 仅本人数据
+	DataScopePosition DataScope = 6 // 指定岗位数据
 )
 
 // DataPermission 数据权限领域模型
 type DataPermission struct {
-	ID        string    `json:"id"`
-	RoleID    int64     `json:"role_id"`   // 角色ID
-	Scope     DataScope `json:"scope"`     // 数据范围
-	DeptIDs   []string  `json:"dept_ids"`  // 部门ID列表(自定义数据权限时使用)
-	TenantID  string    `json:"tenant_id"` // 租户ID
-	CreatedAt int64     `json:"created_at"`
-	UpdatedAt int64     `json:"updated_at"`
+	ID          string    `json:"id"`
+	RoleID      int64     `json:"role_id"`      // 角色ID
+	Scope       DataScope `json:"scope"`        // 数据范围
+	DeptIDs     []string  `json:"dept_ids"`     // 部门ID列表(自定义数据权限时使用)
+	PositionIDs []string  `json:"position_ids"` // 岗位ID列表(岗位数据权限时使用)
+	TenantID    string    `json:"tenant_id"`    // 租户ID
+	CreatedAt   int64     `json:"created_at"`
+	UpdatedAt   int64     `json:"updated_at"`
 }
 
 // Validate 验证数据权限
@@ -31,12 +33,15 @@ func (d *DataPermission) Validate() error {
 	if d.RoleID <= 0 {
 		return fmt.Errorf("角色ID不能为空")
 	}
-	if d.Scope < DataScopeAll || d.Scope > DataScopeSelf {
+	if d.Scope < DataScopeAll || d.Scope > DataScopePosition {
 		return fmt.Errorf("无效的数据范围")
 	}
 	if d.Scope == DataScopeCustom && len(d.DeptIDs) == 0 {
 		return fmt.Errorf("自定义数据权限必须指定部门")
 	}
+	if d.Scope == DataScopePosition && len(d.PositionIDs) == 0 {
+		return fmt.Errorf("岗位数据权限必须指定岗位")
+	}
 	return nil
 }
 
@@ -65,3 +70,55 @@ func (p *DataPermission) HasDeptPermission(deptID string) bool {
 	}
 	return false
 }
+
+// IsPositionScope 是否为岗位数据范围
+func (p *DataPermission) IsPositionScope() bool {
+	return p.Scope == DataScopePosition
+}
+
+// HasPositionPermission 是否有指定岗位的数据权限
+func (p *DataPermission) HasPositionPermission(positionID string) bool {
+	if !p.IsPositionScope() {
+		return true
+	}
+	for _, id := range p.PositionIDs {
+		if id == positionID {
+			return true
+		}
+	}
+	return false
+}
+
+// UserDataScope 用户生效的数据范围, 多个角色的数据权限取并集
+type UserDataScope struct {
+	All         bool     `json:"all"`         // 全部数据
+	Self        bool     `json:"self"`        // 包含本人数据
+	DeptIDs     []string `json:"deptIds"`     // 可访问的部门
+	PositionIDs []string `json:"positionIds"` // 可访问的岗位
+}
+
+// AddDepts 添加可访问的部门, 重复的忽略
+func (s *UserDataScope) AddDepts(ids ...string) {
+	s.DeptIDs = appendUnique(s.DeptIDs, ids...)
+}
+
+// AddPositions 添加可访问的岗位, 重复的忽略
+func (s *UserDataScope) AddPositions(ids ...string) {
+	s.PositionIDs = appendUnique(s.PositionIDs, ids...)
+}
+
+func appendUnique(list []string, ids ...string) []string {
+	for _, id := range ids {
+		exists := false
+		for _, v := range list {
+			if v == id {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			list = append(list, id)
+		}
+	}
+	return list
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"strings"
+
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
 )
@@ -34,6 +36,18 @@ func NewDepartment(code string, name string, sequence int32) *Department {
 	}
 }
 
+// NewExternalDepartment 创建由外部身份源(如 SCIM)同步的部门, 未提供编码时生成随机编码
+func NewExternalDepartment(code string, name string) (*Department, herrors.Herr) {
+	if code == "" {
+		random, err := randomCode(6)
+		if err != nil {
+			return nil, herrors.NewServerHError(err)
+		}
+		code = "EXT" + strings.ToUpper(random)
+	}
+	return NewDepartment(code, name, 0), nil
+}
+
 // Validate 验证部门信息
 func (d *Department) Validate() herrors.Herr {
 	if d.Name == "" {
@@ -117,3 +131,17 @@ func (d *Department) HasChildren() bool {
 func (d *Department) IsAdmin(userID string) bool {
 	return d.AdminID == userID
 }
+
+// UserDepartment 用户所属部门
+type UserDepartment struct {
+	UserID    string
+	DeptID    string
+	IsPrimary bool // 是否主部门
+}
+
+// DepartmentMergeResult 部门合并结果, 记录从源部门转移到目标部门的内容
+type DepartmentMergeResult struct {
+	UserIDs  []string // 转移的用户
+	ChildIDs []string // 转移的直接子部门
+	RoleIDs  []int64  // 自定义数据范围引用了源部门的角色
+}
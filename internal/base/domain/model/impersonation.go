@@ -0,0 +1,74 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/validator"
+)
+
+const (
+	DefaultImpersonationMinutes = 30  // 默认模拟时长(分钟)
+	MaxImpersonationMinutes     = 240 // 最长模拟时长(分钟)
+)
+
+// ImpersonationSession 模拟登录会话, 管理员以用户身份访问系统, 期间的请求以管理员身份记录操作日志
+type ImpersonationSession struct {
+	ID        string
+	TenantID  string
+	ActorID   string // 实际操作的管理员
+	ActorName string
+	UserID    string // 被模拟的用户
+	Username  string
+	Reason    string
+	StartedAt int64
+	ExpiresAt int64
+	EndedAt   int64 // 主动结束时间, 0 表示未结束
+}
+
+// NewImpersonationSession 创建模拟登录会话, minutes 为 0 时使用默认时长
+func NewImpersonationSession(tenantID, actorID, actorName string, user *User, reason string, minutes int) (*ImpersonationSession, herrors.Herr) {
+	if user.ID == actorID {
+		return nil, errors.ImpersonationNotAllowed("cannot impersonate yourself")
+	}
+	if user.Status != UserStatusEnabled {
+		return nil, errors.ImpersonationNotAllowed("user is not active")
+	}
+	if !validator.ValidateRequired(reason) {
+		return nil, errors.ImpersonationInvalid("reason cannot be empty")
+	}
+	if minutes == 0 {
+		minutes = DefaultImpersonationMinutes
+	}
+	if minutes < 0 || minutes > MaxImpersonationMinutes {
+		return nil, errors.ImpersonationInvalid("duration out of range")
+	}
+	// 超级管理员不属于任何租户, 会话记录在用户归属的租户
+	if tenantID == "" {
+		tenantID = user.TenantID
+	}
+	now := time.Now().Unix()
+	return &ImpersonationSession{
+		TenantID:  tenantID,
+		ActorID:   actorID,
+		ActorName: actorName,
+		UserID:    user.ID,
+		Username:  user.Username,
+		Reason:    reason,
+		StartedAt: now,
+		ExpiresAt: now + int64(minutes)*60,
+	}, nil
+}
+
+// IsActive 会话是否仍然有效
+func (s *ImpersonationSession) IsActive() bool {
+	return s.EndedAt == 0 && time.Now().Unix() < s.ExpiresAt
+}
+
+// End 结束会话, 已结束的会话保持原结束时间
+func (s *ImpersonationSession) End() {
+	if s.EndedAt == 0 {
+		s.EndedAt = time.Now().Unix()
+	}
+}
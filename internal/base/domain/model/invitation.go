@@ -0,0 +1,106 @@
+package model
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/validator"
+)
+
+// Invitation 用户邀请, 邀请链接中的签名令牌由这些信息生成
+type Invitation struct {
+	TenantID string // 租户ID
+	UserID   string // 被邀请用户ID
+	Code     string // 邀请码, 与用户当前邀请码一致时有效
+	ExpireAt int64  // 过期时间
+}
+
+// NewInvitedUser 创建待激活的被邀请用户, 初始密码为随机值, 由被邀请人接受邀请时设置
+func NewInvitedUser(tenantID, username, email string) (*User, herrors.Herr) {
+	if !validator.ValidateRequired(email) {
+		return nil, errors.UserInvalidField("email", "cannot be empty")
+	}
+	pwd, err := randomCode(MaxPasswordLength / 2)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	user := NewUser(tenantID, username, pwd)
+	user.Email = email
+	user.Status = UserStatusPending
+	if hr := user.Validate(); hr != nil {
+		return nil, hr
+	}
+	if hr := user.HashPassword(); hr != nil {
+		return nil, hr
+	}
+	return user, nil
+}
+
+// IsPending 是否为待激活用户
+func (u *User) IsPending() bool {
+	return u.Status == UserStatusPending
+}
+
+// IssueInvitation 生成新的邀请码, 之前发出的邀请链接随之失效
+func (u *User) IssueInvitation(ttl time.Duration) (*Invitation, herrors.Herr) {
+	if !u.IsPending() {
+		return nil, errors.UserNotPending(u.ID)
+	}
+	code, err := randomCode(16)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	now := time.Now()
+	u.InvitationCode = code
+	u.InvitationExp = now.Add(ttl).Unix()
+	u.UpdatedAt = now.Unix()
+	return &Invitation{TenantID: u.TenantID, UserID: u.ID, Code: u.InvitationCode, ExpireAt: u.InvitationExp}, nil
+}
+
+// AcceptInvitation 接受邀请: 校验邀请码和有效期, 设置密码并启用用户
+func (u *User) AcceptInvitation(code, pwd string) herrors.Herr {
+	if !u.IsPending() {
+		return errors.UserNotPending(u.ID)
+	}
+	if u.InvitationCode == "" || u.InvitationCode != code {
+		return errors.UserInvitationInvalid("invitation has been replaced or revoked")
+	}
+	if time.Now().Unix() > u.InvitationExp {
+		return errors.UserInvitationInvalid("invitation has expired")
+	}
+	if !validator.ValidatePassword(pwd) {
+		return errors.UserInvalidField("password", "too short, min length is 6")
+	}
+	u.Password = pwd
+	if hr := u.HashPassword(); hr != nil {
+		return hr
+	}
+	u.Status = UserStatusEnabled
+	u.InvitationCode = ""
+	u.InvitationExp = 0
+	u.UpdatedAt = time.Now().Unix()
+	return nil
+}
+
+func randomCode(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// randomDigits 生成 n 位随机数字
+func randomDigits(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = '0' + b[i]%10
+	}
+	return string(b), nil
+}
@@ -0,0 +1,217 @@
+package model
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+// PermissionSeedChange 种子与数据库中单个权限的差异
+type PermissionSeedChange struct {
+	Code       string
+	ParentCode string       // 种子中的父级编码, 删除项为数据库中的父级编码
+	Fields     []string     // 变化的字段, 仅修改项
+	Current    *Permissions // 数据库中的权限, 新增项为空
+	Target     *Permissions // 种子中的权限, 删除项为空
+}
+
+// PermissionSeedDiff 权限种子差异, 新增和修改按父级在前排列, 删除按子级在前排列
+type PermissionSeedDiff struct {
+	Added   []*PermissionSeedChange
+	Changed []*PermissionSeedChange
+	Removed []*PermissionSeedChange
+}
+
+// IsEmpty 种子与数据库一致
+func (d *PermissionSeedDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// NeedApply 导入时是否需要写入, 不删除时只看新增和修改
+func (d *PermissionSeedDiff) NeedApply(prune bool) bool {
+	return len(d.Added) > 0 || len(d.Changed) > 0 || (prune && len(d.Removed) > 0)
+}
+
+// BuildPermissionTree 按 ParentID 组装权限树, 同级按 Sequence 降序
+func BuildPermissionTree(perms []*Permissions) []*Permissions {
+	byID := make(map[int64]*Permissions, len(perms))
+	for _, p := range perms {
+		p.Children = nil
+		byID[p.ID] = p
+	}
+	var roots []*Permissions
+	for _, p := range perms {
+		if parent, ok := byID[p.ParentID]; ok && p.ParentID != 0 {
+			parent.Children = append(parent.Children, p)
+		} else {
+			roots = append(roots, p)
+		}
+	}
+	sortPermissionTree(roots)
+	return roots
+}
+
+func sortPermissionTree(perms []*Permissions) {
+	sort.SliceStable(perms, func(i, j int) bool {
+		if perms[i].Sequence != perms[j].Sequence {
+			return perms[i].Sequence > perms[j].Sequence
+		}
+		return perms[i].Code < perms[j].Code
+	})
+	for _, p := range perms {
+		sortPermissionTree(p.Children)
+	}
+}
+
+// DiffPermissionSeed 比较种子权限树和数据库中的全部权限, 权限以编码标识
+func DiffPermissionSeed(current []*Permissions, seeds []*Permissions) (*PermissionSeedDiff, herrors.Herr) {
+	// 1. 展开种子树, 父级在前
+	type seedItem struct {
+		perm       *Permissions
+		parentCode string
+	}
+	var items []seedItem
+	seen := make(map[string]bool)
+	var walk func(perms []*Permissions, parentCode string) herrors.Herr
+	walk = func(perms []*Permissions, parentCode string) herrors.Herr {
+		for _, p := range perms {
+			if hr := p.Validate(); hr != nil {
+				return hr
+			}
+			if seen[p.Code] {
+				return errors.PermissionInvalidField("code", "duplicate code in seed: "+p.Code)
+			}
+			for _, r := range p.Resources {
+				if r.Method == "" || r.Path == "" {
+					return errors.PermissionInvalidField("resource", "method and path cannot be empty: "+p.Code)
+				}
+				if hr := r.ValidateCondition(); hr != nil {
+					return hr
+				}
+			}
+			seen[p.Code] = true
+			items = append(items, seedItem{perm: p, parentCode: parentCode})
+			if hr := walk(p.Children, p.Code); hr != nil {
+				return hr
+			}
+		}
+		return nil
+	}
+	if hr := walk(seeds, ""); hr != nil {
+		return nil, hr
+	}
+
+	// 2. 数据库中的权限按编码索引
+	codeByID := make(map[int64]string, len(current))
+	byCode := make(map[string]*Permissions, len(current))
+	for _, p := range current {
+		codeByID[p.ID] = p.Code
+		byCode[p.Code] = p
+	}
+
+	diff := &PermissionSeedDiff{}
+	for _, item := range items {
+		cur, ok := byCode[item.perm.Code]
+		if !ok {
+			diff.Added = append(diff.Added, &PermissionSeedChange{
+				Code:       item.perm.Code,
+				ParentCode: item.parentCode,
+				Target:     item.perm,
+			})
+			continue
+		}
+		fields := permissionSeedFields(cur, codeByID[cur.ParentID], item.perm, item.parentCode)
+		if len(fields) > 0 {
+			diff.Changed = append(diff.Changed, &PermissionSeedChange{
+				Code:       item.perm.Code,
+				ParentCode: item.parentCode,
+				Fields:     fields,
+				Current:    cur,
+				Target:     item.perm,
+			})
+		}
+	}
+
+	// 3. 种子中没有的权限, 子级在前以便逐个删除
+	for _, root := range BuildPermissionTree(current) {
+		diff.Removed = appendRemoved(diff.Removed, root, codeByID, seen)
+	}
+	return diff, nil
+}
+
+func appendRemoved(removed []*PermissionSeedChange, p *Permissions, codeByID map[int64]string, seen map[string]bool) []*PermissionSeedChange {
+	for _, child := range p.Children {
+		removed = appendRemoved(removed, child, codeByID, seen)
+	}
+	if !seen[p.Code] {
+		removed = append(removed, &PermissionSeedChange{
+			Code:       p.Code,
+			ParentCode: codeByID[p.ParentID],
+			Current:    p,
+		})
+	}
+	return removed
+}
+
+// permissionSeedFields 比较种子中可声明的字段
+func permissionSeedFields(cur *Permissions, curParent string, target *Permissions, targetParent string) []string {
+	var fields []string
+	if cur.Name != target.Name {
+		fields = append(fields, "name")
+	}
+	if cur.Localize != target.Localize {
+		fields = append(fields, "localize")
+	}
+	if cur.Icon != target.Icon {
+		fields = append(fields, "icon")
+	}
+	if cur.Description != target.Description {
+		fields = append(fields, "description")
+	}
+	if cur.Sequence != target.Sequence {
+		fields = append(fields, "sequence")
+	}
+	if cur.Type != target.Type {
+		fields = append(fields, "type")
+	}
+	if cur.Path != target.Path {
+		fields = append(fields, "path")
+	}
+	if cur.Properties != target.Properties {
+		fields = append(fields, "properties")
+	}
+	if cur.Status != target.Status {
+		fields = append(fields, "status")
+	}
+	if curParent != targetParent {
+		fields = append(fields, "parent")
+	}
+	if !sameResources(cur.Resources, target.Resources) {
+		fields = append(fields, "resources")
+	}
+	return fields
+}
+
+// sameResources 资源按方法、路径和条件比较, 与顺序无关
+func sameResources(a, b []*PermissionsResource) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	keys := func(rs []*PermissionsResource) []string {
+		result := make([]string, 0, len(rs))
+		for _, r := range rs {
+			result = append(result, strings.ToUpper(r.Method)+" "+r.Path+" "+strings.TrimSpace(r.Condition))
+		}
+		sort.Strings(result)
+		return result
+	}
+	ka, kb := keys(a), keys(b)
+	for i := range ka {
+		if ka[i] != kb[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -95,15 +95,20 @@ func (p *Permissions) ChangeParentID(pid int64) herrors.Herr {
 	return nil
 }
 
-// AddResource 添加资源
-func (p *Permissions) AddResource(method, path string) herrors.Herr {
+// AddResource 添加资源, condition 为可选的条件表达式
+func (p *Permissions) AddResource(method, path, condition string) herrors.Herr {
 	if method == "" || path == "" {
 		return errors.PermissionInvalidField("resource", "method and path cannot be empty")
 	}
-	p.Resources = append(p.Resources, &PermissionsResource{
-		Method: method,
-		Path:   path,
-	})
+	resource := &PermissionsResource{
+		Method:    method,
+		Path:      path,
+		Condition: condition,
+	}
+	if hr := resource.ValidateCondition(); hr != nil {
+		return hr
+	}
+	p.Resources = append(p.Resources, resource)
 	p.UpdatedAt = time.Now().Unix()
 	return nil
 }
@@ -114,6 +119,9 @@ func (p *Permissions) UpdateResources(resources []*PermissionsResource) herrors.
 		if r.Method == "" || r.Path == "" {
 			return errors.PermissionInvalidField("resource", "method and path cannot be empty")
 		}
+		if hr := r.ValidateCondition(); hr != nil {
+			return hr
+		}
 	}
 	p.Resources = resources
 	p.UpdatedAt = time.Now().Unix()
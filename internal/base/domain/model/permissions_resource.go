@@ -1,11 +1,20 @@
 package model
 
+import (
+	"strings"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/casbin"
+)
+
 // PermissionsResource 权限资源模型
 type PermissionsResource struct {
 	ID            int64  // 唯一标识
 	PermissionsID int64  // 关联的权限ID
 	Method        string // HTTP方法
 	Path          string // API路径
+	Condition     string // 条件表达式, 为空表示仅按角色授权
 }
 
 // NewPermissionsResource 创建新的权限资源
@@ -27,6 +36,15 @@ func (p *PermissionsResource) UpdateMethod(method string) {
 	p.Method = method
 }
 
+// ValidateCondition 校验条件表达式
+func (p *PermissionsResource) ValidateCondition() herrors.Herr {
+	p.Condition = strings.TrimSpace(p.Condition)
+	if err := casbin.ValidateCondition(p.Condition); err != nil {
+		return errors.PermissionInvalidField("condition", p.String()+": "+err.Error())
+	}
+	return nil
+}
+
 // IsMatch 检查请求是否匹配该资源
 func (p *PermissionsResource) IsMatch(method, path string) bool {
 	return p.Method == method && p.Path == path
@@ -64,6 +82,7 @@ func (p *PermissionsResource) Clone() *PermissionsResource {
 		PermissionsID: p.PermissionsID,
 		Method:        p.Method,
 		Path:          p.Path,
+		Condition:     p.Condition,
 	}
 }
 
@@ -75,6 +94,7 @@ func (p *PermissionsResource) Equal(other *PermissionsResource) bool {
 
 	return p.Method == other.Method &&
 		p.Path == other.Path &&
+		p.Condition == other.Condition &&
 		p.PermissionsID == other.PermissionsID
 }
 
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+const (
+	PositionStatusDisabled int8 = 0 // 停用
+	PositionStatusEnabled  int8 = 1 // 启用
+)
+
+// Position 岗位领域模型
+type Position struct {
+	ID          string
+	TenantID    string
+	Code        string
+	Name        string
+	Sequence    int32
+	Status      int8
+	Description string
+	CreatedAt   int64
+	UpdatedAt   int64
+}
+
+// UserPosition 用户任职岗位
+type UserPosition struct {
+	UserID     string
+	PositionID string
+	IsPrimary  bool // 是否主岗
+}
+
+// NewPosition 创建岗位
+func NewPosition(code string, name string, sequence int32) *Position {
+	return &Position{
+		Code:     code,
+		Name:     name,
+		Sequence: sequence,
+		Status:   PositionStatusEnabled,
+	}
+}
+
+// Validate 验证岗位信息
+func (p *Position) Validate() herrors.Herr {
+	if p.Name == "" {
+		return errors.PositionInvalidField("name", "cannot be empty")
+	}
+	if p.Code == "" {
+		return errors.PositionInvalidField("code", "cannot be empty")
+	}
+	if p.Status != PositionStatusDisabled && p.Status != PositionStatusEnabled {
+		return errors.PositionStatusInvalid(p.Status)
+	}
+	return nil
+}
+
+// UpdateBasicInfo 更新基本信息
+func (p *Position) UpdateBasicInfo(code string, name string, sequence int32, description string) {
+	p.Code = code
+	p.Name = name
+	p.Sequence = sequence
+	p.Description = description
+}
+
+// UpdateStatus 更新状态
+func (p *Position) UpdateStatus(status int8) {
+	p.Status = status
+}
+
+// IsEnabled 检查岗位是否启用
+func (p *Position) IsEnabled() bool {
+	return p.Status == PositionStatusEnabled
+}
+
+// NewUserPositions 生成用户任职岗位, 未指定主岗时第一个岗位为主岗
+func NewUserPositions(userID string, positionIDs []string, primaryID string) ([]*UserPosition, herrors.Herr) {
+	if len(positionIDs) == 0 {
+		return []*UserPosition{}, nil
+	}
+	if primaryID == "" {
+		primaryID = positionIDs[0]
+	}
+	list := make([]*UserPosition, 0, len(positionIDs))
+	seen := make(map[string]bool, len(positionIDs))
+	hasPrimary := false
+	for _, id := range positionIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		list = append(list, &UserPosition{
+			UserID:     userID,
+			PositionID: id,
+			IsPrimary:  id == primaryID,
+		})
+		hasPrimary = hasPrimary || id == primaryID
+	}
+	if !hasPrimary {
+		return nil, errors.PrimaryPositionInvalid(primaryID)
+	}
+	return list, nil
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/validator"
+)
+
+const (
+	ContactTypeEmail = "email" // 邮箱
+	ContactTypePhone = "phone" // 手机号
+
+	VerifyCodeLength   = 6               // 验证码长度
+	VerifyCodeTTL      = 5 * time.Minute // 验证码有效期
+	VerifyCodeInterval = time.Minute     // 验证码发送间隔
+
+	MaxAvatarSize = 2 << 20 // 头像最大 2MB
+)
+
+// avatarExts 允许上传的头像格式
+var avatarExts = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".gif":  true,
+	".webp": true,
+}
+
+// ValidateContact 校验邮箱或手机号格式
+func ValidateContact(contactType, target string) herrors.Herr {
+	switch contactType {
+	case ContactTypeEmail:
+		if !validator.ValidateEmail(target) || !validator.ValidateLength(target, 0, 100) {
+			return errors.UserInvalidField("email", "invalid email format")
+		}
+	case ContactTypePhone:
+		if !validator.ValidatePhone(target) {
+			return errors.UserInvalidField("phone", "invalid phone number format")
+		}
+	default:
+		return errors.UserInvalidField("contact type", "must be email or phone")
+	}
+	return nil
+}
+
+// ValidateAvatar 校验头像文件名与大小
+func ValidateAvatar(fileName string, size int64) herrors.Herr {
+	if size <= 0 {
+		return errors.AvatarInvalid("empty file")
+	}
+	if size > MaxAvatarSize {
+		return errors.AvatarInvalid("file too large, max size is 2MB")
+	}
+	if !avatarExts[strings.ToLower(filepath.Ext(fileName))] {
+		return errors.AvatarInvalid("only jpg, jpeg, png, gif and webp are allowed")
+	}
+	return nil
+}
+
+// NewVerifyCode 生成数字验证码
+func NewVerifyCode() (string, error) {
+	return randomDigits(VerifyCodeLength)
+}
+
+// UpdateProfile 用户自行修改基本信息, 邮箱、手机号需验证后修改
+func (u *User) UpdateProfile(name, nickname, remark string) {
+	u.UpdateBasicInfo(name, nickname, u.Phone, u.Email, u.Avatar, remark)
+}
+
+// ChangeContact 修改已验证的邮箱或手机号
+func (u *User) ChangeContact(contactType, target string) herrors.Herr {
+	if hr := ValidateContact(contactType, target); hr != nil {
+		return hr
+	}
+	switch contactType {
+	case ContactTypeEmail:
+		u.Email = target
+	case ContactTypePhone:
+		u.Phone = target
+	}
+	u.UpdatedAt = time.Now().Unix()
+	return nil
+}
+
+// ChangeAvatar 修改头像
+func (u *User) ChangeAvatar(url string) {
+	u.Avatar = url
+	u.UpdatedAt = time.Now().Unix()
+}
+
+// Contact 获取当前邮箱或手机号
+func (u *User) Contact(contactType string) string {
+	if contactType == ContactTypePhone {
+		return u.Phone
+	}
+	return u.Email
+}
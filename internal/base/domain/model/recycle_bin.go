@@ -0,0 +1,29 @@
+package model
+
+// 回收站实体类型
+const (
+	RecycleEntityUser       = "user"
+	RecycleEntityRole       = "role"
+	RecycleEntityDepartment = "department"
+)
+
+// RecycleItem 回收站记录
+// 删除用户、角色、部门时, 实体及其关联关系(用户角色、部门成员、角色权限)以快照形式保存在回收站,
+// 恢复时一并还原仍然有效的关联关系, 超过保留期限后彻底删除
+type RecycleItem struct {
+	ID         string // 记录ID
+	TenantID   string // 租户ID
+	EntityType string // 实体类型
+	EntityID   string // 实体ID
+	Code       string // 实体唯一标识: 用户名、角色编码或部门编码
+	Name       string // 实体名称
+	DeletedBy  string // 删除人
+	DeletedAt  int64  // 删除时间
+}
+
+// RecycleRoleGrant 恢复时会重新写入的用户角色
+type RecycleRoleGrant struct {
+	UserID           string // 用户ID
+	RoleID           int64  // 角色ID
+	RequiresApproval bool   // 角色分配给用户时是否需要审批
+}
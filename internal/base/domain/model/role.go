@@ -18,18 +18,19 @@ const (
 
 // Role 角色领域模型
 type Role struct {
-	ID          int64          `json:"id"`          // 角色ID
-	TenantID    string         `json:"tenant_id"`   // 租户ID
-	Code        string         `json:"code"`        // 角色编码
-	Name        string         `json:"name"`        // 角色名称
-	Localize    string         `json:"localize"`    // 多语言标识
-	Description string         `json:"description"` // 描述
-	Sequence    int            `json:"sequence"`    // 排序
-	Type        int8           `json:"type"`        // 类型
-	Status      int8           `json:"status"`      // 状态
-	Permissions []*Permissions `json:"permissions"` // 权限列表
-	CreatedAt   int64          `json:"created_at"`  // 创建时间
-	UpdatedAt   int64          `json:"updated_at"`  // 更新时间
+	ID               int64          `json:"id"`                // 角色ID
+	TenantID         string         `json:"tenant_id"`         // 租户ID
+	Code             string         `json:"code"`              // 角色编码
+	Name             string         `json:"name"`              // 角色名称
+	Localize         string         `json:"localize"`          // 多语言标识
+	Description      string         `json:"description"`       // 描述
+	Sequence         int            `json:"sequence"`          // 排序
+	Type             int8           `json:"type"`              // 类型
+	Status           int8           `json:"status"`            // 状态
+	RequiresApproval bool           `json:"requires_approval"` // 分配给用户时是否需要审批
+	Permissions      []*Permissions `json:"permissions"`       // 权限列表
+	CreatedAt        int64          `json:"created_at"`        // 创建时间
+	UpdatedAt        int64          `json:"updated_at"`        // 更新时间
 }
 
 // NewRole 创建角色
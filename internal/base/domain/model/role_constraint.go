@@ -0,0 +1,143 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/validator"
+)
+
+const (
+	RoleConstraintExclusive   int8 = 1 // 互斥: 同一用户最多拥有集合中 Limit 个角色
+	RoleConstraintCardinality int8 = 2 // 基数: 租户内最多 Limit 个用户拥有集合中的角色
+)
+
+// RoleConstraint 职责分离约束
+type RoleConstraint struct {
+	ID          int64
+	TenantID    string
+	Name        string
+	Type        int8
+	RoleIDs     []int64
+	Limit       int
+	Description string
+	CreatedAt   int64
+	UpdatedAt   int64
+}
+
+// RoleAssignment 用户变更后的角色, 新用户尚无ID时 UserID 可使用任意不与已有用户冲突的标识
+type RoleAssignment struct {
+	UserID  string
+	RoleIDs []int64
+}
+
+// RoleConstraintViolation 违反约束的现有分配
+type RoleConstraintViolation struct {
+	Constraint *RoleConstraint
+	UserIDs    []string // 违反约束的用户, 基数约束为全部持有人
+	RoleIDs    []int64  // 互斥约束中用户持有的角色
+	Count      int      // 互斥约束为持有的角色数, 基数约束为持有人数
+}
+
+// NewRoleConstraint 创建约束, 互斥约束未指定 Limit 时默认为 1
+func NewRoleConstraint(name string, constraintType int8, roleIDs []int64, limit int, description string) *RoleConstraint {
+	if limit == 0 && constraintType == RoleConstraintExclusive {
+		limit = 1
+	}
+	now := time.Now().Unix()
+	return &RoleConstraint{
+		Name:        name,
+		Type:        constraintType,
+		RoleIDs:     uniqueRoleIDs(roleIDs),
+		Limit:       limit,
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Validate 验证约束
+func (c *RoleConstraint) Validate() herrors.Herr {
+	if !validator.ValidateRequired(c.Name) {
+		return errors.RoleConstraintInvalid("name cannot be empty")
+	}
+	switch c.Type {
+	case RoleConstraintExclusive:
+		if len(c.RoleIDs) < 2 {
+			return errors.RoleConstraintInvalid("exclusive constraint requires at least 2 roles")
+		}
+		if c.Limit < 1 || c.Limit >= len(c.RoleIDs) {
+			return errors.RoleConstraintInvalid("limit must be between 1 and the number of roles minus 1")
+		}
+	case RoleConstraintCardinality:
+		if len(c.RoleIDs) == 0 {
+			return errors.RoleConstraintInvalid("cardinality constraint requires at least 1 role")
+		}
+		if c.Limit < 1 {
+			return errors.RoleConstraintInvalid("limit must be greater than 0")
+		}
+	default:
+		return errors.RoleConstraintInvalid("unknown constraint type")
+	}
+	return nil
+}
+
+// Update 更新约束
+func (c *RoleConstraint) Update(name string, roleIDs []int64, limit int, description string) {
+	c.Name = name
+	c.RoleIDs = uniqueRoleIDs(roleIDs)
+	c.Limit = limit
+	c.Description = description
+	c.UpdatedAt = time.Now().Unix()
+}
+
+// Contains 约束是否包含角色
+func (c *RoleConstraint) Contains(roleID int64) bool {
+	for _, id := range c.RoleIDs {
+		if id == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// Intersect 返回 roleIDs 中属于约束的角色
+func (c *RoleConstraint) Intersect(roleIDs []int64) []int64 {
+	var result []int64
+	for _, id := range uniqueRoleIDs(roleIDs) {
+		if c.Contains(id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// CheckUserRoles 检查用户持有的约束内角色是否超过互斥上限
+func (c *RoleConstraint) CheckUserRoles(userID string, held []int64) herrors.Herr {
+	if c.Type == RoleConstraintExclusive && len(held) > c.Limit {
+		return errors.RoleMutuallyExclusive(c.Name, userID, held, c.Limit)
+	}
+	return nil
+}
+
+// CheckHolders 检查持有约束内角色的用户数是否超过基数上限
+func (c *RoleConstraint) CheckHolders(holders int) herrors.Herr {
+	if c.Type == RoleConstraintCardinality && holders > c.Limit {
+		return errors.RoleCardinalityExceeded(c.Name, holders, c.Limit)
+	}
+	return nil
+}
+
+func uniqueRoleIDs(roleIDs []int64) []int64 {
+	result := make([]int64, 0, len(roleIDs))
+	seen := make(map[int64]bool, len(roleIDs))
+	for _, id := range roleIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
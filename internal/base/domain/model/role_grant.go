@@ -0,0 +1,144 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+// 角色申请状态
+const (
+	RoleRequestPending  int8 = 1 // 待审批
+	RoleRequestApproved int8 = 2 // 已通过
+	RoleRequestRejected int8 = 3 // 已拒绝
+)
+
+// RoleGrant 用户的角色授予, 仅在有效期内生效
+// StartTime、ExpireTime 为 0 表示不限, 均为 0 时即为永久授予
+type RoleGrant struct {
+	ID         int64
+	TenantID   string
+	UserID     string
+	RoleID     int64
+	StartTime  int64 // 生效时间
+	ExpireTime int64 // 过期时间
+}
+
+// NewRoleGrant 创建角色授予
+func NewRoleGrant(userID string, roleID int64, startTime, expireTime int64) (*RoleGrant, herrors.Herr) {
+	if hr := validateGrantWindow(startTime, expireTime); hr != nil {
+		return nil, hr
+	}
+	return &RoleGrant{
+		UserID:     userID,
+		RoleID:     roleID,
+		StartTime:  startTime,
+		ExpireTime: expireTime,
+	}, nil
+}
+
+// IsActive 当前是否在有效期内
+func (g *RoleGrant) IsActive(now int64) bool {
+	return (g.StartTime == 0 || g.StartTime <= now) && (g.ExpireTime == 0 || g.ExpireTime > now)
+}
+
+// RoleRequest 角色申请, 需要审批的角色由申请人之外的用户审批通过后才授予
+type RoleRequest struct {
+	ID            string
+	TenantID      string
+	UserID        string // 被授予角色的用户
+	RoleID        int64
+	StartTime     int64  // 申请的生效时间
+	ExpireTime    int64  // 申请的过期时间
+	Reason        string // 申请理由
+	Status        int8
+	RequestedBy   string // 申请人
+	ReviewedBy    string // 审批人
+	ReviewComment string // 审批意见
+	ReviewedAt    int64
+	CreatedAt     int64
+}
+
+// NewRoleRequest 创建角色申请
+func NewRoleRequest(tenantID, userID string, roleID int64, startTime, expireTime int64, reason, requestedBy string) (*RoleRequest, herrors.Herr) {
+	if hr := validateGrantWindow(startTime, expireTime); hr != nil {
+		return nil, hr
+	}
+	return &RoleRequest{
+		TenantID:    tenantID,
+		UserID:      userID,
+		RoleID:      roleID,
+		StartTime:   startTime,
+		ExpireTime:  expireTime,
+		Reason:      reason,
+		Status:      RoleRequestPending,
+		RequestedBy: requestedBy,
+		CreatedAt:   time.Now().Unix(),
+	}, nil
+}
+
+// IsPending 是否待审批
+func (r *RoleRequest) IsPending() bool {
+	return r.Status == RoleRequestPending
+}
+
+// Approve 审批通过, 返回需要授予的角色
+// 审批人不能是申请人, 也不能是被授予角色的用户本人
+func (r *RoleRequest) Approve(reviewerID, comment string) (*RoleGrant, herrors.Herr) {
+	if hr := r.review(reviewerID); hr != nil {
+		return nil, hr
+	}
+	now := time.Now().Unix()
+	if r.ExpireTime > 0 && r.ExpireTime <= now {
+		return nil, errors.RoleRequestExpired(r.ID)
+	}
+	r.Status = RoleRequestApproved
+	r.ReviewedBy = reviewerID
+	r.ReviewComment = comment
+	r.ReviewedAt = now
+	return &RoleGrant{
+		TenantID:   r.TenantID,
+		UserID:     r.UserID,
+		RoleID:     r.RoleID,
+		StartTime:  r.StartTime,
+		ExpireTime: r.ExpireTime,
+	}, nil
+}
+
+// Reject 拒绝申请
+func (r *RoleRequest) Reject(reviewerID, comment string) herrors.Herr {
+	if hr := r.review(reviewerID); hr != nil {
+		return hr
+	}
+	r.Status = RoleRequestRejected
+	r.ReviewedBy = reviewerID
+	r.ReviewComment = comment
+	r.ReviewedAt = time.Now().Unix()
+	return nil
+}
+
+func (r *RoleRequest) review(reviewerID string) herrors.Herr {
+	if !r.IsPending() {
+		return errors.RoleRequestReviewed(r.ID)
+	}
+	if reviewerID == "" || reviewerID == r.RequestedBy || reviewerID == r.UserID {
+		return errors.RoleRequestSelfReview(r.ID)
+	}
+	return nil
+}
+
+func validateGrantWindow(startTime, expireTime int64) herrors.Herr {
+	if startTime < 0 || expireTime < 0 {
+		return errors.RoleGrantInvalid("start and expire time cannot be negative")
+	}
+	if expireTime > 0 {
+		if expireTime <= time.Now().Unix() {
+			return errors.RoleGrantInvalid("expire time must be in the future")
+		}
+		if startTime > 0 && expireTime <= startTime {
+			return errors.RoleGrantInvalid("expire time must be after start time")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+// scimTokenPrefix SCIM 令牌前缀, 便于在日志和密钥扫描中识别
+const scimTokenPrefix = "scim_"
+
+// ScimToken 租户的 SCIM 访问令牌, 身份提供方使用该令牌同步本租户的用户和组
+// 只保存令牌的摘要, 明文仅在创建时返回一次
+type ScimToken struct {
+	ID         string
+	TenantID   string
+	Name       string // 名称, 如身份提供方名称
+	TokenHash  string // 令牌摘要
+	ExpireAt   int64  // 过期时间, 0 表示永不过期
+	LastUsedAt int64  // 最近使用时间
+	CreatedAt  int64
+}
+
+// NewScimToken 生成新的 SCIM 令牌, 返回令牌及其明文
+func NewScimToken(tenantID, name string, expireAt int64) (*ScimToken, string, herrors.Herr) {
+	if name == "" {
+		return nil, "", errors.ScimTokenInvalid("name cannot be empty")
+	}
+	code, err := randomCode(32)
+	if err != nil {
+		return nil, "", herrors.NewServerHError(err)
+	}
+	raw := scimTokenPrefix + code
+	return &ScimToken{
+		TenantID:  tenantID,
+		Name:      name,
+		TokenHash: HashScimToken(raw),
+		ExpireAt:  expireAt,
+		CreatedAt: time.Now().Unix(),
+	}, raw, nil
+}
+
+// HashScimToken 计算令牌摘要, 令牌为高熵随机值, 直接使用 SHA-256
+func HashScimToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsExpired 是否已过期
+func (t *ScimToken) IsExpired() bool {
+	return t.ExpireAt > 0 && t.ExpireAt < time.Now().Unix()
+}
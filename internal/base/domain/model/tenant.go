@@ -2,10 +2,12 @@ package model
 
 import (
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/tenantdata"
 )
 
 const (
@@ -13,6 +15,20 @@ const (
 	StatusDisabled = 2 // 禁用/锁定
 )
 
+// 租户数据隔离模式
+const (
+	IsolationShared   = "shared"   // 共享表(默认)
+	IsolationSchema   = "schema"   // 独立Schema
+	IsolationDatabase = "database" // 独立数据库
+)
+
+// 租户开通状态
+const (
+	ProvisionReady   int8 = 0 // 已完成
+	ProvisionPending int8 = 1 // 开通中
+	ProvisionFailed  int8 = 2 // 开通失败
+)
+
 // Tenant 租户领域模型
 type Tenant struct {
 	ID          string
@@ -25,9 +41,17 @@ type Tenant struct {
 	ExpireTime  int64  // 过期时间
 	Description string // 描述
 	LockReason  string // 禁用原因
-	CreatedAt   int64
-	UpdatedAt   int64
-	Permissions []*Permissions // 租户拥有的权限
+	// 数据隔离
+	IsolationMode string // 隔离模式(shared/schema/database)
+	DbSchema      string // 独立Schema名称
+	DbSource      string // 独立数据库连接串
+	// 开通状态(0:已完成 1:开通中 2:开通失败)
+	ProvisionStatus int8
+	CreatedAt       int64
+	UpdatedAt       int64
+	Permissions     []*Permissions // 租户拥有的权限
+	// 开通模板, 仅在创建租户时使用
+	Template *tenantdata.Template
 }
 
 // NewTenant 创建新租户
@@ -77,6 +101,17 @@ func (t *Tenant) Validate() herrors.Herr {
 		}
 	}
 
+	// 验证数据隔离模式
+	switch t.IsolationMode {
+	case "", IsolationShared, IsolationSchema:
+	case IsolationDatabase:
+		if t.DbSource == "" {
+			return errors.TenantInvalidField("db_source", "cannot be empty in database isolation mode")
+		}
+	default:
+		return errors.TenantInvalidField("isolation_mode", "must be shared, schema or database")
+	}
+
 	// 验证管理员用户
 	if t.AdminUser == nil {
 		return errors.TenantAdminInvalid("admin user is required")
@@ -219,3 +254,27 @@ func (t *Tenant) IsLocked() (bool, string) {
 	}
 	return false, ""
 }
+
+// SetIsolation 设置数据隔离模式, schema 模式根据租户编码生成Schema名称
+func (t *Tenant) SetIsolation(mode, source string) {
+	if mode == "" {
+		mode = IsolationShared
+	}
+	t.IsolationMode = mode
+	switch mode {
+	case IsolationSchema:
+		t.DbSchema = "tenant_" + strings.ReplaceAll(t.Code, "-", "_")
+	case IsolationDatabase:
+		t.DbSource = source
+	}
+}
+
+// IsProvisioned 是否已完成开通
+func (t *Tenant) IsProvisioned() bool {
+	return t.ProvisionStatus == ProvisionReady
+}
+
+// IsIsolated 是否物理隔离
+func (t *Tenant) IsIsolated() bool {
+	return t.IsolationMode == IsolationSchema || t.IsolationMode == IsolationDatabase
+}
@@ -0,0 +1,96 @@
+package model
+
+import "time"
+
+// 租户任务类型
+const (
+	TenantJobExport = "export" // 数据导出
+	TenantJobPurge  = "purge"  // 数据清除
+)
+
+// 租户任务状态
+const (
+	TenantJobPending   int8 = 1 // 等待执行
+	TenantJobRunning   int8 = 2 // 执行中
+	TenantJobSucceeded int8 = 3 // 成功
+	TenantJobFailed    int8 = 4 // 失败
+)
+
+// TenantJob 租户下线任务(数据导出/清除)领域模型
+type TenantJob struct {
+	ID         string
+	TenantID   string // 租户ID
+	TenantCode string // 租户编码
+	Type       string // 任务类型(export/purge)
+	Status     int8   // 状态(1:等待 2:执行中 3:成功 4:失败)
+	Progress   int32  // 进度(0-100)
+	Step       string // 当前步骤
+	FilePath   string // 导出归档路径
+	FileSize   int64  // 导出归档大小
+	Report     string // 签名的完成报告(JSON)
+	Error      string // 失败原因
+	Creator    string // 创建人
+	CreatedAt  int64
+	UpdatedAt  int64
+	FinishedAt int64 // 完成时间
+}
+
+// NewTenantJob 创建租户任务
+func NewTenantJob(tenant *Tenant, jobType, creator string) *TenantJob {
+	now := time.Now().Unix()
+	return &TenantJob{
+		TenantID:   tenant.ID,
+		TenantCode: tenant.Code,
+		Type:       jobType,
+		Creator:    creator,
+		Status:     TenantJobPending,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// Start 开始执行
+func (j *TenantJob) Start() {
+	j.Status = TenantJobRunning
+	j.UpdatedAt = time.Now().Unix()
+}
+
+// SetProgress 更新进度
+func (j *TenantJob) SetProgress(progress int32, step string) {
+	if progress > 100 {
+		progress = 100
+	}
+	j.Progress = progress
+	j.Step = step
+	j.UpdatedAt = time.Now().Unix()
+}
+
+// Succeed 执行成功
+func (j *TenantJob) Succeed(report string) {
+	j.finish(TenantJobSucceeded)
+	j.Progress = 100
+	j.Report = report
+}
+
+// Fail 执行失败
+func (j *TenantJob) Fail(err error) {
+	j.finish(TenantJobFailed)
+	j.Error = err.Error()
+}
+
+// IsFinished 是否已结束
+func (j *TenantJob) IsFinished() bool {
+	return j.Status == TenantJobSucceeded || j.Status == TenantJobFailed
+}
+
+// CanDownload 是否可以下载归档
+func (j *TenantJob) CanDownload() bool {
+	return j.Type == TenantJobExport && j.Status == TenantJobSucceeded && j.FilePath != ""
+}
+
+func (j *TenantJob) finish(status int8) {
+	now := time.Now().Unix()
+	j.Status = status
+	j.UpdatedAt = now
+	j.FinishedAt = now
+}
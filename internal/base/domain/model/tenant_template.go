@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/tenantdata"
+)
+
+// TenantTemplate 租户开通模板
+type TenantTemplate struct {
+	ID             string
+	Name           string // 模板名称(唯一)
+	Description    string // 描述
+	Format         string // 模板格式(yaml/json)
+	Content        string // 模板原文
+	SourceTenantID string // 克隆来源租户ID
+	Spec           *tenantdata.Template
+	CreatedAt      int64
+	UpdatedAt      int64
+}
+
+// NewTenantTemplate 根据模板原文创建模板
+func NewTenantTemplate(name, description, format, content string) (*TenantTemplate, herrors.Herr) {
+	now := time.Now().Unix()
+	t := &TenantTemplate{
+		Name:        name,
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := t.SetContent(format, content); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// NewTenantTemplateFromSpec 根据模板定义创建模板, 用于从已有租户克隆
+func NewTenantTemplateFromSpec(name, description, format string, spec *tenantdata.Template) (*TenantTemplate, herrors.Herr) {
+	if format == "" {
+		format = tenantdata.FormatYAML
+	}
+	content, err := spec.Marshal(format)
+	if err != nil {
+		return nil, errors.TenantTemplateInvalid(err.Error())
+	}
+	return NewTenantTemplate(name, description, format, string(content))
+}
+
+// SetContent 更新模板原文, 解析失败时返回错误
+func (t *TenantTemplate) SetContent(format, content string) herrors.Herr {
+	if format == "" {
+		format = tenantdata.DetectFormat([]byte(content))
+	}
+	spec, err := tenantdata.ParseTemplate([]byte(content), format)
+	if err != nil {
+		return errors.TenantTemplateInvalid(err.Error())
+	}
+	t.Format = format
+	t.Content = content
+	t.Spec = spec
+	t.UpdatedAt = time.Now().Unix()
+	return nil
+}
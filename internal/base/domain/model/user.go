@@ -13,6 +13,7 @@ import (
 const (
 	UserStatusEnabled  = 1 // 启用
 	UserStatusDisabled = 2 // 禁用
+	UserStatusPending  = 3 // 待激活(已邀请, 未接受邀请)
 
 	// 密码相关常量
 	MinPasswordLength = 6  // 最小密码长度
@@ -32,6 +33,7 @@ type User struct {
 	Phone          string  `json:"phone"`           // 手机号
 	Remark         string  `json:"remark"`          // 备注
 	InvitationCode string  `json:"invitation_code"` // 邀请码
+	InvitationExp  int64   `json:"invitation_exp"`  // 邀请过期时间
 	Status         int8    `json:"status"`          // 状态
 	Roles          []*Role `json:"roles"`           // 角色列表
 	CreatedAt      int64   `json:"created_at"`      // 创建时间
@@ -53,6 +55,26 @@ func NewUser(tenantID, username, password string) *User {
 	}
 }
 
+// NewExternalUser 创建由外部身份源(如 SCIM)同步的用户
+// 未提供密码时使用随机密码, 用户通过单点登录或重置密码后登录
+func NewExternalUser(tenantID, username, pwd string) (*User, herrors.Herr) {
+	if username == "" {
+		return nil, errors.UserInvalidField("username", "cannot be empty")
+	}
+	if pwd == "" {
+		code, err := randomCode(MaxPasswordLength / 2)
+		if err != nil {
+			return nil, herrors.NewServerHError(err)
+		}
+		pwd = code
+	}
+	user := NewUser(tenantID, username, pwd)
+	if hr := user.HashPassword(); hr != nil {
+		return nil, hr
+	}
+	return user, nil
+}
+
 // Validate 验证用户模型
 func (u *User) Validate() herrors.Herr {
 	// 验证租户ID
@@ -97,7 +119,7 @@ func (u *User) Validate() herrors.Herr {
 	}
 
 	// 验证状态
-	if u.Status != UserStatusEnabled && u.Status != UserStatusDisabled {
+	if u.Status != UserStatusEnabled && u.Status != UserStatusDisabled && u.Status != UserStatusPending {
 		return errors.UserStatusInvalid(u.Status)
 	}
 
@@ -202,6 +224,10 @@ func (u *User) UpdateStatus(status int8) herrors.Herr {
 	if status != UserStatusEnabled && status != UserStatusDisabled {
 		return errors.UserStatusInvalid(status)
 	}
+	// 待激活用户只能通过接受邀请启用
+	if u.Status == UserStatusPending {
+		return errors.UserNotActivated(u.ID)
+	}
 	u.Status = status
 	u.UpdatedAt = time.Now().Unix()
 	return nil
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+)
+
+// UserSheetColumns 用户导入导出的列布局, 导出时密码列为空
+var UserSheetColumns = []string{"username", "password", "name", "nickname", "phone", "email", "status", "dept_code", "role_codes"}
+
+// UserSheetValueSep 多值列(部门编码、角色编码)的分隔符
+const UserSheetValueSep = ","
+
+// UserImportRow 用户导入的一行数据
+type UserImportRow struct {
+	Line      int      // 文件中的行号, 从1开始
+	Username  string   // 用户名
+	Password  string   // 初始密码
+	Name      string   // 姓名
+	Nickname  string   // 昵称
+	Phone     string   // 手机号
+	Email     string   // 邮箱
+	Status    string   // 状态, 为空时默认启用
+	DeptCode  string   // 部门编码
+	RoleCodes []string // 角色编码
+}
+
+// NewUserImportRow 按列布局解析一行数据, 缺少的列视为空值
+func NewUserImportRow(line int, values []string) *UserImportRow {
+	get := func(i int) string {
+		if i < len(values) {
+			return strings.TrimSpace(values[i])
+		}
+		return ""
+	}
+	row := &UserImportRow{
+		Line:     line,
+		Username: get(0),
+		Password: get(1),
+		Name:     get(2),
+		Nickname: get(3),
+		Phone:    get(4),
+		Email:    get(5),
+		Status:   get(6),
+		DeptCode: get(7),
+	}
+	for _, code := range strings.Split(get(8), UserSheetValueSep) {
+		if code = strings.TrimSpace(code); code != "" {
+			row.RoleCodes = append(row.RoleCodes, code)
+		}
+	}
+	return row
+}
+
+// ToUser 转换为用户领域模型, 状态无法解析时返回 false
+func (r *UserImportRow) ToUser(tenantID string) (*User, bool) {
+	user := NewUser(tenantID, r.Username, r.Password)
+	if r.Name != "" {
+		user.Name = r.Name
+	}
+	if r.Nickname != "" {
+		user.Nickname = r.Nickname
+	}
+	user.Phone = r.Phone
+	user.Email = r.Email
+	if r.Status != "" {
+		status, err := strconv.ParseInt(r.Status, 10, 8)
+		if err != nil {
+			return user, false
+		}
+		user.Status = int8(status)
+	}
+	return user, true
+}
+
+// UserImportItem 校验通过待写入的用户
+type UserImportItem struct {
+	User   *User  // 用户, 角色已解析, 只包含无需审批的角色
+	DeptID string // 部门ID, 为空时不分配部门
+	// RequestedRoleIDs 需要审批的角色, 用户创建后为其提交角色申请
+	RequestedRoleIDs []int64
+}
+
+// UserImportError 行错误
+type UserImportError struct {
+	Line     int    `json:"line"`     // 行号
+	Username string `json:"username"` // 用户名
+	Message  string `json:"message"`  // 错误信息
+}
+
+// UserImportResult 导入结果
+type UserImportResult struct {
+	DryRun    bool               `json:"dryRun"`    // 是否仅校验
+	Total     int                `json:"total"`     // 数据行数
+	Imported  int                `json:"imported"`  // 已导入行数
+	Requested int                `json:"requested"` // 需要审批、已提交申请的角色数
+	Errors    []*UserImportError `json:"errors"`    // 行错误, 存在错误时不导入任何数据
+}
+
+// AddError 记录行错误
+func (r *UserImportResult) AddError(row *UserImportRow, message string) {
+	r.Errors = append(r.Errors, &UserImportError{Line: row.Line, Username: row.Username, Message: message})
+}
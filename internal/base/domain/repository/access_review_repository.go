@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+)
+
+// IAccessReviewRepository 权限复核仓储
+type IAccessReviewRepository interface {
+	// Create 在同一事务中保存复核活动及其快照的复核项
+	Create(ctx context.Context, campaign *model.AccessReviewCampaign, items []*model.AccessReviewItem) error
+	// FindByID 查询复核活动, 不存在时返回 nil
+	FindByID(ctx context.Context, id string) (*model.AccessReviewCampaign, error)
+	// FindItems 复核活动的全部复核项
+	FindItems(ctx context.Context, campaignID string) ([]*model.AccessReviewItem, error)
+	// FindItemByID 查询复核项, 不存在时返回 nil
+	FindItemByID(ctx context.Context, id string) (*model.AccessReviewItem, error)
+	// UpdateDecision 保存复核结果
+	UpdateDecision(ctx context.Context, item *model.AccessReviewItem) error
+	// Close 在同一事务中收回 revoked 中的授权、标记其生效时间并关闭活动
+	Close(ctx context.Context, campaign *model.AccessReviewCampaign, revoked []*model.AccessReviewItem) error
+
+	// FindDeptMembers 部门中的用户
+	FindDeptMembers(ctx context.Context, deptIDs []string) ([]*model.UserDepartment, error)
+	// FindUserRoles 未过期的用户角色, userIDs 和 roleIDs 为空时不作为条件
+	FindUserRoles(ctx context.Context, userIDs []string, roleIDs []int64) ([]*model.RoleGrant, error)
+	// FindRolePermissions 角色的权限ID
+	FindRolePermissions(ctx context.Context, roleIDs []int64) (map[int64][]int64, error)
+}
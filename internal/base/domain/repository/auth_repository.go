@@ -17,6 +17,12 @@ type IAuthRepository interface {
 	// ValidateCaptcha 验证验证码
 	ValidateCaptcha(ctx context.Context, key, code string) (bool, error)
 
+	// SaveVerifyCode 保存邮箱/手机验证码, interval 内重复发送时返回 false
+	SaveVerifyCode(ctx context.Context, key, code string, expiration, interval time.Duration) (bool, error)
+
+	// ValidateVerifyCode 验证邮箱/手机验证码, 验证后删除
+	ValidateVerifyCode(ctx context.Context, key, code string) (bool, error)
+
 	// FindByUserID 根据用户ID查找认证信息
 	FindByUserID(ctx context.Context, userID string) (*model.Auth, error)
 }
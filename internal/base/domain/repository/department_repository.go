@@ -17,13 +17,29 @@ type IDepartmentRepository interface {
 	// 查询操作
 	ExistsByCode(ctx context.Context, code string) (bool, error)
 	FindByCode(ctx context.Context, code string) (*model.Department, error)
+	// FindByCodes 根据编码批量查询部门, 不存在的编码忽略
+	FindByCodes(ctx context.Context, codes []string) ([]*model.Department, error)
 
 	// 树形结构操作
 	GetByParentID(ctx context.Context, parentID string) ([]*model.Department, error)
 	GetTreeByParentID(ctx context.Context, parentID string) ([]*model.Department, error)
+	// GetDescendantIDs 获取部门及其全部下级部门ID
+	GetDescendantIDs(ctx context.Context, ids []string) ([]string, error)
+	// GetAncestors 获取部门的全部上级部门, 从根部门开始
+	GetAncestors(ctx context.Context, id string) ([]*model.Department, error)
+	// CountSubtreeUsers 统计部门及其全部下级部门的用户数
+	CountSubtreeUsers(ctx context.Context, id string) (int64, error)
+	// Move 移动部门到新的父部门下, 同时更新下级部门的层级路径
+	Move(ctx context.Context, id string, parentID string) error
+	// Merge 将源部门的用户、子部门、数据权限引用和管理员转移到目标部门并删除源部门
+	Merge(ctx context.Context, sourceID string, targetID string) (*model.DepartmentMergeResult, error)
 
 	// 用户部门操作
 	AssignUsers(ctx context.Context, deptID string, userIDs []string) error
 	RemoveUsers(ctx context.Context, deptID string, userIDs []string) error
 	TransferUser(ctx context.Context, userID string, fromDeptID string, toDeptID string) error
+	// GetUserDepartments 获取用户所属部门, 主部门在前
+	GetUserDepartments(ctx context.Context, userID string) ([]*model.UserDepartment, error)
+	// SetPrimaryDepartment 设置用户主部门
+	SetPrimaryDepartment(ctx context.Context, userID string, deptID string) error
 }
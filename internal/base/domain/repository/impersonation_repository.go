@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+)
+
+// IImpersonationRepository 模拟登录会话仓储
+type IImpersonationRepository interface {
+	Create(ctx context.Context, session *model.ImpersonationSession) error
+	// FindByID 查询会话, 不存在时返回 nil
+	FindByID(ctx context.Context, id string) (*model.ImpersonationSession, error)
+	// End 保存会话结束时间
+	End(ctx context.Context, session *model.ImpersonationSession) error
+}
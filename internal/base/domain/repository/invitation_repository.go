@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+)
+
+// IInvitationTokenProvider 邀请令牌的签发与校验
+type IInvitationTokenProvider interface {
+	// TTL 邀请有效期
+	TTL() time.Duration
+	// Link 签发邀请令牌并生成邀请链接
+	Link(inv *model.Invitation) (string, error)
+	// Parse 校验邀请令牌
+	Parse(token string) (*model.Invitation, error)
+}
+
+// IInvitationSender 向被邀请人投递邀请链接
+type IInvitationSender interface {
+	// Send 将邀请链接发送到被邀请人邮箱
+	Send(ctx context.Context, email, link string, expireAt int64) error
+}
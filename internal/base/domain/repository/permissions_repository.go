@@ -15,4 +15,9 @@ type IPermissionsRepository interface {
 
 	// 业务查询方法
 	ExistsByCode(ctx context.Context, code string) (bool, error)
+	// FindAll 查询全部权限及其资源
+	FindAll(ctx context.Context) ([]*model.Permissions, error)
+	// ApplySeed 在一个事务中写入种子差异, prune 为 true 时删除种子中没有的权限
+	// 新增项写入后回填 Target.ID
+	ApplySeed(ctx context.Context, diff *model.PermissionSeedDiff, prune bool) error
 }
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+)
+
+// IPositionRepository 岗位仓储接口
+type IPositionRepository interface {
+	// 基础操作
+	FindByID(ctx context.Context, id string) (*model.Position, error)
+	FindByIDs(ctx context.Context, ids []string) ([]*model.Position, error)
+	Create(ctx context.Context, position *model.Position) error
+	Update(ctx context.Context, position *model.Position) error
+	Delete(ctx context.Context, id string) error
+
+	// 查询操作
+	ExistsByCode(ctx context.Context, code string) (bool, error)
+	// HasUsers 岗位是否仍有任职人员
+	HasUsers(ctx context.Context, id string) (bool, error)
+
+	// 用户岗位操作
+	GetUserPositions(ctx context.Context, userID string) ([]*model.UserPosition, error)
+	// SetUserPositions 替换用户的任职岗位
+	SetUserPositions(ctx context.Context, userID string, positions []*model.UserPosition) error
+}
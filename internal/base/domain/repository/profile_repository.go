@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"io"
+)
+
+// IVerifyCodeSender 发送邮箱/手机验证码
+type IVerifyCodeSender interface {
+	// Send 将验证码发送到指定邮箱或手机号
+	Send(ctx context.Context, contactType, target, code string) error
+}
+
+// IAvatarStorage 头像文件存储
+type IAvatarStorage interface {
+	// Upload 上传头像, 返回访问地址
+	Upload(ctx context.Context, reader io.Reader, fileName string, size int64, userID string) (string, error)
+}
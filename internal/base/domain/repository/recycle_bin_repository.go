@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+)
+
+// IRecycleBinRepository 回收站仓储接口
+type IRecycleBinRepository interface {
+	// Recycle 将实体及其关联关系移入回收站
+	Recycle(ctx context.Context, entityType, entityID, deletedBy string) error
+	// Restore 从回收站恢复实体, 只恢复关联对象仍然存在的关联关系, 用户角色只恢复 grants 中的部分
+	Restore(ctx context.Context, item *model.RecycleItem, grants []*model.RecycleRoleGrant) error
+	// FindRoleGrants 恢复时会重新写入的用户角色, 只包含仍然存在的用户和角色
+	FindRoleGrants(ctx context.Context, item *model.RecycleItem) ([]*model.RecycleRoleGrant, error)
+	// FindByEntity 根据实体查询回收站记录, 不存在时返回 nil
+	FindByEntity(ctx context.Context, entityType, entityID string) (*model.RecycleItem, error)
+	// FindExpired 查询删除时间早于 before 的记录
+	FindExpired(ctx context.Context, before int64, limit int) ([]*model.RecycleItem, error)
+	// Delete 彻底删除回收站记录
+	Delete(ctx context.Context, id string) error
+}
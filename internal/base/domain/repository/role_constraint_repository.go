@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+)
+
+// IRoleConstraintRepository 职责分离约束仓储
+type IRoleConstraintRepository interface {
+	Create(ctx context.Context, constraint *model.RoleConstraint) error
+	Update(ctx context.Context, constraint *model.RoleConstraint) error
+	Delete(ctx context.Context, id int64) error
+	// FindByID 查询约束, 不存在时返回 nil
+	FindByID(ctx context.Context, id int64) (*model.RoleConstraint, error)
+	// FindAll 当前租户的全部约束
+	FindAll(ctx context.Context) ([]*model.RoleConstraint, error)
+	// FindGrantsByRoles 当前租户持有这些角色且未过期的授予, 包括尚未生效的授予
+	FindGrantsByRoles(ctx context.Context, roleIDs []int64) ([]*model.RoleGrant, error)
+}
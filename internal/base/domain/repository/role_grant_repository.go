@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+)
+
+// IRoleGrantRepository 用户角色授予和角色申请仓储
+type IRoleGrantRepository interface {
+	// Grant 授予角色, 用户已拥有该角色时更新有效期
+	Grant(ctx context.Context, grant *model.RoleGrant) error
+	// Revoke 收回用户的角色
+	Revoke(ctx context.Context, userID string, roleID int64) error
+	// FindByUser 用户的角色授予, 包括未生效和已过期未清理的记录
+	FindByUser(ctx context.Context, userID string) ([]*model.RoleGrant, error)
+	// FindExpired 查询过期时间不晚于 now 的授予, 忽略租户的上下文中查询主库的全部租户
+	FindExpired(ctx context.Context, now int64, limit int) ([]*model.RoleGrant, error)
+	// DeleteByIDs 删除授予记录, 忽略租户的上下文中删除主库的记录
+	DeleteByIDs(ctx context.Context, ids []int64) error
+
+	// CreateRequest 创建角色申请
+	CreateRequest(ctx context.Context, req *model.RoleRequest) error
+	// UpdateRequest 保存审批结果
+	UpdateRequest(ctx context.Context, req *model.RoleRequest) error
+	// ApproveRequest 在同一事务中保存审批结果并授予角色
+	ApproveRequest(ctx context.Context, req *model.RoleRequest, grant *model.RoleGrant) error
+	// FindRequestByID 查询角色申请, 不存在时返回 nil
+	FindRequestByID(ctx context.Context, id string) (*model.RoleRequest, error)
+	// ExistsPendingRequest 是否已有待审批的相同申请
+	ExistsPendingRequest(ctx context.Context, userID string, roleID int64) (bool, error)
+}
@@ -14,6 +14,8 @@ type IRoleRepository interface {
 	FindByID(ctx context.Context, id int64) (*model.Role, error)
 	FindByCode(ctx context.Context, code string) (*model.Role, error)
 	ExistsByCode(ctx context.Context, code string) (bool, error)
+	// FindByCodes 根据编码批量查询角色, 不存在的编码忽略
+	FindByCodes(ctx context.Context, codes []string) ([]*model.Role, error)
 	// ExistsById 检查数据权限是否存在
 	ExistsById(ctx context.Context, id int64) (bool, error)
 	// 权限相关
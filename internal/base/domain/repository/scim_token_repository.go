@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+)
+
+// IScimTokenRepository SCIM 令牌仓储, 令牌保存在平台库中, 按摘要查找时不限定租户
+type IScimTokenRepository interface {
+	Create(ctx context.Context, token *model.ScimToken) error
+	Delete(ctx context.Context, tenantID, id string) error
+	FindByID(ctx context.Context, tenantID, id string) (*model.ScimToken, error)
+	FindByHash(ctx context.Context, hash string) (*model.ScimToken, error)
+	FindByTenant(ctx context.Context, tenantID string) ([]*model.ScimToken, error)
+	// Touch 更新最近使用时间
+	Touch(ctx context.Context, id string, usedAt int64) error
+}
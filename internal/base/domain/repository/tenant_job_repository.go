@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+)
+
+// ITenantJobRepository 租户下线任务仓储
+type ITenantJobRepository interface {
+	Create(ctx context.Context, job *model.TenantJob) error
+	Update(ctx context.Context, job *model.TenantJob) error
+	FindByID(ctx context.Context, id string) (*model.TenantJob, error)
+	// ExistsUnfinished 租户是否有未结束的任务
+	ExistsUnfinished(ctx context.Context, tenantID string) (bool, error)
+}
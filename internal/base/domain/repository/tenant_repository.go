@@ -9,6 +9,8 @@ import (
 type ITenantRepository interface {
 	// 基础操作
 	Create(ctx context.Context, tenant *model.Tenant) error
+	// ResumeCreate 继续开通失败或中断的租户, tenant.ID 为已有的租户记录
+	ResumeCreate(ctx context.Context, tenant *model.Tenant) error
 	Update(ctx context.Context, tenant *model.Tenant) error
 	Delete(ctx context.Context, id string) error
 
@@ -16,6 +18,7 @@ type ITenantRepository interface {
 	FindByID(ctx context.Context, id string) (*model.Tenant, error)
 	FindByCode(ctx context.Context, code string) (*model.Tenant, error)
 	ExistsByCode(ctx context.Context, code string) (bool, error)
+	ExistsByDomain(ctx context.Context, domain string, excludeID string) (bool, error)
 
 	// 权限相关
 	AssignPermissions(ctx context.Context, tenantID string, permissionIDs []int64) error
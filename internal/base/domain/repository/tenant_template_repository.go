@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/tenantdata"
+)
+
+// ITenantTemplateRepository 租户开通模板仓储
+type ITenantTemplateRepository interface {
+	Create(ctx context.Context, tpl *model.TenantTemplate) error
+	Update(ctx context.Context, tpl *model.TenantTemplate) error
+	Delete(ctx context.Context, id string) error
+	FindByID(ctx context.Context, id string) (*model.TenantTemplate, error)
+	ExistsByName(ctx context.Context, name string, excludeID string) (bool, error)
+	// Capture 读取租户现有数据生成模板定义
+	Capture(ctx context.Context, tenantID string) (*tenantdata.Template, error)
+}
@@ -10,17 +10,39 @@ import (
 type IUserRepository interface {
 	// 基础操作
 	Create(ctx context.Context, user *model.User) error
+	// Update 更新用户, 非归属租户下返回 errors.ErrNotHomeTenant
 	Update(ctx context.Context, user *model.User) error
+	// Delete 物理删除用户及其角色、部门关系, 可恢复的删除使用回收站
 	Delete(ctx context.Context, id string) error
 
 	// 用于业务规则验证
 	FindByID(ctx context.Context, id string) (*model.User, error)
 	FindByUsername(ctx context.Context, username string) (*model.User, error)
 	ExistsByUsername(ctx context.Context, username string) (bool, error)
+	CountByUsername(ctx context.Context, username string) (int64, error)
+	// FindExistingUsernames 返回已存在的用户名
+	FindExistingUsernames(ctx context.Context, usernames []string) ([]string, error)
+
+	// BatchCreate 在同一事务中批量创建用户及其角色、部门关联
+	BatchCreate(ctx context.Context, items []*model.UserImportItem) error
+
+	// UpdateInvitation 保存邀请状态: 密码、状态、邀请码及过期时间
+	UpdateInvitation(ctx context.Context, user *model.User) error
 
 	// 角色分配
 	AssignRoles(ctx context.Context, userID string, roleIDs []int64) error
 
 	// 部门相关
 	BelongsToDepartment(ctx context.Context, userID string, deptID string) (bool, error)
+
+	// 租户成员相关, 用户除归属租户外还可以加入其他租户, 需用户本人接受邀请
+	FindMemberByUsername(ctx context.Context, tenantID, username string) (*model.User, error)
+	InviteTenantMember(ctx context.Context, userID, tenantID, invitedBy string) error
+	// AcceptTenantMember 接受邀请成为成员, 没有待接受的邀请时返回 false
+	AcceptTenantMember(ctx context.Context, userID, tenantID string) (bool, error)
+	// RemoveTenantMember 移除成员关系或待接受的邀请
+	RemoveTenantMember(ctx context.Context, userID, tenantID string) error
+	IsTenantMember(ctx context.Context, userID, tenantID string) (bool, error)
+	IsTenantInvited(ctx context.Context, userID, tenantID string) (bool, error)
+	GetMemberTenantIDs(ctx context.Context, userID string) ([]string, error)
 }
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	domanevent "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/events"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/events"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+// AccessReviewService 权限复核
+// 发起时对范围内的用户→角色和角色→权限授权做快照, 用户→角色复核项分配给用户所在部门的管理员,
+// 关闭时收回复核人决定收回的授权, 未复核的授权保持不变
+type AccessReviewService struct {
+	repo     repository.IAccessReviewRepository
+	deptRepo repository.IDepartmentRepository
+	eventBus events.IEventBus
+}
+
+func NewAccessReviewService(
+	repo repository.IAccessReviewRepository,
+	deptRepo repository.IDepartmentRepository,
+	eventBus events.IEventBus,
+) *AccessReviewService {
+	return &AccessReviewService{
+		repo:     repo,
+		deptRepo: deptRepo,
+		eventBus: eventBus,
+	}
+}
+
+// Start 发起复核活动, 返回生成的复核项数量
+func (s *AccessReviewService) Start(ctx context.Context, campaign *model.AccessReviewCampaign) (int, herrors.Herr) {
+	reviewers := newReviewerResolver(s.deptRepo, campaign.OwnerID)
+
+	var (
+		grants     []*model.RoleGrant
+		userDept   map[string]string
+		permRoles  []int64
+		permReview string
+		err        error
+	)
+	switch campaign.ScopeType {
+	case model.AccessReviewScopeDepartment:
+		dept, err := s.deptRepo.FindByID(ctx, campaign.DeptID)
+		if err != nil || dept == nil {
+			return 0, errors.DepartmentNotFound(campaign.DeptID)
+		}
+		deptIDs, err := s.deptRepo.GetDescendantIDs(ctx, []string{campaign.DeptID})
+		if err != nil {
+			return 0, herrors.NewServerHError(err)
+		}
+		members, err := s.repo.FindDeptMembers(ctx, deptIDs)
+		if err != nil {
+			return 0, herrors.NewServerHError(err)
+		}
+		userDept = memberDepartments(members)
+		if len(userDept) > 0 {
+			userIDs := make([]string, 0, len(userDept))
+			for userID := range userDept {
+				userIDs = append(userIDs, userID)
+			}
+			if grants, err = s.repo.FindUserRoles(ctx, userIDs, nil); err != nil {
+				return 0, herrors.NewServerHError(err)
+			}
+		}
+		seen := make(map[int64]bool)
+		for _, grant := range grants {
+			if !seen[grant.RoleID] {
+				seen[grant.RoleID] = true
+				permRoles = append(permRoles, grant.RoleID)
+			}
+		}
+		// 角色→权限复核项由范围根部门的管理员复核
+		if permReview, err = reviewers.resolve(ctx, campaign.DeptID, ""); err != nil {
+			return 0, herrors.NewServerHError(err)
+		}
+	case model.AccessReviewScopeRole:
+		if grants, err = s.repo.FindUserRoles(ctx, nil, campaign.RoleIDs); err != nil {
+			return 0, herrors.NewServerHError(err)
+		}
+		userDept = make(map[string]string)
+		for _, grant := range grants {
+			if _, ok := userDept[grant.UserID]; ok {
+				continue
+			}
+			depts, err := s.deptRepo.GetUserDepartments(ctx, grant.UserID)
+			if err != nil {
+				return 0, herrors.NewServerHError(err)
+			}
+			userDept[grant.UserID] = ""
+			if len(depts) > 0 {
+				userDept[grant.UserID] = depts[0].DeptID
+			}
+		}
+		permRoles = campaign.RoleIDs
+		permReview = campaign.OwnerID
+	}
+
+	var items []*model.AccessReviewItem
+	for _, grant := range grants {
+		reviewerID, err := reviewers.resolve(ctx, userDept[grant.UserID], grant.UserID)
+		if err != nil {
+			return 0, herrors.NewServerHError(err)
+		}
+		items = append(items, model.NewUserRoleReviewItem(campaign, grant.UserID, grant.RoleID, reviewerID))
+	}
+	if len(permRoles) > 0 {
+		perms, err := s.repo.FindRolePermissions(ctx, permRoles)
+		if err != nil {
+			return 0, herrors.NewServerHError(err)
+		}
+		for _, roleID := range permRoles {
+			for _, permID := range perms[roleID] {
+				items = append(items, model.NewRolePermissionReviewItem(campaign, roleID, permID, permReview))
+			}
+		}
+	}
+	if len(items) == 0 {
+		return 0, errors.AccessReviewEmpty()
+	}
+
+	if err := s.repo.Create(ctx, campaign, items); err != nil {
+		return 0, herrors.NewServerHError(err)
+	}
+
+	event := domanevent.NewAccessReviewEvent(campaign.TenantID, campaign.ID, distinctReviewers(items), 0, domanevent.AccessReviewStarted)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return 0, herrors.NewServerHError(err)
+	}
+	return len(items), nil
+}
+
+// Decide 复核人记录保留或收回
+func (s *AccessReviewService) Decide(ctx context.Context, itemID, reviewerID string, decision int8, comment string) herrors.Herr {
+	item, err := s.repo.FindItemByID(ctx, itemID)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if item == nil {
+		return errors.AccessReviewItemNotFound(itemID)
+	}
+	campaign, hr := s.find(ctx, item.CampaignID)
+	if hr != nil {
+		return hr
+	}
+	if !campaign.IsOpen() {
+		return errors.AccessReviewClosed(campaign.ID)
+	}
+	if hr := item.Decide(reviewerID, decision, comment); hr != nil {
+		return hr
+	}
+	if err := s.repo.UpdateDecision(ctx, item); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// Close 关闭复核活动并收回决定收回的授权
+func (s *AccessReviewService) Close(ctx context.Context, id, userID string) (*model.AccessReviewSummary, herrors.Herr) {
+	campaign, hr := s.find(ctx, id)
+	if hr != nil {
+		return nil, hr
+	}
+	if hr := campaign.Close(userID); hr != nil {
+		return nil, hr
+	}
+	items, err := s.repo.FindItems(ctx, id)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	var revoked []*model.AccessReviewItem
+	for _, item := range items {
+		if item.Decision == model.AccessReviewRevoke {
+			revoked = append(revoked, item)
+		}
+	}
+	if err := s.repo.Close(ctx, campaign, revoked); err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+
+	// 授权变更后清除用户和角色的权限缓存
+	users := make(map[string]bool)
+	roles := make(map[int64]bool)
+	for _, item := range revoked {
+		switch item.Kind {
+		case model.AccessReviewItemUserRole:
+			if !users[item.UserID] {
+				users[item.UserID] = true
+				s.publish(ctx, domanevent.NewUserEvent(campaign.TenantID, item.UserID, domanevent.UserRoleChanged))
+			}
+		case model.AccessReviewItemRolePermission:
+			if !roles[item.RoleID] {
+				roles[item.RoleID] = true
+				s.publish(ctx, domanevent.NewRolePermissionsAssignedEvent(campaign.TenantID, item.RoleID, nil))
+			}
+		}
+	}
+	s.publish(ctx, domanevent.NewAccessReviewEvent(campaign.TenantID, campaign.ID, nil, len(revoked), domanevent.AccessReviewClosed))
+	return model.SummarizeAccessReview(items), nil
+}
+
+// publish 关闭后的通知失败不回滚已收回的授权, 只记录日志
+func (s *AccessReviewService) publish(ctx context.Context, event events.Event) {
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		hlog.CtxErrorf(ctx, "publish %s event error: %v", event.EventName(), err)
+	}
+}
+
+func (s *AccessReviewService) find(ctx context.Context, id string) (*model.AccessReviewCampaign, herrors.Herr) {
+	campaign, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	if campaign == nil {
+		return nil, errors.AccessReviewNotFound(id)
+	}
+	return campaign, nil
+}
+
+// reviewerResolver 按部门查找复核人: 从用户所在部门向上找第一个不是该用户本人的部门管理员,
+// 都没有时由发起人复核
+type reviewerResolver struct {
+	deptRepo repository.IDepartmentRepository
+	ownerID  string
+	chains   map[string][]*model.Department
+}
+
+func newReviewerResolver(deptRepo repository.IDepartmentRepository, ownerID string) *reviewerResolver {
+	return &reviewerResolver{
+		deptRepo: deptRepo,
+		ownerID:  ownerID,
+		chains:   make(map[string][]*model.Department),
+	}
+}
+
+func (r *reviewerResolver) resolve(ctx context.Context, deptID, userID string) (string, error) {
+	if deptID == "" {
+		return r.ownerID, nil
+	}
+	chain, ok := r.chains[deptID]
+	if !ok {
+		dept, err := r.deptRepo.FindByID(ctx, deptID)
+		if err != nil {
+			return "", err
+		}
+		ancestors, err := r.deptRepo.GetAncestors(ctx, deptID)
+		if err != nil {
+			return "", err
+		}
+		// 从本部门开始逐级向上
+		if dept != nil {
+			chain = append(chain, dept)
+		}
+		for i := len(ancestors) - 1; i >= 0; i-- {
+			chain = append(chain, ancestors[i])
+		}
+		r.chains[deptID] = chain
+	}
+	for _, dept := range chain {
+		if dept.AdminID != "" && dept.AdminID != userID {
+			return dept.AdminID, nil
+		}
+	}
+	return r.ownerID, nil
+}
+
+// memberDepartments 用户在范围内的部门, 优先取主部门
+func memberDepartments(members []*model.UserDepartment) map[string]string {
+	result := make(map[string]string, len(members))
+	for _, m := range members {
+		if _, ok := result[m.UserID]; !ok || m.IsPrimary {
+			result[m.UserID] = m.DeptID
+		}
+	}
+	return result
+}
+
+func distinctReviewers(items []*model.AccessReviewItem) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, item := range items {
+		if item.ReviewerID != "" && !seen[item.ReviewerID] {
+			seen[item.ReviewerID] = true
+			result = append(result, item.ReviewerID)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/events"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
 
@@ -62,11 +63,17 @@ func (s *AuthService) Login(ctx context.Context, username, password string) (*mo
 
 // ChangePassword 修改密码
 func (s *AuthService) ChangePassword(ctx context.Context, userID string, oldPassword, newPassword string) error {
-	// 获取用户
+	// 获取用户, 当前在加入的其他租户时在归属租户下修改
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return err
 	}
+	if !isHomeTenant(ctx, user.TenantID) {
+		ctx = actx.BuildTenantCtx(ctx, user.TenantID)
+		if user, err = s.userRepo.FindByID(ctx, userID); err != nil {
+			return err
+		}
+	}
 
 	// 验证旧密码
 	if herrors.HaveError(user.ComparePassword(oldPassword)) {
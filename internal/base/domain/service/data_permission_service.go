@@ -14,17 +14,23 @@ import (
 type DataPermissionService struct {
 	permRepo repository.IDataPermissionRepository
 	roleRepo repository.IRoleRepository
+	userRepo repository.IUserRepository
+	deptRepo repository.IDepartmentRepository
 	eventBus pkgEvent.IEventBus
 }
 
 func NewDataPermissionService(
 	permRepo repository.IDataPermissionRepository,
 	roleRepo repository.IRoleRepository,
+	userRepo repository.IUserRepository,
+	deptRepo repository.IDepartmentRepository,
 	eventBus pkgEvent.IEventBus,
 ) *DataPermissionService {
 	return &DataPermissionService{
 		permRepo: permRepo,
 		roleRepo: roleRepo,
+		userRepo: userRepo,
+		deptRepo: deptRepo,
 		eventBus: eventBus,
 	}
 }
@@ -123,3 +129,64 @@ func (s *DataPermissionService) GetByRoleIDs(ctx context.Context, roleIDs []int6
 
 	return perms, nil
 }
+
+// ResolveUserDataScope 计算用户生效的数据范围
+// 各角色的数据权限取并集, 本部门和本部门及以下按用户所属的全部部门计算, 未配置数据权限的角色视为全部数据
+func (s *DataPermissionService) ResolveUserDataScope(ctx context.Context, userID string) (*model.UserDataScope, herrors.Herr) {
+	scope := &model.UserDataScope{DeptIDs: []string{}, PositionIDs: []string{}}
+
+	// 1. 获取用户角色的数据权限
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.DataPermissionQueryFailed(err)
+	}
+	roleIDs := make([]int64, 0, len(user.Roles))
+	for _, role := range user.Roles {
+		roleIDs = append(roleIDs, role.ID)
+	}
+	if len(roleIDs) == 0 {
+		scope.Self = true
+		return scope, nil
+	}
+	perms, err := s.permRepo.GetByRoleIDs(ctx, roleIDs)
+	if err != nil {
+		return nil, errors.DataPermissionQueryFailed(err)
+	}
+	if len(perms) < len(roleIDs) {
+		scope.All = true
+		return scope, nil
+	}
+
+	// 2. 获取用户所属的全部部门
+	userDepts, err := s.deptRepo.GetUserDepartments(ctx, userID)
+	if err != nil {
+		return nil, errors.DataPermissionQueryFailed(err)
+	}
+	deptIDs := make([]string, 0, len(userDepts))
+	for _, ud := range userDepts {
+		deptIDs = append(deptIDs, ud.DeptID)
+	}
+
+	// 3. 合并各角色的数据范围
+	for _, perm := range perms {
+		switch perm.Scope {
+		case model.DataScopeAll:
+			scope.All = true
+		case model.DataScopeDept:
+			scope.AddDepts(deptIDs...)
+		case model.DataScopeDeptTree:
+			treeIDs, err := s.deptRepo.GetDescendantIDs(ctx, deptIDs)
+			if err != nil {
+				return nil, errors.DataPermissionQueryFailed(err)
+			}
+			scope.AddDepts(treeIDs...)
+		case model.DataScopeCustom:
+			scope.AddDepts(perm.DeptIDs...)
+		case model.DataScopeSelf:
+			scope.Self = true
+		case model.DataScopePosition:
+			scope.AddPositions(perm.PositionIDs...)
+		}
+	}
+	return scope, nil
+}
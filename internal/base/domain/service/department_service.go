@@ -14,20 +14,23 @@ import (
 )
 
 type DepartmentService struct {
-	deptRepo repository.IDepartmentRepository
-	userRepo repository.IUserRepository
-	eventBus pkgEvent.IEventBus
+	deptRepo    repository.IDepartmentRepository
+	userRepo    repository.IUserRepository
+	recycleRepo repository.IRecycleBinRepository
+	eventBus    pkgEvent.IEventBus
 }
 
 func NewDepartmentService(
 	deptRepo repository.IDepartmentRepository,
 	userRepo repository.IUserRepository,
+	recycleRepo repository.IRecycleBinRepository,
 	eventBus pkgEvent.IEventBus,
 ) *DepartmentService {
 	return &DepartmentService{
-		deptRepo: deptRepo,
-		userRepo: userRepo,
-		eventBus: eventBus,
+		deptRepo:    deptRepo,
+		userRepo:    userRepo,
+		recycleRepo: recycleRepo,
+		eventBus:    eventBus,
 	}
 }
 
@@ -101,23 +104,22 @@ func (s *DepartmentService) UpdateDepartment(ctx context.Context, dept *model.De
 	}
 
 	// 4. 如果修改了父部门,检查父部门是否存在且有效
-	if oldDept.ParentID != dept.ParentID && dept.HasParent() {
-		parent, err := s.deptRepo.FindByID(ctx, dept.ParentID)
-		if err != nil {
-			return herrors.NewServerHError(err)
-		}
-		if parent == nil {
-			return errors.ParentDepartmentNotFound(dept.ParentID)
-		}
-		if !parent.IsEnabled() {
-			return errors.ParentDepartmentDisabled(dept.ParentID)
+	parentChanged := oldDept.ParentID != dept.ParentID
+	if parentChanged {
+		if hr := s.checkMoveTarget(ctx, dept.ID, dept.ParentID); hr != nil {
+			return hr
 		}
 	}
 
-	// 5. 更新部门
+	// 5. 更新部门, 父部门变更时同步更新层级路径
 	if err := s.deptRepo.Update(ctx, dept); err != nil {
 		return errors.DepartmentUpdateFailed(err)
 	}
+	if parentChanged {
+		if err := s.deptRepo.Move(ctx, dept.ID, dept.ParentID); err != nil {
+			return errors.DepartmentUpdateFailed(err)
+		}
+	}
 
 	// 6. 发布部门更新事件
 	if err := s.eventBus.Publish(ctx, events.NewDepartmentEvent(dept.TenantID, dept.ID, events.DepartmentUpdated)); err != nil {
@@ -138,7 +140,7 @@ func (s *DepartmentService) DeleteDepartment(ctx context.Context, id string) her
 	}
 
 	// 2. 检查是否有子部门
-	children, err := s.deptRepo.GetTreeByParentID(ctx, id)
+	children, err := s.deptRepo.GetByParentID(ctx, id)
 	if err != nil {
 		return herrors.NewServerHError(err)
 	}
@@ -146,8 +148,8 @@ func (s *DepartmentService) DeleteDepartment(ctx context.Context, id string) her
 		return errors.HasChildDepartment(id)
 	}
 
-	// 3. 删除部门
-	if err := s.deptRepo.Delete(ctx, id); err != nil {
+	// 3. 移入回收站, 部门成员关系随快照保存
+	if err := s.recycleRepo.Recycle(ctx, model.RecycleEntityDepartment, id, actx.GetUserId(ctx)); err != nil {
 		return errors.DepartmentDeleteFailed(err)
 	}
 
@@ -254,6 +256,51 @@ func (s *DepartmentService) TransferUser(ctx context.Context, userID string, fro
 	return nil
 }
 
+// SetPrimaryDepartment 设置用户主部门, 新签发的token携带主部门
+func (s *DepartmentService) SetPrimaryDepartment(ctx context.Context, userID string, deptID string) herrors.Herr {
+	// 1. 检查用户是否属于该部门
+	userDepts, err := s.deptRepo.GetUserDepartments(ctx, userID)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	fromDeptID, belongs := "", false
+	for _, ud := range userDepts {
+		if ud.IsPrimary {
+			fromDeptID = ud.DeptID
+		}
+		if ud.DeptID == deptID {
+			belongs = true
+		}
+	}
+	if !belongs {
+		return errors.UserDepartmentNotFound(userID, deptID)
+	}
+	if fromDeptID == deptID {
+		return nil
+	}
+
+	// 2. 检查部门是否有效
+	dept, err := s.deptRepo.FindByID(ctx, deptID)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if !dept.IsEnabled() {
+		return errors.DepartmentDisabled(deptID)
+	}
+
+	// 3. 设置主部门
+	if err := s.deptRepo.SetPrimaryDepartment(ctx, userID, deptID); err != nil {
+		return errors.DepartmentUpdateFailed(err)
+	}
+
+	// 4. 发布主部门变更事件
+	event := events.NewUserPrimaryDeptChangedEvent(actx.GetTenantId(ctx), userID, fromDeptID, deptID)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
 // GetByID 获取部门
 func (s *DepartmentService) GetByID(ctx context.Context, id string) (*model.Department, herrors.Herr) {
 	dept, err := s.deptRepo.FindByID(ctx, id)
@@ -279,14 +326,22 @@ func (s *DepartmentService) MoveDepartment(ctx context.Context, id string, targe
 
 	// 获取原父部门ID
 	oldParentID := dept.ParentID
+	if oldParentID == targetParentID {
+		return nil
+	}
+
+	// 2. 检查目标父部门, 不能移动到自身或下级部门下
+	if hr := s.checkMoveTarget(ctx, id, targetParentID); hr != nil {
+		return hr
+	}
 
-	// 2. 更新父部门
+	// 3. 更新父部门及下级部门的层级路径
 	dept.UpdateParent(targetParentID)
-	if err := s.deptRepo.Update(ctx, dept); err != nil {
-		return herrors.NewServerHError(err)
+	if err := s.deptRepo.Move(ctx, id, targetParentID); err != nil {
+		return errors.DepartmentUpdateFailed(err)
 	}
 
-	// 3. 发布部门移动事件
+	// 4. 发布部门移动事件
 	event := events.NewDepartmentMovedEvent(actx.GetTenantId(ctx), id, oldParentID, targetParentID)
 	if err := s.eventBus.Publish(ctx, event); err != nil {
 		return herrors.NewServerHError(err)
@@ -294,6 +349,77 @@ func (s *DepartmentService) MoveDepartment(ctx context.Context, id string, targe
 	return nil
 }
 
+// MergeDepartment 合并部门, 源部门的用户、子部门、数据权限引用和管理员转移到目标部门后删除源部门
+func (s *DepartmentService) MergeDepartment(ctx context.Context, sourceID string, targetID string) herrors.Herr {
+	if sourceID == targetID {
+		return errors.DepartmentInvalidOperation("cannot merge a department into itself")
+	}
+
+	// 1. 检查源部门和目标部门
+	source, err := s.deptRepo.FindByID(ctx, sourceID)
+	if err != nil {
+		return errors.DepartmentNotFound(sourceID)
+	}
+	target, err := s.deptRepo.FindByID(ctx, targetID)
+	if err != nil {
+		return errors.DepartmentNotFound(targetID)
+	}
+	if !target.IsEnabled() {
+		return errors.DepartmentDisabled(targetID)
+	}
+
+	// 2. 目标部门不能是源部门的下级部门
+	descendants, err := s.deptRepo.GetDescendantIDs(ctx, []string{sourceID})
+	if err != nil {
+		return errors.DepartmentQueryFailed(err)
+	}
+	for _, id := range descendants {
+		if id == targetID {
+			return errors.DepartmentCycle(sourceID, targetID)
+		}
+	}
+
+	// 3. 合并
+	result, err := s.deptRepo.Merge(ctx, sourceID, targetID)
+	if err != nil {
+		return errors.DepartmentUpdateFailed(err)
+	}
+
+	// 4. 发布部门合并事件
+	event := events.NewDepartmentMergedEvent(source.TenantID, sourceID, targetID, result.UserIDs, result.ChildIDs, result.RoleIDs)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// checkMoveTarget 检查目标父部门存在且有效, 且不是部门自身或其下级部门
+func (s *DepartmentService) checkMoveTarget(ctx context.Context, id string, parentID string) herrors.Herr {
+	if parentID == "" {
+		return nil
+	}
+	if parentID == id {
+		return errors.DepartmentCycle(id, parentID)
+	}
+	parent, err := s.deptRepo.FindByID(ctx, parentID)
+	if err != nil {
+		return errors.ParentDepartmentNotFound(parentID)
+	}
+	if !parent.IsEnabled() {
+		return errors.ParentDepartmentDisabled(parentID)
+	}
+	descendants, err := s.deptRepo.GetDescendantIDs(ctx, []string{id})
+	if err != nil {
+		return errors.DepartmentQueryFailed(err)
+	}
+	for _, descendantID := range descendants {
+		if descendantID == parentID {
+			return errors.DepartmentCycle(id, parentID)
+		}
+	}
+	return nil
+}
+
 // SetDepartmentAdmin 设置部门管理员
 func (s *DepartmentService) SetDepartmentAdmin(ctx context.Context, deptID string, adminID string) herrors.Herr {
 	// 1. 检查部门是否存在
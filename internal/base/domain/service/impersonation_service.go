@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	domanevent "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/events"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/events"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+// ImpersonationService 模拟登录
+// 超级管理员可以模拟当前租户的任意用户, 其他有权限的管理员只能模拟角色不超出自己角色的用户
+type ImpersonationService struct {
+	repo     repository.IImpersonationRepository
+	userRepo repository.IUserRepository
+	eventBus events.IEventBus
+}
+
+func NewImpersonationService(repo repository.IImpersonationRepository, userRepo repository.IUserRepository, eventBus events.IEventBus) *ImpersonationService {
+	return &ImpersonationService{
+		repo:     repo,
+		userRepo: userRepo,
+		eventBus: eventBus,
+	}
+}
+
+// Start 当前用户开始模拟 userID
+func (s *ImpersonationService) Start(ctx context.Context, userID, reason string, minutes int) (*model.ImpersonationSession, herrors.Herr) {
+	if actx.IsImpersonating(ctx) {
+		return nil, errors.ImpersonationNotAllowed("already impersonating")
+	}
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	if user == nil {
+		return nil, errors.UserNotFound(userID)
+	}
+	if !actx.IsSuperAdmin(ctx) {
+		held := make(map[string]bool)
+		for _, code := range actx.GetRoles(ctx) {
+			held[code] = true
+		}
+		for _, role := range user.Roles {
+			if !held[role.Code] {
+				return nil, errors.ImpersonationNotAllowed("user holds roles you do not have")
+			}
+		}
+	}
+
+	session, hr := model.NewImpersonationSession(actx.GetTenantId(ctx), actx.GetUserId(ctx), actx.GetUsername(ctx), user, reason, minutes)
+	if hr != nil {
+		return nil, hr
+	}
+	if err := s.repo.Create(ctx, session); err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	event := domanevent.NewUserImpersonationEvent(session.TenantID, session.UserID, session.ID, session.ActorID, session.Reason, domanevent.UserImpersonationStarted)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	return session, nil
+}
+
+// End 结束模拟登录会话, 只有发起人可以结束
+func (s *ImpersonationService) End(ctx context.Context, id, actorID string) herrors.Herr {
+	session, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if session == nil || session.ActorID != actorID {
+		return errors.ImpersonationNotFound(id)
+	}
+	if session.EndedAt != 0 {
+		return nil
+	}
+	session.End()
+	if err := s.repo.End(ctx, session); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	event := domanevent.NewUserImpersonationEvent(session.TenantID, session.UserID, session.ID, session.ActorID, session.Reason, domanevent.UserImpersonationEnded)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
@@ -181,3 +181,56 @@ func (s *PermissionService) CheckHasChildren(ctx context.Context, id int64) (boo
 	}
 	return perm.HasChildren(), nil
 }
+
+// ExportSeed 导出全部权限树, 用于生成种子文件
+func (s *PermissionService) ExportSeed(ctx context.Context) ([]*model.Permissions, herrors.Herr) {
+	perms, err := s.permRepo.FindAll(ctx)
+	if err != nil {
+		return nil, errors.PermissionQueryFailed(err)
+	}
+	return model.BuildPermissionTree(perms), nil
+}
+
+// DiffSeed 比较种子与当前权限
+func (s *PermissionService) DiffSeed(ctx context.Context, seeds []*model.Permissions) (*model.PermissionSeedDiff, herrors.Herr) {
+	perms, err := s.permRepo.FindAll(ctx)
+	if err != nil {
+		return nil, errors.PermissionQueryFailed(err)
+	}
+	return model.DiffPermissionSeed(perms, seeds)
+}
+
+// ApplySeed 按编码导入种子, 已有权限更新为种子内容, prune 为 true 时删除种子中没有的权限
+// 重复导入同一份种子不会产生变化
+func (s *PermissionService) ApplySeed(ctx context.Context, seeds []*model.Permissions, prune bool) (*model.PermissionSeedDiff, herrors.Herr) {
+	diff, hr := s.DiffSeed(ctx, seeds)
+	if hr != nil {
+		return nil, hr
+	}
+	if !diff.NeedApply(prune) {
+		return diff, nil
+	}
+	if err := s.permRepo.ApplySeed(ctx, diff, prune); err != nil {
+		return nil, errors.PermissionUpdateFailed(err)
+	}
+
+	tenantID := actx.GetTenantId(ctx)
+	var changes []*events.PermissionEvent
+	for _, c := range diff.Added {
+		changes = append(changes, events.NewPermissionEvent(tenantID, c.Target.ID, events.PermissionCreated))
+	}
+	for _, c := range diff.Changed {
+		changes = append(changes, events.NewPermissionEvent(tenantID, c.Target.ID, events.PermissionUpdated))
+	}
+	if prune {
+		for _, c := range diff.Removed {
+			changes = append(changes, events.NewPermissionEvent(tenantID, c.Current.ID, events.PermissionDeleted))
+		}
+	}
+	for _, event := range changes {
+		if err := s.eventBus.Publish(ctx, event); err != nil {
+			return nil, herrors.NewServerHError(err)
+		}
+	}
+	return diff, nil
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/events"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	pkgEvent "github.com/ares-cloud/ares-ddd-admin/pkg/events"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+type PositionService struct {
+	positionRepo repository.IPositionRepository
+	userRepo     repository.IUserRepository
+	eventBus     pkgEvent.IEventBus
+}
+
+func NewPositionService(
+	positionRepo repository.IPositionRepository,
+	userRepo repository.IUserRepository,
+	eventBus pkgEvent.IEventBus,
+) *PositionService {
+	return &PositionService{
+		positionRepo: positionRepo,
+		userRepo:     userRepo,
+		eventBus:     eventBus,
+	}
+}
+
+// CreatePosition 创建岗位
+func (s *PositionService) CreatePosition(ctx context.Context, position *model.Position) herrors.Herr {
+	// 1. 验证岗位信息
+	if err := position.Validate(); herrors.HaveError(err) {
+		return err
+	}
+
+	// 2. 检查岗位编码是否存在
+	exists, err := s.positionRepo.ExistsByCode(ctx, position.Code)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if exists {
+		return errors.PositionExists(position.Code)
+	}
+
+	// 3. 创建岗位
+	if err := s.positionRepo.Create(ctx, position); err != nil {
+		return herrors.NewServerHError(err)
+	}
+
+	// 4. 发布岗位创建事件
+	if err := s.eventBus.Publish(ctx, events.NewPositionEvent(position.TenantID, position.ID, events.PositionCreated)); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// UpdatePosition 更新岗位
+func (s *PositionService) UpdatePosition(ctx context.Context, position *model.Position) herrors.Herr {
+	// 1. 验证岗位信息
+	if err := position.Validate(); herrors.HaveError(err) {
+		return err
+	}
+
+	// 2. 检查岗位是否存在
+	old, err := s.positionRepo.FindByID(ctx, position.ID)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if old == nil {
+		return errors.PositionNotFound(position.ID)
+	}
+
+	// 3. 如果修改了编码,检查新编码是否存在
+	if old.Code != position.Code {
+		exists, err := s.positionRepo.ExistsByCode(ctx, position.Code)
+		if err != nil {
+			return herrors.NewServerHError(err)
+		}
+		if exists {
+			return errors.PositionExists(position.Code)
+		}
+	}
+
+	// 4. 更新岗位
+	if err := s.positionRepo.Update(ctx, position); err != nil {
+		return herrors.NewServerHError(err)
+	}
+
+	// 5. 发布岗位更新事件
+	if err := s.eventBus.Publish(ctx, events.NewPositionEvent(position.TenantID, position.ID, events.PositionUpdated)); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// DeletePosition 删除岗位, 仍有任职人员的岗位不允许删除
+func (s *PositionService) DeletePosition(ctx context.Context, id string) herrors.Herr {
+	// 1. 检查岗位是否存在
+	position, err := s.positionRepo.FindByID(ctx, id)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if position == nil {
+		return errors.PositionNotFound(id)
+	}
+
+	// 2. 检查是否有任职人员
+	inUse, err := s.positionRepo.HasUsers(ctx, id)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if inUse {
+		return errors.PositionInUse(id)
+	}
+
+	// 3. 删除岗位
+	if err := s.positionRepo.Delete(ctx, id); err != nil {
+		return herrors.NewServerHError(err)
+	}
+
+	// 4. 发布岗位删除事件
+	if err := s.eventBus.Publish(ctx, events.NewPositionEvent(position.TenantID, position.ID, events.PositionDeleted)); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// GetByID 获取岗位
+func (s *PositionService) GetByID(ctx context.Context, id string) (*model.Position, herrors.Herr) {
+	position, err := s.positionRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	if position == nil {
+		return nil, errors.PositionNotFound(id)
+	}
+	return position, nil
+}
+
+// SetUserPositions 设置用户任职岗位, primaryID 为空时第一个岗位为主岗
+func (s *PositionService) SetUserPositions(ctx context.Context, userID string, positionIDs []string, primaryID string) herrors.Herr {
+	// 1. 检查用户是否存在
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if user == nil {
+		return errors.UserNotFound(userID)
+	}
+
+	// 2. 生成任职关系
+	list, herr := model.NewUserPositions(userID, positionIDs, primaryID)
+	if herr != nil {
+		return herr
+	}
+
+	// 3. 检查岗位是否存在且有效
+	ids := make([]string, 0, len(list))
+	for _, up := range list {
+		ids = append(ids, up.PositionID)
+	}
+	positions, err := s.positionRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	found := make(map[string]*model.Position, len(positions))
+	for _, p := range positions {
+		found[p.ID] = p
+	}
+	for _, id := range ids {
+		p, ok := found[id]
+		if !ok {
+			return errors.PositionNotFound(id)
+		}
+		if !p.IsEnabled() {
+			return errors.PositionDisabled(id)
+		}
+	}
+
+	// 4. 记录变更前的岗位, 用于刷新岗位成员缓存
+	old, err := s.positionRepo.GetUserPositions(ctx, userID)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	changed := ids
+	for _, up := range old {
+		if _, ok := found[up.PositionID]; !ok {
+			changed = append(changed, up.PositionID)
+		}
+	}
+
+	// 5. 保存任职关系
+	if err := s.positionRepo.SetUserPositions(ctx, userID, list); err != nil {
+		return herrors.NewServerHError(err)
+	}
+
+	// 6. 发布任职变更事件
+	event := events.NewUserPositionChangedEvent(actx.GetTenantId(ctx), userID, changed)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
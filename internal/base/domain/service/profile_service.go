@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	domanevent "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/events"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/events"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+// ProfileService 当前登录用户的个人信息维护
+type ProfileService struct {
+	userRepo      repository.IUserRepository
+	authRepo      repository.IAuthRepository
+	codeSender    repository.IVerifyCodeSender
+	avatarStorage repository.IAvatarStorage
+	eventBus      events.IEventBus
+}
+
+func NewProfileService(
+	userRepo repository.IUserRepository,
+	authRepo repository.IAuthRepository,
+	codeSender repository.IVerifyCodeSender,
+	avatarStorage repository.IAvatarStorage,
+	eventBus events.IEventBus,
+) *ProfileService {
+	return &ProfileService{
+		userRepo:      userRepo,
+		authRepo:      authRepo,
+		codeSender:    codeSender,
+		avatarStorage: avatarStorage,
+		eventBus:      eventBus,
+	}
+}
+
+// UpdateProfile 修改姓名、昵称、备注
+func (s *ProfileService) UpdateProfile(ctx context.Context, userID, name, nickname, remark string) herrors.Herr {
+	user, hr := s.getUser(ctx, userID)
+	if hr != nil {
+		return hr
+	}
+	user.UpdateProfile(name, nickname, remark)
+	return s.save(ctx, user)
+}
+
+// SendVerifyCode 向新的邮箱或手机号发送验证码
+func (s *ProfileService) SendVerifyCode(ctx context.Context, userID, contactType, target string) herrors.Herr {
+	if hr := model.ValidateContact(contactType, target); hr != nil {
+		return hr
+	}
+	user, hr := s.getUser(ctx, userID)
+	if hr != nil {
+		return hr
+	}
+	if user.Contact(contactType) == target {
+		return errors.ContactUnchanged(contactType)
+	}
+
+	code, err := model.NewVerifyCode()
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	ok, err := s.authRepo.SaveVerifyCode(ctx, verifyCodeKey(userID, contactType, target), code, model.VerifyCodeTTL, model.VerifyCodeInterval)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if !ok {
+		return errors.VerifyCodeFrequent(int(model.VerifyCodeInterval.Seconds()))
+	}
+	if err := s.codeSender.Send(ctx, contactType, target, code); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// ChangeContact 校验验证码后修改邮箱或手机号
+func (s *ProfileService) ChangeContact(ctx context.Context, userID, contactType, target, code string) herrors.Herr {
+	valid, err := s.authRepo.ValidateVerifyCode(ctx, verifyCodeKey(userID, contactType, target), code)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if !valid {
+		return errors.VerifyCodeInvalid()
+	}
+	user, hr := s.getUser(ctx, userID)
+	if hr != nil {
+		return hr
+	}
+	if hr := user.ChangeContact(contactType, target); hr != nil {
+		return hr
+	}
+	return s.save(ctx, user)
+}
+
+// UploadAvatar 上传并设置头像, 返回头像地址
+func (s *ProfileService) UploadAvatar(ctx context.Context, userID, fileName string, reader io.Reader, size int64) (string, herrors.Herr) {
+	if hr := model.ValidateAvatar(fileName, size); hr != nil {
+		return "", hr
+	}
+	user, hr := s.getUser(ctx, userID)
+	if hr != nil {
+		return "", hr
+	}
+	url, err := s.avatarStorage.Upload(ctx, reader, fileName, size, userID)
+	if err != nil {
+		return "", herrors.TohError(err)
+	}
+	user.ChangeAvatar(url)
+	if hr := s.save(ctx, user); hr != nil {
+		return "", hr
+	}
+	return url, nil
+}
+
+// getUser 获取本人信息, 当前在加入的其他租户时从归属租户重新加载, 角色为归属租户下的角色
+func (s *ProfileService) getUser(ctx context.Context, userID string) (*model.User, herrors.Herr) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err == nil && !isHomeTenant(ctx, user.TenantID) {
+		user, err = s.userRepo.FindByID(actx.BuildTenantCtx(ctx, user.TenantID), userID)
+	}
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, errors.UserNotFound(userID)
+		}
+		return nil, herrors.NewServerHError(err)
+	}
+	return user, nil
+}
+
+// save 在归属租户下保存本人信息
+func (s *ProfileService) save(ctx context.Context, user *model.User) herrors.Herr {
+	if err := s.userRepo.Update(actx.BuildTenantCtx(ctx, user.TenantID), user); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	event := domanevent.NewUserEvent(user.TenantID, user.ID, domanevent.UserUpdated)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+func verifyCodeKey(userID, contactType, target string) string {
+	return fmt.Sprintf("%s:%s:%s", userID, contactType, target)
+}
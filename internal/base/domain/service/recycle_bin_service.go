@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	domanevent "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/events"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/events"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+// recyclePurgeBatch 每批清理的回收站记录数
+const recyclePurgeBatch = 100
+
+// RecycleBinService 用户、角色、部门回收站
+type RecycleBinService struct {
+	recycleRepo repository.IRecycleBinRepository
+	userRepo    repository.IUserRepository
+	roleRepo    repository.IRoleRepository
+	deptRepo    repository.IDepartmentRepository
+	grantRepo   repository.IRoleGrantRepository
+	constraints *RoleConstraintService
+	eventBus    events.IEventBus
+}
+
+func NewRecycleBinService(
+	recycleRepo repository.IRecycleBinRepository,
+	userRepo repository.IUserRepository,
+	roleRepo repository.IRoleRepository,
+	deptRepo repository.IDepartmentRepository,
+	grantRepo repository.IRoleGrantRepository,
+	constraints *RoleConstraintService,
+	eventBus events.IEventBus,
+) *RecycleBinService {
+	return &RecycleBinService{
+		recycleRepo: recycleRepo,
+		userRepo:    userRepo,
+		roleRepo:    roleRepo,
+		deptRepo:    deptRepo,
+		grantRepo:   grantRepo,
+		constraints: constraints,
+		eventBus:    eventBus,
+	}
+}
+
+// Restore 恢复实体, 用户名或编码已被占用时拒绝恢复
+// 需要审批的用户角色不直接恢复, 恢复后为其创建待审批的角色申请; 直接恢复的用户角色须满足职责分离约束
+func (s *RecycleBinService) Restore(ctx context.Context, entityType, entityID string) herrors.Herr {
+	item, err := s.recycleRepo.FindByEntity(ctx, entityType, entityID)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if item == nil {
+		return errors.RecycleItemNotFound(entityType, entityID)
+	}
+	if hr := s.checkConflict(ctx, item); hr != nil {
+		return hr
+	}
+	grants, err := s.recycleRepo.FindRoleGrants(ctx, item)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	granted := make([]*model.RecycleRoleGrant, 0, len(grants))
+	var requested []*model.RecycleRoleGrant
+	for _, grant := range grants {
+		if grant.RequiresApproval {
+			requested = append(requested, grant)
+			continue
+		}
+		granted = append(granted, grant)
+	}
+	if hr := s.checkConstraints(ctx, item, granted); hr != nil {
+		return hr
+	}
+	if err := s.recycleRepo.Restore(ctx, item, granted); err != nil {
+		return herrors.NewServerHError(err)
+	}
+
+	var event events.Event
+	switch item.EntityType {
+	case model.RecycleEntityUser:
+		event = domanevent.NewUserEvent(item.TenantID, item.EntityID, domanevent.UserCreated)
+	case model.RecycleEntityRole:
+		roleID, _ := strconv.ParseInt(item.EntityID, 10, 64)
+		event = domanevent.NewRoleEvent(item.TenantID, roleID, domanevent.RoleCreated)
+	case model.RecycleEntityDepartment:
+		event = domanevent.NewDepartmentEvent(item.TenantID, item.EntityID, domanevent.DepartmentCreated)
+	}
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	for _, grant := range requested {
+		if hr := requestRole(ctx, s.grantRepo, s.eventBus, grant.UserID, grant.RoleID); hr != nil {
+			return hr
+		}
+	}
+	return nil
+}
+
+// PurgeExpired 彻底删除 before 之前移入回收站的记录, 返回删除数量
+func (s *RecycleBinService) PurgeExpired(ctx context.Context, before time.Time) (int, herrors.Herr) {
+	purged := 0
+	for {
+		items, err := s.recycleRepo.FindExpired(ctx, before.Unix(), recyclePurgeBatch)
+		if err != nil {
+			return purged, herrors.NewServerHError(err)
+		}
+		for _, item := range items {
+			if err := s.recycleRepo.Delete(ctx, item.ID); err != nil {
+				return purged, herrors.NewServerHError(err)
+			}
+			hlog.CtxInfof(ctx, "purged %s %s(%s) from recycle bin, tenant: %s", item.EntityType, item.Code, item.EntityID, item.TenantID)
+			purged++
+		}
+		if len(items) < recyclePurgeBatch {
+			return purged, nil
+		}
+	}
+}
+
+// checkConstraints 校验恢复的用户角色是否违反职责分离约束, 恢复的角色追加到用户现有的授予上
+func (s *RecycleBinService) checkConstraints(ctx context.Context, item *model.RecycleItem, grants []*model.RecycleRoleGrant) herrors.Herr {
+	if len(grants) == 0 {
+		return nil
+	}
+	if item.EntityType == model.RecycleEntityUser {
+		roleIDs := make([]int64, 0, len(grants))
+		for _, grant := range grants {
+			roleIDs = append(roleIDs, grant.RoleID)
+		}
+		return s.constraints.CheckAssignment(ctx, item.EntityID, roleIDs, false)
+	}
+	// 恢复角色时所有持有人一并恢复, 基数约束按整批计算
+	assignments := make([]*model.RoleAssignment, 0, len(grants))
+	for _, grant := range grants {
+		assignments = append(assignments, &model.RoleAssignment{UserID: grant.UserID, RoleIDs: []int64{grant.RoleID}})
+	}
+	return s.constraints.Check(ctx, assignments, false)
+}
+
+// checkConflict 检查删除后是否已有同名用户或同编码的角色、部门
+func (s *RecycleBinService) checkConflict(ctx context.Context, item *model.RecycleItem) herrors.Herr {
+	switch item.EntityType {
+	case model.RecycleEntityUser:
+		exists, err := s.userRepo.ExistsByUsername(ctx, item.Code)
+		if err != nil {
+			return herrors.NewServerHError(err)
+		}
+		if exists {
+			return errors.UserExists(item.Code)
+		}
+	case model.RecycleEntityRole:
+		exists, err := s.roleRepo.ExistsByCode(ctx, item.Code)
+		if err != nil {
+			return herrors.NewServerHError(err)
+		}
+		if exists {
+			return errors.RoleExists(item.Code)
+		}
+	case model.RecycleEntityDepartment:
+		exists, err := s.deptRepo.ExistsByCode(ctx, item.Code)
+		if err != nil {
+			return herrors.NewServerHError(err)
+		}
+		if exists {
+			return errors.DepartmentExists(item.Code)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+// RoleConstraintService 职责分离约束, 分配角色前校验互斥和基数约束
+type RoleConstraintService struct {
+	repo     repository.IRoleConstraintRepository
+	roleRepo repository.IRoleRepository
+}
+
+func NewRoleConstraintService(repo repository.IRoleConstraintRepository, roleRepo repository.IRoleRepository) *RoleConstraintService {
+	return &RoleConstraintService{
+		repo:     repo,
+		roleRepo: roleRepo,
+	}
+}
+
+// Create 创建约束
+func (s *RoleConstraintService) Create(ctx context.Context, constraint *model.RoleConstraint) herrors.Herr {
+	if hr := s.validate(ctx, constraint); hr != nil {
+		return hr
+	}
+	if err := s.repo.Create(ctx, constraint); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// Update 更新约束
+func (s *RoleConstraintService) Update(ctx context.Context, id int64, name string, roleIDs []int64, limit int, description string) herrors.Herr {
+	constraint, hr := s.find(ctx, id)
+	if hr != nil {
+		return hr
+	}
+	constraint.Update(name, roleIDs, limit, description)
+	if hr := s.validate(ctx, constraint); hr != nil {
+		return hr
+	}
+	if err := s.repo.Update(ctx, constraint); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// Delete 删除约束
+func (s *RoleConstraintService) Delete(ctx context.Context, id int64) herrors.Herr {
+	if _, hr := s.find(ctx, id); hr != nil {
+		return hr
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// CheckAssignment 校验给用户分配角色后是否违反约束
+// replace 为 true 时 roleIDs 替换用户当前生效的角色, 尚未生效的授予保留; 否则在现有授予上追加
+func (s *RoleConstraintService) CheckAssignment(ctx context.Context, userID string, roleIDs []int64, replace bool) herrors.Herr {
+	return s.Check(ctx, []*model.RoleAssignment{{UserID: userID, RoleIDs: roleIDs}}, replace)
+}
+
+// Check 校验一批角色分配, 基数约束按整批分配后的持有人数计算
+// 只校验新增角色涉及的约束, 已存在的违规不阻止与其无关的分配
+func (s *RoleConstraintService) Check(ctx context.Context, assignments []*model.RoleAssignment, replace bool) herrors.Herr {
+	constraints, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	now := time.Now().Unix()
+	for _, c := range constraints {
+		touched := false
+		for _, a := range assignments {
+			if len(c.Intersect(a.RoleIDs)) > 0 {
+				touched = true
+				break
+			}
+		}
+		if !touched {
+			continue
+		}
+
+		holders, hr := s.holders(ctx, c)
+		if hr != nil {
+			return hr
+		}
+		added := false
+		for _, a := range assignments {
+			before := holders[a.UserID]
+			after := c.Intersect(a.RoleIDs)
+			for roleID, grant := range before {
+				if replace && grant.StartTime <= now {
+					continue
+				}
+				if !containsRoleID(after, roleID) {
+					after = append(after, roleID)
+				}
+			}
+			grew := false
+			for _, roleID := range after {
+				if _, ok := before[roleID]; !ok {
+					grew = true
+					break
+				}
+			}
+			holders[a.UserID] = make(map[int64]*model.RoleGrant, len(after))
+			for _, roleID := range after {
+				holders[a.UserID][roleID] = &model.RoleGrant{UserID: a.UserID, RoleID: roleID}
+			}
+			if !grew {
+				continue
+			}
+			added = true
+			if hr := c.CheckUserRoles(a.UserID, after); hr != nil {
+				return hr
+			}
+		}
+		if !added {
+			continue
+		}
+		if hr := c.CheckHolders(countHolders(holders)); hr != nil {
+			return hr
+		}
+	}
+	return nil
+}
+
+// Violations 当前租户内违反约束的现有分配
+func (s *RoleConstraintService) Violations(ctx context.Context) ([]*model.RoleConstraintViolation, herrors.Herr) {
+	constraints, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	var result []*model.RoleConstraintViolation
+	for _, c := range constraints {
+		holders, hr := s.holders(ctx, c)
+		if hr != nil {
+			return nil, hr
+		}
+		userIDs := make([]string, 0, len(holders))
+		for userID := range holders {
+			userIDs = append(userIDs, userID)
+		}
+		sort.Strings(userIDs)
+
+		switch c.Type {
+		case model.RoleConstraintExclusive:
+			for _, userID := range userIDs {
+				held := make([]int64, 0, len(holders[userID]))
+				for roleID := range holders[userID] {
+					held = append(held, roleID)
+				}
+				if c.CheckUserRoles(userID, held) == nil {
+					continue
+				}
+				sort.Slice(held, func(i, j int) bool { return held[i] < held[j] })
+				result = append(result, &model.RoleConstraintViolation{
+					Constraint: c,
+					UserIDs:    []string{userID},
+					RoleIDs:    held,
+					Count:      len(held),
+				})
+			}
+		case model.RoleConstraintCardinality:
+			if c.CheckHolders(len(userIDs)) != nil {
+				result = append(result, &model.RoleConstraintViolation{
+					Constraint: c,
+					UserIDs:    userIDs,
+					Count:      len(userIDs),
+				})
+			}
+		}
+	}
+	return result, nil
+}
+
+// holders 持有约束内角色的用户及其授予
+func (s *RoleConstraintService) holders(ctx context.Context, c *model.RoleConstraint) (map[string]map[int64]*model.RoleGrant, herrors.Herr) {
+	grants, err := s.repo.FindGrantsByRoles(ctx, c.RoleIDs)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	result := make(map[string]map[int64]*model.RoleGrant)
+	for _, grant := range grants {
+		if result[grant.UserID] == nil {
+			result[grant.UserID] = make(map[int64]*model.RoleGrant)
+		}
+		result[grant.UserID][grant.RoleID] = grant
+	}
+	return result, nil
+}
+
+func (s *RoleConstraintService) validate(ctx context.Context, constraint *model.RoleConstraint) herrors.Herr {
+	if hr := constraint.Validate(); hr != nil {
+		return hr
+	}
+	for _, roleID := range constraint.RoleIDs {
+		if _, hr := findRole(ctx, s.roleRepo, roleID); hr != nil {
+			return hr
+		}
+	}
+	return nil
+}
+
+func (s *RoleConstraintService) find(ctx context.Context, id int64) (*model.RoleConstraint, herrors.Herr) {
+	constraint, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	if constraint == nil {
+		return nil, errors.RoleConstraintNotFound(id)
+	}
+	return constraint, nil
+}
+
+func countHolders(holders map[string]map[int64]*model.RoleGrant) int {
+	count := 0
+	for _, roles := range holders {
+		if len(roles) > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+func containsRoleID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
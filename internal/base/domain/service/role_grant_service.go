@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	domanevent "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/events"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/events"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+// roleGrantExpireBatch 每批清理的过期授予数量
+const roleGrantExpireBatch = 200
+
+// RoleGrantService 限时角色授予与角色申请审批
+type RoleGrantService struct {
+	grantRepo   repository.IRoleGrantRepository
+	roleRepo    repository.IRoleRepository
+	userRepo    repository.IUserRepository
+	constraints *RoleConstraintService
+	eventBus    events.IEventBus
+}
+
+func NewRoleGrantService(
+	grantRepo repository.IRoleGrantRepository,
+	roleRepo repository.IRoleRepository,
+	userRepo repository.IUserRepository,
+	constraints *RoleConstraintService,
+	eventBus events.IEventBus,
+) *RoleGrantService {
+	return &RoleGrantService{
+		grantRepo:   grantRepo,
+		roleRepo:    roleRepo,
+		userRepo:    userRepo,
+		constraints: constraints,
+		eventBus:    eventBus,
+	}
+}
+
+// Grant 在有效期内授予用户角色
+// 角色需要审批时不直接授予, 创建待审批的申请并返回
+func (s *RoleGrantService) Grant(ctx context.Context, userID string, roleID int64, startTime, expireTime int64, reason string) (*model.RoleRequest, herrors.Herr) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	if user == nil {
+		return nil, errors.UserNotFound(userID)
+	}
+	role, hr := findRole(ctx, s.roleRepo, roleID)
+	if hr != nil {
+		return nil, hr
+	}
+	if hr := s.constraints.CheckAssignment(ctx, userID, []int64{roleID}, false); hr != nil {
+		return nil, hr
+	}
+
+	if role.RequiresApproval {
+		exists, err := s.grantRepo.ExistsPendingRequest(ctx, userID, roleID)
+		if err != nil {
+			return nil, herrors.NewServerHError(err)
+		}
+		if exists {
+			return nil, errors.RoleRequestExists(userID, roleID)
+		}
+		req, hr := model.NewRoleRequest(actx.GetTenantId(ctx), userID, roleID, startTime, expireTime, reason, actx.GetUserId(ctx))
+		if hr != nil {
+			return nil, hr
+		}
+		if hr := submitRoleRequest(ctx, s.grantRepo, s.eventBus, req); hr != nil {
+			return nil, hr
+		}
+		return req, nil
+	}
+
+	grant, hr := model.NewRoleGrant(userID, roleID, startTime, expireTime)
+	if hr != nil {
+		return nil, hr
+	}
+	if err := s.grantRepo.Grant(ctx, grant); err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	if err := s.eventBus.Publish(ctx, domanevent.NewUserEvent(actx.GetTenantId(ctx), userID, domanevent.UserRoleChanged)); err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	return nil, nil
+}
+
+// Revoke 收回用户的角色
+func (s *RoleGrantService) Revoke(ctx context.Context, userID string, roleID int64) herrors.Herr {
+	if err := s.grantRepo.Revoke(ctx, userID, roleID); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if err := s.eventBus.Publish(ctx, domanevent.NewUserEvent(actx.GetTenantId(ctx), userID, domanevent.UserRoleChanged)); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// ListGrants 用户的角色授予
+func (s *RoleGrantService) ListGrants(ctx context.Context, userID string) ([]*model.RoleGrant, herrors.Herr) {
+	grants, err := s.grantRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	return grants, nil
+}
+
+// Approve 审批通过角色申请并授予角色, 审批人须为申请人和被授予人之外的用户
+func (s *RoleGrantService) Approve(ctx context.Context, requestID, reviewerID, comment string) herrors.Herr {
+	req, hr := s.findRequest(ctx, requestID)
+	if hr != nil {
+		return hr
+	}
+	grant, hr := req.Approve(reviewerID, comment)
+	if hr != nil {
+		return hr
+	}
+	if _, hr := findRole(ctx, s.roleRepo, req.RoleID); hr != nil {
+		return hr
+	}
+	// 申请期间其他分配可能已使约束饱和, 审批时重新校验
+	if hr := s.constraints.CheckAssignment(ctx, req.UserID, []int64{req.RoleID}, false); hr != nil {
+		return hr
+	}
+
+	if err := s.grantRepo.ApproveRequest(ctx, req, grant); err != nil {
+		return herrors.NewServerHError(err)
+	}
+
+	if err := s.eventBus.Publish(ctx, domanevent.NewUserEvent(req.TenantID, req.UserID, domanevent.UserRoleChanged)); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	event := domanevent.NewRoleRequestEvent(req.TenantID, req.RoleID, req.ID, req.UserID, req.RequestedBy, reviewerID, domanevent.RoleRequestApproved)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// Reject 拒绝角色申请
+func (s *RoleGrantService) Reject(ctx context.Context, requestID, reviewerID, comment string) herrors.Herr {
+	req, hr := s.findRequest(ctx, requestID)
+	if hr != nil {
+		return hr
+	}
+	if hr := req.Reject(reviewerID, comment); hr != nil {
+		return hr
+	}
+	if err := s.grantRepo.UpdateRequest(ctx, req); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	event := domanevent.NewRoleRequestEvent(req.TenantID, req.RoleID, req.ID, req.UserID, req.RequestedBy, reviewerID, domanevent.RoleRequestRejected)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// ExpireGrants 删除已过期的角色授予, 返回受影响的用户ID, 调用方据此使用户的会话失效
+func (s *RoleGrantService) ExpireGrants(ctx context.Context, now time.Time) ([]string, herrors.Herr) {
+	var userIDs []string
+	seen := make(map[string]bool)
+	for {
+		grants, err := s.grantRepo.FindExpired(ctx, now.Unix(), roleGrantExpireBatch)
+		if err != nil {
+			return userIDs, herrors.NewServerHError(err)
+		}
+		if len(grants) == 0 {
+			return userIDs, nil
+		}
+		ids := make([]int64, 0, len(grants))
+		for _, grant := range grants {
+			ids = append(ids, grant.ID)
+		}
+		if err := s.grantRepo.DeleteByIDs(ctx, ids); err != nil {
+			return userIDs, herrors.NewServerHError(err)
+		}
+		for _, grant := range grants {
+			hlog.CtxInfof(ctx, "role %d of user %s expired, tenant: %s", grant.RoleID, grant.UserID, grant.TenantID)
+			key := grant.TenantID + ":" + grant.UserID
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			tctx := actx.BuildTenantCtx(ctx, grant.TenantID)
+			if err := s.eventBus.Publish(tctx, domanevent.NewUserEvent(grant.TenantID, grant.UserID, domanevent.UserRoleChanged)); err != nil {
+				hlog.CtxErrorf(ctx, "publish user role changed event error: %v", err)
+			}
+			userIDs = append(userIDs, grant.UserID)
+		}
+		if len(grants) < roleGrantExpireBatch {
+			return userIDs, nil
+		}
+	}
+}
+
+func (s *RoleGrantService) findRequest(ctx context.Context, id string) (*model.RoleRequest, herrors.Herr) {
+	req, err := s.grantRepo.FindRequestByID(ctx, id)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	if req == nil {
+		return nil, errors.RoleRequestNotFound(id)
+	}
+	return req, nil
+}
+
+// findRole 查询角色, 不存在时返回 RoleNotFound
+func findRole(ctx context.Context, roleRepo repository.IRoleRepository, roleID int64) (*model.Role, herrors.Herr) {
+	role, err := roleRepo.FindByID(ctx, roleID)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, errors.RoleNotFound(roleID)
+		}
+		return nil, herrors.NewServerHError(err)
+	}
+	if role == nil {
+		return nil, errors.RoleNotFound(roleID)
+	}
+	return role, nil
+}
+
+// submitRoleRequest 保存角色申请并通知审批人
+func submitRoleRequest(ctx context.Context, grantRepo repository.IRoleGrantRepository, eventBus events.IEventBus, req *model.RoleRequest) herrors.Herr {
+	if err := grantRepo.CreateRequest(ctx, req); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	event := domanevent.NewRoleRequestEvent(req.TenantID, req.RoleID, req.ID, req.UserID, req.RequestedBy, "", domanevent.RoleRequestCreated)
+	if err := eventBus.Publish(ctx, event); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// requestRole 为需要审批的角色创建长期有效的申请, 已有待审批的申请时跳过
+// 分配、导入、邀请和恢复用户角色时, 需要审批的角色都通过申请授予
+func requestRole(ctx context.Context, grantRepo repository.IRoleGrantRepository, eventBus events.IEventBus, userID string, roleID int64) herrors.Herr {
+	exists, err := grantRepo.ExistsPendingRequest(ctx, userID, roleID)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if exists {
+		return nil
+	}
+	req, hr := model.NewRoleRequest(actx.GetTenantId(ctx), userID, roleID, 0, 0, "", actx.GetUserId(ctx))
+	if hr != nil {
+		return hr
+	}
+	return submitRoleRequest(ctx, grantRepo, eventBus, req)
+}
@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"strconv"
+
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/events"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
@@ -12,17 +15,20 @@ import (
 )
 
 type RoleCommandService struct {
-	roleRepo repository.IRoleRepository
-	eventBus events.IEventBus
+	roleRepo    repository.IRoleRepository
+	recycleRepo repository.IRecycleBinRepository
+	eventBus    events.IEventBus
 }
 
 func NewRoleCommandService(
 	roleRepo repository.IRoleRepository,
+	recycleRepo repository.IRecycleBinRepository,
 	eventBus events.IEventBus,
 ) *RoleCommandService {
 	return &RoleCommandService{
-		roleRepo: roleRepo,
-		eventBus: eventBus,
+		roleRepo:    roleRepo,
+		recycleRepo: recycleRepo,
+		eventBus:    eventBus,
 	}
 }
 
@@ -104,8 +110,8 @@ func (s *RoleCommandService) DeleteRole(ctx context.Context, id int64) herrors.H
 		return errors.RoleInUse(id)
 	}
 
-	// 3. 删除角色
-	if err := s.roleRepo.Delete(ctx, id); err != nil {
+	// 3. 移入回收站, 角色权限随快照保存
+	if err := s.recycleRepo.Recycle(ctx, model.RecycleEntityRole, strconv.FormatInt(id, 10), actx.GetUserId(ctx)); err != nil {
 		return herrors.NewServerHError(err)
 	}
 
@@ -139,7 +145,7 @@ func (s *RoleCommandService) AssignPermissions(ctx context.Context, roleID int64
 	}
 
 	// 4. 发布权限分配事件
-	err = s.eventBus.Publish(ctx, domanevent.NewRolePermissionsAssignedEvent(roleID, permissionIDs))
+	err = s.eventBus.Publish(ctx, domanevent.NewRolePermissionsAssignedEvent(role.TenantID, roleID, permissionIDs))
 	if err != nil {
 		return herrors.NewServerHError(err)
 	}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/events"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	pkgEvents "github.com/ares-cloud/ares-ddd-admin/pkg/events"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+// TenantJobService 租户下线任务服务, 任务提交后由任务执行器异步执行
+type TenantJobService struct {
+	tenantRepo repository.ITenantRepository
+	jobRepo    repository.ITenantJobRepository
+	publisher  pkgEvents.IEventBus
+}
+
+func NewTenantJobService(
+	tenantRepo repository.ITenantRepository,
+	jobRepo repository.ITenantJobRepository,
+	publisher pkgEvents.IEventBus,
+) *TenantJobService {
+	return &TenantJobService{
+		tenantRepo: tenantRepo,
+		jobRepo:    jobRepo,
+		publisher:  publisher,
+	}
+}
+
+// SubmitExport 提交租户数据导出任务
+func (s *TenantJobService) SubmitExport(ctx context.Context, tenantID string) (*model.TenantJob, herrors.Herr) {
+	tenant, herr := s.getTenant(ctx, tenantID)
+	if herr != nil {
+		return nil, herr
+	}
+	return s.submit(ctx, tenant, model.TenantJobExport)
+}
+
+// SubmitPurge 提交租户数据清除任务, 清除前租户必须处于锁定状态
+func (s *TenantJobService) SubmitPurge(ctx context.Context, tenantID string) (*model.TenantJob, herrors.Herr) {
+	tenant, herr := s.getTenant(ctx, tenantID)
+	if herr != nil {
+		return nil, herr
+	}
+	if tenant.IsDefaultTenant() {
+		return nil, errors.TenantIsDefault()
+	}
+	if locked, _ := tenant.IsLocked(); !locked {
+		return nil, errors.TenantNotLocked(tenantID)
+	}
+	return s.submit(ctx, tenant, model.TenantJobPurge)
+}
+
+// GetJob 获取任务
+func (s *TenantJobService) GetJob(ctx context.Context, id string) (*model.TenantJob, herrors.Herr) {
+	job, err := s.jobRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, herrors.NewErr(err)
+	}
+	if job == nil {
+		return nil, errors.TenantJobNotFound(id)
+	}
+	return job, nil
+}
+
+func (s *TenantJobService) getTenant(ctx context.Context, tenantID string) (*model.Tenant, herrors.Herr) {
+	tenant, err := s.tenantRepo.FindByID(ctx, tenantID)
+	if err != nil {
+		return nil, herrors.NewErr(err)
+	}
+	if tenant == nil {
+		return nil, errors.TenantNotFound(tenantID)
+	}
+	return tenant, nil
+}
+
+func (s *TenantJobService) submit(ctx context.Context, tenant *model.Tenant, jobType string) (*model.TenantJob, herrors.Herr) {
+	// 同一租户同时只允许一个任务, 避免导出和清除交叉执行
+	exists, err := s.jobRepo.ExistsUnfinished(ctx, tenant.ID)
+	if err != nil {
+		return nil, herrors.NewErr(err)
+	}
+	if exists {
+		return nil, errors.TenantJobRunning(tenant.ID)
+	}
+
+	job := model.NewTenantJob(tenant, jobType, actx.GetUserId(ctx))
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return nil, herrors.NewErr(err)
+	}
+
+	// 发布任务事件, 由任务执行器异步执行
+	event := events.NewTenantJobEvent(tenant.ID, job.ID, jobType)
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		return nil, herrors.NewErr(err)
+	}
+	return job, nil
+}
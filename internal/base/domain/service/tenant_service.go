@@ -33,16 +33,31 @@ func (s *TenantCommandService) CreateTenant(ctx context.Context, tenant *model.T
 		return err
 	}
 
-	// 检查租户编码是否已存在
-	exists, err := s.tenantRepo.ExistsByCode(ctx, tenant.Code)
+	// 检查租户编码是否已存在, 开通失败的租户重新提交时继续开通
+	existing, err := s.tenantRepo.FindByCode(ctx, tenant.Code)
 	if err != nil {
 		return herrors.NewErr(err)
 	}
-	if exists {
+	if existing != nil && (existing.IsProvisioned() || existing.IsolationMode != tenant.IsolationMode) {
 		return errors.TenantCodeExists(tenant.Code)
 	}
+	excludeID := ""
+	if existing != nil {
+		excludeID = existing.ID
+	}
 
-	if err := s.tenantRepo.Create(ctx, tenant); err != nil {
+	// 检查租户域名是否已被使用
+	if herr := s.checkDomain(ctx, tenant.Domain, excludeID); herr != nil {
+		return herr
+	}
+
+	if existing != nil {
+		tenant.ID = existing.ID
+		err = s.tenantRepo.ResumeCreate(ctx, tenant)
+	} else {
+		err = s.tenantRepo.Create(ctx, tenant)
+	}
+	if err != nil {
 		return herrors.NewErr(err)
 	}
 
@@ -76,6 +91,11 @@ func (s *TenantCommandService) UpdateTenant(ctx context.Context, tenant *model.T
 		return err
 	}
 
+	// 检查租户域名是否已被使用
+	if herr := s.checkDomain(ctx, tenant.Domain, tenant.ID); herr != nil {
+		return herr
+	}
+
 	if err := s.tenantRepo.Update(ctx, tenant); err != nil {
 		return herrors.NewErr(err)
 	}
@@ -231,3 +251,18 @@ func (s *TenantCommandService) UnlockTenant(ctx context.Context, id string) herr
 
 	return nil
 }
+
+// checkDomain 检查域名是否已被其他租户使用
+func (s *TenantCommandService) checkDomain(ctx context.Context, domain, excludeID string) herrors.Herr {
+	if domain == "" {
+		return nil
+	}
+	exists, err := s.tenantRepo.ExistsByDomain(ctx, domain, excludeID)
+	if err != nil {
+		return herrors.NewErr(err)
+	}
+	if exists {
+		return errors.TenantDomainExists(domain)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+)
+
+// TenantTemplateService 租户开通模板服务
+type TenantTemplateService struct {
+	tenantRepo   repository.ITenantRepository
+	templateRepo repository.ITenantTemplateRepository
+}
+
+func NewTenantTemplateService(
+	tenantRepo repository.ITenantRepository,
+	templateRepo repository.ITenantTemplateRepository,
+) *TenantTemplateService {
+	return &TenantTemplateService{
+		tenantRepo:   tenantRepo,
+		templateRepo: templateRepo,
+	}
+}
+
+// CreateTemplate 创建模板
+func (s *TenantTemplateService) CreateTemplate(ctx context.Context, tpl *model.TenantTemplate) herrors.Herr {
+	if herr := s.checkName(ctx, tpl.Name, ""); herr != nil {
+		return herr
+	}
+	if err := s.templateRepo.Create(ctx, tpl); err != nil {
+		return herrors.NewErr(err)
+	}
+	return nil
+}
+
+// UpdateTemplate 更新模板
+func (s *TenantTemplateService) UpdateTemplate(ctx context.Context, id, name, description, format, content string) herrors.Herr {
+	tpl, herr := s.GetTemplate(ctx, id)
+	if herr != nil {
+		return herr
+	}
+	if herr := s.checkName(ctx, name, id); herr != nil {
+		return herr
+	}
+	if herr := tpl.SetContent(format, content); herr != nil {
+		return herr
+	}
+	tpl.Name = name
+	tpl.Description = description
+	if err := s.templateRepo.Update(ctx, tpl); err != nil {
+		return herrors.NewErr(err)
+	}
+	return nil
+}
+
+// DeleteTemplate 删除模板
+func (s *TenantTemplateService) DeleteTemplate(ctx context.Context, id string) herrors.Herr {
+	if _, herr := s.GetTemplate(ctx, id); herr != nil {
+		return herr
+	}
+	if err := s.templateRepo.Delete(ctx, id); err != nil {
+		return herrors.NewErr(err)
+	}
+	return nil
+}
+
+// GetTemplate 获取模板
+func (s *TenantTemplateService) GetTemplate(ctx context.Context, id string) (*model.TenantTemplate, herrors.Herr) {
+	tpl, err := s.templateRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, herrors.NewErr(err)
+	}
+	if tpl == nil {
+		return nil, errors.TenantTemplateNotFound(id)
+	}
+	return tpl, nil
+}
+
+// CloneFromTenant 读取已有租户的部门、角色、数据权限、字典和文件夹生成模板
+func (s *TenantTemplateService) CloneFromTenant(ctx context.Context, tenantID, name, description, format string) (*model.TenantTemplate, herrors.Herr) {
+	tenant, err := s.tenantRepo.FindByID(ctx, tenantID)
+	if err != nil {
+		return nil, herrors.NewErr(err)
+	}
+	if tenant == nil {
+		return nil, errors.TenantNotFound(tenantID)
+	}
+	spec, err := s.templateRepo.Capture(ctx, tenantID)
+	if err != nil {
+		return nil, herrors.NewErr(err)
+	}
+	tpl, herr := model.NewTenantTemplateFromSpec(name, description, format, spec)
+	if herr != nil {
+		return nil, herr
+	}
+	tpl.SourceTenantID = tenantID
+	if herr := s.CreateTemplate(ctx, tpl); herr != nil {
+		return nil, herr
+	}
+	return tpl, nil
+}
+
+func (s *TenantTemplateService) checkName(ctx context.Context, name, excludeID string) herrors.Herr {
+	exists, err := s.templateRepo.ExistsByName(ctx, name, excludeID)
+	if err != nil {
+		return herrors.NewErr(err)
+	}
+	if exists {
+		return errors.TenantTemplateNameExists(name)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ares-cloud/ares-ddd-admin/pkg/events"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	domanevent "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/events"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+)
+
+// UserImportService 用户批量导入
+type UserImportService struct {
+	userRepo    repository.IUserRepository
+	roleRepo    repository.IRoleRepository
+	deptRepo    repository.IDepartmentRepository
+	grantRepo   repository.IRoleGrantRepository
+	constraints *RoleConstraintService
+	eventBus    events.IEventBus
+}
+
+func NewUserImportService(
+	userRepo repository.IUserRepository,
+	roleRepo repository.IRoleRepository,
+	deptRepo repository.IDepartmentRepository,
+	grantRepo repository.IRoleGrantRepository,
+	constraints *RoleConstraintService,
+	eventBus events.IEventBus,
+) *UserImportService {
+	return &UserImportService{
+		userRepo:    userRepo,
+		roleRepo:    roleRepo,
+		deptRepo:    deptRepo,
+		grantRepo:   grantRepo,
+		constraints: constraints,
+		eventBus:    eventBus,
+	}
+}
+
+// Import 校验并导入用户
+// 每一行都会经过 User.Validate 校验并解析部门编码和角色编码, 任意一行出错时不写入数据;
+// 需要审批的角色不直接分配, 用户创建后为其提交角色申请; dryRun 为 true 时只返回校验结果
+func (s *UserImportService) Import(ctx context.Context, tenantID string, rows []*model.UserImportRow, dryRun bool) (*model.UserImportResult, herrors.Herr) {
+	result := &model.UserImportResult{DryRun: dryRun, Total: len(rows)}
+	if len(rows) == 0 {
+		return nil, errors.UserInvalidField("file", "no data rows")
+	}
+
+	depts, roles, existing, hr := s.resolve(ctx, rows)
+	if hr != nil {
+		return nil, hr
+	}
+
+	items := make([]*model.UserImportItem, 0, len(rows))
+	seen := make(map[string]int, len(rows))
+	for _, row := range rows {
+		user, ok := row.ToUser(tenantID)
+		if !ok {
+			result.AddError(row, fmt.Sprintf("invalid status: %s", row.Status))
+			continue
+		}
+		if hr := user.Validate(); hr != nil {
+			result.AddError(row, hr.DefMessage)
+			continue
+		}
+		if line, ok := seen[row.Username]; ok {
+			result.AddError(row, fmt.Sprintf("duplicate username, first seen at line %d", line))
+			continue
+		}
+		seen[row.Username] = row.Line
+		if _, ok := existing[row.Username]; ok {
+			result.AddError(row, fmt.Sprintf("user already exists: %s", row.Username))
+			continue
+		}
+
+		item := &model.UserImportItem{User: user}
+		if row.DeptCode != "" {
+			dept, ok := depts[row.DeptCode]
+			if !ok {
+				result.AddError(row, fmt.Sprintf("department not found: %s", row.DeptCode))
+				continue
+			}
+			item.DeptID = dept.ID
+		}
+		valid := true
+		for _, code := range row.RoleCodes {
+			role, ok := roles[code]
+			if !ok {
+				result.AddError(row, fmt.Sprintf("role not found: %s", code))
+				valid = false
+				break
+			}
+			if role.RequiresApproval {
+				item.RequestedRoleIDs = append(item.RequestedRoleIDs, role.ID)
+				continue
+			}
+			user.Roles = append(user.Roles, role)
+		}
+		if valid {
+			items = append(items, item)
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	// 职责分离约束按整批校验, 用户尚未创建, 以用户名作为用户标识
+	assignments := make([]*model.RoleAssignment, 0, len(items))
+	for _, item := range items {
+		assignment := &model.RoleAssignment{UserID: item.User.Username}
+		for _, role := range item.User.Roles {
+			assignment.RoleIDs = append(assignment.RoleIDs, role.ID)
+		}
+		assignments = append(assignments, assignment)
+	}
+	if hr := s.constraints.Check(ctx, assignments, false); hr != nil {
+		return nil, hr
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	for _, item := range items {
+		if hr := item.User.HashPassword(); hr != nil {
+			return nil, hr
+		}
+	}
+	if err := s.userRepo.BatchCreate(ctx, items); err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	result.Imported = len(items)
+
+	for _, item := range items {
+		event := domanevent.NewUserEvent(item.User.TenantID, item.User.ID, domanevent.UserCreated)
+		if err := s.eventBus.Publish(ctx, event); err != nil {
+			return nil, herrors.NewServerHError(err)
+		}
+		for _, roleID := range item.RequestedRoleIDs {
+			if hr := requestRole(ctx, s.grantRepo, s.eventBus, item.User.ID, roleID); hr != nil {
+				return nil, hr
+			}
+			result.Requested++
+		}
+	}
+	return result, nil
+}
+
+// resolve 批量查询文件中引用的部门、角色以及已存在的用户名
+func (s *UserImportService) resolve(ctx context.Context, rows []*model.UserImportRow) (map[string]*model.Department, map[string]*model.Role, map[string]struct{}, herrors.Herr) {
+	var deptCodes, roleCodes, usernames []string
+	for _, row := range rows {
+		if row.DeptCode != "" {
+			deptCodes = append(deptCodes, row.DeptCode)
+		}
+		roleCodes = append(roleCodes, row.RoleCodes...)
+		if row.Username != "" {
+			usernames = append(usernames, row.Username)
+		}
+	}
+
+	deptList, err := s.deptRepo.FindByCodes(ctx, deptCodes)
+	if err != nil {
+		return nil, nil, nil, herrors.NewServerHError(err)
+	}
+	depts := make(map[string]*model.Department, len(deptList))
+	for _, dept := range deptList {
+		depts[dept.Code] = dept
+	}
+
+	roleList, err := s.roleRepo.FindByCodes(ctx, roleCodes)
+	if err != nil {
+		return nil, nil, nil, herrors.NewServerHError(err)
+	}
+	roles := make(map[string]*model.Role, len(roleList))
+	for _, role := range roleList {
+		roles[role.Code] = role
+	}
+
+	names, err := s.userRepo.FindExistingUsernames(ctx, usernames)
+	if err != nil {
+		return nil, nil, nil, herrors.NewServerHError(err)
+	}
+	existing := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		existing[name] = struct{}{}
+	}
+	return depts, roles, existing, nil
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/events"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
+	domanevent "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/events"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+)
+
+// UserInvitationService 用户邀请
+// 管理员邀请时创建待激活用户并预分配角色和部门, 被邀请人通过签名的邀请链接设置密码后启用
+type UserInvitationService struct {
+	userRepo    repository.IUserRepository
+	roleRepo    repository.IRoleRepository
+	deptRepo    repository.IDepartmentRepository
+	grantRepo   repository.IRoleGrantRepository
+	tokens      repository.IInvitationTokenProvider
+	constraints *RoleConstraintService
+	eventBus    events.IEventBus
+}
+
+func NewUserInvitationService(
+	userRepo repository.IUserRepository,
+	roleRepo repository.IRoleRepository,
+	deptRepo repository.IDepartmentRepository,
+	grantRepo repository.IRoleGrantRepository,
+	tokens repository.IInvitationTokenProvider,
+	constraints *RoleConstraintService,
+	eventBus events.IEventBus,
+) *UserInvitationService {
+	return &UserInvitationService{
+		userRepo:    userRepo,
+		roleRepo:    roleRepo,
+		deptRepo:    deptRepo,
+		grantRepo:   grantRepo,
+		tokens:      tokens,
+		constraints: constraints,
+		eventBus:    eventBus,
+	}
+}
+
+// Invite 邀请用户, 返回邀请链接
+// 需要审批的角色不预分配, 用户创建后为其提交角色申请
+func (s *UserInvitationService) Invite(ctx context.Context, user *model.User, roleIDs []int64, deptID string) (string, herrors.Herr) {
+	exists, err := s.userRepo.ExistsByUsername(ctx, user.Username)
+	if err != nil {
+		return "", herrors.NewServerHError(err)
+	}
+	if exists {
+		return "", errors.UserExists(user.Username)
+	}
+	if deptID != "" {
+		dept, err := s.deptRepo.FindByID(ctx, deptID)
+		if err != nil || dept == nil {
+			return "", errors.DepartmentNotFound(deptID)
+		}
+	}
+	granted := make([]int64, 0, len(roleIDs))
+	var requested []int64
+	for _, id := range roleIDs {
+		role, hr := findRole(ctx, s.roleRepo, id)
+		if hr != nil {
+			return "", hr
+		}
+		if role.RequiresApproval {
+			requested = append(requested, id)
+			continue
+		}
+		granted = append(granted, id)
+		user.Roles = append(user.Roles, role)
+	}
+	// 用户尚未创建, 以用户名作为校验约束时的用户标识
+	if hr := s.constraints.CheckAssignment(ctx, user.Username, granted, false); hr != nil {
+		return "", hr
+	}
+
+	// 用户、角色和部门在同一事务中写入
+	if _, hr := user.IssueInvitation(s.tokens.TTL()); hr != nil {
+		return "", hr
+	}
+	if err := s.userRepo.BatchCreate(ctx, []*model.UserImportItem{{User: user, DeptID: deptID}}); err != nil {
+		return "", herrors.NewServerHError(err)
+	}
+	inv := &model.Invitation{TenantID: user.TenantID, UserID: user.ID, Code: user.InvitationCode, ExpireAt: user.InvitationExp}
+	link, err := s.tokens.Link(inv)
+	if err != nil {
+		return "", herrors.NewServerHError(err)
+	}
+
+	if hr := s.publish(ctx, domanevent.NewUserEvent(user.TenantID, user.ID, domanevent.UserCreated)); hr != nil {
+		return "", hr
+	}
+	event := domanevent.NewUserInvitationEvent(user.TenantID, user.ID, user.Email, link, inv.ExpireAt, domanevent.UserInvited)
+	if hr := s.publish(ctx, event); hr != nil {
+		return "", hr
+	}
+	for _, id := range requested {
+		if hr := requestRole(ctx, s.grantRepo, s.eventBus, user.ID, id); hr != nil {
+			return "", hr
+		}
+	}
+	return link, nil
+}
+
+// Resend 重新发送邀请, 生成新的邀请码并延长有效期, 旧链接失效
+func (s *UserInvitationService) Resend(ctx context.Context, userID string) (string, herrors.Herr) {
+	user, hr := s.findPending(ctx, userID)
+	if hr != nil {
+		return "", hr
+	}
+	inv, hr := user.IssueInvitation(s.tokens.TTL())
+	if hr != nil {
+		return "", hr
+	}
+	if err := s.userRepo.UpdateInvitation(ctx, user); err != nil {
+		return "", herrors.NewServerHError(err)
+	}
+	link, err := s.tokens.Link(inv)
+	if err != nil {
+		return "", herrors.NewServerHError(err)
+	}
+	event := domanevent.NewUserInvitationEvent(user.TenantID, user.ID, user.Email, link, inv.ExpireAt, domanevent.UserInvitationResent)
+	if hr := s.publish(ctx, event); hr != nil {
+		return "", hr
+	}
+	return link, nil
+}
+
+// Revoke 撤销邀请, 删除待激活用户
+func (s *UserInvitationService) Revoke(ctx context.Context, userID string) herrors.Herr {
+	user, hr := s.findPending(ctx, userID)
+	if hr != nil {
+		return hr
+	}
+	if err := s.userRepo.Delete(ctx, userID); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	event := domanevent.NewUserInvitationEvent(user.TenantID, user.ID, user.Email, "", 0, domanevent.UserInvitationRevoked)
+	if hr := s.publish(ctx, event); hr != nil {
+		return hr
+	}
+	return s.publish(ctx, domanevent.NewUserEvent(user.TenantID, user.ID, domanevent.UserDeleted))
+}
+
+// Preview 校验邀请令牌并返回被邀请用户, 供接受邀请页面展示
+func (s *UserInvitationService) Preview(ctx context.Context, token string) (*model.User, herrors.Herr) {
+	inv, err := s.tokens.Parse(token)
+	if err != nil {
+		return nil, errors.UserInvitationInvalid(err.Error())
+	}
+	user, hr := s.findPending(actx.BuildTenantCtx(ctx, inv.TenantID), inv.UserID)
+	if hr != nil {
+		return nil, errors.UserInvitationInvalid("invitation has been accepted or revoked")
+	}
+	if user.InvitationCode != inv.Code {
+		return nil, errors.UserInvitationInvalid("invitation has been replaced or revoked")
+	}
+	return user, nil
+}
+
+// Accept 接受邀请, 设置密码并启用用户
+func (s *UserInvitationService) Accept(ctx context.Context, token, password string) herrors.Herr {
+	inv, err := s.tokens.Parse(token)
+	if err != nil {
+		return errors.UserInvitationInvalid(err.Error())
+	}
+	ctx = actx.BuildTenantCtx(ctx, inv.TenantID)
+	user, err := s.userRepo.FindByID(ctx, inv.UserID)
+	if err != nil || user == nil {
+		return errors.UserInvitationInvalid("invitation has been revoked")
+	}
+	if hr := user.AcceptInvitation(inv.Code, password); hr != nil {
+		return hr
+	}
+	if err := s.userRepo.UpdateInvitation(ctx, user); err != nil {
+		return herrors.NewServerHError(err)
+	}
+
+	event := domanevent.NewUserInvitationEvent(user.TenantID, user.ID, user.Email, "", 0, domanevent.UserInvitationAccepted)
+	if hr := s.publish(ctx, event); hr != nil {
+		return hr
+	}
+	return s.publish(ctx, domanevent.NewUserEvent(user.TenantID, user.ID, domanevent.UserUpdated))
+}
+
+func (s *UserInvitationService) findPending(ctx context.Context, userID string) (*model.User, herrors.Herr) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	if user == nil {
+		return nil, errors.UserNotFound(userID)
+	}
+	if !isHomeTenant(ctx, user.TenantID) {
+		return nil, errors.UserNotHomeTenant(userID)
+	}
+	if !user.IsPending() {
+		return nil, errors.UserNotPending(userID)
+	}
+	return user, nil
+}
+
+func (s *UserInvitationService) publish(ctx context.Context, event events.Event) herrors.Herr {
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/plugin"
+
 	"github.com/ares-cloud/ares-ddd-admin/pkg/events"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
 
@@ -14,17 +17,32 @@ import (
 )
 
 type UserCommandService struct {
-	userRepo repository.IUserRepository
-	eventBus events.IEventBus
+	userRepo    repository.IUserRepository
+	tenantRepo  repository.ITenantRepository
+	recycleRepo repository.IRecycleBinRepository
+	roleRepo    repository.IRoleRepository
+	grantRepo   repository.IRoleGrantRepository
+	constraints *RoleConstraintService
+	eventBus    events.IEventBus
 }
 
 func NewUserCommandService(
 	userRepo repository.IUserRepository,
+	tenantRepo repository.ITenantRepository,
+	recycleRepo repository.IRecycleBinRepository,
+	roleRepo repository.IRoleRepository,
+	grantRepo repository.IRoleGrantRepository,
+	constraints *RoleConstraintService,
 	eventBus events.IEventBus,
 ) *UserCommandService {
 	return &UserCommandService{
-		userRepo: userRepo,
-		eventBus: eventBus,
+		userRepo:    userRepo,
+		tenantRepo:  tenantRepo,
+		recycleRepo: recycleRepo,
+		roleRepo:    roleRepo,
+		grantRepo:   grantRepo,
+		constraints: constraints,
+		eventBus:    eventBus,
 	}
 }
 
@@ -62,6 +80,10 @@ func (s *UserCommandService) UpdateUser(ctx context.Context, user *model.User) h
 	if exists == nil {
 		return errors.UserNotFound(user.ID)
 	}
+	// 其他租户的成员只能维护其在本租户的角色和部门, 不能修改用户本身
+	if !isHomeTenant(ctx, exists.TenantID) {
+		return errors.UserNotHomeTenant(user.ID)
+	}
 
 	// 更新用户
 	if err := s.userRepo.Update(ctx, user); err != nil {
@@ -77,6 +99,7 @@ func (s *UserCommandService) UpdateUser(ctx context.Context, user *model.User) h
 }
 
 // AssignRoles 分配角色
+// 用户尚未拥有且需要审批的角色不直接分配, 为其创建待审批的角色申请
 func (s *UserCommandService) AssignRoles(ctx context.Context, userID string, roleIDs []int64) herrors.Herr {
 	// 检查用户是否存在
 	user, err := s.userRepo.FindByID(ctx, userID)
@@ -87,8 +110,32 @@ func (s *UserCommandService) AssignRoles(ctx context.Context, userID string, rol
 		return errors.UserNotFound(userID)
 	}
 
+	granted := make([]int64, 0, len(roleIDs))
+	for _, roleID := range roleIDs {
+		if user.HasRole(roleID) {
+			granted = append(granted, roleID)
+			continue
+		}
+		role, hr := findRole(ctx, s.roleRepo, roleID)
+		if hr != nil {
+			return hr
+		}
+		if !role.RequiresApproval {
+			granted = append(granted, roleID)
+			continue
+		}
+		if hr := requestRole(ctx, s.grantRepo, s.eventBus, userID, roleID); hr != nil {
+			return hr
+		}
+	}
+
+	// 校验职责分离约束
+	if hr := s.constraints.CheckAssignment(ctx, userID, granted, true); hr != nil {
+		return hr
+	}
+
 	// 分配角色
-	if err := s.userRepo.AssignRoles(ctx, userID, roleIDs); err != nil {
+	if err := s.userRepo.AssignRoles(ctx, userID, granted); err != nil {
 		return herrors.NewServerHError(err)
 	}
 
@@ -111,8 +158,13 @@ func (s *UserCommandService) DeleteUser(ctx context.Context, userID string) herr
 		return errors.UserNotFound(userID)
 	}
 
-	// 删除用户
-	if err := s.userRepo.Delete(ctx, userID); err != nil {
+	// 其他租户的成员只移除成员关系, 不删除用户
+	if tenantID := plugin.GetCtxTenantID(ctx); tenantID != "" && user.TenantID != tenantID {
+		return s.RemoveTenantMember(ctx, tenantID, userID)
+	}
+
+	// 移入回收站, 角色和部门关系随快照保存
+	if err := s.recycleRepo.Recycle(ctx, model.RecycleEntityUser, userID, actx.GetUserId(ctx)); err != nil {
 		return herrors.NewServerHError(err)
 	}
 
@@ -155,3 +207,123 @@ func (s *UserCommandService) GetUser(ctx context.Context, userID string) (*model
 	}
 	return user, nil
 }
+
+// AddTenantMember 邀请其他租户的用户加入租户, 用户本人接受后成为成员, 在该租户拥有独立的角色和部门
+func (s *UserCommandService) AddTenantMember(ctx context.Context, tenantID, homeTenantCode, username string) herrors.Herr {
+	// 查找用户归属租户
+	home, err := s.tenantRepo.FindByCode(actx.BuildIgnoreTenantCtx(ctx), homeTenantCode)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if home == nil {
+		return errors.TenantNotFound(homeTenantCode)
+	}
+	// 在归属租户下查找用户
+	user, err := s.userRepo.FindByUsername(actx.BuildTenantCtx(ctx, home.ID), username)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if user == nil {
+		return errors.UserNotFound(username)
+	}
+	if user.TenantID == tenantID {
+		return errors.UserAlreadyMember(user.ID, tenantID)
+	}
+	exists, err := s.userRepo.IsTenantMember(ctx, user.ID, tenantID)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if exists {
+		return errors.UserAlreadyMember(user.ID, tenantID)
+	}
+	invited, err := s.userRepo.IsTenantInvited(ctx, user.ID, tenantID)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if invited {
+		return errors.TenantMemberInvited(user.ID, tenantID)
+	}
+
+	if err := s.userRepo.InviteTenantMember(ctx, user.ID, tenantID, actx.GetUserId(ctx)); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// AcceptTenantInvitation 用户接受加入租户的邀请
+func (s *UserCommandService) AcceptTenantInvitation(ctx context.Context, userID, tenantID string) herrors.Herr {
+	ok, err := s.userRepo.AcceptTenantMember(ctx, userID, tenantID)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if !ok {
+		return errors.TenantMemberNotInvited(userID, tenantID)
+	}
+
+	// 发布加入租户事件
+	event := domanevent.NewUserEvent(tenantID, userID, domanevent.UserJoinTenant)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// DeclineTenantInvitation 用户拒绝加入租户的邀请
+func (s *UserCommandService) DeclineTenantInvitation(ctx context.Context, userID, tenantID string) herrors.Herr {
+	invited, err := s.userRepo.IsTenantInvited(ctx, userID, tenantID)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if !invited {
+		return errors.TenantMemberNotInvited(userID, tenantID)
+	}
+	if err := s.userRepo.RemoveTenantMember(ctx, userID, tenantID); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// RemoveTenantMember 移除租户成员, 或撤销尚未接受的邀请
+func (s *UserCommandService) RemoveTenantMember(ctx context.Context, tenantID string, userID string) herrors.Herr {
+	exists, err := s.userRepo.IsTenantMember(ctx, userID, tenantID)
+	if err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if !exists {
+		invited, err := s.userRepo.IsTenantInvited(ctx, userID, tenantID)
+		if err != nil {
+			return herrors.NewServerHError(err)
+		}
+		if !invited {
+			return errors.UserNotMember(userID, tenantID)
+		}
+	}
+	if err := s.userRepo.RemoveTenantMember(ctx, userID, tenantID); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	if !exists {
+		return nil
+	}
+
+	// 发布离开租户事件
+	event := domanevent.NewUserEvent(tenantID, userID, domanevent.UserLeaveTenant)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return herrors.NewServerHError(err)
+	}
+	return nil
+}
+
+// GetUserTenantIDs 获取用户可访问的租户ID列表, 第一个为归属租户
+func (s *UserCommandService) GetUserTenantIDs(ctx context.Context, user *model.User) ([]string, herrors.Herr) {
+	memberIDs, err := s.userRepo.GetMemberTenantIDs(ctx, user.ID)
+	if err != nil {
+		return nil, herrors.NewServerHError(err)
+	}
+	return append([]string{user.TenantID}, memberIDs...), nil
+}
+
+// isHomeTenant 判断当前租户是否为用户的归属租户, 未指定租户或忽略租户时不限制
+func isHomeTenant(ctx context.Context, userTenantID string) bool {
+	tenantID := plugin.GetCtxTenantID(ctx)
+	return tenantID == "" || plugin.IsIgnoreTenant(ctx) || tenantID == userTenantID
+}
@@ -7,10 +7,22 @@ import (
 
 var ProviderSet = wire.NewSet(
 	service.NewAuthService,
+	service.NewProfileService,
 	service.NewRoleCommandService,
 	service.NewPermissionService,
 	service.NewTenantCommandService,
+	service.NewTenantJobService,
+	service.NewTenantTemplateService,
 	service.NewDepartmentService,
+	service.NewPositionService,
 	service.NewUserCommandService,
+	service.NewUserImportService,
+	service.NewUserInvitationService,
+	service.NewScimTokenService,
+	service.NewRecycleBinService,
+	service.NewRoleGrantService,
+	service.NewRoleConstraintService,
+	service.NewAccessReviewService,
+	service.NewImpersonationService,
 	service.NewDataPermissionService,
 )
@@ -0,0 +1,31 @@
+// Package avatar 通过存储模块保存用户头像
+package avatar
+
+import (
+	"context"
+	"io"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/storage/domain/service"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+)
+
+// rootFolderID 存储模块根目录
+const rootFolderID = "0"
+
+// StorageAvatar 头像保存在当前租户的存储根目录
+type StorageAvatar struct {
+	storageService *service.StorageService
+}
+
+func NewStorageAvatar(storageService *service.StorageService) repository.IAvatarStorage {
+	return &StorageAvatar{storageService: storageService}
+}
+
+func (s *StorageAvatar) Upload(ctx context.Context, reader io.Reader, fileName string, size int64, userID string) (string, error) {
+	file, herr := s.storageService.UploadFile(ctx, reader, fileName, size, rootFolderID, actx.GetTenantId(ctx), userID)
+	if herr != nil {
+		return "", herr
+	}
+	return file.URL, nil
+}
@@ -3,7 +3,11 @@ package casbin
 import (
 	"context"
 	"fmt"
+	"strconv"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
 	psb "github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/casbin"
 	"github.com/cloudwego/hertz/pkg/common/hlog"
 )
@@ -20,15 +24,47 @@ func NewRepositoryImpl(rr repository.ISysRoleRepo, pr repository.IPermissionsRep
 	}
 }
 
-// FindAllEnabled 获取所有启用的角色及其权限
-func (r *RepositoryImpl) FindAllEnabled(ctx context.Context) ([]*psb.Role, error) {
-	ctx = context.Background()
-	// 获取所有启用的角色
-	roles, err := r.rr.FindAllEnabled(ctx)
+// FindEnabledByTenant 获取租户下所有启用的角色及其权限
+// 独立 Schema/数据库的租户的角色在租户库中, 按租户路由查询
+func (r *RepositoryImpl) FindEnabledByTenant(ctx context.Context, tenantID string) ([]*psb.Role, error) {
+	ctx = actx.BuildTenantCtx(ctx, tenantID)
+	roles, err := r.rr.FindEnabledByTenant(ctx, tenantID)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "casbin [FindEnabledByTenant] error: %v", err)
+		return nil, err
+	}
+	return r.toCasbinRoles(ctx, roles)
+}
+
+// FindEnabledRole 获取租户下启用的角色及其权限, 角色不存在、已删除或已禁用时返回空
+// tenantID 为空时只在主库中查询
+func (r *RepositoryImpl) FindEnabledRole(ctx context.Context, tenantID, roleID string) (*psb.Role, error) {
+	if tenantID == "" {
+		ctx = actx.BuildIgnoreTenantCtx(ctx)
+	} else {
+		ctx = actx.BuildTenantCtx(ctx, tenantID)
+	}
+	id, err := strconv.ParseInt(roleID, 10, 64)
 	if err != nil {
-		hlog.CtxErrorf(ctx, "casbin [FindAllEnabled] error: %v", err)
+		return nil, fmt.Errorf("invalid role id: %s", roleID)
+	}
+	roles, err := r.rr.FindByIds(ctx, []int64{id})
+	if err != nil {
+		hlog.CtxErrorf(ctx, "casbin [FindEnabledRole] error: %v", err)
 		return nil, err
 	}
+	if len(roles) == 0 || roles[0].Status != 1 || roles[0].DeletedAt != 0 {
+		return nil, nil
+	}
+	result, err := r.toCasbinRoles(ctx, roles)
+	if err != nil {
+		return nil, err
+	}
+	return result[0], nil
+}
+
+// toCasbinRoles 转换为 casbin 角色格式并加载权限资源
+func (r *RepositoryImpl) toCasbinRoles(ctx context.Context, roles []*entity.Role) ([]*psb.Role, error) {
 	if len(roles) == 0 {
 		return []*psb.Role{}, nil
 	}
@@ -39,8 +75,11 @@ func (r *RepositoryImpl) FindAllEnabled(ctx context.Context) ([]*psb.Role, error
 		roleIds[i] = role.ID
 	}
 	roleResourcesMap, err := r.pr.GetResourcesByRolesGrouped(ctx, roleIds)
-	// 转换为 casbin 角色格式
-	var casbinRoles []*psb.Role
+	if err != nil {
+		hlog.CtxErrorf(ctx, "casbin [GetResourcesByRolesGrouped] error: %v", err)
+		return nil, err
+	}
+	casbinRoles := make([]*psb.Role, 0, len(roles))
 	for _, role := range roles {
 		casbinRole := &psb.Role{
 			Id:       fmt.Sprintf("%d", role.ID),
@@ -48,16 +87,14 @@ func (r *RepositoryImpl) FindAllEnabled(ctx context.Context) ([]*psb.Role, error
 			TenantID: role.TenantID,
 		}
 		// 添加权限
-		if resources, ok := roleResourcesMap[role.ID]; ok {
-			for _, resource := range resources {
-				casbinRole.Permissions = append(casbinRole.Permissions, psb.ApiPermissions{
-					Id:     fmt.Sprintf("%d", resource.PermissionsID),
-					Method: resource.Method,
-					Path:   resource.Path,
-				})
-			}
+		for _, resource := range roleResourcesMap[role.ID] {
+			casbinRole.Permissions = append(casbinRole.Permissions, psb.ApiPermissions{
+				Id:        fmt.Sprintf("%d", resource.PermissionsID),
+				Method:    resource.Method,
+				Path:      resource.Path,
+				Condition: resource.Condition,
+			})
 		}
-
 		casbinRoles = append(casbinRoles, casbinRole)
 	}
 
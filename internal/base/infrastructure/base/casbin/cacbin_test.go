@@ -0,0 +1,92 @@
+package casbin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/plugin"
+)
+
+// isolatedRoleRepo 模拟独立数据库的租户, 只有按该租户路由的查询才能查到角色
+type isolatedRoleRepo struct {
+	repository.ISysRoleRepo
+	tenantID string
+	roles    []*entity.Role
+}
+
+func (f *isolatedRoleRepo) routed(ctx context.Context) bool {
+	return !plugin.IsIgnoreTenant(ctx) && plugin.GetCtxTenantID(ctx) == f.tenantID
+}
+
+func (f *isolatedRoleRepo) FindEnabledByTenant(ctx context.Context, _ string) ([]*entity.Role, error) {
+	if !f.routed(ctx) {
+		return nil, nil
+	}
+	return f.roles, nil
+}
+
+func (f *isolatedRoleRepo) FindByIds(ctx context.Context, ids []int64) ([]*entity.Role, error) {
+	if !f.routed(ctx) {
+		return nil, nil
+	}
+	var result []*entity.Role
+	for _, r := range f.roles {
+		for _, id := range ids {
+			if r.ID == id {
+				result = append(result, r)
+			}
+		}
+	}
+	return result, nil
+}
+
+type isolatedPermRepo struct {
+	repository.IPermissionsRepo
+	role *isolatedRoleRepo
+}
+
+func (f *isolatedPermRepo) GetResourcesByRolesGrouped(ctx context.Context, roles []int64) (map[int64][]*entity.PermissionsResource, error) {
+	result := make(map[int64][]*entity.PermissionsResource)
+	if !f.role.routed(ctx) {
+		return result, nil
+	}
+	for _, id := range roles {
+		result[id] = []*entity.PermissionsResource{{PermissionsID: 9, Method: "GET", Path: "/api/orders"}}
+	}
+	return result, nil
+}
+
+func TestRepositoryLoadsIsolatedTenant(t *testing.T) {
+	rr := &isolatedRoleRepo{
+		tenantID: "t1",
+		roles:    []*entity.Role{{ID: 1, Code: "admin", TenantID: "t1", Status: 1}},
+	}
+	repo := NewRepositoryImpl(rr, &isolatedPermRepo{role: rr})
+
+	roles, err := repo.FindEnabledByTenant(context.Background(), "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roles) != 1 || len(roles[0].Permissions) != 1 || roles[0].Permissions[0].Path != "/api/orders" {
+		t.Fatalf("isolated tenant policies not loaded: %+v", roles)
+	}
+
+	role, err := repo.FindEnabledRole(context.Background(), "t1", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role == nil || len(role.Permissions) != 1 {
+		t.Fatalf("isolated tenant role not loaded: %+v", role)
+	}
+
+	// 未指定租户时只查询主库, 查不到独立租户的角色
+	role, err = repo.FindEnabledRole(context.Background(), "", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role != nil {
+		t.Fatalf("expected no role from the main pool, got %+v", role)
+	}
+}
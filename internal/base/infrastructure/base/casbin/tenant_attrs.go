@@ -0,0 +1,37 @@
+package casbin
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	psb "github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/casbin"
+)
+
+type TenantAttributeProviderImpl struct {
+	repo repository.ISysTenantRepo
+}
+
+func NewTenantAttributeProviderImpl(repo repository.ISysTenantRepo) psb.ITenantAttributeProvider {
+	return &TenantAttributeProviderImpl{
+		repo: repo,
+	}
+}
+
+// TenantAttributes 获取条件表达式中可引用的租户属性, 租户不存在时返回空
+func (p *TenantAttributeProviderImpl) TenantAttributes(ctx context.Context, tenantID string) (map[string]interface{}, error) {
+	tenant, err := p.repo.FindById(actx.BuildIgnoreTenantCtx(ctx), tenantID)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return map[string]interface{}{
+		psb.VarTenantCode:      tenant.Code,
+		psb.VarTenantName:      tenant.Name,
+		psb.VarTenantDomain:    tenant.Domain,
+		psb.VarTenantIsolation: tenant.IsolationMode,
+	}, nil
+}
@@ -20,7 +20,32 @@ func NewDbOperationLogWriter(repo repository.IOperationLogRepo) oplog.IDbOperati
 }
 
 func (w *DbOperationLogWriter) Save(ctx context.Context, data *oplog.OperationLog) error {
-	log := &entity.OperationLog{
+	return w.repo.Create(ctx, toOperationLogEntity(data))
+}
+
+// SaveBatch 批量写入, 部分写入失败时返回未写入的日志
+func (w *DbOperationLogWriter) SaveBatch(ctx context.Context, data []*oplog.OperationLog) error {
+	logs := make([]*entity.OperationLog, 0, len(data))
+	sources := make(map[*entity.OperationLog]*oplog.OperationLog, len(data))
+	for _, item := range data {
+		log := toOperationLogEntity(item)
+		logs = append(logs, log)
+		sources[log] = item
+	}
+	failed, err := w.repo.CreateBatch(ctx, logs)
+	if err == nil {
+		return nil
+	}
+	batchErr := &oplog.BatchError{Err: err}
+	for _, log := range failed {
+		batchErr.Failed = append(batchErr.Failed, sources[log])
+	}
+	return batchErr
+}
+
+func toOperationLogEntity(data *oplog.OperationLog) *entity.OperationLog {
+	return &entity.OperationLog{
+		RequestID: data.RequestID,
 		UserID:    data.UserID,
 		Username:  data.Username,
 		TenantID:  data.TenantID,
@@ -35,9 +60,11 @@ func (w *DbOperationLogWriter) Save(ctx context.Context, data *oplog.OperationLo
 		Duration:  data.Duration,
 		Module:    data.Module,
 		Action:    data.Action,
+
+		ImpersonatedUserID:   data.ImpersonatedUserID,
+		ImpersonatedUsername: data.ImpersonatedUsername,
 		BaseIntTime: database.BaseIntTime{
 			CreatedAt: data.CreatedAt.Unix(),
 		},
 	}
-	return w.repo.Create(ctx, log)
 }
@@ -0,0 +1,67 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/infrastructure/configs"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	ptenant "github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/tenant"
+)
+
+type ResolverImpl struct {
+	repo repository.ISysTenantRepo
+}
+
+func NewResolverImpl(repo repository.ISysTenantRepo) *ResolverImpl {
+	return &ResolverImpl{
+		repo: repo,
+	}
+}
+
+// NewCachedResolver 带缓存的租户解析器, 租户事件到达时由 TenantResolveEventHandler 清除缓存
+func NewCachedResolver(resolver *ResolverImpl, conf *configs.Bootstrap) *ptenant.CachedResolver {
+	return ptenant.NewCachedResolver(resolver, conf.Tenant.GetResolveCacheTTL(), conf.Tenant.GetResolveCacheSize())
+}
+
+// ResolveByCode 根据租户编码解析租户
+func (r *ResolverImpl) ResolveByCode(ctx context.Context, code string) (*ptenant.TenantInfo, error) {
+	tenant, err := r.repo.GetByCode(actx.BuildIgnoreTenantCtx(ctx), code)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toTenantInfo(tenant), nil
+}
+
+// ResolveByDomain 根据自定义域名解析租户
+func (r *ResolverImpl) ResolveByDomain(ctx context.Context, domain string) (*ptenant.TenantInfo, error) {
+	tenant, err := r.repo.GetByDomain(actx.BuildIgnoreTenantCtx(ctx), domain)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toTenantInfo(tenant), nil
+}
+
+func toTenantInfo(t *entity.Tenant) *ptenant.TenantInfo {
+	// 尚未开通完成的租户不可访问
+	if t == nil || t.DeletedAt > 0 || t.ProvisionStatus != model.ProvisionReady {
+		return nil
+	}
+	return &ptenant.TenantInfo{
+		ID:         t.ID,
+		Code:       t.Code,
+		Name:       t.Name,
+		Locked:     t.Status == model.StatusDisabled,
+		LockReason: t.LockReason,
+		ExpireTime: t.ExpireTime,
+	}
+}
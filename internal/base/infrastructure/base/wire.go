@@ -3,10 +3,16 @@ package base
 import (
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/base/casbin"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/base/oplog"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/base/tenant"
+	ptenant "github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/tenant"
 	"github.com/google/wire"
 )
 
 var ProviderSet = wire.NewSet(
 	casbin.NewRepositoryImpl,
+	casbin.NewTenantAttributeProviderImpl,
 	oplog.NewDbOperationLogWriter,
+	tenant.NewResolverImpl,
+	tenant.NewCachedResolver,
+	wire.Bind(new(ptenant.ITenantResolver), new(*ptenant.CachedResolver)),
 )
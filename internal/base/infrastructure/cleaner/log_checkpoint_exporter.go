@@ -0,0 +1,90 @@
+package cleaner
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
+	"github.com/ares-cloud/ares-ddd-admin/internal/infrastructure/configs"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hashchain"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// LogCheckpointExporter 定期导出日志哈希链的签名检查点, 导出的文件应复制到系统之外保存
+type LogCheckpointExporter struct {
+	query      query.ILogChainQuery
+	signingKey string
+	dir        string
+	// 导出间隔
+	interval time.Duration
+	// 停止信号
+	stopChan chan struct{}
+}
+
+func NewLogCheckpointExporter(query query.ILogChainQuery, conf *configs.Bootstrap) *LogCheckpointExporter {
+	return &LogCheckpointExporter{
+		query:      query,
+		signingKey: conf.LogChain.GetSigningKey(),
+		dir:        conf.LogChain.GetCheckpointDir(),
+		interval:   conf.LogChain.GetCheckpointInterval(),
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// Start 启动导出任务, 未配置签名私钥时不导出
+func (e *LogCheckpointExporter) Start() {
+	if e.signingKey == "" {
+		hlog.Info("log_chain.signing_key is not configured, log chain checkpoints will not be exported")
+		return
+	}
+	key, err := hashchain.ParseSigningKey(e.signingKey)
+	if err != nil {
+		hlog.Errorf("log chain checkpoint exporter disabled: %v", err)
+		return
+	}
+	ticker := time.NewTicker(e.interval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				e.export(key)
+			case <-e.stopChan:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止导出任务
+func (e *LogCheckpointExporter) Stop() {
+	close(e.stopChan)
+}
+
+func (e *LogCheckpointExporter) export(key ed25519.PrivateKey) {
+	ctx := context.Background()
+	cp, err := e.query.Checkpoint(ctx, key)
+	if err != nil {
+		hlog.Errorf("create log chain checkpoint error: %v", err)
+		return
+	}
+	content, err := hashchain.MarshalCheckpoint(cp)
+	if err != nil {
+		hlog.Errorf("marshal log chain checkpoint error: %v", err)
+		return
+	}
+	if err := os.MkdirAll(e.dir, 0o755); err != nil {
+		hlog.Errorf("create checkpoint dir error: %v", err)
+		return
+	}
+	name := filepath.Join(e.dir, fmt.Sprintf("checkpoint_%s.json", time.Unix(cp.CreatedAt, 0).Format("20060102T150405")))
+	if err := os.WriteFile(name, content, 0o644); err != nil {
+		hlog.Errorf("write log chain checkpoint error: %v", err)
+		return
+	}
+	hlog.Infof("exported log chain checkpoint of %d chains to %s", len(cp.Heads), name)
+}
@@ -0,0 +1,70 @@
+package cleaner
+
+import (
+	"context"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/infrastructure/configs"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// RecycleCleaner 用户、角色、部门回收站清理任务
+type RecycleCleaner struct {
+	service *service.RecycleBinService
+	tenants repository.ISysTenantRepo
+	// 保留天数
+	retentionDays int
+	// 清理间隔
+	interval time.Duration
+	// 停止信号
+	stopChan chan struct{}
+}
+
+func NewRecycleCleaner(service *service.RecycleBinService, tenants repository.ISysTenantRepo, conf *configs.Bootstrap) *RecycleCleaner {
+	return &RecycleCleaner{
+		service:       service,
+		tenants:       tenants,
+		retentionDays: conf.RecycleBin.GetRetentionDays(),
+		interval:      conf.RecycleBin.GetInterval(),
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// Start 启动清理任务
+func (c *RecycleCleaner) Start() {
+	ticker := time.NewTicker(c.interval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				c.clean()
+			case <-c.stopChan:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止清理任务
+func (c *RecycleCleaner) Stop() {
+	close(c.stopChan)
+}
+
+// clean 彻底删除主库和各独立存储租户中超过保留天数的记录
+func (c *RecycleCleaner) clean() {
+	// 计算过期时间
+	expireTime := time.Now().AddDate(0, 0, -c.retentionDays)
+
+	forEachStore(context.Background(), c.tenants, func(ctx context.Context) {
+		purged, err := c.service.PurgeExpired(ctx, expireTime)
+		if err != nil {
+			hlog.CtxErrorf(ctx, "purge expired recycle bin error: %v", err)
+		}
+		if purged > 0 {
+			hlog.CtxInfof(ctx, "purged %d expired recycle bin items", purged)
+		}
+	})
+}
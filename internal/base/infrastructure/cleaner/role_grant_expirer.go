@@ -0,0 +1,71 @@
+package cleaner
+
+import (
+	"context"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/infrastructure/configs"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/token"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// RoleGrantExpirer 限时角色到期任务, 收回过期的角色并使相关用户的会话失效
+type RoleGrantExpirer struct {
+	service *service.RoleGrantService
+	tenants repository.ISysTenantRepo
+	// 检查间隔
+	interval time.Duration
+	// 停止信号
+	stopChan chan struct{}
+}
+
+func NewRoleGrantExpirer(service *service.RoleGrantService, tenants repository.ISysTenantRepo, conf *configs.Bootstrap) *RoleGrantExpirer {
+	return &RoleGrantExpirer{
+		service:  service,
+		tenants:  tenants,
+		interval: conf.RoleGrant.GetExpireInterval(),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start 启动到期任务, 用户的令牌由 tk 管理
+func (e *RoleGrantExpirer) Start(tk token.IToken) {
+	ticker := time.NewTicker(e.interval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				e.expire(tk)
+			case <-e.stopChan:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止到期任务
+func (e *RoleGrantExpirer) Stop() {
+	close(e.stopChan)
+}
+
+// expire 收回主库和各独立存储租户的过期角色, 令牌中携带角色编码, 删除用户令牌使其重新登录
+func (e *RoleGrantExpirer) expire(tk token.IToken) {
+	now := time.Now()
+	forEachStore(context.Background(), e.tenants, func(ctx context.Context) {
+		userIDs, err := e.service.ExpireGrants(ctx, now)
+		if err != nil {
+			hlog.CtxErrorf(ctx, "expire role grants error: %v", err)
+		}
+		for _, userID := range userIDs {
+			if err := tk.DelUserToken(userID); err != nil {
+				hlog.CtxErrorf(ctx, "revoke tokens of user %s error: %v", userID, err)
+			}
+		}
+		if len(userIDs) > 0 {
+			hlog.CtxInfof(ctx, "expired role grants of %d users", len(userIDs))
+		}
+	})
+}
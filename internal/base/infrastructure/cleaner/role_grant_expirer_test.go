@@ -0,0 +1,105 @@
+package cleaner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	drepository "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/service"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/infrastructure/configs"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/plugin"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/events"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/token"
+)
+
+// storeGrantRepo 按存储保存授予记录, 主库的键为空字符串, 租户库的键为租户ID
+type storeGrantRepo struct {
+	drepository.IRoleGrantRepository
+	stores map[string][]*model.RoleGrant
+}
+
+func (f *storeGrantRepo) store(ctx context.Context) string {
+	if plugin.IsIgnoreTenant(ctx) {
+		return ""
+	}
+	return plugin.GetCtxTenantID(ctx)
+}
+
+func (f *storeGrantRepo) FindExpired(ctx context.Context, now int64, limit int) ([]*model.RoleGrant, error) {
+	var result []*model.RoleGrant
+	for _, g := range f.stores[f.store(ctx)] {
+		if g.ExpireTime > 0 && g.ExpireTime <= now && len(result) < limit {
+			result = append(result, g)
+		}
+	}
+	return result, nil
+}
+
+func (f *storeGrantRepo) DeleteByIDs(ctx context.Context, ids []int64) error {
+	key := f.store(ctx)
+	var kept []*model.RoleGrant
+	for _, g := range f.stores[key] {
+		deleted := false
+		for _, id := range ids {
+			if g.ID == id {
+				deleted = true
+			}
+		}
+		if !deleted {
+			kept = append(kept, g)
+		}
+	}
+	f.stores[key] = kept
+	return nil
+}
+
+type fakeTenantRepo struct {
+	repository.ISysTenantRepo
+	isolated []*database.TenantIsolation
+}
+
+func (f *fakeTenantRepo) ListIsolated(_ context.Context) ([]*database.TenantIsolation, error) {
+	return f.isolated, nil
+}
+
+type nopEventBus struct{}
+
+func (nopEventBus) Subscribe(string, events.EventHandler) error { return nil }
+func (nopEventBus) Publish(context.Context, events.Event) error { return nil }
+
+type recordToken struct {
+	token.IToken
+	users []string
+}
+
+func (f *recordToken) DelUserToken(userID string) error {
+	f.users = append(f.users, userID)
+	return nil
+}
+
+func TestRoleGrantExpirerIsolatedTenant(t *testing.T) {
+	expired := time.Now().Add(-time.Hour).Unix()
+	grants := &storeGrantRepo{stores: map[string][]*model.RoleGrant{
+		"":   {{ID: 1, TenantID: "shared", UserID: "u1", RoleID: 1, ExpireTime: expired}},
+		"t1": {{ID: 2, TenantID: "t1", UserID: "u2", RoleID: 2, ExpireTime: expired}},
+	}}
+	tenants := &fakeTenantRepo{isolated: []*database.TenantIsolation{
+		{TenantID: "t1", Mode: database.IsolationDatabase, Source: "dsn"},
+	}}
+	svc := service.NewRoleGrantService(grants, nil, nil, nil, nopEventBus{})
+	e := NewRoleGrantExpirer(svc, tenants, &configs.Bootstrap{})
+
+	tk := &recordToken{}
+	e.expire(tk)
+
+	if len(grants.stores[""]) != 0 || len(grants.stores["t1"]) != 0 {
+		t.Fatalf("expired grants not removed: %+v", grants.stores)
+	}
+	if len(tk.users) != 2 || tk.users[0] != "u1" || tk.users[1] != "u2" {
+		t.Fatalf("tokens of expired users not revoked: %v", tk.users)
+	}
+}
@@ -0,0 +1,27 @@
+package cleaner
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// forEachStore 依次在主库和每个独立 Schema/数据库租户的租户库上执行 fn
+// 主库在忽略租户的上下文中执行, 覆盖共享存储的全部租户; 独立存储的租户在各自的租户上下文中执行
+func forEachStore(ctx context.Context, tenants repository.ISysTenantRepo, fn func(ctx context.Context)) {
+	fn(actx.BuildIgnoreTenantCtx(ctx))
+
+	isos, err := tenants.ListIsolated(actx.BuildIgnoreTenantCtx(ctx))
+	if err != nil {
+		hlog.Errorf("list isolated tenants error: %v", err)
+		return
+	}
+	for _, iso := range isos {
+		if !iso.IsIsolated() {
+			continue
+		}
+		fn(actx.BuildTenantCtx(ctx, iso.TenantID))
+	}
+}
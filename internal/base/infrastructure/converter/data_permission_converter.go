@@ -20,10 +20,15 @@ func (c *DataPermissionConverter) ToDTO(perm *entity.DataPermission) *dto.DataPe
 	if perm.DeptIDs != "" {
 		deptIds = strings.Split(perm.DeptIDs, ",")
 	}
+	positionIds := make([]string, 0)
+	if perm.PositionIDs != "" {
+		positionIds = strings.Split(perm.PositionIDs, ",")
+	}
 	return &dto.DataPermissionDto{
-		ID:      perm.ID,
-		RoleID:  perm.RoleID,
-		Scope:   perm.Scope,
-		DeptIDs: deptIds,
+		ID:          perm.ID,
+		RoleID:      perm.RoleID,
+		Scope:       perm.Scope,
+		DeptIDs:     deptIds,
+		PositionIDs: positionIds,
 	}
 }
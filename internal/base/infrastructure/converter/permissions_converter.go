@@ -22,8 +22,9 @@ func (c *PermissionsConverter) ToDTO(p *entity.Permissions, es []*entity.Permiss
 	if len(es) > 0 {
 		for _, r := range es {
 			resources = append(resources, &dto.PermissionsResourceDto{
-				Method: r.Method,
-				Path:   r.Path,
+				Method:    r.Method,
+				Path:      r.Path,
+				Condition: r.Condition,
 			})
 		}
 	}
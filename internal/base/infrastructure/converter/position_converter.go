@@ -0,0 +1,60 @@
+package converter
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+)
+
+type PositionConverter struct{}
+
+func NewPositionConverter() *PositionConverter {
+	return &PositionConverter{}
+}
+
+// ToDTO 将实体转换为DTO
+func (c *PositionConverter) ToDTO(position *entity.Position) *dto.PositionDto {
+	if position == nil {
+		return nil
+	}
+	return &dto.PositionDto{
+		ID:          position.ID,
+		Code:        position.Code,
+		Name:        position.Name,
+		Sequence:    position.Sequence,
+		Status:      position.Status,
+		Description: position.Description,
+		CreatedAt:   position.CreatedAt,
+		UpdatedAt:   position.UpdatedAt,
+	}
+}
+
+// ToDTOList 将实体列表转换为DTO列表
+func (c *PositionConverter) ToDTOList(positions []*entity.Position) []*dto.PositionDto {
+	dtos := make([]*dto.PositionDto, 0, len(positions))
+	for _, position := range positions {
+		if dto := c.ToDTO(position); dto != nil {
+			dtos = append(dtos, dto)
+		}
+	}
+	return dtos
+}
+
+// ToUserPositionDTOList 将用户岗位关联转换为DTO列表, 按关联顺序输出
+func (c *PositionConverter) ToUserPositionDTOList(relations []*entity.UserPosition, positions []*entity.Position) []*dto.UserPositionDto {
+	positionMap := make(map[string]*entity.Position, len(positions))
+	for _, position := range positions {
+		positionMap[position.ID] = position
+	}
+	dtos := make([]*dto.UserPositionDto, 0, len(relations))
+	for _, relation := range relations {
+		position, ok := positionMap[relation.PositionID]
+		if !ok {
+			continue
+		}
+		dtos = append(dtos, &dto.UserPositionDto{
+			PositionDto: *c.ToDTO(position),
+			IsPrimary:   relation.IsPrimary,
+		})
+	}
+	return dtos
+}
@@ -17,18 +17,19 @@ func (c *RoleConverter) ToDTO(role *entity.Role, permIds []int64) *dto.RoleDto {
 		return nil
 	}
 	return &dto.RoleDto{
-		ID:          role.ID,
-		Code:        role.Code,
-		Name:        role.Name,
-		Type:        role.Type,
-		Localize:    role.Localize,
-		Description: role.Description,
-		Sequence:    role.Sequence,
-		Status:      role.Status,
-		PermIds:     permIds,
-		TenantID:    role.TenantID,
-		CreatedAt:   role.CreatedAt,
-		UpdatedAt:   role.UpdatedAt,
+		ID:               role.ID,
+		Code:             role.Code,
+		Name:             role.Name,
+		Type:             role.Type,
+		Localize:         role.Localize,
+		Description:      role.Description,
+		Sequence:         role.Sequence,
+		Status:           role.Status,
+		PermIds:          permIds,
+		RequiresApproval: role.RequiresApproval,
+		TenantID:         role.TenantID,
+		CreatedAt:        role.CreatedAt,
+		UpdatedAt:        role.UpdatedAt,
 	}
 }
 
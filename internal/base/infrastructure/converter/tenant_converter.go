@@ -1,6 +1,8 @@
 package converter
 
 import (
+	"encoding/json"
+
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
 )
@@ -31,7 +33,13 @@ func (c *TenantConverter) ToDTO(t *entity.Tenant, adminUser *entity.SysUser) *dt
 		Status:      t.Status,
 		ExpireTime:  t.ExpireTime,
 		CreatedAt:   t.CreatedAt,
-		UpdatedAt:   t.UpdatedAt,
+
+		IsolationMode: t.IsolationMode,
+		DbSchema:      t.DbSchema,
+		UpdatedAt:     t.UpdatedAt,
+
+		ProvisionStatus: t.ProvisionStatus,
+		ProvisionError:  t.ProvisionError,
 	}
 
 	// 转换管理员用户
@@ -52,3 +60,65 @@ func (c *TenantConverter) ToDTOList(tenants []*entity.Tenant) []*dto.TenantDto {
 	}
 	return dtos
 }
+
+// ToJobDTO 将租户任务实体转换为DTO
+func (c *TenantConverter) ToJobDTO(j *entity.TenantJob) *dto.TenantJobDto {
+	if j == nil {
+		return nil
+	}
+	res := &dto.TenantJobDto{
+		ID:           j.ID,
+		TenantID:     j.TenantID,
+		TenantCode:   j.TenantCode,
+		Type:         j.Type,
+		Status:       j.Status,
+		Progress:     j.Progress,
+		Step:         j.Step,
+		FileSize:     j.FileSize,
+		Downloadable: j.FilePath != "",
+		Error:        j.Error,
+		Creator:      j.Creator,
+		CreatedAt:    j.CreatedAt,
+		FinishedAt:   j.FinishedAt,
+		FilePath:     j.FilePath,
+	}
+	if j.Report != "" {
+		res.Report = json.RawMessage(j.Report)
+	}
+	return res
+}
+
+// ToJobDTOList 将租户任务实体列表转换为DTO列表
+func (c *TenantConverter) ToJobDTOList(jobs []*entity.TenantJob) []*dto.TenantJobDto {
+	res := make([]*dto.TenantJobDto, 0, len(jobs))
+	for _, j := range jobs {
+		res = append(res, c.ToJobDTO(j))
+	}
+	return res
+}
+
+// ToTemplateDTO 将租户模板实体转换为DTO
+func (c *TenantConverter) ToTemplateDTO(t *entity.TenantTemplate) *dto.TenantTemplateDto {
+	if t == nil {
+		return nil
+	}
+	return &dto.TenantTemplateDto{
+		ID:             t.ID,
+		Name:           t.Name,
+		Description:    t.Description,
+		Format:         t.Format,
+		Content:        t.Content,
+		SourceTenantID: t.SourceTenantID,
+		CreatedAt:      t.CreatedAt,
+		UpdatedAt:      t.UpdatedAt,
+	}
+}
+
+// ToTemplateDTOList 将租户模板实体列表转换为DTO列表
+func (c *TenantConverter) ToTemplateDTOList(list []*entity.TenantTemplate) []*dto.TenantTemplateDto {
+	res := make([]*dto.TenantTemplateDto, 0, len(list))
+	for _, t := range list {
+		res = append(res, c.ToTemplateDTO(t))
+	}
+	return res
+}
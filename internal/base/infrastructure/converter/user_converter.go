@@ -28,6 +28,7 @@ func (c *UserConverter) ToDTO(user *entity.SysUser, roleIds []int64) *dto.UserDt
 		Email:          user.Email,
 		Remark:         user.Remark,
 		InvitationCode: user.InvitationCode,
+		InvitationExp:  user.InvitationExp,
 		Status:         user.Status,
 		RoleIds:        roleIds,
 		CreatedAt:      user.CreatedAt,
@@ -7,6 +7,7 @@ import (
 var ProviderSet = wire.NewSet(
 	NewUserConverter,
 	NewDepartmentConverter,
+	NewPositionConverter,
 	NewDataPermissionConverter,
 	NewPermissionsConverter,
 	NewRoleConverter,
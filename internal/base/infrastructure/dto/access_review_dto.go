@@ -0,0 +1,57 @@
+package dto
+
+// AccessReviewDto 权限复核活动
+type AccessReviewDto struct {
+	ID        string  `json:"id"`        // 活动ID
+	Name      string  `json:"name"`      // 活动名称
+	ScopeType int8    `json:"scopeType"` // 范围类型(1:部门 2:角色)
+	DeptID    string  `json:"deptId"`    // 部门范围的根部门
+	RoleIDs   []int64 `json:"roleIds"`   // 角色范围的角色集合
+	Status    int8    `json:"status"`    // 状态(1:进行中 2:已关闭)
+	DueAt     int64   `json:"dueAt"`     // 截止时间
+	OwnerID   string  `json:"ownerId"`   // 发起人
+	ClosedBy  string  `json:"closedBy"`  // 关闭人
+	ClosedAt  int64   `json:"closedAt"`  // 关闭时间
+	CreatedAt int64   `json:"createdAt"` // 发起时间
+}
+
+// AccessReviewItemDto 复核项
+type AccessReviewItemDto struct {
+	ID             string `json:"id"`             // 复核项ID
+	CampaignID     string `json:"campaignId"`     // 活动ID
+	Kind           int8   `json:"kind"`           // 类型(1:用户角色 2:角色权限)
+	UserID         string `json:"userId"`         // 用户ID
+	Username       string `json:"username"`       // 用户名
+	RoleID         int64  `json:"roleId"`         // 角色ID
+	RoleName       string `json:"roleName"`       // 角色名称
+	PermissionID   int64  `json:"permissionId"`   // 权限ID
+	PermissionCode string `json:"permissionCode"` // 权限编码
+	ReviewerID     string `json:"reviewerId"`     // 复核人
+	Decision       int8   `json:"decision"`       // 复核结果(0:待复核 1:保留 2:收回)
+	Comment        string `json:"comment"`        // 复核意见
+	DecidedAt      int64  `json:"decidedAt"`      // 复核时间
+	AppliedAt      int64  `json:"appliedAt"`      // 收回生效时间
+}
+
+// AccessReviewSummaryDto 复核统计
+type AccessReviewSummaryDto struct {
+	Total   int `json:"total"`   // 复核项总数
+	Pending int `json:"pending"` // 待复核
+	Keep    int `json:"keep"`    // 保留
+	Revoke  int `json:"revoke"`  // 收回
+}
+
+// AccessReviewReportDto 复核报告, 通过 HMAC-SHA256 签名, 作为复核完成的审计凭证
+type AccessReviewReportDto struct {
+	Campaign    *AccessReviewDto        `json:"campaign"`    // 复核活动
+	Summary     *AccessReviewSummaryDto `json:"summary"`     // 统计
+	Items       []*AccessReviewItemDto  `json:"items"`       // 复核项
+	GeneratedAt int64                   `json:"generatedAt"` // 生成时间
+	Signature   string                  `json:"signature"`   // 签名
+}
+
+// AccessReviewStartedDto 发起复核活动的结果
+type AccessReviewStartedDto struct {
+	ID    string `json:"id"`    // 活动ID
+	Items int    `json:"items"` // 生成的复核项数量
+}
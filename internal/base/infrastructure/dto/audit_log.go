@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+)
+
+// AuditLogDto 数据变更记录DTO
+type AuditLogDto struct {
+	ID         int64  `json:"id"`
+	RequestID  string `json:"request_id"`  // 请求ID, 关联操作日志
+	TenantID   string `json:"tenant_id"`   // 租户ID
+	EntityType string `json:"entity_type"` // 实体类型
+	EntityID   string `json:"entity_id"`   // 实体ID
+	Action     string `json:"action"`      // 变更类型(create/update/delete)
+	ActorID    string `json:"actor_id"`    // 操作人ID
+	ActorName  string `json:"actor_name"`  // 操作人用户名
+	// 模拟登录期间的变更, 操作人为实际操作的管理员
+	ImpersonatedUserID string            `json:"impersonated_user_id,omitempty"` // 被模拟的用户ID
+	Changes            []*AuditChangeDto `json:"changes"`                        // 字段变更
+	CreatedAt          int64             `json:"createdAt"`                      // 创建时间
+}
+
+// AuditChangeDto 字段变更, 敏感字段的值为 ******
+type AuditChangeDto struct {
+	Field string      `json:"field"` // 字段(列名)
+	Old   interface{} `json:"old"`   // 变更前
+	New   interface{} `json:"new"`   // 变更后
+}
+
+// ToAuditLogDto 转换为DTO
+func ToAuditLogDto(model *entity.AuditLog) *AuditLogDto {
+	changes := make([]*AuditChangeDto, 0)
+	_ = json.Unmarshal([]byte(model.Changes), &changes)
+	return &AuditLogDto{
+		ID:                 model.ID,
+		RequestID:          model.RequestID,
+		TenantID:           model.TenantID,
+		EntityType:         model.EntityType,
+		EntityID:           model.EntityID,
+		Action:             model.Action,
+		ActorID:            model.ActorID,
+		ActorName:          model.ActorName,
+		ImpersonatedUserID: model.ImpersonatedUserID,
+		Changes:            changes,
+		CreatedAt:          model.CreatedAt,
+	}
+}
+
+// ToAuditLogDtoList 转换为DTO列表
+func ToAuditLogDtoList(models []*entity.AuditLog) []*AuditLogDto {
+	dtos := make([]*AuditLogDto, 0, len(models))
+	for _, m := range models {
+		dtos = append(dtos, ToAuditLogDto(m))
+	}
+	return dtos
+}
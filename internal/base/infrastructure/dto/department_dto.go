@@ -13,6 +13,7 @@ type DepartmentDto struct {
 	Email       string           `json:"email"`       // 邮箱
 	Status      int8             `json:"status"`      // 部门状态
 	Description string           `json:"description"` // 描述
+	IsPrimary   bool             `json:"isPrimary"`   // 是否用户主部门, 仅用户部门列表返回
 	Children    []*DepartmentDto `json:"children"`    // 子部门
 }
 
@@ -23,3 +24,17 @@ type DepartmentTreeDto struct {
 	Name     string               `json:"name"`     // 部门名称
 	Children []*DepartmentTreeDto `json:"children"` // 子部门
 }
+
+// OrgChartNodeDto 组织架构图节点
+type OrgChartNodeDto struct {
+	ID             string             `json:"id"`             // 部门ID
+	ParentID       string             `json:"parentId"`       // 父部门ID
+	Code           string             `json:"code"`           // 部门编码
+	Name           string             `json:"name"`           // 部门名称
+	Leader         string             `json:"leader"`         // 负责人
+	AdminID        string             `json:"adminId"`        // 管理员ID
+	AdminName      string             `json:"adminName"`      // 管理员姓名
+	Headcount      int64              `json:"headcount"`      // 直属人数
+	TotalHeadcount int64              `json:"totalHeadcount"` // 含下级部门的人数, 按部门累加
+	Children       []*OrgChartNodeDto `json:"children"`       // 子部门
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "github.com/ares-cloud/ares-ddd-admin/pkg/hashchain"
+
+// LogChainVerifyDto 日志哈希链校验结果
+type LogChainVerifyDto struct {
+	TenantID string           `json:"tenant_id"`       // 租户ID
+	LogType  string           `json:"log_type"`        // 日志类型(oplog/login)
+	HeadSeq  int64            `json:"head_seq"`        // 链尾序号
+	Verified int64            `json:"verified"`        // 校验通过的记录数
+	Valid    bool             `json:"valid"`           // 整条链是否完整
+	Break    *hashchain.Break `json:"break,omitempty"` // 第一处断裂
+}
@@ -7,6 +7,7 @@ import (
 // OperationLogDto 操作日志DTO
 type OperationLogDto struct {
 	ID        int64  `json:"id"`
+	RequestID string `json:"request_id"` // 请求ID, 关联数据变更记录
 	UserID    string `json:"user_id"`    // 操作人ID
 	Username  string `json:"username"`   // 操作人用户名
 	TenantID  string `json:"tenant_id"`  // 租户ID
@@ -22,12 +23,16 @@ type OperationLogDto struct {
 	Module    string `json:"module"`     // 模块名称
 	Action    string `json:"action"`     // 操作类型
 	CreatedAt int64  `json:"createdAt"`  // 创建时间
+	// 模拟登录期间的操作, 操作人为实际操作的管理员
+	ImpersonatedUserID   string `json:"impersonated_user_id,omitempty"`  // 被模拟的用户ID
+	ImpersonatedUsername string `json:"impersonated_username,omitempty"` // 被模拟的用户名
 }
 
 // ToOperationLogDto 转换为DTO
 func ToOperationLogDto(model *entity.OperationLog) *OperationLogDto {
 	return &OperationLogDto{
 		ID:        model.ID,
+		RequestID: model.RequestID,
 		UserID:    model.UserID,
 		Username:  model.Username,
 		TenantID:  model.TenantID,
@@ -43,6 +48,9 @@ func ToOperationLogDto(model *entity.OperationLog) *OperationLogDto {
 		Module:    model.Module,
 		Action:    model.Action,
 		CreatedAt: model.CreatedAt,
+
+		ImpersonatedUserID:   model.ImpersonatedUserID,
+		ImpersonatedUsername: model.ImpersonatedUsername,
 	}
 }
 
@@ -23,6 +23,36 @@ type PermissionsDto struct {
 
 // PermissionsResourceDto 权限资源数据传输对象
 type PermissionsResourceDto struct {
-	Method string `json:"method"` // HTTP方法
-	Path   string `json:"path"`   // 资源路径
+	Method    string `json:"method"`              // HTTP方法
+	Path      string `json:"path"`                // 资源路径
+	Condition string `json:"condition,omitempty"` // 条件表达式
+}
+
+// PermissionExplainDto 接口访问解释结果
+type PermissionExplainDto struct {
+	Allowed    bool                  `json:"allowed"`    // 是否允许访问
+	SuperAdmin bool                  `json:"superAdmin"` // 超级管理员不受策略限制
+	Roles      []string              `json:"roles"`      // 用户角色编码
+	Env        *PermissionExplainEnv `json:"env"`        // 条件求值环境
+	Policies   []*PolicyExplainDto   `json:"policies"`   // 与请求匹配的策略
+}
+
+// PermissionExplainEnv 条件求值环境
+type PermissionExplainEnv struct {
+	Time     int64                  `json:"time"`            // 请求时间
+	IP       string                 `json:"ip"`              // 客户端IP
+	Tenant   string                 `json:"tenant"`          // 租户ID
+	Platform string                 `json:"platform"`        // 登录平台
+	Device   string                 `json:"device"`          // 设备名称
+	Attrs    map[string]interface{} `json:"attrs,omitempty"` // 租户属性
+}
+
+// PolicyExplainDto 策略及条件求值结果
+type PolicyExplainDto struct {
+	Role      string `json:"role"`                // 角色编码
+	Method    string `json:"method"`              // 请求方法
+	Path      string `json:"path"`                // 资源路径
+	Condition string `json:"condition,omitempty"` // 条件表达式
+	Satisfied bool   `json:"satisfied"`           // 条件是否满足
+	Error     string `json:"error,omitempty"`     // 条件求值错误
 }
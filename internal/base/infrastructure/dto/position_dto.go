@@ -0,0 +1,19 @@
+package dto
+
+// PositionDto 岗位DTO
+type PositionDto struct {
+	ID          string `json:"id"`          // 岗位ID
+	Code        string `json:"code"`        // 岗位编码
+	Name        string `json:"name"`        // 岗位名称
+	Sequence    int32  `json:"sequence"`    // 排序
+	Status      int8   `json:"status"`      // 岗位状态
+	Description string `json:"description"` // 描述
+	CreatedAt   int64  `json:"createdAt"`   // 创建时间
+	UpdatedAt   int64  `json:"updatedAt"`   // 更新时间
+}
+
+// UserPositionDto 用户任职岗位DTO
+type UserPositionDto struct {
+	PositionDto
+	IsPrimary bool `json:"isPrimary"` // 是否主岗
+}
@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+)
+
+// RecycleItemDto 回收站记录
+type RecycleItemDto struct {
+	EntityID  string `json:"entityId"`  // 实体ID, 恢复时使用
+	Code      string `json:"code"`      // 用户名或编码
+	Name      string `json:"name"`      // 名称
+	DeletedBy string `json:"deletedBy"` // 删除人
+	DeletedAt int64  `json:"deletedAt"` // 删除时间
+	ExpireAt  int64  `json:"expireAt"`  // 彻底删除时间
+}
+
+func ToRecycleItemDtoList(list []*entity.RecycleBin, retention int64) []*RecycleItemDto {
+	result := make([]*RecycleItemDto, 0, len(list))
+	for _, item := range list {
+		result = append(result, &RecycleItemDto{
+			EntityID:  item.EntityID,
+			Code:      item.Code,
+			Name:      item.Name,
+			DeletedBy: item.DeletedBy,
+			DeletedAt: item.DeletedAt,
+			ExpireAt:  item.DeletedAt + retention,
+		})
+	}
+	return result
+}
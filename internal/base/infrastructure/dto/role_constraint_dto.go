@@ -0,0 +1,25 @@
+package dto
+
+// RoleConstraintDto 职责分离约束
+type RoleConstraintDto struct {
+	ID          int64    `json:"id"`          // 约束ID
+	Name        string   `json:"name"`        // 约束名称
+	Type        int8     `json:"type"`        // 约束类型(1:互斥 2:基数)
+	RoleIDs     []int64  `json:"roleIds"`     // 角色ID
+	RoleNames   []string `json:"roleNames"`   // 角色名称
+	Limit       int      `json:"limit"`       // 上限
+	Description string   `json:"description"` // 描述
+	CreatedAt   int64    `json:"createdAt"`   // 创建时间
+	UpdatedAt   int64    `json:"updatedAt"`   // 更新时间
+}
+
+// RoleConstraintViolationDto 违反约束的现有分配
+type RoleConstraintViolationDto struct {
+	ConstraintID   int64    `json:"constraintId"`   // 约束ID
+	ConstraintName string   `json:"constraintName"` // 约束名称
+	Type           int8     `json:"type"`           // 约束类型(1:互斥 2:基数)
+	Limit          int      `json:"limit"`          // 上限
+	UserIDs        []string `json:"userIds"`        // 违反约束的用户, 基数约束为全部持有人
+	RoleIDs        []int64  `json:"roleIds"`        // 互斥约束中用户持有的角色
+	Count          int      `json:"count"`          // 互斥约束为持有的角色数, 基数约束为持有人数
+}
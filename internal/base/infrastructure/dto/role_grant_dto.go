@@ -0,0 +1,29 @@
+package dto
+
+// RoleGrantDto 用户的角色授予
+type RoleGrantDto struct {
+	RoleID     int64  `json:"roleId"`     // 角色ID
+	RoleCode   string `json:"roleCode"`   // 角色编码
+	RoleName   string `json:"roleName"`   // 角色名称
+	StartTime  int64  `json:"startTime"`  // 生效时间, 0 表示立即生效
+	ExpireTime int64  `json:"expireTime"` // 过期时间, 0 表示永久有效
+	Active     bool   `json:"active"`     // 当前是否生效
+}
+
+// RoleRequestDto 角色申请
+type RoleRequestDto struct {
+	ID            string `json:"id"`            // 申请ID
+	UserID        string `json:"userId"`        // 被授予角色的用户
+	RoleID        int64  `json:"roleId"`        // 角色ID
+	RoleCode      string `json:"roleCode"`      // 角色编码
+	RoleName      string `json:"roleName"`      // 角色名称
+	StartTime     int64  `json:"startTime"`     // 申请的生效时间
+	ExpireTime    int64  `json:"expireTime"`    // 申请的过期时间
+	Reason        string `json:"reason"`        // 申请理由
+	Status        int8   `json:"status"`        // 状态(1:待审批 2:已通过 3:已拒绝)
+	RequestedBy   string `json:"requestedBy"`   // 申请人
+	ReviewedBy    string `json:"reviewedBy"`    // 审批人
+	ReviewComment string `json:"reviewComment"` // 审批意见
+	ReviewedAt    int64  `json:"reviewedAt"`    // 审批时间
+	CreatedAt     int64  `json:"createdAt"`     // 申请时间
+}
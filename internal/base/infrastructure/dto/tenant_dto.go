@@ -1,16 +1,63 @@
 package dto
 
+import "encoding/json"
+
 // TenantDto 租户数据传输对象
 type TenantDto struct {
-	ID          string   `json:"id"`          // ID
-	Code        string   `json:"code"`        // 租户编码
-	Name        string   `json:"name"`        // 租户名称
-	Domain      string   `json:"domain"`      // 域名
-	Description string   `json:"description"` // 描述
-	IsDefault   int8     `json:"isDefault"`   // 是否默认租户
-	Status      int8     `json:"status"`      // 状态
-	AdminUser   *UserDto `json:"adminUser"`   // 管理员用户
-	ExpireTime  int64    `json:"expireTime"`  // 过期时间
-	CreatedAt   int64    `json:"createdAt"`   // 创建时间
-	UpdatedAt   int64    `json:"updatedAt"`   // 更新时间
+	ID              string   `json:"id"`              // ID
+	Code            string   `json:"code"`            // 租户编码
+	Name            string   `json:"name"`            // 租户名称
+	Domain          string   `json:"domain"`          // 域名
+	Description     string   `json:"description"`     // 描述
+	IsDefault       int8     `json:"isDefault"`       // 是否默认租户
+	Status          int8     `json:"status"`          // 状态
+	AdminUser       *UserDto `json:"adminUser"`       // 管理员用户
+	ExpireTime      int64    `json:"expireTime"`      // 过期时间
+	IsolationMode   string   `json:"isolationMode"`   // 数据隔离模式
+	DbSchema        string   `json:"dbSchema"`        // 独立Schema名称
+	ProvisionStatus int8     `json:"provisionStatus"` // 开通状态(0:已完成 1:开通中 2:开通失败)
+	ProvisionError  string   `json:"provisionError"`  // 开通失败原因
+	CreatedAt       int64    `json:"createdAt"`       // 创建时间
+	UpdatedAt       int64    `json:"updatedAt"`       // 更新时间
+}
+
+// UserTenantDto 用户可访问的租户
+type UserTenantDto struct {
+	TenantID   string `json:"tenantId"`   // 租户ID
+	Code       string `json:"code"`       // 租户编码
+	Name       string `json:"name"`       // 租户名称
+	Status     int8   `json:"status"`     // 租户状态
+	ExpireTime int64  `json:"expireTime"` // 过期时间
+	Home       bool   `json:"home"`       // 是否为归属租户
+}
+
+// TenantJobDto 租户下线任务
+type TenantJobDto struct {
+	ID           string          `json:"id"`           // 任务ID
+	TenantID     string          `json:"tenantId"`     // 租户ID
+	TenantCode   string          `json:"tenantCode"`   // 租户编码
+	Type         string          `json:"type"`         // 任务类型(export/purge)
+	Status       int8            `json:"status"`       // 状态(1:等待 2:执行中 3:成功 4:失败)
+	Progress     int32           `json:"progress"`     // 进度(0-100)
+	Step         string          `json:"step"`         // 当前步骤
+	FileSize     int64           `json:"fileSize"`     // 导出归档大小
+	Downloadable bool            `json:"downloadable"` // 是否可下载归档
+	Report       json.RawMessage `json:"report"`       // 签名的完成报告
+	Error        string          `json:"error"`        // 失败原因
+	Creator      string          `json:"creator"`      // 创建人
+	CreatedAt    int64           `json:"createdAt"`    // 创建时间
+	FinishedAt   int64           `json:"finishedAt"`   // 完成时间
+	FilePath     string          `json:"-"`            // 导出归档路径
+}
+
+// TenantTemplateDto 租户开通模板
+type TenantTemplateDto struct {
+	ID             string `json:"id"`                // 模板ID
+	Name           string `json:"name"`              // 模板名称
+	Description    string `json:"description"`       // 描述
+	Format         string `json:"format"`            // 模板格式(yaml/json)
+	Content        string `json:"content,omitempty"` // 模板内容, 列表中不返回
+	SourceTenantID string `json:"sourceTenantId"`    // 克隆来源租户ID
+	CreatedAt      int64  `json:"createdAt"`         // 创建时间
+	UpdatedAt      int64  `json:"updatedAt"`         // 更新时间
 }
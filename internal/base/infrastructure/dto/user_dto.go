@@ -12,8 +12,16 @@ type UserDto struct {
 	Email          string  `json:"email"`          // 邮箱
 	Remark         string  `json:"remark"`         // 备注
 	InvitationCode string  `json:"invitationCode"` // 邀请码
+	InvitationExp  int64   `json:"invitationExp"`  // 邀请过期时间, 待激活用户有效
 	Status         int8    `json:"status"`         // 状态,1启用,2禁用
 	RoleIds        []int64 `json:"roleIds"`        // 角色ID列表
 	CreatedAt      int64   `json:"createdAt"`      // 创建时间
 	UpdatedAt      int64   `json:"updatedAt"`      // 更新时间
 }
+
+// UserExportDto 用户导出数据
+type UserExportDto struct {
+	*UserDto
+	DeptCodes []string `json:"deptCodes"` // 部门编码
+	RoleCodes []string `json:"roleCodes"` // 角色编码
+}
@@ -6,6 +6,14 @@ type UserInfoDto struct {
 	Permissions []string `json:"permissions"` // 所有权限列表
 	Roles       []string `json:"roles"`       // 角色列表
 	HomePage    string   `json:"homePage"`    // 首页
+	// 模拟登录时返回实际操作的管理员, 前端据此显示模拟登录提示
+	Impersonation *ImpersonationInfoDto `json:"impersonation,omitempty"`
+}
+
+// ImpersonationInfoDto 模拟登录信息
+type ImpersonationInfoDto struct {
+	ActorId   string `json:"actorId"`   // 实际操作人ID
+	ActorName string `json:"actorName"` // 实际操作人账号
 }
 
 func ToUserInfoDto(user *UserDto, permissions []string, roles []string) *UserInfoDto {
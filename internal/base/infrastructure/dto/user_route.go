@@ -0,0 +1,28 @@
+package dto
+
+// UserRoutesDto 前端动态路由和按钮权限
+type UserRoutesDto struct {
+	Routes  []*RouteDto `json:"routes"`  // 路由树
+	Buttons []string    `json:"buttons"` // 按钮权限编码
+	Version string      `json:"version"` // 内容摘要, 与响应头 ETag 一致
+}
+
+// RouteDto 前端路由
+type RouteDto struct {
+	Name      string       `json:"name"`               // 路由名称, 取权限编码
+	Path      string       `json:"path"`               // 路由路径
+	Component string       `json:"component"`          // 组件路径
+	Redirect  string       `json:"redirect,omitempty"` // 重定向路径
+	Meta      RouteMetaDto `json:"meta"`
+	Children  []*RouteDto  `json:"children,omitempty"`
+}
+
+// RouteMetaDto 路由元信息
+type RouteMetaDto struct {
+	Title     string `json:"title"`             // 按请求语言翻译后的标题
+	I18nKey   string `json:"i18nKey,omitempty"` // 国际化key, 前端可自行翻译
+	Icon      string `json:"icon,omitempty"`    // 图标
+	Order     int    `json:"order"`             // 排序
+	Hidden    bool   `json:"hidden"`            // 不在菜单中显示
+	KeepAlive bool   `json:"keepAlive"`         // 缓存页面
+}
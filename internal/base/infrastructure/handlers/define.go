@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/events"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/offboard"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query/cache/handlers"
 	pkgEvent "github.com/ares-cloud/ares-ddd-admin/pkg/events"
 )
@@ -9,13 +10,19 @@ import (
 type HandlerEvent struct {
 	queryCache *handlers.EventHandler
 	uh         *UserEventHandler
+	ph         *PolicyEventHandler
+	th         *TenantResolveEventHandler
+	jobRunner  *offboard.TenantJobRunner
 	eventBus   pkgEvent.IEventBus
 }
 
-func NewHandlerEvent(eventBus pkgEvent.IEventBus, queryCache *handlers.EventHandler, uh *UserEventHandler) *HandlerEvent {
+func NewHandlerEvent(eventBus pkgEvent.IEventBus, queryCache *handlers.EventHandler, uh *UserEventHandler, ph *PolicyEventHandler, th *TenantResolveEventHandler, jobRunner *offboard.TenantJobRunner) *HandlerEvent {
 	return &HandlerEvent{
 		queryCache: queryCache,
 		uh:         uh,
+		ph:         ph,
+		th:         th,
+		jobRunner:  jobRunner,
 		eventBus:   eventBus,
 	}
 }
@@ -26,6 +33,17 @@ func (h *HandlerEvent) Register() {
 	h.eventBus.Subscribe(events.UserUpdated, h.uh)
 	h.eventBus.Subscribe(events.UserDeleted, h.uh)
 	h.eventBus.Subscribe(events.UserRoleChanged, h.uh)
+	h.eventBus.Subscribe(events.UserInvited, h.uh)
+	h.eventBus.Subscribe(events.UserInvitationResent, h.uh)
+	h.eventBus.Subscribe(events.UserInvitationRevoked, h.uh)
+	h.eventBus.Subscribe(events.UserInvitationAccepted, h.uh)
+	h.eventBus.Subscribe(events.RoleRequestCreated, h.uh)
+	h.eventBus.Subscribe(events.RoleRequestApproved, h.uh)
+	h.eventBus.Subscribe(events.RoleRequestRejected, h.uh)
+	h.eventBus.Subscribe(events.AccessReviewStarted, h.uh)
+	h.eventBus.Subscribe(events.AccessReviewClosed, h.uh)
+	h.eventBus.Subscribe(events.UserImpersonationStarted, h.uh)
+	h.eventBus.Subscribe(events.UserImpersonationEnded, h.uh)
 
 	// 注册缓存相关事件
 	// 用户事件
@@ -34,6 +52,8 @@ func (h *HandlerEvent) Register() {
 	h.eventBus.Subscribe(events.UserUpdated, h.queryCache)
 	h.eventBus.Subscribe(events.UserDeleted, h.queryCache)
 	h.eventBus.Subscribe(events.UserRoleChanged, h.queryCache)
+	h.eventBus.Subscribe(events.UserJoinTenant, h.queryCache)
+	h.eventBus.Subscribe(events.UserLeaveTenant, h.queryCache)
 
 	// 角色事件
 	h.eventBus.Subscribe(events.RoleCreated, h.queryCache)
@@ -46,9 +66,17 @@ func (h *HandlerEvent) Register() {
 	h.eventBus.Subscribe(events.DepartmentUpdated, h.queryCache)
 	h.eventBus.Subscribe(events.DepartmentDeleted, h.queryCache)
 	h.eventBus.Subscribe(events.DepartmentMoved, h.queryCache)
+	h.eventBus.Subscribe(events.DepartmentMerged, h.queryCache)
 	h.eventBus.Subscribe(events.UserAssigned, h.queryCache)
 	h.eventBus.Subscribe(events.UserRemoved, h.queryCache)
 	h.eventBus.Subscribe(events.UserTransferred, h.queryCache)
+	h.eventBus.Subscribe(events.UserPrimaryDeptChanged, h.queryCache)
+
+	// 岗位事件
+	h.eventBus.Subscribe(events.PositionCreated, h.queryCache)
+	h.eventBus.Subscribe(events.PositionUpdated, h.queryCache)
+	h.eventBus.Subscribe(events.PositionDeleted, h.queryCache)
+	h.eventBus.Subscribe(events.UserPositionChanged, h.queryCache)
 
 	// 权限事件
 	h.eventBus.Subscribe(events.PermissionCreated, h.queryCache)
@@ -66,4 +94,20 @@ func (h *HandlerEvent) Register() {
 	h.eventBus.Subscribe(events.TenantDeleted, h.queryCache)
 	h.eventBus.Subscribe(events.TenantLocked, h.queryCache)
 	h.eventBus.Subscribe(events.TenantUnlocked, h.queryCache)
+
+	// 访问策略
+	h.eventBus.Subscribe(events.RoleCreated, h.ph)
+	h.eventBus.Subscribe(events.RoleUpdated, h.ph)
+	h.eventBus.Subscribe(events.RoleDeleted, h.ph)
+	h.eventBus.Subscribe(events.RolePermissionsChanged, h.ph)
+
+	// 租户解析缓存
+	h.eventBus.Subscribe(events.TenantCreated, h.th)
+	h.eventBus.Subscribe(events.TenantUpdated, h.th)
+	h.eventBus.Subscribe(events.TenantDeleted, h.th)
+	h.eventBus.Subscribe(events.TenantLocked, h.th)
+	h.eventBus.Subscribe(events.TenantUnlocked, h.th)
+
+	// 租户下线任务
+	h.eventBus.Subscribe(events.TenantJobAdded, h.jobRunner)
 }
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/events"
+	pkgEvents "github.com/ares-cloud/ares-ddd-admin/pkg/events"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/casbin"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// PolicyEventHandler 角色变更后通知各实例增量更新访问策略
+type PolicyEventHandler struct {
+	ef *casbin.Enforcer
+}
+
+func NewPolicyEventHandler(ef *casbin.Enforcer) *PolicyEventHandler {
+	return &PolicyEventHandler{
+		ef: ef,
+	}
+}
+
+// Handle 处理事件
+func (h *PolicyEventHandler) Handle(ctx context.Context, event pkgEvents.Event) error {
+	var e *events.RoleEvent
+	switch v := event.(type) {
+	case *events.RoleEvent:
+		e = v
+	case *events.RolePermissionsAssignedEvent:
+		e = v.RoleEvent
+	default:
+		return nil
+	}
+	if err := h.ef.PublishRoleUpdate(ctx, e.TenantID, strconv.FormatInt(e.RoleID, 10)); err != nil {
+		hlog.CtxErrorf(ctx, "publish role %d policy update error: %v", e.RoleID, err)
+	}
+	return nil
+}
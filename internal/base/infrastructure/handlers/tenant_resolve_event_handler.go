@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/events"
+	pkgEvents "github.com/ares-cloud/ares-ddd-admin/pkg/events"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/tenant"
+)
+
+// TenantResolveEventHandler 租户变更、锁定后清除租户解析缓存
+type TenantResolveEventHandler struct {
+	resolver *tenant.CachedResolver
+}
+
+func NewTenantResolveEventHandler(resolver *tenant.CachedResolver) *TenantResolveEventHandler {
+	return &TenantResolveEventHandler{
+		resolver: resolver,
+	}
+}
+
+// Handle 处理事件
+func (h *TenantResolveEventHandler) Handle(ctx context.Context, event pkgEvents.Event) error {
+	var e *events.TenantEvent
+	switch v := event.(type) {
+	case *events.TenantEvent:
+		e = v
+	case *events.TenantPermissionEvent:
+		e = v.TenantEvent
+	default:
+		return nil
+	}
+	h.resolver.Evict(e.TenantID)
+	return nil
+}
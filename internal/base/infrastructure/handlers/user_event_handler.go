@@ -2,17 +2,22 @@ package handlers
 
 import (
 	"context"
+
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/events"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
 	pkgEvents "github.com/ares-cloud/ares-ddd-admin/pkg/events"
 	"github.com/cloudwego/hertz/pkg/common/hlog"
 )
 
 // UserEventHandler 用户事件处理器
 type UserEventHandler struct {
+	invitationSender repository.IInvitationSender
 }
 
-func NewUserEventHandler() *UserEventHandler {
-	return &UserEventHandler{}
+func NewUserEventHandler(invitationSender repository.IInvitationSender) *UserEventHandler {
+	return &UserEventHandler{
+		invitationSender: invitationSender,
+	}
 }
 
 // Handle 处理事件
@@ -20,6 +25,14 @@ func (h *UserEventHandler) Handle(ctx context.Context, event pkgEvents.Event) er
 	switch e := event.(type) {
 	case *events.UserEvent:
 		return h.handleUserEvent(ctx, e)
+	case *events.UserInvitationEvent:
+		return h.handleInvitationEvent(ctx, e)
+	case *events.RoleRequestEvent:
+		return h.handleRoleRequestEvent(ctx, e)
+	case *events.AccessReviewEvent:
+		return h.handleAccessReviewEvent(ctx, e)
+	case *events.UserImpersonationEvent:
+		return h.handleImpersonationEvent(ctx, e)
 	default:
 		return nil
 	}
@@ -41,6 +54,58 @@ func (h *UserEventHandler) handleUserEvent(ctx context.Context, event *events.Us
 	return nil
 }
 
+// handleInvitationEvent 处理用户邀请事件
+// 发送和重新发送时将邀请链接投递给被邀请人, 邀请链接可直接激活账号, 不写入日志
+func (h *UserEventHandler) handleInvitationEvent(ctx context.Context, event *events.UserInvitationEvent) error {
+	switch event.EventName() {
+	case events.UserInvited, events.UserInvitationResent:
+		if err := h.invitationSender.Send(ctx, event.Email, event.Link, event.ExpireAt); err != nil {
+			hlog.CtxErrorf(ctx, "用户邀请投递失败: 租户ID=%s, 用户ID=%s, 错误=%v", event.TenantID, event.UserID, err)
+			return err
+		}
+		hlog.CtxInfof(ctx, "用户邀请已投递: 租户ID=%s, 用户ID=%s", event.TenantID, event.UserID)
+	default:
+		hlog.CtxDebugf(ctx, "用户邀请事件[%s]: 租户ID=%s, 用户ID=%s", event.EventName(), event.TenantID, event.UserID)
+	}
+	return nil
+}
+
+// handleRoleRequestEvent 处理角色申请事件
+// 默认仅记录日志, 接入通知渠道时在此通知审批人和申请人
+func (h *UserEventHandler) handleRoleRequestEvent(ctx context.Context, event *events.RoleRequestEvent) error {
+	switch event.EventName() {
+	case events.RoleRequestCreated:
+		hlog.CtxInfof(ctx, "角色申请待审批: 租户ID=%s, 申请ID=%s, 用户ID=%s, 角色ID=%d, 申请人=%s", event.TenantID, event.RequestID, event.UserID, event.RoleID, event.RequestedBy)
+	default:
+		hlog.CtxInfof(ctx, "角色申请已审批[%s]: 租户ID=%s, 申请ID=%s, 审批人=%s", event.EventName(), event.TenantID, event.RequestID, event.ReviewedBy)
+	}
+	return nil
+}
+
+// handleAccessReviewEvent 处理权限复核事件
+// 默认仅记录日志, 接入通知渠道时在此提醒复核人
+func (h *UserEventHandler) handleAccessReviewEvent(ctx context.Context, event *events.AccessReviewEvent) error {
+	switch event.EventName() {
+	case events.AccessReviewStarted:
+		hlog.CtxInfof(ctx, "权限复核待处理: 租户ID=%s, 活动ID=%s, 复核人=%v", event.TenantID, event.CampaignID, event.ReviewerIDs)
+	default:
+		hlog.CtxInfof(ctx, "权限复核已关闭: 租户ID=%s, 活动ID=%s, 收回授权数=%d", event.TenantID, event.CampaignID, event.Revoked)
+	}
+	return nil
+}
+
+// handleImpersonationEvent 处理模拟登录事件
+// 模拟期间的请求已记录在操作日志中, 这里记录会话的开始和结束, 接入通知渠道时可在此通知被模拟的用户
+func (h *UserEventHandler) handleImpersonationEvent(ctx context.Context, event *events.UserImpersonationEvent) error {
+	switch event.EventName() {
+	case events.UserImpersonationStarted:
+		hlog.CtxInfof(ctx, "模拟登录开始: 租户ID=%s, 会话ID=%s, 操作人=%s, 用户ID=%s, 原因=%s", event.TenantID, event.SessionID, event.ActorID, event.UserID, event.Reason)
+	default:
+		hlog.CtxInfof(ctx, "模拟登录结束: 租户ID=%s, 会话ID=%s, 操作人=%s, 用户ID=%s", event.TenantID, event.SessionID, event.ActorID, event.UserID)
+	}
+	return nil
+}
+
 // handleUserDeleted 处理用户删除事件
 func (h *UserEventHandler) handleUserDeleted(ctx context.Context, event *events.UserEvent) error {
 	return nil
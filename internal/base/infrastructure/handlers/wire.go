@@ -6,5 +6,7 @@ import (
 
 var ProviderSet = wire.NewSet(
 	NewUserEventHandler,
+	NewPolicyEventHandler,
+	NewTenantResolveEventHandler,
 	NewHandlerEvent,
 )
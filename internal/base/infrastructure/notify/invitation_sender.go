@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/infrastructure/configs"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// NewInvitationSender 配置了发送服务时通过 webhook 投递邀请; 未配置时拒绝投递
+// 邀请链接可直接激活账号, 任何环境都不写入日志
+func NewInvitationSender(conf *configs.Bootstrap) repository.IInvitationSender {
+	if url := conf.Invitation.GetWebhookURL(); url != "" {
+		return NewWebhookInvitationSender(url, conf.Invitation.GetTimeout())
+	}
+	hlog.Warn("invitation sender is not configured, invitations are not delivered")
+	return &disabledInvitationSender{}
+}
+
+// WebhookInvitationSender 将邀请以 JSON POST 到发送服务, 由发送服务投递邮件
+type WebhookInvitationSender struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookInvitationSender(url string, timeout time.Duration) *WebhookInvitationSender {
+	return &WebhookInvitationSender{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+type invitationPayload struct {
+	Email    string `json:"email"`     // 被邀请人邮箱
+	Link     string `json:"link"`      // 邀请链接
+	ExpireAt int64  `json:"expire_at"` // 过期时间
+}
+
+func (s *WebhookInvitationSender) Send(ctx context.Context, email, link string, expireAt int64) error {
+	if err := postJSON(ctx, s.client, s.url, &invitationPayload{Email: email, Link: link, ExpireAt: expireAt}); err != nil {
+		return fmt.Errorf("send invitation failed: %w", err)
+	}
+	return nil
+}
+
+// disabledInvitationSender 未配置发送服务时拒绝投递
+type disabledInvitationSender struct{}
+
+func (s *disabledInvitationSender) Send(ctx context.Context, email, link string, expireAt int64) error {
+	return ErrSenderNotConfigured
+}
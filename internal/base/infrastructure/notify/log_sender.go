@@ -0,0 +1,42 @@
+// Package notify 验证码等通知的发送
+package notify
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/infrastructure/configs"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// ErrSenderNotConfigured 未配置验证码或邀请的发送服务
+var ErrSenderNotConfigured = errors.New("notify sender is not configured")
+
+// NewCodeSender 配置了发送服务时通过 webhook 发送; 未配置时仅开发环境写入调试日志, 其他环境拒绝发送
+func NewCodeSender(conf *configs.Bootstrap) repository.IVerifyCodeSender {
+	if url := conf.VerifyCode.GetWebhookURL(); url != "" {
+		return NewWebhookCodeSender(url, conf.VerifyCode.GetTimeout())
+	}
+	if configs.Mode == configs.Development {
+		hlog.Warn("verify code sender is not configured, codes are written to the debug log")
+		return &LogCodeSender{}
+	}
+	hlog.Warn("verify code sender is not configured, sending verify codes is disabled")
+	return &disabledCodeSender{}
+}
+
+// LogCodeSender 将验证码写入调试日志, 仅用于未接入短信/邮件服务的开发环境
+type LogCodeSender struct{}
+
+func (s *LogCodeSender) Send(ctx context.Context, contactType, target, code string) error {
+	hlog.CtxDebugf(ctx, "verify code for %s %s: %s", contactType, target, code)
+	return nil
+}
+
+// disabledCodeSender 未配置发送服务时拒绝发送
+type disabledCodeSender struct{}
+
+func (s *disabledCodeSender) Send(ctx context.Context, contactType, target, code string) error {
+	return ErrSenderNotConfigured
+}
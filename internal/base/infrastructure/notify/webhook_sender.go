@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WebhookCodeSender 将验证码以 JSON POST 到发送服务, 由发送服务投递邮件或短信
+type WebhookCodeSender struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookCodeSender(url string, timeout time.Duration) *WebhookCodeSender {
+	return &WebhookCodeSender{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+type webhookPayload struct {
+	Type   string `json:"type"`   // 类型 email/phone
+	Target string `json:"target"` // 邮箱或手机号
+	Code   string `json:"code"`   // 验证码
+}
+
+func (s *WebhookCodeSender) Send(ctx context.Context, contactType, target, code string) error {
+	if err := postJSON(ctx, s.client, s.url, &webhookPayload{Type: contactType, Target: target, Code: code}); err != nil {
+		return fmt.Errorf("send verify code failed: %w", err)
+	}
+	return nil
+}
+
+// postJSON 以 JSON POST 到发送服务, 非 2xx 响应视为失败
+func postJSON(ctx context.Context, client *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package offboard
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/events"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	drepository "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/infrastructure/configs"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	pkgEvents "github.com/ares-cloud/ares-ddd-admin/pkg/events"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/tenantdata"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+const defaultArchiveDir = "./data/tenant_archive"
+
+// TenantJobRunner 租户下线任务执行器, 订阅任务事件后异步执行导出/清除
+type TenantJobRunner struct {
+	db         database.IDataBase
+	jobRepo    drepository.ITenantJobRepository
+	sysJobRepo repository.ISysTenantJobRepo
+	tenantRepo repository.ISysTenantRepo
+	registry   *tenantdata.Registry
+	eventBus   pkgEvents.IEventBus
+	archiveDir string
+	secret     string
+}
+
+func NewTenantJobRunner(
+	conf *configs.Bootstrap,
+	db database.IDataBase,
+	jobRepo drepository.ITenantJobRepository,
+	sysJobRepo repository.ISysTenantJobRepo,
+	tenantRepo repository.ISysTenantRepo,
+	registry *tenantdata.Registry,
+	eventBus pkgEvents.IEventBus,
+) *TenantJobRunner {
+	r := &TenantJobRunner{
+		db:         db,
+		jobRepo:    jobRepo,
+		sysJobRepo: sysJobRepo,
+		tenantRepo: tenantRepo,
+		registry:   registry,
+		eventBus:   eventBus,
+		archiveDir: defaultArchiveDir,
+	}
+	if conf.JWT != nil {
+		r.secret = conf.JWT.SigningKey
+	}
+	if conf.Tenant != nil {
+		if conf.Tenant.ArchiveDir != "" {
+			r.archiveDir = conf.Tenant.ArchiveDir
+		}
+		if conf.Tenant.ReportSecret != "" {
+			r.secret = conf.Tenant.ReportSecret
+		}
+	}
+	registry.Register(baseSections(db)...)
+	// 服务重启前未执行完的任务无法恢复, 标记为失败后由管理员重新提交
+	if err := sysJobRepo.FailUnfinished(context.Background(), "interrupted by server restart"); err != nil {
+		hlog.Errorf("fail unfinished tenant jobs error: %v", err)
+	}
+	return r
+}
+
+// Handle 处理任务事件
+func (r *TenantJobRunner) Handle(ctx context.Context, event pkgEvents.Event) error {
+	e, ok := event.(*events.TenantJobEvent)
+	if !ok {
+		return nil
+	}
+	go r.run(e.JobID)
+	return nil
+}
+
+func (r *TenantJobRunner) run(jobID string) {
+	ctx := actx.BuildIgnoreTenantCtx(context.Background())
+	job, err := r.jobRepo.FindByID(ctx, jobID)
+	if err != nil || job == nil {
+		hlog.Errorf("load tenant job %s error: %v", jobID, err)
+		return
+	}
+	job.Start()
+	r.save(ctx, job)
+
+	report := &tenantdata.Report{
+		JobID:      job.ID,
+		JobType:    job.Type,
+		TenantID:   job.TenantID,
+		TenantCode: job.TenantCode,
+		StartedAt:  time.Now().Unix(),
+	}
+	switch job.Type {
+	case model.TenantJobExport:
+		err = r.export(ctx, job, report)
+	case model.TenantJobPurge:
+		err = r.purge(ctx, job, report)
+	default:
+		err = fmt.Errorf("unknown tenant job type: %s", job.Type)
+	}
+	if err == nil {
+		err = r.sign(report)
+	}
+	if err != nil {
+		hlog.Errorf("tenant job %s(%s) of tenant %s failed: %v", job.ID, job.Type, job.TenantID, err)
+		job.Fail(err)
+		r.save(ctx, job)
+		return
+	}
+	data, _ := json.Marshal(report)
+	job.Succeed(string(data))
+	r.save(ctx, job)
+}
+
+// export 导出租户数据到归档文件
+func (r *TenantJobRunner) export(ctx context.Context, job *model.TenantJob, report *tenantdata.Report) (err error) {
+	if err := os.MkdirAll(r.archiveDir, 0o750); err != nil {
+		return err
+	}
+	path := filepath.Join(r.archiveDir, fmt.Sprintf("tenant_%s_%s.zip", job.TenantCode, job.ID))
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+		if err != nil {
+			_ = os.Remove(path)
+		}
+	}()
+
+	h := sha256.New()
+	w := tenantdata.NewArchiveWriter(io.MultiWriter(f, h), job.TenantID, job.TenantCode)
+	sections := r.registry.Sections()
+	for i, s := range sections {
+		r.progress(ctx, job, i, len(sections), s.Name())
+		w.Begin(s.Name())
+		rows, err := s.Export(ctx, job.TenantID, w)
+		if err != nil {
+			return fmt.Errorf("export section %s failed: %w", s.Name(), err)
+		}
+		if err := w.End(rows); err != nil {
+			return err
+		}
+		report.Sections = append(report.Sections, &tenantdata.SectionReport{Name: s.Name(), Rows: rows})
+	}
+	if _, err := w.Close(); err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	job.FilePath = path
+	job.FileSize = info.Size()
+	report.ArchiveSHA256 = hex.EncodeToString(h.Sum(nil))
+	return nil
+}
+
+// purge 物理删除租户的全部数据, 包括独立存储、导出归档和租户本身
+func (r *TenantJobRunner) purge(ctx context.Context, job *model.TenantJob, report *tenantdata.Report) error {
+	var tenant entity.Tenant
+	if err := r.tenantRepo.Db(ctx).Where("id = ?", job.TenantID).First(&tenant).Error; err != nil {
+		return fmt.Errorf("load tenant failed: %w", err)
+	}
+	sections := r.registry.Sections()
+	for i, s := range sections {
+		r.progress(ctx, job, i, len(sections), s.Name())
+		rows, err := s.Purge(ctx, job.TenantID)
+		if err != nil {
+			return fmt.Errorf("purge section %s failed: %w", s.Name(), err)
+		}
+		report.Sections = append(report.Sections, &tenantdata.SectionReport{Name: s.Name(), Rows: rows})
+	}
+
+	r.progress(ctx, job, len(sections), len(sections), "archives")
+	archives, err := r.purgeArchives(ctx, job.TenantID)
+	if err != nil {
+		return err
+	}
+	report.Sections = append(report.Sections, &tenantdata.SectionReport{Name: "archives", Rows: archives})
+
+	// 删除独立Schema/库, 最后删除租户
+	iso := &database.TenantIsolation{
+		TenantID: tenant.ID,
+		Mode:     tenant.IsolationMode,
+		Schema:   tenant.DbSchema,
+		Source:   tenant.DbSource,
+	}
+	if err := r.db.DeprovisionTenant(ctx, iso); err != nil {
+		return fmt.Errorf("deprovision tenant storage failed: %w", err)
+	}
+	if err := r.tenantRepo.DelByIdUnScoped(ctx, tenant.ID); err != nil {
+		return fmt.Errorf("delete tenant failed: %w", err)
+	}
+	report.Sections = append(report.Sections, &tenantdata.SectionReport{Name: "tenant_record", Rows: 1})
+
+	if err := r.eventBus.Publish(ctx, events.NewTenantEvent(tenant.ID, events.TenantDeleted)); err != nil {
+		hlog.Errorf("publish tenant deleted event error: %v", err)
+	}
+	return nil
+}
+
+// purgeArchives 删除租户历史导出的归档文件
+func (r *TenantJobRunner) purgeArchives(ctx context.Context, tenantID string) (int64, error) {
+	jobs, err := r.sysJobRepo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	for _, j := range jobs {
+		if j.FilePath == "" {
+			continue
+		}
+		if err := os.Remove(j.FilePath); err != nil && !os.IsNotExist(err) {
+			return count, fmt.Errorf("remove archive %s failed: %w", j.FilePath, err)
+		}
+		j.FilePath = ""
+		if err := r.sysJobRepo.Update(ctx, j); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (r *TenantJobRunner) sign(report *tenantdata.Report) error {
+	report.FinishedAt = time.Now().Unix()
+	if r.secret == "" {
+		return fmt.Errorf("report secret is not configured")
+	}
+	return report.Sign(r.secret)
+}
+
+func (r *TenantJobRunner) progress(ctx context.Context, job *model.TenantJob, done, total int, step string) {
+	// 最后一步完成后才到 100
+	job.SetProgress(int32(done*100/(total+1)), step)
+	r.save(ctx, job)
+}
+
+func (r *TenantJobRunner) save(ctx context.Context, job *model.TenantJob) {
+	if err := r.jobRepo.Update(ctx, job); err != nil {
+		hlog.Errorf("update tenant job %s error: %v", job.ID, err)
+	}
+}
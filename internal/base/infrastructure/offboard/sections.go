@@ -0,0 +1,260 @@
+package offboard
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/tenantdata"
+)
+
+// tableSpec 租户业务表, 条件中的 ? 为租户ID
+type tableSpec struct {
+	model interface{}
+	where string
+	omit  []string
+}
+
+// tableSection 由若干租户业务表组成的分区, 在租户上下文中执行, 独立存储的租户会路由到租户库
+// shared 为 true 时表位于主库, 在忽略租户的上下文中执行, 由条件过滤租户
+type tableSection struct {
+	name   string
+	db     database.IDataBase
+	shared bool
+	tables []tableSpec
+}
+
+func (s *tableSection) Name() string {
+	return s.name
+}
+
+func (s *tableSection) ctx(ctx context.Context, tenantID string) context.Context {
+	if s.shared {
+		return actx.BuildIgnoreTenantCtx(ctx)
+	}
+	return actx.BuildTenantCtx(ctx, tenantID)
+}
+
+func (s *tableSection) Export(ctx context.Context, tenantID string, w *tenantdata.ArchiveWriter) (int64, error) {
+	ctx = s.ctx(ctx, tenantID)
+	var total int64
+	for _, t := range s.tables {
+		n, err := tenantdata.ExportTable(ctx, s.db.DB(ctx), w, tenantdata.Table{
+			Model: t.model,
+			Where: t.where,
+			Args:  []interface{}{tenantID},
+			Omit:  t.omit,
+		})
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (s *tableSection) Purge(ctx context.Context, tenantID string) (int64, error) {
+	ctx = s.ctx(ctx, tenantID)
+	var total int64
+	// 倒序删除, 先删除关联表
+	for i := len(s.tables) - 1; i >= 0; i-- {
+		t := s.tables[i]
+		n, err := tenantdata.PurgeTable(ctx, s.db.DB(ctx), t.model, t.where, tenantID)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// tenantSection 租户信息、租户权限及成员关系, 这些表位于主库
+type tenantSection struct {
+	db database.IDataBase
+}
+
+func (s *tenantSection) Name() string {
+	return "tenant"
+}
+
+func (s *tenantSection) Export(ctx context.Context, tenantID string, w *tenantdata.ArchiveWriter) (int64, error) {
+	ctx = actx.BuildIgnoreTenantCtx(ctx)
+	db := s.db.DB(ctx)
+	tables := []tenantdata.Table{
+		// 独立数据库连接串属于平台内部信息, 不导出
+		{Model: &entity.Tenant{}, Where: "id = ?", Args: []interface{}{tenantID}, Omit: []string{"db_source"}},
+		{Model: &entity.TenantPermissions{}, Where: "tenant_id = ?", Args: []interface{}{tenantID}},
+		{Model: &entity.SysUserTenant{}, Where: "tenant_id = ?", Args: []interface{}{tenantID}},
+	}
+	var total int64
+	for _, t := range tables {
+		n, err := tenantdata.ExportTable(ctx, db, w, t)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// Purge 删除租户权限及成员关系, 租户本身由任务最后删除
+func (s *tenantSection) Purge(ctx context.Context, tenantID string) (int64, error) {
+	// 租户用户可能位于租户独立库, 先在租户上下文中查出用户ID
+	var userIDs []string
+	if err := s.db.DB(actx.BuildTenantCtx(ctx, tenantID)).Model(&entity.SysUser{}).
+		Where("tenant_id = ?", tenantID).Pluck("id", &userIDs).Error; err != nil {
+		return 0, err
+	}
+	ctx = actx.BuildIgnoreTenantCtx(ctx)
+	db := s.db.DB(ctx)
+	var total int64
+	n, err := tenantdata.PurgeTable(ctx, db, &entity.TenantPermissions{}, "tenant_id = ?", tenantID)
+	if err != nil {
+		return total, err
+	}
+	total += n
+	// 加入本租户的成员关系, 以及本租户用户加入其他租户的成员关系
+	where, args := "tenant_id = ?", []interface{}{tenantID}
+	if len(userIDs) > 0 {
+		where, args = "tenant_id = ? OR user_id IN ?", []interface{}{tenantID, userIDs}
+	}
+	n, err = tenantdata.PurgeTable(ctx, db, &entity.SysUserTenant{}, where, args...)
+	if err != nil {
+		return total, err
+	}
+	return total + n, nil
+}
+
+// logSection 登录日志和操作日志, 按租户和月份分表存储在主库
+type logSection struct {
+	db database.IDataBase
+}
+
+func (s *logSection) Name() string {
+	return "logs"
+}
+
+func (s *logSection) Export(ctx context.Context, tenantID string, w *tenantdata.ArchiveWriter) (int64, error) {
+	ctx = actx.BuildIgnoreTenantCtx(ctx)
+	tables, err := s.tables(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, table := range tables {
+		n, err := tenantdata.ExportTable(ctx, s.db.DB(ctx), w, tenantdata.Table{Name: table})
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (s *logSection) Purge(ctx context.Context, tenantID string) (int64, error) {
+	ctx = actx.BuildIgnoreTenantCtx(ctx)
+	tables, err := s.tables(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, table := range tables {
+		var n int64
+		if err := s.db.DB(ctx).Table(table).Count(&n).Error; err != nil {
+			return total, err
+		}
+		if err := s.db.DB(ctx).Migrator().DropTable(table); err != nil {
+			return total, fmt.Errorf("drop log table %s failed: %w", table, err)
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// tables 获取租户的日志分表
+func (s *logSection) tables(ctx context.Context, tenantID string) ([]string, error) {
+	all, err := s.db.DB(ctx).Migrator().GetTables()
+	if err != nil {
+		return nil, err
+	}
+	prefixes := []string{
+		fmt.Sprintf("sys_login_log_%s_", tenantID),
+		fmt.Sprintf("sys_operation_log_%s_", tenantID),
+	}
+	var tables []string
+	for _, table := range all {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(table, prefix) {
+				tables = append(tables, table)
+				break
+			}
+		}
+	}
+	return tables, nil
+}
+
+// baseSections 基础模块的租户数据分区, 清除时按顺序执行, 租户分区需要在用户分区之前
+// 新增租户数据表时需在此登记对应的分区, 否则下线时不会被导出和清除
+func baseSections(db database.IDataBase) []tenantdata.Section {
+	return []tenantdata.Section{
+		&tenantSection{db: db},
+		&tableSection{name: "users", db: db, tables: []tableSpec{
+			// 密码哈希不导出
+			{model: &entity.SysUser{}, where: "tenant_id = ?", omit: []string{"password"}},
+			{model: &entity.SysUserRole{}, where: "tenant_id = ?"},
+		}},
+		&tableSection{name: "roles", db: db, tables: []tableSpec{
+			{model: &entity.Role{}, where: "tenant_id = ?"},
+			{model: &entity.RolePermissions{}, where: "tenant_id = ?"},
+		}},
+		// 用户部门、用户岗位按部门、岗位所属租户选择, 包含加入本租户的其他租户成员
+		&tableSection{name: "departments", db: db, tables: []tableSpec{
+			{model: &entity.Department{}, where: "tenant_id = ?"},
+			{model: &entity.UserDepartment{}, where: "dept_id IN (SELECT id FROM sys_department WHERE tenant_id = ?)"},
+		}},
+		&tableSection{name: "positions", db: db, tables: []tableSpec{
+			{model: &entity.Position{}, where: "tenant_id = ?"},
+			{model: &entity.UserPosition{}, where: "position_id IN (SELECT id FROM sys_position WHERE tenant_id = ?)"},
+		}},
+		&tableSection{name: "data_permissions", db: db, tables: []tableSpec{
+			{model: &entity.DataPermission{}, where: "tenant_id = ?"},
+		}},
+		&tableSection{name: "dictionaries", db: db, tables: []tableSpec{
+			{model: &entity.DictItem{}, where: "tenant_id = ?"},
+		}},
+		&tableSection{name: "role_requests", db: db, tables: []tableSpec{
+			{model: &entity.RoleRequest{}, where: "tenant_id = ?"},
+		}},
+		&tableSection{name: "role_constraints", db: db, tables: []tableSpec{
+			{model: &entity.RoleConstraint{}, where: "tenant_id = ?"},
+		}},
+		&tableSection{name: "access_reviews", db: db, tables: []tableSpec{
+			{model: &entity.AccessReview{}, where: "tenant_id = ?"},
+			{model: &entity.AccessReviewItem{}, where: "tenant_id = ?"},
+		}},
+		&tableSection{name: "impersonations", db: db, tables: []tableSpec{
+			{model: &entity.ImpersonationSession{}, where: "tenant_id = ?"},
+		}},
+		&tableSection{name: "recycle_bin", db: db, tables: []tableSpec{
+			{model: &entity.RecycleBin{}, where: "tenant_id = ?"},
+		}},
+		&tableSection{name: "audit_logs", db: db, tables: []tableSpec{
+			{model: &entity.AuditLog{}, where: "tenant_id = ?"},
+		}},
+		// 令牌哈希不导出
+		&tableSection{name: "scim_tokens", db: db, shared: true, tables: []tableSpec{
+			{model: &entity.ScimToken{}, where: "tenant_id = ?", omit: []string{"token_hash"}},
+		}},
+		// 从租户克隆的模板包含租户数据, 随租户一并清除
+		&tableSection{name: "tenant_templates", db: db, shared: true, tables: []tableSpec{
+			{model: &entity.TenantTemplate{}, where: "source_tenant_id = ?"},
+		}},
+		&logSection{db: db},
+		&tableSection{name: "log_chain", db: db, shared: true, tables: []tableSpec{
+			{model: &entity.LogChainHead{}, where: "tenant_id = ?"},
+		}},
+	}
+}
@@ -16,7 +16,7 @@ type dataPermissionRepo struct {
 func NewDataPermissionRepo(db database.IDataBase) repository.IDataPermissionRepo {
 	model := new(entity.DataPermission)
 	// 同步表
-	if err := db.DB(context.Background()).AutoMigrate(model); err != nil {
+	if err := db.AutoMigrate(model); err != nil {
 		hlog.Fatalf("sync sys user tables to db error: %v", err)
 	}
 	return &dataPermissionRepo{db: db}
@@ -55,8 +55,9 @@ func (d *dataPermissionRepo) Save(ctx context.Context, e *entity.DataPermission)
 		return d.db.DB(ctx).Model(&entity.DataPermission{}).
 			Where("role_id = ?", e.RoleID).
 			Updates(map[string]interface{}{
-				"scope":    e.Scope,
-				"dept_ids": e.DeptIDs,
+				"scope":        e.Scope,
+				"dept_ids":     e.DeptIDs,
+				"position_ids": e.PositionIDs,
 			}).Error
 	}
 
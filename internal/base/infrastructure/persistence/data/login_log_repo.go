@@ -3,30 +3,100 @@ package data
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hashchain"
+	"gorm.io/gorm"
 )
 
 type loginLogRepo struct {
-	db database.IDataBase
+	db       database.IDataBase
+	chain    repository.ILogChainRepo
+	migrated sync.Map // 本进程已同步过结构的表
 }
 
-func NewLoginLogRepo(db database.IDataBase) repository.ILoginLogRepo {
+func NewLoginLogRepo(db database.IDataBase, chain repository.ILogChainRepo) repository.ILoginLogRepo {
 	return &loginLogRepo{
-		db: db,
+		db:    db,
+		chain: chain,
 	}
 }
 
+// Create 创建登录日志, 链接到租户登录日志哈希链的末尾
 func (r *loginLogRepo) Create(ctx context.Context, log *entity.LoginLog) error {
 	t := time.Unix(log.LoginTime, 0)
 	if err := r.EnsureTable(ctx, log.TenantID, t); err != nil {
 		return err
 	}
-	return r.db.DB(ctx).Table(r.GetTableName(log.TenantID, t)).Create(log).Error
+	return r.chain.Append(ctx, entity.LogChainLogin, log.TenantID, t, func(tx *gorm.DB, head *entity.LogChainHead) error {
+		log.Seq = head.Seq + 1
+		log.PrevHash = head.Hash
+		content, err := loginLogContent(log)
+		if err != nil {
+			return err
+		}
+		log.Hash = hashchain.Hash(log.PrevHash, content)
+		if err := tx.Table(r.GetTableName(log.TenantID, t)).Create(log).Error; err != nil {
+			return err
+		}
+		head.Seq, head.Hash = log.Seq, log.Hash
+		return nil
+	})
+}
+
+// FindChain 查询哈希链记录, 表不存在时返回空
+func (r *loginLogRepo) FindChain(ctx context.Context, tenantID string, month time.Time, afterSeq int64, limit int) ([]*hashchain.Entry, error) {
+	tableName := r.GetTableName(tenantID, month)
+	db := r.db.DB(actx.BuildIgnoreTenantCtx(ctx))
+	if !db.Migrator().HasTable(tableName) {
+		return nil, nil
+	}
+	var logs []*entity.LoginLog
+	err := db.Table(tableName).
+		Where("seq > ?", afterSeq).
+		Order("seq").
+		Limit(limit).
+		Find(&logs).Error
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*hashchain.Entry, 0, len(logs))
+	for _, log := range logs {
+		content, err := loginLogContent(log)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &hashchain.Entry{ID: log.ID, Seq: log.Seq, PrevHash: log.PrevHash, Hash: log.Hash, Content: content})
+	}
+	return entries, nil
+}
+
+// loginLogContent 登录日志的规范形式, 字段顺序不可调整; 创建时间由数据库写入时生成, 不参与哈希
+func loginLogContent(log *entity.LoginLog) ([]byte, error) {
+	return hashchain.Canonical(struct {
+		Seq       int64  `json:"seq"`
+		TenantID  string `json:"tenant_id"`
+		UserID    string `json:"user_id"`
+		Username  string `json:"username"`
+		LoginType int8   `json:"login_type"`
+		IP        string `json:"ip"`
+		Location  string `json:"location"`
+		Device    string `json:"device"`
+		OS        string `json:"os"`
+		Browser   string `json:"browser"`
+		Status    int8   `json:"status"`
+		Message   string `json:"message"`
+		LoginTime int64  `json:"login_time"`
+	}{
+		log.Seq, log.TenantID, log.UserID, log.Username, log.LoginType, log.IP, log.Location,
+		log.Device, log.OS, log.Browser, log.Status, log.Message, log.LoginTime,
+	})
 }
 
 func (r *loginLogRepo) FindByID(ctx context.Context, id int64) (*entity.LoginLog, error) {
@@ -58,8 +128,8 @@ func (r *loginLogRepo) Find(ctx context.Context, tenantID string, month time.Tim
 	}
 
 	// 添加分页
-	if limit, offset := qb.BuildLimit(); limit != "" {
-		db = db.Limit(offset[1]).Offset(offset[0])
+	if limit, values := qb.BuildLimit(); limit != "" {
+		db = db.Limit(values[0]).Offset(values[1])
 	}
 
 	// 执行查询
@@ -91,11 +161,11 @@ func (r *loginLogRepo) Count(ctx context.Context, tenantID string, month time.Ti
 	return count, nil
 }
 
+// EnsureTable 确保表存在且结构与实体一致
+// 按月分表在首次写入时创建, 已存在的表在本进程首次访问时同步一次, 以补充新增的字段
 func (r *loginLogRepo) EnsureTable(ctx context.Context, tenantID string, month time.Time) error {
 	tableName := r.GetTableName(tenantID, month)
-
-	// 检查表是否存在
-	if r.db.DB(ctx).Migrator().HasTable(tableName) {
+	if _, ok := r.migrated.Load(tableName); ok {
 		return nil
 	}
 
@@ -105,7 +175,11 @@ func (r *loginLogRepo) EnsureTable(ctx context.Context, tenantID string, month t
 	}
 
 	// 使用 GORM 自动迁移创建表
-	return r.db.DB(ctx).Table(tableName).AutoMigrate(&LoginLogTable{})
+	if err := r.db.DB(ctx).Table(tableName).AutoMigrate(&LoginLogTable{}); err != nil {
+		return err
+	}
+	r.migrated.Store(tableName, true)
+	return nil
 }
 
 func (r *loginLogRepo) GetTableName(tenantID string, month time.Time) string {
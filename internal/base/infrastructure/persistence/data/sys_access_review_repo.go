@@ -0,0 +1,141 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+)
+
+type sysAccessReviewRepo struct {
+	*baserepo.BaseRepo[entity.AccessReview, string]
+}
+
+func NewSysAccessReviewRepo(data database.IDataBase) repository.ISysAccessReviewRepo {
+	model := new(entity.AccessReview)
+	// 同步表
+	if err := data.AutoMigrate(model, &entity.AccessReviewItem{}); err != nil {
+		hlog.Fatalf("sync sys access review tables to db error: %v", err)
+	}
+	return &sysAccessReviewRepo{
+		BaseRepo: baserepo.NewBaseRepo[entity.AccessReview, string](data, entity.AccessReview{}),
+	}
+}
+
+// CreateWithItems 保存复核活动及复核项
+func (r *sysAccessReviewRepo) CreateWithItems(ctx context.Context, campaign *entity.AccessReview, items []*entity.AccessReviewItem) error {
+	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
+		if err := r.Db(ctx).Create(campaign).Error; err != nil {
+			return err
+		}
+		return r.Db(ctx).CreateInBatches(items, 200).Error
+	})
+}
+
+// GetItem 获取复核项
+func (r *sysAccessReviewRepo) GetItem(ctx context.Context, id string) (*entity.AccessReviewItem, error) {
+	var item entity.AccessReviewItem
+	if err := r.Db(ctx).Where("id = ?", id).First(&item).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ListItems 复核活动的复核项, reviewerID 不为空时只返回分配给该复核人的复核项
+func (r *sysAccessReviewRepo) ListItems(ctx context.Context, campaignID, reviewerID string) ([]*entity.AccessReviewItem, error) {
+	var list []*entity.AccessReviewItem
+	db := r.Db(ctx).Where("campaign_id = ?", campaignID)
+	if reviewerID != "" {
+		db = db.Where("reviewer_id = ?", reviewerID)
+	}
+	err := db.Order("kind, user_id, role_id, permission_id").Find(&list).Error
+	return list, err
+}
+
+// ListOpenItemsByReviewer 进行中的复核活动里分配给复核人的复核项
+func (r *sysAccessReviewRepo) ListOpenItemsByReviewer(ctx context.Context, reviewerID string) ([]*entity.AccessReviewItem, error) {
+	var list []*entity.AccessReviewItem
+	err := r.Db(ctx).
+		Where("reviewer_id = ?", reviewerID).
+		Where("campaign_id IN (?)", r.Db(ctx).Model(&entity.AccessReview{}).Select("id").Where("status = ?", 1)). // 1:进行中
+		Order("campaign_id, kind, user_id, role_id, permission_id").
+		Find(&list).Error
+	return list, err
+}
+
+// UpdateItemDecision 保存复核结果
+func (r *sysAccessReviewRepo) UpdateItemDecision(ctx context.Context, item *entity.AccessReviewItem) error {
+	return r.Db(ctx).Model(&entity.AccessReviewItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+		"decision":   item.Decision,
+		"comment":    item.Comment,
+		"decided_at": item.DecidedAt,
+	}).Error
+}
+
+// CloseWithRevocations 收回用户角色和角色权限, 标记复核项生效时间并关闭活动
+func (r *sysAccessReviewRepo) CloseWithRevocations(ctx context.Context, campaign *entity.AccessReview, userRoles []*entity.SysUserRole, rolePerms []*entity.RolePermissions, itemIDs []string) error {
+	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
+		for _, ur := range userRoles {
+			if err := r.Db(ctx).Where("user_id = ? AND role_id = ?", ur.UserID, ur.RoleID).
+				Delete(&entity.SysUserRole{}).Error; err != nil {
+				return err
+			}
+		}
+		for _, rp := range rolePerms {
+			if err := r.Db(ctx).Where("role_id = ? AND permission_id = ?", rp.RoleID, rp.PermissionID).
+				Delete(&entity.RolePermissions{}).Error; err != nil {
+				return err
+			}
+		}
+		if len(itemIDs) > 0 {
+			if err := r.Db(ctx).Model(&entity.AccessReviewItem{}).Where("id IN ?", itemIDs).
+				Update("applied_at", campaign.ClosedAt).Error; err != nil {
+				return err
+			}
+		}
+		return r.Db(ctx).Model(&entity.AccessReview{}).Where("id = ?", campaign.ID).Updates(map[string]interface{}{
+			"status":    campaign.Status,
+			"closed_by": campaign.ClosedBy,
+			"closed_at": campaign.ClosedAt,
+		}).Error
+	})
+}
+
+// ListDeptMembers 部门中的用户
+func (r *sysAccessReviewRepo) ListDeptMembers(ctx context.Context, deptIDs []string) ([]*entity.UserDepartment, error) {
+	var list []*entity.UserDepartment
+	if len(deptIDs) == 0 {
+		return list, nil
+	}
+	err := r.Db(ctx).Where("dept_id IN ?", deptIDs).Order("user_id").Find(&list).Error
+	return list, err
+}
+
+// ListUserRoles 未过期的用户角色, 包括尚未生效的授予
+func (r *sysAccessReviewRepo) ListUserRoles(ctx context.Context, userIDs []string, roleIDs []int64) ([]*entity.SysUserRole, error) {
+	var list []*entity.SysUserRole
+	db := r.Db(ctx).Where("(expire_time = 0 OR expire_time > ?)", time.Now().Unix())
+	if len(userIDs) > 0 {
+		db = db.Where("user_id IN ?", userIDs)
+	}
+	if len(roleIDs) > 0 {
+		db = db.Where("role_id IN ?", roleIDs)
+	}
+	err := db.Order("user_id, role_id").Find(&list).Error
+	return list, err
+}
+
+// ListRolePermissions 角色的权限关联
+func (r *sysAccessReviewRepo) ListRolePermissions(ctx context.Context, roleIDs []int64) ([]*entity.RolePermissions, error) {
+	var list []*entity.RolePermissions
+	if len(roleIDs) == 0 {
+		return list, nil
+	}
+	err := r.Db(ctx).Where("role_id IN ?", roleIDs).Order("role_id, permission_id").Find(&list).Error
+	return list, err
+}
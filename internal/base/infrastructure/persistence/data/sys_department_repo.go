@@ -2,7 +2,12 @@ package data
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"gorm.io/gorm"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
@@ -17,14 +22,332 @@ type sysDepartmentRepo struct {
 func NewSysDepartmentRepo(data database.IDataBase) repository.ISysDepartmentRepo {
 	model := new(entity.Department)
 	// 同步表
-	if err := data.DB(context.Background()).AutoMigrate(model, &entity.UserDepartment{}); err != nil {
+	if err := data.AutoMigrate(model, &entity.UserDepartment{}); err != nil {
 		hlog.Fatalf("sync sys department tables to db error: %v", err)
 	}
+	// 部门编码改为租户内唯一, 移除旧的全局唯一索引
+	if migrator := data.DB(context.Background()).Migrator(); migrator.HasIndex(model, "idx_sys_department_code") {
+		if err := migrator.DropIndex(model, "idx_sys_department_code"); err != nil {
+			hlog.Fatalf("drop sys department code index error: %v", err)
+		}
+	}
+	// 补全历史部门的层级路径
+	if err := rebuildDeptPaths(data.DB(context.Background())); err != nil {
+		hlog.Fatalf("rebuild sys department path error: %v", err)
+	}
 	return &sysDepartmentRepo{
 		BaseRepo: baserepo.NewBaseRepo[entity.Department, string](data, entity.Department{}),
 	}
 }
 
+// deptPath 拼接部门层级路径
+func deptPath(parentPath string, id string) string {
+	if parentPath == "" {
+		parentPath = "/"
+	}
+	return parentPath + id + "/"
+}
+
+// rebuildDeptPaths 存在未设置路径的部门时, 按父部门关系重建全部部门的层级路径
+func rebuildDeptPaths(db *gorm.DB) error {
+	var missing int64
+	if err := db.Model(&entity.Department{}).Where("path = '' OR path IS NULL").Count(&missing).Error; err != nil {
+		return err
+	}
+	if missing == 0 {
+		return nil
+	}
+	var depts []*entity.Department
+	if err := db.Select("id", "parent_id", "path").Find(&depts).Error; err != nil {
+		return err
+	}
+	parents := make(map[string]string, len(depts))
+	for _, dept := range depts {
+		parents[dept.ID] = dept.ParentID
+	}
+	for _, dept := range depts {
+		// 自下而上查找祖先, 父部门不存在或出现环时视为根部门
+		ids := []string{dept.ID}
+		seen := map[string]bool{dept.ID: true}
+		for parentID := parents[dept.ID]; parentID != ""; parentID = parents[parentID] {
+			if _, ok := parents[parentID]; !ok || seen[parentID] {
+				break
+			}
+			seen[parentID] = true
+			ids = append(ids, parentID)
+		}
+		path := "/"
+		for i := len(ids) - 1; i >= 0; i-- {
+			path = deptPath(path, ids[i])
+		}
+		if path == dept.Path {
+			continue
+		}
+		if err := db.Model(&entity.Department{}).Where("id = ?", dept.ID).Update("path", path).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Create 创建部门并设置层级路径
+func (r *sysDepartmentRepo) Create(ctx context.Context, dept *entity.Department) error {
+	parentPath := ""
+	if dept.ParentID != "" {
+		parent, err := r.FindById(ctx, dept.ParentID)
+		if err != nil {
+			return err
+		}
+		parentPath = parent.Path
+	}
+	dept.Path = deptPath(parentPath, dept.ID)
+	_, err := r.Add(ctx, dept)
+	return err
+}
+
+// Move 移动部门到新的父部门下, 同时更新所有下级部门的层级路径
+func (r *sysDepartmentRepo) Move(ctx context.Context, id string, parentID string) error {
+	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
+		dept, err := r.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+		parentPath := ""
+		if parentID != "" {
+			parent, err := r.FindById(ctx, parentID)
+			if err != nil {
+				return err
+			}
+			if strings.HasPrefix(parent.Path, dept.Path) {
+				return fmt.Errorf("department %s cannot be moved under its descendant %s", id, parentID)
+			}
+			parentPath = parent.Path
+		}
+		oldPath, newPath := dept.Path, deptPath(parentPath, id)
+		if err := r.Db(ctx).Model(&entity.Department{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"parent_id":  parentID,
+			"updated_at": time.Now().Unix(),
+		}).Error; err != nil {
+			return err
+		}
+		if oldPath == newPath {
+			return nil
+		}
+		return r.Db(ctx).Model(&entity.Department{}).Where("path LIKE ?", oldPath+"%").
+			Update("path", gorm.Expr("REPLACE(path, ?, ?)", oldPath, newPath)).Error
+	})
+}
+
+// Merge 在一个事务内将源部门的用户、子部门、自定义数据范围引用和管理员转移到目标部门, 然后删除源部门
+// 用户已在目标部门时只保留一条关联, 源部门是用户主部门时目标部门成为主部门
+func (r *sysDepartmentRepo) Merge(ctx context.Context, sourceID string, targetID string) (*repository.MergeResult, error) {
+	result := &repository.MergeResult{}
+	err := r.GetDb().InTx(ctx, func(ctx context.Context) error {
+		source, err := r.FindById(ctx, sourceID)
+		if err != nil {
+			return err
+		}
+		target, err := r.FindById(ctx, targetID)
+		if err != nil {
+			return err
+		}
+
+		// 1. 转移用户
+		var members []*entity.UserDepartment
+		if err := r.Db(ctx).Where("dept_id = ?", sourceID).Find(&members).Error; err != nil {
+			return err
+		}
+		var existing []string
+		if err := r.Db(ctx).Model(&entity.UserDepartment{}).Where("dept_id = ?", targetID).
+			Pluck("user_id", &existing).Error; err != nil {
+			return err
+		}
+		inTarget := make(map[string]bool, len(existing))
+		for _, userID := range existing {
+			inTarget[userID] = true
+		}
+		for _, member := range members {
+			result.UserIDs = append(result.UserIDs, member.UserID)
+			if !inTarget[member.UserID] {
+				if err := r.Db(ctx).Model(&entity.UserDepartment{}).Where("id = ?", member.ID).
+					Update("dept_id", targetID).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if member.IsPrimary {
+				if err := r.Db(ctx).Model(&entity.UserDepartment{}).
+					Where("user_id = ? AND dept_id = ?", member.UserID, targetID).
+					Update("is_primary", true).Error; err != nil {
+					return err
+				}
+			}
+			if err := r.Db(ctx).Where("id = ?", member.ID).Delete(&entity.UserDepartment{}).Error; err != nil {
+				return err
+			}
+		}
+
+		// 2. 转移子部门
+		children, err := r.GetByParentID(ctx, sourceID)
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			if err := r.Move(ctx, child.ID, targetID); err != nil {
+				return err
+			}
+			result.ChildIDs = append(result.ChildIDs, child.ID)
+		}
+
+		// 3. 替换自定义数据范围中的源部门
+		if result.RoleIDs, err = r.replaceScopeDept(ctx, sourceID, targetID); err != nil {
+			return err
+		}
+
+		// 4. 目标部门没有管理员时由源部门管理员接任
+		if target.AdminID == "" && source.AdminID != "" {
+			if err := r.Db(ctx).Model(&entity.Department{}).Where("id = ?", targetID).
+				Update("admin_id", source.AdminID).Error; err != nil {
+				return err
+			}
+		}
+
+		// 5. 删除源部门
+		return r.DelByIdUnScoped(ctx, sourceID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// replaceScopeDept 将数据权限部门列表中的源部门替换为目标部门, 返回受影响的角色
+func (r *sysDepartmentRepo) replaceScopeDept(ctx context.Context, sourceID string, targetID string) ([]int64, error) {
+	var perms []*entity.DataPermission
+	if err := r.Db(ctx).Where("dept_ids LIKE ?", "%"+sourceID+"%").Find(&perms).Error; err != nil {
+		return nil, err
+	}
+	roleIDs := make([]int64, 0, len(perms))
+	for _, perm := range perms {
+		ids := strings.Split(perm.DeptIDs, ",")
+		replaced := make([]string, 0, len(ids))
+		seen := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			if id == sourceID {
+				id = targetID
+			}
+			if id == "" || seen[id] {
+				continue
+			}
+			seen[id] = true
+			replaced = append(replaced, id)
+		}
+		deptIDs := strings.Join(replaced, ",")
+		if deptIDs == perm.DeptIDs {
+			continue
+		}
+		if err := r.Db(ctx).Model(&entity.DataPermission{}).Where("id = ?", perm.ID).
+			Update("dept_ids", deptIDs).Error; err != nil {
+			return nil, err
+		}
+		roleIDs = append(roleIDs, perm.RoleID)
+	}
+	return roleIDs, nil
+}
+
+// CountUsersGroupByDept 统计各部门的直属用户数
+func (r *sysDepartmentRepo) CountUsersGroupByDept(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		DeptID string
+		Count  int64
+	}
+	if err := r.Db(ctx).Model(&entity.UserDepartment{}).
+		Select("sys_user_dept.dept_id AS dept_id, COUNT(*) AS count").
+		Joins("JOIN sys_department ON sys_department.id = sys_user_dept.dept_id").
+		Group("sys_user_dept.dept_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.DeptID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetDescendants 获取部门的全部下级部门(不含自身), 按层级路径排序
+func (r *sysDepartmentRepo) GetDescendants(ctx context.Context, id string) ([]*entity.Department, error) {
+	var depts []*entity.Department
+	db := r.Db(ctx)
+	if id != "" {
+		dept, err := r.FindById(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where("path LIKE ? AND id <> ?", dept.Path+"%", id)
+	}
+	err := db.Order("path").Find(&depts).Error
+	return depts, err
+}
+
+// GetDescendantIDs 获取部门及其全部下级部门ID
+func (r *sysDepartmentRepo) GetDescendantIDs(ctx context.Context, ids []string) ([]string, error) {
+	var result []string
+	if len(ids) == 0 {
+		return result, nil
+	}
+	var paths []string
+	if err := r.Db(ctx).Model(&entity.Department{}).Where("id IN ?", ids).Pluck("path", &paths).Error; err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return result, nil
+	}
+	conds := make([]string, 0, len(paths))
+	args := make([]interface{}, 0, len(paths))
+	for _, path := range paths {
+		conds = append(conds, "path LIKE ?")
+		args = append(args, path+"%")
+	}
+	err := r.Db(ctx).Model(&entity.Department{}).
+		Where(strings.Join(conds, " OR "), args...).
+		Pluck("id", &result).Error
+	return result, err
+}
+
+// GetAncestors 获取部门的全部上级部门(不含自身), 从根部门开始
+func (r *sysDepartmentRepo) GetAncestors(ctx context.Context, id string) ([]*entity.Department, error) {
+	dept, err := r.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ids := strings.Split(strings.Trim(dept.Path, "/"), "/")
+	ids = ids[:len(ids)-1]
+	if len(ids) == 0 {
+		return []*entity.Department{}, nil
+	}
+	var depts []*entity.Department
+	if err := r.Db(ctx).Where("id IN ?", ids).Order("path").Find(&depts).Error; err != nil {
+		return nil, err
+	}
+	return depts, nil
+}
+
+// CountSubtreeUsers 统计部门及其全部下级部门的用户数, 同一用户只计一次
+func (r *sysDepartmentRepo) CountSubtreeUsers(ctx context.Context, id string) (int64, error) {
+	dept, err := r.FindById(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	err = r.Db(ctx).Model(&entity.UserDepartment{}).
+		Joins("JOIN sys_department ON sys_department.id = sys_user_dept.dept_id").
+		Where("sys_department.path LIKE ?", dept.Path+"%").
+		Distinct("sys_user_dept.user_id").
+		Count(&count).Error
+	return count, err
+}
+
 // GetByCode 根据编码获取部门
 func (r *sysDepartmentRepo) GetByCode(ctx context.Context, code string) (*entity.Department, error) {
 	var dept entity.Department
@@ -35,6 +358,16 @@ func (r *sysDepartmentRepo) GetByCode(ctx context.Context, code string) (*entity
 	return &dept, nil
 }
 
+// GetByCodes 根据编码批量获取部门
+func (r *sysDepartmentRepo) GetByCodes(ctx context.Context, codes []string) ([]*entity.Department, error) {
+	var depts []*entity.Department
+	if len(codes) == 0 {
+		return depts, nil
+	}
+	err := r.Db(ctx).Where("code IN ?", codes).Find(&depts).Error
+	return depts, err
+}
+
 // GetByParentID 获取子部门
 func (r *sysDepartmentRepo) GetByParentID(ctx context.Context, parentID string) ([]*entity.Department, error) {
 	var depts []*entity.Department
@@ -45,21 +378,32 @@ func (r *sysDepartmentRepo) GetByParentID(ctx context.Context, parentID string)
 	return depts, nil
 }
 
-// GetByUserID 获取用户部门关联
+// GetByUserID 获取用户部门关联, 主部门在前
 func (r *sysDepartmentRepo) GetByUserID(ctx context.Context, userID string) ([]*entity.UserDepartment, error) {
 	var list []*entity.UserDepartment
-	err := r.Db(ctx).Where("user_id = ?", userID).Find(&list).Error
+	err := r.Db(ctx).Where("user_id = ?", userID).Order("is_primary DESC").Order("id").Find(&list).Error
 	return list, err
 }
 func (r *sysDepartmentRepo) GetDeptByUserID(ctx context.Context, userID string) ([]*entity.Department, error) {
 	var list []*entity.Department
-	err := r.Db(ctx).Model(&entity.UserDepartment{}).
-		Joins("LEFT JOIN sys_department ON sys_department.id = user_department.dept_id").
-		Where("user_department.user_id = ?", userID).
+	err := r.Db(ctx).Model(&entity.Department{}).
+		Joins("JOIN sys_user_dept ON sys_department.id = sys_user_dept.dept_id").
+		Where("sys_user_dept.user_id = ?", userID).
+		Order("sys_user_dept.is_primary DESC").Order("sys_user_dept.id").
 		Find(&list).Error
 	return list, err
 }
 
+// GetByUserIDs 批量获取用户部门关联
+func (r *sysDepartmentRepo) GetByUserIDs(ctx context.Context, userIDs []string) ([]*entity.UserDepartment, error) {
+	var list []*entity.UserDepartment
+	if len(userIDs) == 0 {
+		return list, nil
+	}
+	err := r.Db(ctx).Where("user_id IN ?", userIDs).Find(&list).Error
+	return list, err
+}
+
 // FindByIds 根据ID列表查询部门
 func (r *sysDepartmentRepo) FindByIds(ctx context.Context, ids []string) ([]*entity.Department, error) {
 	var depts []*entity.Department
@@ -70,43 +414,125 @@ func (r *sysDepartmentRepo) FindByIds(ctx context.Context, ids []string) ([]*ent
 	return depts, nil
 }
 
-// AssignUsers 分配用户到部门
+// AssignUsers 分配用户到部门, 已在部门中的用户忽略, 没有主部门的用户以该部门为主部门
 func (r *sysDepartmentRepo) AssignUsers(ctx context.Context, deptID string, userIDs []string) error {
 	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
-		// 批量创建用户部门关联
-		userDepts := make([]*entity.UserDepartment, 0, len(userIDs))
-		for _, userID := range userIDs {
-			userDepts = append(userDepts, &entity.UserDepartment{
-				ID:     r.GenInt64Id(),
-				UserID: userID,
-				DeptID: deptID,
-			})
-		}
-		return r.Db(ctx).Create(&userDepts).Error
+		if err := r.addUsers(ctx, deptID, userIDs); err != nil {
+			return err
+		}
+		return r.ensurePrimary(ctx, userIDs)
 	})
 }
 
-// RemoveUsers 从部门移除用户
+// RemoveUsers 从部门移除用户, 移除的是主部门时由剩余最早加入的部门接任
 func (r *sysDepartmentRepo) RemoveUsers(ctx context.Context, deptID string, userIDs []string) error {
-	return r.Db(ctx).Where("dept_id = ? AND user_id IN ?", deptID, userIDs).
-		Delete(&entity.UserDepartment{}).Error
+	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
+		if err := r.Db(ctx).Where("dept_id = ? AND user_id IN ?", deptID, userIDs).
+			Delete(&entity.UserDepartment{}).Error; err != nil {
+			return err
+		}
+		return r.ensurePrimary(ctx, userIDs)
+	})
 }
 
-// TransferUser 调动用户部门
+// TransferUser 调动用户部门, 原部门为主部门时新部门成为主部门
 func (r *sysDepartmentRepo) TransferUser(ctx context.Context, userID string, fromDeptID string, toDeptID string) error {
 	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
 		// 1. 如果有原部门，先移除
+		wasPrimary := false
 		if fromDeptID != "" {
-			if err := r.RemoveUsers(ctx, fromDeptID, []string{userID}); err != nil {
+			var count int64
+			if err := r.Db(ctx).Model(&entity.UserDepartment{}).
+				Where("user_id = ? AND dept_id = ? AND is_primary = ?", userID, fromDeptID, true).
+				Count(&count).Error; err != nil {
+				return err
+			}
+			wasPrimary = count > 0
+			if err := r.Db(ctx).Where("dept_id = ? AND user_id = ?", fromDeptID, userID).
+				Delete(&entity.UserDepartment{}).Error; err != nil {
 				return err
 			}
 		}
 
 		// 2. 添加到新部门
-		if err := r.AssignUsers(ctx, toDeptID, []string{userID}); err != nil {
+		if err := r.addUsers(ctx, toDeptID, []string{userID}); err != nil {
 			return err
 		}
+		if wasPrimary {
+			return r.SetPrimary(ctx, userID, toDeptID)
+		}
+		return r.ensurePrimary(ctx, []string{userID})
+	})
+}
 
-		return nil
+// SetPrimary 设置用户主部门
+func (r *sysDepartmentRepo) SetPrimary(ctx context.Context, userID string, deptID string) error {
+	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
+		if err := r.Db(ctx).Model(&entity.UserDepartment{}).
+			Where("user_id = ? AND dept_id <> ?", userID, deptID).
+			Update("is_primary", false).Error; err != nil {
+			return err
+		}
+		return r.Db(ctx).Model(&entity.UserDepartment{}).
+			Where("user_id = ? AND dept_id = ?", userID, deptID).
+			Update("is_primary", true).Error
 	})
 }
+
+// addUsers 添加部门成员, 已在部门中的用户忽略
+func (r *sysDepartmentRepo) addUsers(ctx context.Context, deptID string, userIDs []string) error {
+	var exists []string
+	if err := r.Db(ctx).Model(&entity.UserDepartment{}).
+		Where("dept_id = ? AND user_id IN ?", deptID, userIDs).
+		Pluck("user_id", &exists).Error; err != nil {
+		return err
+	}
+	skip := make(map[string]bool, len(exists))
+	for _, id := range exists {
+		skip[id] = true
+	}
+	userDepts := make([]*entity.UserDepartment, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if skip[userID] {
+			continue
+		}
+		skip[userID] = true
+		userDepts = append(userDepts, &entity.UserDepartment{
+			ID:     r.GenInt64Id(),
+			UserID: userID,
+			DeptID: deptID,
+		})
+	}
+	if len(userDepts) == 0 {
+		return nil
+	}
+	return r.Db(ctx).Create(&userDepts).Error
+}
+
+// ensurePrimary 为有部门但没有主部门的用户设置最早加入的部门为主部门
+func (r *sysDepartmentRepo) ensurePrimary(ctx context.Context, userIDs []string) error {
+	list, err := r.GetByUserIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	first := make(map[string]*entity.UserDepartment, len(userIDs))
+	hasPrimary := make(map[string]bool, len(userIDs))
+	for _, ud := range list {
+		if ud.IsPrimary {
+			hasPrimary[ud.UserID] = true
+		}
+		if f, ok := first[ud.UserID]; !ok || ud.ID < f.ID {
+			first[ud.UserID] = ud
+		}
+	}
+	for userID, ud := range first {
+		if hasPrimary[userID] {
+			continue
+		}
+		if err := r.Db(ctx).Model(&entity.UserDepartment{}).
+			Where("id = ?", ud.ID).Update("is_primary", true).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package data
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+)
+
+type sysImpersonationRepo struct {
+	*baserepo.BaseRepo[entity.ImpersonationSession, string]
+}
+
+func NewSysImpersonationRepo(data database.IDataBase) repository.ISysImpersonationRepo {
+	model := new(entity.ImpersonationSession)
+	// 同步表
+	if err := data.AutoMigrate(model); err != nil {
+		hlog.Fatalf("sync sys impersonation session tables to db error: %v", err)
+	}
+	return &sysImpersonationRepo{
+		BaseRepo: baserepo.NewBaseRepo[entity.ImpersonationSession, string](data, entity.ImpersonationSession{}),
+	}
+}
+
+// UpdateEndedAt 保存会话结束时间
+func (r *sysImpersonationRepo) UpdateEndedAt(ctx context.Context, id string, endedAt int64) error {
+	return r.Db(ctx).Model(&entity.ImpersonationSession{}).Where("id = ?", id).Update("ended_at", endedAt).Error
+}
@@ -2,12 +2,14 @@ package data
 
 import (
 	"context"
+	"time"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
 
 	"github.com/cloudwego/hertz/pkg/common/hlog"
 
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
@@ -195,8 +197,9 @@ func (r *sysMenuRepo) GetTreeByUserAndType(ctx context.Context, userID string, p
 	var rolePerms []*entity.RolePermissions
 	var userRoles []*entity.SysUserRole
 
-	// 查询用户角色
-	err := r.Db(ctx).Where("user_id = ?", userID).Find(&userRoles).Error
+	// 查询用户当前生效的角色
+	now := time.Now().Unix()
+	err := r.Db(ctx).Where("user_id = ?", userID).Where(activeUserRole("sys_user_role"), now, now).Find(&userRoles).Error
 	if err != nil {
 		return nil, nil, err
 	}
@@ -314,45 +317,48 @@ func getPermissionIDs(permissions []*entity.Permissions) []int64 {
 }
 
 // GetResourcesByRolesGrouped 根据角色ID获取分组的权限资源
+// 角色权限关联是租户表, 权限和权限资源是公共表, 独立数据库实例的租户无法关联查询,
+// 先在租户库中查询角色的权限ID, 再到主库中查询权限资源
 func (r *sysMenuRepo) GetResourcesByRolesGrouped(ctx context.Context, roles []int64) (map[int64][]*entity.PermissionsResource, error) {
 	// 结果map: roleID -> resources
 	resourceMap := make(map[int64][]*entity.PermissionsResource)
-
-	// 1. 先查询角色权限关联和权限资源
-	var results []struct {
-		RoleID       int64  `gorm:"column:role_id"`
-		Method       string `gorm:"column:method"`
-		Path         string `gorm:"column:path"`
-		PermissionID int64  `gorm:"column:permissions_id"`
+	for _, roleID := range roles {
+		resourceMap[roleID] = []*entity.PermissionsResource{}
 	}
 
-	err := r.Db(ctx).Table("sys_role_permissions").
-		Select("sys_role_permissions.role_id, pr.method, pr.path, pr.permissions_id").
-		Joins("JOIN sys_permissions p ON p.id = sys_role_permissions.permission_id").
-		Joins("JOIN sys_permissions_resource pr ON pr.permissions_id = p.id").
-		Where("sys_role_permissions.role_id IN ? AND p.status = ?", roles, 1).
-		Scan(&results).Error
-	if err != nil {
+	// 1. 查询角色权限关联
+	var links []*entity.RolePermissions
+	if err := r.Db(ctx).Select("role_id", "permission_id").Where("role_id IN ?", roles).Find(&links).Error; err != nil {
 		return nil, err
 	}
+	if len(links) == 0 {
+		return resourceMap, nil
+	}
+	rolesByPerm := make(map[int64][]int64)
+	for _, link := range links {
+		rolesByPerm[link.PermissionID] = append(rolesByPerm[link.PermissionID], link.RoleID)
+	}
+	permIDs := make([]int64, 0, len(rolesByPerm))
+	for id := range rolesByPerm {
+		permIDs = append(permIDs, id)
+	}
 
-	// 2. 组织数据到map中
-	for _, result := range results {
-		resource := &entity.PermissionsResource{
-			PermissionsID: result.PermissionID,
-			Method:        result.Method,
-			Path:          result.Path,
-		}
-		resourceMap[result.RoleID] = append(resourceMap[result.RoleID], resource)
+	// 2. 查询启用的权限资源
+	var resources []*entity.PermissionsResource
+	err := r.Db(actx.BuildIgnoreTenantCtx(ctx)).Model(&entity.PermissionsResource{}).
+		Joins("JOIN sys_permissions p ON p.id = sys_permissions_resource.permissions_id").
+		Where("sys_permissions_resource.permissions_id IN ? AND p.status = ?", permIDs, 1).
+		Find(&resources).Error
+	if err != nil {
+		return nil, err
 	}
 
-	// 3. 确保所有角色都有对应的切片(即使是空的)
-	for _, roleID := range roles {
-		if _, exists := resourceMap[roleID]; !exists {
-			resourceMap[roleID] = []*entity.PermissionsResource{}
+	// 3. 按角色分组
+	for _, resource := range resources {
+		for _, roleID := range rolesByPerm[resource.PermissionsID] {
+			resourceMap[roleID] = append(resourceMap[roleID], resource)
 		}
 	}
-
 	return resourceMap, nil
 }
 
@@ -0,0 +1,78 @@
+package data
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+type sysPositionRepo struct {
+	*baserepo.BaseRepo[entity.Position, string]
+}
+
+func NewSysPositionRepo(data database.IDataBase) repository.ISysPositionRepo {
+	model := new(entity.Position)
+	// 同步表
+	if err := data.AutoMigrate(model, &entity.UserPosition{}); err != nil {
+		hlog.Fatalf("sync sys position tables to db error: %v", err)
+	}
+	return &sysPositionRepo{
+		BaseRepo: baserepo.NewBaseRepo[entity.Position, string](data, entity.Position{}),
+	}
+}
+
+// GetByCode 根据编码获取岗位
+func (r *sysPositionRepo) GetByCode(ctx context.Context, code string) (*entity.Position, error) {
+	var position entity.Position
+	err := r.Db(ctx).Where("code = ?", code).First(&position).Error
+	if err != nil {
+		return nil, err
+	}
+	return &position, nil
+}
+
+// GetByUserID 获取用户岗位关联, 主岗在前
+func (r *sysPositionRepo) GetByUserID(ctx context.Context, userID string) ([]*entity.UserPosition, error) {
+	var list []*entity.UserPosition
+	err := r.Db(ctx).Where("user_id = ?", userID).Order("is_primary DESC").Find(&list).Error
+	return list, err
+}
+
+// GetUserIDsByPositionIDs 获取岗位下的用户ID
+func (r *sysPositionRepo) GetUserIDsByPositionIDs(ctx context.Context, positionIDs []string) ([]string, error) {
+	var userIDs []string
+	if len(positionIDs) == 0 {
+		return userIDs, nil
+	}
+	err := r.Db(ctx).Model(&entity.UserPosition{}).
+		Where("position_id IN ?", positionIDs).
+		Distinct().Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// CountUsers 统计岗位任职人数
+func (r *sysPositionRepo) CountUsers(ctx context.Context, positionID string) (int64, error) {
+	var count int64
+	err := r.Db(ctx).Model(&entity.UserPosition{}).Where("position_id = ?", positionID).Count(&count).Error
+	return count, err
+}
+
+// SetUserPositions 替换用户岗位关联
+func (r *sysPositionRepo) SetUserPositions(ctx context.Context, userID string, list []*entity.UserPosition) error {
+	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
+		if err := r.Db(ctx).Where("user_id = ?", userID).Delete(&entity.UserPosition{}).Error; err != nil {
+			return err
+		}
+		if len(list) == 0 {
+			return nil
+		}
+		for _, up := range list {
+			up.ID = r.GenInt64Id()
+		}
+		return r.Db(ctx).Create(&list).Error
+	})
+}
@@ -0,0 +1,462 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+)
+
+// userSnapshot 用户快照
+type userSnapshot struct {
+	User      *entity.SysUser          `json:"user"`
+	Roles     []*entity.SysUserRole    `json:"roles"`
+	Depts     []*entity.UserDepartment `json:"depts"`
+	Positions []*entity.UserPosition   `json:"positions"`
+}
+
+// roleSnapshot 角色快照
+type roleSnapshot struct {
+	Role        *entity.Role              `json:"role"`
+	Permissions []*entity.RolePermissions `json:"permissions"`
+	Users       []*entity.SysUserRole     `json:"users"`
+}
+
+// departmentSnapshot 部门快照
+type departmentSnapshot struct {
+	Department *entity.Department       `json:"department"`
+	Users      []*entity.UserDepartment `json:"users"`
+}
+
+// sysRecycleBinRepo 回收站
+// 移入回收站时在同一事务中写入快照并物理删除实体和关联关系, 恢复时按快照重新写入
+type sysRecycleBinRepo struct {
+	*baserepo.BaseRepo[entity.RecycleBin, string]
+}
+
+func NewSysRecycleBinRepo(data database.IDataBase) repository.ISysRecycleBinRepo {
+	model := new(entity.RecycleBin)
+	// 同步表
+	if err := data.AutoMigrate(model); err != nil {
+		hlog.Fatalf("sync sys recycle bin tables to db error: %v", err)
+	}
+	return &sysRecycleBinRepo{
+		BaseRepo: baserepo.NewBaseRepo[entity.RecycleBin, string](data, entity.RecycleBin{}),
+	}
+}
+
+// RecycleUser 用户及其在当前租户的角色、部门、岗位关系移入回收站
+func (r *sysRecycleBinRepo) RecycleUser(ctx context.Context, id string, deletedBy string) error {
+	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
+		var snap userSnapshot
+		if err := r.Db(ctx).Where("id = ?", id).First(&snap.User).Error; err != nil {
+			return err
+		}
+		if err := r.Db(ctx).Where("user_id = ?", id).Find(&snap.Roles).Error; err != nil {
+			return err
+		}
+		if err := r.Db(ctx).Where("user_id = ?", id).Find(&snap.Depts).Error; err != nil {
+			return err
+		}
+		if err := r.Db(ctx).Where("user_id = ?", id).Find(&snap.Positions).Error; err != nil {
+			return err
+		}
+		if err := r.save(ctx, model.RecycleEntityUser, id, snap.User.TenantID, snap.User.Username, snap.User.Name, deletedBy, &snap); err != nil {
+			return err
+		}
+		if err := r.Db(ctx).Where("id IN ?", userRoleIDs(snap.Roles)).Delete(&entity.SysUserRole{}).Error; err != nil {
+			return err
+		}
+		if err := r.Db(ctx).Where("id IN ?", userDeptIDs(snap.Depts)).Delete(&entity.UserDepartment{}).Error; err != nil {
+			return err
+		}
+		if err := r.Db(ctx).Where("user_id = ?", id).Delete(&entity.UserPosition{}).Error; err != nil {
+			return err
+		}
+		return r.Db(ctx).Where("id = ?", id).Delete(&entity.SysUser{}).Error
+	})
+}
+
+// RecycleRole 角色及其权限、用户关系移入回收站
+func (r *sysRecycleBinRepo) RecycleRole(ctx context.Context, id int64, deletedBy string) error {
+	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
+		var snap roleSnapshot
+		if err := r.Db(ctx).Where("id = ?", id).First(&snap.Role).Error; err != nil {
+			return err
+		}
+		if err := r.Db(ctx).Where("role_id = ?", id).Find(&snap.Permissions).Error; err != nil {
+			return err
+		}
+		if err := r.Db(ctx).Where("role_id = ?", id).Find(&snap.Users).Error; err != nil {
+			return err
+		}
+		entityID := strconv.FormatInt(id, 10)
+		if err := r.save(ctx, model.RecycleEntityRole, entityID, snap.Role.TenantID, snap.Role.Code, snap.Role.Name, deletedBy, &snap); err != nil {
+			return err
+		}
+		if err := r.Db(ctx).Unscoped().Where("role_id = ?", id).Delete(&entity.RolePermissions{}).Error; err != nil {
+			return err
+		}
+		if err := r.Db(ctx).Where("id IN ?", userRoleIDs(snap.Users)).Delete(&entity.SysUserRole{}).Error; err != nil {
+			return err
+		}
+		return r.Db(ctx).Where("id = ?", id).Delete(&entity.Role{}).Error
+	})
+}
+
+// RecycleDepartment 部门及其成员关系移入回收站
+func (r *sysRecycleBinRepo) RecycleDepartment(ctx context.Context, id string, deletedBy string) error {
+	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
+		var snap departmentSnapshot
+		if err := r.Db(ctx).Where("id = ?", id).First(&snap.Department).Error; err != nil {
+			return err
+		}
+		if err := r.Db(ctx).Where("dept_id = ?", id).Find(&snap.Users).Error; err != nil {
+			return err
+		}
+		if err := r.save(ctx, model.RecycleEntityDepartment, id, snap.Department.TenantID, snap.Department.Code, snap.Department.Name, deletedBy, &snap); err != nil {
+			return err
+		}
+		if err := r.Db(ctx).Where("dept_id = ?", id).Delete(&entity.UserDepartment{}).Error; err != nil {
+			return err
+		}
+		return r.Db(ctx).Where("id = ?", id).Delete(&entity.Department{}).Error
+	})
+}
+
+// Restore 按快照恢复实体, 关联的角色、部门、权限或用户已不存在时跳过该关联
+// 用户角色只恢复 userRoles 中的部分, 部门的上级部门已不存在时恢复为顶级部门
+func (r *sysRecycleBinRepo) Restore(ctx context.Context, id string, userRoles []*entity.SysUserRole) error {
+	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
+		var item entity.RecycleBin
+		if err := r.Db(ctx).Where("id = ?", id).First(&item).Error; err != nil {
+			return err
+		}
+		var err error
+		switch item.EntityType {
+		case model.RecycleEntityUser:
+			err = r.restoreUser(ctx, &item, userRoles)
+		case model.RecycleEntityRole:
+			err = r.restoreRole(ctx, &item, userRoles)
+		case model.RecycleEntityDepartment:
+			err = r.restoreDepartment(ctx, &item)
+		default:
+			err = fmt.Errorf("unsupported recycle entity type: %s", item.EntityType)
+		}
+		if err != nil {
+			return err
+		}
+		return r.Delete(ctx, id)
+	})
+}
+
+// GetUserRoles 恢复时会写入的用户角色及涉及的角色, 只包含仍然存在的用户和角色
+// 恢复角色时返回快照中的角色
+func (r *sysRecycleBinRepo) GetUserRoles(ctx context.Context, id string) ([]*entity.SysUserRole, []*entity.Role, error) {
+	var item entity.RecycleBin
+	if err := r.Db(ctx).Where("id = ?", id).First(&item).Error; err != nil {
+		return nil, nil, err
+	}
+	switch item.EntityType {
+	case model.RecycleEntityUser:
+		var snap userSnapshot
+		if err := json.Unmarshal([]byte(item.Snapshot), &snap); err != nil {
+			return nil, nil, err
+		}
+		return r.snapshotUserRoles(ctx, &snap)
+	case model.RecycleEntityRole:
+		var snap roleSnapshot
+		if err := json.Unmarshal([]byte(item.Snapshot), &snap); err != nil {
+			return nil, nil, err
+		}
+		users, err := r.snapshotRoleUsers(ctx, &snap)
+		if err != nil {
+			return nil, nil, err
+		}
+		return users, []*entity.Role{snap.Role}, nil
+	}
+	return nil, nil, nil
+}
+
+// snapshotUserRoles 用户快照中角色仍然存在的用户角色
+func (r *sysRecycleBinRepo) snapshotUserRoles(ctx context.Context, snap *userSnapshot) ([]*entity.SysUserRole, []*entity.Role, error) {
+	roleIDs := make([]int64, 0, len(snap.Roles))
+	for _, ur := range snap.Roles {
+		roleIDs = append(roleIDs, ur.RoleID)
+	}
+	var existRoles []*entity.Role
+	if len(roleIDs) > 0 {
+		if err := r.Db(ctx).Where("id IN ?", roleIDs).Find(&existRoles).Error; err != nil {
+			return nil, nil, err
+		}
+	}
+	exists := make(map[int64]bool, len(existRoles))
+	for _, role := range existRoles {
+		exists[role.ID] = true
+	}
+	roles := make([]*entity.SysUserRole, 0, len(snap.Roles))
+	for _, ur := range snap.Roles {
+		if exists[ur.RoleID] {
+			roles = append(roles, ur)
+		}
+	}
+	return roles, existRoles, nil
+}
+
+// snapshotRoleUsers 角色快照中用户仍然存在的用户角色
+func (r *sysRecycleBinRepo) snapshotRoleUsers(ctx context.Context, snap *roleSnapshot) ([]*entity.SysUserRole, error) {
+	userIDs := make([]string, 0, len(snap.Users))
+	for _, ur := range snap.Users {
+		userIDs = append(userIDs, ur.UserID)
+	}
+	existUsers, err := r.existingIDs(ctx, &entity.SysUser{}, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*entity.SysUserRole, 0, len(snap.Users))
+	for _, ur := range snap.Users {
+		if existUsers[ur.UserID] {
+			users = append(users, ur)
+		}
+	}
+	return users, nil
+}
+
+func (r *sysRecycleBinRepo) restoreUser(ctx context.Context, item *entity.RecycleBin, userRoles []*entity.SysUserRole) error {
+	var snap userSnapshot
+	if err := json.Unmarshal([]byte(item.Snapshot), &snap); err != nil {
+		return err
+	}
+	if err := r.Db(ctx).Create(snap.User).Error; err != nil {
+		return err
+	}
+	roles, _, err := r.snapshotUserRoles(ctx, &snap)
+	if err != nil {
+		return err
+	}
+	roles = filterUserRoles(roles, userRoles)
+	deptIDs := make([]string, 0, len(snap.Depts))
+	for _, ud := range snap.Depts {
+		deptIDs = append(deptIDs, ud.DeptID)
+	}
+	existDepts, err := r.existingIDs(ctx, &entity.Department{}, deptIDs)
+	if err != nil {
+		return err
+	}
+	depts := make([]*entity.UserDepartment, 0, len(snap.Depts))
+	for _, ud := range snap.Depts {
+		if existDepts[ud.DeptID] {
+			depts = append(depts, ud)
+		}
+	}
+	positionIDs := make([]string, 0, len(snap.Positions))
+	for _, up := range snap.Positions {
+		positionIDs = append(positionIDs, up.PositionID)
+	}
+	existPositions, err := r.existingIDs(ctx, &entity.Position{}, positionIDs)
+	if err != nil {
+		return err
+	}
+	positions := make([]*entity.UserPosition, 0, len(snap.Positions))
+	hasPrimary := false
+	for _, up := range snap.Positions {
+		if existPositions[up.PositionID] {
+			positions = append(positions, up)
+			hasPrimary = hasPrimary || up.IsPrimary
+		}
+	}
+	// 主岗已删除时第一个岗位为主岗
+	if len(positions) > 0 && !hasPrimary {
+		positions[0].IsPrimary = true
+	}
+	if len(roles) > 0 {
+		if err := r.Db(ctx).Create(&roles).Error; err != nil {
+			return err
+		}
+	}
+	if len(depts) > 0 {
+		if err := r.Db(ctx).Create(&depts).Error; err != nil {
+			return err
+		}
+	}
+	if len(positions) > 0 {
+		return r.Db(ctx).Create(&positions).Error
+	}
+	return nil
+}
+
+func (r *sysRecycleBinRepo) restoreRole(ctx context.Context, item *entity.RecycleBin, userRoles []*entity.SysUserRole) error {
+	var snap roleSnapshot
+	if err := json.Unmarshal([]byte(item.Snapshot), &snap); err != nil {
+		return err
+	}
+	if err := r.Db(ctx).Create(snap.Role).Error; err != nil {
+		return err
+	}
+	permIDs := make([]int64, 0, len(snap.Permissions))
+	for _, rp := range snap.Permissions {
+		permIDs = append(permIDs, rp.PermissionID)
+	}
+	// 权限为平台数据, 不按租户过滤
+	existPerms, err := r.existingIDs(actx.BuildIgnoreTenantCtx(ctx), &entity.Permissions{}, permIDs)
+	if err != nil {
+		return err
+	}
+	perms := make([]*entity.RolePermissions, 0, len(snap.Permissions))
+	for _, rp := range snap.Permissions {
+		if existPerms[fmt.Sprint(rp.PermissionID)] {
+			perms = append(perms, rp)
+		}
+	}
+	users, err := r.snapshotRoleUsers(ctx, &snap)
+	if err != nil {
+		return err
+	}
+	users = filterUserRoles(users, userRoles)
+	if len(perms) > 0 {
+		if err := r.Db(ctx).Create(&perms).Error; err != nil {
+			return err
+		}
+	}
+	if len(users) > 0 {
+		return r.Db(ctx).Create(&users).Error
+	}
+	return nil
+}
+
+func (r *sysRecycleBinRepo) restoreDepartment(ctx context.Context, item *entity.RecycleBin) error {
+	var snap departmentSnapshot
+	if err := json.Unmarshal([]byte(item.Snapshot), &snap); err != nil {
+		return err
+	}
+	// 父部门仍存在时按其当前路径恢复, 否则恢复为根部门
+	parentPath := ""
+	if parentID := snap.Department.ParentID; parentID != "" {
+		var parents []*entity.Department
+		if err := r.Db(ctx).Where("id = ?", parentID).Find(&parents).Error; err != nil {
+			return err
+		}
+		if len(parents) == 0 {
+			snap.Department.ParentID = ""
+		} else {
+			parentPath = parents[0].Path
+		}
+	}
+	snap.Department.Path = deptPath(parentPath, snap.Department.ID)
+	if err := r.Db(ctx).Create(snap.Department).Error; err != nil {
+		return err
+	}
+	userIDs := make([]string, 0, len(snap.Users))
+	for _, ud := range snap.Users {
+		userIDs = append(userIDs, ud.UserID)
+	}
+	existUsers, err := r.existingIDs(ctx, &entity.SysUser{}, userIDs)
+	if err != nil {
+		return err
+	}
+	users := make([]*entity.UserDepartment, 0, len(snap.Users))
+	for _, ud := range snap.Users {
+		if existUsers[ud.UserID] {
+			users = append(users, ud)
+		}
+	}
+	if len(users) > 0 {
+		return r.Db(ctx).Create(&users).Error
+	}
+	return nil
+}
+
+// GetByEntity 根据实体获取回收站记录
+func (r *sysRecycleBinRepo) GetByEntity(ctx context.Context, entityType, entityID string) (*entity.RecycleBin, error) {
+	var item entity.RecycleBin
+	if err := r.Db(ctx).Where("entity_type = ? AND entity_id = ?", entityType, entityID).First(&item).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ListExpired 获取删除时间早于 before 的记录, 不包含快照
+func (r *sysRecycleBinRepo) ListExpired(ctx context.Context, before int64, limit int) ([]*entity.RecycleBin, error) {
+	var list []*entity.RecycleBin
+	err := r.Db(ctx).Omit("snapshot").Where("deleted_at < ?", before).
+		Order("deleted_at").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+// Delete 物理删除回收站记录
+func (r *sysRecycleBinRepo) Delete(ctx context.Context, id string) error {
+	return r.Db(ctx).Where("id = ?", id).Delete(&entity.RecycleBin{}).Error
+}
+
+// save 写入回收站记录
+func (r *sysRecycleBinRepo) save(ctx context.Context, entityType, entityID, tenantID, code, name, deletedBy string, snap interface{}) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	return r.Db(ctx).Create(&entity.RecycleBin{
+		ID:         r.GenStringId(),
+		TenantID:   tenantID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Code:       code,
+		Name:       name,
+		Snapshot:   string(data),
+		DeletedBy:  deletedBy,
+		DeletedAt:  time.Now().Unix(),
+	}).Error
+}
+
+// existingIDs 返回仍然存在的ID集合
+func (r *sysRecycleBinRepo) existingIDs(ctx context.Context, m interface{}, ids interface{}) (map[string]bool, error) {
+	var exists []string
+	if err := r.Db(ctx).Model(m).Where("id IN ?", ids).Pluck("id", &exists).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[string]bool, len(exists))
+	for _, id := range exists {
+		result[id] = true
+	}
+	return result, nil
+}
+
+// filterUserRoles 只保留 allowed 中包含的用户角色
+func filterUserRoles(list []*entity.SysUserRole, allowed []*entity.SysUserRole) []*entity.SysUserRole {
+	keys := make(map[string]bool, len(allowed))
+	for _, ur := range allowed {
+		keys[fmt.Sprintf("%s:%d", ur.UserID, ur.RoleID)] = true
+	}
+	result := make([]*entity.SysUserRole, 0, len(list))
+	for _, ur := range list {
+		if keys[fmt.Sprintf("%s:%d", ur.UserID, ur.RoleID)] {
+			result = append(result, ur)
+		}
+	}
+	return result
+}
+
+func userRoleIDs(list []*entity.SysUserRole) []int64 {
+	ids := make([]int64, 0, len(list))
+	for _, item := range list {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func userDeptIDs(list []*entity.UserDepartment) []int64 {
+	ids := make([]int64, 0, len(list))
+	for _, item := range list {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
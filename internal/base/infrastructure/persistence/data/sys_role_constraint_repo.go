@@ -0,0 +1,58 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+)
+
+type sysRoleConstraintRepo struct {
+	*baserepo.BaseRepo[entity.RoleConstraint, int64]
+}
+
+func NewSysRoleConstraintRepo(data database.IDataBase) repository.ISysRoleConstraintRepo {
+	model := new(entity.RoleConstraint)
+	// 同步表
+	if err := data.AutoMigrate(model); err != nil {
+		hlog.Fatalf("sync sys role constraint tables to db error: %v", err)
+	}
+	return &sysRoleConstraintRepo{
+		BaseRepo: baserepo.NewBaseRepo[entity.RoleConstraint, int64](data, entity.RoleConstraint{}),
+	}
+}
+
+// UpdateConstraint 更新约束, 描述允许清空
+func (r *sysRoleConstraintRepo) UpdateConstraint(ctx context.Context, e *entity.RoleConstraint) error {
+	return r.Db(ctx).Model(&entity.RoleConstraint{}).Where("id = ?", e.ID).Updates(map[string]interface{}{
+		"name":        e.Name,
+		"role_ids":    e.RoleIDs,
+		"max_count":   e.MaxCount,
+		"description": e.Description,
+		"updated_at":  e.UpdatedAt,
+	}).Error
+}
+
+// ListAll 当前租户的全部约束
+func (r *sysRoleConstraintRepo) ListAll(ctx context.Context) ([]*entity.RoleConstraint, error) {
+	var list []*entity.RoleConstraint
+	err := r.Db(ctx).Where("deleted_at = 0").Order("id").Find(&list).Error
+	return list, err
+}
+
+// ListUserRolesByRoleIDs 当前租户持有这些角色且未过期的用户角色, 包括尚未生效的授予
+func (r *sysRoleConstraintRepo) ListUserRolesByRoleIDs(ctx context.Context, roleIDs []int64) ([]*entity.SysUserRole, error) {
+	var list []*entity.SysUserRole
+	if len(roleIDs) == 0 {
+		return list, nil
+	}
+	err := r.Db(ctx).Where("role_id IN ?", roleIDs).
+		Where("(expire_time = 0 OR expire_time > ?)", time.Now().Unix()).
+		Order("id").Find(&list).Error
+	return list, err
+}
@@ -0,0 +1,116 @@
+package data
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+)
+
+// sysRoleGrantRepo 有效期内的用户角色授予和需要审批的角色申请
+type sysRoleGrantRepo struct {
+	*baserepo.BaseRepo[entity.RoleRequest, string]
+}
+
+func NewSysRoleGrantRepo(data database.IDataBase) repository.ISysRoleGrantRepo {
+	model := new(entity.RoleRequest)
+	// 同步表
+	if err := data.AutoMigrate(model); err != nil {
+		hlog.Fatalf("sync sys role request tables to db error: %v", err)
+	}
+	return &sysRoleGrantRepo{
+		BaseRepo: baserepo.NewBaseRepo[entity.RoleRequest, string](data, entity.RoleRequest{}),
+	}
+}
+
+// activeUserRole 用户角色在 now 时刻有效的条件, table 为 sys_user_role 的表名或别名
+func activeUserRole(table string) string {
+	return fmt.Sprintf("(%[1]s.start_time = 0 OR %[1]s.start_time <= ?) AND (%[1]s.expire_time = 0 OR %[1]s.expire_time > ?)", table)
+}
+
+// GrantUserRole 授予用户角色, 已有该角色时只更新有效期
+func (r *sysRoleGrantRepo) GrantUserRole(ctx context.Context, userRole *entity.SysUserRole) error {
+	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
+		var exist entity.SysUserRole
+		err := r.Db(ctx).Where("user_id = ? AND role_id = ?", userRole.UserID, userRole.RoleID).
+			Limit(1).Find(&exist).Error
+		if err != nil {
+			return err
+		}
+		if exist.ID == 0 {
+			return r.Db(ctx).Create(userRole).Error
+		}
+		userRole.ID = exist.ID
+		return r.Db(ctx).Model(&entity.SysUserRole{}).Where("id = ?", exist.ID).Updates(map[string]interface{}{
+			"start_time":  userRole.StartTime,
+			"expire_time": userRole.ExpireTime,
+		}).Error
+	})
+}
+
+// RevokeUserRole 收回用户角色
+func (r *sysRoleGrantRepo) RevokeUserRole(ctx context.Context, userID string, roleID int64) error {
+	return r.Db(ctx).Where("user_id = ? AND role_id = ?", userID, roleID).Delete(&entity.SysUserRole{}).Error
+}
+
+// ListUserRoles 用户在当前租户的全部角色授予
+func (r *sysRoleGrantRepo) ListUserRoles(ctx context.Context, userID string) ([]*entity.SysUserRole, error) {
+	var list []*entity.SysUserRole
+	err := r.Db(ctx).Where("user_id = ?", userID).Order("id").Find(&list).Error
+	return list, err
+}
+
+// ListExpiredUserRoles 过期时间不晚于 now 的用户角色
+// 忽略租户的上下文中查询主库的全部租户, 租户上下文中查询该租户的存储
+func (r *sysRoleGrantRepo) ListExpiredUserRoles(ctx context.Context, now int64, limit int) ([]*entity.SysUserRole, error) {
+	var list []*entity.SysUserRole
+	err := r.Db(ctx).Where("expire_time > 0 AND expire_time <= ?", now).
+		Order("expire_time").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+// DeleteUserRoles 删除用户角色, 存储的选择同 ListExpiredUserRoles
+func (r *sysRoleGrantRepo) DeleteUserRoles(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.Db(ctx).Where("id IN ?", ids).Delete(&entity.SysUserRole{}).Error
+}
+
+// CreateRequest 创建角色申请
+func (r *sysRoleGrantRepo) CreateRequest(ctx context.Context, req *entity.RoleRequest) error {
+	return r.Db(ctx).Create(req).Error
+}
+
+// UpdateRequestReview 保存审批结果
+func (r *sysRoleGrantRepo) UpdateRequestReview(ctx context.Context, req *entity.RoleRequest) error {
+	return r.Db(ctx).Model(&entity.RoleRequest{}).Where("id = ?", req.ID).Updates(map[string]interface{}{
+		"status":         req.Status,
+		"reviewed_by":    req.ReviewedBy,
+		"review_comment": req.ReviewComment,
+		"reviewed_at":    req.ReviewedAt,
+	}).Error
+}
+
+// GetRequest 获取角色申请
+func (r *sysRoleGrantRepo) GetRequest(ctx context.Context, id string) (*entity.RoleRequest, error) {
+	var req entity.RoleRequest
+	if err := r.Db(ctx).Where("id = ?", id).First(&req).Error; err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// CountRequests 用户对该角色指定状态的申请数量
+func (r *sysRoleGrantRepo) CountRequests(ctx context.Context, userID string, roleID int64, status int8) (int64, error) {
+	var count int64
+	err := r.Db(ctx).Model(&entity.RoleRequest{}).
+		Where("user_id = ? AND role_id = ? AND status = ?", userID, roleID, status).
+		Count(&count).Error
+	return count, err
+}
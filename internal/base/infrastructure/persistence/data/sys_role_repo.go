@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"time"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
@@ -19,7 +20,7 @@ type sysRoleRepo struct {
 func NewSysRoleRepo(data database.IDataBase) repository.ISysRoleRepo {
 	model := new(entity.Role)
 	// 同步表
-	if err := data.DB(context.Background()).AutoMigrate(model, &entity.RolePermissions{}); err != nil {
+	if err := data.AutoMigrate(model, &entity.RolePermissions{}); err != nil {
 		hlog.Fatalf("sync sys user tables to db error: %v", err)
 	}
 	return &sysRoleRepo{
@@ -37,6 +38,16 @@ func (r *sysRoleRepo) GetByCode(ctx context.Context, code string) (*entity.Role,
 	return &role, nil
 }
 
+// GetByCodes 根据编码批量获取角色
+func (r *sysRoleRepo) GetByCodes(ctx context.Context, codes []string) ([]*entity.Role, error) {
+	var roles []*entity.Role
+	if len(codes) == 0 {
+		return roles, nil
+	}
+	err := r.Db(ctx).Where("code IN ?", codes).Find(&roles).Error
+	return roles, err
+}
+
 // GetByRoleId 获取角色的权限关联
 func (r *sysRoleRepo) GetByRoleId(ctx context.Context, roleId int64) ([]*entity.RolePermissions, error) {
 	var rolePerms []*entity.RolePermissions
@@ -52,12 +63,14 @@ func (r *sysRoleRepo) DeletePermissionsByRoleId(ctx context.Context, roleId int6
 	return r.Db(ctx).Unscoped().Where("role_id = ?", roleId).Delete(&entity.RolePermissions{}).Error
 }
 
-// GetByUserId 根据用户ID获取角色列表
+// GetByUserId 根据用户ID获取当前生效的角色列表
 func (r *sysRoleRepo) GetByUserId(ctx context.Context, userId string) ([]*entity.Role, error) {
 	var roles []*entity.Role
+	now := time.Now().Unix()
 	err := r.Db(ctx).Model(&entity.Role{}).
 		Joins("JOIN sys_user_role ON sys_user_role.role_id = sys_role.id").
 		Where("sys_user_role.user_id = ? AND sys_role.status = ?", userId, 1).
+		Where(activeUserRole("sys_user_role"), now, now).
 		Order("sys_role.sequence").
 		Find(&roles).Error
 	if err != nil {
@@ -112,6 +125,16 @@ func (r *sysRoleRepo) FindAllEnabled(ctx context.Context) ([]*entity.Role, error
 	return list, nil
 }
 
+// FindEnabledByTenant 获取租户下所有启用的角色
+func (r *sysRoleRepo) FindEnabledByTenant(ctx context.Context, tenantID string) ([]*entity.Role, error) {
+	var list []*entity.Role
+	err := r.Db(ctx).Where("tenant_id = ? and status = 1 and deleted_at = 0", tenantID).Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
 // UpdatePermissions 更新角色权限
 func (r *sysRoleRepo) UpdatePermissions(ctx context.Context, roleID int64, permIDs []int64) error {
 	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
@@ -201,11 +224,13 @@ func (r *sysRoleRepo) HasPermission(ctx context.Context, roleID int64, permissio
 	return count > 0, err
 }
 
-// GetIdsByUserId 获取用户的角色ID列表
+// GetIdsByUserId 获取用户当前生效的角色ID列表
 func (r *sysRoleRepo) GetIdsByUserId(ctx context.Context, userId string) ([]int64, error) {
 	var roleIds []int64
+	now := time.Now().Unix()
 	err := r.Db(ctx).Model(&entity.SysUserRole{}).
 		Where("user_id = ?", userId).
+		Where(activeUserRole("sys_user_role"), now, now).
 		Pluck("role_id", &roleIds).Error
 	if err != nil {
 		return nil, err
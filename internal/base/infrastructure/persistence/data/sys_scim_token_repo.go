@@ -0,0 +1,71 @@
+package data
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// sysScimTokenRepo SCIM 令牌, 保存在平台库中, 认证时尚未确定租户, 所有操作忽略租户过滤
+type sysScimTokenRepo struct {
+	*baserepo.BaseRepo[entity.ScimToken, string]
+}
+
+func NewSysScimTokenRepo(data database.IDataBase) repository.ISysScimTokenRepo {
+	model := new(entity.ScimToken)
+	// 同步表
+	if err := data.DB(context.Background()).AutoMigrate(model); err != nil {
+		hlog.Fatalf("sync scim token tables to db error: %v", err)
+	}
+	return &sysScimTokenRepo{
+		BaseRepo: baserepo.NewBaseRepo[entity.ScimToken, string](data, entity.ScimToken{}),
+	}
+}
+
+// Create 创建令牌
+func (r *sysScimTokenRepo) Create(ctx context.Context, token *entity.ScimToken) error {
+	return r.Db(actx.BuildIgnoreTenantCtx(ctx)).Create(token).Error
+}
+
+// Delete 删除租户的令牌
+func (r *sysScimTokenRepo) Delete(ctx context.Context, tenantID, id string) error {
+	return r.Db(actx.BuildIgnoreTenantCtx(ctx)).Where("tenant_id = ? AND id = ?", tenantID, id).
+		Delete(&entity.ScimToken{}).Error
+}
+
+// GetByID 获取租户的令牌
+func (r *sysScimTokenRepo) GetByID(ctx context.Context, tenantID, id string) (*entity.ScimToken, error) {
+	var token entity.ScimToken
+	if err := r.Db(actx.BuildIgnoreTenantCtx(ctx)).Where("tenant_id = ? AND id = ?", tenantID, id).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetByHash 根据令牌摘要获取令牌
+func (r *sysScimTokenRepo) GetByHash(ctx context.Context, hash string) (*entity.ScimToken, error) {
+	var token entity.ScimToken
+	if err := r.Db(actx.BuildIgnoreTenantCtx(ctx)).Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ListByTenant 获取租户的令牌列表, 按创建时间倒序
+func (r *sysScimTokenRepo) ListByTenant(ctx context.Context, tenantID string) ([]*entity.ScimToken, error) {
+	var tokens []*entity.ScimToken
+	err := r.Db(actx.BuildIgnoreTenantCtx(ctx)).Where("tenant_id = ?", tenantID).
+		Order("created_at DESC").Find(&tokens).Error
+	return tokens, err
+}
+
+// UpdateLastUsed 更新最近使用时间
+func (r *sysScimTokenRepo) UpdateLastUsed(ctx context.Context, id string, usedAt int64) error {
+	return r.Db(actx.BuildIgnoreTenantCtx(ctx)).Model(&entity.ScimToken{}).Where("id = ?", id).
+		Update("last_used_at", usedAt).Error
+}
@@ -0,0 +1,72 @@
+package data
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// sysTenantJobRepo 租户下线任务, 由平台管理, 所有操作忽略租户过滤
+type sysTenantJobRepo struct {
+	*baserepo.BaseRepo[entity.TenantJob, string]
+}
+
+func NewSysTenantJobRepo(data database.IDataBase) repository.ISysTenantJobRepo {
+	model := new(entity.TenantJob)
+	// 同步表
+	if err := data.DB(context.Background()).AutoMigrate(model); err != nil {
+		hlog.Fatalf("sync tenant job tables to db error: %v", err)
+	}
+	return &sysTenantJobRepo{
+		BaseRepo: baserepo.NewBaseRepo[entity.TenantJob, string](data, entity.TenantJob{}),
+	}
+}
+
+// Create 创建任务
+func (r *sysTenantJobRepo) Create(ctx context.Context, job *entity.TenantJob) error {
+	return r.Db(actx.BuildIgnoreTenantCtx(ctx)).Create(job).Error
+}
+
+// Update 更新任务
+func (r *sysTenantJobRepo) Update(ctx context.Context, job *entity.TenantJob) error {
+	return r.Db(actx.BuildIgnoreTenantCtx(ctx)).Save(job).Error
+}
+
+// GetByID 根据ID获取任务
+func (r *sysTenantJobRepo) GetByID(ctx context.Context, id string) (*entity.TenantJob, error) {
+	var job entity.TenantJob
+	if err := r.Db(actx.BuildIgnoreTenantCtx(ctx)).Where("id = ?", id).First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListByTenant 获取租户的任务列表, 按创建时间倒序
+func (r *sysTenantJobRepo) ListByTenant(ctx context.Context, tenantID string) ([]*entity.TenantJob, error) {
+	var jobs []*entity.TenantJob
+	err := r.Db(actx.BuildIgnoreTenantCtx(ctx)).Where("tenant_id = ?", tenantID).
+		Order("created_at DESC").Find(&jobs).Error
+	return jobs, err
+}
+
+// ExistsUnfinished 租户是否有未结束的任务
+func (r *sysTenantJobRepo) ExistsUnfinished(ctx context.Context, tenantID string) (bool, error) {
+	var count int64
+	err := r.Db(actx.BuildIgnoreTenantCtx(ctx)).Model(&entity.TenantJob{}).
+		Where("tenant_id = ? AND status IN ?", tenantID, []int8{model.TenantJobPending, model.TenantJobRunning}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FailUnfinished 将未结束的任务标记为失败, 服务重启后调用
+func (r *sysTenantJobRepo) FailUnfinished(ctx context.Context, reason string) error {
+	return r.Db(actx.BuildIgnoreTenantCtx(ctx)).Model(&entity.TenantJob{}).
+		Where("status IN ?", []int8{model.TenantJobPending, model.TenantJobRunning}).
+		Updates(map[string]interface{}{"status": model.TenantJobFailed, "error": reason}).Error
+}
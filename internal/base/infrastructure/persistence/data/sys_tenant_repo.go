@@ -25,9 +25,64 @@ func NewSysTenantRepo(data database.IDataBase) repository.ISysTenantRepo {
 	if err := data.DB(context.Background()).AutoMigrate(model, &entity.TenantPermissions{}); err != nil {
 		hlog.Fatalf("sync tenant tables to db error: %v", err)
 	}
-	return &sysTenantRepo{
+	repo := &sysTenantRepo{
 		BaseRepo: baserepo.NewBaseRepo[entity.Tenant, string](data, entity.Tenant{}),
 	}
+	// 租户路由通过租户表获取隔离配置
+	data.SetTenantIsolationProvider(repo)
+	return repo
+}
+
+// GetIsolation 获取租户数据隔离配置
+func (r *sysTenantRepo) GetIsolation(ctx context.Context, tenantID string) (*database.TenantIsolation, error) {
+	var tenant entity.Tenant
+	err := r.Db(ctx).Select("id", "isolation_mode", "db_schema", "db_source").
+		Where("id = ? AND deleted_at = 0", tenantID).First(&tenant).Error
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &database.TenantIsolation{
+		TenantID: tenant.ID,
+		Mode:     tenant.IsolationMode,
+		Schema:   tenant.DbSchema,
+		Source:   tenant.DbSource,
+	}, nil
+}
+
+// ListIsolated 获取全部独立Schema/数据库的租户
+func (r *sysTenantRepo) ListIsolated(ctx context.Context) ([]*database.TenantIsolation, error) {
+	var tenants []*entity.Tenant
+	err := r.Db(ctx).Select("id", "isolation_mode", "db_schema", "db_source").
+		Where("isolation_mode IN ? AND deleted_at = 0", []string{database.IsolationSchema, database.IsolationDatabase}).
+		Find(&tenants).Error
+	if err != nil {
+		return nil, err
+	}
+	isos := make([]*database.TenantIsolation, 0, len(tenants))
+	for _, tenant := range tenants {
+		isos = append(isos, &database.TenantIsolation{
+			TenantID: tenant.ID,
+			Mode:     tenant.IsolationMode,
+			Schema:   tenant.DbSchema,
+			Source:   tenant.DbSource,
+		})
+	}
+	return isos, nil
+}
+
+// SetProvisionStatus 更新开通状态及失败原因
+func (r *sysTenantRepo) SetProvisionStatus(ctx context.Context, tenantID string, status int8, reason string) error {
+	if len(reason) > 512 {
+		reason = reason[:512]
+	}
+	return r.Db(ctx).Model(&entity.Tenant{}).Where("id = ?", tenantID).Updates(map[string]interface{}{
+		"provision_status": status,
+		"provision_error":  reason,
+		"updated_at":       time.Now().Unix(),
+	}).Error
 }
 
 // GetByCode 根据编码获取租户
@@ -40,6 +95,16 @@ func (r *sysTenantRepo) GetByCode(ctx context.Context, code string) (*entity.Ten
 	return &tenant, nil
 }
 
+// GetByDomain 根据自定义域名获取租户
+func (r *sysTenantRepo) GetByDomain(ctx context.Context, domain string) (*entity.Tenant, error) {
+	var tenant entity.Tenant
+	err := r.Db(ctx).Where("domain = ? AND deleted_at = 0", domain).First(&tenant).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tenant, nil
+}
+
 // DelById 删除租户（包括关联关系）
 func (r *sysTenantRepo) DelById(ctx context.Context, id string) error {
 	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
@@ -0,0 +1,76 @@
+package data
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// sysTenantTemplateRepo 租户开通模板, 由平台管理, 所有操作忽略租户过滤
+type sysTenantTemplateRepo struct {
+	*baserepo.BaseRepo[entity.TenantTemplate, string]
+}
+
+func NewSysTenantTemplateRepo(data database.IDataBase) repository.ISysTenantTemplateRepo {
+	model := new(entity.TenantTemplate)
+	// 同步表
+	if err := data.DB(context.Background()).AutoMigrate(model); err != nil {
+		hlog.Fatalf("sync tenant template tables to db error: %v", err)
+	}
+	return &sysTenantTemplateRepo{
+		BaseRepo: baserepo.NewBaseRepo[entity.TenantTemplate, string](data, entity.TenantTemplate{}),
+	}
+}
+
+// Create 创建模板
+func (r *sysTenantTemplateRepo) Create(ctx context.Context, tpl *entity.TenantTemplate) error {
+	return r.Db(actx.BuildIgnoreTenantCtx(ctx)).Create(tpl).Error
+}
+
+// Update 更新模板名称、描述及内容
+func (r *sysTenantTemplateRepo) Update(ctx context.Context, tpl *entity.TenantTemplate) error {
+	return r.Db(actx.BuildIgnoreTenantCtx(ctx)).Model(tpl).
+		Select("name", "description", "format", "content", "updated_at").
+		Updates(tpl).Error
+}
+
+// Delete 删除模板
+func (r *sysTenantTemplateRepo) Delete(ctx context.Context, id string) error {
+	return r.Db(actx.BuildIgnoreTenantCtx(ctx)).Where("id = ?", id).Delete(&entity.TenantTemplate{}).Error
+}
+
+// GetByID 根据ID获取模板
+func (r *sysTenantTemplateRepo) GetByID(ctx context.Context, id string) (*entity.TenantTemplate, error) {
+	var tpl entity.TenantTemplate
+	if err := r.Db(actx.BuildIgnoreTenantCtx(ctx)).Where("id = ?", id).First(&tpl).Error; err != nil {
+		return nil, err
+	}
+	return &tpl, nil
+}
+
+// List 获取模板列表, 不返回模板内容
+func (r *sysTenantTemplateRepo) List(ctx context.Context, name string) ([]*entity.TenantTemplate, error) {
+	var list []*entity.TenantTemplate
+	db := r.Db(actx.BuildIgnoreTenantCtx(ctx)).Omit("content")
+	if name != "" {
+		db = db.Where("name LIKE ?", "%"+name+"%")
+	}
+	err := db.Order("created_at DESC").Find(&list).Error
+	return list, err
+}
+
+// ExistsByName 模板名称是否已存在
+func (r *sysTenantTemplateRepo) ExistsByName(ctx context.Context, name string, excludeID string) (bool, error) {
+	var count int64
+	db := r.Db(actx.BuildIgnoreTenantCtx(ctx)).Model(&entity.TenantTemplate{}).Where("name = ?", name)
+	if excludeID != "" {
+		db = db.Where("id <> ?", excludeID)
+	}
+	err := db.Count(&count).Error
+	return count > 0, err
+}
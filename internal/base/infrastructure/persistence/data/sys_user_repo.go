@@ -2,6 +2,10 @@ package data
 
 import (
 	"context"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/plugin"
 
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
 
@@ -11,6 +15,7 @@ import (
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
 	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"gorm.io/gorm"
 )
 
 // sysUserRepo ， 用户数据层
@@ -28,10 +33,21 @@ type sysUserRepo struct {
 //	biz.ISysUserRepo ：desc
 func NewSysUserRepo(data database.IDataBase) repository.ISysUserRepo {
 	model := new(entity.SysUser)
+	// 用户名改为租户内唯一, 移除旧的全局唯一索引
+	migrator := data.DB(context.Background()).Migrator()
+	if migrator.HasIndex(model, "idx_sys_user_username") {
+		if err := migrator.DropIndex(model, "idx_sys_user_username"); err != nil {
+			hlog.Fatalf("drop sys user username index error: %v", err)
+		}
+	}
 	// 同步表
-	if err := data.DB(context.Background()).AutoMigrate(model, &entity.SysUserRole{}); err != nil {
+	if err := data.AutoMigrate(model, &entity.SysUserRole{}); err != nil {
 		hlog.Fatalf("sync sys user tables to db error: %v", err)
 	}
+	// 租户成员关系属于全局身份, 保存在主库
+	if err := data.DB(context.Background()).AutoMigrate(&entity.SysUserTenant{}); err != nil {
+		hlog.Fatalf("sync sys user tenant tables to db error: %v", err)
+	}
 	return &sysUserRepo{
 		BaseRepo: baserepo.NewBaseRepo[entity.SysUser, string](data, entity.SysUser{}),
 	}
@@ -44,8 +60,226 @@ func (r *sysUserRepo) GetByUsername(ctx context.Context, username string) (*enti
 	}
 	return result, nil
 }
+
+// CountByUsername 统计用户名数量(未指定租户时用于判断用户名是否跨租户重复)
+func (r *sysUserRepo) CountByUsername(ctx context.Context, username string) (int64, error) {
+	var count int64
+	err := r.Db(ctx).Model(&entity.SysUser{}).Where("username = ?", username).Count(&count).Error
+	return count, err
+}
+
+// GetExistingUsernames 获取已存在的用户名
+func (r *sysUserRepo) GetExistingUsernames(ctx context.Context, usernames []string) ([]string, error) {
+	var result []string
+	if len(usernames) == 0 {
+		return result, nil
+	}
+	err := r.Db(ctx).Model(&entity.SysUser{}).Where("username IN ?", usernames).Pluck("username", &result).Error
+	return result, err
+}
+
+// GetRoleCodesByUserIds 批量获取用户的角色编码
+func (r *sysUserRepo) GetRoleCodesByUserIds(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	var rows []struct {
+		UserID string
+		Code   string
+	}
+	result := make(map[string][]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+	err := r.Db(ctx).Model(&entity.SysUserRole{}).
+		Select("sys_user_role.user_id, sys_role.code").
+		Joins("JOIN sys_role ON sys_role.id = sys_user_role.role_id").
+		Where("sys_user_role.user_id IN ?", userIDs).
+		Order("sys_role.sequence").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.UserID] = append(result[row.UserID], row.Code)
+	}
+	return result, nil
+}
+
+// GetDeptCodesByUserIds 批量获取用户的部门编码
+func (r *sysUserRepo) GetDeptCodesByUserIds(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	var rows []struct {
+		UserID string
+		Code   string
+	}
+	result := make(map[string][]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+	err := r.Db(ctx).Model(&entity.UserDepartment{}).
+		Select("sys_user_dept.user_id, sys_department.code").
+		Joins("JOIN sys_department ON sys_department.id = sys_user_dept.dept_id").
+		Where("sys_user_dept.user_id IN ?", userIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.UserID] = append(result[row.UserID], row.Code)
+	}
+	return result, nil
+}
+
+// DelById 物理删除用户及其当前租户下的角色和部门关系
+func (r *sysUserRepo) DelById(ctx context.Context, id string) error {
+	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
+		if err := r.DeleteRoleByUserId(ctx, id); err != nil {
+			return err
+		}
+		if err := r.Db(ctx).Where("user_id = ?", id).Delete(&entity.UserDepartment{}).Error; err != nil {
+			return err
+		}
+		return r.Db(ctx).Where("id = ?", id).Delete(&entity.SysUser{}).Error
+	})
+}
+
 func (r *sysUserRepo) DeleteRoleByUserId(ctx context.Context, userId string) error {
-	return r.Db(ctx).Where("user_id = ?", userId).Delete(&entity.SysUserRole{}).Error
+	db := r.Db(ctx).Where("user_id = ?", userId)
+	// 用户可属于多个租户, 只删除当前租户下的角色
+	if tenantID := plugin.GetCtxTenantID(ctx); tenantID != "" && !plugin.IsIgnoreTenant(ctx) {
+		db = db.Where("tenant_id = ?", tenantID)
+	}
+	return db.Delete(&entity.SysUserRole{}).Error
+}
+
+// SyncUserRoles 将用户在当前租户的角色同步为 roleIDs
+// 保留的角色维持原有效期, 已过期的记录删除后按永久授予重新写入, 尚未生效的授予不受影响
+func (r *sysUserRepo) SyncUserRoles(ctx context.Context, userID string, roleIDs []int64) error {
+	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
+		now := time.Now().Unix()
+		userRoleDb := func() *gorm.DB {
+			db := r.Db(ctx).Model(&entity.SysUserRole{}).Where("user_id = ?", userID)
+			if tenantID := plugin.GetCtxTenantID(ctx); tenantID != "" && !plugin.IsIgnoreTenant(ctx) {
+				db = db.Where("tenant_id = ?", tenantID)
+			}
+			return db
+		}
+		db := userRoleDb()
+		if len(roleIDs) > 0 {
+			db = db.Where("((role_id NOT IN ? AND start_time <= ?) OR (expire_time > 0 AND expire_time <= ?))", roleIDs, now, now)
+		} else {
+			db = db.Where("start_time <= ?", now)
+		}
+		if err := db.Delete(&entity.SysUserRole{}).Error; err != nil {
+			return err
+		}
+		if len(roleIDs) == 0 {
+			return nil
+		}
+
+		var exists []int64
+		if err := userRoleDb().Where("role_id IN ?", roleIDs).Pluck("role_id", &exists).Error; err != nil {
+			return err
+		}
+		held := make(map[int64]bool, len(exists))
+		for _, id := range exists {
+			held[id] = true
+		}
+		userRoles := make([]*entity.SysUserRole, 0, len(roleIDs))
+		for _, roleID := range roleIDs {
+			if held[roleID] {
+				continue
+			}
+			held[roleID] = true
+			userRoles = append(userRoles, &entity.SysUserRole{UserID: userID, RoleID: roleID})
+		}
+		if len(userRoles) == 0 {
+			return nil
+		}
+		return r.Db(ctx).Create(&userRoles).Error
+	})
+}
+
+// InviteTenantMember 邀请用户加入租户, 用户接受前不是该租户的成员
+func (r *sysUserRepo) InviteTenantMember(ctx context.Context, userID, tenantID, invitedBy string) error {
+	ctx = actx.BuildIgnoreTenantCtx(ctx)
+	return r.Db(ctx).Create(&entity.SysUserTenant{
+		UserID:    userID,
+		TenantID:  tenantID,
+		Status:    entity.UserTenantStatusPending,
+		InvitedBy: invitedBy,
+		CreatedAt: time.Now().Unix(),
+	}).Error
+}
+
+// AcceptTenantMember 接受邀请, 没有待接受的邀请时返回 false
+func (r *sysUserRepo) AcceptTenantMember(ctx context.Context, userID, tenantID string) (bool, error) {
+	ctx = actx.BuildIgnoreTenantCtx(ctx)
+	result := r.Db(ctx).Model(&entity.SysUserTenant{}).
+		Where("user_id = ? AND tenant_id = ? AND status = ?", userID, tenantID, entity.UserTenantStatusPending).
+		Updates(map[string]interface{}{
+			"status":    entity.UserTenantStatusActive,
+			"joined_at": time.Now().Unix(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// RemoveTenantMember 移除租户成员关系或待接受的邀请, 以及其在该租户下的角色
+func (r *sysUserRepo) RemoveTenantMember(ctx context.Context, userID, tenantID string) error {
+	ctx = actx.BuildIgnoreTenantCtx(ctx)
+	return r.GetDb().InTx(ctx, func(ctx context.Context) error {
+		if err := r.Db(ctx).Where("user_id = ? AND tenant_id = ?", userID, tenantID).Delete(&entity.SysUserRole{}).Error; err != nil {
+			return err
+		}
+		return r.Db(ctx).Where("user_id = ? AND tenant_id = ?", userID, tenantID).Delete(&entity.SysUserTenant{}).Error
+	})
+}
+
+// IsTenantMember 判断用户是否为租户成员(不含归属租户和待接受的邀请)
+func (r *sysUserRepo) IsTenantMember(ctx context.Context, userID, tenantID string) (bool, error) {
+	return r.hasMembership(ctx, userID, tenantID, entity.UserTenantStatusActive)
+}
+
+// IsTenantInvited 判断用户是否有该租户待接受的邀请
+func (r *sysUserRepo) IsTenantInvited(ctx context.Context, userID, tenantID string) (bool, error) {
+	return r.hasMembership(ctx, userID, tenantID, entity.UserTenantStatusPending)
+}
+
+func (r *sysUserRepo) hasMembership(ctx context.Context, userID, tenantID string, status int8) (bool, error) {
+	var count int64
+	err := r.Db(actx.BuildIgnoreTenantCtx(ctx)).Model(&entity.SysUserTenant{}).
+		Where("user_id = ? AND tenant_id = ? AND status = ?", userID, tenantID, status).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetMemberTenantIDs 获取用户加入的租户ID列表(不含归属租户)
+func (r *sysUserRepo) GetMemberTenantIDs(ctx context.Context, userID string) ([]string, error) {
+	return r.membershipTenantIDs(ctx, userID, entity.UserTenantStatusActive)
+}
+
+// GetInvitedTenantIDs 获取邀请用户加入且尚未接受的租户ID列表
+func (r *sysUserRepo) GetInvitedTenantIDs(ctx context.Context, userID string) ([]string, error) {
+	return r.membershipTenantIDs(ctx, userID, entity.UserTenantStatusPending)
+}
+
+func (r *sysUserRepo) membershipTenantIDs(ctx context.Context, userID string, status int8) ([]string, error) {
+	var tenantIDs []string
+	err := r.Db(actx.BuildIgnoreTenantCtx(ctx)).Model(&entity.SysUserTenant{}).
+		Where("user_id = ? AND status = ?", userID, status).
+		Order("created_at").
+		Pluck("tenant_id", &tenantIDs).Error
+	return tenantIDs, err
+}
+
+// GetMemberByUsername 根据用户名获取租户成员
+func (r *sysUserRepo) GetMemberByUsername(ctx context.Context, tenantID, username string) (*entity.SysUser, error) {
+	var result *entity.SysUser
+	err := r.Db(actx.BuildIgnoreTenantCtx(ctx)).
+		Joins("JOIN sys_user_tenant ut ON ut.user_id = sys_user.id").
+		Where("ut.tenant_id = ? AND ut.status = ? AND sys_user.username = ? AND sys_user.deleted_at = 0", tenantID, entity.UserTenantStatusActive, username).
+		First(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 func (r *sysUserRepo) BelongsToDepartment(ctx context.Context, userID string, deptID string) (bool, error) {
 	var count int64
@@ -57,26 +291,43 @@ func (r *sysUserRepo) BelongsToDepartment(ctx context.Context, userID string, de
 
 // GetUserPermissionCodes 获取用户权限代码列表
 func (r *sysUserRepo) GetUserPermissionCodes(ctx context.Context, userID string) ([]string, error) {
+	permIDs, err := r.userPermissionIDs(ctx, userID)
+	if err != nil || len(permIDs) == 0 {
+		return nil, err
+	}
 	var codes []string
-	err := r.Db(ctx).Model(&entity.Permissions{}).
-		Joins("JOIN sys_role_permissions ON sys_role_permissions.permission_id = sys_permissions.id").
-		Joins("JOIN sys_user_role ON sys_user_role.role_id = sys_role_permissions.role_id").
-		Where("sys_user_role.user_id = ?", userID).
-		Pluck("sys_permissions.code", &codes).Error
+	err = r.Db(actx.BuildIgnoreTenantCtx(ctx)).Model(&entity.Permissions{}).
+		Where("id IN ?", permIDs).
+		Pluck("code", &codes).Error
 	return codes, err
 }
 
 // GetUserMenus 获取用户菜单权限
 func (r *sysUserRepo) GetUserMenus(ctx context.Context, userID string) ([]*entity.Permissions, error) {
+	permIDs, err := r.userPermissionIDs(ctx, userID)
+	if err != nil || len(permIDs) == 0 {
+		return nil, err
+	}
 	var permissions []*entity.Permissions
-	err := r.Db(ctx).Model(&entity.Permissions{}).
-		Joins("JOIN sys_role_permissions ON sys_role_permissions.permission_id = sys_permissions.id").
-		Joins("JOIN sys_user_role ON sys_user_role.role_id = sys_role_permissions.role_id").
-		Where("sys_user_role.user_id = ? AND sys_permissions.type = ?", userID, 1).
+	err = r.Db(actx.BuildIgnoreTenantCtx(ctx)).
+		Where("id IN ? AND type = ?", permIDs, 1).
 		Find(&permissions).Error
 	return permissions, err
 }
 
+// userPermissionIDs 用户生效角色的权限ID
+// 角色授权是租户表, 权限是公共表, 独立数据库实例的租户无法关联查询, 先在租户库中查询权限ID
+func (r *sysUserRepo) userPermissionIDs(ctx context.Context, userID string) ([]int64, error) {
+	var permIDs []int64
+	now := time.Now().Unix()
+	err := r.Db(ctx).Model(&entity.RolePermissions{}).
+		Joins("JOIN sys_user_role ON sys_user_role.role_id = sys_role_permissions.role_id").
+		Where("sys_user_role.user_id = ?", userID).
+		Where(activeUserRole("sys_user_role"), now, now).
+		Distinct().Pluck("sys_role_permissions.permission_id", &permIDs).Error
+	return permIDs, err
+}
+
 // FindByDepartment 查询部门下的用户
 func (r *sysUserRepo) FindByDepartment(ctx context.Context, deptID string, excludeAdminID string, qb *db_query.QueryBuilder) ([]*entity.SysUser, error) {
 	// 构建查询
@@ -171,9 +422,10 @@ func (r *sysUserRepo) AssignUsersToDepartment(ctx context.Context, deptID string
 		userDepts := make([]*entity.UserDepartment, len(userIDs))
 		for i, userID := range userIDs {
 			userDepts[i] = &entity.UserDepartment{
-				ID:     r.GenInt64Id(),
-				UserID: userID,
-				DeptID: deptID,
+				ID:        r.GenInt64Id(),
+				UserID:    userID,
+				DeptID:    deptID,
+				IsPrimary: true,
 			}
 		}
 		return r.Db(ctx).Create(&userDepts).Error
@@ -10,6 +10,15 @@ var ProviderSet = wire.NewSet(
 	NewSysRoleRepo,
 	NewSysTenantRepo,
 	NewSysDepartmentRepo,
+	NewSysPositionRepo,
 	NewDataPermissionRepo,
 	NewLoginLogRepo,
+	NewSysTenantJobRepo,
+	NewSysTenantTemplateRepo,
+	NewSysScimTokenRepo,
+	NewSysRecycleBinRepo,
+	NewSysRoleGrantRepo,
+	NewSysRoleConstraintRepo,
+	NewSysAccessReviewRepo,
+	NewSysImpersonationRepo,
 )
@@ -0,0 +1,53 @@
+package entity
+
+// AccessReview 权限复核活动
+type AccessReview struct {
+	ID        string `json:"id" gorm:"primaryKey;size:32;comment:活动ID"`
+	TenantID  string `json:"tenant_id" gorm:"size:32;index;comment:租户ID"`
+	Name      string `json:"name" gorm:"size:128;comment:活动名称"`
+	ScopeType int8   `json:"scope_type" gorm:"not null;default:1;comment:范围类型(1:部门 2:角色)"`
+	DeptID    string `json:"dept_id" gorm:"size:32;comment:部门范围的根部门"`
+	RoleIDs   string `json:"role_ids" gorm:"size:1024;comment:角色范围的角色ID,逗号分隔"`
+	Status    int8   `json:"status" gorm:"index;not null;default:1;comment:状态(1:进行中 2:已关闭)"`
+	DueAt     int64  `json:"due_at" gorm:"not null;default:0;comment:截止时间"`
+	OwnerID   string `json:"owner_id" gorm:"size:32;comment:发起人"`
+	ClosedBy  string `json:"closed_by" gorm:"size:32;comment:关闭人"`
+	ClosedAt  int64  `json:"closed_at" gorm:"not null;default:0;comment:关闭时间"`
+	CreatedAt int64  `json:"created_at" gorm:"index;not null;default:0;comment:发起时间"`
+}
+
+// TableName 定义表名
+func (AccessReview) TableName() string {
+	return "sys_access_review"
+}
+
+// GetPrimaryKey 获取主键字段名
+func (AccessReview) GetPrimaryKey() string {
+	return "id"
+}
+
+// AccessReviewItem 权限复核项, 发起时的授权快照
+type AccessReviewItem struct {
+	ID           string `json:"id" gorm:"primaryKey;size:32;comment:复核项ID"`
+	CampaignID   string `json:"campaign_id" gorm:"size:32;index;comment:活动ID"`
+	TenantID     string `json:"tenant_id" gorm:"size:32;index;comment:租户ID"`
+	Kind         int8   `json:"kind" gorm:"not null;comment:类型(1:用户角色 2:角色权限)"`
+	UserID       string `json:"user_id" gorm:"size:32;comment:用户ID"`
+	RoleID       int64  `json:"role_id" gorm:"comment:角色ID"`
+	PermissionID int64  `json:"permission_id" gorm:"not null;default:0;comment:权限ID"`
+	ReviewerID   string `json:"reviewer_id" gorm:"size:32;index;comment:复核人"`
+	Decision     int8   `json:"decision" gorm:"not null;default:0;comment:复核结果(0:待复核 1:保留 2:收回)"`
+	Comment      string `json:"comment" gorm:"size:512;comment:复核意见"`
+	DecidedAt    int64  `json:"decided_at" gorm:"not null;default:0;comment:复核时间"`
+	AppliedAt    int64  `json:"applied_at" gorm:"not null;default:0;comment:收回生效时间"`
+}
+
+// TableName 定义表名
+func (AccessReviewItem) TableName() string {
+	return "sys_access_review_item"
+}
+
+// GetPrimaryKey 获取主键字段名
+func (AccessReviewItem) GetPrimaryKey() string {
+	return "id"
+}
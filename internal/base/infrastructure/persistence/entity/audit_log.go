@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+)
+
+// AuditLog 数据变更记录, 通过请求ID关联操作日志
+type AuditLog struct {
+	database.BaseIntTime
+	ID         int64  `json:"id" gorm:"primaryKey;autoIncrement;comment:唯一ID"`
+	RequestID  string `json:"request_id" gorm:"type:varchar(64);index:idx_audit_request_id;comment:请求ID"`
+	TenantID   string `json:"tenant_id" gorm:"type:varchar(64);index:idx_audit_tenant_id;comment:租户ID"`
+	EntityType string `json:"entity_type" gorm:"type:varchar(64);index:idx_audit_entity,priority:1;comment:实体类型"`
+	EntityID   string `json:"entity_id" gorm:"type:varchar(64);index:idx_audit_entity,priority:2;comment:实体ID"`
+	Action     string `json:"action" gorm:"type:varchar(16);comment:变更类型(create/update/delete)"`
+	ActorID    string `json:"actor_id" gorm:"type:varchar(64);comment:操作人ID"`
+	ActorName  string `json:"actor_name" gorm:"type:varchar(64);comment:操作人用户名"`
+	// 模拟登录期间 ActorID/ActorName 为实际操作的管理员, 以下为被模拟的用户
+	ImpersonatedUserID string `json:"impersonated_user_id" gorm:"type:varchar(64);comment:被模拟的用户ID"`
+	Changes            string `json:"changes" gorm:"type:text;comment:字段变更(JSON)"`
+}
+
+// TableName 表名
+func (AuditLog) TableName() string {
+	return "sys_audit_log"
+}
@@ -2,14 +2,20 @@ package entity
 
 // DataPermission 数据权限实体
 type DataPermission struct {
-	ID       string `gorm:"column:id;primary_key"`
-	RoleID   int64  `gorm:"column:role_id"`
-	Scope    int8   `gorm:"column:scope"`
-	DeptIDs  string `gorm:"column:dept_ids"` // JSON数组字符串
-	TenantID string `gorm:"column:tenant_id"`
+	ID          string `gorm:"column:id;primary_key"`
+	RoleID      int64  `gorm:"column:role_id"`
+	Scope       int8   `gorm:"column:scope"`
+	DeptIDs     string `gorm:"column:dept_ids"`     // 部门ID, 逗号分隔
+	PositionIDs string `gorm:"column:position_ids"` // 岗位ID, 逗号分隔
+	TenantID    string `gorm:"column:tenant_id"`
 }
 
 // TableName 表名
 func (DataPermission) TableName() string {
 	return "sys_data_permission"
 }
+
+// AuditType 记录数据变更的实体类型
+func (DataPermission) AuditType() string {
+	return "data_permission"
+}
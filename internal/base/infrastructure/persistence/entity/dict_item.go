@@ -0,0 +1,27 @@
+package entity
+
+import "github.com/ares-cloud/ares-ddd-admin/pkg/database"
+
+// DictItem 字典项实体
+type DictItem struct {
+	database.BaseModel
+	ID       string `json:"id" gorm:"primaryKey;size:32;comment:字典项ID"`
+	TenantID string `json:"tenant_id" gorm:"size:32;index;comment:租户ID"`
+	DictType string `json:"dict_type" gorm:"size:64;index;comment:字典类型"`
+	DictName string `json:"dict_name" gorm:"size:128;comment:字典名称"`
+	Label    string `json:"label" gorm:"size:128;comment:字典标签"`
+	Value    string `json:"value" gorm:"size:128;comment:字典值"`
+	Sequence int    `json:"sequence" gorm:"default:0;comment:排序"`
+	Status   int8   `json:"status" gorm:"default:1;comment:状态(1:启用 2:禁用)"`
+	Remark   string `json:"remark" gorm:"size:255;comment:备注"`
+}
+
+// TableName 定义表名
+func (d DictItem) TableName() string {
+	return "sys_dict_item"
+}
+
+// GetPrimaryKey 获取主键字段名
+func (d DictItem) GetPrimaryKey() string {
+	return "id"
+}
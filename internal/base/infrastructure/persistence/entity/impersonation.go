@@ -0,0 +1,25 @@
+package entity
+
+// ImpersonationSession 模拟登录会话
+type ImpersonationSession struct {
+	ID        string `json:"id" gorm:"primaryKey;size:32;comment:会话ID"`
+	TenantID  string `json:"tenant_id" gorm:"size:32;index;comment:租户ID"`
+	ActorID   string `json:"actor_id" gorm:"size:32;index;comment:实际操作的管理员ID"`
+	ActorName string `json:"actor_name" gorm:"size:64;comment:实际操作的管理员账号"`
+	UserID    string `json:"user_id" gorm:"size:32;index;comment:被模拟的用户ID"`
+	Username  string `json:"username" gorm:"size:64;comment:被模拟的用户名"`
+	Reason    string `json:"reason" gorm:"size:512;comment:模拟原因"`
+	StartedAt int64  `json:"started_at" gorm:"index;not null;default:0;comment:开始时间"`
+	ExpiresAt int64  `json:"expires_at" gorm:"not null;default:0;comment:到期时间"`
+	EndedAt   int64  `json:"ended_at" gorm:"not null;default:0;comment:主动结束时间"`
+}
+
+// TableName 定义表名
+func (ImpersonationSession) TableName() string {
+	return "sys_impersonation_session"
+}
+
+// GetPrimaryKey 获取主键字段名
+func (ImpersonationSession) GetPrimaryKey() string {
+	return "id"
+}
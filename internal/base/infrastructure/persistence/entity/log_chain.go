@@ -0,0 +1,21 @@
+package entity
+
+const (
+	LogChainOperation = "oplog" // 操作日志
+	LogChainLogin     = "login" // 登录日志
+)
+
+// LogChainHead 租户日志哈希链的链尾, 追加日志时加锁以保证序号连续
+type LogChainHead struct {
+	TenantID   string `json:"tenant_id" gorm:"primaryKey;type:varchar(64);comment:租户ID"`
+	LogType    string `json:"log_type" gorm:"primaryKey;type:varchar(16);comment:日志类型(oplog/login)"`
+	Seq        int64  `json:"seq" gorm:"not null;default:0;comment:最后一条日志的序号"`
+	Hash       string `json:"hash" gorm:"type:varchar(64);comment:最后一条日志的哈希"`
+	StartMonth string `json:"start_month" gorm:"type:varchar(6);comment:第一条日志所在月份(200601)"`
+	UpdatedAt  int64  `json:"updated_at" gorm:"not null;default:0;comment:更新时间"`
+}
+
+// TableName 表名
+func (LogChainHead) TableName() string {
+	return "sys_log_chain"
+}
@@ -20,4 +20,8 @@ type LoginLog struct {
 	Status    int8   `json:"status" gorm:"type:smallint;default:1;comment:登录状态(1:成功 2:失败)"`
 	Message   string `json:"message" gorm:"type:varchar(255);comment:登录消息"`
 	LoginTime int64  `json:"login_time" gorm:"index:idx_login_time;comment:登录时间"`
+	// 租户内的哈希链, 哈希覆盖上一条日志的哈希和本条日志的规范形式
+	Seq      int64  `json:"seq" gorm:"index:idx_seq;default:0;comment:链上序号"`
+	PrevHash string `json:"prev_hash" gorm:"type:varchar(64);comment:上一条日志的哈希"`
+	Hash     string `json:"hash" gorm:"type:varchar(64);comment:本条日志的哈希"`
 }
@@ -15,6 +15,7 @@ const (
 type OperationLog struct {
 	database.BaseIntTime
 	ID        int64  `json:"id" gorm:"primaryKey;autoIncrement;comment:唯一ID"`
+	RequestID string `json:"request_id" gorm:"type:varchar(64);index:idx_request_id;comment:请求ID"`
 	UserID    string `json:"user_id" gorm:"type:varchar(64);index:idx_user_id;comment:用户ID"`
 	Username  string `json:"username" gorm:"type:varchar(64);index:idx_username;comment:用户名"`
 	TenantID  string `json:"tenant_id" gorm:"type:varchar(64);index:idx_tenant_id;comment:租户ID"`
@@ -29,4 +30,11 @@ type OperationLog struct {
 	Duration  int64  `json:"duration" gorm:"type:bigint;comment:执行时长(ms)"`
 	Module    string `json:"module" gorm:"type:varchar(64);comment:模块名称"`
 	Action    string `json:"action" gorm:"type:varchar(32);comment:操作类型"`
+	// 模拟登录期间 UserID/Username 为实际操作的管理员, 以下为被模拟的用户
+	ImpersonatedUserID   string `json:"impersonated_user_id" gorm:"type:varchar(64);comment:被模拟的用户ID"`
+	ImpersonatedUsername string `json:"impersonated_username" gorm:"type:varchar(64);comment:被模拟的用户名"`
+	// 租户内的哈希链, 哈希覆盖上一条日志的哈希和本条日志的规范形式
+	Seq      int64  `json:"seq" gorm:"index:idx_seq;default:0;comment:链上序号"`
+	PrevHash string `json:"prev_hash" gorm:"type:varchar(64);comment:上一条日志的哈希"`
+	Hash     string `json:"hash" gorm:"type:varchar(64);comment:本条日志的哈希"`
 }
@@ -24,6 +24,11 @@ func (a Permissions) TableName() string {
 	return "sys_permissions"
 }
 
+// AuditType 记录数据变更的实体类型
+func (a Permissions) AuditType() string {
+	return "permissions"
+}
+
 // GetPrimaryKey ， 定义表主键 base repo 会使用，非 gorm 原生接口
 // 参数：
 // 返回值：
@@ -0,0 +1,34 @@
+package entity
+
+import "github.com/ares-cloud/ares-ddd-admin/pkg/database"
+
+// Position 岗位数据库实体
+type Position struct {
+	database.BaseModel
+	ID          string `json:"id" gorm:"primaryKey;size:32;comment:岗位ID"`                                                       // 岗位ID
+	TenantID    string `json:"tenant_id" gorm:"size:32;index;uniqueIndex:idx_sys_position_tenant_code,priority:1;comment:租户ID"` // 租户ID
+	Code        string `json:"code" gorm:"size:50;uniqueIndex:idx_sys_position_tenant_code,priority:2;comment:岗位编码"`            // 岗位编码(租户内唯一)
+	Name        string `json:"name" gorm:"size:100;comment:岗位名称"`                                                               // 岗位名称
+	Sequence    int32  `json:"sequence" gorm:"default:0;comment:显示顺序"`                                                          // 显示顺序
+	Status      int8   `json:"status" gorm:"default:1;comment:岗位状态(0停用 1启用)"`                                                   // 岗位状态
+	Description string `json:"description" gorm:"size:200;comment:描述"`                                                          // 描述
+}
+
+// TableName 表名
+func (Position) TableName() string {
+	return "sys_position"
+}
+
+// AuditType 记录数据变更的实体类型
+func (Position) AuditType() string {
+	return "position"
+}
+
+// GetPrimaryKey ， 定义表主键 base repo 会使用，非 gorm 原生接口
+// 参数：
+// 返回值：
+//
+//	string ：表主键
+func (Position) GetPrimaryKey() string {
+	return "id"
+}
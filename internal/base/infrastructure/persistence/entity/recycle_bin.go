@@ -0,0 +1,24 @@
+package entity
+
+// RecycleBin 回收站, 保存被删除实体及其关联关系的快照
+type RecycleBin struct {
+	ID         string `json:"id" gorm:"primaryKey;size:32;comment:记录ID"`
+	TenantID   string `json:"tenant_id" gorm:"size:32;index;comment:租户ID"`
+	EntityType string `json:"entity_type" gorm:"size:16;uniqueIndex:idx_sys_recycle_bin_entity,priority:1;comment:实体类型"`
+	EntityID   string `json:"entity_id" gorm:"size:32;uniqueIndex:idx_sys_recycle_bin_entity,priority:2;comment:实体ID"`
+	Code       string `json:"code" gorm:"size:64;comment:用户名或编码"`
+	Name       string `json:"name" gorm:"size:128;comment:名称"`
+	Snapshot   string `json:"snapshot" gorm:"type:text;comment:实体及关联关系快照(JSON)"`
+	DeletedBy  string `json:"deleted_by" gorm:"size:32;comment:删除人"`
+	DeletedAt  int64  `json:"deleted_at" gorm:"index;not null;default:0;comment:删除时间"`
+}
+
+// TableName 定义表名
+func (r RecycleBin) TableName() string {
+	return "sys_recycle_bin"
+}
+
+// GetPrimaryKey 获取主键字段名
+func (r RecycleBin) GetPrimaryKey() string {
+	return "id"
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "github.com/ares-cloud/ares-ddd-admin/pkg/database"
+
+// RoleConstraint 职责分离约束
+type RoleConstraint struct {
+	database.BaseModel
+	ID          int64  `json:"id" gorm:"primaryKey;autoIncrement;comment:唯一ID"`                   // 唯一ID
+	TenantID    string `json:"tenant_id" gorm:"size:32;index;comment:租户ID"`                       // 租户ID
+	Name        string `json:"name" gorm:"size:128;comment:约束名称"`                                 // 约束名称
+	Type        int8   `json:"type" gorm:"not null;default:1;comment:约束类型(1:互斥 2:基数)"`            // 约束类型
+	RoleIDs     string `json:"role_ids" gorm:"size:1024;comment:角色ID,逗号分隔"`                       // 角色ID, 逗号分隔
+	MaxCount    int    `json:"max_count" gorm:"not null;default:1;comment:互斥为单个用户最多角色数,基数为最多用户数"` // 上限
+	Description string `json:"description" gorm:"size:512;comment:描述"`                            // 描述
+}
+
+// TableName 定义表名
+func (RoleConstraint) TableName() string {
+	return "sys_role_constraint"
+}
+
+// GetPrimaryKey 获取主键字段名
+func (RoleConstraint) GetPrimaryKey() string {
+	return "id"
+}
@@ -0,0 +1,28 @@
+package entity
+
+// RoleRequest 角色申请, 需要审批的角色审批通过后写入用户角色
+type RoleRequest struct {
+	ID            string `json:"id" gorm:"primaryKey;size:32;comment:申请ID"`
+	TenantID      string `json:"tenant_id" gorm:"size:32;index;comment:租户ID"`
+	UserID        string `json:"user_id" gorm:"size:32;index;comment:被授予角色的用户ID"`
+	RoleID        int64  `json:"role_id" gorm:"index;comment:角色ID"`
+	StartTime     int64  `json:"start_time" gorm:"not null;default:0;comment:生效时间,0表示审批通过后立即生效"`
+	ExpireTime    int64  `json:"expire_time" gorm:"not null;default:0;comment:过期时间,0表示永久有效"`
+	Reason        string `json:"reason" gorm:"size:512;comment:申请理由"`
+	Status        int8   `json:"status" gorm:"index;not null;default:1;comment:状态(1:待审批 2:已通过 3:已拒绝)"`
+	RequestedBy   string `json:"requested_by" gorm:"size:32;comment:申请人"`
+	ReviewedBy    string `json:"reviewed_by" gorm:"size:32;comment:审批人"`
+	ReviewComment string `json:"review_comment" gorm:"size:512;comment:审批意见"`
+	ReviewedAt    int64  `json:"reviewed_at" gorm:"not null;default:0;comment:审批时间"`
+	CreatedAt     int64  `json:"created_at" gorm:"index;not null;default:0;comment:申请时间"`
+}
+
+// TableName 定义表名
+func (r RoleRequest) TableName() string {
+	return "sys_role_request"
+}
+
+// GetPrimaryKey 获取主键字段名
+func (r RoleRequest) GetPrimaryKey() string {
+	return "id"
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "github.com/ares-cloud/ares-ddd-admin/pkg/database"
+
+// ScimToken SCIM 访问令牌实体, 只保存令牌摘要
+type ScimToken struct {
+	database.BaseIntTime
+	ID         string `json:"id" gorm:"primaryKey;size:32;comment:令牌ID"`
+	TenantID   string `json:"tenant_id" gorm:"size:32;index;comment:租户ID"`
+	Name       string `json:"name" gorm:"size:64;comment:令牌名称"`
+	TokenHash  string `json:"token_hash" gorm:"size:64;uniqueIndex;comment:令牌摘要"`
+	ExpireAt   int64  `json:"expire_at" gorm:"default:0;comment:过期时间,0表示永不过期"`
+	LastUsedAt int64  `json:"last_used_at" gorm:"default:0;comment:最近使用时间"`
+}
+
+// TableName 定义表名
+func (t ScimToken) TableName() string {
+	return "sys_scim_token"
+}
+
+// GetPrimaryKey 获取主键字段名
+func (t ScimToken) GetPrimaryKey() string {
+	return "id"
+}
@@ -6,8 +6,8 @@ import "github.com/ares-cloud/ares-ddd-admin/pkg/database"
 type SysUser struct {
 	database.BaseModel
 	ID             string `json:"id" gorm:"primaryKey;size:32;comment:用户ID"`
-	TenantID       string `json:"tenant_id" gorm:"size:32;index;comment:租户ID"`
-	Username       string `json:"username" gorm:"size:32;uniqueIndex;comment:用户名"`
+	TenantID       string `json:"tenant_id" gorm:"size:32;index;uniqueIndex:idx_sys_user_tenant_username,priority:1;comment:租户ID"`
+	Username       string `json:"username" gorm:"size:32;uniqueIndex:idx_sys_user_tenant_username,priority:2;comment:用户名(租户内唯一)"`
 	Avatar         string `json:"avatar" gorm:"size:255;comment:头像"`
 	Name           string `json:"name" gorm:"size:128;comment:姓名"`
 	Nickname       string `json:"nickname" gorm:"size:128;comment:昵称"`
@@ -16,6 +16,7 @@ type SysUser struct {
 	Email          string `json:"email" gorm:"size:128;comment:邮箱"`
 	Remark         string `json:"remark" gorm:"size:512;comment:备注"`
 	InvitationCode string `json:"invitation_code" gorm:"size:32;comment:邀请码"`
+	InvitationExp  int64  `json:"invitation_exp" gorm:"default:0;comment:邀请过期时间"`
 	Status         int8   `json:"status" gorm:"column:status;default:1;comment:状态,1启用,2禁用"`
 }
 
@@ -24,6 +25,25 @@ func (a SysUser) TableName() string {
 	return "sys_user"
 }
 
+// AuditType 记录数据变更的实体类型
+func (a SysUser) AuditType() string {
+	return "user"
+}
+
+// MemberView 其他租户可见的成员信息, 去掉手机号、邮箱、密码等归属租户的个人信息
+func (a *SysUser) MemberView() *SysUser {
+	return &SysUser{
+		BaseModel: a.BaseModel,
+		ID:        a.ID,
+		TenantID:  a.TenantID,
+		Username:  a.Username,
+		Avatar:    a.Avatar,
+		Name:      a.Name,
+		Nickname:  a.Nickname,
+		Status:    a.Status,
+	}
+}
+
 // GetPrimaryKey ， 定义表主键 base repo 会使用，非 gorm 原生接口
 // 参数：
 // 返回值：
@@ -2,10 +2,12 @@ package entity
 
 // SysUserRole 基于角色的访问控制 (RBAC) 的用户角色
 type SysUserRole struct {
-	ID       int64  `json:"id" gorm:"primaryKey;autoIncrement;comment:唯一ID"` // 唯一ID
-	UserID   string `json:"user_id" gorm:"index;comment:来源于 User.ID"`        // 来源于 User.ID
-	RoleID   int64  `json:"role_id" gorm:"index;comment:来源于 Role.ID"`        // 来源于 Role.ID
-	TenantID string `json:"tenant_id" gorm:"size:32;index;comment:租户ID"`     // 租户ID
+	ID         int64  `json:"id" gorm:"primaryKey;autoIncrement;comment:唯一ID"`                  // 唯一ID
+	UserID     string `json:"user_id" gorm:"index;comment:来源于 User.ID"`                         // 来源于 User.ID
+	RoleID     int64  `json:"role_id" gorm:"index;comment:来源于 Role.ID"`                         // 来源于 Role.ID
+	TenantID   string `json:"tenant_id" gorm:"size:32;index;comment:租户ID"`                      // 租户ID
+	StartTime  int64  `json:"start_time" gorm:"not null;default:0;comment:生效时间,0表示立即生效"`        // 生效时间
+	ExpireTime int64  `json:"expire_time" gorm:"index;not null;default:0;comment:过期时间,0表示永久有效"` // 过期时间
 }
 
 // TableName 定义数据库中用户角色表的名称
@@ -0,0 +1,23 @@
+package entity
+
+const (
+	UserTenantStatusPending int8 = 1 // 已邀请, 等待用户接受
+	UserTenantStatusActive  int8 = 2 // 已加入
+)
+
+// SysUserTenant 用户租户成员关系, 用户可以加入归属租户以外的其他租户
+// 租户管理员发起邀请后为待接受状态, 用户本人接受后才成为成员
+type SysUserTenant struct {
+	ID        int64  `json:"id" gorm:"primaryKey;autoIncrement;comment:唯一ID"`                                  // 唯一ID
+	UserID    string `json:"user_id" gorm:"size:32;uniqueIndex:idx_sys_user_tenant,priority:1;comment:用户ID"`   // 用户ID
+	TenantID  string `json:"tenant_id" gorm:"size:32;uniqueIndex:idx_sys_user_tenant,priority:2;comment:租户ID"` // 租户ID
+	Status    int8   `json:"status" gorm:"not null;default:1;comment:状态,1待接受,2已加入"`                            // 状态
+	InvitedBy string `json:"invited_by" gorm:"size:32;comment:邀请人"`                                            // 邀请人
+	CreatedAt int64  `json:"created_at" gorm:"column:created_at;not null;default:0;comment:邀请时间"`              // 邀请时间
+	JoinedAt  int64  `json:"joined_at" gorm:"not null;default:0;comment:加入时间"`                                 // 加入时间
+}
+
+// TableName 定义数据库中用户租户表的名称
+func (a *SysUserTenant) TableName() string {
+	return "sys_user_tenant"
+}
@@ -8,13 +8,20 @@ type Tenant struct {
 	ID          string `json:"id" gorm:"primaryKey;size:32;comment:租户ID"`
 	Code        string `json:"code" gorm:"size:32;uniqueIndex;comment:租户编码"`
 	Name        string `json:"name" gorm:"size:128;comment:租户名称"`
-	Domain      string `json:"domain" gorm:"size:255;comment:租户名称"`
+	Domain      string `json:"domain" gorm:"size:255;index;comment:租户域名"`
 	AdminUserID string `json:"admin_user_id" gorm:"size:32;comment:管理员用户ID"`
 	Status      int8   `json:"status" gorm:"default:1;comment:状态(1:启用 2:禁用)"`
 	IsDefault   int8   `json:"is_default" gorm:"default:2;comment:是否默认租户(1:是 2:否)"`
 	ExpireTime  int64  `json:"expire_time" gorm:"comment:过期时间"`
 	Description string `json:"description" gorm:"size:512;comment:描述"`
 	LockReason  string `json:"lock_reason" gorm:"size:255;comment:禁用原因"`
+	// 数据隔离
+	IsolationMode string `json:"isolation_mode" gorm:"size:16;default:'shared';comment:数据隔离模式(shared/schema/database)"`
+	DbSchema      string `json:"db_schema" gorm:"size:64;comment:独立Schema名称"`
+	DbSource      string `json:"-" gorm:"size:512;comment:独立数据库连接串"`
+	// 开通状态, 独立存储的租户开通失败后保留记录, 可重新提交创建请求继续开通或删除
+	ProvisionStatus int8   `json:"provision_status" gorm:"not null;default:0;comment:开通状态(0:已完成 1:开通中 2:开通失败)"`
+	ProvisionError  string `json:"provision_error" gorm:"size:512;comment:开通失败原因"`
 }
 
 // TableName 定义表名
@@ -22,6 +29,11 @@ func (t Tenant) TableName() string {
 	return "sys_tenant"
 }
 
+// AuditType 记录数据变更的实体类型
+func (t Tenant) AuditType() string {
+	return "tenant"
+}
+
 // GetPrimaryKey 获取主键字段名
 func (t Tenant) GetPrimaryKey() string {
 	return "id"
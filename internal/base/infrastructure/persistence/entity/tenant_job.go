@@ -0,0 +1,31 @@
+package entity
+
+import "github.com/ares-cloud/ares-ddd-admin/pkg/database"
+
+// TenantJob 租户下线任务实体
+type TenantJob struct {
+	database.BaseIntTime
+	ID         string `json:"id" gorm:"primaryKey;size:32;comment:任务ID"`
+	TenantID   string `json:"tenant_id" gorm:"size:32;index;comment:租户ID"`
+	TenantCode string `json:"tenant_code" gorm:"size:32;comment:租户编码"`
+	Type       string `json:"type" gorm:"size:16;comment:任务类型(export/purge)"`
+	Status     int8   `json:"status" gorm:"default:1;comment:状态(1:等待 2:执行中 3:成功 4:失败)"`
+	Progress   int32  `json:"progress" gorm:"default:0;comment:进度"`
+	Step       string `json:"step" gorm:"size:64;comment:当前步骤"`
+	FilePath   string `json:"file_path" gorm:"size:512;comment:导出归档路径"`
+	FileSize   int64  `json:"file_size" gorm:"comment:导出归档大小"`
+	Report     string `json:"report" gorm:"type:text;comment:签名的完成报告"`
+	Error      string `json:"error" gorm:"size:1024;comment:失败原因"`
+	Creator    string `json:"creator" gorm:"size:32;comment:创建人"`
+	FinishedAt int64  `json:"finished_at" gorm:"comment:完成时间"`
+}
+
+// TableName 定义表名
+func (t TenantJob) TableName() string {
+	return "sys_tenant_job"
+}
+
+// GetPrimaryKey 获取主键字段名
+func (t TenantJob) GetPrimaryKey() string {
+	return "id"
+}
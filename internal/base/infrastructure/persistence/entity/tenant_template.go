@@ -0,0 +1,24 @@
+package entity
+
+import "github.com/ares-cloud/ares-ddd-admin/pkg/database"
+
+// TenantTemplate 租户开通模板实体
+type TenantTemplate struct {
+	database.BaseModel
+	ID             string `json:"id" gorm:"primaryKey;size:32;comment:模板ID"`
+	Name           string `json:"name" gorm:"size:64;uniqueIndex;comment:模板名称"`
+	Description    string `json:"description" gorm:"size:512;comment:描述"`
+	Format         string `json:"format" gorm:"size:8;comment:模板格式(yaml/json)"`
+	Content        string `json:"content" gorm:"type:text;comment:模板内容"`
+	SourceTenantID string `json:"source_tenant_id" gorm:"size:32;comment:克隆来源租户ID"`
+}
+
+// TableName 定义表名
+func (t TenantTemplate) TableName() string {
+	return "sys_tenant_template"
+}
+
+// GetPrimaryKey 获取主键字段名
+func (t TenantTemplate) GetPrimaryKey() string {
+	return "id"
+}
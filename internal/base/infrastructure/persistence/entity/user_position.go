@@ -0,0 +1,14 @@
+package entity
+
+// UserPosition 用户岗位关系表
+type UserPosition struct {
+	ID         int64  `json:"id" gorm:"primaryKey;autoIncrement;comment:唯一ID"` // 唯一ID
+	UserID     string `gorm:"column:user_id;size:32;index;comment:用户ID"`
+	PositionID string `gorm:"column:position_id;size:32;index;comment:岗位ID"`
+	IsPrimary  bool   `gorm:"column:is_primary;default:false;comment:是否主岗"`
+}
+
+// TableName 表名
+func (UserPosition) TableName() string {
+	return "sys_user_position"
+}
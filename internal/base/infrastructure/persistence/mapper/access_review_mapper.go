@@ -0,0 +1,114 @@
+package mapper
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+)
+
+type AccessReviewMapper struct{}
+
+// ToEntity 复核活动转换为实体
+func (m *AccessReviewMapper) ToEntity(c *model.AccessReviewCampaign) *entity.AccessReview {
+	if c == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(c.RoleIDs))
+	for _, id := range c.RoleIDs {
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+	return &entity.AccessReview{
+		ID:        c.ID,
+		TenantID:  c.TenantID,
+		Name:      c.Name,
+		ScopeType: c.ScopeType,
+		DeptID:    c.DeptID,
+		RoleIDs:   strings.Join(ids, ","),
+		Status:    c.Status,
+		DueAt:     c.DueAt,
+		OwnerID:   c.OwnerID,
+		ClosedBy:  c.ClosedBy,
+		ClosedAt:  c.ClosedAt,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+// ToDomain 实体转换为复核活动
+func (m *AccessReviewMapper) ToDomain(e *entity.AccessReview) *model.AccessReviewCampaign {
+	if e == nil {
+		return nil
+	}
+	var roleIDs []int64
+	for _, s := range strings.Split(e.RoleIDs, ",") {
+		if id, err := strconv.ParseInt(s, 10, 64); err == nil {
+			roleIDs = append(roleIDs, id)
+		}
+	}
+	return &model.AccessReviewCampaign{
+		ID:        e.ID,
+		TenantID:  e.TenantID,
+		Name:      e.Name,
+		ScopeType: e.ScopeType,
+		DeptID:    e.DeptID,
+		RoleIDs:   roleIDs,
+		Status:    e.Status,
+		DueAt:     e.DueAt,
+		OwnerID:   e.OwnerID,
+		ClosedBy:  e.ClosedBy,
+		ClosedAt:  e.ClosedAt,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// ToItemEntity 复核项转换为实体
+func (m *AccessReviewMapper) ToItemEntity(i *model.AccessReviewItem) *entity.AccessReviewItem {
+	if i == nil {
+		return nil
+	}
+	return &entity.AccessReviewItem{
+		ID:           i.ID,
+		CampaignID:   i.CampaignID,
+		TenantID:     i.TenantID,
+		Kind:         i.Kind,
+		UserID:       i.UserID,
+		RoleID:       i.RoleID,
+		PermissionID: i.PermissionID,
+		ReviewerID:   i.ReviewerID,
+		Decision:     i.Decision,
+		Comment:      i.Comment,
+		DecidedAt:    i.DecidedAt,
+		AppliedAt:    i.AppliedAt,
+	}
+}
+
+// ToItem 实体转换为复核项
+func (m *AccessReviewMapper) ToItem(e *entity.AccessReviewItem) *model.AccessReviewItem {
+	if e == nil {
+		return nil
+	}
+	return &model.AccessReviewItem{
+		ID:           e.ID,
+		CampaignID:   e.CampaignID,
+		TenantID:     e.TenantID,
+		Kind:         e.Kind,
+		UserID:       e.UserID,
+		RoleID:       e.RoleID,
+		PermissionID: e.PermissionID,
+		ReviewerID:   e.ReviewerID,
+		Decision:     e.Decision,
+		Comment:      e.Comment,
+		DecidedAt:    e.DecidedAt,
+		AppliedAt:    e.AppliedAt,
+	}
+}
+
+// ToItemList 实体列表转换为复核项列表
+func (m *AccessReviewMapper) ToItemList(list []*entity.AccessReviewItem) []*model.AccessReviewItem {
+	result := make([]*model.AccessReviewItem, 0, len(list))
+	for _, e := range list {
+		result = append(result, m.ToItem(e))
+	}
+	return result
+}
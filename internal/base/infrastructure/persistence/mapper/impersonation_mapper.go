@@ -0,0 +1,46 @@
+package mapper
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+)
+
+type ImpersonationMapper struct{}
+
+// ToEntity 领域模型转换为实体
+func (m *ImpersonationMapper) ToEntity(s *model.ImpersonationSession) *entity.ImpersonationSession {
+	if s == nil {
+		return nil
+	}
+	return &entity.ImpersonationSession{
+		ID:        s.ID,
+		TenantID:  s.TenantID,
+		ActorID:   s.ActorID,
+		ActorName: s.ActorName,
+		UserID:    s.UserID,
+		Username:  s.Username,
+		Reason:    s.Reason,
+		StartedAt: s.StartedAt,
+		ExpiresAt: s.ExpiresAt,
+		EndedAt:   s.EndedAt,
+	}
+}
+
+// ToDomain 实体转换为领域模型
+func (m *ImpersonationMapper) ToDomain(e *entity.ImpersonationSession) *model.ImpersonationSession {
+	if e == nil {
+		return nil
+	}
+	return &model.ImpersonationSession{
+		ID:        e.ID,
+		TenantID:  e.TenantID,
+		ActorID:   e.ActorID,
+		ActorName: e.ActorName,
+		UserID:    e.UserID,
+		Username:  e.Username,
+		Reason:    e.Reason,
+		StartedAt: e.StartedAt,
+		ExpiresAt: e.ExpiresAt,
+		EndedAt:   e.EndedAt,
+	}
+}
@@ -44,6 +44,7 @@ func (m *PermissionsMapper) ToDomain(e *entity.Permissions, resources []*entity.
 				PermissionsID: r.PermissionsID,
 				Method:        r.Method,
 				Path:          r.Path,
+				Condition:     r.Condition,
 			})
 		}
 	}
@@ -82,6 +83,7 @@ func (m *PermissionsMapper) ToEntity(d *model.Permissions) (*entity.Permissions,
 				PermissionsID: d.ID,
 				Method:        r.Method,
 				Path:          r.Path,
+				Condition:     r.Condition,
 			}
 		}
 	}
@@ -0,0 +1,77 @@
+package mapper
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+)
+
+type PositionMapper struct{}
+
+func (m *PositionMapper) ToDomain(e *entity.Position) *model.Position {
+	if e == nil {
+		return nil
+	}
+	return &model.Position{
+		ID:          e.ID,
+		TenantID:    e.TenantID,
+		Code:        e.Code,
+		Name:        e.Name,
+		Sequence:    e.Sequence,
+		Status:      e.Status,
+		Description: e.Description,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+func (m *PositionMapper) ToDomainList(entities []*entity.Position) []*model.Position {
+	if len(entities) == 0 {
+		return make([]*model.Position, 0)
+	}
+	list := make([]*model.Position, len(entities))
+	for i, e := range entities {
+		list[i] = m.ToDomain(e)
+	}
+	return list
+}
+
+func (m *PositionMapper) ToEntity(p *model.Position) *entity.Position {
+	if p == nil {
+		return nil
+	}
+	return &entity.Position{
+		ID:          p.ID,
+		TenantID:    p.TenantID,
+		Code:        p.Code,
+		Name:        p.Name,
+		Sequence:    p.Sequence,
+		Status:      p.Status,
+		Description: p.Description,
+		BaseModel: database.BaseModel{
+			BaseIntTime: database.BaseIntTime{
+				CreatedAt: p.CreatedAt,
+				UpdatedAt: p.UpdatedAt,
+			},
+		},
+	}
+}
+
+func (m *PositionMapper) ToUserPositionDomain(e *entity.UserPosition) *model.UserPosition {
+	if e == nil {
+		return nil
+	}
+	return &model.UserPosition{
+		UserID:     e.UserID,
+		PositionID: e.PositionID,
+		IsPrimary:  e.IsPrimary,
+	}
+}
+
+func (m *PositionMapper) ToUserPositionDomainList(entities []*entity.UserPosition) []*model.UserPosition {
+	list := make([]*model.UserPosition, 0, len(entities))
+	for _, e := range entities {
+		list = append(list, m.ToUserPositionDomain(e))
+	}
+	return list
+}
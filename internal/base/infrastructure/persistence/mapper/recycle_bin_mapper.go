@@ -0,0 +1,34 @@
+package mapper
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+)
+
+type RecycleBinMapper struct{}
+
+// ToDomain 实体转换为领域模型
+func (m *RecycleBinMapper) ToDomain(e *entity.RecycleBin) *model.RecycleItem {
+	if e == nil {
+		return nil
+	}
+	return &model.RecycleItem{
+		ID:         e.ID,
+		TenantID:   e.TenantID,
+		EntityType: e.EntityType,
+		EntityID:   e.EntityID,
+		Code:       e.Code,
+		Name:       e.Name,
+		DeletedBy:  e.DeletedBy,
+		DeletedAt:  e.DeletedAt,
+	}
+}
+
+// ToDomainList 实体列表转换为领域模型列表
+func (m *RecycleBinMapper) ToDomainList(list []*entity.RecycleBin) []*model.RecycleItem {
+	result := make([]*model.RecycleItem, 0, len(list))
+	for _, e := range list {
+		result = append(result, m.ToDomain(e))
+	}
+	return result
+}
@@ -0,0 +1,67 @@
+package mapper
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+)
+
+type RoleConstraintMapper struct{}
+
+// ToEntity 约束转换为实体
+func (m *RoleConstraintMapper) ToEntity(c *model.RoleConstraint) *entity.RoleConstraint {
+	if c == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(c.RoleIDs))
+	for _, id := range c.RoleIDs {
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+	e := &entity.RoleConstraint{
+		ID:          c.ID,
+		TenantID:    c.TenantID,
+		Name:        c.Name,
+		Type:        c.Type,
+		RoleIDs:     strings.Join(ids, ","),
+		MaxCount:    c.Limit,
+		Description: c.Description,
+	}
+	e.CreatedAt = c.CreatedAt
+	e.UpdatedAt = c.UpdatedAt
+	return e
+}
+
+// ToDomain 实体转换为约束
+func (m *RoleConstraintMapper) ToDomain(e *entity.RoleConstraint) *model.RoleConstraint {
+	if e == nil {
+		return nil
+	}
+	var roleIDs []int64
+	for _, s := range strings.Split(e.RoleIDs, ",") {
+		if id, err := strconv.ParseInt(s, 10, 64); err == nil {
+			roleIDs = append(roleIDs, id)
+		}
+	}
+	return &model.RoleConstraint{
+		ID:          e.ID,
+		TenantID:    e.TenantID,
+		Name:        e.Name,
+		Type:        e.Type,
+		RoleIDs:     roleIDs,
+		Limit:       e.MaxCount,
+		Description: e.Description,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+// ToDomainList 实体列表转换为约束列表
+func (m *RoleConstraintMapper) ToDomainList(list []*entity.RoleConstraint) []*model.RoleConstraint {
+	result := make([]*model.RoleConstraint, 0, len(list))
+	for _, e := range list {
+		result = append(result, m.ToDomain(e))
+	}
+	return result
+}
@@ -0,0 +1,91 @@
+package mapper
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+)
+
+type RoleGrantMapper struct{}
+
+// ToUserRole 角色授予转换为用户角色实体
+func (m *RoleGrantMapper) ToUserRole(grant *model.RoleGrant) *entity.SysUserRole {
+	if grant == nil {
+		return nil
+	}
+	return &entity.SysUserRole{
+		ID:         grant.ID,
+		TenantID:   grant.TenantID,
+		UserID:     grant.UserID,
+		RoleID:     grant.RoleID,
+		StartTime:  grant.StartTime,
+		ExpireTime: grant.ExpireTime,
+	}
+}
+
+// ToGrant 用户角色实体转换为角色授予
+func (m *RoleGrantMapper) ToGrant(e *entity.SysUserRole) *model.RoleGrant {
+	if e == nil {
+		return nil
+	}
+	return &model.RoleGrant{
+		ID:         e.ID,
+		TenantID:   e.TenantID,
+		UserID:     e.UserID,
+		RoleID:     e.RoleID,
+		StartTime:  e.StartTime,
+		ExpireTime: e.ExpireTime,
+	}
+}
+
+// ToGrantList 用户角色实体列表转换为角色授予列表
+func (m *RoleGrantMapper) ToGrantList(list []*entity.SysUserRole) []*model.RoleGrant {
+	result := make([]*model.RoleGrant, 0, len(list))
+	for _, e := range list {
+		result = append(result, m.ToGrant(e))
+	}
+	return result
+}
+
+// ToRequestEntity 角色申请转换为实体
+func (m *RoleGrantMapper) ToRequestEntity(req *model.RoleRequest) *entity.RoleRequest {
+	if req == nil {
+		return nil
+	}
+	return &entity.RoleRequest{
+		ID:            req.ID,
+		TenantID:      req.TenantID,
+		UserID:        req.UserID,
+		RoleID:        req.RoleID,
+		StartTime:     req.StartTime,
+		ExpireTime:    req.ExpireTime,
+		Reason:        req.Reason,
+		Status:        req.Status,
+		RequestedBy:   req.RequestedBy,
+		ReviewedBy:    req.ReviewedBy,
+		ReviewComment: req.ReviewComment,
+		ReviewedAt:    req.ReviewedAt,
+		CreatedAt:     req.CreatedAt,
+	}
+}
+
+// ToRequest 实体转换为角色申请
+func (m *RoleGrantMapper) ToRequest(e *entity.RoleRequest) *model.RoleRequest {
+	if e == nil {
+		return nil
+	}
+	return &model.RoleRequest{
+		ID:            e.ID,
+		TenantID:      e.TenantID,
+		UserID:        e.UserID,
+		RoleID:        e.RoleID,
+		StartTime:     e.StartTime,
+		ExpireTime:    e.ExpireTime,
+		Reason:        e.Reason,
+		Status:        e.Status,
+		RequestedBy:   e.RequestedBy,
+		ReviewedBy:    e.ReviewedBy,
+		ReviewComment: e.ReviewComment,
+		ReviewedAt:    e.ReviewedAt,
+		CreatedAt:     e.CreatedAt,
+	}
+}
@@ -13,30 +13,32 @@ func NewRoleMapper() *RoleMapper {
 
 func (m *RoleMapper) ToDomain(e *entity.Role, permissions []*model.Permissions) *model.Role {
 	return &model.Role{
-		ID:          e.ID,
-		Code:        e.Code,
-		Name:        e.Name,
-		Localize:    e.Localize,
-		Description: e.Description,
-		Sequence:    e.Sequence,
-		Status:      e.Status,
-		Type:        e.Type,
-		Permissions: permissions,
-		CreatedAt:   e.CreatedAt,
-		UpdatedAt:   e.UpdatedAt,
+		ID:               e.ID,
+		Code:             e.Code,
+		Name:             e.Name,
+		Localize:         e.Localize,
+		Description:      e.Description,
+		Sequence:         e.Sequence,
+		Status:           e.Status,
+		Type:             e.Type,
+		Permissions:      permissions,
+		RequiresApproval: e.RequiresApproval,
+		CreatedAt:        e.CreatedAt,
+		UpdatedAt:        e.UpdatedAt,
 	}
 }
 
 func (m *RoleMapper) ToEntity(d *model.Role) *entity.Role {
 	return &entity.Role{
-		ID:          d.ID,
-		Code:        d.Code,
-		Name:        d.Name,
-		Localize:    d.Localize,
-		Description: d.Description,
-		Sequence:    d.Sequence,
-		Type:        d.Type,
-		Status:      d.Status,
+		ID:               d.ID,
+		Code:             d.Code,
+		Name:             d.Name,
+		Localize:         d.Localize,
+		Description:      d.Description,
+		Sequence:         d.Sequence,
+		Type:             d.Type,
+		Status:           d.Status,
+		RequiresApproval: d.RequiresApproval,
 	}
 }
 
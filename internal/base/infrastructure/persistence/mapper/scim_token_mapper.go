@@ -0,0 +1,52 @@
+package mapper
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+)
+
+type ScimTokenMapper struct{}
+
+// ToEntity 领域模型转换为实体
+func (m *ScimTokenMapper) ToEntity(domain *model.ScimToken) *entity.ScimToken {
+	if domain == nil {
+		return nil
+	}
+	return &entity.ScimToken{
+		ID:         domain.ID,
+		TenantID:   domain.TenantID,
+		Name:       domain.Name,
+		TokenHash:  domain.TokenHash,
+		ExpireAt:   domain.ExpireAt,
+		LastUsedAt: domain.LastUsedAt,
+		BaseIntTime: database.BaseIntTime{
+			CreatedAt: domain.CreatedAt,
+		},
+	}
+}
+
+// ToDomain 实体转换为领域模型
+func (m *ScimTokenMapper) ToDomain(e *entity.ScimToken) *model.ScimToken {
+	if e == nil {
+		return nil
+	}
+	return &model.ScimToken{
+		ID:         e.ID,
+		TenantID:   e.TenantID,
+		Name:       e.Name,
+		TokenHash:  e.TokenHash,
+		ExpireAt:   e.ExpireAt,
+		LastUsedAt: e.LastUsedAt,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+// ToDomainList 实体列表转换为领域模型列表
+func (m *ScimTokenMapper) ToDomainList(list []*entity.ScimToken) []*model.ScimToken {
+	result := make([]*model.ScimToken, 0, len(list))
+	for _, e := range list {
+		result = append(result, m.ToDomain(e))
+	}
+	return result
+}
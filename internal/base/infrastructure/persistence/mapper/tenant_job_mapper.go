@@ -0,0 +1,59 @@
+package mapper
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+)
+
+type TenantJobMapper struct{}
+
+// ToEntity 领域模型转换为实体
+func (m *TenantJobMapper) ToEntity(domain *model.TenantJob) *entity.TenantJob {
+	if domain == nil {
+		return nil
+	}
+	return &entity.TenantJob{
+		ID:         domain.ID,
+		TenantID:   domain.TenantID,
+		TenantCode: domain.TenantCode,
+		Type:       domain.Type,
+		Status:     domain.Status,
+		Progress:   domain.Progress,
+		Step:       domain.Step,
+		FilePath:   domain.FilePath,
+		FileSize:   domain.FileSize,
+		Report:     domain.Report,
+		Error:      domain.Error,
+		Creator:    domain.Creator,
+		FinishedAt: domain.FinishedAt,
+		BaseIntTime: database.BaseIntTime{
+			CreatedAt: domain.CreatedAt,
+			UpdatedAt: domain.UpdatedAt,
+		},
+	}
+}
+
+// ToDomain 实体转换为领域模型
+func (m *TenantJobMapper) ToDomain(entity *entity.TenantJob) *model.TenantJob {
+	if entity == nil {
+		return nil
+	}
+	return &model.TenantJob{
+		ID:         entity.ID,
+		TenantID:   entity.TenantID,
+		TenantCode: entity.TenantCode,
+		Type:       entity.Type,
+		Status:     entity.Status,
+		Progress:   entity.Progress,
+		Step:       entity.Step,
+		FilePath:   entity.FilePath,
+		FileSize:   entity.FileSize,
+		Report:     entity.Report,
+		Error:      entity.Error,
+		Creator:    entity.Creator,
+		CreatedAt:  entity.CreatedAt,
+		UpdatedAt:  entity.UpdatedAt,
+		FinishedAt: entity.FinishedAt,
+	}
+}
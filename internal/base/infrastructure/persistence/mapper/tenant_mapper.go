@@ -30,6 +30,10 @@ func (m *TenantMapper) ToEntity(domain *model.Tenant) *entity.Tenant {
 		IsDefault:   domain.IsDefault,
 		ExpireTime:  domain.ExpireTime,
 		Description: domain.Description,
+
+		IsolationMode: domain.IsolationMode,
+		DbSchema:      domain.DbSchema,
+		DbSource:      domain.DbSource,
 	}
 }
 
@@ -49,7 +53,13 @@ func (m *TenantMapper) ToDomain(entity *entity.Tenant, adminUser *entity.SysUser
 		ExpireTime:  entity.ExpireTime,
 		Description: entity.Description,
 		CreatedAt:   entity.CreatedAt,
-		UpdatedAt:   entity.UpdatedAt,
+
+		IsolationMode: entity.IsolationMode,
+		DbSchema:      entity.DbSchema,
+		DbSource:      entity.DbSource,
+		UpdatedAt:     entity.UpdatedAt,
+
+		ProvisionStatus: entity.ProvisionStatus,
 	}
 
 	// 转换管理员用户
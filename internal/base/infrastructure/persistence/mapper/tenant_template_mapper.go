@@ -0,0 +1,53 @@
+package mapper
+
+import (
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/tenantdata"
+)
+
+type TenantTemplateMapper struct{}
+
+// ToEntity 领域模型转换为实体
+func (m *TenantTemplateMapper) ToEntity(domain *model.TenantTemplate) *entity.TenantTemplate {
+	if domain == nil {
+		return nil
+	}
+	return &entity.TenantTemplate{
+		ID:             domain.ID,
+		Name:           domain.Name,
+		Description:    domain.Description,
+		Format:         domain.Format,
+		Content:        domain.Content,
+		SourceTenantID: domain.SourceTenantID,
+		BaseModel: database.BaseModel{
+			BaseIntTime: database.BaseIntTime{
+				CreatedAt: domain.CreatedAt,
+				UpdatedAt: domain.UpdatedAt,
+			},
+		},
+	}
+}
+
+// ToDomain 实体转换为领域模型, 模板内容解析失败时返回错误
+func (m *TenantTemplateMapper) ToDomain(e *entity.TenantTemplate) (*model.TenantTemplate, error) {
+	if e == nil {
+		return nil, nil
+	}
+	spec, err := tenantdata.ParseTemplate([]byte(e.Content), e.Format)
+	if err != nil {
+		return nil, err
+	}
+	return &model.TenantTemplate{
+		ID:             e.ID,
+		Name:           e.Name,
+		Description:    e.Description,
+		Format:         e.Format,
+		Content:        e.Content,
+		SourceTenantID: e.SourceTenantID,
+		Spec:           spec,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}, nil
+}
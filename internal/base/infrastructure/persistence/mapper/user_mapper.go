@@ -16,12 +16,14 @@ func (m *UserMapper) ToDomain(e *entity.SysUser, roles []*model.Role) *model.Use
 		ID:             e.ID,
 		Name:           e.Name,
 		Username:       e.Username,
+		Nickname:       e.Nickname,
 		Avatar:         e.Avatar,
 		Password:       e.Password,
 		Phone:          e.Phone,
 		Email:          e.Email,
 		Remark:         e.Remark,
 		InvitationCode: e.InvitationCode,
+		InvitationExp:  e.InvitationExp,
 		Status:         e.Status,
 		Roles:          roles,
 		CreatedAt:      e.CreatedAt,
@@ -35,12 +37,14 @@ func (m *UserMapper) ToEntity(d *model.User) *entity.SysUser {
 		ID:             d.ID,
 		Username:       d.Username,
 		Name:           d.Name,
+		Nickname:       d.Nickname,
 		Avatar:         d.Avatar,
 		Password:       d.Password,
 		Phone:          d.Phone,
 		Email:          d.Email,
 		Remark:         d.Remark,
 		InvitationCode: d.InvitationCode,
+		InvitationExp:  d.InvitationExp,
 		Status:         d.Status,
 	}
 }
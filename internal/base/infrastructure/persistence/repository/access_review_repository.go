@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	drepository "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/mapper"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+)
+
+type ISysAccessReviewRepo interface {
+	baserepo.IBaseRepo[entity.AccessReview, string]
+	CreateWithItems(ctx context.Context, campaign *entity.AccessReview, items []*entity.AccessReviewItem) error
+	GetItem(ctx context.Context, id string) (*entity.AccessReviewItem, error)
+	ListItems(ctx context.Context, campaignID, reviewerID string) ([]*entity.AccessReviewItem, error)
+	ListOpenItemsByReviewer(ctx context.Context, reviewerID string) ([]*entity.AccessReviewItem, error)
+	UpdateItemDecision(ctx context.Context, item *entity.AccessReviewItem) error
+	CloseWithRevocations(ctx context.Context, campaign *entity.AccessReview, userRoles []*entity.SysUserRole, rolePerms []*entity.RolePermissions, itemIDs []string) error
+	ListDeptMembers(ctx context.Context, deptIDs []string) ([]*entity.UserDepartment, error)
+	ListUserRoles(ctx context.Context, userIDs []string, roleIDs []int64) ([]*entity.SysUserRole, error)
+	ListRolePermissions(ctx context.Context, roleIDs []int64) ([]*entity.RolePermissions, error)
+}
+
+type accessReviewRepository struct {
+	repo        ISysAccessReviewRepo
+	mapper      *mapper.AccessReviewMapper
+	grantMapper *mapper.RoleGrantMapper
+}
+
+func NewAccessReviewRepository(repo ISysAccessReviewRepo) drepository.IAccessReviewRepository {
+	return &accessReviewRepository{
+		repo:        repo,
+		mapper:      &mapper.AccessReviewMapper{},
+		grantMapper: &mapper.RoleGrantMapper{},
+	}
+}
+
+func (r *accessReviewRepository) Create(ctx context.Context, campaign *model.AccessReviewCampaign, items []*model.AccessReviewItem) error {
+	campaign.ID = r.repo.GenStringId()
+	list := make([]*entity.AccessReviewItem, 0, len(items))
+	for _, item := range items {
+		item.ID = r.repo.GenStringId()
+		item.CampaignID = campaign.ID
+		list = append(list, r.mapper.ToItemEntity(item))
+	}
+	return r.repo.CreateWithItems(ctx, r.mapper.ToEntity(campaign), list)
+}
+
+func (r *accessReviewRepository) FindByID(ctx context.Context, id string) (*model.AccessReviewCampaign, error) {
+	e, err := r.repo.FindById(ctx, id)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.mapper.ToDomain(e), nil
+}
+
+func (r *accessReviewRepository) FindItems(ctx context.Context, campaignID string) ([]*model.AccessReviewItem, error) {
+	list, err := r.repo.ListItems(ctx, campaignID, "")
+	if err != nil {
+		return nil, err
+	}
+	return r.mapper.ToItemList(list), nil
+}
+
+func (r *accessReviewRepository) FindItemByID(ctx context.Context, id string) (*model.AccessReviewItem, error) {
+	e, err := r.repo.GetItem(ctx, id)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.mapper.ToItem(e), nil
+}
+
+func (r *accessReviewRepository) UpdateDecision(ctx context.Context, item *model.AccessReviewItem) error {
+	return r.repo.UpdateItemDecision(ctx, r.mapper.ToItemEntity(item))
+}
+
+func (r *accessReviewRepository) Close(ctx context.Context, campaign *model.AccessReviewCampaign, revoked []*model.AccessReviewItem) error {
+	var (
+		userRoles []*entity.SysUserRole
+		rolePerms []*entity.RolePermissions
+		itemIDs   []string
+	)
+	for _, item := range revoked {
+		switch item.Kind {
+		case model.AccessReviewItemUserRole:
+			userRoles = append(userRoles, &entity.SysUserRole{UserID: item.UserID, RoleID: item.RoleID})
+		case model.AccessReviewItemRolePermission:
+			rolePerms = append(rolePerms, &entity.RolePermissions{RoleID: item.RoleID, PermissionID: item.PermissionID})
+		}
+		item.AppliedAt = campaign.ClosedAt
+		itemIDs = append(itemIDs, item.ID)
+	}
+	return r.repo.CloseWithRevocations(ctx, r.mapper.ToEntity(campaign), userRoles, rolePerms, itemIDs)
+}
+
+func (r *accessReviewRepository) FindDeptMembers(ctx context.Context, deptIDs []string) ([]*model.UserDepartment, error) {
+	list, err := r.repo.ListDeptMembers(ctx, deptIDs)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.UserDepartment, 0, len(list))
+	for _, e := range list {
+		result = append(result, &model.UserDepartment{UserID: e.UserID, DeptID: e.DeptID, IsPrimary: e.IsPrimary})
+	}
+	return result, nil
+}
+
+func (r *accessReviewRepository) FindUserRoles(ctx context.Context, userIDs []string, roleIDs []int64) ([]*model.RoleGrant, error) {
+	list, err := r.repo.ListUserRoles(ctx, userIDs, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+	return r.grantMapper.ToGrantList(list), nil
+}
+
+func (r *accessReviewRepository) FindRolePermissions(ctx context.Context, roleIDs []int64) (map[int64][]int64, error) {
+	list, err := r.repo.ListRolePermissions(ctx, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64][]int64, len(roleIDs))
+	for _, e := range list {
+		result[e.RoleID] = append(result[e.RoleID], e.PermissionID)
+	}
+	return result, nil
+}
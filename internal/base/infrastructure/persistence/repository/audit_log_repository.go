@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/plugin"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// IAuditLogRepo 数据变更记录, 同时作为数据库审计插件的写入器
+type IAuditLogRepo interface {
+	plugin.IAuditRecorder
+	Find(ctx context.Context, qb *db_query.QueryBuilder) ([]*entity.AuditLog, error)
+	Count(ctx context.Context, qb *db_query.QueryBuilder) (int64, error)
+}
+
+type auditLogRepository struct {
+	db database.IDataBase
+}
+
+func NewAuditLogRepository(db database.IDataBase) IAuditLogRepo {
+	if err := db.AutoMigrate(&entity.AuditLog{}); err != nil {
+		hlog.Fatalf("auto migrate audit log error: %v", err)
+	}
+	repo := &auditLogRepository{
+		db: db,
+	}
+	db.SetAuditRecorder(repo)
+	return repo
+}
+
+// Record 写入数据变更记录
+func (r *auditLogRepository) Record(ctx context.Context, records []*plugin.AuditRecord) error {
+	logs := make([]*entity.AuditLog, 0, len(records))
+	for _, record := range records {
+		changes, err := json.Marshal(record.Changes)
+		if err != nil {
+			return err
+		}
+		logs = append(logs, &entity.AuditLog{
+			RequestID:          record.RequestID,
+			TenantID:           record.TenantID,
+			EntityType:         record.EntityType,
+			EntityID:           record.EntityID,
+			Action:             record.Action,
+			ActorID:            record.ActorID,
+			ActorName:          record.ActorName,
+			ImpersonatedUserID: record.ImpersonatedUserID,
+			Changes:            string(changes),
+			BaseIntTime: database.BaseIntTime{
+				CreatedAt: record.CreatedAt.Unix(),
+			},
+		})
+	}
+	return r.db.DB(ctx).Create(logs).Error
+}
+
+// Find 查询数据变更记录
+func (r *auditLogRepository) Find(ctx context.Context, qb *db_query.QueryBuilder) ([]*entity.AuditLog, error) {
+	var entities []*entity.AuditLog
+	db := r.db.DB(ctx).Model(&entity.AuditLog{})
+
+	if where, values := qb.BuildWhere(); where != "" {
+		db = db.Where(where, values...)
+	}
+	if orderBy := qb.BuildOrderBy(); orderBy != "" {
+		db = db.Order(orderBy)
+	}
+	if limit, values := qb.BuildLimit(); limit != "" {
+		db = db.Limit(values[0]).Offset(values[1])
+	}
+
+	if err := db.Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// Count 统计数量
+func (r *auditLogRepository) Count(ctx context.Context, qb *db_query.QueryBuilder) (int64, error) {
+	var count int64
+	db := r.db.DB(ctx).Model(&entity.AuditLog{})
+
+	if where, values := qb.BuildWhere(); where != "" {
+		db = db.Where(where, values...)
+	}
+	if err := db.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -3,8 +3,11 @@ package repository
 import (
 	"context"
 	"errors"
+	derrors "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/mapper"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/plugin"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/h_redis"
 	"github.com/redis/go-redis/v9"
 	"time"
@@ -27,7 +30,23 @@ func NewAuthRepository(userRepo repository.IUserRepository, rdb *h_redis.RedisCl
 }
 
 func (r *authRepository) FindByUsername(ctx context.Context, username string) (*model.Auth, error) {
+	// 用户名仅在租户内唯一, 未解析出租户时需确认用户名不存在歧义
+	if plugin.GetCtxTenantID(ctx) == "" {
+		count, err := r.userRepo.CountByUsername(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		if count > 1 {
+			return nil, derrors.ErrTenantRequired
+		}
+	}
 	user, err := r.userRepo.FindByUsername(ctx, username)
+	if err != nil && errors.Is(err, database.ErrRecordNotFound) {
+		// 当前租户下不存在时, 查找加入了当前租户的成员
+		if tenantID := plugin.GetCtxTenantID(ctx); tenantID != "" {
+			user, err = r.userRepo.FindMemberByUsername(ctx, tenantID, username)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -53,6 +72,29 @@ func (r *authRepository) ValidateCaptcha(ctx context.Context, key, code string)
 	return storedCode == code, nil
 }
 
+func (r *authRepository) SaveVerifyCode(ctx context.Context, key, code string, expiration, interval time.Duration) (bool, error) {
+	ok, err := r.rdb.SetNX(ctx, "verify_lock:"+key, 1, interval).Result()
+	if err != nil || !ok {
+		return false, err
+	}
+	if err := r.rdb.Set(ctx, "verify:"+key, code, expiration).Err(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *authRepository) ValidateVerifyCode(ctx context.Context, key, code string) (bool, error) {
+	// 读取的同时删除验证码, 每个验证码只能校验一次, 防止并发请求重复使用或暴力尝试
+	storedCode, err := r.rdb.GetDel(ctx, "verify:"+key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, nil
+		}
+		return false, err
+	}
+	return storedCode == code, nil
+}
+
 func (r *authRepository) FindByUserID(ctx context.Context, userID string) (*model.Auth, error) {
 	user, err := r.userRepo.FindByID(ctx, userID)
 	if err != nil {
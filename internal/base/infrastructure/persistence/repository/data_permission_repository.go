@@ -2,7 +2,6 @@ package repository
 
 import (
 	"context"
-	"encoding/json"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
@@ -68,12 +67,17 @@ func (r *dataPermissionRepository) Save(ctx context.Context, perm *model.DataPer
 	if len(perm.DeptIDs) > 0 {
 		deptIds = strings.Join(perm.DeptIDs, ",")
 	}
+	positionIds := ""
+	if len(perm.PositionIDs) > 0 {
+		positionIds = strings.Join(perm.PositionIDs, ",")
+	}
 
 	e := &entity.DataPermission{
-		RoleID:   perm.RoleID,
-		Scope:    int8(perm.Scope),
-		DeptIDs:  deptIds,
-		TenantID: perm.TenantID,
+		RoleID:      perm.RoleID,
+		Scope:       int8(perm.Scope),
+		DeptIDs:     deptIds,
+		PositionIDs: positionIds,
+		TenantID:    perm.TenantID,
 	}
 
 	return r.repo.Save(ctx, e)
@@ -86,16 +90,20 @@ func (r *dataPermissionRepository) DeleteByRoleID(ctx context.Context, roleID in
 
 // toDomain 将实体转换为领域模型
 func (r *dataPermissionRepository) toDomain(e *entity.DataPermission) (*model.DataPermission, error) {
-	var deptIDs []string
-	if err := json.Unmarshal([]byte(e.DeptIDs), &deptIDs); err != nil {
-		return nil, err
-	}
-
 	return &model.DataPermission{
-		ID:       e.ID,
-		RoleID:   e.RoleID,
-		Scope:    model.DataScope(e.Scope),
-		DeptIDs:  deptIDs,
-		TenantID: e.TenantID,
+		ID:          e.ID,
+		RoleID:      e.RoleID,
+		Scope:       model.DataScope(e.Scope),
+		DeptIDs:     splitIDs(e.DeptIDs),
+		PositionIDs: splitIDs(e.PositionIDs),
+		TenantID:    e.TenantID,
 	}, nil
 }
+
+// splitIDs 拆分逗号分隔的ID列表
+func splitIDs(ids string) []string {
+	if ids == "" {
+		return []string{}
+	}
+	return strings.Split(ids, ",")
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"sort"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
 	drepository "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
@@ -14,13 +15,31 @@ import (
 type ISysDepartmentRepo interface {
 	baserepo.IBaseRepo[entity.Department, string]
 	GetByCode(ctx context.Context, code string) (*entity.Department, error)
+	GetByCodes(ctx context.Context, codes []string) ([]*entity.Department, error)
 	GetByParentID(ctx context.Context, parentID string) ([]*entity.Department, error)
 	GetByUserID(ctx context.Context, userID string) ([]*entity.UserDepartment, error)
+	GetByUserIDs(ctx context.Context, userIDs []string) ([]*entity.UserDepartment, error)
 	GetDeptByUserID(ctx context.Context, userID string) ([]*entity.Department, error)
 	FindByIds(ctx context.Context, ids []string) ([]*entity.Department, error)
 	AssignUsers(ctx context.Context, deptID string, userIDs []string) error
 	RemoveUsers(ctx context.Context, deptID string, userIDs []string) error
 	TransferUser(ctx context.Context, userID string, fromDeptID string, toDeptID string) error
+	SetPrimary(ctx context.Context, userID string, deptID string) error
+	Create(ctx context.Context, dept *entity.Department) error
+	Move(ctx context.Context, id string, parentID string) error
+	GetDescendants(ctx context.Context, id string) ([]*entity.Department, error)
+	GetDescendantIDs(ctx context.Context, ids []string) ([]string, error)
+	GetAncestors(ctx context.Context, id string) ([]*entity.Department, error)
+	CountSubtreeUsers(ctx context.Context, id string) (int64, error)
+	CountUsersGroupByDept(ctx context.Context) (map[string]int64, error)
+	Merge(ctx context.Context, sourceID string, targetID string) (*MergeResult, error)
+}
+
+// MergeResult 部门合并结果
+type MergeResult struct {
+	UserIDs  []string
+	ChildIDs []string
+	RoleIDs  []int64
 }
 
 type departmentRepository struct {
@@ -38,8 +57,11 @@ func NewDepartmentRepository(repo ISysDepartmentRepo) drepository.IDepartmentRep
 func (r *departmentRepository) Create(ctx context.Context, dept *model.Department) error {
 	deptEntity := r.mapper.ToEntity(dept)
 	deptEntity.ID = r.repo.GenStringId()
-	_, err := r.repo.Add(ctx, deptEntity)
-	return err
+	if err := r.repo.Create(ctx, deptEntity); err != nil {
+		return err
+	}
+	dept.ID = deptEntity.ID
+	return nil
 }
 
 func (r *departmentRepository) Update(ctx context.Context, dept *model.Department) error {
@@ -91,29 +113,85 @@ func (r *departmentRepository) FindByCode(ctx context.Context, code string) (*mo
 	return r.mapper.ToDomain(dept), nil
 }
 
-// GetTreeByParentID 获取指定父部门下的部门树
-func (r *departmentRepository) GetTreeByParentID(ctx context.Context, parentID string) ([]*model.Department, error) {
-	// 1. 获取直接子部门
-	children, err := r.repo.GetByParentID(ctx, parentID)
+// FindByCodes 根据编码批量查询部门
+func (r *departmentRepository) FindByCodes(ctx context.Context, codes []string) ([]*model.Department, error) {
+	depts, err := r.repo.GetByCodes(ctx, codes)
 	if err != nil {
 		return nil, err
 	}
+	return r.mapper.ToDomainList(depts), nil
+}
 
-	// 转换为领域模型
-	domainChildren := r.mapper.ToDomainList(children)
+// GetTreeByParentID 获取指定父部门下的部门树, 父部门为空时返回全部部门树
+func (r *departmentRepository) GetTreeByParentID(ctx context.Context, parentID string) ([]*model.Department, error) {
+	// 1. 按层级路径一次查出全部下级部门
+	descendants, err := r.repo.GetDescendants(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
 
-	// 2. 递归获取每个子部门的子部门
-	for _, child := range domainChildren {
-		subChildren, err := r.GetTreeByParentID(ctx, child.ID)
-		if err != nil {
-			return nil, err
+	// 2. 按父部门关系组装部门树
+	depts := r.mapper.ToDomainList(descendants)
+	deptMap := make(map[string]*model.Department, len(depts))
+	for _, dept := range depts {
+		deptMap[dept.ID] = dept
+	}
+	roots := make([]*model.Department, 0)
+	for _, dept := range sortBySequence(depts) {
+		if parent, ok := deptMap[dept.ParentID]; ok && dept.ParentID != parentID {
+			parent.AddChild(dept)
+			continue
 		}
-		for _, subChild := range subChildren {
-			child.AddChild(subChild)
+		if dept.ParentID == parentID {
+			roots = append(roots, dept)
 		}
 	}
+	return roots, nil
+}
 
-	return domainChildren, nil
+// GetDescendantIDs 获取部门及其全部下级部门ID
+func (r *departmentRepository) GetDescendantIDs(ctx context.Context, ids []string) ([]string, error) {
+	return r.repo.GetDescendantIDs(ctx, ids)
+}
+
+// GetAncestors 获取部门的全部上级部门, 从根部门开始
+func (r *departmentRepository) GetAncestors(ctx context.Context, id string) ([]*model.Department, error) {
+	depts, err := r.repo.GetAncestors(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.mapper.ToDomainList(depts), nil
+}
+
+// CountSubtreeUsers 统计部门及其全部下级部门的用户数
+func (r *departmentRepository) CountSubtreeUsers(ctx context.Context, id string) (int64, error) {
+	return r.repo.CountSubtreeUsers(ctx, id)
+}
+
+// Move 移动部门, 同时更新下级部门的层级路径
+func (r *departmentRepository) Move(ctx context.Context, id string, parentID string) error {
+	return r.repo.Move(ctx, id, parentID)
+}
+
+// Merge 合并部门
+func (r *departmentRepository) Merge(ctx context.Context, sourceID string, targetID string) (*model.DepartmentMergeResult, error) {
+	result, err := r.repo.Merge(ctx, sourceID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.DepartmentMergeResult{
+		UserIDs:  result.UserIDs,
+		ChildIDs: result.ChildIDs,
+		RoleIDs:  result.RoleIDs,
+	}, nil
+}
+
+// sortBySequence 按显示顺序排序, 顺序相同时保持原有顺序
+func sortBySequence(depts []*model.Department) []*model.Department {
+	sort.SliceStable(depts, func(i, j int) bool {
+		return depts[i].Sequence < depts[j].Sequence
+	})
+	return depts
 }
 
 // TransferUser 调动用户部门
@@ -121,6 +199,28 @@ func (r *departmentRepository) TransferUser(ctx context.Context, userID string,
 	return r.repo.TransferUser(ctx, userID, fromDeptID, toDeptID)
 }
 
+// GetUserDepartments 获取用户所属部门, 主部门在前
+func (r *departmentRepository) GetUserDepartments(ctx context.Context, userID string) ([]*model.UserDepartment, error) {
+	list, err := r.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*model.UserDepartment, 0, len(list))
+	for _, ud := range list {
+		res = append(res, &model.UserDepartment{
+			UserID:    ud.UserID,
+			DeptID:    ud.DeptID,
+			IsPrimary: ud.IsPrimary,
+		})
+	}
+	return res, nil
+}
+
+// SetPrimaryDepartment 设置用户主部门
+func (r *departmentRepository) SetPrimaryDepartment(ctx context.Context, userID string, deptID string) error {
+	return r.repo.SetPrimary(ctx, userID, deptID)
+}
+
 // GetByParentID 获取指定父部门下的直接子部门列表
 func (r *departmentRepository) GetByParentID(ctx context.Context, parentID string) ([]*model.Department, error) {
 	// 获取子部门列表
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	drepository "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/mapper"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+)
+
+type ISysImpersonationRepo interface {
+	baserepo.IBaseRepo[entity.ImpersonationSession, string]
+	UpdateEndedAt(ctx context.Context, id string, endedAt int64) error
+}
+
+type impersonationRepository struct {
+	repo   ISysImpersonationRepo
+	mapper *mapper.ImpersonationMapper
+}
+
+func NewImpersonationRepository(repo ISysImpersonationRepo) drepository.IImpersonationRepository {
+	return &impersonationRepository{
+		repo:   repo,
+		mapper: &mapper.ImpersonationMapper{},
+	}
+}
+
+func (r *impersonationRepository) Create(ctx context.Context, session *model.ImpersonationSession) error {
+	session.ID = r.repo.GenStringId()
+	_, err := r.repo.Add(ctx, r.mapper.ToEntity(session))
+	return err
+}
+
+func (r *impersonationRepository) FindByID(ctx context.Context, id string) (*model.ImpersonationSession, error) {
+	e, err := r.repo.FindById(ctx, id)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.mapper.ToDomain(e), nil
+}
+
+func (r *impersonationRepository) End(ctx context.Context, session *model.ImpersonationSession) error {
+	return r.repo.UpdateEndedAt(ctx, session.ID, session.EndedAt)
+}
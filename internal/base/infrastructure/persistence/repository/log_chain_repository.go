@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ILogChainRepo 日志哈希链的链尾
+type ILogChainRepo interface {
+	// Append 锁定租户的链尾后调用 fn 写入日志, fn 负责按 head 的序号和哈希链接日志并推进 head
+	Append(ctx context.Context, logType, tenantID string, month time.Time, fn func(tx *gorm.DB, head *entity.LogChainHead) error) error
+	// Head 查询租户的链尾, 不存在时返回 nil
+	Head(ctx context.Context, logType, tenantID string) (*entity.LogChainHead, error)
+	// Heads 查询全部链尾
+	Heads(ctx context.Context) ([]*entity.LogChainHead, error)
+}
+
+type logChainRepository struct {
+	db database.IDataBase
+}
+
+func NewLogChainRepository(db database.IDataBase) ILogChainRepo {
+	// 日志表不区分租户存储, 链尾也保存在公共库
+	if err := db.DB(context.Background()).AutoMigrate(&entity.LogChainHead{}); err != nil {
+		hlog.Fatalf("sync log chain tables to db error: %v", err)
+	}
+	return &logChainRepository{
+		db: db,
+	}
+}
+
+func (r *logChainRepository) Append(ctx context.Context, logType, tenantID string, month time.Time, fn func(tx *gorm.DB, head *entity.LogChainHead) error) error {
+	// 链尾和日志表中的租户ID由调用方指定, 不使用上下文中的租户
+	ctx = actx.BuildIgnoreTenantCtx(ctx)
+	return r.db.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		head, err := r.lockHead(tx, logType, tenantID)
+		if err != nil {
+			return err
+		}
+		if head == nil {
+			// 第一条日志, 并发创建时忽略冲突后重新加锁读取
+			err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.LogChainHead{
+				TenantID:   tenantID,
+				LogType:    logType,
+				StartMonth: month.Format("200601"),
+			}).Error
+			if err != nil {
+				return err
+			}
+			if head, err = r.lockHead(tx, logType, tenantID); err != nil {
+				return err
+			}
+		}
+		seq := head.Seq
+		if err := fn(tx, head); err != nil {
+			return err
+		}
+		if head.Seq == seq {
+			return nil
+		}
+		return tx.Model(&entity.LogChainHead{}).
+			Where("tenant_id = ? AND log_type = ?", tenantID, logType).
+			Updates(map[string]interface{}{
+				"seq":        head.Seq,
+				"hash":       head.Hash,
+				"updated_at": time.Now().Unix(),
+			}).Error
+	})
+}
+
+func (r *logChainRepository) lockHead(tx *gorm.DB, logType, tenantID string) (*entity.LogChainHead, error) {
+	var head entity.LogChainHead
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("tenant_id = ? AND log_type = ?", tenantID, logType).
+		First(&head).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &head, nil
+}
+
+func (r *logChainRepository) Head(ctx context.Context, logType, tenantID string) (*entity.LogChainHead, error) {
+	var head entity.LogChainHead
+	err := r.db.DB(actx.BuildIgnoreTenantCtx(ctx)).
+		Where("tenant_id = ? AND log_type = ?", tenantID, logType).
+		First(&head).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &head, nil
+}
+
+func (r *logChainRepository) Heads(ctx context.Context) ([]*entity.LogChainHead, error) {
+	var heads []*entity.LogChainHead
+	err := r.db.DB(actx.BuildIgnoreTenantCtx(ctx)).
+		Order("tenant_id, log_type").
+		Find(&heads).Error
+	return heads, err
+}
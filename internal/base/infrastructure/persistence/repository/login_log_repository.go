@@ -9,6 +9,7 @@ import (
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hashchain"
 )
 
 type ILoginLogRepo interface {
@@ -16,6 +17,8 @@ type ILoginLogRepo interface {
 	// 动态查询方法
 	Find(ctx context.Context, tenantID string, month time.Time, qb *db_query.QueryBuilder) ([]*entity.LoginLog, error)
 	Count(ctx context.Context, tenantID string, month time.Time, qb *db_query.QueryBuilder) (int64, error)
+	// FindChain 查询月份内序号大于 afterSeq 的哈希链记录, 按序号升序
+	FindChain(ctx context.Context, tenantID string, month time.Time, afterSeq int64, limit int) ([]*hashchain.Entry, error)
 }
 
 type loginLogRepository struct {
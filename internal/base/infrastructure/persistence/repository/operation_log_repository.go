@@ -2,30 +2,43 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hashchain"
+	"gorm.io/gorm"
 )
 
 type IOperationLogRepo interface {
 	Create(ctx context.Context, log *entity.OperationLog) error
+	// CreateBatch 批量创建操作日志, 按租户和月份分组, 每组在一个事务中链接到哈希链末尾
+	// 某组写入失败时继续写入其他组, 返回未写入的日志
+	CreateBatch(ctx context.Context, logs []*entity.OperationLog) ([]*entity.OperationLog, error)
 	Find(ctx context.Context, tenantID string, month time.Time, qb *db_query.QueryBuilder) ([]*entity.OperationLog, error)
 	Count(ctx context.Context, tenantID string, month time.Time, qb *db_query.QueryBuilder) (int64, error)
+	// FindChain 查询月份内序号大于 afterSeq 的哈希链记录, 按序号升序
+	FindChain(ctx context.Context, tenantID string, month time.Time, afterSeq int64, limit int) ([]*hashchain.Entry, error)
 }
 type operationLogRepository struct {
-	db database.IDataBase
+	db       database.IDataBase
+	chain    ILogChainRepo
+	migrated sync.Map // 本进程已同步过结构的表
 }
 
-func NewOperationLogRepository(db database.IDataBase) IOperationLogRepo {
+func NewOperationLogRepository(db database.IDataBase, chain ILogChainRepo) IOperationLogRepo {
 	return &operationLogRepository{
-		db: db,
+		db:    db,
+		chain: chain,
 	}
 }
 
-// Create 创建操作日志
+// Create 创建操作日志, 链接到租户操作日志哈希链的末尾
 func (r *operationLogRepository) Create(ctx context.Context, log *entity.OperationLog) error {
 	t := time.Unix(log.CreatedAt, 0)
 	// 确保表存在
@@ -33,7 +46,132 @@ func (r *operationLogRepository) Create(ctx context.Context, log *entity.Operati
 		return err
 	}
 
-	return r.db.DB(ctx).Table(r.GetTableName(log.TenantID, t)).Create(log).Error
+	return r.chain.Append(ctx, entity.LogChainOperation, log.TenantID, t, func(tx *gorm.DB, head *entity.LogChainHead) error {
+		log.Seq = head.Seq + 1
+		log.PrevHash = head.Hash
+		content, err := operationLogContent(log)
+		if err != nil {
+			return err
+		}
+		log.Hash = hashchain.Hash(log.PrevHash, content)
+		if err := tx.Table(r.GetTableName(log.TenantID, t)).Create(log).Error; err != nil {
+			return err
+		}
+		head.Seq, head.Hash = log.Seq, log.Hash
+		return nil
+	})
+}
+
+// CreateBatch 批量创建操作日志, 返回写入失败的日志
+// 各组可能位于不同的租户数据库, 无法在同一事务中写入, 按组提交
+func (r *operationLogRepository) CreateBatch(ctx context.Context, logs []*entity.OperationLog) ([]*entity.OperationLog, error) {
+	type groupKey struct {
+		tenantID string
+		table    string
+	}
+	groups := make(map[groupKey][]*entity.OperationLog)
+	keys := make([]groupKey, 0)
+	for _, log := range logs {
+		key := groupKey{tenantID: log.TenantID, table: r.GetTableName(log.TenantID, time.Unix(log.CreatedAt, 0))}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], log)
+	}
+
+	var failed []*entity.OperationLog
+	var errs []error
+	for _, key := range keys {
+		group := groups[key]
+		if err := r.createGroup(ctx, key.tenantID, key.table, group); err != nil {
+			failed = append(failed, group...)
+			errs = append(errs, fmt.Errorf("write %d operation logs to %s: %w", len(group), key.table, err))
+		}
+	}
+	return failed, errors.Join(errs...)
+}
+
+// createGroup 在一个事务中写入同一租户、同一月份的日志
+func (r *operationLogRepository) createGroup(ctx context.Context, tenantID, table string, group []*entity.OperationLog) error {
+	t := time.Unix(group[0].CreatedAt, 0)
+	if err := r.EnsureTable(ctx, tenantID, t); err != nil {
+		return err
+	}
+	return r.chain.Append(ctx, entity.LogChainOperation, tenantID, t, func(tx *gorm.DB, head *entity.LogChainHead) error {
+		seq, prev := head.Seq, head.Hash
+		for _, log := range group {
+			seq++
+			log.Seq = seq
+			log.PrevHash = prev
+			content, err := operationLogContent(log)
+			if err != nil {
+				return err
+			}
+			log.Hash = hashchain.Hash(log.PrevHash, content)
+			prev = log.Hash
+		}
+		if err := tx.Table(table).CreateInBatches(group, len(group)).Error; err != nil {
+			return err
+		}
+		head.Seq, head.Hash = seq, prev
+		return nil
+	})
+}
+
+// FindChain 查询哈希链记录, 表不存在时返回空
+func (r *operationLogRepository) FindChain(ctx context.Context, tenantID string, month time.Time, afterSeq int64, limit int) ([]*hashchain.Entry, error) {
+	tableName := r.GetTableName(tenantID, month)
+	db := r.db.DB(actx.BuildIgnoreTenantCtx(ctx))
+	if !db.Migrator().HasTable(tableName) {
+		return nil, nil
+	}
+	var logs []*entity.OperationLog
+	err := db.Table(tableName).
+		Where("seq > ?", afterSeq).
+		Order("seq").
+		Limit(limit).
+		Find(&logs).Error
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*hashchain.Entry, 0, len(logs))
+	for _, log := range logs {
+		content, err := operationLogContent(log)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &hashchain.Entry{ID: log.ID, Seq: log.Seq, PrevHash: log.PrevHash, Hash: log.Hash, Content: content})
+	}
+	return entries, nil
+}
+
+// operationLogContent 操作日志的规范形式, 字段顺序不可调整
+func operationLogContent(log *entity.OperationLog) ([]byte, error) {
+	return hashchain.Canonical(struct {
+		Seq                  int64  `json:"seq"`
+		TenantID             string `json:"tenant_id"`
+		RequestID            string `json:"request_id"`
+		UserID               string `json:"user_id"`
+		Username             string `json:"username"`
+		Method               string `json:"method"`
+		Path                 string `json:"path"`
+		Query                string `json:"query"`
+		Body                 string `json:"body"`
+		IP                   string `json:"ip"`
+		UserAgent            string `json:"user_agent"`
+		Status               int    `json:"status"`
+		Error                string `json:"error"`
+		Duration             int64  `json:"duration"`
+		Module               string `json:"module"`
+		Action               string `json:"action"`
+		ImpersonatedUserID   string `json:"impersonated_user_id"`
+		ImpersonatedUsername string `json:"impersonated_username"`
+		CreatedAt            int64  `json:"created_at"`
+	}{
+		log.Seq, log.TenantID, log.RequestID, log.UserID, log.Username, log.Method, log.Path, log.Query, log.Body,
+		log.IP, log.UserAgent, log.Status, log.Error, log.Duration, log.Module, log.Action,
+		log.ImpersonatedUserID, log.ImpersonatedUsername, log.CreatedAt,
+	})
 }
 
 // Find 查询操作日志列表
@@ -57,8 +195,8 @@ func (r *operationLogRepository) Find(ctx context.Context, tenantID string, mont
 	}
 
 	// 添加分页
-	if limit, offset := qb.BuildLimit(); limit != "" {
-		db = db.Limit(offset[1]).Offset(offset[0])
+	if limit, values := qb.BuildLimit(); limit != "" {
+		db = db.Limit(values[0]).Offset(values[1])
 	}
 
 	// 执行查询
@@ -91,12 +229,11 @@ func (r *operationLogRepository) Count(ctx context.Context, tenantID string, mon
 	return count, nil
 }
 
-// EnsureTable 确保表存在
+// EnsureTable 确保表存在且结构与实体一致
+// 按月分表在首次写入时创建, 已存在的表在本进程首次访问时同步一次, 以补充新增的字段
 func (r *operationLogRepository) EnsureTable(ctx context.Context, tenantID string, month time.Time) error {
 	tableName := r.GetTableName(tenantID, month)
-
-	// 检查表是否存在
-	if r.db.DB(ctx).Migrator().HasTable(tableName) {
+	if _, ok := r.migrated.Load(tableName); ok {
 		return nil
 	}
 
@@ -106,7 +243,11 @@ func (r *operationLogRepository) EnsureTable(ctx context.Context, tenantID strin
 	}
 
 	// 使用 GORM 自动迁移创建表
-	return r.db.DB(ctx).Table(tableName).AutoMigrate(&OperationLogTable{})
+	if err := r.db.DB(ctx).Table(tableName).AutoMigrate(&OperationLogTable{}); err != nil {
+		return err
+	}
+	r.migrated.Store(tableName, true)
+	return nil
 }
 
 // GetTableName 获取表名
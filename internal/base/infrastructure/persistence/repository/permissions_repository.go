@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/mapper"
+	"time"
 
 	drepository "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
 
@@ -52,6 +53,7 @@ func (r *permissionsRepository) Create(ctx context.Context, permissions *model.P
 		if err != nil {
 			return err
 		}
+		permissions.ID = dm.ID
 
 		// 创建权限资源
 		if len(resources) > 0 {
@@ -138,3 +140,83 @@ func (r *permissionsRepository) FindByID(ctx context.Context, id int64) (*model.
 
 	return r.mapper.ToDomain(permEntity, resource), nil
 }
+
+func (r *permissionsRepository) FindAll(ctx context.Context) ([]*model.Permissions, error) {
+	var perms []*entity.Permissions
+	if err := r.repo.Db(ctx).Order("sequence desc").Find(&perms).Error; err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(perms))
+	for _, p := range perms {
+		ids = append(ids, p.ID)
+	}
+	resources := make(map[int64][]*entity.PermissionsResource)
+	if len(ids) > 0 {
+		rs, err := r.repo.GetResourceByPermissionsIds(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, res := range rs {
+			resources[res.PermissionsID] = append(resources[res.PermissionsID], res)
+		}
+	}
+	result := make([]*model.Permissions, 0, len(perms))
+	for _, p := range perms {
+		result = append(result, r.mapper.ToDomain(p, resources[p.ID]))
+	}
+	return result, nil
+}
+
+// permissionSeedColumns 种子可以声明的列, 更新时零值也要写入
+var permissionSeedColumns = []string{"name", "localize", "icon", "description", "sequence", "type", "path", "properties", "status", "parent_id", "updated_at"}
+
+func (r *permissionsRepository) ApplySeed(ctx context.Context, diff *model.PermissionSeedDiff, prune bool) error {
+	return r.repo.GetDb().InTx(ctx, func(ctx context.Context) error {
+		// 编码到ID, 新增项的父级可能是已有权限或本次新增的权限
+		var rows []*entity.Permissions
+		if err := r.repo.Db(ctx).Select("id", "code").Find(&rows).Error; err != nil {
+			return err
+		}
+		ids := make(map[string]int64, len(rows))
+		for _, row := range rows {
+			ids[row.Code] = row.ID
+		}
+
+		for _, c := range diff.Added {
+			c.Target.ParentID = ids[c.ParentCode]
+			if err := r.Create(ctx, c.Target); err != nil {
+				return err
+			}
+			ids[c.Code] = c.Target.ID
+		}
+
+		for _, c := range diff.Changed {
+			c.Target.ID = c.Current.ID
+			c.Target.ParentID = ids[c.ParentCode]
+			permEntity, resources := r.mapper.ToEntity(c.Target)
+			permEntity.UpdatedAt = time.Now().Unix()
+			if err := r.repo.Db(ctx).Model(&entity.Permissions{}).Where("id = ?", permEntity.ID).
+				Select(permissionSeedColumns).Updates(permEntity).Error; err != nil {
+				return err
+			}
+			if err := r.repo.DelByPermissionsId(ctx, permEntity.ID); err != nil {
+				return err
+			}
+			for _, resource := range resources {
+				resource.PermissionsID = permEntity.ID
+				if err := r.repo.SavePermissionsResource(ctx, resource); err != nil {
+					return err
+				}
+			}
+		}
+
+		if prune {
+			for _, c := range diff.Removed {
+				if err := r.Delete(ctx, c.Current.ID); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	drepository "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/mapper"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+)
+
+type ISysPositionRepo interface {
+	baserepo.IBaseRepo[entity.Position, string]
+	GetByCode(ctx context.Context, code string) (*entity.Position, error)
+	GetByUserID(ctx context.Context, userID string) ([]*entity.UserPosition, error)
+	GetUserIDsByPositionIDs(ctx context.Context, positionIDs []string) ([]string, error)
+	CountUsers(ctx context.Context, positionID string) (int64, error)
+	SetUserPositions(ctx context.Context, userID string, list []*entity.UserPosition) error
+}
+
+type positionRepository struct {
+	repo   ISysPositionRepo
+	mapper *mapper.PositionMapper
+}
+
+func NewPositionRepository(repo ISysPositionRepo) drepository.IPositionRepository {
+	return &positionRepository{
+		repo:   repo,
+		mapper: &mapper.PositionMapper{},
+	}
+}
+
+func (r *positionRepository) Create(ctx context.Context, position *model.Position) error {
+	positionEntity := r.mapper.ToEntity(position)
+	positionEntity.ID = r.repo.GenStringId()
+	if _, err := r.repo.Add(ctx, positionEntity); err != nil {
+		return err
+	}
+	position.ID = positionEntity.ID
+	return nil
+}
+
+func (r *positionRepository) Update(ctx context.Context, position *model.Position) error {
+	positionEntity := r.mapper.ToEntity(position)
+	return r.repo.EditById(ctx, positionEntity.ID, positionEntity)
+}
+
+func (r *positionRepository) Delete(ctx context.Context, id string) error {
+	return r.repo.DelByIdUnScoped(ctx, id)
+}
+
+// FindByID 根据ID查询岗位
+func (r *positionRepository) FindByID(ctx context.Context, id string) (*model.Position, error) {
+	position, err := r.repo.FindById(ctx, id)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.mapper.ToDomain(position), nil
+}
+
+// FindByIDs 根据ID列表查询岗位
+func (r *positionRepository) FindByIDs(ctx context.Context, ids []string) ([]*model.Position, error) {
+	if len(ids) == 0 {
+		return make([]*model.Position, 0), nil
+	}
+	positions, err := r.repo.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return r.mapper.ToDomainList(positions), nil
+}
+
+// ExistsByCode 检查岗位编码是否存在
+func (r *positionRepository) ExistsByCode(ctx context.Context, code string) (bool, error) {
+	position, err := r.repo.GetByCode(ctx, code)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return position != nil, nil
+}
+
+// HasUsers 岗位是否仍有任职人员
+func (r *positionRepository) HasUsers(ctx context.Context, id string) (bool, error) {
+	count, err := r.repo.CountUsers(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetUserPositions 获取用户任职岗位
+func (r *positionRepository) GetUserPositions(ctx context.Context, userID string) ([]*model.UserPosition, error) {
+	list, err := r.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.mapper.ToUserPositionDomainList(list), nil
+}
+
+// SetUserPositions 替换用户的任职岗位
+func (r *positionRepository) SetUserPositions(ctx context.Context, userID string, positions []*model.UserPosition) error {
+	list := make([]*entity.UserPosition, 0, len(positions))
+	for _, up := range positions {
+		list = append(list, &entity.UserPosition{
+			UserID:     userID,
+			PositionID: up.PositionID,
+			IsPrimary:  up.IsPrimary,
+		})
+	}
+	return r.repo.SetUserPositions(ctx, userID, list)
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	drepository "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/mapper"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+)
+
+type ISysRecycleBinRepo interface {
+	baserepo.IBaseRepo[entity.RecycleBin, string]
+	// RecycleUser 用户及其角色、部门关系移入回收站
+	RecycleUser(ctx context.Context, id string, deletedBy string) error
+	// RecycleRole 角色及其权限、用户关系移入回收站
+	RecycleRole(ctx context.Context, id int64, deletedBy string) error
+	// RecycleDepartment 部门及其成员关系移入回收站
+	RecycleDepartment(ctx context.Context, id string, deletedBy string) error
+	// Restore 按快照恢复实体和关联关系, 并删除回收站记录, 用户角色只恢复 userRoles 中的部分
+	Restore(ctx context.Context, id string, userRoles []*entity.SysUserRole) error
+	// GetUserRoles 恢复时会写入的用户角色及涉及的角色
+	GetUserRoles(ctx context.Context, id string) ([]*entity.SysUserRole, []*entity.Role, error)
+	GetByEntity(ctx context.Context, entityType, entityID string) (*entity.RecycleBin, error)
+	ListExpired(ctx context.Context, before int64, limit int) ([]*entity.RecycleBin, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type recycleBinRepository struct {
+	repo   ISysRecycleBinRepo
+	mapper *mapper.RecycleBinMapper
+}
+
+func NewRecycleBinRepository(repo ISysRecycleBinRepo) drepository.IRecycleBinRepository {
+	return &recycleBinRepository{
+		repo:   repo,
+		mapper: &mapper.RecycleBinMapper{},
+	}
+}
+
+func (r *recycleBinRepository) Recycle(ctx context.Context, entityType, entityID, deletedBy string) error {
+	switch entityType {
+	case model.RecycleEntityUser:
+		return r.repo.RecycleUser(ctx, entityID, deletedBy)
+	case model.RecycleEntityRole:
+		id, err := strconv.ParseInt(entityID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid role id: %s", entityID)
+		}
+		return r.repo.RecycleRole(ctx, id, deletedBy)
+	case model.RecycleEntityDepartment:
+		return r.repo.RecycleDepartment(ctx, entityID, deletedBy)
+	}
+	return fmt.Errorf("unsupported recycle entity type: %s", entityType)
+}
+
+func (r *recycleBinRepository) Restore(ctx context.Context, item *model.RecycleItem, grants []*model.RecycleRoleGrant) error {
+	userRoles := make([]*entity.SysUserRole, 0, len(grants))
+	for _, grant := range grants {
+		userRoles = append(userRoles, &entity.SysUserRole{UserID: grant.UserID, RoleID: grant.RoleID})
+	}
+	return r.repo.Restore(ctx, item.ID, userRoles)
+}
+
+func (r *recycleBinRepository) FindRoleGrants(ctx context.Context, item *model.RecycleItem) ([]*model.RecycleRoleGrant, error) {
+	userRoles, roles, err := r.repo.GetUserRoles(ctx, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	approval := make(map[int64]bool, len(roles))
+	for _, role := range roles {
+		approval[role.ID] = role.RequiresApproval
+	}
+	grants := make([]*model.RecycleRoleGrant, 0, len(userRoles))
+	for _, ur := range userRoles {
+		grants = append(grants, &model.RecycleRoleGrant{UserID: ur.UserID, RoleID: ur.RoleID, RequiresApproval: approval[ur.RoleID]})
+	}
+	return grants, nil
+}
+
+func (r *recycleBinRepository) FindByEntity(ctx context.Context, entityType, entityID string) (*model.RecycleItem, error) {
+	e, err := r.repo.GetByEntity(ctx, entityType, entityID)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.mapper.ToDomain(e), nil
+}
+
+func (r *recycleBinRepository) FindExpired(ctx context.Context, before int64, limit int) ([]*model.RecycleItem, error) {
+	list, err := r.repo.ListExpired(ctx, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.mapper.ToDomainList(list), nil
+}
+
+func (r *recycleBinRepository) Delete(ctx context.Context, id string) error {
+	return r.repo.Delete(ctx, id)
+}
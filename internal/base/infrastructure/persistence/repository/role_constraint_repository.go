@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	drepository "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/mapper"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+)
+
+type ISysRoleConstraintRepo interface {
+	baserepo.IBaseRepo[entity.RoleConstraint, int64]
+	UpdateConstraint(ctx context.Context, e *entity.RoleConstraint) error
+	ListAll(ctx context.Context) ([]*entity.RoleConstraint, error)
+	ListUserRolesByRoleIDs(ctx context.Context, roleIDs []int64) ([]*entity.SysUserRole, error)
+}
+
+type roleConstraintRepository struct {
+	repo        ISysRoleConstraintRepo
+	mapper      *mapper.RoleConstraintMapper
+	grantMapper *mapper.RoleGrantMapper
+}
+
+func NewRoleConstraintRepository(repo ISysRoleConstraintRepo) drepository.IRoleConstraintRepository {
+	return &roleConstraintRepository{
+		repo:        repo,
+		mapper:      &mapper.RoleConstraintMapper{},
+		grantMapper: &mapper.RoleGrantMapper{},
+	}
+}
+
+func (r *roleConstraintRepository) Create(ctx context.Context, constraint *model.RoleConstraint) error {
+	e := r.mapper.ToEntity(constraint)
+	if _, err := r.repo.Add(ctx, e); err != nil {
+		return err
+	}
+	constraint.ID = e.ID
+	return nil
+}
+
+func (r *roleConstraintRepository) Update(ctx context.Context, constraint *model.RoleConstraint) error {
+	return r.repo.UpdateConstraint(ctx, r.mapper.ToEntity(constraint))
+}
+
+func (r *roleConstraintRepository) Delete(ctx context.Context, id int64) error {
+	return r.repo.DelById(ctx, id)
+}
+
+func (r *roleConstraintRepository) FindByID(ctx context.Context, id int64) (*model.RoleConstraint, error) {
+	e, err := r.repo.FindById(ctx, id)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.mapper.ToDomain(e), nil
+}
+
+func (r *roleConstraintRepository) FindAll(ctx context.Context) ([]*model.RoleConstraint, error) {
+	list, err := r.repo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.mapper.ToDomainList(list), nil
+}
+
+func (r *roleConstraintRepository) FindGrantsByRoles(ctx context.Context, roleIDs []int64) ([]*model.RoleGrant, error) {
+	list, err := r.repo.ListUserRolesByRoleIDs(ctx, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+	return r.grantMapper.ToGrantList(list), nil
+}
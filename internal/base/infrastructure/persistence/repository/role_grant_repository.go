@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	drepository "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/mapper"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+)
+
+type ISysRoleGrantRepo interface {
+	baserepo.IBaseRepo[entity.RoleRequest, string]
+	GrantUserRole(ctx context.Context, userRole *entity.SysUserRole) error
+	RevokeUserRole(ctx context.Context, userID string, roleID int64) error
+	ListUserRoles(ctx context.Context, userID string) ([]*entity.SysUserRole, error)
+	ListExpiredUserRoles(ctx context.Context, now int64, limit int) ([]*entity.SysUserRole, error)
+	DeleteUserRoles(ctx context.Context, ids []int64) error
+	CreateRequest(ctx context.Context, req *entity.RoleRequest) error
+	UpdateRequestReview(ctx context.Context, req *entity.RoleRequest) error
+	GetRequest(ctx context.Context, id string) (*entity.RoleRequest, error)
+	CountRequests(ctx context.Context, userID string, roleID int64, status int8) (int64, error)
+}
+
+type roleGrantRepository struct {
+	repo   ISysRoleGrantRepo
+	mapper *mapper.RoleGrantMapper
+}
+
+func NewRoleGrantRepository(repo ISysRoleGrantRepo) drepository.IRoleGrantRepository {
+	return &roleGrantRepository{
+		repo:   repo,
+		mapper: &mapper.RoleGrantMapper{},
+	}
+}
+
+func (r *roleGrantRepository) Grant(ctx context.Context, grant *model.RoleGrant) error {
+	e := r.mapper.ToUserRole(grant)
+	if err := r.repo.GrantUserRole(ctx, e); err != nil {
+		return err
+	}
+	grant.ID = e.ID
+	return nil
+}
+
+func (r *roleGrantRepository) Revoke(ctx context.Context, userID string, roleID int64) error {
+	return r.repo.RevokeUserRole(ctx, userID, roleID)
+}
+
+func (r *roleGrantRepository) FindByUser(ctx context.Context, userID string) ([]*model.RoleGrant, error) {
+	list, err := r.repo.ListUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.mapper.ToGrantList(list), nil
+}
+
+func (r *roleGrantRepository) FindExpired(ctx context.Context, now int64, limit int) ([]*model.RoleGrant, error) {
+	list, err := r.repo.ListExpiredUserRoles(ctx, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.mapper.ToGrantList(list), nil
+}
+
+func (r *roleGrantRepository) DeleteByIDs(ctx context.Context, ids []int64) error {
+	return r.repo.DeleteUserRoles(ctx, ids)
+}
+
+func (r *roleGrantRepository) CreateRequest(ctx context.Context, req *model.RoleRequest) error {
+	e := r.mapper.ToRequestEntity(req)
+	e.ID = r.repo.GenStringId()
+	if err := r.repo.CreateRequest(ctx, e); err != nil {
+		return err
+	}
+	req.ID = e.ID
+	return nil
+}
+
+func (r *roleGrantRepository) UpdateRequest(ctx context.Context, req *model.RoleRequest) error {
+	return r.repo.UpdateRequestReview(ctx, r.mapper.ToRequestEntity(req))
+}
+
+func (r *roleGrantRepository) ApproveRequest(ctx context.Context, req *model.RoleRequest, grant *model.RoleGrant) error {
+	return r.repo.GetDb().InTx(ctx, func(ctx context.Context) error {
+		if err := r.repo.UpdateRequestReview(ctx, r.mapper.ToRequestEntity(req)); err != nil {
+			return err
+		}
+		return r.Grant(ctx, grant)
+	})
+}
+
+func (r *roleGrantRepository) FindRequestByID(ctx context.Context, id string) (*model.RoleRequest, error) {
+	e, err := r.repo.GetRequest(ctx, id)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.mapper.ToRequest(e), nil
+}
+
+func (r *roleGrantRepository) ExistsPendingRequest(ctx context.Context, userID string, roleID int64) (bool, error) {
+	count, err := r.repo.CountRequests(ctx, userID, roleID, model.RoleRequestPending)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -16,12 +16,14 @@ import (
 type ISysRoleRepo interface {
 	baserepo.IBaseRepo[entity.Role, int64]
 	GetByCode(ctx context.Context, code string) (*entity.Role, error)
+	GetByCodes(ctx context.Context, codes []string) ([]*entity.Role, error)
 	GetByRoleId(ctx context.Context, roleId int64) ([]*entity.RolePermissions, error)
 	DeletePermissionsByRoleId(ctx context.Context, roleId int64) error
 	GetByUserId(ctx context.Context, userId string) ([]*entity.Role, error)
 	GetUserRoles(ctx context.Context, userId string) ([]*entity.SysUserRole, error)
 	FindByIds(ctx context.Context, ids []int64) ([]*entity.Role, error)
 	FindAllEnabled(ctx context.Context) ([]*entity.Role, error)
+	FindEnabledByTenant(ctx context.Context, tenantID string) ([]*entity.Role, error)
 	Find(ctx context.Context, qb *db_query.QueryBuilder) ([]*entity.Role, error)
 	UpdatePermissions(ctx context.Context, roleID int64, permIDs []int64) error
 	GetRolePermissions(ctx context.Context, roleID int64) ([]*entity.Permissions, error)
@@ -82,6 +84,12 @@ func (r *roleRepository) Update(ctx context.Context, role *model.Role) error {
 		if err != nil {
 			return err
 		}
+		// EditById 忽略零值, 单独更新审批标记以便关闭
+		err = r.repo.Db(ctx).Model(&entity.Role{}).Where("id = ?", roleEntity.ID).
+			Update("requires_approval", roleEntity.RequiresApproval).Error
+		if err != nil {
+			return err
+		}
 		err = r.repo.DeletePermissionsByRoleId(ctx, roleEntity.ID)
 		if err != nil {
 			return err
@@ -146,6 +154,15 @@ func (r *roleRepository) FindByCode(ctx context.Context, code string) (*model.Ro
 	return r.mapper.ToDomain(roleEntity, nil), nil
 }
 
+// FindByCodes 根据编码批量查询角色
+func (r *roleRepository) FindByCodes(ctx context.Context, codes []string) ([]*model.Role, error) {
+	roles, err := r.repo.GetByCodes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+	return r.mapper.ToDomainList(roles), nil
+}
+
 func (r *roleRepository) ExistsByCode(ctx context.Context, code string) (bool, error) {
 	_, err := r.repo.GetByCode(ctx, code)
 	if err != nil {
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	drepository "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/mapper"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+)
+
+type ISysScimTokenRepo interface {
+	baserepo.IBaseRepo[entity.ScimToken, string]
+	Create(ctx context.Context, token *entity.ScimToken) error
+	Delete(ctx context.Context, tenantID, id string) error
+	GetByID(ctx context.Context, tenantID, id string) (*entity.ScimToken, error)
+	GetByHash(ctx context.Context, hash string) (*entity.ScimToken, error)
+	ListByTenant(ctx context.Context, tenantID string) ([]*entity.ScimToken, error)
+	UpdateLastUsed(ctx context.Context, id string, usedAt int64) error
+}
+
+type scimTokenRepository struct {
+	repo   ISysScimTokenRepo
+	mapper *mapper.ScimTokenMapper
+}
+
+func NewScimTokenRepository(repo ISysScimTokenRepo) drepository.IScimTokenRepository {
+	return &scimTokenRepository{
+		repo:   repo,
+		mapper: &mapper.ScimTokenMapper{},
+	}
+}
+
+func (r *scimTokenRepository) Create(ctx context.Context, token *model.ScimToken) error {
+	e := r.mapper.ToEntity(token)
+	e.ID = r.repo.GenStringId()
+	if err := r.repo.Create(ctx, e); err != nil {
+		return err
+	}
+	token.ID = e.ID
+	return nil
+}
+
+func (r *scimTokenRepository) Delete(ctx context.Context, tenantID, id string) error {
+	return r.repo.Delete(ctx, tenantID, id)
+}
+
+func (r *scimTokenRepository) FindByID(ctx context.Context, tenantID, id string) (*model.ScimToken, error) {
+	e, err := r.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.mapper.ToDomain(e), nil
+}
+
+func (r *scimTokenRepository) FindByHash(ctx context.Context, hash string) (*model.ScimToken, error) {
+	e, err := r.repo.GetByHash(ctx, hash)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.mapper.ToDomain(e), nil
+}
+
+func (r *scimTokenRepository) FindByTenant(ctx context.Context, tenantID string) ([]*model.ScimToken, error) {
+	list, err := r.repo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.mapper.ToDomainList(list), nil
+}
+
+func (r *scimTokenRepository) Touch(ctx context.Context, id string, usedAt int64) error {
+	return r.repo.UpdateLastUsed(ctx, id, usedAt)
+}
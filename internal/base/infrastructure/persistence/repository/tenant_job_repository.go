@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	drepository "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/mapper"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+)
+
+type ISysTenantJobRepo interface {
+	baserepo.IBaseRepo[entity.TenantJob, string]
+	Create(ctx context.Context, job *entity.TenantJob) error
+	Update(ctx context.Context, job *entity.TenantJob) error
+	GetByID(ctx context.Context, id string) (*entity.TenantJob, error)
+	ListByTenant(ctx context.Context, tenantID string) ([]*entity.TenantJob, error)
+	ExistsUnfinished(ctx context.Context, tenantID string) (bool, error)
+	FailUnfinished(ctx context.Context, reason string) error
+}
+
+type tenantJobRepository struct {
+	repo   ISysTenantJobRepo
+	mapper *mapper.TenantJobMapper
+}
+
+func NewTenantJobRepository(repo ISysTenantJobRepo) drepository.ITenantJobRepository {
+	return &tenantJobRepository{
+		repo:   repo,
+		mapper: &mapper.TenantJobMapper{},
+	}
+}
+
+func (r *tenantJobRepository) Create(ctx context.Context, job *model.TenantJob) error {
+	e := r.mapper.ToEntity(job)
+	e.ID = r.repo.GenStringId()
+	if err := r.repo.Create(ctx, e); err != nil {
+		return err
+	}
+	job.ID = e.ID
+	return nil
+}
+
+func (r *tenantJobRepository) Update(ctx context.Context, job *model.TenantJob) error {
+	return r.repo.Update(ctx, r.mapper.ToEntity(job))
+}
+
+func (r *tenantJobRepository) FindByID(ctx context.Context, id string) (*model.TenantJob, error) {
+	e, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.mapper.ToDomain(e), nil
+}
+
+func (r *tenantJobRepository) ExistsUnfinished(ctx context.Context, tenantID string) (bool, error) {
+	return r.repo.ExistsUnfinished(ctx, tenantID)
+}
@@ -10,15 +10,21 @@ import (
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
 	drepository "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/tenantdata"
 )
 
 type ISysTenantRepo interface {
 	baserepo.IBaseRepo[entity.Tenant, string]
 	Update(ctx context.Context, tenant *entity.Tenant) error
 	DeleteWithRelations(ctx context.Context, id string) error // 删除租户及关联数据
+	GetByCode(ctx context.Context, code string) (*entity.Tenant, error)
+	GetByDomain(ctx context.Context, domain string) (*entity.Tenant, error)
+	// ListIsolated 获取全部独立Schema/数据库的租户
+	ListIsolated(ctx context.Context) ([]*database.TenantIsolation, error)
 
 	// 权限相关
 	AssignPermissions(ctx context.Context, tenantID string, permissionIDs []int64) error
@@ -26,6 +32,9 @@ type ISysTenantRepo interface {
 	GetTenantIDPermissionsByType(ctx context.Context, tenantID string, int8 int64) ([]*entity.Permissions, error)
 	HasPermission(ctx context.Context, tenantID string, permissionID int64) (bool, error)
 
+	// SetProvisionStatus 更新开通状态及失败原因
+	SetProvisionStatus(ctx context.Context, tenantID string, status int8, reason string) error
+
 	Lock(ctx context.Context, tenantID string, reason string) error
 	Unlock(ctx context.Context, tenantID string) error
 	GetTenantRoles(ctx context.Context, tenantID string) ([]*entity.Role, error)
@@ -34,17 +43,19 @@ type ISysTenantRepo interface {
 type tenantRepository struct {
 	repo       ISysTenantRepo
 	userRepo   ISysUserRepo
+	registry   *tenantdata.Registry
 	mapper     *mapper.TenantMapper
 	userMapper *mapper.UserMapper
 	permMapper *mapper.PermissionsMapper
 }
 
-func NewTenantRepository(repo ISysTenantRepo, userRepo ISysUserRepo) drepository.ITenantRepository {
+func NewTenantRepository(repo ISysTenantRepo, userRepo ISysUserRepo, registry *tenantdata.Registry) drepository.ITenantRepository {
 	userMapper := &mapper.UserMapper{}
 	permMapper := &mapper.PermissionsMapper{}
 	return &tenantRepository{
 		repo:       repo,
 		userRepo:   userRepo,
+		registry:   registry,
 		mapper:     mapper.NewTenantMapper(userMapper),
 		userMapper: userMapper,
 		permMapper: permMapper,
@@ -53,10 +64,15 @@ func NewTenantRepository(repo ISysTenantRepo, userRepo ISysUserRepo) drepository
 
 func (r *tenantRepository) Create(ctx context.Context, tenant *model.Tenant) error {
 	tenantEntity := r.mapper.ToEntity(tenant)
-	return r.repo.GetDb().InTx(ctx, func(ctx context.Context) error {
-		// 生成ID
-		tenantEntity.ID = r.repo.GenStringId()
-
+	// 生成ID
+	tenantEntity.ID = r.repo.GenStringId()
+	tenant.ID = tenantEntity.ID
+	if tenant.IsIsolated() {
+		return r.createIsolated(ctx, tenant, tenantEntity)
+	}
+	// 在新租户的上下文中开启事务, 管理员用户和模板数据归属新租户
+	tctx := actx.BuildTenantCtx(ctx, tenantEntity.ID)
+	return r.repo.GetDb().InTx(tctx, func(ctx context.Context) error {
 		// 创建管理员用户
 		if tenant.AdminUser != nil {
 			userEntity := r.userMapper.ToEntity(tenant.AdminUser)
@@ -72,15 +88,109 @@ func (r *tenantRepository) Create(ctx context.Context, tenant *model.Tenant) err
 		if _, err := r.repo.Add(ctx, tenantEntity); err != nil {
 			return fmt.Errorf("create tenant failed: %w", err)
 		}
+
+		// 按开通模板初始化租户数据, 失败时整体回滚
+		if err := r.registry.Provision(ctx, tenantEntity.ID, tenantEntity.AdminUserID, tenant.Template); err != nil {
+			return fmt.Errorf("apply tenant template failed: %w", err)
+		}
 		return nil
 	})
 }
 
+// createIsolated 创建独立Schema/数据库的租户
+// 主库和租户库无法在同一事务中提交, 先写入开通中的租户记录, 再初始化租户存储和租户数据,
+// 失败时将租户标记为开通失败, 由 ResumeCreate 从中断处继续
+func (r *tenantRepository) createIsolated(ctx context.Context, tenant *model.Tenant, tenantEntity *entity.Tenant) error {
+	if tenant.AdminUser != nil {
+		tenantEntity.AdminUserID = r.userRepo.GenStringId()
+	}
+	tenantEntity.ProvisionStatus = model.ProvisionPending
+	// 租户记录在主库
+	if _, err := r.repo.Add(ctx, tenantEntity); err != nil {
+		return fmt.Errorf("create tenant failed: %w", err)
+	}
+	return r.provision(ctx, tenant, tenantEntity)
+}
+
+// ResumeCreate 继续开通失败或中断的独立存储租户, 已完成的步骤不会重复执行
+func (r *tenantRepository) ResumeCreate(ctx context.Context, tenant *model.Tenant) error {
+	tenantEntity, err := r.repo.FindById(ctx, tenant.ID)
+	if err != nil {
+		return fmt.Errorf("find tenant failed: %w", err)
+	}
+	if tenantEntity.ProvisionStatus == model.ProvisionReady {
+		return nil
+	}
+	if tenantEntity.AdminUserID == "" && tenant.AdminUser != nil {
+		tenantEntity.AdminUserID = r.userRepo.GenStringId()
+		if err := r.repo.Update(ctx, tenantEntity); err != nil {
+			return fmt.Errorf("update tenant failed: %w", err)
+		}
+	}
+	return r.provision(ctx, tenant, tenantEntity)
+}
+
+// provision 初始化租户存储, 在租户库的同一事务中创建管理员用户和模板数据, 完成后标记为已开通
+// 存储初始化可重复执行; 管理员用户已存在说明租户数据已提交, 跳过
+func (r *tenantRepository) provision(ctx context.Context, tenant *model.Tenant, tenantEntity *entity.Tenant) error {
+	db := r.repo.GetDb()
+	err := db.ProvisionTenant(ctx, &database.TenantIsolation{
+		TenantID: tenantEntity.ID,
+		Mode:     tenantEntity.IsolationMode,
+		Schema:   tenantEntity.DbSchema,
+		Source:   tenantEntity.DbSource,
+	})
+	if err != nil {
+		return r.provisionFailed(ctx, tenantEntity.ID, fmt.Errorf("provision tenant storage failed: %w", err))
+	}
+
+	tctx := actx.BuildTenantCtx(ctx, tenantEntity.ID)
+	err = db.InTx(tctx, func(ctx context.Context) error {
+		if tenantEntity.AdminUserID != "" {
+			_, err := r.userRepo.FindById(ctx, tenantEntity.AdminUserID)
+			if err == nil {
+				return nil
+			}
+			if !database.IfErrorNotFound(err) {
+				return err
+			}
+		}
+		if tenant.AdminUser != nil {
+			userEntity := r.userMapper.ToEntity(tenant.AdminUser)
+			userEntity.ID = tenantEntity.AdminUserID
+			userEntity.TenantID = tenantEntity.ID
+			if _, err := r.userRepo.Add(ctx, userEntity); err != nil {
+				return fmt.Errorf("create admin user failed: %w", err)
+			}
+		}
+		if err := r.registry.Provision(ctx, tenantEntity.ID, tenantEntity.AdminUserID, tenant.Template); err != nil {
+			return fmt.Errorf("apply tenant template failed: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return r.provisionFailed(ctx, tenantEntity.ID, err)
+	}
+	if err := r.repo.SetProvisionStatus(ctx, tenantEntity.ID, model.ProvisionReady, ""); err != nil {
+		return fmt.Errorf("update tenant provision status failed: %w", err)
+	}
+	return nil
+}
+
+// provisionFailed 标记租户开通失败, 保留已初始化的存储以便继续开通或删除
+func (r *tenantRepository) provisionFailed(ctx context.Context, tenantID string, err error) error {
+	if markErr := r.repo.SetProvisionStatus(ctx, tenantID, model.ProvisionFailed, err.Error()); markErr != nil {
+		return fmt.Errorf("%w, mark tenant provision failed: %v", err, markErr)
+	}
+	return err
+}
+
 func (r *tenantRepository) Update(ctx context.Context, tenant *model.Tenant) error {
 	tenantEntity := r.mapper.ToEntity(tenant)
 	if err := r.repo.Update(ctx, tenantEntity); err != nil {
 		return fmt.Errorf("update tenant failed: %w", err)
 	}
+	r.repo.GetDb().EvictTenant(tenant.ID)
 	return nil
 }
 
@@ -88,6 +198,7 @@ func (r *tenantRepository) Delete(ctx context.Context, id string) error {
 	if err := r.repo.DeleteWithRelations(ctx, id); err != nil {
 		return fmt.Errorf("delete tenant failed: %w", err)
 	}
+	r.repo.GetDb().EvictTenant(id)
 	return nil
 }
 
@@ -141,6 +252,20 @@ func (r *tenantRepository) ExistsByCode(ctx context.Context, code string) (bool,
 	return count > 0, nil
 }
 
+func (r *tenantRepository) ExistsByDomain(ctx context.Context, domain string, excludeID string) (bool, error) {
+	qb := db_query.NewQueryBuilder()
+	qb.Where("domain", db_query.Eq, domain)
+	if excludeID != "" {
+		qb.Where("id", db_query.Neq, excludeID)
+	}
+
+	count, err := r.repo.Count(ctx, qb)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 func (r *tenantRepository) AssignPermissions(ctx context.Context, tenantID string, permissionIDs []int64) error {
 	return r.repo.AssignPermissions(ctx, tenantID, permissionIDs)
 }
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
+	drepository "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/mapper"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/provision"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/baserepo"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/tenantdata"
+)
+
+type ISysTenantTemplateRepo interface {
+	baserepo.IBaseRepo[entity.TenantTemplate, string]
+	Create(ctx context.Context, tpl *entity.TenantTemplate) error
+	Update(ctx context.Context, tpl *entity.TenantTemplate) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*entity.TenantTemplate, error)
+	List(ctx context.Context, name string) ([]*entity.TenantTemplate, error)
+	ExistsByName(ctx context.Context, name string, excludeID string) (bool, error)
+}
+
+type tenantTemplateRepository struct {
+	repo     ISysTenantTemplateRepo
+	registry *tenantdata.Registry
+	mapper   *mapper.TenantTemplateMapper
+}
+
+func NewTenantTemplateRepository(repo ISysTenantTemplateRepo, registry *tenantdata.Registry, base *provision.BaseProvisioner) drepository.ITenantTemplateRepository {
+	// 注册基础模块的开通处理器, 其他模块在各自的服务中注册
+	registry.RegisterProvisioner(base)
+	return &tenantTemplateRepository{
+		repo:     repo,
+		registry: registry,
+		mapper:   &mapper.TenantTemplateMapper{},
+	}
+}
+
+func (r *tenantTemplateRepository) Create(ctx context.Context, tpl *model.TenantTemplate) error {
+	e := r.mapper.ToEntity(tpl)
+	e.ID = r.repo.GenStringId()
+	if err := r.repo.Create(ctx, e); err != nil {
+		return err
+	}
+	tpl.ID = e.ID
+	return nil
+}
+
+func (r *tenantTemplateRepository) Update(ctx context.Context, tpl *model.TenantTemplate) error {
+	return r.repo.Update(ctx, r.mapper.ToEntity(tpl))
+}
+
+func (r *tenantTemplateRepository) Delete(ctx context.Context, id string) error {
+	return r.repo.Delete(ctx, id)
+}
+
+func (r *tenantTemplateRepository) FindByID(ctx context.Context, id string) (*model.TenantTemplate, error) {
+	e, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.mapper.ToDomain(e)
+}
+
+func (r *tenantTemplateRepository) ExistsByName(ctx context.Context, name string, excludeID string) (bool, error) {
+	return r.repo.ExistsByName(ctx, name, excludeID)
+}
+
+// Capture 在来源租户的上下文中读取数据, 独立Schema/数据库的租户会路由到租户库
+func (r *tenantTemplateRepository) Capture(ctx context.Context, tenantID string) (*tenantdata.Template, error) {
+	return r.registry.Capture(actx.BuildTenantCtx(ctx, tenantID), tenantID)
+}
@@ -3,8 +3,13 @@ package repository
 import (
 	"context"
 
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/plugin"
+	"gorm.io/gorm"
+
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/mapper"
 
+	derrors "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/errors"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
 	drepository "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/repository"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
@@ -16,7 +21,13 @@ import (
 type ISysUserRepo interface {
 	baserepo.IBaseRepo[entity.SysUser, string]
 	GetByUsername(ctx context.Context, username string) (*entity.SysUser, error)
+	CountByUsername(ctx context.Context, username string) (int64, error)
+	GetExistingUsernames(ctx context.Context, usernames []string) ([]string, error)
+	GetRoleCodesByUserIds(ctx context.Context, userIDs []string) (map[string][]string, error)
+	GetDeptCodesByUserIds(ctx context.Context, userIDs []string) (map[string][]string, error)
 	DeleteRoleByUserId(ctx context.Context, userId string) error
+	// SyncUserRoles 同步用户在当前租户的角色, 保留原有授予的有效期
+	SyncUserRoles(ctx context.Context, userID string, roleIDs []int64) error
 	BelongsToDepartment(ctx context.Context, userID string, deptID string) (bool, error)
 	GetUserPermissionCodes(ctx context.Context, userID string) ([]string, error)
 	GetUserMenus(ctx context.Context, userID string) ([]*entity.Permissions, error)
@@ -26,8 +37,21 @@ type ISysUserRepo interface {
 	CountUnassignedUsers(ctx context.Context, qb *db_query.QueryBuilder) (int64, error)
 	FindByRoleID(ctx context.Context, roleID int64) ([]*entity.SysUser, error)
 	AssignUsersToDepartment(ctx context.Context, deptID string, userIDs []string) error
+
+	// 租户成员相关
+	InviteTenantMember(ctx context.Context, userID, tenantID, invitedBy string) error
+	AcceptTenantMember(ctx context.Context, userID, tenantID string) (bool, error)
+	RemoveTenantMember(ctx context.Context, userID, tenantID string) error
+	IsTenantMember(ctx context.Context, userID, tenantID string) (bool, error)
+	IsTenantInvited(ctx context.Context, userID, tenantID string) (bool, error)
+	GetMemberTenantIDs(ctx context.Context, userID string) ([]string, error)
+	GetInvitedTenantIDs(ctx context.Context, userID string) ([]string, error)
+	GetMemberByUsername(ctx context.Context, tenantID, username string) (*entity.SysUser, error)
 }
 
+// batchSize 批量写入时每批的行数
+const batchSize = 200
+
 type userRepository struct {
 	repo       ISysUserRepo
 	roleRepo   ISysRoleRepo
@@ -71,31 +95,23 @@ func (r *userRepository) Create(ctx context.Context, user *model.User) error {
 	return err
 }
 
+// Update 更新用户及其在当前租户的角色, 只能在用户的归属租户下修改
 func (r *userRepository) Update(ctx context.Context, user *model.User) error {
+	if !isHomeTenant(ctx, user.TenantID) {
+		return derrors.ErrNotHomeTenant
+	}
 	userEntity := r.mapper.ToEntity(user)
 	err := r.repo.GetDb().InTx(ctx, func(ctx context.Context) error {
 		err := r.repo.EditById(ctx, userEntity.ID, userEntity)
 		if err != nil {
 			return err
 		}
-		err = r.repo.DeleteRoleByUserId(ctx, userEntity.ID)
-		if err != nil {
-			return err
-		}
-		if len(user.Roles) > 0 {
-			// 创建用户角色关联
-			userRoles := make([]*entity.SysUserRole, 0, len(user.Roles))
-			for _, role := range user.Roles {
-				userRoles = append(userRoles, &entity.SysUserRole{
-					UserID: userEntity.ID,
-					RoleID: role.ID,
-				})
-			}
-			if err = r.repo.Db(ctx).Create(&userRoles).Error; err != nil {
-				return err
-			}
+		// 同步用户角色关联, 保留原有授予的有效期
+		roleIDs := make([]int64, 0, len(user.Roles))
+		for _, role := range user.Roles {
+			roleIDs = append(roleIDs, role.ID)
 		}
-		return nil
+		return r.repo.SyncUserRoles(ctx, userEntity.ID, roleIDs)
 	})
 	return err
 }
@@ -103,44 +119,65 @@ func (r *userRepository) Update(ctx context.Context, user *model.User) error {
 func (r *userRepository) FindByID(ctx context.Context, id string) (*model.User, error) {
 	// 查询用户基本信息
 	userEntity, err := r.repo.FindById(ctx, id)
+	if err != nil && database.IfErrorNotFound(err) {
+		// 不在当前租户下的用户, 如果是当前租户的成员也可以查到
+		userEntity, err = r.findMember(ctx, id)
+	}
 	if err != nil {
 		if database.IfErrorNotFound(err) {
 			return nil, database.ErrRecordNotFound
 		}
 		return nil, err
 	}
+	return r.toDomainWithRoles(ctx, userEntity)
+}
 
-	// 查询用户角色关联
-	userRoles, err := r.roleRepo.GetByUserId(ctx, id)
+func (r *userRepository) FindByUsername(ctx context.Context, username string) (*model.User, error) {
+	// 查询用户基本信息
+	userEntity, err := r.repo.GetByUsername(ctx, username)
 	if err != nil {
-		return nil, err
-	}
-	roles := make([]*model.Role, 0)
-	if len(userRoles) > 0 {
-		roleIds := make([]int64, 0)
-		for _, role := range userRoles {
-			roleIds = append(roleIds, role.ID)
-		}
-		rs, err1 := r.roleRepo.FindByIds(ctx, roleIds)
-		if err1 != nil {
-			return nil, err1
+		if database.IfErrorNotFound(err) {
+			return nil, database.ErrRecordNotFound
 		}
-		roles = r.roleMapper.ToDomainList(rs)
+		return nil, err
 	}
-	// 转换为领域模型
-	return r.mapper.ToDomain(userEntity, roles), nil
+	return r.toDomainWithRoles(ctx, userEntity)
 }
 
-func (r *userRepository) FindByUsername(ctx context.Context, username string) (*model.User, error) {
-	// 查询用户基本信息
-	userEntity, err := r.repo.GetByUsername(ctx, username)
+// FindMemberByUsername 根据用户名查找租户成员
+func (r *userRepository) FindMemberByUsername(ctx context.Context, tenantID, username string) (*model.User, error) {
+	userEntity, err := r.repo.GetMemberByUsername(ctx, tenantID, username)
 	if err != nil {
 		if database.IfErrorNotFound(err) {
 			return nil, database.ErrRecordNotFound
 		}
 		return nil, err
 	}
+	return r.toDomainWithRoles(ctx, userEntity)
+}
+
+// findMember 查找当前租户的成员用户, 只返回其他租户可见的信息
+func (r *userRepository) findMember(ctx context.Context, id string) (*entity.SysUser, error) {
+	tenantID := plugin.GetCtxTenantID(ctx)
+	if tenantID == "" || plugin.IsIgnoreTenant(ctx) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	ok, err := r.repo.IsTenantMember(ctx, id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	userEntity, err := r.repo.FindById(actx.BuildIgnoreTenantCtx(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	return userEntity.MemberView(), nil
+}
 
+// toDomainWithRoles 转换为领域模型并加载当前租户下的角色
+func (r *userRepository) toDomainWithRoles(ctx context.Context, userEntity *entity.SysUser) (*model.User, error) {
 	// 查询用户角色关联
 	userRoles, err := r.roleRepo.GetByUserId(ctx, userEntity.ID)
 	if err != nil {
@@ -173,36 +210,123 @@ func (r *userRepository) ExistsByUsername(ctx context.Context, username string)
 	return true, nil
 }
 
-func (r *userRepository) Delete(ctx context.Context, id string) error {
-	return r.repo.DelById(ctx, id)
+func (r *userRepository) CountByUsername(ctx context.Context, username string) (int64, error) {
+	return r.repo.CountByUsername(ctx, username)
 }
 
-// BelongsToDepartment 检查用户是否属于指定部门
-func (r *userRepository) BelongsToDepartment(ctx context.Context, userID string, deptID string) (bool, error) {
-	return r.repo.BelongsToDepartment(ctx, userID, deptID)
+// FindExistingUsernames 返回已存在的用户名
+func (r *userRepository) FindExistingUsernames(ctx context.Context, usernames []string) ([]string, error) {
+	return r.repo.GetExistingUsernames(ctx, usernames)
 }
 
-// AssignRoles 分配角色给用户
-func (r *userRepository) AssignRoles(ctx context.Context, userID string, roleIDs []int64) error {
+// BatchCreate 批量创建用户, 用户、角色关联和部门关联分别分批写入
+func (r *userRepository) BatchCreate(ctx context.Context, items []*model.UserImportItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	users := make([]*entity.SysUser, 0, len(items))
+	var userRoles []*entity.SysUserRole
+	var userDepts []*entity.UserDepartment
+	for _, item := range items {
+		userEntity := r.mapper.ToEntity(item.User)
+		userEntity.ID = r.repo.GenStringId()
+		item.User.ID = userEntity.ID
+		users = append(users, userEntity)
+		for _, role := range item.User.Roles {
+			userRoles = append(userRoles, &entity.SysUserRole{UserID: userEntity.ID, RoleID: role.ID})
+		}
+		if item.DeptID != "" {
+			userDepts = append(userDepts, &entity.UserDepartment{ID: r.repo.GenInt64Id(), UserID: userEntity.ID, DeptID: item.DeptID, IsPrimary: true})
+		}
+	}
 	return r.repo.GetDb().InTx(ctx, func(ctx context.Context) error {
-		// 1. 删除原有角色关联
-		if err := r.repo.DeleteRoleByUserId(ctx, userID); err != nil {
+		if err := r.repo.Db(ctx).CreateInBatches(&users, batchSize).Error; err != nil {
 			return err
 		}
-
-		// 2. 创建新的角色关联
-		if len(roleIDs) > 0 {
-			userRoles := make([]*entity.SysUserRole, len(roleIDs))
-			for i, roleID := range roleIDs {
-				userRoles[i] = &entity.SysUserRole{
-					UserID: userID,
-					RoleID: roleID,
-				}
+		if len(userRoles) > 0 {
+			if err := r.repo.Db(ctx).CreateInBatches(&userRoles, batchSize).Error; err != nil {
+				return err
 			}
-			if err := r.repo.Db(ctx).Create(&userRoles).Error; err != nil {
+		}
+		if len(userDepts) > 0 {
+			if err := r.repo.Db(ctx).CreateInBatches(&userDepts, batchSize).Error; err != nil {
 				return err
 			}
 		}
 		return nil
 	})
 }
+
+// UpdateInvitation 保存邀请状态, 邀请码和过期时间可能被清空, 使用 map 更新零值
+func (r *userRepository) UpdateInvitation(ctx context.Context, user *model.User) error {
+	if !isHomeTenant(ctx, user.TenantID) {
+		return derrors.ErrNotHomeTenant
+	}
+	return r.repo.Db(ctx).Model(&entity.SysUser{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+		"password":        user.Password,
+		"status":          user.Status,
+		"invitation_code": user.InvitationCode,
+		"invitation_exp":  user.InvitationExp,
+		"updated_at":      user.UpdatedAt,
+	}).Error
+}
+
+func (r *userRepository) Delete(ctx context.Context, id string) error {
+	if tenantID := plugin.GetCtxTenantID(ctx); tenantID != "" && !plugin.IsIgnoreTenant(ctx) {
+		// 删除不经过租户过滤, 先在当前租户下查询确认
+		if _, err := r.repo.FindById(ctx, id); err != nil {
+			if database.IfErrorNotFound(err) {
+				return derrors.ErrNotHomeTenant
+			}
+			return err
+		}
+	}
+	return r.repo.DelById(ctx, id)
+}
+
+// isHomeTenant 判断当前租户是否为用户的归属租户, 未指定租户或忽略租户时不限制
+// 其他租户的成员只能维护其在本租户的角色和部门, 不能修改用户本身
+func isHomeTenant(ctx context.Context, userTenantID string) bool {
+	tenantID := plugin.GetCtxTenantID(ctx)
+	return tenantID == "" || plugin.IsIgnoreTenant(ctx) || tenantID == userTenantID
+}
+
+// BelongsToDepartment 检查用户是否属于指定部门
+func (r *userRepository) BelongsToDepartment(ctx context.Context, userID string, deptID string) (bool, error) {
+	return r.repo.BelongsToDepartment(ctx, userID, deptID)
+}
+
+// AssignRoles 分配角色给用户
+func (r *userRepository) AssignRoles(ctx context.Context, userID string, roleIDs []int64) error {
+	return r.repo.SyncUserRoles(ctx, userID, roleIDs)
+}
+
+// InviteTenantMember 邀请用户加入租户
+func (r *userRepository) InviteTenantMember(ctx context.Context, userID, tenantID, invitedBy string) error {
+	return r.repo.InviteTenantMember(ctx, userID, tenantID, invitedBy)
+}
+
+// AcceptTenantMember 接受加入租户的邀请
+func (r *userRepository) AcceptTenantMember(ctx context.Context, userID, tenantID string) (bool, error) {
+	return r.repo.AcceptTenantMember(ctx, userID, tenantID)
+}
+
+// RemoveTenantMember 移除租户成员
+func (r *userRepository) RemoveTenantMember(ctx context.Context, userID, tenantID string) error {
+	return r.repo.RemoveTenantMember(ctx, userID, tenantID)
+}
+
+// IsTenantMember 判断用户是否为租户成员
+func (r *userRepository) IsTenantMember(ctx context.Context, userID, tenantID string) (bool, error) {
+	return r.repo.IsTenantMember(ctx, userID, tenantID)
+}
+
+// IsTenantInvited 判断用户是否有该租户待接受的邀请
+func (r *userRepository) IsTenantInvited(ctx context.Context, userID, tenantID string) (bool, error) {
+	return r.repo.IsTenantInvited(ctx, userID, tenantID)
+}
+
+// GetMemberTenantIDs 获取用户加入的租户ID列表
+func (r *userRepository) GetMemberTenantIDs(ctx context.Context, userID string) ([]string, error) {
+	return r.repo.GetMemberTenantIDs(ctx, userID)
+}
@@ -12,6 +12,17 @@ var ProviderSet = wire.NewSet(
 	NewAuthRepository,
 	NewLoginLogRepository,
 	NewOperationLogRepository,
+	NewAuditLogRepository,
+	NewLogChainRepository,
 	NewDepartmentRepository,
+	NewPositionRepository,
 	NewDataPermissionRepository,
+	NewTenantJobRepository,
+	NewTenantTemplateRepository,
+	NewScimTokenRepository,
+	NewRecycleBinRepository,
+	NewRoleGrantRepository,
+	NewRoleConstraintRepository,
+	NewAccessReviewRepository,
+	NewImpersonationRepository,
 )
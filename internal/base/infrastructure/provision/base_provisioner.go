@@ -0,0 +1,290 @@
+// Package provision 按开通模板初始化租户的基础数据, 以及从已有租户生成模板
+package provision
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/tenantdata"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// BaseProvisioner 基础模块的开通处理器: 部门、角色及权限、数据权限、字典
+type BaseProvisioner struct {
+	db database.IDataBase
+}
+
+func NewBaseProvisioner(db database.IDataBase) *BaseProvisioner {
+	// 同步字典表
+	if err := db.AutoMigrate(&entity.DictItem{}); err != nil {
+		hlog.Fatalf("sync sys dict item tables to db error: %v", err)
+	}
+	return &BaseProvisioner{db: db}
+}
+
+func (p *BaseProvisioner) Name() string {
+	return "base"
+}
+
+// Provision 按模板创建部门、角色、数据权限和字典项
+func (p *BaseProvisioner) Provision(ctx context.Context, tenantID, operator string, tpl *tenantdata.Template) error {
+	deptIDs, err := p.provisionDepartments(ctx, tenantID, tpl)
+	if err != nil {
+		return err
+	}
+	if err := p.provisionRoles(ctx, tenantID, tpl, deptIDs); err != nil {
+		return err
+	}
+	return p.provisionDictionaries(ctx, tenantID, tpl)
+}
+
+func (p *BaseProvisioner) provisionDepartments(ctx context.Context, tenantID string, tpl *tenantdata.Template) (map[string]string, error) {
+	depts, err := tpl.SortedDepartments()
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]string, len(depts))
+	for _, d := range depts {
+		e := &entity.Department{
+			ID:          p.db.GenStringId(),
+			TenantID:    tenantID,
+			ParentID:    ids[d.ParentCode],
+			Code:        d.Code,
+			Name:        d.Name,
+			Sequence:    d.Sequence,
+			Leader:      d.Leader,
+			Phone:       d.Phone,
+			Email:       d.Email,
+			Status:      1,
+			Description: d.Description,
+		}
+		if err := p.db.DB(ctx).Create(e).Error; err != nil {
+			return nil, fmt.Errorf("create department %s: %w", d.Code, err)
+		}
+		ids[d.Code] = e.ID
+	}
+	return ids, nil
+}
+
+func (p *BaseProvisioner) provisionRoles(ctx context.Context, tenantID string, tpl *tenantdata.Template, deptIDs map[string]string) error {
+	for _, r := range tpl.Roles {
+		role := &entity.Role{
+			Code:        r.Code,
+			Name:        r.Name,
+			Type:        r.Type,
+			Description: r.Description,
+			Sequence:    r.Sequence,
+			Status:      1,
+			TenantID:    tenantID,
+		}
+		if role.Type == 0 {
+			role.Type = int8(entity.RoleTypeResource)
+		}
+		if err := p.db.DB(ctx).Create(role).Error; err != nil {
+			return fmt.Errorf("create role %s: %w", r.Code, err)
+		}
+
+		permIDs, err := p.permissionIDs(ctx, r.Permissions)
+		if err != nil {
+			return fmt.Errorf("role %s: %w", r.Code, err)
+		}
+		if len(permIDs) > 0 {
+			rps := make([]*entity.RolePermissions, 0, len(permIDs))
+			for _, id := range permIDs {
+				rps = append(rps, &entity.RolePermissions{RoleID: role.ID, PermissionID: id, TenantID: tenantID})
+			}
+			if err := p.db.DB(ctx).Create(&rps).Error; err != nil {
+				return fmt.Errorf("assign role %s permissions: %w", r.Code, err)
+			}
+		}
+
+		if r.DataScope == nil {
+			continue
+		}
+		ids := make([]string, 0, len(r.DataScope.Departments))
+		for _, code := range r.DataScope.Departments {
+			ids = append(ids, deptIDs[code])
+		}
+		dp := &entity.DataPermission{
+			ID:       p.db.GenStringId(),
+			RoleID:   role.ID,
+			Scope:    r.DataScope.Scope,
+			DeptIDs:  strings.Join(ids, ","),
+			TenantID: tenantID,
+		}
+		if err := p.db.DB(ctx).Create(dp).Error; err != nil {
+			return fmt.Errorf("create role %s data permission: %w", r.Code, err)
+		}
+	}
+	return nil
+}
+
+// permissionIDs 将权限编码转换为权限ID, 编码不存在时返回错误
+// 权限表在主库, 不使用租户库的事务查询
+func (p *BaseProvisioner) permissionIDs(ctx context.Context, codes []string) ([]int64, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+	var perms []*entity.Permissions
+	if err := p.db.DB(actx.BuildIgnoreTenantCtx(context.Background())).Where("code IN ?", codes).Find(&perms).Error; err != nil {
+		return nil, err
+	}
+	found := make(map[string]struct{}, len(perms))
+	ids := make([]int64, 0, len(perms))
+	for _, perm := range perms {
+		found[perm.Code] = struct{}{}
+		ids = append(ids, perm.ID)
+	}
+	for _, code := range codes {
+		if _, ok := found[code]; !ok {
+			return nil, fmt.Errorf("permission code not found: %s", code)
+		}
+	}
+	return ids, nil
+}
+
+func (p *BaseProvisioner) provisionDictionaries(ctx context.Context, tenantID string, tpl *tenantdata.Template) error {
+	var items []*entity.DictItem
+	for _, d := range tpl.Dictionaries {
+		for _, item := range d.Items {
+			items = append(items, &entity.DictItem{
+				ID:       p.db.GenStringId(),
+				TenantID: tenantID,
+				DictType: d.Type,
+				DictName: d.Name,
+				Label:    item.Label,
+				Value:    item.Value,
+				Sequence: item.Sequence,
+				Status:   1,
+				Remark:   item.Remark,
+			})
+		}
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	if err := p.db.DB(ctx).Create(&items).Error; err != nil {
+		return fmt.Errorf("create dictionary items: %w", err)
+	}
+	return nil
+}
+
+// Capture 读取租户的部门、角色、数据权限和字典项
+func (p *BaseProvisioner) Capture(ctx context.Context, tenantID string, tpl *tenantdata.Template) error {
+	deptCodes, err := p.captureDepartments(ctx, tenantID, tpl)
+	if err != nil {
+		return err
+	}
+	if err := p.captureRoles(ctx, tenantID, tpl, deptCodes); err != nil {
+		return err
+	}
+	return p.captureDictionaries(ctx, tenantID, tpl)
+}
+
+func (p *BaseProvisioner) captureDepartments(ctx context.Context, tenantID string, tpl *tenantdata.Template) (map[string]string, error) {
+	var depts []*entity.Department
+	if err := p.db.DB(ctx).Where("tenant_id = ?", tenantID).Order("sequence").Find(&depts).Error; err != nil {
+		return nil, err
+	}
+	codes := make(map[string]string, len(depts))
+	for _, d := range depts {
+		codes[d.ID] = d.Code
+	}
+	for _, d := range depts {
+		tpl.Departments = append(tpl.Departments, tenantdata.DepartmentSpec{
+			Code:        d.Code,
+			Name:        d.Name,
+			ParentCode:  codes[d.ParentID],
+			Sequence:    d.Sequence,
+			Leader:      d.Leader,
+			Phone:       d.Phone,
+			Email:       d.Email,
+			Description: d.Description,
+		})
+	}
+	return codes, nil
+}
+
+func (p *BaseProvisioner) captureRoles(ctx context.Context, tenantID string, tpl *tenantdata.Template, deptCodes map[string]string) error {
+	var roles []*entity.Role
+	if err := p.db.DB(ctx).Where("tenant_id = ?", tenantID).Order("sequence").Find(&roles).Error; err != nil {
+		return err
+	}
+	for _, r := range roles {
+		spec := tenantdata.RoleSpec{
+			Code:        r.Code,
+			Name:        r.Name,
+			Type:        r.Type,
+			Description: r.Description,
+			Sequence:    r.Sequence,
+		}
+		codes, err := p.permissionCodes(ctx, r.ID)
+		if err != nil {
+			return err
+		}
+		spec.Permissions = codes
+
+		var dps []*entity.DataPermission
+		if err := p.db.DB(ctx).Where("role_id = ?", r.ID).Limit(1).Find(&dps).Error; err != nil {
+			return err
+		}
+		if len(dps) > 0 {
+			scope := &tenantdata.DataScopeSpec{Scope: dps[0].Scope}
+			for _, id := range strings.Split(dps[0].DeptIDs, ",") {
+				if code, ok := deptCodes[id]; ok {
+					scope.Departments = append(scope.Departments, code)
+				}
+			}
+			spec.DataScope = scope
+		}
+		tpl.Roles = append(tpl.Roles, spec)
+	}
+	return nil
+}
+
+// permissionCodes 获取角色的权限编码, 角色权限关联在租户库, 权限表在主库
+func (p *BaseProvisioner) permissionCodes(ctx context.Context, roleID int64) ([]string, error) {
+	var ids []int64
+	if err := p.db.DB(ctx).Model(&entity.RolePermissions{}).Where("role_id = ?", roleID).
+		Pluck("permission_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var codes []string
+	if err := p.db.DB(actx.BuildIgnoreTenantCtx(context.Background())).Model(&entity.Permissions{}).
+		Where("id IN ?", ids).Distinct().Pluck("code", &codes).Error; err != nil {
+		return nil, err
+	}
+	sort.Strings(codes)
+	return codes, nil
+}
+
+func (p *BaseProvisioner) captureDictionaries(ctx context.Context, tenantID string, tpl *tenantdata.Template) error {
+	var items []*entity.DictItem
+	if err := p.db.DB(ctx).Where("tenant_id = ?", tenantID).Order("dict_type, sequence").Find(&items).Error; err != nil {
+		return err
+	}
+	index := make(map[string]int)
+	for _, item := range items {
+		i, ok := index[item.DictType]
+		if !ok {
+			i = len(tpl.Dictionaries)
+			index[item.DictType] = i
+			tpl.Dictionaries = append(tpl.Dictionaries, tenantdata.DictionarySpec{Type: item.DictType, Name: item.DictName})
+		}
+		tpl.Dictionaries[i].Items = append(tpl.Dictionaries[i].Items, tenantdata.DictItemSpec{
+			Label:    item.Label,
+			Value:    item.Value,
+			Sequence: item.Sequence,
+			Remark:   item.Remark,
+		})
+	}
+	return nil
+}
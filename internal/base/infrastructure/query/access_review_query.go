@@ -0,0 +1,22 @@
+package query
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+)
+
+// IAccessReviewQuery 权限复核查询接口
+type IAccessReviewQuery interface {
+	// Find 查询复核活动
+	Find(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.AccessReviewDto, error)
+	// Count 统计复核活动数量
+	Count(ctx context.Context, qb *db_query.QueryBuilder) (int64, error)
+	// GetByID 查询复核活动, 不存在时返回 nil
+	GetByID(ctx context.Context, id string) (*dto.AccessReviewDto, error)
+	// FindItems 复核活动的全部复核项
+	FindItems(ctx context.Context, campaignID string) ([]*dto.AccessReviewItemDto, error)
+	// FindOpenItemsByReviewer 进行中的复核活动里分配给复核人的复核项
+	FindOpenItemsByReviewer(ctx context.Context, reviewerID string) ([]*dto.AccessReviewItemDto, error)
+}
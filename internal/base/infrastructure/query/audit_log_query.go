@@ -0,0 +1,16 @@
+package query
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+)
+
+// IAuditLogQuery 数据变更记录查询接口
+type IAuditLogQuery interface {
+	// Find 查询数据变更记录
+	Find(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.AuditLogDto, error)
+	// Count 统计数据变更记录数量
+	Count(ctx context.Context, qb *db_query.QueryBuilder) (int64, error)
+}
@@ -53,6 +53,19 @@ func (c *DepartmentQueryCache) GetDepartmentTree(ctx context.Context, parentID s
 	return tree, err
 }
 
+// 上级部门、子树用户数和组织架构图不缓存,直接透传
+func (c *DepartmentQueryCache) GetDepartmentAncestors(ctx context.Context, id string) ([]*dto.DepartmentDto, error) {
+	return c.next.GetDepartmentAncestors(ctx, id)
+}
+
+func (c *DepartmentQueryCache) CountSubtreeUsers(ctx context.Context, id string) (int64, error) {
+	return c.next.CountSubtreeUsers(ctx, id)
+}
+
+func (c *DepartmentQueryCache) GetOrgChart(ctx context.Context, rootID string) ([]*dto.OrgChartNodeDto, error) {
+	return c.next.GetOrgChart(ctx, rootID)
+}
+
 // GetUserDepartments 获取用户部门列表(带缓存)
 func (c *DepartmentQueryCache) GetUserDepartments(ctx context.Context, userID string) ([]*dto.DepartmentDto, error) {
 	tenantID := actx.GetTenantId(ctx)
@@ -113,6 +126,12 @@ func (c *DepartmentQueryCache) InvalidateDepartmentUserCache(ctx context.Context
 	return c.decorator.InvalidateCache(ctx, keys.DepartmentUsersKey(tenantID, deptID))
 }
 
+// InvalidateUserDepartmentsCache 清除用户部门列表缓存
+func (c *DepartmentQueryCache) InvalidateUserDepartmentsCache(ctx context.Context, userID string) error {
+	tenantID := actx.GetTenantId(ctx)
+	return c.decorator.InvalidateCache(ctx, keys.UserDepartmentsKey(tenantID, userID))
+}
+
 // InvalidateChildrenCache 清除部门子节点列表缓存
 func (c *DepartmentQueryCache) InvalidateChildrenCache(ctx context.Context, parentID string) error {
 	tenantID := actx.GetTenantId(ctx)
@@ -16,6 +16,7 @@ type EventHandler struct {
 	userCache     *cache.UserQueryCache
 	roleCache     *cache.RoleQueryCache
 	deptCache     *cache.DepartmentQueryCache
+	positionCache *cache.PositionQueryCache
 	permCache     *cache.PermissionsQueryCache
 	dataPermCache *cache.DataPermissionQueryCache
 	tenantCache   *cache.TenantQueryCache
@@ -25,6 +26,7 @@ func NewCacheEventHandler(
 	userCache *cache.UserQueryCache,
 	roleCache *cache.RoleQueryCache,
 	deptCache *cache.DepartmentQueryCache,
+	positionCache *cache.PositionQueryCache,
 	permCache *cache.PermissionsQueryCache,
 	dataPermCache *cache.DataPermissionQueryCache,
 	tenantCache *cache.TenantQueryCache,
@@ -33,6 +35,7 @@ func NewCacheEventHandler(
 		userCache:     userCache,
 		roleCache:     roleCache,
 		deptCache:     deptCache,
+		positionCache: positionCache,
 		permCache:     permCache,
 		dataPermCache: dataPermCache,
 		tenantCache:   tenantCache,
@@ -57,12 +60,22 @@ func (h *EventHandler) Handle(ctx context.Context, event pkgEvent.Event) error {
 		return h.handleDepartmentEvent(ctx, e)
 	case *events.DepartmentMovedEvent:
 		return h.handleDepartmentMovedEvent(ctx, e)
+	case *events.DepartmentMergedEvent:
+		return h.handleDepartmentMergedEvent(ctx, e)
 	case *events.UserRemovedEvent:
 		return h.handleUserRemovedEvent(ctx, e)
 	case *events.UserAssignedEvent:
 		return h.handleUserAssignedEvent(ctx, e)
 	case *events.UserTransferredEvent:
 		return h.handleUserTransferredEvent(ctx, e)
+	case *events.UserPrimaryDeptChangedEvent:
+		return h.handleUserPrimaryDeptChangedEvent(ctx, e)
+
+	// 岗位相关事件
+	case *events.PositionEvent:
+		return h.handlePositionEvent(ctx, e)
+	case *events.UserPositionChangedEvent:
+		return h.handleUserPositionChangedEvent(ctx, e)
 
 	// todo 权限变更清除关联用户和角色
 	// 权限相关事件
@@ -167,6 +180,15 @@ func (h *EventHandler) handleUserEvent(ctx context.Context, event *events.UserEv
 			}
 		}
 
+	case events.UserJoinTenant, events.UserLeaveTenant:
+		// 成员关系变化, 清除用户缓存及用户列表缓存
+		if err := h.userCache.InvalidateUserCache(ctx, event.UserID); err != nil {
+			return fmt.Errorf("清除用户缓存失败: %w", err)
+		}
+		if err := h.userCache.InvalidateUserListCache(ctx); err != nil {
+			return fmt.Errorf("清除用户列表缓存失败: %w", err)
+		}
+
 	case events.UserRoleChanged:
 		// 1. 清除用户权限相关缓存
 		if err := h.userCache.InvalidateUserCache(ctx, event.UserID); err != nil {
@@ -318,6 +340,31 @@ func (h *EventHandler) handleDepartmentMovedEvent(ctx context.Context, event *ev
 	return nil
 }
 
+func (h *EventHandler) handleDepartmentMergedEvent(ctx context.Context, event *events.DepartmentMergedEvent) error {
+	hlog.CtxDebugf(ctx, "处理部门合并事件: 源部门ID=%s, 目标部门ID=%s", event.SourceDeptID, event.DeptID)
+
+	// 1. 子部门和成员都发生了变化, 清除租户下所有部门缓存
+	if err := h.deptCache.InvalidateTenantDepartmentCache(ctx, event.TenantID); err != nil {
+		return fmt.Errorf("清除部门缓存失败: %w", err)
+	}
+
+	// 2. 清除转移用户的部门缓存
+	for _, userID := range event.UserIDs {
+		if err := h.userCache.InvalidateUserDepartmentCache(ctx, userID); err != nil {
+			return fmt.Errorf("清除用户部门缓存失败: %w", err)
+		}
+	}
+
+	// 3. 清除引用了源部门的数据权限缓存
+	for _, roleID := range event.RoleIDs {
+		if err := h.dataPermCache.InvalidateCache(ctx, roleID); err != nil {
+			return fmt.Errorf("清除角色[%d]数据权限缓存失败: %w", roleID, err)
+		}
+	}
+
+	return nil
+}
+
 func (h *EventHandler) handleUserRemovedEvent(ctx context.Context, event *events.UserRemovedEvent) error {
 	hlog.CtxDebugf(ctx, "处理用户移除事件: 部门ID=%s, 用户ID=%s", event.DeptID, event.UserIDs)
 
@@ -331,6 +378,9 @@ func (h *EventHandler) handleUserRemovedEvent(ctx context.Context, event *events
 		if err := h.userCache.InvalidateUserDepartmentCache(ctx, v); err != nil {
 			return fmt.Errorf("清除用户部门缓存失败: %w", err)
 		}
+		if err := h.deptCache.InvalidateUserDepartmentsCache(ctx, v); err != nil {
+			return fmt.Errorf("清除用户部门缓存失败: %w", err)
+		}
 	}
 
 	return nil
@@ -349,6 +399,9 @@ func (h *EventHandler) handleUserAssignedEvent(ctx context.Context, event *event
 		if err := h.userCache.InvalidateUserDepartmentCache(ctx, v); err != nil {
 			return fmt.Errorf("清除用户部门缓存失败: %w", err)
 		}
+		if err := h.deptCache.InvalidateUserDepartmentsCache(ctx, v); err != nil {
+			return fmt.Errorf("清除用户部门缓存失败: %w", err)
+		}
 	}
 	return nil
 }
@@ -371,6 +424,24 @@ func (h *EventHandler) handleUserTransferredEvent(ctx context.Context, event *ev
 	if err := h.userCache.InvalidateUserDepartmentCache(ctx, event.UserID); err != nil {
 		return fmt.Errorf("清除用户部门缓存失败: %w", err)
 	}
+	if err := h.deptCache.InvalidateUserDepartmentsCache(ctx, event.UserID); err != nil {
+		return fmt.Errorf("清除用户部门缓存失败: %w", err)
+	}
+
+	return nil
+}
+
+func (h *EventHandler) handleUserPrimaryDeptChangedEvent(ctx context.Context, event *events.UserPrimaryDeptChangedEvent) error {
+	hlog.CtxDebugf(ctx, "处理用户主部门变更事件: 用户ID=%s, 原主部门ID=%s, 新主部门ID=%s",
+		event.UserID, event.FromDeptID, event.DeptID)
+
+	// 清除用户的部门缓存
+	if err := h.userCache.InvalidateUserDepartmentCache(ctx, event.UserID); err != nil {
+		return fmt.Errorf("清除用户部门缓存失败: %w", err)
+	}
+	if err := h.deptCache.InvalidateUserDepartmentsCache(ctx, event.UserID); err != nil {
+		return fmt.Errorf("清除用户部门缓存失败: %w", err)
+	}
 
 	return nil
 }
@@ -422,6 +493,50 @@ func (h *EventHandler) handleDepartmentEvent(ctx context.Context, event *events.
 	return nil
 }
 
+// 岗位相关事件处理
+func (h *EventHandler) handlePositionEvent(ctx context.Context, event *events.PositionEvent) error {
+	hlog.CtxDebugf(ctx, "处理岗位事件: %s, 岗位ID=%s", event.EventName(), event.PositionID)
+
+	switch event.EventName() {
+	case events.PositionUpdated, events.PositionDeleted:
+		// 清除岗位基本信息缓存
+		if err := h.positionCache.InvalidateCache(ctx, event.PositionID); err != nil {
+			return fmt.Errorf("清除岗位缓存失败: %w", err)
+		}
+		// 清除岗位下用户的岗位缓存
+		userIDs, err := h.positionCache.GetPositionUserIDs(ctx, []string{event.PositionID})
+		if err != nil {
+			return fmt.Errorf("获取岗位用户列表失败: %w", err)
+		}
+		for _, userID := range userIDs {
+			if err := h.positionCache.InvalidateUserPositionCache(ctx, userID); err != nil {
+				return fmt.Errorf("清除用户[%s]岗位缓存失败: %w", userID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *EventHandler) handleUserPositionChangedEvent(ctx context.Context, event *events.UserPositionChangedEvent) error {
+	hlog.CtxDebugf(ctx, "处理用户岗位变更事件: 用户ID=%s, 岗位ID=%s", event.UserID, event.PositionIDs)
+
+	// 1. 清除用户的岗位缓存
+	if err := h.positionCache.InvalidateUserPositionCache(ctx, event.UserID); err != nil {
+		return fmt.Errorf("清除用户岗位缓存失败: %w", err)
+	}
+
+	// 2. 清除用户缓存及用户列表缓存
+	if err := h.userCache.InvalidateUserCache(ctx, event.UserID); err != nil {
+		return fmt.Errorf("清除用户缓存失败: %w", err)
+	}
+	if err := h.userCache.InvalidateUserListCache(ctx); err != nil {
+		return fmt.Errorf("清除用户列表缓存失败: %w", err)
+	}
+
+	return nil
+}
+
 // 数据权限相关事件处理
 func (h *EventHandler) handleDataPermissionEvent(ctx context.Context, event *events.DataPermissionEvent) error {
 	hlog.CtxDebugf(ctx, "处理数据权限事件: %s, 角色ID=%d", event.EventName(), event.Permission.RoleID)
@@ -500,6 +615,10 @@ func (h *EventHandler) handleTenantEvent(ctx context.Context, event *events.Tena
 		if err := h.deptCache.InvalidateTenantDepartmentCache(ctx, event.TenantID); err != nil {
 			return fmt.Errorf("清除租户部门缓存失败: %w", err)
 		}
+		// 清除租户下所有岗位缓存
+		if err := h.positionCache.InvalidateTenantPositionCache(ctx, event.TenantID); err != nil {
+			return fmt.Errorf("清除租户岗位缓存失败: %w", err)
+		}
 
 	case events.TenantLocked, events.TenantUnlocked:
 		// 租户锁定状态变更时清除租户状态缓存
@@ -0,0 +1,17 @@
+package keys
+
+import "fmt"
+
+const (
+	positionPrefix = "position:"
+)
+
+// PositionKey 岗位缓存key
+func PositionKey(tenantID string, positionID string) string {
+	return fmt.Sprintf("%s%s:%s", positionPrefix, tenantID, positionID)
+}
+
+// UserPositionsKey 用户岗位缓存key
+func UserPositionsKey(tenantID string, userID string) string {
+	return fmt.Sprintf("%s%s:user:%s:positions", positionPrefix, tenantID, userID)
+}
@@ -6,29 +6,59 @@ const (
 	userPrefix = "sys_user_cache:"
 )
 
-// UserKey 用户缓存key
-func UserKey(userID string) string {
-	return fmt.Sprintf("%s:%s", userPrefix, userID)
+// UserKey 用户缓存key, 用户详情中的角色与租户相关
+func UserKey(tenantID, userID string) string {
+	return UserDetailPrefix(userID) + tenantID
 }
 
-// UserPermissionsKey 用户权限缓存key
-func UserPermissionsKey(userID string) string {
-	return fmt.Sprintf("user:permissions:%s", userID)
+// UserDetailPrefix 用户所有租户下的详情缓存前缀
+func UserDetailPrefix(userID string) string {
+	return fmt.Sprintf("%s:%s:", userPrefix, userID)
+}
+
+// UserPermissionsKey 用户权限缓存key, 用户在不同租户下权限不同
+func UserPermissionsKey(tenantID, userID string) string {
+	return UserPermissionsPrefix(userID) + tenantID
+}
+
+// UserPermissionsPrefix 用户所有租户下的权限缓存前缀
+func UserPermissionsPrefix(userID string) string {
+	return fmt.Sprintf("user:permissions:%s:", userID)
 }
 
 // UserRolesKey 用户角色缓存key
-func UserRolesKey(userID string) string {
-	return fmt.Sprintf("%s:roles:%s", userPrefix, userID)
+func UserRolesKey(tenantID, userID string) string {
+	return UserRolesPrefix(userID) + tenantID
+}
+
+// UserRolesPrefix 用户所有租户下的角色缓存前缀
+func UserRolesPrefix(userID string) string {
+	return fmt.Sprintf("%s:roles:%s:", userPrefix, userID)
 }
 
 // UserMenusKey 用户菜单缓存key
-func UserMenusKey(userID string) string {
-	return fmt.Sprintf("user:menus:%s", userID)
+func UserMenusKey(tenantID, userID string) string {
+	return UserMenusPrefix(userID) + tenantID
+}
+
+// UserMenusPrefix 用户所有租户下的菜单缓存前缀
+func UserMenusPrefix(userID string) string {
+	return fmt.Sprintf("user:menus:%s:", userID)
+}
+
+// UserButtonsKey 用户按钮权限缓存key, 放在菜单前缀下随菜单缓存一起清除
+func UserButtonsKey(tenantID, userID string) string {
+	return UserMenusPrefix(userID) + "buttons:" + tenantID
 }
 
 // UserRoleCodesKey 用户角色编码缓存key
-func UserRoleCodesKey(userID string) string {
-	return fmt.Sprintf("%s:role:codes:%s", userPrefix, userID)
+func UserRoleCodesKey(tenantID, userID string) string {
+	return UserRoleCodesPrefix(userID) + tenantID
+}
+
+// UserRoleCodesPrefix 用户所有租户下的角色编码缓存前缀
+func UserRoleCodesPrefix(userID string) string {
+	return fmt.Sprintf("%s:role:codes:%s:", userPrefix, userID)
 }
 
 // UserDepartmentKey 用户部门缓存key
@@ -36,14 +66,14 @@ func UserDepartmentKey(userID string) string {
 	return fmt.Sprintf("user:department:%s", userID)
 }
 
-// UserKeys 生成用户相关的所有缓存key
-func UserKeys(userID string) []string {
+// UserPrefixes 生成用户所有租户下与租户相关的缓存前缀
+func UserPrefixes(userID string) []string {
 	return []string{
-		UserKey(userID),
-		UserPermissionsKey(userID),
-		UserRolesKey(userID),
-		UserMenusKey(userID),
-		UserRoleCodesKey(userID),
+		UserDetailPrefix(userID),
+		UserPermissionsPrefix(userID),
+		UserRolesPrefix(userID),
+		UserMenusPrefix(userID),
+		UserRoleCodesPrefix(userID),
 	}
 }
 
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query/cache/keys"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query/impl"
+	dCache "github.com/ares-cloud/ares-ddd-admin/internal/infrastructure/database/cache"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+)
+
+type PositionQueryCache struct {
+	next      *impl.PositionQueryService
+	decorator *dCache.CacheDecorator
+}
+
+func NewPositionQueryCache(
+	next *impl.PositionQueryService,
+	decorator *dCache.CacheDecorator,
+) *PositionQueryCache {
+	return &PositionQueryCache{
+		next:      next,
+		decorator: decorator,
+	}
+}
+
+// GetPosition 获取岗位信息(带缓存)
+func (c *PositionQueryCache) GetPosition(ctx context.Context, id string) (*dto.PositionDto, error) {
+	tenantID := actx.GetTenantId(ctx)
+	key := keys.PositionKey(tenantID, id)
+	var position *dto.PositionDto
+	err := c.decorator.Cached(ctx, key, &position, func() error {
+		var err error
+		position, err = c.next.GetPosition(ctx, id)
+		return err
+	})
+	return position, err
+}
+
+// GetUserPositions 获取用户岗位列表(带缓存)
+func (c *PositionQueryCache) GetUserPositions(ctx context.Context, userID string) ([]*dto.UserPositionDto, error) {
+	tenantID := actx.GetTenantId(ctx)
+	key := keys.UserPositionsKey(tenantID, userID)
+	var positions []*dto.UserPositionDto
+	err := c.decorator.Cached(ctx, key, &positions, func() error {
+		var err error
+		positions, err = c.next.GetUserPositions(ctx, userID)
+		return err
+	})
+	return positions, err
+}
+
+// 列表查询不缓存,直接透传
+func (c *PositionQueryCache) FindPositions(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.PositionDto, error) {
+	return c.next.FindPositions(ctx, qb)
+}
+
+func (c *PositionQueryCache) CountPositions(ctx context.Context, qb *db_query.QueryBuilder) (int64, error) {
+	return c.next.CountPositions(ctx, qb)
+}
+
+func (c *PositionQueryCache) GetPositionUserIDs(ctx context.Context, positionIDs []string) ([]string, error) {
+	return c.next.GetPositionUserIDs(ctx, positionIDs)
+}
+
+// InvalidateCache 清除岗位缓存
+func (c *PositionQueryCache) InvalidateCache(ctx context.Context, positionID string) error {
+	tenantID := actx.GetTenantId(ctx)
+	return c.decorator.InvalidateCache(ctx, keys.PositionKey(tenantID, positionID))
+}
+
+// InvalidateUserPositionCache 清除用户岗位缓存
+func (c *PositionQueryCache) InvalidateUserPositionCache(ctx context.Context, userID string) error {
+	tenantID := actx.GetTenantId(ctx)
+	return c.decorator.InvalidateCache(ctx, keys.UserPositionsKey(tenantID, userID))
+}
+
+// InvalidateTenantPositionCache 清除租户下所有岗位缓存
+func (c *PositionQueryCache) InvalidateTenantPositionCache(ctx context.Context, tenantID string) error {
+	return c.decorator.InvalidateTenantTypeCache(ctx, tenantID, "position")
+}
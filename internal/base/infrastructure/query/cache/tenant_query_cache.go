@@ -45,6 +45,24 @@ func (c *TenantQueryCache) CountTenants(ctx context.Context, qb *db_query.QueryB
 	return c.next.CountTenants(ctx, qb)
 }
 
+// ListTenantJobs 任务进度实时变化, 不缓存
+func (c *TenantQueryCache) ListTenantJobs(ctx context.Context, tenantID string) ([]*dto.TenantJobDto, error) {
+	return c.next.ListTenantJobs(ctx, tenantID)
+}
+
+func (c *TenantQueryCache) GetTenantJob(ctx context.Context, id string) (*dto.TenantJobDto, error) {
+	return c.next.GetTenantJob(ctx, id)
+}
+
+// ListTenantTemplates 模板由平台维护且访问较少, 不缓存
+func (c *TenantQueryCache) ListTenantTemplates(ctx context.Context, name string) ([]*dto.TenantTemplateDto, error) {
+	return c.next.ListTenantTemplates(ctx, name)
+}
+
+func (c *TenantQueryCache) GetTenantTemplate(ctx context.Context, id string) (*dto.TenantTemplateDto, error) {
+	return c.next.GetTenantTemplate(ctx, id)
+}
+
 // GetTenantPermissions 获取租户权限(带缓存)
 func (c *TenantQueryCache) GetTenantPermissions(ctx context.Context, tenantID string) ([]*dto.PermissionsDto, error) {
 	key := keys.TenantPermissionsKey(tenantID)
@@ -31,7 +31,7 @@ func (c *UserQueryCache) GetSuperAdmin(ctx context.Context) (*dto.UserInfoDto, e
 }
 
 func (c *UserQueryCache) GetUser(ctx context.Context, id string) (*dto.UserDto, error) {
-	key := keys.UserKey(id)
+	key := keys.UserKey(actx.GetTenantId(ctx), id)
 	var user *dto.UserDto
 	err := c.decorator.Cached(ctx, key, &user, func() error {
 		var err error
@@ -41,8 +41,18 @@ func (c *UserQueryCache) GetUser(ctx context.Context, id string) (*dto.UserDto,
 	return user, err
 }
 
+// GetUserTenants 获取用户可访问的租户列表(不缓存)
+func (c *UserQueryCache) GetUserTenants(ctx context.Context, userID string) ([]*dto.UserTenantDto, error) {
+	return c.next.GetUserTenants(ctx, userID)
+}
+
+// GetUserTenantInvitations 获取用户待接受的租户邀请(不缓存)
+func (c *UserQueryCache) GetUserTenantInvitations(ctx context.Context, userID string) ([]*dto.UserTenantDto, error) {
+	return c.next.GetUserTenantInvitations(ctx, userID)
+}
+
 func (c *UserQueryCache) GetUserPermissions(ctx context.Context, userID string) ([]string, error) {
-	key := keys.UserPermissionsKey(userID)
+	key := keys.UserPermissionsKey(actx.GetTenantId(ctx), userID)
 	var permissions []string
 	err := c.decorator.Cached(ctx, key, &permissions, func() error {
 		var err error
@@ -53,7 +63,7 @@ func (c *UserQueryCache) GetUserPermissions(ctx context.Context, userID string)
 }
 
 func (c *UserQueryCache) GetUserRoles(ctx context.Context, userID string) ([]*dto.RoleDto, error) {
-	key := keys.UserRolesKey(userID)
+	key := keys.UserRolesKey(actx.GetTenantId(ctx), userID)
 	var roles []*dto.RoleDto
 	err := c.decorator.Cached(ctx, key, &roles, func() error {
 		var err error
@@ -67,7 +77,7 @@ func (c *UserQueryCache) GetUserTreeMenus(ctx context.Context, userID string) ([
 	if actx.IsSuperAdmin(ctx) {
 		return c.next.GetUserTreeMenus(ctx, userID)
 	}
-	key := keys.UserMenusKey(userID)
+	key := keys.UserMenusKey(actx.GetTenantId(ctx), userID)
 	var menus []*dto.PermissionsTreeDto
 	err := c.decorator.Cached(ctx, key, &menus, func() error {
 		var err error
@@ -77,11 +87,29 @@ func (c *UserQueryCache) GetUserTreeMenus(ctx context.Context, userID string) ([
 	return menus, err
 }
 
+func (c *UserQueryCache) GetUserButtons(ctx context.Context, userID string) ([]string, error) {
+	if actx.IsSuperAdmin(ctx) {
+		return c.next.GetUserButtons(ctx, userID)
+	}
+	key := keys.UserButtonsKey(actx.GetTenantId(ctx), userID)
+	var buttons []string
+	err := c.decorator.Cached(ctx, key, &buttons, func() error {
+		var err error
+		buttons, err = c.next.GetUserButtons(ctx, userID)
+		return err
+	})
+	return buttons, err
+}
+
 // 列表查询不缓存,直接透传
 func (c *UserQueryCache) FindUsers(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.UserDto, error) {
 	return c.next.FindUsers(ctx, qb)
 }
 
+func (c *UserQueryCache) FindUsersForExport(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.UserExportDto, error) {
+	return c.next.FindUsersForExport(ctx, qb)
+}
+
 func (c *UserQueryCache) CountUsers(ctx context.Context, qb *db_query.QueryBuilder) (int64, error) {
 	return c.next.CountUsers(ctx, qb)
 }
@@ -103,7 +131,7 @@ func (c *UserQueryCache) GetUserDepartments(ctx context.Context, userID string)
 
 // GetUserRolesCode 获取用户角色编码列表(带缓存)
 func (c *UserQueryCache) GetUserRolesCode(ctx context.Context, userID string) ([]string, error) {
-	key := keys.UserRoleCodesKey(userID)
+	key := keys.UserRoleCodesKey(actx.GetTenantId(ctx), userID)
 	var roleCodes []string
 	err := c.decorator.Cached(ctx, key, &roleCodes, func() error {
 		var err error
@@ -115,14 +143,12 @@ func (c *UserQueryCache) GetUserRolesCode(ctx context.Context, userID string) ([
 
 // InvalidateUserCache 使用户缓存失效
 func (c *UserQueryCache) InvalidateUserCache(ctx context.Context, userID string) error {
-	keys := []string{
-		keys.UserKey(userID),
-		keys.UserPermissionsKey(userID),
-		keys.UserRolesKey(userID),
-		keys.UserMenusKey(userID),
-		keys.UserRoleCodesKey(userID),
+	for _, prefix := range keys.UserPrefixes(userID) {
+		if err := c.decorator.InvalidatePrefix(ctx, prefix); err != nil {
+			return err
+		}
 	}
-	return c.decorator.InvalidateCache(ctx, keys...)
+	return nil
 }
 
 // InvalidateUserListCache 使用户列表缓存失效
@@ -132,12 +158,12 @@ func (c *UserQueryCache) InvalidateUserListCache(ctx context.Context) error {
 
 // InvalidateUserPermissionCache 清除用户权限缓存
 func (c *UserQueryCache) InvalidateUserPermissionCache(ctx context.Context, userID string) error {
-	return c.decorator.InvalidateCache(ctx, keys.UserPermissionsKey(userID))
+	return c.decorator.InvalidatePrefix(ctx, keys.UserPermissionsPrefix(userID))
 }
 
 // InvalidateUserMenuCache 清除用户菜单缓存
 func (c *UserQueryCache) InvalidateUserMenuCache(ctx context.Context, userID string) error {
-	return c.decorator.InvalidateCache(ctx, keys.UserMenusKey(userID))
+	return c.decorator.InvalidatePrefix(ctx, keys.UserMenusPrefix(userID))
 }
 
 // InvalidateUserDepartmentCache 清除用户部门缓存
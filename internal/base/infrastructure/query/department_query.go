@@ -16,6 +16,12 @@ type IDepartmentQueryService interface {
 
 	// 树形结构查询
 	GetDepartmentTree(ctx context.Context, parentID string) ([]*dto.DepartmentTreeDto, error)
+	// GetDepartmentAncestors 获取部门的全部上级部门, 从根部门开始
+	GetDepartmentAncestors(ctx context.Context, id string) ([]*dto.DepartmentDto, error)
+	// CountSubtreeUsers 统计部门及其全部下级部门的用户数, 同一用户只计一次
+	CountSubtreeUsers(ctx context.Context, id string) (int64, error)
+	// GetOrgChart 获取组织架构图, rootID 为空时返回全部部门
+	GetOrgChart(ctx context.Context, rootID string) ([]*dto.OrgChartNodeDto, error)
 
 	// 用户部门查询
 	GetUserDepartments(ctx context.Context, userID string) ([]*dto.DepartmentDto, error)
@@ -0,0 +1,167 @@
+package impl
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+)
+
+type AccessReviewQueryService struct {
+	repo            repository.ISysAccessReviewRepo
+	userRepo        repository.ISysUserRepo
+	roleRepo        repository.ISysRoleRepo
+	permissionsRepo repository.IPermissionsRepo
+}
+
+func NewAccessReviewQueryService(
+	repo repository.ISysAccessReviewRepo,
+	userRepo repository.ISysUserRepo,
+	roleRepo repository.ISysRoleRepo,
+	permissionsRepo repository.IPermissionsRepo,
+) *AccessReviewQueryService {
+	return &AccessReviewQueryService{
+		repo:            repo,
+		userRepo:        userRepo,
+		roleRepo:        roleRepo,
+		permissionsRepo: permissionsRepo,
+	}
+}
+
+func (s *AccessReviewQueryService) Find(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.AccessReviewDto, error) {
+	list, err := s.repo.Find(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*dto.AccessReviewDto, 0, len(list))
+	for _, item := range list {
+		result = append(result, toAccessReviewDto(item))
+	}
+	return result, nil
+}
+
+func (s *AccessReviewQueryService) Count(ctx context.Context, qb *db_query.QueryBuilder) (int64, error) {
+	return s.repo.Count(ctx, qb)
+}
+
+func (s *AccessReviewQueryService) GetByID(ctx context.Context, id string) (*dto.AccessReviewDto, error) {
+	campaign, err := s.repo.FindById(ctx, id)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toAccessReviewDto(campaign), nil
+}
+
+func (s *AccessReviewQueryService) FindItems(ctx context.Context, campaignID string) ([]*dto.AccessReviewItemDto, error) {
+	list, err := s.repo.ListItems(ctx, campaignID, "")
+	if err != nil {
+		return nil, err
+	}
+	return s.toItemDtos(ctx, list)
+}
+
+func (s *AccessReviewQueryService) FindOpenItemsByReviewer(ctx context.Context, reviewerID string) ([]*dto.AccessReviewItemDto, error) {
+	list, err := s.repo.ListOpenItemsByReviewer(ctx, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toItemDtos(ctx, list)
+}
+
+// toItemDtos 补充复核项的用户名、角色名称和权限编码
+func (s *AccessReviewQueryService) toItemDtos(ctx context.Context, list []*entity.AccessReviewItem) ([]*dto.AccessReviewItemDto, error) {
+	var (
+		userIDs []string
+		roleIDs []int64
+		permIDs []int64
+	)
+	for _, item := range list {
+		if item.UserID != "" {
+			userIDs = append(userIDs, item.UserID)
+		}
+		roleIDs = append(roleIDs, item.RoleID)
+		if item.PermissionID > 0 {
+			permIDs = append(permIDs, item.PermissionID)
+		}
+	}
+	usernames := make(map[string]string)
+	if len(userIDs) > 0 {
+		users, err := s.userRepo.FindByIds(ctx, userIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			usernames[user.ID] = user.Username
+		}
+	}
+	roleNames := make(map[int64]string)
+	if len(roleIDs) > 0 {
+		roles, err := s.roleRepo.FindByIds(ctx, roleIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range roles {
+			roleNames[role.ID] = role.Name
+		}
+	}
+	permCodes := make(map[int64]string)
+	if len(permIDs) > 0 {
+		perms, err := s.permissionsRepo.FindByIds(ctx, permIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, perm := range perms {
+			permCodes[perm.ID] = perm.Code
+		}
+	}
+
+	result := make([]*dto.AccessReviewItemDto, 0, len(list))
+	for _, item := range list {
+		result = append(result, &dto.AccessReviewItemDto{
+			ID:             item.ID,
+			CampaignID:     item.CampaignID,
+			Kind:           item.Kind,
+			UserID:         item.UserID,
+			Username:       usernames[item.UserID],
+			RoleID:         item.RoleID,
+			RoleName:       roleNames[item.RoleID],
+			PermissionID:   item.PermissionID,
+			PermissionCode: permCodes[item.PermissionID],
+			ReviewerID:     item.ReviewerID,
+			Decision:       item.Decision,
+			Comment:        item.Comment,
+			DecidedAt:      item.DecidedAt,
+			AppliedAt:      item.AppliedAt,
+		})
+	}
+	return result, nil
+}
+
+func toAccessReviewDto(e *entity.AccessReview) *dto.AccessReviewDto {
+	d := &dto.AccessReviewDto{
+		ID:        e.ID,
+		Name:      e.Name,
+		ScopeType: e.ScopeType,
+		DeptID:    e.DeptID,
+		Status:    e.Status,
+		DueAt:     e.DueAt,
+		OwnerID:   e.OwnerID,
+		ClosedBy:  e.ClosedBy,
+		ClosedAt:  e.ClosedAt,
+		CreatedAt: e.CreatedAt,
+	}
+	for _, v := range strings.Split(e.RoleIDs, ",") {
+		if id, err := strconv.ParseInt(v, 10, 64); err == nil {
+			d.RoleIDs = append(d.RoleIDs, id)
+		}
+	}
+	return d
+}
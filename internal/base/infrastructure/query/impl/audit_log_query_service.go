@@ -0,0 +1,31 @@
+package impl
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+)
+
+type AuditLogQueryService struct {
+	repo repository.IAuditLogRepo
+}
+
+func NewAuditLogQueryService(repo repository.IAuditLogRepo) *AuditLogQueryService {
+	return &AuditLogQueryService{
+		repo: repo,
+	}
+}
+
+func (s *AuditLogQueryService) Find(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.AuditLogDto, error) {
+	logs, err := s.repo.Find(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToAuditLogDtoList(logs), nil
+}
+
+func (s *AuditLogQueryService) Count(ctx context.Context, qb *db_query.QueryBuilder) (int64, error) {
+	return s.repo.Count(ctx, qb)
+}
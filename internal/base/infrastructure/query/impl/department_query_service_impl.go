@@ -2,9 +2,11 @@ package impl
 
 import (
 	"context"
+	"sort"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/converter"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
 )
@@ -53,56 +55,129 @@ func (d *DepartmentQueryService) CountDepartments(ctx context.Context, qb *db_qu
 	return d.deptRepo.Count(ctx, qb)
 }
 
-// GetDepartmentTree 获取部门树
+// GetDepartmentTree 获取部门树, 按层级路径一次查出全部下级部门后组装
 func (d *DepartmentQueryService) GetDepartmentTree(ctx context.Context, parentID string) ([]*dto.DepartmentTreeDto, error) {
-	// 1. 获取直接子部门
-	children, err := d.deptRepo.GetByParentID(ctx, parentID)
+	descendants, err := d.deptRepo.GetDescendants(ctx, parentID)
 	if err != nil {
 		return nil, err
 	}
+	sort.SliceStable(descendants, func(i, j int) bool {
+		return descendants[i].Sequence < descendants[j].Sequence
+	})
 
-	// 2. 构建部门树
-	tree := make([]*dto.DepartmentTreeDto, len(children))
-	// 复制一份数据，避免修改原始实体
-	for i, child := range children {
-		tree[i] = d.deptConverter.ToTreeDTO(child)
+	nodes := make(map[string]*dto.DepartmentTreeDto, len(descendants))
+	for _, dept := range descendants {
+		nodes[dept.ID] = d.deptConverter.ToTreeDTO(dept)
 	}
-
-	for _, child := range tree {
-		// 递归获取子部门
-		subChildren, err := d.getChildrenRecursively(ctx, child.ID)
-		if err != nil {
-			return nil, err
+	tree := make([]*dto.DepartmentTreeDto, 0)
+	for _, dept := range descendants {
+		node := nodes[dept.ID]
+		if dept.ParentID == parentID {
+			tree = append(tree, node)
+			continue
+		}
+		if parent, ok := nodes[dept.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
 		}
-		// 添加子部门
-		child.Children = subChildren
 	}
-
 	return tree, nil
 }
 
-// getChildrenRecursively 递归获取子部门
-func (d *DepartmentQueryService) getChildrenRecursively(ctx context.Context, parentID string) ([]*dto.DepartmentTreeDto, error) {
-	children, err := d.deptRepo.GetByParentID(ctx, parentID)
+// GetDepartmentAncestors 获取部门的全部上级部门, 从根部门开始
+func (d *DepartmentQueryService) GetDepartmentAncestors(ctx context.Context, id string) ([]*dto.DepartmentDto, error) {
+	depts, err := d.deptRepo.GetAncestors(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	return d.deptConverter.ToDTOList(depts), nil
+}
 
-	// 复制一份数据，避免修改原始实体
-	result := make([]*dto.DepartmentTreeDto, len(children))
-	for i, child := range children {
-		result[i] = d.deptConverter.ToTreeDTO(child)
+// CountSubtreeUsers 统计部门及其全部下级部门的用户数
+func (d *DepartmentQueryService) CountSubtreeUsers(ctx context.Context, id string) (int64, error) {
+	return d.deptRepo.CountSubtreeUsers(ctx, id)
+}
+
+// GetOrgChart 获取组织架构图, 包含负责人、管理员和人数
+func (d *DepartmentQueryService) GetOrgChart(ctx context.Context, rootID string) ([]*dto.OrgChartNodeDto, error) {
+	// 1. 查询根部门及其全部下级部门
+	depts, err := d.deptRepo.GetDescendants(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+	topParentID := ""
+	if rootID != "" {
+		root, err := d.deptRepo.FindById(ctx, rootID)
+		if err != nil {
+			return nil, err
+		}
+		topParentID = root.ParentID
+		depts = append([]*entity.Department{root}, depts...)
 	}
+	sort.SliceStable(depts, func(i, j int) bool {
+		return depts[i].Sequence < depts[j].Sequence
+	})
 
-	for _, child := range result {
-		subChildren, err := d.getChildrenRecursively(ctx, child.ID)
+	// 2. 查询各部门人数和管理员姓名
+	counts, err := d.deptRepo.CountUsersGroupByDept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	adminIDs := make([]string, 0)
+	for _, dept := range depts {
+		if dept.AdminID != "" {
+			adminIDs = append(adminIDs, dept.AdminID)
+		}
+	}
+	adminNames := make(map[string]string, len(adminIDs))
+	if len(adminIDs) > 0 {
+		admins, err := d.userRepo.FindByIds(ctx, adminIDs)
 		if err != nil {
 			return nil, err
 		}
-		child.Children = subChildren
+		for _, admin := range admins {
+			adminNames[admin.ID] = admin.Name
+		}
+	}
+
+	// 3. 组装树并累加人数
+	nodes := make(map[string]*dto.OrgChartNodeDto, len(depts))
+	for _, dept := range depts {
+		nodes[dept.ID] = &dto.OrgChartNodeDto{
+			ID:        dept.ID,
+			ParentID:  dept.ParentID,
+			Code:      dept.Code,
+			Name:      dept.Name,
+			Leader:    dept.Leader,
+			AdminID:   dept.AdminID,
+			AdminName: adminNames[dept.AdminID],
+			Headcount: counts[dept.ID],
+			Children:  []*dto.OrgChartNodeDto{},
+		}
+	}
+	roots := make([]*dto.OrgChartNodeDto, 0)
+	for _, dept := range depts {
+		node := nodes[dept.ID]
+		if dept.ID == rootID || (rootID == "" && dept.ParentID == topParentID) {
+			roots = append(roots, node)
+			continue
+		}
+		if parent, ok := nodes[dept.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+	for _, root := range roots {
+		sumHeadcount(root)
 	}
+	return roots, nil
+}
 
-	return result, nil
+// sumHeadcount 累加下级部门人数
+func sumHeadcount(node *dto.OrgChartNodeDto) int64 {
+	node.TotalHeadcount = node.Headcount
+	for _, child := range node.Children {
+		node.TotalHeadcount += sumHeadcount(child)
+	}
+	return node.TotalHeadcount
 }
 
 // GetUserDepartments 获取用户部门
@@ -129,7 +204,26 @@ func (d *DepartmentQueryService) GetUserDepartments(ctx context.Context, userID
 		return nil, err
 	}
 
-	return d.deptConverter.ToDTOList(depts), nil
+	return sortUserDepartments(d.deptConverter.ToDTOList(depts), userDepts), nil
+}
+
+// sortUserDepartments 按用户部门关联排序并标记主部门, 主部门在前
+func sortUserDepartments(depts []*dto.DepartmentDto, userDepts []*entity.UserDepartment) []*dto.DepartmentDto {
+	deptMap := make(map[string]*dto.DepartmentDto, len(depts))
+	for _, dept := range depts {
+		deptMap[dept.ID] = dept
+	}
+	result := make([]*dto.DepartmentDto, 0, len(depts))
+	for _, ud := range userDepts {
+		dept, ok := deptMap[ud.DeptID]
+		if !ok {
+			continue
+		}
+		dept.IsPrimary = ud.IsPrimary
+		result = append(result, dept)
+		delete(deptMap, ud.DeptID)
+	}
+	return result
 }
 
 // GetDepartmentUsers 获取部门用户
@@ -0,0 +1,164 @@
+package impl
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hashchain"
+)
+
+// chainBatchSize 每个月份表每次读取的记录数
+const chainBatchSize = 500
+
+// chainLoader 读取月份表中序号大于 afterSeq 的记录
+type chainLoader func(ctx context.Context, tenantID string, month time.Time, afterSeq int64, limit int) ([]*hashchain.Entry, error)
+
+type LogChainQueryService struct {
+	chain   repository.ILogChainRepo
+	loaders map[string]chainLoader
+}
+
+func NewLogChainQueryService(
+	chain repository.ILogChainRepo,
+	opRepo repository.IOperationLogRepo,
+	loginRepo repository.ILoginLogRepo,
+) *LogChainQueryService {
+	return &LogChainQueryService{
+		chain: chain,
+		loaders: map[string]chainLoader{
+			entity.LogChainOperation: opRepo.FindChain,
+			entity.LogChainLogin:     loginRepo.FindChain,
+		},
+	}
+}
+
+func (s *LogChainQueryService) Verify(ctx context.Context, logType, tenantID string, anchors map[int64]string) (*dto.LogChainVerifyDto, error) {
+	load, ok := s.loaders[logType]
+	if !ok {
+		return nil, fmt.Errorf("unknown log type: %s", logType)
+	}
+	result := &dto.LogChainVerifyDto{TenantID: tenantID, LogType: logType, Valid: true}
+	head, err := s.chain.Head(ctx, logType, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if head == nil {
+		return result, nil
+	}
+	result.HeadSeq = head.Seq
+
+	start, err := time.Parse("200601", head.StartMonth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start month %q of log chain: %w", head.StartMonth, err)
+	}
+	// 日志按创建时间分表, 跨月时相邻序号可能在不同的表中, 同时读取各月份表并按序号合并
+	cursors := make([]*chainCursor, 0)
+	for m := start; !m.After(time.Now()); m = m.AddDate(0, 1, 0) {
+		cursors = append(cursors, &chainCursor{month: m})
+	}
+
+	v := hashchain.NewVerifier(anchors)
+	var brk *hashchain.Break
+	for v.Expected() <= head.Seq {
+		e, err := nextChainEntry(ctx, cursors, load, tenantID, v.Expected())
+		if err != nil {
+			return nil, err
+		}
+		if e == nil {
+			break
+		}
+		if brk = v.Next(e); brk != nil {
+			break
+		}
+	}
+	if brk == nil && v.Expected() > head.Seq {
+		// 已校验到链尾时, 各月份表中剩余的序号不大于链尾的记录均为重复记录
+		e, err := nextChainEntry(ctx, cursors, load, tenantID, head.Seq)
+		if err != nil {
+			return nil, err
+		}
+		if e != nil {
+			brk = v.Next(e)
+		}
+	}
+	if brk == nil {
+		brk = v.Finish(head.Seq, head.Hash)
+	}
+	result.Verified = v.Verified()
+	result.Valid = brk == nil
+	result.Break = brk
+	return result, nil
+}
+
+func (s *LogChainQueryService) Heads(ctx context.Context) ([]*hashchain.Head, error) {
+	heads, err := s.chain.Heads(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*hashchain.Head, 0, len(heads))
+	for _, h := range heads {
+		result = append(result, &hashchain.Head{TenantID: h.TenantID, LogType: h.LogType, Seq: h.Seq, Hash: h.Hash})
+	}
+	return result, nil
+}
+
+func (s *LogChainQueryService) Checkpoint(ctx context.Context, key ed25519.PrivateKey) (*hashchain.Checkpoint, error) {
+	heads, err := s.Heads(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cp := &hashchain.Checkpoint{CreatedAt: time.Now().Unix(), Heads: heads}
+	if err := cp.Sign(key); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// chainCursor 月份表的读取位置
+type chainCursor struct {
+	month time.Time
+	buf   []*hashchain.Entry
+	after int64
+	done  bool
+}
+
+func (c *chainCursor) peek(ctx context.Context, load chainLoader, tenantID string) (*hashchain.Entry, error) {
+	if len(c.buf) == 0 && !c.done {
+		entries, err := load(ctx, tenantID, c.month, c.after, chainBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) < chainBatchSize {
+			c.done = true
+		}
+		if len(entries) > 0 {
+			c.after = entries[len(entries)-1].Seq
+		}
+		c.buf = entries
+	}
+	if len(c.buf) == 0 {
+		return nil, nil
+	}
+	return c.buf[0], nil
+}
+
+// nextChainEntry 从各月份表中取出序号为 seq 的记录, 不存在时返回 nil
+// 序号小于 seq 的记录为已校验序号的重复记录, 同样返回, 由校验器报告断裂
+func nextChainEntry(ctx context.Context, cursors []*chainCursor, load chainLoader, tenantID string, seq int64) (*hashchain.Entry, error) {
+	for _, c := range cursors {
+		e, err := c.peek(ctx, load, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if e != nil && e.Seq <= seq {
+			c.buf = c.buf[1:]
+			return e, nil
+		}
+	}
+	return nil, nil
+}
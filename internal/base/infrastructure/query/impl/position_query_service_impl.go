@@ -0,0 +1,73 @@
+package impl
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/converter"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+)
+
+type PositionQueryService struct {
+	positionRepo      repository.ISysPositionRepo
+	positionConverter *converter.PositionConverter
+}
+
+func NewPositionQueryService(
+	positionRepo repository.ISysPositionRepo,
+	positionConverter *converter.PositionConverter,
+) *PositionQueryService {
+	return &PositionQueryService{
+		positionRepo:      positionRepo,
+		positionConverter: positionConverter,
+	}
+}
+
+// GetPosition 获取岗位详情
+func (p *PositionQueryService) GetPosition(ctx context.Context, id string) (*dto.PositionDto, error) {
+	position, err := p.positionRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return p.positionConverter.ToDTO(position), nil
+}
+
+// FindPositions 查询岗位列表
+func (p *PositionQueryService) FindPositions(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.PositionDto, error) {
+	positions, err := p.positionRepo.Find(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+	return p.positionConverter.ToDTOList(positions), nil
+}
+
+// CountPositions 统计岗位数量
+func (p *PositionQueryService) CountPositions(ctx context.Context, qb *db_query.QueryBuilder) (int64, error) {
+	return p.positionRepo.Count(ctx, qb)
+}
+
+// GetUserPositions 获取用户任职岗位, 主岗在前
+func (p *PositionQueryService) GetUserPositions(ctx context.Context, userID string) ([]*dto.UserPositionDto, error) {
+	relations, err := p.positionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(relations) == 0 {
+		return make([]*dto.UserPositionDto, 0), nil
+	}
+	ids := make([]string, 0, len(relations))
+	for _, relation := range relations {
+		ids = append(ids, relation.PositionID)
+	}
+	positions, err := p.positionRepo.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return p.positionConverter.ToUserPositionDTOList(relations, positions), nil
+}
+
+// GetPositionUserIDs 获取岗位下的用户ID
+func (p *PositionQueryService) GetPositionUserIDs(ctx context.Context, positionIDs []string) ([]string, error) {
+	return p.positionRepo.GetUserIDsByPositionIDs(ctx, positionIDs)
+}
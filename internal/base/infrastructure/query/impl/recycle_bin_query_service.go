@@ -0,0 +1,34 @@
+package impl
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/infrastructure/configs"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+)
+
+type RecycleBinQueryService struct {
+	repo      repository.ISysRecycleBinRepo
+	retention int64
+}
+
+func NewRecycleBinQueryService(repo repository.ISysRecycleBinRepo, conf *configs.Bootstrap) *RecycleBinQueryService {
+	return &RecycleBinQueryService{
+		repo:      repo,
+		retention: int64(conf.RecycleBin.GetRetentionDays()) * 24 * 3600,
+	}
+}
+
+func (s *RecycleBinQueryService) Find(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.RecycleItemDto, error) {
+	list, err := s.repo.Find(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToRecycleItemDtoList(list, s.retention), nil
+}
+
+func (s *RecycleBinQueryService) Count(ctx context.Context, qb *db_query.QueryBuilder) (int64, error) {
+	return s.repo.Count(ctx, qb)
+}
@@ -0,0 +1,68 @@
+package impl
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+)
+
+type RoleConstraintQueryService struct {
+	repo     repository.ISysRoleConstraintRepo
+	roleRepo repository.ISysRoleRepo
+}
+
+func NewRoleConstraintQueryService(repo repository.ISysRoleConstraintRepo, roleRepo repository.ISysRoleRepo) *RoleConstraintQueryService {
+	return &RoleConstraintQueryService{
+		repo:     repo,
+		roleRepo: roleRepo,
+	}
+}
+
+func (s *RoleConstraintQueryService) FindAll(ctx context.Context) ([]*dto.RoleConstraintDto, error) {
+	list, err := s.repo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.RoleConstraintDto, 0, len(list))
+	var allIDs []int64
+	for _, item := range list {
+		c := &dto.RoleConstraintDto{
+			ID:          item.ID,
+			Name:        item.Name,
+			Type:        item.Type,
+			Limit:       item.MaxCount,
+			Description: item.Description,
+			CreatedAt:   item.CreatedAt,
+			UpdatedAt:   item.UpdatedAt,
+		}
+		for _, v := range strings.Split(item.RoleIDs, ",") {
+			if id, err := strconv.ParseInt(v, 10, 64); err == nil {
+				c.RoleIDs = append(c.RoleIDs, id)
+			}
+		}
+		allIDs = append(allIDs, c.RoleIDs...)
+		result = append(result, c)
+	}
+	if len(allIDs) == 0 {
+		return result, nil
+	}
+
+	roles, err := s.roleRepo.FindByIds(ctx, allIDs)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(roles))
+	for _, role := range roles {
+		names[role.ID] = role.Name
+	}
+	for _, c := range result {
+		for _, id := range c.RoleIDs {
+			c.RoleNames = append(c.RoleNames, names[id])
+		}
+	}
+	return result, nil
+}
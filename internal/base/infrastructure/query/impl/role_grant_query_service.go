@@ -0,0 +1,114 @@
+package impl
+
+import (
+	"context"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+)
+
+type RoleGrantQueryService struct {
+	repo     repository.ISysRoleGrantRepo
+	roleRepo repository.ISysRoleRepo
+}
+
+func NewRoleGrantQueryService(repo repository.ISysRoleGrantRepo, roleRepo repository.ISysRoleRepo) *RoleGrantQueryService {
+	return &RoleGrantQueryService{
+		repo:     repo,
+		roleRepo: roleRepo,
+	}
+}
+
+func (s *RoleGrantQueryService) GetUserGrants(ctx context.Context, userID string) ([]*dto.RoleGrantDto, error) {
+	list, err := s.repo.ListUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	roleIDs := make([]int64, 0, len(list))
+	for _, item := range list {
+		roleIDs = append(roleIDs, item.RoleID)
+	}
+	roles, err := s.rolesByID(ctx, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	result := make([]*dto.RoleGrantDto, 0, len(list))
+	for _, item := range list {
+		grant := &dto.RoleGrantDto{
+			RoleID:     item.RoleID,
+			StartTime:  item.StartTime,
+			ExpireTime: item.ExpireTime,
+			Active:     (item.StartTime == 0 || item.StartTime <= now) && (item.ExpireTime == 0 || item.ExpireTime > now),
+		}
+		if role, ok := roles[item.RoleID]; ok {
+			grant.RoleCode = role.Code
+			grant.RoleName = role.Name
+		}
+		result = append(result, grant)
+	}
+	return result, nil
+}
+
+func (s *RoleGrantQueryService) FindRequests(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.RoleRequestDto, error) {
+	list, err := s.repo.Find(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+	roleIDs := make([]int64, 0, len(list))
+	for _, item := range list {
+		roleIDs = append(roleIDs, item.RoleID)
+	}
+	roles, err := s.rolesByID(ctx, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.RoleRequestDto, 0, len(list))
+	for _, item := range list {
+		req := &dto.RoleRequestDto{
+			ID:            item.ID,
+			UserID:        item.UserID,
+			RoleID:        item.RoleID,
+			StartTime:     item.StartTime,
+			ExpireTime:    item.ExpireTime,
+			Reason:        item.Reason,
+			Status:        item.Status,
+			RequestedBy:   item.RequestedBy,
+			ReviewedBy:    item.ReviewedBy,
+			ReviewComment: item.ReviewComment,
+			ReviewedAt:    item.ReviewedAt,
+			CreatedAt:     item.CreatedAt,
+		}
+		if role, ok := roles[item.RoleID]; ok {
+			req.RoleCode = role.Code
+			req.RoleName = role.Name
+		}
+		result = append(result, req)
+	}
+	return result, nil
+}
+
+func (s *RoleGrantQueryService) CountRequests(ctx context.Context, qb *db_query.QueryBuilder) (int64, error) {
+	return s.repo.Count(ctx, qb)
+}
+
+// rolesByID 按ID查询角色
+func (s *RoleGrantQueryService) rolesByID(ctx context.Context, ids []int64) (map[int64]*entity.Role, error) {
+	result := make(map[int64]*entity.Role, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+	roles, err := s.roleRepo.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		result[role.ID] = role
+	}
+	return result, nil
+}
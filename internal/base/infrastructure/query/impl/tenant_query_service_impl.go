@@ -6,6 +6,7 @@ import (
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/converter"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
 )
 
@@ -15,6 +16,8 @@ type TenantQueryService struct {
 	converter            *converter.TenantConverter
 	permissionsRepo      repository.IPermissionsRepo
 	permissionsConverter *converter.PermissionsConverter
+	jobRepo              repository.ISysTenantJobRepo
+	templateRepo         repository.ISysTenantTemplateRepo
 }
 
 func NewTenantQueryService(
@@ -23,6 +26,8 @@ func NewTenantQueryService(
 	permissionsRepo repository.IPermissionsRepo,
 	converter *converter.TenantConverter,
 	permissionsConverter *converter.PermissionsConverter,
+	jobRepo repository.ISysTenantJobRepo,
+	templateRepo repository.ISysTenantTemplateRepo,
 ) *TenantQueryService {
 	return &TenantQueryService{
 		tenantRepo:           tenantRepo,
@@ -30,6 +35,8 @@ func NewTenantQueryService(
 		converter:            converter,
 		permissionsRepo:      permissionsRepo,
 		permissionsConverter: permissionsConverter,
+		jobRepo:              jobRepo,
+		templateRepo:         templateRepo,
 	}
 }
 
@@ -76,3 +83,45 @@ func (t *TenantQueryService) GetTenantPermissions(ctx context.Context, tenantID
 	// 4. 转换为DTO
 	return t.permissionsConverter.ToDTOList(permissions), nil
 }
+
+// ListTenantJobs 获取租户下线任务列表
+func (t *TenantQueryService) ListTenantJobs(ctx context.Context, tenantID string) ([]*dto.TenantJobDto, error) {
+	jobs, err := t.jobRepo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return t.converter.ToJobDTOList(jobs), nil
+}
+
+// GetTenantJob 获取租户下线任务
+func (t *TenantQueryService) GetTenantJob(ctx context.Context, id string) (*dto.TenantJobDto, error) {
+	job, err := t.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return t.converter.ToJobDTO(job), nil
+}
+
+// ListTenantTemplates 获取租户开通模板列表
+func (t *TenantQueryService) ListTenantTemplates(ctx context.Context, name string) ([]*dto.TenantTemplateDto, error) {
+	list, err := t.templateRepo.List(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return t.converter.ToTemplateDTOList(list), nil
+}
+
+// GetTenantTemplate 获取租户开通模板
+func (t *TenantQueryService) GetTenantTemplate(ctx context.Context, id string) (*dto.TenantTemplateDto, error) {
+	tpl, err := t.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return t.converter.ToTemplateDTO(tpl), nil
+}
@@ -8,12 +8,15 @@ import (
 	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/constant"
 	"gorm.io/gorm"
+	"sort"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/converter"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/entity"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/plugin"
 )
 
 type UserQueryService struct {
@@ -77,6 +80,10 @@ func (u *UserQueryService) GetSuperAdmin(_ context.Context) (*dto.UserInfoDto, e
 func (u *UserQueryService) GetUser(ctx context.Context, id string) (*dto.UserDto, error) {
 	// 1. 获取用户基本信息
 	user, err := u.userRepo.FindById(ctx, id)
+	if err != nil && database.IfErrorNotFound(err) {
+		// 加入当前租户的其他租户用户
+		user, err = u.findMember(ctx, id)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -117,6 +124,40 @@ func (u *UserQueryService) FindUsers(ctx context.Context, qb *db_query.QueryBuil
 	return userDtos, nil
 }
 
+// FindUsersForExport 查询用户列表及其部门编码、角色编码
+func (u *UserQueryService) FindUsersForExport(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.UserExportDto, error) {
+	users, err := u.userRepo.Find(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+	roleCodes, err := u.userRepo.GetRoleCodesByUserIds(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	deptCodes, err := u.userRepo.GetDeptCodesByUserIds(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.UserExportDto, 0, len(users))
+	for _, user := range users {
+		userDto := u.userConverter.ToDTO(user, nil)
+		if userDto == nil {
+			continue
+		}
+		result = append(result, &dto.UserExportDto{
+			UserDto:   userDto,
+			DeptCodes: deptCodes[user.ID],
+			RoleCodes: roleCodes[user.ID],
+		})
+	}
+	return result, nil
+}
+
 // GetUserRolesCode 获取用户角色编码列表
 func (u *UserQueryService) GetUserRolesCode(ctx context.Context, userID string) ([]string, error) {
 	// 1.判断用户是不是租户管理员
@@ -267,6 +308,46 @@ func (u *UserQueryService) GetUserTreeMenus(ctx context.Context, userID string)
 	return u.permissionsConverter.ToSimpleTreeDTOList(permissions), nil
 }
 
+// GetUserButtons 获取用户按钮权限编码, 规则与菜单一致: 超级管理员全部、租户管理员为租户授权范围、其他用户按角色
+func (u *UserQueryService) GetUserButtons(ctx context.Context, userID string) ([]string, error) {
+	var permissions []*entity.Permissions
+	if actx.IsSuperAdmin(ctx) {
+		ps, _, err := u.permissionsRepo.GetTreeByType(ctx, 2)
+		if err != nil {
+			return nil, err
+		}
+		permissions = ps
+	} else {
+		tenantId := actx.GetTenantId(ctx)
+		if tenantId == "" {
+			return nil, errors.New("租户ID不能为空")
+		}
+		tenant, err := u.tenantRepo.FindById(ctx, tenantId)
+		if err != nil {
+			return nil, err
+		}
+		if tenant.AdminUserID == userID {
+			permissions, err = u.tenantRepo.GetTenantIDPermissionsByType(ctx, tenantId, 2)
+		} else {
+			permissions, _, err = u.permissionsRepo.GetTreeByUserAndType(ctx, userID, 2) // type=2表示按钮类型
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	seen := make(map[string]bool, len(permissions))
+	codes := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		if p.Status != 1 || seen[p.Code] {
+			continue
+		}
+		seen[p.Code] = true
+		codes = append(codes, p.Code)
+	}
+	sort.Strings(codes)
+	return codes, nil
+}
+
 // FindUsersByDepartment 查询部门下的用户
 func (u *UserQueryService) FindUsersByDepartment(ctx context.Context, deptID string, excludeAdminID string, qb *db_query.QueryBuilder) ([]*dto.UserDto, error) {
 	users, err := u.userRepo.FindByDepartment(ctx, deptID, excludeAdminID, qb)
@@ -304,7 +385,11 @@ func (u *UserQueryService) GetUserDepartments(ctx context.Context, userID string
 		}
 		return nil, err
 	}
-	return u.deptConverter.ToDTOList(departments), nil
+	userDepts, err := u.deptRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return sortUserDepartments(u.deptConverter.ToDTOList(departments), userDepts), nil
 }
 
 // FindUnassignedUsers 查询未分配部门的用户
@@ -320,3 +405,80 @@ func (u *UserQueryService) FindUnassignedUsers(ctx context.Context, qb *db_query
 func (u *UserQueryService) CountUnassignedUsers(ctx context.Context, qb *db_query.QueryBuilder) (int64, error) {
 	return u.userRepo.CountUnassignedUsers(ctx, qb)
 }
+
+// findMember 查找加入当前租户的成员用户, 只返回其他租户可见的信息
+func (u *UserQueryService) findMember(ctx context.Context, id string) (*entity.SysUser, error) {
+	tenantID := actx.GetTenantId(ctx)
+	if !plugin.TenantIDNotNil(tenantID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	ok, err := u.userRepo.IsTenantMember(ctx, id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	user, err := u.userRepo.FindById(actx.BuildIgnoreTenantCtx(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	return user.MemberView(), nil
+}
+
+// GetUserTenants 获取用户可访问的租户列表, 归属租户排在第一位
+func (u *UserQueryService) GetUserTenants(ctx context.Context, userID string) ([]*dto.UserTenantDto, error) {
+	ictx := actx.BuildIgnoreTenantCtx(ctx)
+	user, err := u.userRepo.FindById(ictx, userID)
+	if err != nil {
+		if database.IfErrorNotFound(err) {
+			return []*dto.UserTenantDto{}, nil
+		}
+		return nil, err
+	}
+	memberIDs, err := u.userRepo.GetMemberTenantIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.toUserTenants(ictx, user.TenantID, append([]string{user.TenantID}, memberIDs...))
+}
+
+// GetUserTenantInvitations 获取邀请用户加入且尚未接受的租户列表
+func (u *UserQueryService) GetUserTenantInvitations(ctx context.Context, userID string) ([]*dto.UserTenantDto, error) {
+	tenantIDs, err := u.userRepo.GetInvitedTenantIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tenantIDs) == 0 {
+		return []*dto.UserTenantDto{}, nil
+	}
+	return u.toUserTenants(actx.BuildIgnoreTenantCtx(ctx), "", tenantIDs)
+}
+
+// toUserTenants 按 tenantIDs 的顺序返回未删除的租户
+func (u *UserQueryService) toUserTenants(ctx context.Context, homeTenantID string, tenantIDs []string) ([]*dto.UserTenantDto, error) {
+	tenants, err := u.tenantRepo.FindByIds(ctx, tenantIDs)
+	if err != nil {
+		return nil, err
+	}
+	tenantMap := make(map[string]*entity.Tenant, len(tenants))
+	for _, t := range tenants {
+		tenantMap[t.ID] = t
+	}
+	result := make([]*dto.UserTenantDto, 0, len(tenantIDs))
+	for _, id := range tenantIDs {
+		t, ok := tenantMap[id]
+		if !ok || t.DeletedAt > 0 {
+			continue
+		}
+		result = append(result, &dto.UserTenantDto{
+			TenantID:   t.ID,
+			Code:       t.Code,
+			Name:       t.Name,
+			Status:     t.Status,
+			ExpireTime: t.ExpireTime,
+			Home:       t.ID == homeTenantID,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,19 @@
+package query
+
+import (
+	"context"
+	"crypto/ed25519"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hashchain"
+)
+
+// ILogChainQuery 操作日志和登录日志哈希链查询接口
+type ILogChainQuery interface {
+	// Verify 按序号遍历租户的日志链, 返回第一处断裂, anchors 为签名检查点中的 序号 -> 哈希
+	Verify(ctx context.Context, logType, tenantID string, anchors map[int64]string) (*dto.LogChainVerifyDto, error)
+	// Heads 全部日志链的链尾
+	Heads(ctx context.Context) ([]*hashchain.Head, error)
+	// Checkpoint 生成全部链尾的签名检查点
+	Checkpoint(ctx context.Context, key ed25519.PrivateKey) (*hashchain.Checkpoint, error)
+}
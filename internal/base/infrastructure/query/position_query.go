@@ -0,0 +1,20 @@
+package query
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+)
+
+// IPositionQueryService 岗位查询服务接口
+type IPositionQueryService interface {
+	// 基础查询
+	GetPosition(ctx context.Context, id string) (*dto.PositionDto, error)
+	FindPositions(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.PositionDto, error)
+	CountPositions(ctx context.Context, qb *db_query.QueryBuilder) (int64, error)
+
+	// 用户岗位查询
+	GetUserPositions(ctx context.Context, userID string) ([]*dto.UserPositionDto, error)
+	GetPositionUserIDs(ctx context.Context, positionIDs []string) ([]string, error)
+}
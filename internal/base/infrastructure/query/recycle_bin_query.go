@@ -0,0 +1,16 @@
+package query
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+)
+
+// IRecycleBinQuery 回收站查询接口
+type IRecycleBinQuery interface {
+	// Find 查询回收站记录
+	Find(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.RecycleItemDto, error)
+	// Count 统计回收站记录数量
+	Count(ctx context.Context, qb *db_query.QueryBuilder) (int64, error)
+}
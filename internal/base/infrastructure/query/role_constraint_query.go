@@ -0,0 +1,13 @@
+package query
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+)
+
+// IRoleConstraintQuery 职责分离约束查询接口
+type IRoleConstraintQuery interface {
+	// FindAll 当前租户的全部约束
+	FindAll(ctx context.Context) ([]*dto.RoleConstraintDto, error)
+}
@@ -0,0 +1,18 @@
+package query
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/db_query"
+)
+
+// IRoleGrantQuery 限时角色与角色申请查询接口
+type IRoleGrantQuery interface {
+	// GetUserGrants 用户在当前租户的角色授予, 包括未生效的授予
+	GetUserGrants(ctx context.Context, userID string) ([]*dto.RoleGrantDto, error)
+	// FindRequests 查询角色申请
+	FindRequests(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.RoleRequestDto, error)
+	// CountRequests 统计角色申请数量
+	CountRequests(ctx context.Context, qb *db_query.QueryBuilder) (int64, error)
+}
@@ -12,4 +12,12 @@ type ITenantQueryService interface {
 	FindTenants(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.TenantDto, error)
 	CountTenants(ctx context.Context, qb *db_query.QueryBuilder) (int64, error)
 	GetTenantPermissions(ctx context.Context, tenantID string) ([]*dto.PermissionsDto, error)
+	// ListTenantJobs 获取租户下线任务列表
+	ListTenantJobs(ctx context.Context, tenantID string) ([]*dto.TenantJobDto, error)
+	// GetTenantJob 获取租户下线任务
+	GetTenantJob(ctx context.Context, id string) (*dto.TenantJobDto, error)
+	// ListTenantTemplates 获取租户开通模板列表
+	ListTenantTemplates(ctx context.Context, name string) ([]*dto.TenantTemplateDto, error)
+	// GetTenantTemplate 获取租户开通模板
+	GetTenantTemplate(ctx context.Context, id string) (*dto.TenantTemplateDto, error)
 }
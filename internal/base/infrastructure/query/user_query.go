@@ -14,15 +14,25 @@ type IUserQueryService interface {
 	GetUser(ctx context.Context, id string) (*dto.UserDto, error)
 	FindUsers(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.UserDto, error)
 	CountUsers(ctx context.Context, qb *db_query.QueryBuilder) (int64, error)
+	// FindUsersForExport 查询用户及其部门编码、角色编码, 用于导出
+	FindUsersForExport(ctx context.Context, qb *db_query.QueryBuilder) ([]*dto.UserExportDto, error)
+	// GetUserTenants 获取用户可访问的租户列表(归属租户及加入的租户)
+	GetUserTenants(ctx context.Context, userID string) ([]*dto.UserTenantDto, error)
+	// GetUserTenantInvitations 获取邀请用户加入且尚未接受的租户列表
+	GetUserTenantInvitations(ctx context.Context, userID string) ([]*dto.UserTenantDto, error)
 
 	// 权限相关查询
 	GetUserPermissions(ctx context.Context, userID string) ([]string, error)
 	GetUserRoles(ctx context.Context, userID string) ([]*dto.RoleDto, error)
 	GetUserMenus(ctx context.Context, userID string) ([]*dto.PermissionsDto, error)
 	GetUserTreeMenus(ctx context.Context, userID string) ([]*dto.PermissionsTreeDto, error)
+	// GetUserButtons 获取用户在当前租户的按钮权限编码
+	GetUserButtons(ctx context.Context, userID string) ([]string, error)
 	GetUserRolesCode(ctx context.Context, userID string) ([]string, error)
 
 	// 部门相关查询
 	FindUsersByDepartment(ctx context.Context, deptID string, excludeAdminID string, qb *db_query.QueryBuilder) ([]*dto.UserDto, error)
 	CountUsersByDepartment(ctx context.Context, deptID string, excludeAdminID string, qb *db_query.QueryBuilder) (int64, error)
+	// GetUserDepartments 获取用户所属部门, 主部门在前
+	GetUserDepartments(ctx context.Context, userID string) ([]*dto.DepartmentDto, error)
 }
@@ -13,15 +13,23 @@ var ProviderSet = wire.NewSet(
 	impl.NewPermissionsQueryService,
 	impl.NewTenantQueryService,
 	impl.NewDepartmentQueryService,
+	impl.NewPositionQueryService,
 	impl.NewDataPermissionQueryService,
 	impl.NewOperationLogQueryService,
+	impl.NewAuditLogQueryService,
+	impl.NewLogChainQueryService,
 	impl.NewLoginLogQueryService,
+	impl.NewRecycleBinQueryService,
+	impl.NewRoleGrantQueryService,
+	impl.NewRoleConstraintQueryService,
+	impl.NewAccessReviewQueryService,
 
 	cache.NewUserQueryCache,
 	cache.NewRoleQueryCache,
 	cache.NewPermissionsQueryCache,
 	cache.NewTenantQueryCache,
 	cache.NewDepartmentQueryCache,
+	cache.NewPositionQueryCache,
 	cache.NewDataPermissionQueryCache,
 
 	handlers.NewCacheEventHandler,
@@ -30,8 +38,15 @@ var ProviderSet = wire.NewSet(
 	wire.Bind(new(ITenantQueryService), new(*cache.TenantQueryCache)),
 	wire.Bind(new(IRoleQueryService), new(*cache.RoleQueryCache)),
 	wire.Bind(new(IDepartmentQueryService), new(*cache.DepartmentQueryCache)),
+	wire.Bind(new(IPositionQueryService), new(*cache.PositionQueryCache)),
 	wire.Bind(new(IPermissionsQuery), new(*cache.PermissionsQueryCache)),
 	wire.Bind(new(IDataPermissionQuery), new(*cache.DataPermissionQueryCache)),
 	wire.Bind(new(IOperationLogQuery), new(*impl.OperationLogQueryService)),
+	wire.Bind(new(IAuditLogQuery), new(*impl.AuditLogQueryService)),
+	wire.Bind(new(ILogChainQuery), new(*impl.LogChainQueryService)),
 	wire.Bind(new(ILoginLogQuery), new(*impl.LoginLogQueryService)),
+	wire.Bind(new(IRecycleBinQuery), new(*impl.RecycleBinQueryService)),
+	wire.Bind(new(IRoleGrantQuery), new(*impl.RoleGrantQueryService)),
+	wire.Bind(new(IRoleConstraintQuery), new(*impl.RoleConstraintQueryService)),
+	wire.Bind(new(IAccessReviewQuery), new(*impl.AccessReviewQueryService)),
 )
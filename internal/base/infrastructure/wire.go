@@ -1,18 +1,33 @@
 package infrastructure
 
 import (
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/avatar"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/base"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/cleaner"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/converter"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/handlers"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/invitation"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/notify"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/offboard"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/persistence"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/provision"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/query"
 	"github.com/google/wire"
 )
 
 var ProviderSet = wire.NewSet(
+	avatar.NewStorageAvatar,
 	base.ProviderSet,
+	cleaner.NewRecycleCleaner,
+	cleaner.NewRoleGrantExpirer,
+	cleaner.NewLogCheckpointExporter,
 	converter.ProviderSet,
 	handlers.ProviderSet,
+	invitation.NewTokenProvider,
+	notify.NewCodeSender,
+	notify.NewInvitationSender,
+	offboard.NewTenantJobRunner,
 	persistence.ProviderSet,
+	provision.NewBaseProvisioner,
 	query.ProviderSet,
 )
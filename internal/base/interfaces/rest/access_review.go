@@ -0,0 +1,225 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/handlers"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
+	_ "github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver"
+	_ "github.com/ares-cloud/ares-ddd-admin/pkg/hserver/base_info"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/casbin"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/jwt"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/oplog"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/models"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/token"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/hertz/pkg/route"
+)
+
+type AccessReviewController struct {
+	handler    *handlers.AccessReviewHandler
+	ef         *casbin.Enforcer
+	moduleName string
+}
+
+func NewAccessReviewController(handler *handlers.AccessReviewHandler, ef *casbin.Enforcer) *AccessReviewController {
+	return &AccessReviewController{
+		handler:    handler,
+		ef:         ef,
+		moduleName: "权限复核",
+	}
+}
+
+func (c *AccessReviewController) RegisterRouter(g *route.RouterGroup, t token.IToken) {
+	v1 := g.Group("/v1")
+	ar := v1.Group("/sys/access-review", jwt.Handler(t))
+	{
+		ar.POST("", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.moduleName,
+			Action:      "发起",
+		}), hserver.NewHandlerFu[commands.StartAccessReviewCommand](c.Start))
+		ar.GET("", casbin.Handler(c.ef), hserver.NewHandlerFu[queries.ListAccessReviewsQuery](c.List))
+		ar.GET("/:id/items", casbin.Handler(c.ef), hserver.NewHandlerFu[models.StringIdReq](c.Items))
+		ar.POST("/:id/close", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.moduleName,
+			Action:      "关闭",
+		}), hserver.NewHandlerFu[models.StringIdReq](c.Close))
+		ar.GET("/:id/report", casbin.Handler(c.ef), c.ExportReport)
+		// 复核人只能查看和复核分配给自己的复核项, 不需要菜单权限
+		ar.GET("/item/mine", hserver.NewNotParHandlerFu(c.MyItems))
+		ar.PUT("/item/:id", jwt.DenyImpersonation(), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.moduleName,
+			Action:      "复核",
+		}), hserver.NewHandlerFu[commands.DecideAccessReviewItemCommand](c.Decide))
+	}
+}
+
+// Start 发起权限复核
+// @Summary 发起权限复核
+// @Description 对部门子树或角色集合内的用户→角色和角色→权限授权做快照, 用户→角色复核项分配给用户所在部门的管理员
+// @Tags 权限复核
+// @ID StartAccessReview
+// @Accept json
+// @Produce json
+// @Param req body commands.StartAccessReviewCommand true "复核范围"
+// @Success 200 {object} base_info.Success{data=dto.AccessReviewStartedDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/access-review [post]
+func (c *AccessReviewController) Start(ctx context.Context, params *commands.StartAccessReviewCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.handler.HandleStart(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// List 权限复核活动列表
+// @Summary 权限复核活动列表
+// @Description 分页查询当前租户的权限复核活动
+// @Tags 权限复核
+// @ID AccessReviewList
+// @Accept json
+// @Produce json
+// @Param req query queries.ListAccessReviewsQuery true "查询参数"
+// @Success 200 {object} base_info.Success{data=models.PageRes[dto.AccessReviewDto]}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/access-review [get]
+func (c *AccessReviewController) List(ctx context.Context, params *queries.ListAccessReviewsQuery) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.handler.HandleList(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// Items 复核项列表
+// @Summary 复核项列表
+// @Description 查询复核活动的全部复核项
+// @Tags 权限复核
+// @ID AccessReviewItems
+// @Accept json
+// @Produce json
+// @Param id path string true "活动ID"
+// @Success 200 {object} base_info.Success{data=[]dto.AccessReviewItemDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/access-review/{id}/items [get]
+func (c *AccessReviewController) Items(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.handler.HandleListItems(ctx, params.Id)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// MyItems 我的复核项
+// @Summary 我的复核项
+// @Description 进行中的复核活动里分配给当前用户的复核项
+// @Tags 权限复核
+// @ID MyAccessReviewItems
+// @Accept json
+// @Produce json
+// @Success 200 {object} base_info.Success{data=[]dto.AccessReviewItemDto}
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/access-review/item/mine [get]
+func (c *AccessReviewController) MyItems(ctx context.Context) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.handler.HandleListMyItems(ctx)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// Decide 复核
+// @Summary 复核
+// @Description 复核人对分配给自己的复核项记录保留或收回, 活动关闭前可以修改
+// @Tags 权限复核
+// @ID DecideAccessReviewItem
+// @Accept json
+// @Produce json
+// @Param id path string true "复核项ID"
+// @Param req body commands.DecideAccessReviewItemCommand true "复核结果"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/access-review/item/{id} [put]
+func (c *AccessReviewController) Decide(ctx context.Context, params *commands.DecideAccessReviewItemCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.handler.HandleDecide(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// Close 关闭权限复核
+// @Summary 关闭权限复核
+// @Description 关闭复核活动并收回复核人决定收回的授权, 未复核的授权保持不变
+// @Tags 权限复核
+// @ID CloseAccessReview
+// @Accept json
+// @Produce json
+// @Param id path string true "活动ID"
+// @Success 200 {object} base_info.Success{data=dto.AccessReviewSummaryDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/access-review/{id}/close [post]
+func (c *AccessReviewController) Close(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.handler.HandleClose(ctx, params.Id)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// ExportReport 导出复核报告
+// @Summary 导出复核报告
+// @Description 导出复核活动、统计和全部复核项, 报告经 HMAC-SHA256 签名, 签名同时写入 X-Report-Signature 响应头
+// @Tags 权限复核
+// @ID ExportAccessReviewReport
+// @Produce json
+// @Param id path string true "活动ID"
+// @Success 200 {object} dto.AccessReviewReportDto
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Router /v1/sys/access-review/{id}/report [get]
+func (c *AccessReviewController) ExportReport(ctx context.Context, rc *app.RequestContext) {
+	result := hserver.DefaultResponseResult()
+	id := rc.Param("id")
+	report, herr := c.handler.HandleExportReport(ctx, id)
+	if herr != nil {
+		rc.JSON(http.StatusOK, result.WithError(herr))
+		return
+	}
+	body, err := json.Marshal(report)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "export access review report error: %s", err)
+		rc.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	rc.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("access-review-%s.json", id)))
+	rc.Response.Header.Set("X-Report-Signature", report.Signature)
+	rc.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
@@ -8,18 +8,21 @@ import (
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/device"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/jwt"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/token"
 	"github.com/cloudwego/hertz/pkg/route"
 )
 
 type AuthController struct {
-	authHandler *handlers.AuthHandler
-	t           token.IToken
+	authHandler       *handlers.AuthHandler
+	invitationHandler *handlers.UserInvitationHandler
+	t                 token.IToken
 }
 
-func NewAuthController(authHandler *handlers.AuthHandler) *AuthController {
+func NewAuthController(authHandler *handlers.AuthHandler, invitationHandler *handlers.UserInvitationHandler) *AuthController {
 	return &AuthController{
-		authHandler: authHandler,
+		authHandler:       authHandler,
+		invitationHandler: invitationHandler,
 	}
 }
 
@@ -30,7 +33,11 @@ func (c *AuthController) RegisterRouter(g *route.RouterGroup, t token.IToken) {
 	{
 		auth.POST("/login", device.Handler(), hserver.NewHandlerFu[commands.LoginCommand](c.Login))
 		auth.POST("/refresh", hserver.NewHandlerFu[commands.RefreshTokenCommand](c.RefreshToken))
+		auth.POST("/switch-tenant", jwt.Handler(t), jwt.DenyImpersonation(), hserver.NewHandlerFu[commands.SwitchTenantCommand](c.SwitchTenant))
+		auth.POST("/impersonate/stop", jwt.Handler(t), hserver.NewHandlerFu[commands.StopImpersonationCommand](c.StopImpersonation))
 		auth.GET("/captcha", hserver.NewHandlerFu[queries.GetCaptchaQuery](c.GetCaptcha))
+		auth.GET("/invitation", hserver.NewHandlerFu[queries.PreviewInvitationQuery](c.PreviewInvitation))
+		auth.POST("/invitation/accept", hserver.NewHandlerFu[commands.AcceptInvitationCommand](c.AcceptInvitation))
 	}
 }
 
@@ -78,6 +85,50 @@ func (c *AuthController) RefreshToken(ctx context.Context, params *commands.Refr
 	return result.WithData(data)
 }
 
+// SwitchTenant 切换租户
+// @Summary 切换租户
+// @Description 切换到当前用户所属的其他租户, 返回新租户下的访问令牌
+// @Tags 认证
+// @ID SwitchTenant
+// @Accept json
+// @Produce json
+// @Param req body commands.SwitchTenantCommand true "切换租户请求"
+// @Success 200 {object} base_info.Success{data=dto.AuthDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "认证失败"
+// @Failure 500 {object} base_info.Swagger500Resp "服务器内部错误"
+// @Router /v1/auth/switch-tenant [post]
+func (c *AuthController) SwitchTenant(ctx context.Context, params *commands.SwitchTenantCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.authHandler.HandleSwitchTenant(ctx, *params, c.t)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// StopImpersonation 结束模拟登录
+// @Summary 结束模拟登录
+// @Description 结束模拟登录会话并注销模拟令牌, 可以使用模拟令牌或发起模拟的管理员令牌调用
+// @Tags 认证
+// @ID StopImpersonation
+// @Accept json
+// @Produce json
+// @Param req body commands.StopImpersonationCommand true "模拟登录会话"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "认证失败"
+// @Failure 500 {object} base_info.Swagger500Resp "服务器内部错误"
+// @Router /v1/auth/impersonate/stop [post]
+func (c *AuthController) StopImpersonation(ctx context.Context, params *commands.StopImpersonationCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.authHandler.HandleStopImpersonation(ctx, *params, c.t)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
 // GetCaptcha 获取验证码
 // @Summary 获取验证码
 // @Description 获取图形验证码
@@ -98,3 +149,45 @@ func (c *AuthController) GetCaptcha(ctx context.Context, params *queries.GetCapt
 	}
 	return result.WithData(data)
 }
+
+// PreviewInvitation 查看邀请
+// @Summary 查看邀请
+// @Description 校验邀请令牌并返回被邀请人信息, 无需登录
+// @Tags 认证
+// @ID PreviewInvitation
+// @Accept json
+// @Produce json
+// @Param token query string true "邀请令牌"
+// @Success 200 {object} base_info.Success{data=dto.InvitationPreviewDto}
+// @Failure 400 {object} base_info.Swagger400Resp "邀请无效或已过期"
+// @Failure 500 {object} base_info.Swagger500Resp "服务器内部错误"
+// @Router /v1/auth/invitation [get]
+func (c *AuthController) PreviewInvitation(ctx context.Context, params *queries.PreviewInvitationQuery) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.invitationHandler.HandlePreview(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// AcceptInvitation 接受邀请
+// @Summary 接受邀请
+// @Description 被邀请人设置密码并激活账号, 无需登录
+// @Tags 认证
+// @ID AcceptInvitation
+// @Accept json
+// @Produce json
+// @Param req body commands.AcceptInvitationCommand true "邀请令牌和密码"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "邀请无效或已过期"
+// @Failure 500 {object} base_info.Swagger500Resp "服务器内部错误"
+// @Router /v1/auth/invitation/accept [post]
+func (c *AuthController) AcceptInvitation(ctx context.Context, params *commands.AcceptInvitationCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.invitationHandler.HandleAccept(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/handlers"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
+	_ "github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
 	_ "github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/jwt"
@@ -34,6 +35,7 @@ func (c *DataPermissionController) RegisterRouter(g *route.RouterGroup, t token.
 	{
 		dp.POST("/assign", hserver.NewHandlerFu[commands.AssignDataPermissionCommand](c.AssignDataPermission))
 		dp.POST("/remove", hserver.NewHandlerFu[commands.RemoveDataPermissionCommand](c.RemoveDataPermission))
+		dp.GET("/scope", hserver.NewNotParHandlerFu(c.GetCurrentScope))
 		dp.GET("/:id", hserver.NewHandlerFu[models.IntIdReq](c.GetByRoleID))
 	}
 }
@@ -92,3 +94,22 @@ func (c *DataPermissionController) GetByRoleID(ctx context.Context, req *models.
 	}
 	return result.WithData(data)
 }
+
+// GetCurrentScope 获取当前用户生效的数据范围
+// @Summary 获取当前用户生效的数据范围
+// @Description 合并当前用户所有角色的数据权限, 本部门范围按用户所属的全部部门计算
+// @Tags 数据权限
+// @Accept json
+// @Produce json
+// @Success 200 {object} base_info.Success{data=model.UserDataScope}
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/data-permission/scope [get]
+func (c *DataPermissionController) GetCurrentScope(ctx context.Context) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.queryHandler.HandleGetCurrentScope(ctx)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
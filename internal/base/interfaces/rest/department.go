@@ -2,33 +2,42 @@ package rest
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/handlers"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver"
 	_ "github.com/ares-cloud/ares-ddd-admin/pkg/hserver/base_info"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/casbin"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/jwt"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/oplog"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/models"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/sheet"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/token"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
 	"github.com/cloudwego/hertz/pkg/route"
 )
 
 type DepartmentController struct {
-	cmdHandler   *handlers.DepartmentCommandHandler
-	queryHandler *handlers.DepartmentQueryHandler
-	ef           *casbin.Enforcer
-	moduleName   string
+	cmdHandler     *handlers.DepartmentCommandHandler
+	queryHandler   *handlers.DepartmentQueryHandler
+	recycleHandler *handlers.RecycleBinHandler
+	ef             *casbin.Enforcer
+	moduleName     string
 }
 
-func NewDepartmentController(cmdHandler *handlers.DepartmentCommandHandler, queryHandler *handlers.DepartmentQueryHandler, ef *casbin.Enforcer) *DepartmentController {
+func NewDepartmentController(cmdHandler *handlers.DepartmentCommandHandler, queryHandler *handlers.DepartmentQueryHandler, recycleHandler *handlers.RecycleBinHandler, ef *casbin.Enforcer) *DepartmentController {
 	return &DepartmentController{
-		cmdHandler:   cmdHandler,
-		queryHandler: queryHandler,
-		ef:           ef,
-		moduleName:   "部门",
+		cmdHandler:     cmdHandler,
+		queryHandler:   queryHandler,
+		recycleHandler: recycleHandler,
+		ef:             ef,
+		moduleName:     "部门",
 	}
 }
 
@@ -57,14 +66,22 @@ func (c *DepartmentController) RegisterRouter(g *route.RouterGroup, t token.ITok
 		}), hserver.NewHandlerFu[models.StringIdReq](c.DeleteDepartment))
 
 		dept.GET("/:id", casbin.Handler(c.ef), hserver.NewHandlerFu[models.StringIdReq](c.GetDetails))
+		dept.GET("/:id/ancestors", casbin.Handler(c.ef), hserver.NewHandlerFu[models.StringIdReq](c.GetAncestors))
+		dept.GET("/:id/subtree-user-count", casbin.Handler(c.ef), hserver.NewHandlerFu[models.StringIdReq](c.CountSubtreeUsers))
 
 		dept.GET("/tree", casbin.Handler(c.ef), hserver.NewHandlerFu[queries.GetDepartmentTreeQuery](c.GetDepartmentTree))
+		dept.GET("/org-chart", casbin.Handler(c.ef), c.ExportOrgChart)
 
 		dept.POST("/move", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
 			IncludeBody: true,
 			Module:      c.moduleName,
 			Action:      "移动",
 		}), hserver.NewHandlerFu[commands.MoveDepartmentCommand](c.MoveDepartment))
+		dept.POST("/merge", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.moduleName,
+			Action:      "合并",
+		}), hserver.NewHandlerFu[commands.MergeDepartmentCommand](c.MergeDepartment))
 		dept.POST("/admin", hserver.NewHandlerFu[commands.SetDepartmentAdminCommand](c.SetAdmin))
 		dept.GET("/:id/users", casbin.Handler(c.ef), hserver.NewHandlerFu[queries.GetDepartmentUsersQuery](c.GetDepartmentUsers))
 		dept.POST("/users", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
@@ -84,6 +101,17 @@ func (c *DepartmentController) RegisterRouter(g *route.RouterGroup, t token.ITok
 			Module:      c.moduleName,
 			Action:      "人员调动",
 		}), hserver.NewHandlerFu[commands.TransferUserCommand](c.TransferUser))
+		dept.PUT("/primary", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.moduleName,
+			Action:      "设置主部门",
+		}), hserver.NewHandlerFu[commands.SetPrimaryDepartmentCommand](c.SetPrimary))
+		dept.GET("/recycle", casbin.Handler(c.ef), hserver.NewHandlerFu[queries.ListRecycleBinQuery](c.RecycleList))
+		dept.POST("/recycle/:id/restore", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.moduleName,
+			Action:      "恢复",
+		}), hserver.NewHandlerFu[models.StringIdReq](c.Restore))
 	}
 }
 
@@ -196,6 +224,48 @@ func (c *DepartmentController) GetDetails(ctx context.Context, params *models.St
 	return result.WithData(data)
 }
 
+// GetAncestors 获取上级部门
+// @Summary 获取上级部门
+// @Description 获取部门的全部上级部门, 从根部门开始排列
+// @Tags 系统部门
+// @Accept json
+// @Produce json
+// @Param id path string true "部门ID"
+// @Success 200 {object} base_info.Success{data=[]dto.DepartmentDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/dept/{id}/ancestors [get]
+func (c *DepartmentController) GetAncestors(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.queryHandler.HandleGetAncestors(ctx, &queries.GetDepartmentQuery{ID: params.Id})
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// CountSubtreeUsers 统计部门及下级部门用户数
+// @Summary 统计部门及下级部门用户数
+// @Description 统计部门及其全部下级部门的用户数, 同一用户属于多个部门时只计一次
+// @Tags 系统部门
+// @Accept json
+// @Produce json
+// @Param id path string true "部门ID"
+// @Success 200 {object} base_info.Success{data=int64}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/dept/{id}/subtree-user-count [get]
+func (c *DepartmentController) CountSubtreeUsers(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.queryHandler.HandleCountSubtreeUsers(ctx, &queries.GetDepartmentQuery{ID: params.Id})
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
 // GetDepartmentTree 获取部门树
 // @Summary 获取部门树
 // @Description 获取部门树形结构
@@ -347,6 +417,66 @@ func (c *DepartmentController) GetUnassignedUsers(ctx context.Context, req *quer
 	//return result.WithData(data)
 }
 
+// MergeDepartment 合并部门
+// @Summary 合并部门
+// @Description 在一个事务内将源部门的用户、子部门、自定义数据范围引用和管理员转移到目标部门, 然后删除源部门
+// @Tags 部门管理
+// @Accept json
+// @Produce json
+// @Param req body commands.MergeDepartmentCommand true "合并参数"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/dept/merge [post]
+func (c *DepartmentController) MergeDepartment(ctx context.Context, req *commands.MergeDepartmentCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	if err := c.cmdHandler.HandleMerge(ctx, req); err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// ExportOrgChart 导出组织架构图
+// @Summary 导出组织架构图
+// @Description 导出部门树及负责人、管理员和人数, 支持 JSON 树、CSV 和 Graphviz DOT 格式
+// @Tags 部门管理
+// @Produce octet-stream
+// @Param req query queries.ExportOrgChartQuery true "导出参数"
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Router /v1/sys/dept/org-chart [get]
+func (c *DepartmentController) ExportOrgChart(ctx context.Context, rc *app.RequestContext) {
+	result := hserver.DefaultResponseResult()
+	var params queries.ExportOrgChartQuery
+	if err := rc.BindAndValidate(&params); err != nil {
+		rc.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if params.Format == "" {
+		params.Format = queries.OrgChartFormatJSON
+	}
+	chart, herr := c.queryHandler.HandleGetOrgChart(ctx, &params)
+	if herr != nil {
+		rc.JSON(http.StatusOK, result.WithError(herr))
+		return
+	}
+
+	filename := fmt.Sprintf("org-chart-%s.%s", time.Now().Format("20060102150405"), params.Format)
+	rc.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	switch params.Format {
+	case queries.OrgChartFormatCSV:
+		rc.SetContentType(sheet.ContentType(sheet.FormatCSV))
+	case queries.OrgChartFormatDOT:
+		rc.SetContentType("text/vnd.graphviz; charset=utf-8")
+	default:
+		rc.SetContentType("application/json; charset=utf-8")
+	}
+	if err := handlers.WriteOrgChart(rc, chart, params.Format); err != nil {
+		hlog.CtxErrorf(ctx, "export org chart error: %s", err)
+	}
+}
+
 // TransferUser 人员部门调动
 // @Summary 人员部门调动
 // @Description 将用户从一个部门调动到另一个部门
@@ -366,3 +496,67 @@ func (c *DepartmentController) TransferUser(ctx context.Context, req *commands.T
 	}
 	return result
 }
+
+// SetPrimary 设置用户主部门
+// @Summary 设置用户主部门
+// @Description 将用户所属的某个部门设为主部门, 重新登录后token携带新的主部门
+// @Tags 部门管理
+// @Accept json
+// @Produce json
+// @Param req body commands.SetPrimaryDepartmentCommand true "主部门参数"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/dept/primary [put]
+func (c *DepartmentController) SetPrimary(ctx context.Context, req *commands.SetPrimaryDepartmentCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	if err := c.cmdHandler.HandleSetPrimary(ctx, req); err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// RecycleList 获取部门回收站列表
+// @Summary 获取部门回收站列表
+// @Description 获取已删除且未超过保留期的部门
+// @Tags 系统部门
+// @ID RecycleDepartmentList
+// @Accept json
+// @Produce json
+// @Param req query queries.ListRecycleBinQuery true "查询参数"
+// @Success 200 {object} base_info.Success{data=models.PageRes[dto.RecycleItemDto]}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/dept/recycle [get]
+func (c *DepartmentController) RecycleList(ctx context.Context, params *queries.ListRecycleBinQuery) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.recycleHandler.HandleList(ctx, model.RecycleEntityDepartment, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// Restore 从回收站恢复部门
+// @Summary 从回收站恢复部门
+// @Description 恢复部门及其仍然存在的关联关系
+// @Tags 系统部门
+// @ID RestoreDepartment
+// @Accept json
+// @Produce json
+// @Param id path string true "部门ID"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/dept/recycle/{id}/restore [post]
+func (c *DepartmentController) Restore(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.recycleHandler.HandleRestore(ctx, model.RecycleEntityDepartment, params.Id)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
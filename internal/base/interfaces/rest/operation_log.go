@@ -15,12 +15,14 @@ import (
 
 type OperationLogController struct {
 	queryHandler *handlers.OperationLogQueryHandler
+	chainHandler *handlers.LogChainHandler
 	ef           *casbin.Enforcer
 }
 
-func NewOperationLogController(queryHandler *handlers.OperationLogQueryHandler, ef *casbin.Enforcer) *OperationLogController {
+func NewOperationLogController(queryHandler *handlers.OperationLogQueryHandler, chainHandler *handlers.LogChainHandler, ef *casbin.Enforcer) *OperationLogController {
 	return &OperationLogController{
 		queryHandler: queryHandler,
+		chainHandler: chainHandler,
 		ef:           ef,
 	}
 }
@@ -30,6 +32,8 @@ func (c *OperationLogController) RegisterRouter(g *route.RouterGroup, t token.IT
 	oplog := v1.Group("/oplog", jwt.Handler(t))
 	{
 		oplog.GET("/list", casbin.Handler(c.ef), hserver.NewHandlerFu[queries.ListOperationLogQuery](c.List))
+		oplog.GET("/audit", casbin.Handler(c.ef), hserver.NewHandlerFu[queries.ListAuditLogQuery](c.ListAudit))
+		oplog.GET("/chain/verify", casbin.Handler(c.ef), hserver.NewHandlerFu[queries.VerifyLogChainQuery](c.VerifyChain))
 	}
 }
 
@@ -47,6 +51,7 @@ func (c *OperationLogController) RegisterRouter(g *route.RouterGroup, t token.IT
 // @Param end_time query int64 false "结束时间"
 // @Param current query int false "页码"
 // @Param size query int false "每页大小"
+// @Param request_id query string false "请求ID"
 // @Success 200 {object} base_info.Success{data=[]dto.OperationLogDto}
 // @Failure 400 {object} base_info.Swagger400Resp "参数错误"
 // @Failure 500 {object} base_info.Swagger500Resp "服务器内部错误"
@@ -59,3 +64,49 @@ func (c *OperationLogController) List(ctx context.Context, q *queries.ListOperat
 	}
 	return result.WithData(data)
 }
+
+// ListAudit 查询数据变更记录
+// @Summary 查询数据变更记录
+// @Description 按实体查询字段级变更历史, 或按操作日志的请求ID查询该请求产生的全部变更
+// @Tags 操作日志
+// @Accept json
+// @Produce json
+// @Param entity_type query string false "实体类型"
+// @Param entity_id query string false "实体ID"
+// @Param request_id query string false "请求ID"
+// @Param action query string false "变更类型(create/update/delete)"
+// @Param current query int false "页码"
+// @Param size query int false "每页大小"
+// @Success 200 {object} base_info.Success{data=[]dto.AuditLogDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 500 {object} base_info.Swagger500Resp "服务器内部错误"
+// @Router /v1/oplog/audit [get]
+func (c *OperationLogController) ListAudit(ctx context.Context, q *queries.ListAuditLogQuery) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.queryHandler.HandleListAudit(ctx, q)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// VerifyChain 校验日志哈希链
+// @Summary 校验日志哈希链
+// @Description 按序号遍历当前租户的操作日志或登录日志哈希链, 返回第一处断裂
+// @Tags 操作日志
+// @Accept json
+// @Produce json
+// @Param log_type query string true "日志类型(oplog/login)"
+// @Param tenant_id query string false "租户ID, 仅超级管理员可指定"
+// @Success 200 {object} base_info.Success{data=dto.LogChainVerifyDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 500 {object} base_info.Swagger500Resp "服务器内部错误"
+// @Router /v1/oplog/chain/verify [get]
+func (c *OperationLogController) VerifyChain(ctx context.Context, q *queries.VerifyLogChainQuery) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.chainHandler.HandleVerify(ctx, q)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
@@ -0,0 +1,223 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/handlers"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver"
+	_ "github.com/ares-cloud/ares-ddd-admin/pkg/hserver/base_info"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/casbin"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/jwt"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/oplog"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/models"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/token"
+	"github.com/cloudwego/hertz/pkg/route"
+)
+
+type PositionController struct {
+	cmdHandler   *handlers.PositionCommandHandler
+	queryHandler *handlers.PositionQueryHandler
+	ef           *casbin.Enforcer
+	moduleName   string
+}
+
+func NewPositionController(cmdHandler *handlers.PositionCommandHandler, queryHandler *handlers.PositionQueryHandler, ef *casbin.Enforcer) *PositionController {
+	return &PositionController{
+		cmdHandler:   cmdHandler,
+		queryHandler: queryHandler,
+		ef:           ef,
+		moduleName:   "岗位",
+	}
+}
+
+func (c *PositionController) RegisterRouter(g *route.RouterGroup, t token.IToken) {
+	v1 := g.Group("/v1")
+	position := v1.Group("/sys/position", jwt.Handler(t))
+	{
+		position.POST("", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.moduleName,
+			Action:      "新增",
+		}), hserver.NewHandlerFu[commands.CreatePositionCommand](c.AddPosition))
+
+		position.GET("", casbin.Handler(c.ef), hserver.NewHandlerFu[queries.ListPositionsQuery](c.PositionList))
+
+		position.PUT("", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.moduleName,
+			Action:      "修改",
+		}), hserver.NewHandlerFu[commands.UpdatePositionCommand](c.UpdatePosition))
+
+		position.DELETE("/:id", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.moduleName,
+			Action:      "删除",
+		}), hserver.NewHandlerFu[models.StringIdReq](c.DeletePosition))
+
+		position.GET("/:id", casbin.Handler(c.ef), hserver.NewHandlerFu[models.StringIdReq](c.GetDetails))
+
+		position.GET("/user/:id", casbin.Handler(c.ef), hserver.NewHandlerFu[models.StringIdReq](c.GetUserPositions))
+
+		position.PUT("/user", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.moduleName,
+			Action:      "设置任职",
+		}), hserver.NewHandlerFu[commands.SetUserPositionsCommand](c.SetUserPositions))
+	}
+}
+
+// AddPosition 添加岗位
+// @Summary 添加岗位
+// @Description 添加岗位
+// @Tags 系统岗位
+// @ID AddPosition
+// @Accept json
+// @Produce json
+// @Param req body commands.CreatePositionCommand true "岗位信息"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/position [post]
+func (c *PositionController) AddPosition(ctx context.Context, params *commands.CreatePositionCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.cmdHandler.HandleCreate(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// PositionList 获取岗位列表
+// @Summary 获取岗位列表
+// @Description 获取岗位列表
+// @Tags 系统岗位
+// @ID PositionList
+// @Accept json
+// @Produce json
+// @Param req query queries.ListPositionsQuery true "查询参数"
+// @Success 200 {object} base_info.Success{data=models.PageRes[dto.PositionDto]}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/position [get]
+func (c *PositionController) PositionList(ctx context.Context, params *queries.ListPositionsQuery) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.queryHandler.HandleList(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// UpdatePosition 更新岗位
+// @Summary 更新岗位
+// @Description 更新岗位信息
+// @Tags 系统岗位
+// @ID UpdatePosition
+// @Accept json
+// @Produce json
+// @Param req body commands.UpdatePositionCommand true "岗位信息"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/position [put]
+func (c *PositionController) UpdatePosition(ctx context.Context, params *commands.UpdatePositionCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.cmdHandler.HandleUpdate(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// DeletePosition 删除岗位
+// @Summary 删除岗位
+// @Description 删除岗位, 仍有任职人员的岗位不允许删除
+// @Tags 系统岗位
+// @ID DeletePosition
+// @Accept json
+// @Produce json
+// @Param id path string true "岗位ID"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/position/{id} [delete]
+func (c *PositionController) DeletePosition(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.cmdHandler.HandleDelete(ctx, &commands.DeletePositionCommand{ID: params.Id})
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// GetDetails 获取岗位详情
+// @Summary 获取岗位详情
+// @Description 获取岗位详情
+// @Tags 系统岗位
+// @ID GetPositionDetails
+// @Accept json
+// @Produce json
+// @Param id path string true "岗位ID"
+// @Success 200 {object} base_info.Success{data=dto.PositionDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/position/{id} [get]
+func (c *PositionController) GetDetails(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.queryHandler.HandleGet(ctx, &queries.GetPositionQuery{ID: params.Id})
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// GetUserPositions 获取用户任职岗位
+// @Summary 获取用户任职岗位
+// @Description 获取用户任职岗位, 主岗在前
+// @Tags 系统岗位
+// @ID GetUserPositions
+// @Accept json
+// @Produce json
+// @Param id path string true "用户ID"
+// @Success 200 {object} base_info.Success{data=[]dto.UserPositionDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/position/user/{id} [get]
+func (c *PositionController) GetUserPositions(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.queryHandler.HandleGetUserPositions(ctx, &queries.GetUserPositionsQuery{UserID: params.Id})
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// SetUserPositions 设置用户任职岗位
+// @Summary 设置用户任职岗位
+// @Description 替换用户的任职岗位, 未指定主岗时第一个岗位为主岗
+// @Tags 系统岗位
+// @ID SetUserPositions
+// @Accept json
+// @Produce json
+// @Param req body commands.SetUserPositionsCommand true "任职信息"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/position/user [put]
+func (c *PositionController) SetUserPositions(ctx context.Context, params *commands.SetUserPositionsCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.cmdHandler.HandleSetUserPositions(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
@@ -0,0 +1,303 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/handlers"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
+	_ "github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver"
+	_ "github.com/ares-cloud/ares-ddd-admin/pkg/hserver/base_info"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/jwt"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/oplog"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/models"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/token"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/route"
+)
+
+// ProfileController 个人中心, 仅操作当前登录用户, 不做权限校验
+type ProfileController struct {
+	handler    *handlers.ProfileHandler
+	moduleName string
+}
+
+func NewProfileController(handler *handlers.ProfileHandler) *ProfileController {
+	return &ProfileController{
+		handler:    handler,
+		moduleName: "个人中心",
+	}
+}
+
+func (c *ProfileController) RegisterRouter(g *route.RouterGroup, t token.IToken) {
+	v1 := g.Group("/v1")
+	pr := v1.Group("/profile", jwt.Handler(t))
+	{
+		pr.GET("", hserver.NewNotParHandlerFu(c.GetProfile))
+		pr.PUT("", oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.moduleName,
+			Action:      "修改资料",
+		}), hserver.NewHandlerFu[commands.UpdateProfileCommand](c.UpdateProfile))
+		pr.PUT("/password", jwt.DenyImpersonation(), oplog.Record(oplog.LogOption{
+			IncludeBody: false,
+			Module:      c.moduleName,
+			Action:      "修改密码",
+		}), hserver.NewHandlerFu[commands.ChangePasswordCommand](c.ChangePassword))
+		pr.POST("/avatar", oplog.Record(oplog.LogOption{
+			IncludeBody: false,
+			Module:      c.moduleName,
+			Action:      "修改头像",
+		}), c.UploadAvatar)
+		pr.POST("/verify-code", hserver.NewHandlerFu[commands.SendVerifyCodeCommand](c.SendVerifyCode))
+		pr.PUT("/contact", jwt.DenyImpersonation(), oplog.Record(oplog.LogOption{
+			IncludeBody: false,
+			Module:      c.moduleName,
+			Action:      "修改联系方式",
+		}), hserver.NewHandlerFu[commands.ChangeContactCommand](c.ChangeContact))
+		pr.GET("/login-logs", hserver.NewHandlerFu[queries.ListProfileLoginLogsQuery](c.LoginLogs))
+		pr.GET("/tenant-invitations", hserver.NewNotParHandlerFu(c.TenantInvitations))
+		pr.POST("/tenant-invitations/:id/accept", jwt.DenyImpersonation(), oplog.Record(oplog.LogOption{
+			IncludeBody: false,
+			Module:      c.moduleName,
+			Action:      "接受租户邀请",
+		}), hserver.NewHandlerFu[models.StringIdReq](c.AcceptTenantInvitation))
+		pr.POST("/tenant-invitations/:id/decline", jwt.DenyImpersonation(), oplog.Record(oplog.LogOption{
+			IncludeBody: false,
+			Module:      c.moduleName,
+			Action:      "拒绝租户邀请",
+		}), hserver.NewHandlerFu[models.StringIdReq](c.DeclineTenantInvitation))
+	}
+}
+
+// GetProfile 获取个人信息
+// @Summary 获取个人信息
+// @Description 获取当前登录用户的基本信息
+// @Tags 个人中心
+// @ID GetProfile
+// @Accept json
+// @Produce json
+// @Success 200 {object} base_info.Success{data=dto.UserDto}
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/profile [get]
+func (c *ProfileController) GetProfile(ctx context.Context) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.handler.HandleGet(ctx)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// UpdateProfile 修改个人资料
+// @Summary 修改个人资料
+// @Description 修改姓名、昵称、备注, 邮箱和手机号需验证后修改
+// @Tags 个人中心
+// @ID UpdateProfile
+// @Accept json
+// @Produce json
+// @Param req body commands.UpdateProfileCommand true "个人资料"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/profile [put]
+func (c *ProfileController) UpdateProfile(ctx context.Context, params *commands.UpdateProfileCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.handler.HandleUpdate(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// ChangePassword 修改密码
+// @Summary 修改密码
+// @Description 校验原密码后修改当前登录用户的密码
+// @Tags 个人中心
+// @ID ChangeProfilePassword
+// @Accept json
+// @Produce json
+// @Param req body commands.ChangePasswordCommand true "密码信息"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/profile/password [put]
+func (c *ProfileController) ChangePassword(ctx context.Context, params *commands.ChangePasswordCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.handler.HandleChangePassword(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// UploadAvatar 上传头像
+// @Summary 上传头像
+// @Description 通过存储模块上传头像并设置为当前头像, 支持 jpg/jpeg/png/gif/webp, 最大 2MB
+// @Tags 个人中心
+// @ID UploadProfileAvatar
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "头像文件"
+// @Success 200 {object} base_info.Success{data=string}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/profile/avatar [post]
+func (c *ProfileController) UploadAvatar(ctx context.Context, rc *app.RequestContext) {
+	result := hserver.DefaultResponseResult()
+	fileHeader, err := rc.FormFile("file")
+	if err != nil {
+		rc.JSON(http.StatusOK, result.WithError(herrors.NewBadReqError("获取文件失败")))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		rc.JSON(http.StatusOK, result.WithError(herrors.NewBadReqError("读取文件失败")))
+		return
+	}
+	defer file.Close()
+
+	url, herr := c.handler.HandleUploadAvatar(ctx, fileHeader.Filename, file, fileHeader.Size)
+	if herr != nil {
+		rc.JSON(http.StatusOK, result.WithError(herr))
+		return
+	}
+	rc.JSON(http.StatusOK, result.WithData(url))
+}
+
+// SendVerifyCode 发送验证码
+// @Summary 发送验证码
+// @Description 向新的邮箱或手机号发送验证码, 同一号码每分钟只能发送一次
+// @Tags 个人中心
+// @ID SendProfileVerifyCode
+// @Accept json
+// @Produce json
+// @Param req body commands.SendVerifyCodeCommand true "邮箱或手机号"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/profile/verify-code [post]
+func (c *ProfileController) SendVerifyCode(ctx context.Context, params *commands.SendVerifyCodeCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.handler.HandleSendVerifyCode(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// ChangeContact 修改邮箱或手机号
+// @Summary 修改邮箱或手机号
+// @Description 校验验证码后修改邮箱或手机号
+// @Tags 个人中心
+// @ID ChangeProfileContact
+// @Accept json
+// @Produce json
+// @Param req body commands.ChangeContactCommand true "验证信息"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/profile/contact [put]
+func (c *ProfileController) ChangeContact(ctx context.Context, params *commands.ChangeContactCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.handler.HandleChangeContact(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// LoginLogs 查询本人登录记录
+// @Summary 查询本人登录记录
+// @Description 按月查询当前登录用户的登录记录
+// @Tags 个人中心
+// @ID ListProfileLoginLogs
+// @Accept json
+// @Produce json
+// @Param req query queries.ListProfileLoginLogsQuery true "查询参数"
+// @Success 200 {object} base_info.Success{data=[]dto.LoginLogDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/profile/login-logs [get]
+func (c *ProfileController) LoginLogs(ctx context.Context, params *queries.ListProfileLoginLogsQuery) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.handler.HandleLoginLogs(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// TenantInvitations 查询待接受的租户邀请
+// @Summary 查询待接受的租户邀请
+// @Description 查询邀请本人加入且尚未接受的租户
+// @Tags 个人中心
+// @ID ListProfileTenantInvitations
+// @Accept json
+// @Produce json
+// @Success 200 {object} base_info.Success{data=[]dto.UserTenantDto}
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/profile/tenant-invitations [get]
+func (c *ProfileController) TenantInvitations(ctx context.Context) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.handler.HandleTenantInvitations(ctx)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// AcceptTenantInvitation 接受租户邀请
+// @Summary 接受租户邀请
+// @Description 接受后成为该租户的成员, 可以切换到该租户
+// @Tags 个人中心
+// @ID AcceptProfileTenantInvitation
+// @Accept json
+// @Produce json
+// @Param id path string true "租户ID"
+// @Success 200 {object} base_info.Success
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/profile/tenant-invitations/{id}/accept [post]
+func (c *ProfileController) AcceptTenantInvitation(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.handler.HandleAcceptTenantInvitation(ctx, params.Id)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// DeclineTenantInvitation 拒绝租户邀请
+// @Summary 拒绝租户邀请
+// @Description 拒绝后删除该邀请
+// @Tags 个人中心
+// @ID DeclineProfileTenantInvitation
+// @Accept json
+// @Produce json
+// @Param id path string true "租户ID"
+// @Success 200 {object} base_info.Success
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/profile/tenant-invitations/{id}/decline [post]
+func (c *ProfileController) DeclineTenantInvitation(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.handler.HandleDeclineTenantInvitation(ctx, params.Id)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
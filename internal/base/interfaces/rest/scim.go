@@ -0,0 +1,401 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/hertz/pkg/route"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/handlers"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/plugin"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/casbin"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/jwt"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/oplog"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/models"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/scim"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/token"
+)
+
+// ScimController SCIM 2.0 用户和组同步接口, 以及 SCIM 令牌管理接口
+type ScimController struct {
+	scimHandel  *handlers.ScimHandler
+	tokenHandel *handlers.ScimTokenHandler
+	ef          *casbin.Enforcer
+	modeNma     string
+}
+
+func NewScimController(scimHandel *handlers.ScimHandler, tokenHandel *handlers.ScimTokenHandler, ef *casbin.Enforcer) *ScimController {
+	return &ScimController{
+		scimHandel:  scimHandel,
+		tokenHandel: tokenHandel,
+		ef:          ef,
+		modeNma:     "SCIM令牌",
+	}
+}
+
+func (c *ScimController) RegisterRouter(g *route.RouterGroup, t token.IToken) {
+	v1 := g.Group("/v1")
+	tr := v1.Group("/sys/scim/tokens", jwt.Handler(t))
+	{
+		tr.POST("", casbin.Handler(c.ef), jwt.DenyImpersonation(), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "新增",
+		}), hserver.NewHandlerFu[commands.CreateScimTokenCommand](c.CreateToken))
+		tr.GET("", casbin.Handler(c.ef), hserver.NewNotParHandlerFu(c.TokenList))
+		tr.DELETE("/:id", casbin.Handler(c.ef), jwt.DenyImpersonation(), oplog.Record(oplog.LogOption{
+			Module: c.modeNma,
+			Action: "删除",
+		}), hserver.NewHandlerFu[models.StringIdReq](c.DeleteToken))
+	}
+
+	sr := g.Group("/scim/v2", c.authenticate)
+	{
+		sr.GET("/ServiceProviderConfig", c.ServiceProviderConfig)
+		sr.GET("/Users", c.ListUsers)
+		sr.POST("/Users", c.CreateUser)
+		sr.GET("/Users/:id", c.GetUser)
+		sr.PUT("/Users/:id", c.ReplaceUser)
+		sr.PATCH("/Users/:id", c.PatchUser)
+		sr.DELETE("/Users/:id", c.DeleteUser)
+		sr.GET("/Groups", c.ListGroups)
+		sr.POST("/Groups", c.CreateGroup)
+		sr.GET("/Groups/:id", c.GetGroup)
+		sr.PUT("/Groups/:id", c.ReplaceGroup)
+		sr.PATCH("/Groups/:id", c.PatchGroup)
+		sr.DELETE("/Groups/:id", c.DeleteGroup)
+	}
+}
+
+// CreateToken 创建SCIM令牌
+// @Summary 创建SCIM令牌
+// @Description 创建当前租户的SCIM令牌, 令牌明文只在创建时返回一次
+// @Tags SCIM令牌
+// @ID CreateScimToken
+// @Accept json
+// @Produce json
+// @Param req body commands.CreateScimTokenCommand true "属性说明请在对应model中查看"
+// @Success 200 {object} base_info.Success{data=dto.ScimTokenDto}
+// @Failure 400 {object} base_info.Swagger400Resp "code为400 参数输入错误"
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Failure 500 {object} base_info.Swagger500Resp "code为500 服务端内部错误"
+// @Router /v1/sys/scim/tokens [post]
+func (c *ScimController) CreateToken(ctx context.Context, params *commands.CreateScimTokenCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.tokenHandel.HandleCreate(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// TokenList SCIM令牌列表
+// @Summary SCIM令牌列表
+// @Description 当前租户的SCIM令牌列表
+// @Tags SCIM令牌
+// @ID ScimTokenList
+// @Accept json
+// @Produce json
+// @Success 200 {object} base_info.Success{data=[]dto.ScimTokenDto}
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Failure 500 {object} base_info.Swagger500Resp "code为500 服务端内部错误"
+// @Router /v1/sys/scim/tokens [get]
+func (c *ScimController) TokenList(ctx context.Context) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.tokenHandel.HandleList(ctx)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// DeleteToken 删除SCIM令牌
+// @Summary 删除SCIM令牌
+// @Description 删除SCIM令牌, 删除后立即失效
+// @Tags SCIM令牌
+// @ID DeleteScimToken
+// @Accept json
+// @Produce json
+// @Param id path string true "令牌ID"
+// @Success 200 {object} base_info.Success
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Failure 404 {object} base_info.Swagger400Resp "code为404 令牌不存在"
+// @Failure 500 {object} base_info.Swagger500Resp "code为500 服务端内部错误"
+// @Router /v1/sys/scim/tokens/{id} [delete]
+func (c *ScimController) DeleteToken(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	if err := c.tokenHandel.HandleDelete(ctx, params.Id); err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// authenticate 校验 Bearer 令牌, 以令牌所属租户处理请求
+// 已通过请求头或域名解析出租户时, 令牌必须属于该租户
+func (c *ScimController) authenticate(ctx context.Context, rc *app.RequestContext) {
+	authorization := string(rc.Request.Header.Peek("Authorization"))
+	parts := strings.SplitN(authorization, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") || parts[1] == "" {
+		rc.Response.Header.Set("WWW-Authenticate", "Bearer")
+		c.writeError(rc, scim.NewError(http.StatusUnauthorized, "", "bearer token is required"))
+		rc.Abort()
+		return
+	}
+	tk, hr := c.tokenHandel.HandleAuthenticate(ctx, parts[1])
+	if hr != nil {
+		hlog.CtxInfof(ctx, "scim authenticate failed: %s", hr)
+		c.writeError(rc, scim.NewError(http.StatusUnauthorized, "", hr.DefMessage))
+		rc.Abort()
+		return
+	}
+	if resolved := plugin.GetCtxTenantID(ctx); resolved != "" && resolved != tk.TenantID {
+		c.writeError(rc, scim.NewError(http.StatusForbidden, "", "token does not belong to the requested tenant"))
+		rc.Abort()
+		return
+	}
+	ctx = actx.BuildTenantCtx(ctx, tk.TenantID)
+	ctx = actx.WithUserId(ctx, "scim:"+tk.ID)
+	ctx = actx.WithUsername(ctx, "scim:"+tk.Name)
+	rc.Next(ctx)
+}
+
+// ServiceProviderConfig 服务能力说明
+func (c *ScimController) ServiceProviderConfig(ctx context.Context, rc *app.RequestContext) {
+	cfg := scim.DefaultServiceProviderConfig()
+	cfg.Meta.Location = c.location(rc, "ServiceProviderConfig", "")
+	c.write(rc, http.StatusOK, cfg)
+}
+
+// ListUsers 查询用户, 支持 filter、startIndex 和 count
+func (c *ScimController) ListUsers(ctx context.Context, rc *app.RequestContext) {
+	page, ok := c.pagination(rc)
+	if !ok {
+		return
+	}
+	data, hr := c.scimHandel.ListUsers(ctx, rc.Query("filter"), page)
+	if hr != nil {
+		c.writeHError(ctx, rc, hr)
+		return
+	}
+	for _, u := range data.Resources.([]*scim.User) {
+		u.Meta.Location = c.location(rc, "Users", u.ID)
+	}
+	c.write(rc, http.StatusOK, data)
+}
+
+// GetUser 获取用户
+func (c *ScimController) GetUser(ctx context.Context, rc *app.RequestContext) {
+	data, hr := c.scimHandel.GetUser(ctx, rc.Param("id"))
+	c.writeUser(ctx, rc, http.StatusOK, data, hr)
+}
+
+// CreateUser 创建用户
+func (c *ScimController) CreateUser(ctx context.Context, rc *app.RequestContext) {
+	var in scim.User
+	if !c.bind(rc, &in) {
+		return
+	}
+	data, hr := c.scimHandel.CreateUser(ctx, &in)
+	c.writeUser(ctx, rc, http.StatusCreated, data, hr)
+}
+
+// ReplaceUser 替换用户
+func (c *ScimController) ReplaceUser(ctx context.Context, rc *app.RequestContext) {
+	var in scim.User
+	if !c.bind(rc, &in) {
+		return
+	}
+	data, hr := c.scimHandel.ReplaceUser(ctx, rc.Param("id"), &in)
+	c.writeUser(ctx, rc, http.StatusOK, data, hr)
+}
+
+// PatchUser 修改用户
+func (c *ScimController) PatchUser(ctx context.Context, rc *app.RequestContext) {
+	var req scim.PatchRequest
+	if !c.bind(rc, &req) {
+		return
+	}
+	data, hr := c.scimHandel.PatchUser(ctx, rc.Param("id"), &req)
+	c.writeUser(ctx, rc, http.StatusOK, data, hr)
+}
+
+// DeleteUser 删除用户
+func (c *ScimController) DeleteUser(ctx context.Context, rc *app.RequestContext) {
+	if hr := c.scimHandel.DeleteUser(ctx, rc.Param("id")); hr != nil {
+		c.writeHError(ctx, rc, hr)
+		return
+	}
+	rc.SetStatusCode(http.StatusNoContent)
+}
+
+// ListGroups 查询组, excludedAttributes=members 时不返回成员
+func (c *ScimController) ListGroups(ctx context.Context, rc *app.RequestContext) {
+	page, ok := c.pagination(rc)
+	if !ok {
+		return
+	}
+	data, hr := c.scimHandel.ListGroups(ctx, rc.Query("filter"), page, !excludeMembers(rc))
+	if hr != nil {
+		c.writeHError(ctx, rc, hr)
+		return
+	}
+	for _, g := range data.Resources.([]*scim.Group) {
+		g.Meta.Location = c.location(rc, "Groups", g.ID)
+	}
+	c.write(rc, http.StatusOK, data)
+}
+
+// GetGroup 获取组
+func (c *ScimController) GetGroup(ctx context.Context, rc *app.RequestContext) {
+	data, hr := c.scimHandel.GetGroup(ctx, rc.Param("id"), !excludeMembers(rc))
+	c.writeGroup(ctx, rc, http.StatusOK, data, hr)
+}
+
+// CreateGroup 创建组
+func (c *ScimController) CreateGroup(ctx context.Context, rc *app.RequestContext) {
+	var in scim.Group
+	if !c.bind(rc, &in) {
+		return
+	}
+	data, hr := c.scimHandel.CreateGroup(ctx, &in)
+	c.writeGroup(ctx, rc, http.StatusCreated, data, hr)
+}
+
+// ReplaceGroup 替换组
+func (c *ScimController) ReplaceGroup(ctx context.Context, rc *app.RequestContext) {
+	var in scim.Group
+	if !c.bind(rc, &in) {
+		return
+	}
+	data, hr := c.scimHandel.ReplaceGroup(ctx, rc.Param("id"), &in)
+	c.writeGroup(ctx, rc, http.StatusOK, data, hr)
+}
+
+// PatchGroup 修改组, 成功时不返回内容
+func (c *ScimController) PatchGroup(ctx context.Context, rc *app.RequestContext) {
+	var req scim.PatchRequest
+	if !c.bind(rc, &req) {
+		return
+	}
+	if hr := c.scimHandel.PatchGroup(ctx, rc.Param("id"), &req); hr != nil {
+		c.writeHError(ctx, rc, hr)
+		return
+	}
+	rc.SetStatusCode(http.StatusNoContent)
+}
+
+// DeleteGroup 删除组
+func (c *ScimController) DeleteGroup(ctx context.Context, rc *app.RequestContext) {
+	if hr := c.scimHandel.DeleteGroup(ctx, rc.Param("id")); hr != nil {
+		c.writeHError(ctx, rc, hr)
+		return
+	}
+	rc.SetStatusCode(http.StatusNoContent)
+}
+
+func (c *ScimController) writeUser(ctx context.Context, rc *app.RequestContext, status int, data *scim.User, hr herrors.Herr) {
+	if hr != nil {
+		c.writeHError(ctx, rc, hr)
+		return
+	}
+	data.Meta.Location = c.location(rc, "Users", data.ID)
+	rc.Response.Header.Set("Location", data.Meta.Location)
+	c.write(rc, status, data)
+}
+
+func (c *ScimController) writeGroup(ctx context.Context, rc *app.RequestContext, status int, data *scim.Group, hr herrors.Herr) {
+	if hr != nil {
+		c.writeHError(ctx, rc, hr)
+		return
+	}
+	data.Meta.Location = c.location(rc, "Groups", data.ID)
+	rc.Response.Header.Set("Location", data.Meta.Location)
+	c.write(rc, status, data)
+}
+
+// pagination 解析分页参数, 参数不合法时直接返回错误
+func (c *ScimController) pagination(rc *app.RequestContext) (scim.Pagination, bool) {
+	startIndex, count := 1, 0
+	var err error
+	if v := rc.Query("startIndex"); v != "" {
+		if startIndex, err = strconv.Atoi(v); err != nil {
+			c.writeError(rc, scim.NewError(http.StatusBadRequest, scim.ErrInvalidValue, "startIndex must be an integer"))
+			return scim.Pagination{}, false
+		}
+	}
+	v := rc.Query("count")
+	if v != "" {
+		if count, err = strconv.Atoi(v); err != nil {
+			c.writeError(rc, scim.NewError(http.StatusBadRequest, scim.ErrInvalidValue, "count must be an integer"))
+			return scim.Pagination{}, false
+		}
+	}
+	return scim.NewPagination(startIndex, count, v != ""), true
+}
+
+func (c *ScimController) bind(rc *app.RequestContext, v interface{}) bool {
+	if err := json.Unmarshal(rc.Request.Body(), v); err != nil {
+		c.writeError(rc, scim.NewError(http.StatusBadRequest, scim.ErrInvalidSyntax, err.Error()))
+		return false
+	}
+	return true
+}
+
+// location 资源地址
+func (c *ScimController) location(rc *app.RequestContext, resourceType, id string) string {
+	path := string(rc.Path())
+	base := path[:strings.Index(path, "/scim/v2")+len("/scim/v2")]
+	loc := fmt.Sprintf("%s://%s%s/%s", rc.URI().Scheme(), rc.Host(), base, resourceType)
+	if id != "" {
+		loc += "/" + id
+	}
+	return loc
+}
+
+// writeHError 业务错误转换为 SCIM 错误, 使用错误码作为 HTTP 状态
+func (c *ScimController) writeHError(ctx context.Context, rc *app.RequestContext, hr herrors.Herr) {
+	status := hr.Code
+	if status < http.StatusBadRequest || status > 599 {
+		status = http.StatusInternalServerError
+	}
+	if status >= http.StatusInternalServerError {
+		hlog.CtxErrorf(ctx, "scim request error: %s", hr)
+	}
+	scimType := ""
+	if scim.IsErrorType(hr.Reason) {
+		scimType = hr.Reason
+	}
+	c.writeError(rc, scim.NewError(status, scimType, hr.DefMessage))
+}
+
+func (c *ScimController) writeError(rc *app.RequestContext, e *scim.Error) {
+	c.write(rc, e.StatusCode(), e)
+}
+
+func (c *ScimController) write(rc *app.RequestContext, status int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		rc.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	rc.Data(status, scim.ContentType, body)
+}
+
+func excludeMembers(rc *app.RequestContext) bool {
+	for _, attr := range strings.Split(rc.Query("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attr), "members") {
+			return true
+		}
+	}
+	return false
+}
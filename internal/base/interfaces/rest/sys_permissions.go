@@ -2,15 +2,25 @@ package rest
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/handlers"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/casbin"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/jwt"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/oplog"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
 
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/models"
 
+	_ "github.com/ares-cloud/ares-ddd-admin/internal/base/application/dto"
 	_ "github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver"
 	_ "github.com/ares-cloud/ares-ddd-admin/pkg/hserver/base_info"
@@ -21,14 +31,16 @@ import (
 type SysPermissionsController struct {
 	cmdHandel   *handlers.PermissionsCommandHandler
 	queryHandel *handlers.PermissionsQueryHandler
+	seedHandel  *handlers.PermissionSeedHandler
 	ef          *casbin.Enforcer
 	modeNma     string
 }
 
-func NewSysPermissionsController(cmdHandel *handlers.PermissionsCommandHandler, queryHandel *handlers.PermissionsQueryHandler, ef *casbin.Enforcer) *SysPermissionsController {
+func NewSysPermissionsController(cmdHandel *handlers.PermissionsCommandHandler, queryHandel *handlers.PermissionsQueryHandler, seedHandel *handlers.PermissionSeedHandler, ef *casbin.Enforcer) *SysPermissionsController {
 	return &SysPermissionsController{
 		cmdHandel:   cmdHandel,
 		queryHandel: queryHandel,
+		seedHandel:  seedHandel,
 		ef:          ef,
 		modeNma:     "系统权限",
 	}
@@ -58,6 +70,13 @@ func (c *SysPermissionsController) RegisterRouter(g *route.RouterGroup, t token.
 		ur.GET("/tree", hserver.NewHandlerFu[queries.GetPermissionsTreeQuery](c.GetPermissionsTree))
 		ur.GET("/simple/tree", hserver.NewNotParHandlerFu(c.GetPermissionsSimpleTree))
 		ur.GET("/enabled", hserver.NewNotParHandlerFu(c.GetAllEnabled))
+		ur.GET("/explain", casbin.Handler(c.ef), hserver.NewHandlerFu[queries.ExplainPermissionQuery](c.Explain))
+		ur.GET("/seed/export", casbin.Handler(c.ef), c.ExportSeed)
+		ur.POST("/seed/import", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: false,
+			Module:      c.modeNma,
+			Action:      "导入种子",
+		}), c.ImportSeed)
 	}
 }
 
@@ -81,6 +100,26 @@ func (c *SysPermissionsController) AddPermissions(ctx context.Context, params *c
 	return result
 }
 
+// Explain 解释接口访问结果
+// @Summary 解释接口访问结果
+// @Description 列出用户角色在当前租户下与请求匹配的策略, 以及条件表达式的求值结果
+// @Tags 系统权限
+// @ID ExplainPermission
+// @Param req query queries.ExplainPermissionQuery true "属性说明请在对应model中查看"
+// @Success 200 {object} base_info.Success{data=dto.PermissionExplainDto}
+// @Failure 400 {object} base_info.Swagger400Resp "code为400 参数输入错误"
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Failure 500 {object} base_info.Swagger500Resp "code为500 服务端内部错误"
+// @Router /v1/sys/permissions/explain [get]
+func (c *SysPermissionsController) Explain(ctx context.Context, params *queries.ExplainPermissionQuery) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.queryHandel.HandleExplain(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
 // PermissionsList 获取权限列表
 // @Summary 获取权限列表
 // @Description 获取权限列表
@@ -232,3 +271,92 @@ func (c *SysPermissionsController) GetAllEnabled(ctx context.Context) *hserver.R
 	}
 	return result.WithData(data)
 }
+
+// ExportSeed 导出权限种子
+// @Summary 导出权限种子
+// @Description 导出全部权限及其资源, 以编码标识并按层级嵌套, 可导入到其他环境
+// @Tags 系统权限
+// @ID ExportPermissionSeed
+// @Produce octet-stream
+// @Param format query string false "文件格式 yaml/json, 默认 yaml"
+// @Success 200 {file} file "种子文件"
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Router /v1/sys/permissions/seed/export [get]
+func (c *SysPermissionsController) ExportSeed(ctx context.Context, rc *app.RequestContext) {
+	var params queries.ExportPermissionSeedQuery
+	if err := rc.BindAndValidate(&params); err != nil {
+		rc.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if params.Format == "" {
+		params.Format = handlers.PermissionSeedYAML
+	}
+	content, herr := c.seedHandel.HandleExport(ctx, params.Format)
+	if herr != nil {
+		hlog.CtxErrorf(ctx, "export permission seed error: %s", herr)
+		rc.JSON(http.StatusOK, hserver.DefaultResponseResult().WithError(herr))
+		return
+	}
+	filename := fmt.Sprintf("permissions-%s.%s", time.Now().Format("20060102150405"), params.Format)
+	rc.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	contentType := "application/yaml; charset=utf-8"
+	if params.Format == handlers.PermissionSeedJSON {
+		contentType = "application/json; charset=utf-8"
+	}
+	rc.Data(http.StatusOK, contentType, content)
+}
+
+// ImportSeed 导入权限种子
+// @Summary 导入权限种子
+// @Description 上传 yaml/json 种子文件, 按编码新增或更新权限, dryRun=true 时只返回差异; prune=true 时删除种子中没有的权限
+// @Tags 系统权限
+// @ID ImportPermissionSeed
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "种子文件"
+// @Param dryRun formData bool false "仅比较差异"
+// @Param prune formData bool false "删除种子中没有的权限"
+// @Success 200 {object} base_info.Success{data=dto.PermissionSeedDiffDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "服务器内部错误"
+// @Router /v1/sys/permissions/seed/import [post]
+func (c *SysPermissionsController) ImportSeed(ctx context.Context, rc *app.RequestContext) {
+	result := hserver.DefaultResponseResult()
+	fileHeader, err := rc.FormFile("file")
+	if err != nil {
+		rc.JSON(http.StatusOK, result.WithError(herrors.NewBadReqError("获取文件失败")))
+		return
+	}
+	format := handlers.PermissionSeedFormat(fileHeader.Filename)
+	if format == "" {
+		rc.JSON(http.StatusOK, result.WithError(herrors.NewBadReqError("仅支持 yaml/json 文件")))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		rc.JSON(http.StatusOK, result.WithError(herrors.NewBadReqError("读取文件失败")))
+		return
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		rc.JSON(http.StatusOK, result.WithError(herrors.NewBadReqError("读取文件失败")))
+		return
+	}
+	dryRun, _ := strconv.ParseBool(string(rc.FormValue("dryRun")))
+	prune, _ := strconv.ParseBool(string(rc.FormValue("prune")))
+
+	data, herr := c.seedHandel.HandleImport(ctx, &commands.ImportPermissionSeedCommand{
+		Format:  format,
+		DryRun:  dryRun,
+		Prune:   prune,
+		Content: content,
+	})
+	if herr != nil {
+		rc.JSON(http.StatusOK, result.WithError(herr))
+		return
+	}
+	rc.JSON(http.StatusOK, result.WithData(data))
+}
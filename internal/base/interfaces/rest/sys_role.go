@@ -6,6 +6,7 @@ import (
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/handlers"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/casbin"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/jwt"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/oplog"
@@ -20,18 +21,22 @@ import (
 )
 
 type SysRoleController struct {
-	cmdHandel   *handlers.RoleCommandHandler
-	queryHandel *handlers.RoleQueryHandler
-	ef          *casbin.Enforcer
-	modeNma     string
+	cmdHandel        *handlers.RoleCommandHandler
+	queryHandel      *handlers.RoleQueryHandler
+	recycleHandel    *handlers.RecycleBinHandler
+	constraintHandel *handlers.RoleConstraintHandler
+	ef               *casbin.Enforcer
+	modeNma          string
 }
 
-func NewSysRoleController(cmdHandel *handlers.RoleCommandHandler, queryHandel *handlers.RoleQueryHandler, ef *casbin.Enforcer) *SysRoleController {
+func NewSysRoleController(cmdHandel *handlers.RoleCommandHandler, queryHandel *handlers.RoleQueryHandler, recycleHandel *handlers.RecycleBinHandler, constraintHandel *handlers.RoleConstraintHandler, ef *casbin.Enforcer) *SysRoleController {
 	return &SysRoleController{
-		cmdHandel:   cmdHandel,
-		queryHandel: queryHandel,
-		ef:          ef,
-		modeNma:     "角色",
+		cmdHandel:        cmdHandel,
+		queryHandel:      queryHandel,
+		recycleHandel:    recycleHandel,
+		constraintHandel: constraintHandel,
+		ef:               ef,
+		modeNma:          "角色",
 	}
 }
 
@@ -63,6 +68,29 @@ func (c *SysRoleController) RegisterRouter(g *route.RouterGroup, t token.IToken)
 		ur.GET("/:id", casbin.Handler(c.ef), hserver.NewHandlerFu[models.IntIdReq](c.GetDetails))
 		ur.GET("/enabled", hserver.NewNotParHandlerFu(c.GetAllEnabled))
 		ur.GET("/data-permission", hserver.NewNotParHandlerFu(c.GetAllDataPermission))
+		ur.GET("/recycle", casbin.Handler(c.ef), hserver.NewHandlerFu[queries.ListRecycleBinQuery](c.RecycleList))
+		ur.POST("/recycle/:id/restore", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "恢复",
+		}), hserver.NewHandlerFu[models.StringIdReq](c.Restore))
+		ur.GET("/constraint", casbin.Handler(c.ef), hserver.NewNotParHandlerFu(c.ConstraintList))
+		ur.GET("/constraint/violations", casbin.Handler(c.ef), hserver.NewNotParHandlerFu(c.ConstraintViolations))
+		ur.POST("/constraint", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "新增职责分离约束",
+		}), hserver.NewHandlerFu[commands.CreateRoleConstraintCommand](c.AddConstraint))
+		ur.PUT("/constraint", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "修改职责分离约束",
+		}), hserver.NewHandlerFu[commands.UpdateRoleConstraintCommand](c.UpdateConstraint))
+		ur.DELETE("/constraint/:id", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "删除职责分离约束",
+		}), hserver.NewHandlerFu[models.IntIdReq](c.DeleteConstraint))
 	}
 }
 
@@ -235,3 +263,155 @@ func (c *SysRoleController) GetAllDataPermission(ctx context.Context) *hserver.R
 	}
 	return result.WithData(data)
 }
+
+// RecycleList 获取角色回收站列表
+// @Summary 获取角色回收站列表
+// @Description 获取已删除且未超过保留期的角色
+// @Tags 系统角色
+// @ID RecycleRoleList
+// @Accept json
+// @Produce json
+// @Param req query queries.ListRecycleBinQuery true "查询参数"
+// @Success 200 {object} base_info.Success{data=models.PageRes[dto.RecycleItemDto]}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/role/recycle [get]
+func (c *SysRoleController) RecycleList(ctx context.Context, params *queries.ListRecycleBinQuery) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.recycleHandel.HandleList(ctx, model.RecycleEntityRole, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// Restore 从回收站恢复角色
+// @Summary 从回收站恢复角色
+// @Description 恢复角色及其仍然存在的关联关系
+// @Tags 系统角色
+// @ID RestoreRole
+// @Accept json
+// @Produce json
+// @Param id path string true "角色ID"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/role/recycle/{id}/restore [post]
+func (c *SysRoleController) Restore(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.recycleHandel.HandleRestore(ctx, model.RecycleEntityRole, params.Id)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// ConstraintList 获取职责分离约束
+// @Summary 获取职责分离约束
+// @Description 获取当前租户的互斥角色约束和角色基数约束
+// @Tags 系统角色
+// @ID RoleConstraintList
+// @Accept json
+// @Produce json
+// @Success 200 {object} base_info.Success{data=[]dto.RoleConstraintDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/role/constraint [get]
+func (c *SysRoleController) ConstraintList(ctx context.Context) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.constraintHandel.HandleList(ctx)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// ConstraintViolations 获取违反职责分离约束的现有分配
+// @Summary 获取违反职责分离约束的现有分配
+// @Description 列出同时持有互斥角色的用户以及持有人数超过上限的基数约束
+// @Tags 系统角色
+// @ID RoleConstraintViolations
+// @Accept json
+// @Produce json
+// @Success 200 {object} base_info.Success{data=[]dto.RoleConstraintViolationDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/role/constraint/violations [get]
+func (c *SysRoleController) ConstraintViolations(ctx context.Context) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.constraintHandel.HandleViolations(ctx)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// AddConstraint 新增职责分离约束
+// @Summary 新增职责分离约束
+// @Description 互斥约束限制单个用户最多持有集合中的角色数, 基数约束限制持有集合中角色的用户数
+// @Tags 系统角色
+// @ID AddRoleConstraint
+// @Accept json
+// @Produce json
+// @Param req body commands.CreateRoleConstraintCommand true "约束信息"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/role/constraint [post]
+func (c *SysRoleController) AddConstraint(ctx context.Context, params *commands.CreateRoleConstraintCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.constraintHandel.HandleCreate(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// UpdateConstraint 修改职责分离约束
+// @Summary 修改职责分离约束
+// @Description 修改约束名称、角色集合和上限, 约束类型不可修改
+// @Tags 系统角色
+// @ID UpdateRoleConstraint
+// @Accept json
+// @Produce json
+// @Param req body commands.UpdateRoleConstraintCommand true "约束信息"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/role/constraint [put]
+func (c *SysRoleController) UpdateConstraint(ctx context.Context, params *commands.UpdateRoleConstraintCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.constraintHandel.HandleUpdate(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// DeleteConstraint 删除职责分离约束
+// @Summary 删除职责分离约束
+// @Description 删除指定ID的约束
+// @Tags 系统角色
+// @ID DeleteRoleConstraint
+// @Accept json
+// @Produce json
+// @Param id path int64 true "约束ID"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/role/constraint/{id} [delete]
+func (c *SysRoleController) DeleteConstraint(ctx context.Context, params *models.IntIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.constraintHandel.HandleDelete(ctx, params.Id)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
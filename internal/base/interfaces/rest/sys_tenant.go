@@ -2,6 +2,9 @@ package rest
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/handlers"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
@@ -14,20 +17,31 @@ import (
 	_ "github.com/ares-cloud/ares-ddd-admin/pkg/hserver/base_info"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/models"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/token"
+	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/route"
 )
 
 type SysTenantController struct {
 	cmdHandel   *handlers.TenantCommandHandler
 	queryHandel *handlers.TenantQueryHandler
+	jobHandel   *handlers.TenantJobHandler
+	tplHandel   *handlers.TenantTemplateHandler
 	ef          *casbin.Enforcer
 	modeNma     string
 }
 
-func NewSysTenantController(cmdHandel *handlers.TenantCommandHandler, queryHandel *handlers.TenantQueryHandler, ef *casbin.Enforcer) *SysTenantController {
+func NewSysTenantController(
+	cmdHandel *handlers.TenantCommandHandler,
+	queryHandel *handlers.TenantQueryHandler,
+	jobHandel *handlers.TenantJobHandler,
+	tplHandel *handlers.TenantTemplateHandler,
+	ef *casbin.Enforcer,
+) *SysTenantController {
 	return &SysTenantController{
 		cmdHandel:   cmdHandel,
 		queryHandel: queryHandel,
+		jobHandel:   jobHandel,
+		tplHandel:   tplHandel,
 		ef:          ef,
 		modeNma:     "租户",
 	}
@@ -59,6 +73,44 @@ func (c *SysTenantController) RegisterRouter(g *route.RouterGroup, t token.IToke
 			Action:      "分配权限",
 		}), casbin.Handler(c.ef), hserver.NewHandlerFu[commands.AssignTenantPermissionsCommand](c.AssignPermissions))
 		ur.GET("/permissions/:id", casbin.Handler(c.ef), hserver.NewHandlerFu[models.StringIdReq](c.GetPermissions))
+		// 租户下线: 数据导出与清除
+		ur.POST("/export", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "导出数据",
+		}), hserver.NewHandlerFu[commands.SubmitTenantJobCommand](c.ExportTenant))
+		ur.POST("/purge", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "清除数据",
+		}), hserver.NewHandlerFu[commands.SubmitTenantJobCommand](c.PurgeTenant))
+		ur.GET("/jobs", casbin.Handler(c.ef), hserver.NewHandlerFu[queries.ListTenantJobsQuery](c.ListJobs))
+		ur.GET("/job/:id", casbin.Handler(c.ef), hserver.NewHandlerFu[models.StringIdReq](c.GetJob))
+		ur.GET("/job/:id/download", casbin.Handler(c.ef), c.DownloadArchive)
+		// 开通模板
+		ur.GET("/templates", casbin.Handler(c.ef), hserver.NewHandlerFu[queries.ListTenantTemplatesQuery](c.ListTemplates))
+		ur.GET("/template/:id", casbin.Handler(c.ef), hserver.NewHandlerFu[models.StringIdReq](c.GetTemplate))
+		ur.GET("/template/:id/download", casbin.Handler(c.ef), c.DownloadTemplate)
+		ur.POST("/template", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "新增模板",
+		}), hserver.NewHandlerFu[commands.CreateTenantTemplateCommand](c.AddTemplate))
+		ur.PUT("/template", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "修改模板",
+		}), hserver.NewHandlerFu[commands.UpdateTenantTemplateCommand](c.UpdateTemplate))
+		ur.DELETE("/template/:id", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "删除模板",
+		}), hserver.NewHandlerFu[models.StringIdReq](c.DeleteTemplate))
+		ur.POST("/template/clone", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "克隆模板",
+		}), hserver.NewHandlerFu[commands.CloneTenantTemplateCommand](c.CloneTemplate))
 	}
 }
 
@@ -193,3 +245,255 @@ func (c *SysTenantController) GetPermissions(ctx context.Context, params *models
 	}
 	return result.WithData(data)
 }
+
+// ExportTenant 导出租户数据
+// @Summary 导出租户数据
+// @Description 提交异步导出任务, 将租户全部数据及存储文件打包为带清单的归档
+// @Tags 系统租户
+// @ID ExportTenant
+// @Accept json
+// @Produce json
+// @Param req body commands.SubmitTenantJobCommand true "租户信息"
+// @Success 200 {object} base_info.Success{data=dto.TenantJobDto}
+// @Failure 400 {object} base_info.Swagger400Resp "code为400 参数输入错误"
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Failure 500 {object} base_info.Swagger500Resp "code为500 服务端内部错误"
+// @Router /v1/sys/tenant/export [post]
+func (c *SysTenantController) ExportTenant(ctx context.Context, params *commands.SubmitTenantJobCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.jobHandel.HandleExport(ctx, *params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// PurgeTenant 清除租户数据
+// @Summary 清除租户数据
+// @Description 提交异步清除任务, 物理删除租户全部数据及存储文件, 租户需先锁定
+// @Tags 系统租户
+// @ID PurgeTenant
+// @Accept json
+// @Produce json
+// @Param req body commands.SubmitTenantJobCommand true "租户信息"
+// @Success 200 {object} base_info.Success{data=dto.TenantJobDto}
+// @Failure 400 {object} base_info.Swagger400Resp "code为400 参数输入错误"
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Failure 500 {object} base_info.Swagger500Resp "code为500 服务端内部错误"
+// @Router /v1/sys/tenant/purge [post]
+func (c *SysTenantController) PurgeTenant(ctx context.Context, params *commands.SubmitTenantJobCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.jobHandel.HandlePurge(ctx, *params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// ListJobs 获取租户下线任务列表
+// @Summary 获取租户下线任务列表
+// @Description 获取租户的导出/清除任务列表
+// @Tags 系统租户
+// @ID ListTenantJobs
+// @Accept json
+// @Produce json
+// @Param tenant_id query string true "租户ID"
+// @Success 200 {object} base_info.Success{data=[]dto.TenantJobDto}
+// @Failure 400 {object} base_info.Swagger400Resp "code为400 参数输入错误"
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Failure 500 {object} base_info.Swagger500Resp "code为500 服务端内部错误"
+// @Router /v1/sys/tenant/jobs [get]
+func (c *SysTenantController) ListJobs(ctx context.Context, params *queries.ListTenantJobsQuery) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.jobHandel.HandleListJobs(ctx, *params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// GetJob 获取租户下线任务
+// @Summary 获取租户下线任务
+// @Description 获取任务进度及签名的完成报告
+// @Tags 系统租户
+// @ID GetTenantJob
+// @Accept json
+// @Produce json
+// @Param id path string true "任务ID"
+// @Success 200 {object} base_info.Success{data=dto.TenantJobDto}
+// @Failure 400 {object} base_info.Swagger400Resp "code为400 参数输入错误"
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Failure 500 {object} base_info.Swagger500Resp "code为500 服务端内部错误"
+// @Router /v1/sys/tenant/job/{id} [get]
+func (c *SysTenantController) GetJob(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.jobHandel.HandleGetJob(ctx, params.Id)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// DownloadArchive 下载租户数据归档
+// @Summary 下载租户数据归档
+// @Description 下载导出任务生成的归档文件
+// @Tags 系统租户
+// @ID DownloadTenantArchive
+// @Produce octet-stream
+// @Param id path string true "任务ID"
+// @Router /v1/sys/tenant/job/{id}/download [get]
+func (c *SysTenantController) DownloadArchive(ctx context.Context, rc *app.RequestContext) {
+	path, filename, err := c.jobHandel.HandleGetArchive(ctx, rc.Param("id"))
+	if err != nil {
+		rc.String(err.Code, err.DefMessage)
+		return
+	}
+	rc.FileAttachment(path, filename)
+}
+
+// ListTemplates 获取开通模板列表
+// @Summary 获取开通模板列表
+// @Description 获取租户开通模板列表, 不包含模板内容
+// @Tags 系统租户
+// @ID ListTenantTemplates
+// @Accept json
+// @Produce json
+// @Param name query string false "模板名称"
+// @Success 200 {object} base_info.Success{data=[]dto.TenantTemplateDto}
+// @Failure 400 {object} base_info.Swagger400Resp "code为400 参数输入错误"
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Failure 500 {object} base_info.Swagger500Resp "code为500 服务端内部错误"
+// @Router /v1/sys/tenant/templates [get]
+func (c *SysTenantController) ListTemplates(ctx context.Context, params *queries.ListTenantTemplatesQuery) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.tplHandel.HandleList(ctx, *params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// GetTemplate 获取开通模板
+// @Summary 获取开通模板
+// @Description 获取租户开通模板详情及内容
+// @Tags 系统租户
+// @ID GetTenantTemplate
+// @Accept json
+// @Produce json
+// @Param id path string true "模板ID"
+// @Success 200 {object} base_info.Success{data=dto.TenantTemplateDto}
+// @Failure 400 {object} base_info.Swagger400Resp "code为400 参数输入错误"
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Failure 500 {object} base_info.Swagger500Resp "code为500 服务端内部错误"
+// @Router /v1/sys/tenant/template/{id} [get]
+func (c *SysTenantController) GetTemplate(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.tplHandel.HandleGet(ctx, params.Id)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// DownloadTemplate 下载开通模板
+// @Summary 下载开通模板
+// @Description 以 YAML/JSON 文件下载租户开通模板
+// @Tags 系统租户
+// @ID DownloadTenantTemplate
+// @Produce octet-stream
+// @Param id path string true "模板ID"
+// @Router /v1/sys/tenant/template/{id}/download [get]
+func (c *SysTenantController) DownloadTemplate(ctx context.Context, rc *app.RequestContext) {
+	tpl, err := c.tplHandel.HandleGet(ctx, rc.Param("id"))
+	if err != nil {
+		rc.String(err.Code, err.DefMessage)
+		return
+	}
+	rc.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", tpl.Name+"."+tpl.Format))
+	rc.Data(http.StatusOK, "application/octet-stream", []byte(tpl.Content))
+}
+
+// AddTemplate 新增开通模板
+// @Summary 新增开通模板
+// @Description 新增 YAML/JSON 格式的租户开通模板, 声明部门、角色、数据权限、字典和文件夹
+// @Tags 系统租户
+// @ID AddTenantTemplate
+// @Accept json
+// @Produce json
+// @Param req body commands.CreateTenantTemplateCommand true "模板信息"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "code为400 参数输入错误"
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Failure 500 {object} base_info.Swagger500Resp "code为500 服务端内部错误"
+// @Router /v1/sys/tenant/template [post]
+func (c *SysTenantController) AddTemplate(ctx context.Context, params *commands.CreateTenantTemplateCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	if err := c.tplHandel.HandleCreate(ctx, *params); err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// UpdateTemplate 修改开通模板
+// @Summary 修改开通模板
+// @Description 修改租户开通模板, 已开通的租户不受影响
+// @Tags 系统租户
+// @ID UpdateTenantTemplate
+// @Accept json
+// @Produce json
+// @Param req body commands.UpdateTenantTemplateCommand true "模板信息"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "code为400 参数输入错误"
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Failure 500 {object} base_info.Swagger500Resp "code为500 服务端内部错误"
+// @Router /v1/sys/tenant/template [put]
+func (c *SysTenantController) UpdateTemplate(ctx context.Context, params *commands.UpdateTenantTemplateCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	if err := c.tplHandel.HandleUpdate(ctx, *params); err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// DeleteTemplate 删除开通模板
+// @Summary 删除开通模板
+// @Description 删除指定ID的租户开通模板
+// @Tags 系统租户
+// @ID DeleteTenantTemplate
+// @Accept json
+// @Produce json
+// @Param id path string true "模板ID"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "code为400 参数输入错误"
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Failure 500 {object} base_info.Swagger500Resp "code为500 服务端内部错误"
+// @Router /v1/sys/tenant/template/{id} [delete]
+func (c *SysTenantController) DeleteTemplate(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	if err := c.tplHandel.HandleDelete(ctx, params.Id); err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// CloneTemplate 从已有租户生成开通模板
+// @Summary 从已有租户生成开通模板
+// @Description 读取已有租户的部门、角色、数据权限、字典和文件夹生成开通模板
+// @Tags 系统租户
+// @ID CloneTenantTemplate
+// @Accept json
+// @Produce json
+// @Param req body commands.CloneTenantTemplateCommand true "克隆信息"
+// @Success 200 {object} base_info.Success{data=dto.TenantTemplateDto}
+// @Failure 400 {object} base_info.Swagger400Resp "code为400 参数输入错误"
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Failure 500 {object} base_info.Swagger500Resp "code为500 服务端内部错误"
+// @Router /v1/sys/tenant/template/clone [post]
+func (c *SysTenantController) CloneTemplate(ctx context.Context, params *commands.CloneTenantTemplateCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.tplHandel.HandleClone(ctx, *params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
@@ -2,16 +2,28 @@ package rest
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/commands"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/handlers"
 	"github.com/ares-cloud/ares-ddd-admin/internal/base/application/queries"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/casbin"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/jwt"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/oplog"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/sheet"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/hertz/pkg/protocol/http1/resp"
 
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/models"
 
+	"github.com/ares-cloud/ares-ddd-admin/internal/base/domain/model"
 	_ "github.com/ares-cloud/ares-ddd-admin/internal/base/infrastructure/dto"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver"
 	_ "github.com/ares-cloud/ares-ddd-admin/pkg/hserver/base_info"
@@ -20,22 +32,32 @@ import (
 )
 
 type SysUserController struct {
-	cmdHandel   *handlers.UserCommandHandler
-	queryHandel *handlers.UserQueryHandler
-	ef          *casbin.Enforcer
-	modeNma     string
+	cmdHandel        *handlers.UserCommandHandler
+	queryHandel      *handlers.UserQueryHandler
+	invitationHandel *handlers.UserInvitationHandler
+	recycleHandel    *handlers.RecycleBinHandler
+	grantHandel      *handlers.RoleGrantHandler
+	authHandel       *handlers.AuthHandler
+	ef               *casbin.Enforcer
+	modeNma          string
+	t                token.IToken
 }
 
-func NewSysUserController(cmdHandel *handlers.UserCommandHandler, queryHandel *handlers.UserQueryHandler, ef *casbin.Enforcer) *SysUserController {
+func NewSysUserController(cmdHandel *handlers.UserCommandHandler, queryHandel *handlers.UserQueryHandler, invitationHandel *handlers.UserInvitationHandler, recycleHandel *handlers.RecycleBinHandler, grantHandel *handlers.RoleGrantHandler, authHandel *handlers.AuthHandler, ef *casbin.Enforcer) *SysUserController {
 	return &SysUserController{
-		cmdHandel:   cmdHandel,
-		queryHandel: queryHandel,
-		ef:          ef,
-		modeNma:     "系统用户",
+		cmdHandel:        cmdHandel,
+		queryHandel:      queryHandel,
+		invitationHandel: invitationHandel,
+		recycleHandel:    recycleHandel,
+		grantHandel:      grantHandel,
+		authHandel:       authHandel,
+		ef:               ef,
+		modeNma:          "系统用户",
 	}
 }
 
 func (c *SysUserController) RegisterRouter(g *route.RouterGroup, t token.IToken) {
+	c.t = t
 	v1 := g.Group("/v1")
 	ur := v1.Group("/sys/user", jwt.Handler(t))
 	{
@@ -65,9 +87,67 @@ func (c *SysUserController) RegisterRouter(g *route.RouterGroup, t token.IToken)
 			Module:      c.modeNma,
 			Action:      "分配角色",
 		}), hserver.NewHandlerFu[commands.AssignUserRoleCommand](c.AssignRole))
+		ur.POST("/member", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "邀请加入租户",
+		}), hserver.NewHandlerFu[commands.AddTenantMemberCommand](c.AddTenantMember))
+		ur.POST("/import", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			Module: c.modeNma,
+			Action: "批量导入",
+		}), c.ImportUsers)
+		ur.GET("/export", casbin.Handler(c.ef), c.ExportUsers)
+		ur.POST("/invite", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "邀请用户",
+		}), hserver.NewHandlerFu[commands.InviteUserCommand](c.InviteUser))
+		ur.GET("/invitations", casbin.Handler(c.ef), hserver.NewHandlerFu[queries.ListInvitationsQuery](c.InvitationList))
+		ur.POST("/invitation/:id/resend", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			Module: c.modeNma,
+			Action: "重新发送邀请",
+		}), hserver.NewHandlerFu[models.StringIdReq](c.ResendInvitation))
+		ur.DELETE("/invitation/:id", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			Module: c.modeNma,
+			Action: "撤销邀请",
+		}), hserver.NewHandlerFu[models.StringIdReq](c.RevokeInvitation))
 		ur.GET("/:id", casbin.Handler(c.ef), hserver.NewHandlerFu[models.StringIdReq](c.GetDetails))
 		ur.GET("/info", hserver.NewNotParHandlerFu(c.GetUserInfo))
 		ur.GET("/menus", hserver.NewNotParHandlerFu(c.GetUserMenus))
+		ur.GET("/routes", c.GetUserRoutes)
+		ur.GET("/recycle", casbin.Handler(c.ef), hserver.NewHandlerFu[queries.ListRecycleBinQuery](c.RecycleList))
+		ur.POST("/recycle/:id/restore", casbin.Handler(c.ef), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "恢复",
+		}), hserver.NewHandlerFu[models.StringIdReq](c.Restore))
+		ur.GET("/:id/role-grants", casbin.Handler(c.ef), hserver.NewHandlerFu[models.StringIdReq](c.RoleGrantList))
+		ur.POST("/role-grant", casbin.Handler(c.ef), jwt.DenyImpersonation(), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "授予角色",
+		}), hserver.NewHandlerFu[commands.GrantRoleCommand](c.GrantRole))
+		ur.DELETE("/role-grant", casbin.Handler(c.ef), jwt.DenyImpersonation(), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "收回角色",
+		}), hserver.NewHandlerFu[commands.RevokeRoleGrantCommand](c.RevokeRole))
+		ur.GET("/role-requests", casbin.Handler(c.ef), hserver.NewHandlerFu[queries.ListRoleRequestsQuery](c.RoleRequestList))
+		ur.POST("/role-request/:id/approve", casbin.Handler(c.ef), jwt.DenyImpersonation(), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "通过角色申请",
+		}), hserver.NewHandlerFu[commands.ReviewRoleRequestCommand](c.ApproveRoleRequest))
+		ur.POST("/role-request/:id/reject", casbin.Handler(c.ef), jwt.DenyImpersonation(), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "拒绝角色申请",
+		}), hserver.NewHandlerFu[commands.ReviewRoleRequestCommand](c.RejectRoleRequest))
+		ur.POST("/:id/impersonate", casbin.Handler(c.ef), jwt.DenyImpersonation(), oplog.Record(oplog.LogOption{
+			IncludeBody: true,
+			Module:      c.modeNma,
+			Action:      "模拟登录",
+		}), hserver.NewHandlerFu[commands.ImpersonateUserCommand](c.Impersonate))
 	}
 }
 
@@ -221,6 +301,28 @@ func (c *SysUserController) AssignRole(ctx context.Context, params *commands.Ass
 	return result
 }
 
+// AddTenantMember 邀请其他租户的用户加入当前租户
+// @Summary 邀请加入租户
+// @Description 邀请其他租户的用户加入当前租户, 用户在个人中心接受后成为成员, 可在当前租户分配独立的角色和部门
+// @Tags 系统用户
+// @ID AddTenantMember
+// @Accept json
+// @Produce json
+// @Param req body commands.AddTenantMemberCommand true "成员信息"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "服务器内部错误"
+// @Router /v1/sys/user/member [post]
+func (c *SysUserController) AddTenantMember(ctx context.Context, params *commands.AddTenantMemberCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.cmdHandel.HandleAddTenantMember(ctx, *params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
 // GetUserInfo 获取用户信息
 // @Summary 获取用户信息
 // @Description 获取当前登录用户的详细信息，包括权限和菜单
@@ -272,3 +374,418 @@ func (c *SysUserController) GetUserMenus(ctx context.Context) *hserver.ResponseR
 	}
 	return result.WithData(data)
 }
+
+// ImportUsers 批量导入用户
+// @Summary 批量导入用户
+// @Description 上传 CSV/XLSX 文件批量创建用户, 首行为表头, 列依次为 username,password,name,nickname,phone,email,status,dept_code,role_codes;
+// @Description 多个角色编码以逗号分隔. 任意一行校验失败时不导入任何数据, dryRun 为 true 时只返回逐行校验结果
+// @Tags 系统用户
+// @ID ImportUsers
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV/XLSX 文件"
+// @Param dryRun formData bool false "仅校验不导入"
+// @Success 200 {object} base_info.Success{data=model.UserImportResult}
+// @Failure 400 {object} base_info.Swagger400Resp "code为400 参数输入错误"
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Failure 500 {object} base_info.Swagger500Resp "code为500 服务端内部错误"
+// @Router /v1/sys/user/import [post]
+func (c *SysUserController) ImportUsers(ctx context.Context, rc *app.RequestContext) {
+	result := hserver.DefaultResponseResult()
+	fileHeader, err := rc.FormFile("file")
+	if err != nil {
+		rc.JSON(http.StatusOK, result.WithError(herrors.NewBadReqError("获取文件失败")))
+		return
+	}
+	format := sheet.DetectFormat(fileHeader.Filename)
+	if format == "" {
+		rc.JSON(http.StatusOK, result.WithError(herrors.NewBadReqError("仅支持 csv/xlsx 文件")))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		rc.JSON(http.StatusOK, result.WithError(herrors.NewBadReqError("读取文件失败")))
+		return
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		rc.JSON(http.StatusOK, result.WithError(herrors.NewBadReqError("读取文件失败")))
+		return
+	}
+	dryRun, _ := strconv.ParseBool(string(rc.FormValue("dryRun")))
+
+	data, herr := c.cmdHandel.HandleImport(ctx, &commands.ImportUsersCommand{
+		Format:  format,
+		DryRun:  dryRun,
+		Content: content,
+	})
+	if herr != nil {
+		rc.JSON(http.StatusOK, result.WithError(herr))
+		return
+	}
+	rc.JSON(http.StatusOK, result.WithData(data))
+}
+
+// GetUserRoutes 获取用户前端路由
+// @Summary 获取用户前端路由
+// @Description 获取当前登录用户的前端路由树和按钮权限编码, 响应头返回 ETag, 请求携带 If-None-Match 且内容未变化时返回 304
+// @Tags 系统用户
+// @ID GetUserRoutes
+// @Accept json
+// @Produce json
+// @Param If-None-Match header string false "上次响应的 ETag"
+// @Success 200 {object} base_info.Success{data=dto.UserRoutesDto}
+// @Success 304 "内容未变化"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "服务器内部错误"
+// @Router /v1/sys/user/routes [get]
+func (c *SysUserController) GetUserRoutes(ctx context.Context, rc *app.RequestContext) {
+	result := hserver.DefaultResponseResult()
+	data, herr := c.queryHandel.HandleGetUserRoutes(ctx, queries.GetUserMenusQuery{
+		UserID: actx.GetUserId(ctx),
+	})
+	if herr != nil {
+		rc.JSON(http.StatusOK, result.WithError(herr))
+		return
+	}
+	etag := strconv.Quote(data.Version)
+	rc.Response.Header.Set("ETag", etag)
+	rc.Response.Header.Set("Cache-Control", "no-cache")
+	if string(rc.GetHeader("If-None-Match")) == etag {
+		rc.SetStatusCode(http.StatusNotModified)
+		return
+	}
+	rc.JSON(http.StatusOK, result.WithData(data))
+}
+
+// ExportUsers 导出用户
+// @Summary 导出用户
+// @Description 按用户列表的过滤条件流式导出用户及其部门、角色编码, 列布局与批量导入一致, 密码列为空
+// @Tags 系统用户
+// @ID ExportUsers
+// @Produce octet-stream
+// @Param req query queries.ExportUsersQuery true "属性说明请在对应model中查看"
+// @Failure 400 {object} base_info.Swagger400Resp "code为400 参数输入错误"
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Router /v1/sys/user/export [get]
+func (c *SysUserController) ExportUsers(ctx context.Context, rc *app.RequestContext) {
+	var params queries.ExportUsersQuery
+	if err := rc.BindAndValidate(&params); err != nil {
+		rc.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if params.Format == "" {
+		params.Format = sheet.FormatCSV
+	}
+	if params.Format != sheet.FormatCSV && params.Format != sheet.FormatXLSX {
+		rc.String(http.StatusBadRequest, "unsupported format: "+params.Format)
+		return
+	}
+
+	filename := fmt.Sprintf("users-%s.%s", time.Now().Format("20060102150405"), params.Format)
+	rc.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	rc.SetContentType(sheet.ContentType(params.Format))
+	// 分块传输, 边查询边输出
+	rc.Response.HijackWriter(resp.NewChunkedBodyWriter(&rc.Response, rc.GetWriter()))
+
+	w, err := sheet.NewWriter(rc, params.Format)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "create export writer error: %s", err)
+		return
+	}
+	if herr := c.queryHandel.HandleExport(ctx, &params, w); herr != nil {
+		hlog.CtxErrorf(ctx, "export users error: %s", herr)
+		return
+	}
+	if err := w.Close(); err != nil {
+		hlog.CtxErrorf(ctx, "export users error: %s", err)
+	}
+}
+
+// InviteUser 邀请用户
+// @Summary 邀请用户
+// @Description 创建待激活用户并预分配角色和部门, 返回邀请链接, 被邀请人接受邀请后启用
+// @Tags 系统用户
+// @ID InviteUser
+// @Accept json
+// @Produce json
+// @Param req body commands.InviteUserCommand true "邀请信息"
+// @Success 200 {object} base_info.Success{data=dto.InvitationDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "服务器内部错误"
+// @Router /v1/sys/user/invite [post]
+func (c *SysUserController) InviteUser(ctx context.Context, params *commands.InviteUserCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.invitationHandel.HandleInvite(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// InvitationList 待接受邀请列表
+// @Summary 待接受邀请列表
+// @Description 分页查询已邀请未激活的用户
+// @Tags 系统用户
+// @ID InvitationList
+// @Param req query queries.ListInvitationsQuery true "属性说明请在对应model中查看"
+// @Success 200 {object} base_info.Success{data=[]dto.UserDto}
+// @Failure 400 {object} base_info.Swagger400Resp "code为400 参数输入错误"
+// @Failure 401 {object} base_info.Swagger401Resp "code为401 token未带上"
+// @Failure 500 {object} base_info.Swagger500Resp "code为500 服务端内部错误"
+// @Router /v1/sys/user/invitations [get]
+func (c *SysUserController) InvitationList(ctx context.Context, params *queries.ListInvitationsQuery) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.invitationHandel.HandleList(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// ResendInvitation 重新发送邀请
+// @Summary 重新发送邀请
+// @Description 生成新的邀请链接并延长有效期, 之前的链接失效
+// @Tags 系统用户
+// @ID ResendInvitation
+// @Accept json
+// @Produce json
+// @Param id path string true "用户ID"
+// @Success 200 {object} base_info.Success{data=dto.InvitationDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "服务器内部错误"
+// @Router /v1/sys/user/invitation/{id}/resend [post]
+func (c *SysUserController) ResendInvitation(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.invitationHandel.HandleResend(ctx, params.Id)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// RevokeInvitation 撤销邀请
+// @Summary 撤销邀请
+// @Description 撤销邀请并删除待激活用户
+// @Tags 系统用户
+// @ID RevokeInvitation
+// @Accept json
+// @Produce json
+// @Param id path string true "用户ID"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "服务器内部错误"
+// @Router /v1/sys/user/invitation/{id} [delete]
+func (c *SysUserController) RevokeInvitation(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.invitationHandel.HandleRevoke(ctx, params.Id)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// RecycleList 获取用户回收站列表
+// @Summary 获取用户回收站列表
+// @Description 获取已删除且未超过保留期的用户
+// @Tags 系统用户
+// @ID RecycleUserList
+// @Accept json
+// @Produce json
+// @Param req query queries.ListRecycleBinQuery true "查询参数"
+// @Success 200 {object} base_info.Success{data=models.PageRes[dto.RecycleItemDto]}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/user/recycle [get]
+func (c *SysUserController) RecycleList(ctx context.Context, params *queries.ListRecycleBinQuery) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.recycleHandel.HandleList(ctx, model.RecycleEntityUser, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// Restore 从回收站恢复用户
+// @Summary 从回收站恢复用户
+// @Description 恢复用户及其仍然存在的关联关系
+// @Tags 系统用户
+// @ID RestoreUser
+// @Accept json
+// @Produce json
+// @Param id path string true "用户ID"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/user/recycle/{id}/restore [post]
+func (c *SysUserController) Restore(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.recycleHandel.HandleRestore(ctx, model.RecycleEntityUser, params.Id)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// RoleGrantList 用户的角色授予
+// @Summary 用户的角色授予
+// @Description 获取用户在当前租户的角色及有效期, 包括未生效的限时角色
+// @Tags 系统用户
+// @ID RoleGrantList
+// @Accept json
+// @Produce json
+// @Param id path string true "用户ID"
+// @Success 200 {object} base_info.Success{data=[]dto.RoleGrantDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/user/{id}/role-grants [get]
+func (c *SysUserController) RoleGrantList(ctx context.Context, params *models.StringIdReq) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.grantHandel.HandleGetUserGrants(ctx, params.Id)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// GrantRole 授予用户角色
+// @Summary 授予用户角色
+// @Description 在有效期内授予用户角色, 角色需要审批时创建角色申请并返回申请ID
+// @Tags 系统用户
+// @ID GrantRole
+// @Accept json
+// @Produce json
+// @Param req body commands.GrantRoleCommand true "授予信息"
+// @Success 200 {object} base_info.Success{data=string}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/user/role-grant [post]
+func (c *SysUserController) GrantRole(ctx context.Context, params *commands.GrantRoleCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	requestID, err := c.grantHandel.HandleGrant(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(requestID)
+}
+
+// RevokeRole 收回用户角色
+// @Summary 收回用户角色
+// @Description 收回用户的角色, 包括尚未生效的限时角色
+// @Tags 系统用户
+// @ID RevokeRole
+// @Accept json
+// @Produce json
+// @Param req body commands.RevokeRoleGrantCommand true "收回信息"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/user/role-grant [delete]
+func (c *SysUserController) RevokeRole(ctx context.Context, params *commands.RevokeRoleGrantCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.grantHandel.HandleRevoke(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// RoleRequestList 角色申请列表
+// @Summary 角色申请列表
+// @Description 分页查询需要审批的角色申请
+// @Tags 系统用户
+// @ID RoleRequestList
+// @Accept json
+// @Produce json
+// @Param req query queries.ListRoleRequestsQuery true "查询参数"
+// @Success 200 {object} base_info.Success{data=models.PageRes[dto.RoleRequestDto]}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/user/role-requests [get]
+func (c *SysUserController) RoleRequestList(ctx context.Context, params *queries.ListRoleRequestsQuery) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.grantHandel.HandleListRequests(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
+
+// ApproveRoleRequest 通过角色申请
+// @Summary 通过角色申请
+// @Description 审批通过并授予角色, 申请人和被授予角色的用户不能审批
+// @Tags 系统用户
+// @ID ApproveRoleRequest
+// @Accept json
+// @Produce json
+// @Param id path string true "申请ID"
+// @Param req body commands.ReviewRoleRequestCommand true "审批意见"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/user/role-request/{id}/approve [post]
+func (c *SysUserController) ApproveRoleRequest(ctx context.Context, params *commands.ReviewRoleRequestCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.grantHandel.HandleApprove(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// RejectRoleRequest 拒绝角色申请
+// @Summary 拒绝角色申请
+// @Description 拒绝角色申请, 申请人和被授予角色的用户不能审批
+// @Tags 系统用户
+// @ID RejectRoleRequest
+// @Accept json
+// @Produce json
+// @Param id path string true "申请ID"
+// @Param req body commands.ReviewRoleRequestCommand true "审批意见"
+// @Success 200 {object} base_info.Success
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/user/role-request/{id}/reject [post]
+func (c *SysUserController) RejectRoleRequest(ctx context.Context, params *commands.ReviewRoleRequestCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	err := c.grantHandel.HandleReject(ctx, params)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result
+}
+
+// Impersonate 模拟登录
+// @Summary 模拟登录
+// @Description 以指定用户身份签发模拟登录令牌, 期间禁止修改密码等敏感操作, 所有请求以当前管理员身份记录操作日志
+// @Tags 系统用户
+// @ID Impersonate
+// @Accept json
+// @Produce json
+// @Param id path string true "用户ID"
+// @Param req body commands.ImpersonateUserCommand true "模拟原因和时长"
+// @Success 200 {object} base_info.Success{data=dto.AuthDto}
+// @Failure 400 {object} base_info.Swagger400Resp "参数错误"
+// @Failure 401 {object} base_info.Swagger401Resp "未授权"
+// @Failure 500 {object} base_info.Swagger500Resp "内部错误"
+// @Router /v1/sys/user/{id}/impersonate [post]
+func (c *SysUserController) Impersonate(ctx context.Context, params *commands.ImpersonateUserCommand) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.authHandel.HandleImpersonate(ctx, *params, c.t)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
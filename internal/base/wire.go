@@ -21,6 +21,10 @@ var ProviderSet = wire.NewSet(
 	rest.NewLoginLogController,
 	rest.NewOperationLogController,
 	rest.NewDepartmentController,
+	rest.NewPositionController,
 	rest.NewDataPermissionController,
+	rest.NewScimController,
+	rest.NewProfileController,
+	rest.NewAccessReviewController,
 	NewBaseServer,
 )
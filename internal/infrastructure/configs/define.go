@@ -1,5 +1,7 @@
 package configs
 
+import "time"
+
 var (
 	Mode EnvMode // 开发环境
 )
@@ -21,6 +23,13 @@ type Bootstrap struct {
 	Data       *Data          `mapstructure:"data"`
 	ConfPath   *string        `mapstructure:"conf_path"`
 	Storage    *StorageConfig `mapstructure:"storage"` // 添加存储配置
+	Tenant     *Tenant        `mapstructure:"tenant"`  // 租户解析配置
+	Invitation *Invitation    `mapstructure:"invitation"`
+	RecycleBin *RecycleBin    `mapstructure:"recycle_bin"` // 用户、角色、部门回收站配置
+	RoleGrant  *RoleGrant     `mapstructure:"role_grant"`  // 限时角色配置
+	LogChain   *LogChain      `mapstructure:"log_chain"`   // 日志哈希链检查点配置
+	OpLog      *OpLog         `mapstructure:"oplog"`       // 操作日志批量写入配置
+	VerifyCode *VerifyCode    `mapstructure:"verify_code"` // 邮箱/手机验证码发送配置
 }
 
 type Server struct {
@@ -54,11 +63,20 @@ type Data struct {
 
 // DataBase 数据库
 type DataBase struct {
-	Driver       string `mapstructure:"driver"`
-	Source       string `mapstructure:"source"`
-	MaxIdleConns int32  `mapstructure:"max_idle_conns"`
-	MaxOpenConns int32  `mapstructure:"max_open_conns"`
-	LogLevel     int    `mapstructure:"log_level"`
+	Driver       string      `mapstructure:"driver"`
+	Source       string      `mapstructure:"source"`
+	MaxIdleConns int32       `mapstructure:"max_idle_conns"`
+	MaxOpenConns int32       `mapstructure:"max_open_conns"`
+	LogLevel     int         `mapstructure:"log_level"`
+	TenantPool   *TenantPool `mapstructure:"tenant_pool"`
+}
+
+// TenantPool 租户独立Schema/数据库连接池
+type TenantPool struct {
+	MaxIdleConns int32 `mapstructure:"max_idle_conns"` // 每个租户最大空闲连接数
+	MaxOpenConns int32 `mapstructure:"max_open_conns"` // 每个租户最大连接数
+	MaxPools     int32 `mapstructure:"max_pools"`      // 最多同时保持的租户连接池数量
+	MetaTTL      int64 `mapstructure:"meta_ttl"`       // 租户隔离配置缓存时间(秒)
 }
 
 // Redis 数据库
@@ -70,8 +88,210 @@ type Redis struct {
 	ReadTimeout  int64  `mapstructure:"read_timeout"`
 	WriteTimeout int64  `mapstructure:"write_timeout"`
 }
+
+// Tenant 租户解析配置
+type Tenant struct {
+	Header     string `mapstructure:"header"`      // 租户编码请求头,默认 X-Tenant-Code
+	BaseDomain string `mapstructure:"base_domain"` // 平台主域名,<code>.base_domain 解析为租户
+	Required   bool   `mapstructure:"required"`    // 是否必须解析出租户
+	// 解析缓存
+	ResolveCacheTTL  time.Duration `mapstructure:"resolve_cache_ttl"`  // 租户解析结果缓存时间, 默认30s
+	ResolveCacheSize int           `mapstructure:"resolve_cache_size"` // 最多缓存的解析结果数, 默认4096
+	// 租户下线
+	ArchiveDir   string `mapstructure:"archive_dir"`   // 租户数据导出归档目录
+	ReportSecret string `mapstructure:"report_secret"` // 导出/清除报告签名密钥, 为空时使用 jwt.signing_key
+	// 访问策略
+	PolicyCacheSize int `mapstructure:"policy_cache_size"` // 最多同时加载访问策略的租户数, 默认256, 超出时淘汰最久未访问的租户
+}
+
+// GetPolicyCacheSize 最多同时加载访问策略的租户数
+func (t *Tenant) GetPolicyCacheSize() int {
+	if t == nil {
+		return 0
+	}
+	return t.PolicyCacheSize
+}
+
+// GetResolveCacheTTL 租户解析结果缓存时间, 为0时使用默认值
+func (t *Tenant) GetResolveCacheTTL() time.Duration {
+	if t == nil {
+		return 0
+	}
+	return t.ResolveCacheTTL
+}
+
+// GetResolveCacheSize 最多缓存的解析结果数, 为0时使用默认值
+func (t *Tenant) GetResolveCacheSize() int {
+	if t == nil {
+		return 0
+	}
+	return t.ResolveCacheSize
+}
+
+// Invitation 用户邀请配置, 未配置发送服务时不投递邀请, 由管理员通过接口返回的链接转交
+type Invitation struct {
+	SigningKey  string        `mapstructure:"signing_key"`  // 邀请链接签名密钥, 为空时使用 jwt.signing_key
+	ExpireHours int64         `mapstructure:"expire_hours"` // 邀请有效期(小时), 默认72
+	AcceptURL   string        `mapstructure:"accept_url"`   // 接受邀请页面地址, 邀请令牌以 token 参数附加
+	WebhookURL  string        `mapstructure:"webhook_url"`  // 邀请发送服务地址, 以 JSON POST {email, link, expire_at}
+	Timeout     time.Duration `mapstructure:"timeout"`      // 请求超时, 默认5s
+}
+
+// GetWebhookURL 邀请发送服务地址
+func (i *Invitation) GetWebhookURL() string {
+	if i == nil {
+		return ""
+	}
+	return i.WebhookURL
+}
+
+// GetTimeout 请求超时
+func (i *Invitation) GetTimeout() time.Duration {
+	if i == nil || i.Timeout <= 0 {
+		return 5 * time.Second
+	}
+	return i.Timeout
+}
+
+// RecycleBin 回收站配置
+type RecycleBin struct {
+	RetentionDays int           `mapstructure:"retention_days"` // 保留天数, 默认30
+	Interval      time.Duration `mapstructure:"interval"`       // 清理间隔, 默认1h
+}
+
+// GetRetentionDays 保留天数
+func (r *RecycleBin) GetRetentionDays() int {
+	if r == nil || r.RetentionDays <= 0 {
+		return 30
+	}
+	return r.RetentionDays
+}
+
+// GetInterval 清理间隔
+func (r *RecycleBin) GetInterval() time.Duration {
+	if r == nil || r.Interval <= 0 {
+		return time.Hour
+	}
+	return r.Interval
+}
+
+// RoleGrant 限时角色配置
+type RoleGrant struct {
+	ExpireInterval time.Duration `mapstructure:"expire_interval"` // 过期角色检查间隔, 默认1m
+}
+
+// GetExpireInterval 过期角色检查间隔
+func (r *RoleGrant) GetExpireInterval() time.Duration {
+	if r == nil || r.ExpireInterval <= 0 {
+		return time.Minute
+	}
+	return r.ExpireInterval
+}
+
+// LogChain 操作日志和登录日志哈希链的签名检查点配置
+type LogChain struct {
+	SigningKey         string        `mapstructure:"signing_key"`         // 检查点签名私钥, base64 编码的 ed25519 种子, 为空时不导出检查点
+	CheckpointDir      string        `mapstructure:"checkpoint_dir"`      // 检查点导出目录, 默认 ./checkpoints
+	CheckpointInterval time.Duration `mapstructure:"checkpoint_interval"` // 检查点导出间隔, 默认24h
+}
+
+// GetCheckpointDir 检查点导出目录
+func (l *LogChain) GetCheckpointDir() string {
+	if l == nil || l.CheckpointDir == "" {
+		return "./checkpoints"
+	}
+	return l.CheckpointDir
+}
+
+// GetCheckpointInterval 检查点导出间隔
+func (l *LogChain) GetCheckpointInterval() time.Duration {
+	if l == nil || l.CheckpointInterval <= 0 {
+		return 24 * time.Hour
+	}
+	return l.CheckpointInterval
+}
+
+// GetSigningKey 检查点签名私钥
+func (l *LogChain) GetSigningKey() string {
+	if l == nil {
+		return ""
+	}
+	return l.SigningKey
+}
+
+// OpLog 操作日志批量写入配置
+type OpLog struct {
+	QueueSize     int           `mapstructure:"queue_size"`     // 队列长度, 默认10000
+	BatchSize     int           `mapstructure:"batch_size"`     // 每批写入条数, 默认100
+	FlushInterval time.Duration `mapstructure:"flush_interval"` // 未攒满一批时的最长等待时间, 默认1s
+	FallbackDir   string        `mapstructure:"fallback_dir"`   // 队列已满或写库失败时的本地文件目录, 为空时丢弃
+	CloseTimeout  time.Duration `mapstructure:"close_timeout"`  // 停机时等待写完队列的最长时间, 默认10s
+}
+
+// GetQueueSize 队列长度
+func (o *OpLog) GetQueueSize() int {
+	if o == nil || o.QueueSize <= 0 {
+		return 10000
+	}
+	return o.QueueSize
+}
+
+// GetBatchSize 每批写入条数
+func (o *OpLog) GetBatchSize() int {
+	if o == nil || o.BatchSize <= 0 {
+		return 100
+	}
+	return o.BatchSize
+}
+
+// GetFlushInterval 最长等待时间
+func (o *OpLog) GetFlushInterval() time.Duration {
+	if o == nil || o.FlushInterval <= 0 {
+		return time.Second
+	}
+	return o.FlushInterval
+}
+
+// GetCloseTimeout 停机时等待写完队列的最长时间
+func (o *OpLog) GetCloseTimeout() time.Duration {
+	if o == nil || o.CloseTimeout <= 0 {
+		return 10 * time.Second
+	}
+	return o.CloseTimeout
+}
+
+// GetFallbackDir 本地文件目录
+func (o *OpLog) GetFallbackDir() string {
+	if o == nil {
+		return ""
+	}
+	return o.FallbackDir
+}
+
 type SuperAdmin struct {
 	Nickname string `mapstructure:"nickname"`
 	Phone    string `mapstructure:"phone"`
 	Password string `mapstructure:"password"`
 }
+
+// VerifyCode 邮箱/手机验证码发送配置, 未配置发送服务时开发环境写入调试日志, 其他环境拒绝发送
+type VerifyCode struct {
+	WebhookURL string        `mapstructure:"webhook_url"` // 验证码发送服务地址, 以 JSON POST {type, target, code}
+	Timeout    time.Duration `mapstructure:"timeout"`     // 请求超时, 默认5s
+}
+
+// GetWebhookURL 验证码发送服务地址
+func (v *VerifyCode) GetWebhookURL() string {
+	if v == nil {
+		return ""
+	}
+	return v.WebhookURL
+}
+
+// GetTimeout 请求超时
+func (v *VerifyCode) GetTimeout() time.Duration {
+	if v == nil || v.Timeout <= 0 {
+		return 5 * time.Second
+	}
+	return v.Timeout
+}
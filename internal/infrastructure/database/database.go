@@ -6,6 +6,7 @@ import (
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/database/snowflake_id"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/h_redis"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/tenantdata"
 	"github.com/dtm-labs/rockscache"
 	"github.com/google/wire"
 	"time"
@@ -18,6 +19,7 @@ var ProviderSet = wire.NewSet(
 	NewRc,
 	cache.NewCache,
 	cache.NewCacheDecorator,
+	tenantdata.NewRegistry,
 )
 
 func NewDataBase(ig snowflake_id.IIdGenerate, conf *configs.Data) (database.IDataBase, func(), error) {
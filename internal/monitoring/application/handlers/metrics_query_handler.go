@@ -55,3 +55,20 @@ func (h *MetricsQueryHandler) HandleGetRuntimeMetrics(ctx context.Context) (*dto
 		GCCPUFraction: metrics.GCCPUFraction,
 	}, nil
 }
+
+// HandleGetOplogMetrics 处理获取操作日志写入指标
+func (h *MetricsQueryHandler) HandleGetOplogMetrics(ctx context.Context) (*dto.OplogMetricsDto, herrors.Herr) {
+	metrics := h.service.GetOplogMetrics()
+	if metrics == nil {
+		return &dto.OplogMetricsDto{}, nil
+	}
+
+	return &dto.OplogMetricsDto{
+		QueueDepth:    metrics.QueueDepth,
+		QueueCapacity: metrics.QueueCapacity,
+		Written:       metrics.Written,
+		Dropped:       metrics.Dropped,
+		Fallback:      metrics.Fallback,
+		Failed:        metrics.Failed,
+	}, nil
+}
@@ -60,3 +60,13 @@ func (m *RuntimeMetrics) SetGCStats(pauseNs, lastGC uint64, numGC uint32, cpuFra
 	m.NumGC = numGC
 	m.GCCPUFraction = cpuFraction
 }
+
+// OplogMetrics 操作日志写入指标
+type OplogMetrics struct {
+	QueueDepth    int   // 当前队列中的日志数
+	QueueCapacity int   // 队列长度
+	Written       int64 // 已写入数据库的日志数
+	Dropped       int64 // 丢弃的日志数
+	Fallback      int64 // 写入本地文件的日志数
+	Failed        int64 // 写入失败的批次数
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ares-cloud/ares-ddd-admin/internal/monitoring/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/oplog"
 	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/shirou/gopsutil/v3/disk"
 	"github.com/shirou/gopsutil/v3/mem"
@@ -67,3 +68,23 @@ func (s *MetricsService) GetRuntimeMetrics() *model.RuntimeMetrics {
 		GCCPUFraction: m.GCCPUFraction,
 	}
 }
+
+// GetOplogMetrics 获取操作日志写入指标, 写入器未初始化或不提供指标时返回 nil
+func (s *MetricsService) GetOplogMetrics() *model.OplogMetrics {
+	logger := oplog.GetLogger()
+	if logger == nil {
+		return nil
+	}
+	stats, ok := logger.Stats()
+	if !ok {
+		return nil
+	}
+	return &model.OplogMetrics{
+		QueueDepth:    stats.QueueDepth,
+		QueueCapacity: stats.QueueCapacity,
+		Written:       stats.Written,
+		Dropped:       stats.Dropped,
+		Fallback:      stats.Fallback,
+		Failed:        stats.Failed,
+	}
+}
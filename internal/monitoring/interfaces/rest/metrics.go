@@ -27,6 +27,7 @@ func (c *MetricsController) RegisterRouter(g *route.RouterGroup, t token.IToken)
 	{
 		metrics.GET("/system", hserver.NewHandlerFu[queries.GetSystemMetricsQuery](c.GetSystemMetrics))
 		metrics.GET("/runtime", hserver.NewNotParHandlerFu(c.GetRuntimeMetrics))
+		metrics.GET("/oplog", hserver.NewNotParHandlerFu(c.GetOplogMetrics))
 	}
 }
 
@@ -63,3 +64,20 @@ func (c *MetricsController) GetRuntimeMetrics(ctx context.Context) *hserver.Resp
 	}
 	return result.WithData(data)
 }
+
+// GetOplogMetrics 获取操作日志写入指标
+// @Summary 获取操作日志写入指标
+// @Description 获取操作日志队列深度、已写入、丢弃和写入本地文件的数量
+// @Tags 监控指标
+// @Accept json
+// @Produce json
+// @Success 200 {object} base_info.Success{data=dto.OplogMetricsDto}
+// @Router /v1/metrics/oplog [get]
+func (c *MetricsController) GetOplogMetrics(ctx context.Context) *hserver.ResponseResult {
+	result := hserver.DefaultResponseResult()
+	data, err := c.queryHandler.HandleGetOplogMetrics(ctx)
+	if err != nil {
+		return result.WithError(err)
+	}
+	return result.WithData(data)
+}
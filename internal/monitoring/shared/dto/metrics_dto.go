@@ -56,3 +56,13 @@ type APIMetricsDto struct {
 	ResponseTime float64   `json:"response_time"` // 平均响应时间
 	CreatedAt    time.Time `json:"created_at"`    // 创建时间
 }
+
+// OplogMetricsDto 操作日志写入指标DTO
+type OplogMetricsDto struct {
+	QueueDepth    int   `json:"queue_depth"`    // 当前队列中的日志数
+	QueueCapacity int   `json:"queue_capacity"` // 队列长度
+	Written       int64 `json:"written"`        // 已写入数据库的日志数
+	Dropped       int64 `json:"dropped"`        // 丢弃的日志数
+	Fallback      int64 `json:"fallback"`       // 写入本地文件的日志数
+	Failed        int64 `json:"failed"`         // 写入失败的批次数
+}
@@ -15,6 +15,9 @@ import (
 	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/herrors"
 )
 
+// DefaultFolderName 默认文件夹名称
+const DefaultFolderName = "默认文件夹"
+
 type StorageService struct {
 	repo    repository.IStorageRepository
 	storage storage.StorageFactory
@@ -205,7 +208,7 @@ func (s *StorageService) MoveFile(ctx context.Context, fileID string, targetFold
 func (s *StorageService) CreateDefaultFolder(ctx context.Context, tenantID, createdBy string) (*model.Folder, herrors.Herr) {
 	// 1. 构建默认文件夹对象
 	folder := &model.Folder{
-		Name:      "默认文件夹",
+		Name:      DefaultFolderName,
 		ParentID:  "0",
 		TenantID:  tenantID,
 		CreatedBy: createdBy,
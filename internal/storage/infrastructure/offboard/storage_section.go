@@ -0,0 +1,128 @@
+package offboard
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	domainstorage "github.com/ares-cloud/ares-ddd-admin/internal/storage/domain/storage"
+	"github.com/ares-cloud/ares-ddd-admin/internal/storage/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/storage/infrastructure/persistence/mapper"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/tenantdata"
+)
+
+const fileShareWhere = "file_id IN (SELECT id FROM files WHERE tenant_id = ?)"
+
+// StorageSection 存储模块的租户数据分区: 文件夹、文件、分享记录及存储中的文件对象
+type StorageSection struct {
+	db      database.IDataBase
+	factory domainstorage.StorageFactory
+	mapper  *mapper.StorageMapper
+}
+
+func NewStorageSection(db database.IDataBase, factory domainstorage.StorageFactory) *StorageSection {
+	return &StorageSection{
+		db:      db,
+		factory: factory,
+		mapper:  &mapper.StorageMapper{},
+	}
+}
+
+func (s *StorageSection) Name() string {
+	return "storage"
+}
+
+// Export 导出文件元数据, 文件内容写入 objects 目录
+func (s *StorageSection) Export(ctx context.Context, tenantID string, w *tenantdata.ArchiveWriter) (int64, error) {
+	ctx = actx.BuildTenantCtx(ctx, tenantID)
+	db := s.db.DB(ctx)
+	tables := []tenantdata.Table{
+		{Model: &entity.Folder{}, Where: "tenant_id = ?", Args: []interface{}{tenantID}},
+		{Model: &entity.File{}, Where: "tenant_id = ?", Args: []interface{}{tenantID}},
+		{Model: &entity.FileShare{}, Where: fileShareWhere, Args: []interface{}{tenantID}},
+	}
+	var total int64
+	for _, t := range tables {
+		n, err := tenantdata.ExportTable(ctx, db, w, t)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+
+	files, err := s.files(ctx, tenantID)
+	if err != nil {
+		return total, err
+	}
+	for _, f := range files {
+		if err := s.exportObject(ctx, w, f); err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func (s *StorageSection) exportObject(ctx context.Context, w *tenantdata.ArchiveWriter, f *entity.File) error {
+	storage, err := s.factory.GetStorage(s.mapper.ToFileDomain(f).StorageType)
+	if err != nil {
+		return err
+	}
+	reader, err := storage.Download(ctx, s.mapper.ToFileDomain(f))
+	if err != nil {
+		return fmt.Errorf("download file %s failed: %w", f.ID, err)
+	}
+	defer reader.Close()
+	out, err := w.Create(path.Join("objects", f.ID+"_"+path.Base(f.Name)))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, reader)
+	return err
+}
+
+// Purge 删除存储中的文件对象及文件元数据
+func (s *StorageSection) Purge(ctx context.Context, tenantID string) (int64, error) {
+	ctx = actx.BuildTenantCtx(ctx, tenantID)
+	files, err := s.files(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	for _, f := range files {
+		file := s.mapper.ToFileDomain(f)
+		storage, err := s.factory.GetStorage(file.StorageType)
+		if err != nil {
+			return 0, err
+		}
+		if err := storage.Delete(ctx, file); err != nil {
+			return 0, fmt.Errorf("delete file %s from storage failed: %w", f.ID, err)
+		}
+	}
+
+	db := s.db.DB(ctx)
+	var total int64
+	n, err := tenantdata.PurgeTable(ctx, db, &entity.FileShare{}, fileShareWhere, tenantID)
+	if err != nil {
+		return total, err
+	}
+	total += n
+	n, err = tenantdata.PurgeTable(ctx, db, &entity.File{}, "tenant_id = ?", tenantID)
+	if err != nil {
+		return total, err
+	}
+	total += n
+	n, err = tenantdata.PurgeTable(ctx, db, &entity.Folder{}, "tenant_id = ?", tenantID)
+	if err != nil {
+		return total, err
+	}
+	return total + n, nil
+}
+
+// files 获取租户的全部文件, 包括回收站中的文件
+func (s *StorageSection) files(ctx context.Context, tenantID string) ([]*entity.File, error) {
+	var files []*entity.File
+	err := s.db.DB(ctx).Where("tenant_id = ?", tenantID).Find(&files).Error
+	return files, err
+}
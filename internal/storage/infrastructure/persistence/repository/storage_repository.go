@@ -47,7 +47,7 @@ type storageRepository struct {
 
 func NewStorageRepository(db database.IDataBase, repo IStorageRepos) repository.IStorageRepository {
 	// 同步表
-	if err := db.DB(context.Background()).AutoMigrate(&entity.Folder{}, &entity.File{}, &entity.FileShare{}); err != nil {
+	if err := db.AutoMigrate(&entity.Folder{}, &entity.File{}, &entity.FileShare{}); err != nil {
 		hlog.Fatalf("sync sys storage tables to db error: %v", err)
 	}
 	return &storageRepository{
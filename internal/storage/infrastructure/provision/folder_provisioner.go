@@ -0,0 +1,106 @@
+// Package provision 按开通模板初始化租户的存储文件夹, 以及从已有租户生成模板
+package provision
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/internal/storage/domain/model"
+	"github.com/ares-cloud/ares-ddd-admin/internal/storage/domain/service"
+	"github.com/ares-cloud/ares-ddd-admin/internal/storage/infrastructure/persistence/entity"
+	"github.com/ares-cloud/ares-ddd-admin/internal/storage/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/tenantdata"
+)
+
+// FolderProvisioner 存储模块的开通处理器
+type FolderProvisioner struct {
+	service *service.StorageService
+	repo    repository.IStorageRepos
+}
+
+func NewFolderProvisioner(service *service.StorageService, repo repository.IStorageRepos) *FolderProvisioner {
+	return &FolderProvisioner{
+		service: service,
+		repo:    repo,
+	}
+}
+
+func (p *FolderProvisioner) Name() string {
+	return "storage"
+}
+
+// Provision 创建默认文件夹及模板声明的文件夹
+func (p *FolderProvisioner) Provision(ctx context.Context, tenantID, operator string, tpl *tenantdata.Template) error {
+	if tpl.DefaultFolder {
+		if _, herr := p.service.CreateDefaultFolder(ctx, tenantID, operator); herr != nil {
+			return fmt.Errorf("create default folder: %w", herr)
+		}
+	}
+	return p.createFolders(ctx, tenantID, operator, "0", tpl.Folders)
+}
+
+func (p *FolderProvisioner) createFolders(ctx context.Context, tenantID, operator, parentID string, specs []tenantdata.FolderSpec) error {
+	for _, spec := range specs {
+		folder := &model.Folder{
+			Name:      spec.Name,
+			ParentID:  parentID,
+			TenantID:  tenantID,
+			CreatedBy: operator,
+			CreatedAt: time.Now().Unix(),
+		}
+		if herr := p.service.CreateFolder(ctx, folder); herr != nil {
+			return fmt.Errorf("create folder %s: %w", spec.Name, herr)
+		}
+		if err := p.createFolders(ctx, tenantID, operator, folder.ID, spec.Children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Capture 读取租户的文件夹结构, 根目录下的默认文件夹记为 DefaultFolder
+func (p *FolderProvisioner) Capture(ctx context.Context, tenantID string, tpl *tenantdata.Template) error {
+	folders, _, err := p.repo.ListFolders(ctx, "", nil)
+	if err != nil {
+		return err
+	}
+	children := make(map[string][]*entity.Folder)
+	for _, f := range folders {
+		if f.TenantID != tenantID {
+			continue
+		}
+		parentID := f.ParentID
+		if parentID == "" {
+			parentID = "0"
+		}
+		children[parentID] = append(children[parentID], f)
+	}
+	var roots []*entity.Folder
+	for _, f := range children["0"] {
+		if f.Name == service.DefaultFolderName {
+			tpl.DefaultFolder = true
+			continue
+		}
+		roots = append(roots, f)
+	}
+	tpl.Folders = buildFolderSpecs(roots, children)
+	return nil
+}
+
+func buildFolderSpecs(folders []*entity.Folder, children map[string][]*entity.Folder) []tenantdata.FolderSpec {
+	specs := make([]tenantdata.FolderSpec, 0, len(folders))
+	seen := make(map[string]struct{}, len(folders))
+	for _, f := range folders {
+		// 同级同名文件夹在模板中只保留一个
+		if _, ok := seen[f.Name]; ok {
+			continue
+		}
+		seen[f.Name] = struct{}{}
+		specs = append(specs, tenantdata.FolderSpec{
+			Name:     f.Name,
+			Children: buildFolderSpecs(children[f.ID], children),
+		})
+	}
+	return specs
+}
@@ -11,8 +11,11 @@ import (
 	"github.com/ares-cloud/ares-ddd-admin/internal/storage/application/handlers"
 	"github.com/ares-cloud/ares-ddd-admin/internal/storage/domain/service"
 	"github.com/ares-cloud/ares-ddd-admin/internal/storage/infrastructure/cleaner"
+	"github.com/ares-cloud/ares-ddd-admin/internal/storage/infrastructure/offboard"
 	"github.com/ares-cloud/ares-ddd-admin/internal/storage/infrastructure/persistence/repository"
+	"github.com/ares-cloud/ares-ddd-admin/internal/storage/infrastructure/provision"
 	"github.com/ares-cloud/ares-ddd-admin/internal/storage/interfaces/rest"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/tenantdata"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/token"
 )
 
@@ -27,6 +30,8 @@ var ProviderSet = wire.NewSet(
 	rest.NewStorageController,
 	cleaner.NewRecycleCleaner,
 	storage.NewStorageFactory,
+	offboard.NewStorageSection,
+	provision.NewFolderProvisioner,
 	NewServer,
 )
 
@@ -37,11 +42,21 @@ type Server struct {
 }
 
 // NewServer creates a new storage server.
-func NewServer(controller *rest.StorageController, cleaner *cleaner.RecycleCleaner) (*Server, func(), error) {
+func NewServer(
+	controller *rest.StorageController,
+	cleaner *cleaner.RecycleCleaner,
+	registry *tenantdata.Registry,
+	section *offboard.StorageSection,
+	provisioner *provision.FolderProvisioner,
+) (*Server, func(), error) {
 	s := &Server{
 		controller: controller,
 		cleaner:    cleaner,
 	}
+	// 注册租户数据分区, 租户下线时导出/清除存储数据
+	registry.Register(section)
+	// 注册开通处理器, 租户开通时按模板创建文件夹
+	registry.RegisterProvisioner(provisioner)
 	cleanup := func() {
 		hlog.Info("closing the data resources")
 		s.cleaner.Stop()
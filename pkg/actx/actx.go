@@ -24,6 +24,9 @@ const (
 	UserAgent      = "UserAgent"
 	IgnoreTenantId = "ignore_tenant_Id"
 	KeyDeptId      = "deptId"
+	KeyActorId     = "actorId"
+	KeyActorName   = "actorName"
+	KeyRequestId   = "requestId"
 )
 
 func WithUserId(ctx context.Context, userId string) context.Context {
@@ -51,15 +54,15 @@ func WithToken(ctx context.Context, token string) context.Context {
 }
 
 func GetDeptId(ctx context.Context) string {
-	return fmt.Sprintf("%v", ctx.Value(KeyToken))
+	return fmt.Sprintf("%v", ctx.Value(KeyDeptId))
 }
 
-func WithDeptId(ctx context.Context, token string) context.Context {
-	return context.WithValue(ctx, KeyDeptId, token)
+func WithDeptId(ctx context.Context, deptId string) context.Context {
+	return context.WithValue(ctx, KeyDeptId, deptId)
 }
 
 func GetToken(ctx context.Context) string {
-	return fmt.Sprintf("%v", ctx.Value(KeyDeptId))
+	return fmt.Sprintf("%v", ctx.Value(KeyToken))
 }
 func WithRole(ctx context.Context, role []string) context.Context {
 	return context.WithValue(ctx, KeyRole, strings.Join(role, ","))
@@ -130,6 +133,44 @@ func BuildIgnoreTenantCtx(ctx context.Context) context.Context {
 	return WithIgnoreTenantId(ctx)
 }
 
+// BuildTenantCtx 构建指定租户的ctx, 同时取消忽略租户
+func BuildTenantCtx(ctx context.Context, tenantId string) context.Context {
+	ctx = context.WithValue(ctx, IgnoreTenantId, "")
+	return WithTenantId(ctx, tenantId)
+}
+
+func WithActor(ctx context.Context, actorId, actorName string) context.Context {
+	ctx = context.WithValue(ctx, KeyActorId, actorId)
+	return context.WithValue(ctx, KeyActorName, actorName)
+}
+
+// GetActorId 模拟登录时实际操作的管理员ID, 非模拟登录时为空
+func GetActorId(ctx context.Context) string {
+	v, _ := ctx.Value(KeyActorId).(string)
+	return v
+}
+
+// GetActorName 模拟登录时实际操作的管理员账号
+func GetActorName(ctx context.Context) string {
+	v, _ := ctx.Value(KeyActorName).(string)
+	return v
+}
+
+// IsImpersonating 当前请求是否来自模拟登录
+func IsImpersonating(ctx context.Context) bool {
+	return GetActorId(ctx) != ""
+}
+
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, KeyRequestId, requestId)
+}
+
+// GetRequestId 请求ID, 由操作日志中间件生成, 用于关联操作日志和数据变更记录
+func GetRequestId(ctx context.Context) string {
+	v, _ := ctx.Value(KeyRequestId).(string)
+	return v
+}
+
 func Store(ctx context.Context, accessToken token.AccessToken) context.Context {
 	ctx = WithUserId(ctx, accessToken.UserId)
 	ctx = WithPlatform(ctx, accessToken.Platform)
@@ -137,6 +178,8 @@ func Store(ctx context.Context, accessToken token.AccessToken) context.Context {
 	ctx = WithRole(ctx, accessToken.Roles)
 	ctx = WithTenantId(ctx, accessToken.TenantId)
 	ctx = WithUsername(ctx, accessToken.UserName)
+	ctx = WithDeptId(ctx, accessToken.DeptId)
+	ctx = WithActor(ctx, accessToken.ActorId, accessToken.ActorName)
 	return ctx
 }
 func IsSuperAdmin(ctx context.Context) bool {
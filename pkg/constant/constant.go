@@ -12,6 +12,12 @@ const (
 	ReasonSuccess         = "Success"
 	PleaseDoNotResubmit   = "PleaseDoNotResubmit"
 	ReasonNoAccess        = "noAccess"
+	// 租户解析
+	ReasonTenantNotResolved = "tenantNotResolved"
+	ReasonTenantLocked      = "tenantLocked"
+	ReasonTenantExpired     = "tenantExpired"
+	// 模拟登录
+	ReasonImpersonationForbidden = "impersonationForbidden"
 )
 const (
 	RespCode      = "code"
@@ -276,7 +276,7 @@ func (r *BaseRepo[T, I]) Find(ctx context.Context, qb *db_query.QueryBuilder) ([
 	}
 
 	if limit, values := qb.BuildLimit(); limit != "" {
-		db = db.Offset(values[0]).Limit(values[1])
+		db = db.Limit(values[0]).Offset(values[1])
 	}
 	return res, db.Find(&res).Error
 }
@@ -18,8 +18,10 @@ type contextTxKey struct{}
 
 // Data ， 数据相关类
 type Data struct {
-	db *gorm.DB
-	ig snowflake_id.IIdGenerate
+	db     *gorm.DB
+	ig     snowflake_id.IIdGenerate
+	router *TenantRouter
+	audit  *plugin.AuditPlugin
 }
 
 // NewData ， 创建 data
@@ -53,6 +55,16 @@ func NewData(ig snowflake_id.IIdGenerate, cof *configs.Data) (IDataBase, func(),
 	}
 	//迁移基础表
 	err = db.Use(plugin.NewTenantPlugin())
+	// 租户路由, 独立Schema/数据库的租户使用各自的连接池
+	router := NewTenantRouter(cof.DataBase.Driver, cof.DataBase.Source, tenantPoolConfig(cof.DataBase.TenantPool))
+	if err = db.Use(router); err != nil {
+		hlog.Fatalf("failed register tenant router: %v", err)
+	}
+	// 数据变更记录, 写入器由审计日志仓储设置
+	audit := plugin.NewAuditPlugin()
+	if err = db.Use(audit); err != nil {
+		hlog.Fatalf("failed register audit plugin: %v", err)
+	}
 	// 获取底层的 SQL 连接池
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -67,8 +79,21 @@ func NewData(ig snowflake_id.IIdGenerate, cof *configs.Data) (IDataBase, func(),
 	sqlDB.SetMaxOpenConns(int(cof.DataBase.MaxOpenConns)) // 设置打开数据库连接的最大数量
 	sqlDB.SetConnMaxLifetime(time.Hour)                   // 设置连接的最大存活时间
 	sqlDB.SetConnMaxIdleTime(20 * time.Minute)            // 设置空闲连接的最大存活时间
-	return &Data{db: db, ig: ig}, cleanup, nil
+	return &Data{db: db, ig: ig, router: router, audit: audit}, cleanup, nil
+}
+
+func tenantPoolConfig(conf *configs.TenantPool) *TenantPoolConfig {
+	if conf == nil {
+		return nil
+	}
+	return &TenantPoolConfig{
+		MaxIdleConns: int(conf.MaxIdleConns),
+		MaxOpenConns: int(conf.MaxOpenConns),
+		MaxPools:     int(conf.MaxPools),
+		MetaTTL:      time.Duration(conf.MetaTTL) * time.Second,
+	}
 }
+
 func (d Data) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
 	// 检查 ctx 中是否已有事务
 	if _, ok := ctx.Value(contextTxKey{}).(*gorm.DB); ok {
@@ -76,7 +101,12 @@ func (d Data) InTx(ctx context.Context, fn func(ctx context.Context) error) erro
 		return fn(ctx)
 	}
 	// 未找到事务，创建新的事务
-	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	db, release, err := d.txDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return db.Transaction(func(tx *gorm.DB) error {
 		// 将 tx 放入到 ctx 中
 		ctx = context.WithValue(ctx, contextTxKey{}, tx)
 		return fn(ctx)
@@ -84,7 +114,12 @@ func (d Data) InTx(ctx context.Context, fn func(ctx context.Context) error) erro
 }
 
 func (d Data) InIndependentTx(ctx context.Context, fn func(ctx context.Context) error) error {
-	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	db, release, err := d.txDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return db.Transaction(func(tx *gorm.DB) error {
 		// 将 tx 放入到 ctx 中
 		ctx = context.WithValue(ctx, contextTxKey{}, tx)
 		return fn(ctx)
@@ -101,6 +136,51 @@ func (d Data) DB(ctx context.Context) *gorm.DB {
 	return d.db.WithContext(ctx)
 }
 
+// txDB 获取开启事务的db, 独立Schema/数据库的租户在租户连接池上开启事务
+// 事务结束后调用 release 释放租户连接池
+func (d Data) txDB(ctx context.Context) (*gorm.DB, func(), error) {
+	db := d.db.WithContext(ctx)
+	pool, release, err := d.router.Acquire(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pool != nil {
+		db.Statement.ConnPool = pool
+	}
+	return db, release, nil
+}
+
+func (d Data) AutoMigrate(models ...interface{}) error {
+	if err := d.db.AutoMigrate(models...); err != nil {
+		return err
+	}
+	return d.router.Register(d.db, models...)
+}
+
+func (d Data) SetTenantIsolationProvider(provider ITenantIsolationProvider) {
+	d.router.SetProvider(provider)
+}
+
+func (d Data) SetAuditRecorder(recorder plugin.IAuditRecorder) {
+	d.audit.SetRecorder(recorder)
+}
+
+func (d Data) MigrateTenants(ctx context.Context) error {
+	return d.router.MigrateAll(ctx, d.db)
+}
+
+func (d Data) ProvisionTenant(ctx context.Context, iso *TenantIsolation) error {
+	return d.router.Provision(ctx, d.db, iso)
+}
+
+func (d Data) DeprovisionTenant(ctx context.Context, iso *TenantIsolation) error {
+	return d.router.Deprovision(ctx, d.db, iso)
+}
+
+func (d Data) EvictTenant(tenantID string) {
+	d.router.Evict(tenantID)
+}
+
 func (d Data) GenStringId() string {
 	return d.ig.GenStringId()
 }
@@ -46,6 +46,8 @@ type QueryBuilder struct {
 	conditions []Condition
 	orderBy    []string
 	page       *Page
+	offset     int
+	limit      int
 }
 
 // NewQueryBuilder 创建查询构建器
@@ -82,6 +84,15 @@ func (qb *QueryBuilder) WithPage(page *Page) *QueryBuilder {
 	return qb
 }
 
+// WithOffset 按偏移量分页, 用于偏移量不是页大小整数倍的场景(如 SCIM 的 startIndex), 设置分页后失效
+func (qb *QueryBuilder) WithOffset(offset, limit int) *QueryBuilder {
+	if offset < 0 {
+		offset = 0
+	}
+	qb.offset, qb.limit = offset, limit
+	return qb
+}
+
 // BuildWhere 构建WHERE子句
 func (qb *QueryBuilder) BuildWhere() (string, []interface{}) {
 	if len(qb.conditions) == 0 {
@@ -123,13 +134,16 @@ func (qb *QueryBuilder) BuildOrderBy() string {
 	//return "ORDER BY " + strings.Join(qb.orderBy, ", ")
 }
 
-// BuildLimit 构建LIMIT子句
+// BuildLimit 构建LIMIT子句, 参数依次为 limit、offset, 使用 MySQL 和 PostgreSQL 通用的 LIMIT ? OFFSET ?
 func (qb *QueryBuilder) BuildLimit() (string, []int) {
 	if qb.page == nil {
+		if qb.limit > 0 {
+			return "LIMIT ? OFFSET ?", []int{qb.limit, qb.offset}
+		}
 		return "", nil
 	}
 	qb.page.Fix()
-	return "LIMIT ?, ?", []int{qb.page.Offset(), qb.page.Limit()}
+	return "LIMIT ? OFFSET ?", []int{qb.page.Limit(), qb.page.Offset()}
 }
 
 // Build 将查询条件应用到GORM的DB对象上
@@ -163,6 +177,8 @@ func (qb *QueryBuilder) Build(db *gorm.DB) *gorm.DB {
 	if qb.page != nil {
 		qb.page.Fix()
 		db = db.Offset(qb.page.Offset()).Limit(qb.page.Limit())
+	} else if qb.limit > 0 {
+		db = db.Offset(qb.offset).Limit(qb.limit)
 	}
 
 	return db
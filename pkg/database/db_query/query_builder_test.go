@@ -0,0 +1,54 @@
+package db_query
+
+import (
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newPgTestDB 不连接数据库, 只生成 PostgreSQL 语句
+func newPgTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: "host=127.0.0.1 port=1 user=postgres dbname=ares_admin sslmode=disable",
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestBuildLimitPgsql(t *testing.T) {
+	db := newPgTestDB(t)
+	cases := []struct {
+		name string
+		qb   *QueryBuilder
+		want string
+	}{
+		{"offset", NewQueryBuilder().WithOffset(20, 10), "SELECT * FROM sys_user LIMIT 10 OFFSET 20"},
+		{"page", NewQueryBuilder().WithPage(&Page{Current: 3, Size: 15}), "SELECT * FROM sys_user LIMIT 15 OFFSET 30"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			limit, values := c.qb.BuildLimit()
+			args := make([]interface{}, 0, len(values))
+			for _, v := range values {
+				args = append(args, v)
+			}
+			stmt := db.Raw("SELECT * FROM sys_user "+limit, args...).Find(&[]map[string]interface{}{}).Statement
+			if got := stmt.SQL.String(); got != "SELECT * FROM sys_user LIMIT $1 OFFSET $2" {
+				t.Fatalf("unexpected sql: %s", got)
+			}
+			if got := db.Dialector.Explain(stmt.SQL.String(), stmt.Vars...); got != c.want {
+				t.Fatalf("expected %s, got %s", c.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildLimitWithoutPage(t *testing.T) {
+	if limit, values := NewQueryBuilder().BuildLimit(); limit != "" || values != nil {
+		t.Fatalf("expected no limit, got %q %v", limit, values)
+	}
+}
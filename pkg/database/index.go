@@ -2,6 +2,8 @@ package database
 
 import (
 	"context"
+
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/plugin"
 	"gorm.io/gorm"
 )
 
@@ -16,4 +18,18 @@ type IDataBase interface {
 	GenStringId() string
 	// GenInt64Id 生成int类型的id
 	GenInt64Id() int64
+	// AutoMigrate 迁移租户业务表, 同时登记到租户路由, 独立Schema/数据库的租户初始化时迁移这些表
+	AutoMigrate(models ...interface{}) error
+	// SetTenantIsolationProvider 设置租户隔离配置提供者
+	SetTenantIsolationProvider(provider ITenantIsolationProvider)
+	// SetAuditRecorder 设置数据变更记录写入器
+	SetAuditRecorder(recorder plugin.IAuditRecorder)
+	// MigrateTenants 迁移全部独立Schema/数据库租户的租户业务表, 启动时调用
+	MigrateTenants(ctx context.Context) error
+	// ProvisionTenant 初始化租户独立存储, 可重复执行
+	ProvisionTenant(ctx context.Context, iso *TenantIsolation) error
+	// DeprovisionTenant 删除租户独立存储, 租户数据清除时调用
+	DeprovisionTenant(ctx context.Context, iso *TenantIsolation) error
+	// EvictTenant 清除租户路由缓存, 租户隔离配置变更后调用
+	EvictTenant(tenantID string)
 }
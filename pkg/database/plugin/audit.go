@@ -0,0 +1,411 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// 变更类型
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+)
+
+// AuditRedacted 敏感字段记录的值
+const AuditRedacted = "******"
+
+// DefaultAuditRedactFields 列名包含以下关键字的字段不记录原值
+var DefaultAuditRedactFields = []string{"password", "secret", "token"}
+
+// auditIgnoreFields 不参与比较的字段
+var auditIgnoreFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"creator":    true,
+	"updater":    true,
+}
+
+// auditDeletedField 软删除字段, 由 0 变为非 0 时记为删除
+const auditDeletedField = "deleted_at"
+
+// auditMaxRows 单条语句最多记录的行数
+const auditMaxRows = 500
+
+const auditRowsKey = "audit:rows"
+
+// Auditable 需要记录数据变更的实体, 返回实体类型
+type Auditable interface {
+	AuditType() string
+}
+
+// AuditChange 字段变更
+type AuditChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// AuditRecord 单个实体的变更记录
+type AuditRecord struct {
+	RequestID  string
+	TenantID   string
+	EntityType string
+	EntityID   string
+	Action     string
+	// 模拟登录期间 ActorID/ActorName 为实际操作的管理员
+	ActorID            string
+	ActorName          string
+	ImpersonatedUserID string
+	Changes            []*AuditChange
+	CreatedAt          time.Time
+}
+
+// IAuditRecorder 变更记录写入, ctx 中带有事务时与业务数据在同一事务中写入
+type IAuditRecorder interface {
+	Record(ctx context.Context, records []*AuditRecord) error
+}
+
+// AuditPlugin 记录实现了 Auditable 的实体在创建、更新、删除时的字段变更
+type AuditPlugin struct {
+	recorder atomic.Value
+	redact   []string
+}
+
+func NewAuditPlugin(redactFields ...string) *AuditPlugin {
+	if len(redactFields) == 0 {
+		redactFields = DefaultAuditRedactFields
+	}
+	return &AuditPlugin{redact: redactFields}
+}
+
+func (p *AuditPlugin) Name() string {
+	return "audit_plugin"
+}
+
+// SetRecorder 设置变更记录写入器, 未设置时不记录
+func (p *AuditPlugin) SetRecorder(recorder IAuditRecorder) {
+	p.recorder.Store(recorder)
+}
+
+func (p *AuditPlugin) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Create().After("gorm:create").Register("audit:after_create", p.afterCreate); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("audit:before_update", p.beforeChange); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("audit:after_update", p.afterUpdate); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().Before("gorm:delete").Register("audit:before_delete", p.beforeChange); err != nil {
+		return err
+	}
+	return db.Callback().Delete().After("gorm:delete").Register("audit:after_delete", p.afterDelete)
+}
+
+func (p *AuditPlugin) getRecorder() IAuditRecorder {
+	recorder, _ := p.recorder.Load().(IAuditRecorder)
+	return recorder
+}
+
+// auditType 语句操作的实体类型, 只记录单主键的实体
+func (p *AuditPlugin) auditType(db *gorm.DB) (string, bool) {
+	if p.getRecorder() == nil || db.Error != nil || db.Statement.Schema == nil || len(db.Statement.Schema.PrimaryFields) != 1 {
+		return "", false
+	}
+	a, ok := reflect.New(db.Statement.Schema.ModelType).Interface().(Auditable)
+	if !ok {
+		return "", false
+	}
+	return a.AuditType(), true
+}
+
+func (p *AuditPlugin) afterCreate(db *gorm.DB) {
+	entityType, ok := p.auditType(db)
+	if !ok {
+		return
+	}
+	records := make([]*AuditRecord, 0)
+	for _, rv := range structValues(db.Statement.ReflectValue) {
+		record := p.newRecord(db, entityType, AuditCreate, rv)
+		record.Changes = p.diff(db.Statement.Schema, nil, p.snapshot(db, rv))
+		records = append(records, record)
+	}
+	p.record(db, records)
+}
+
+// beforeChange 更新或删除前读取受影响的行
+func (p *AuditPlugin) beforeChange(db *gorm.DB) {
+	if _, ok := p.auditType(db); !ok {
+		return
+	}
+	exprs := p.conditions(db)
+	if len(exprs) == 0 {
+		return
+	}
+	rows, err := p.load(db, exprs)
+	if err != nil {
+		hlog.CtxWarnf(db.Statement.Context, "audit load rows of %s error: %v", db.Statement.Table, err)
+		return
+	}
+	if len(rows) > 0 {
+		db.InstanceSet(auditRowsKey, rows)
+	}
+}
+
+func (p *AuditPlugin) afterUpdate(db *gorm.DB) {
+	entityType, ok := p.auditType(db)
+	if !ok {
+		return
+	}
+	oldRows := p.loaded(db)
+	if len(oldRows) == 0 {
+		return
+	}
+	pk := db.Statement.Schema.PrimaryFields[0]
+	ids := make([]interface{}, 0, len(oldRows))
+	for _, rv := range oldRows {
+		id, _ := pk.ValueOf(db.Statement.Context, rv)
+		ids = append(ids, id)
+	}
+	// 按主键重新读取, 避免更新了查询条件中的字段后读不到
+	newRows, err := p.load(db, []clause.Expression{
+		clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: pk.DBName}, Values: ids},
+	})
+	if err != nil {
+		hlog.CtxWarnf(db.Statement.Context, "audit reload rows of %s error: %v", db.Statement.Table, err)
+		return
+	}
+	newByID := make(map[string]reflect.Value, len(newRows))
+	for _, rv := range newRows {
+		newByID[p.entityID(db, rv)] = rv
+	}
+	records := make([]*AuditRecord, 0, len(oldRows))
+	for _, rv := range oldRows {
+		newRV, ok := newByID[p.entityID(db, rv)]
+		if !ok {
+			continue
+		}
+		oldSnap, newSnap := p.snapshot(db, rv), p.snapshot(db, newRV)
+		changes := p.diff(db.Statement.Schema, oldSnap, newSnap)
+		if len(changes) == 0 {
+			continue
+		}
+		action := AuditUpdate
+		if isZero(oldSnap[auditDeletedField]) && !isZero(newSnap[auditDeletedField]) {
+			action = AuditDelete
+		}
+		record := p.newRecord(db, entityType, action, newRV)
+		record.Changes = changes
+		records = append(records, record)
+	}
+	p.record(db, records)
+}
+
+func (p *AuditPlugin) afterDelete(db *gorm.DB) {
+	entityType, ok := p.auditType(db)
+	if !ok {
+		return
+	}
+	records := make([]*AuditRecord, 0)
+	for _, rv := range p.loaded(db) {
+		record := p.newRecord(db, entityType, AuditDelete, rv)
+		record.Changes = p.diff(db.Statement.Schema, p.snapshot(db, rv), nil)
+		records = append(records, record)
+	}
+	p.record(db, records)
+}
+
+func (p *AuditPlugin) record(db *gorm.DB, records []*AuditRecord) {
+	if len(records) == 0 {
+		return
+	}
+	if err := p.getRecorder().Record(db.Statement.Context, records); err != nil {
+		hlog.CtxErrorf(db.Statement.Context, "audit record %s error: %v", db.Statement.Table, err)
+	}
+}
+
+func (p *AuditPlugin) loaded(db *gorm.DB) []reflect.Value {
+	v, ok := db.InstanceGet(auditRowsKey)
+	if !ok {
+		return nil
+	}
+	rows, _ := v.([]reflect.Value)
+	return rows
+}
+
+// conditions 语句的查询条件, 按模型主键更新时主键条件在 gorm:update 中才会加入, 这里提前补上
+func (p *AuditPlugin) conditions(db *gorm.DB) []clause.Expression {
+	stmt := db.Statement
+	exprs := make([]clause.Expression, 0)
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			exprs = append(exprs, where.Exprs...)
+		}
+	}
+	if stmt.ReflectValue.Kind() == reflect.Struct {
+		for _, f := range stmt.Schema.PrimaryFields {
+			if v, zero := f.ValueOf(stmt.Context, stmt.ReflectValue); !zero {
+				exprs = append(exprs, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: f.DBName}, Value: v})
+			}
+		}
+	}
+	return exprs
+}
+
+// load 在语句的连接(事务)上读取当前的行
+func (p *AuditPlugin) load(db *gorm.DB, exprs []clause.Expression) ([]reflect.Value, error) {
+	stmt := db.Statement
+	dest := reflect.New(reflect.SliceOf(reflect.PointerTo(stmt.Schema.ModelType)))
+	err := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).
+		Table(stmt.Table).
+		Clauses(clause.Where{Exprs: exprs}).
+		Limit(auditMaxRows).
+		Find(dest.Interface()).Error
+	if err != nil {
+		return nil, err
+	}
+	return structValues(dest.Elem()), nil
+}
+
+func (p *AuditPlugin) newRecord(db *gorm.DB, entityType, action string, rv reflect.Value) *AuditRecord {
+	ctx := db.Statement.Context
+	record := &AuditRecord{
+		RequestID:  actx.GetRequestId(ctx),
+		TenantID:   GetCtxTenantID(ctx),
+		EntityType: entityType,
+		EntityID:   p.entityID(db, rv),
+		Action:     action,
+		ActorID:    ctxValue(actx.GetUserId(ctx)),
+		ActorName:  ctxValue(actx.GetUsername(ctx)),
+		CreatedAt:  time.Now(),
+	}
+	if actx.IsImpersonating(ctx) {
+		record.ImpersonatedUserID = record.ActorID
+		record.ActorID = actx.GetActorId(ctx)
+		record.ActorName = actx.GetActorName(ctx)
+	}
+	// 实体上的租户优先
+	if field := db.Statement.Schema.LookUpField(actx.KeyTenantId); field != nil {
+		if v, zero := field.ValueOf(ctx, rv); !zero {
+			record.TenantID = fmt.Sprint(v)
+		}
+	}
+	return record
+}
+
+func (p *AuditPlugin) entityID(db *gorm.DB, rv reflect.Value) string {
+	v, _ := db.Statement.Schema.PrimaryFields[0].ValueOf(db.Statement.Context, rv)
+	return fmt.Sprint(v)
+}
+
+// snapshot 行的字段值, 键为列名
+func (p *AuditPlugin) snapshot(db *gorm.DB, rv reflect.Value) map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, f := range db.Statement.Schema.Fields {
+		if f.DBName == "" || auditIgnoreFields[f.DBName] {
+			continue
+		}
+		v, _ := f.ValueOf(db.Statement.Context, rv)
+		values[f.DBName] = indirect(v)
+	}
+	return values
+}
+
+// diff 按字段顺序比较新旧值, 创建和删除只记录非零值
+func (p *AuditPlugin) diff(sch *schema.Schema, oldValues, newValues map[string]interface{}) []*AuditChange {
+	changes := make([]*AuditChange, 0)
+	for _, f := range sch.Fields {
+		oldV, hasOld := oldValues[f.DBName]
+		newV, hasNew := newValues[f.DBName]
+		if !hasOld && !hasNew {
+			continue
+		}
+		if hasOld && hasNew && reflect.DeepEqual(oldV, newV) {
+			continue
+		}
+		if (!hasOld || isZero(oldV)) && (!hasNew || isZero(newV)) {
+			continue
+		}
+		if p.redacted(f.DBName) {
+			oldV, newV = redact(oldV), redact(newV)
+		}
+		changes = append(changes, &AuditChange{Field: f.DBName, Old: oldV, New: newV})
+	}
+	return changes
+}
+
+func (p *AuditPlugin) redacted(column string) bool {
+	column = strings.ToLower(column)
+	for _, key := range p.redact {
+		if strings.Contains(column, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func redact(v interface{}) interface{} {
+	if isZero(v) {
+		return v
+	}
+	return AuditRedacted
+}
+
+// structValues 展开为结构体值
+func structValues(rv reflect.Value) []reflect.Value {
+	rv = reflect.Indirect(rv)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		values := make([]reflect.Value, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			if v := reflect.Indirect(rv.Index(i)); v.Kind() == reflect.Struct {
+				values = append(values, v)
+			}
+		}
+		return values
+	case reflect.Struct:
+		return []reflect.Value{rv}
+	}
+	return nil
+}
+
+func indirect(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+	return rv.Interface()
+}
+
+func isZero(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	return reflect.ValueOf(v).IsZero()
+}
+
+// ctxValue 上下文中缺失的值会被格式化为 <nil>
+func ctxValue(v string) string {
+	if v == "<nil>" {
+		return ""
+	}
+	return v
+}
@@ -0,0 +1,78 @@
+package plugin
+
+import (
+	"sync"
+	"testing"
+
+	"gorm.io/gorm/schema"
+)
+
+type auditUser struct {
+	ID        string `gorm:"primaryKey"`
+	Name      string
+	Password  string
+	Status    int8
+	UpdatedAt int64
+	DeletedAt int64
+}
+
+func (auditUser) AuditType() string {
+	return "user"
+}
+
+func parseAuditSchema(t *testing.T) *schema.Schema {
+	sch, err := schema.Parse(&auditUser{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sch
+}
+
+func changesByField(changes []*AuditChange) map[string]*AuditChange {
+	m := make(map[string]*AuditChange, len(changes))
+	for _, c := range changes {
+		m[c.Field] = c
+	}
+	return m
+}
+
+func TestAuditDiffUpdate(t *testing.T) {
+	p := NewAuditPlugin()
+	sch := parseAuditSchema(t)
+	oldValues := map[string]interface{}{"id": "1", "name": "a", "password": "x", "status": int8(1), "deleted_at": int64(0)}
+	newValues := map[string]interface{}{"id": "1", "name": "b", "password": "y", "status": int8(1), "deleted_at": int64(0)}
+
+	changes := changesByField(p.diff(sch, oldValues, newValues))
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d", len(changes))
+	}
+	if c := changes["name"]; c == nil || c.Old != "a" || c.New != "b" {
+		t.Fatalf("unexpected name change: %+v", c)
+	}
+	if c := changes["password"]; c == nil || c.Old != AuditRedacted || c.New != AuditRedacted {
+		t.Fatalf("password should be redacted: %+v", c)
+	}
+}
+
+func TestAuditDiffCreateSkipsZero(t *testing.T) {
+	p := NewAuditPlugin()
+	sch := parseAuditSchema(t)
+	newValues := map[string]interface{}{"id": "1", "name": "a", "password": "", "status": int8(0), "deleted_at": int64(0)}
+
+	changes := changesByField(p.diff(sch, nil, newValues))
+	if len(changes) != 2 || changes["id"] == nil || changes["name"] == nil {
+		t.Fatalf("unexpected changes: %v", changes)
+	}
+	if changes["name"].Old != nil {
+		t.Fatalf("created field should have no old value: %+v", changes["name"])
+	}
+}
+
+func TestAuditRedactedClearToEmpty(t *testing.T) {
+	p := NewAuditPlugin()
+	sch := parseAuditSchema(t)
+	changes := p.diff(sch, map[string]interface{}{"password": "x"}, map[string]interface{}{"password": ""})
+	if len(changes) != 1 || changes[0].Old != AuditRedacted || changes[0].New != "" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+}
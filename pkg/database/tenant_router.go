@@ -0,0 +1,615 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/database/plugin"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/go-sql-driver/mysql"
+	gmysql "gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// 租户隔离模式
+const (
+	IsolationShared   = "shared"   // 共享表, 通过 tenant_id 过滤(默认)
+	IsolationSchema   = "schema"   // 独立Schema(PostgreSQL)/独立库(MySQL), 与主库同实例
+	IsolationDatabase = "database" // 独立数据库实例
+)
+
+var schemaNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,62}$`)
+
+// TenantIsolation 租户隔离配置
+type TenantIsolation struct {
+	TenantID string // 租户ID
+	Mode     string // 隔离模式
+	Schema   string // Schema名称, schema 模式使用
+	Source   string // 数据源, database 模式使用
+}
+
+// IsIsolated 是否为物理隔离
+func (t *TenantIsolation) IsIsolated() bool {
+	return t != nil && (t.Mode == IsolationSchema || t.Mode == IsolationDatabase)
+}
+
+// ITenantIsolationProvider 租户隔离配置提供者
+type ITenantIsolationProvider interface {
+	// GetIsolation 获取租户隔离配置,不存在时返回 nil
+	GetIsolation(ctx context.Context, tenantID string) (*TenantIsolation, error)
+	// ListIsolated 获取全部独立Schema/数据库的租户, 启动时迁移租户库使用
+	ListIsolated(ctx context.Context) ([]*TenantIsolation, error)
+}
+
+// ErrCrossDatabaseJoin 独立数据库实例的租户无法在一条语句中关联公共表和租户表
+var ErrCrossDatabaseJoin = errors.New("tenant with isolated database cannot join shared tables and tenant tables in one statement")
+
+// TenantPoolConfig 租户连接池配置
+type TenantPoolConfig struct {
+	MaxIdleConns int           // 每个租户连接池最大空闲连接数
+	MaxOpenConns int           // 每个租户连接池最大连接数
+	MaxPools     int           // 最多同时保持的租户连接池数量
+	MetaTTL      time.Duration // 租户隔离配置缓存时间
+}
+
+type tenantEntry struct {
+	iso      *TenantIsolation
+	pool     *sql.DB
+	loadedAt time.Time
+	lastUsed time.Time
+	refs     int  // 正在使用连接池的语句和事务数
+	retired  bool // 已被替换或淘汰, 引用归零后关闭连接池
+}
+
+const entryInstanceKey = "tenant_router:entry"
+
+// TenantRouter 租户路由, 根据租户隔离配置将租户表的操作路由到租户独立的连接池
+type TenantRouter struct {
+	driver   string
+	source   string
+	conf     TenantPoolConfig
+	provider ITenantIsolationProvider
+	main     gorm.ConnPool // 主库连接池, 独立数据库实例的租户访问公共表时使用
+	mu       sync.Mutex
+	entries  map[string]*tenantEntry
+	tables   map[string]struct{}
+	models   []interface{}
+}
+
+// NewTenantRouter 创建租户路由
+func NewTenantRouter(driver, source string, conf *TenantPoolConfig) *TenantRouter {
+	c := TenantPoolConfig{MaxIdleConns: 2, MaxOpenConns: 10, MaxPools: 50, MetaTTL: 5 * time.Minute}
+	if conf != nil {
+		if conf.MaxIdleConns > 0 {
+			c.MaxIdleConns = conf.MaxIdleConns
+		}
+		if conf.MaxOpenConns > 0 {
+			c.MaxOpenConns = conf.MaxOpenConns
+		}
+		if conf.MaxPools > 0 {
+			c.MaxPools = conf.MaxPools
+		}
+		if conf.MetaTTL > 0 {
+			c.MetaTTL = conf.MetaTTL
+		}
+	}
+	return &TenantRouter{
+		driver:  driver,
+		source:  source,
+		conf:    c,
+		entries: make(map[string]*tenantEntry),
+		tables:  make(map[string]struct{}),
+	}
+}
+
+func (r *TenantRouter) Name() string {
+	return "tenant_router"
+}
+
+func (r *TenantRouter) Initialize(db *gorm.DB) error {
+	r.main = db.ConnPool
+	cb := db.Callback()
+	if err := cb.Create().Before("*").Register("tenant_router:create", r.route); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("*").Register("tenant_router:query", r.route); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("*").Register("tenant_router:update", r.route); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("*").Register("tenant_router:delete", r.route); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("*").Register("tenant_router:row", r.route); err != nil {
+		return err
+	}
+	// 语句执行完后释放连接池引用
+	if err := cb.Create().After("*").Register("tenant_router:create_release", r.releaseStatement); err != nil {
+		return err
+	}
+	if err := cb.Query().After("*").Register("tenant_router:query_release", r.releaseStatement); err != nil {
+		return err
+	}
+	if err := cb.Update().After("*").Register("tenant_router:update_release", r.releaseStatement); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("*").Register("tenant_router:delete_release", r.releaseStatement); err != nil {
+		return err
+	}
+	return cb.Row().After("*").Register("tenant_router:row_release", r.releaseStatement)
+}
+
+// SetProvider 设置租户隔离配置提供者
+func (r *TenantRouter) SetProvider(provider ITenantIsolationProvider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.provider = provider
+}
+
+// Register 登记租户业务表, 租户初始化时会在租户独立库中迁移这些表
+func (r *TenantRouter) Register(db *gorm.DB, models ...interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(m); err != nil {
+			return err
+		}
+		if _, ok := r.tables[stmt.Schema.Table]; ok {
+			continue
+		}
+		r.tables[stmt.Schema.Table] = struct{}{}
+		r.models = append(r.models, m)
+	}
+	return nil
+}
+
+// Evict 清除租户隔离配置缓存, 连接池在正在执行的语句和事务结束后关闭
+func (r *TenantRouter) Evict(tenantID string) {
+	r.mu.Lock()
+	var retired *sql.DB
+	if entry, ok := r.entries[tenantID]; ok {
+		delete(r.entries, tenantID)
+		retired = r.retire(entry)
+	}
+	r.mu.Unlock()
+	closePools(retired)
+}
+
+// Acquire 获取租户连接池, 共享模式的租户返回 nil; 使用完后必须调用 release, 之后连接池才可能被关闭
+func (r *TenantRouter) Acquire(ctx context.Context) (*sql.DB, func(), error) {
+	entry, err := r.acquire(ctx)
+	if err != nil || entry == nil {
+		return nil, func() {}, err
+	}
+	if entry.pool == nil {
+		r.release(entry)
+		return nil, func() {}, nil
+	}
+	var once sync.Once
+	return entry.pool, func() { once.Do(func() { r.release(entry) }) }, nil
+}
+
+// acquire 获取租户路由并增加引用, 共享模式或未指定租户时返回 nil
+func (r *TenantRouter) acquire(ctx context.Context) (*tenantEntry, error) {
+	if plugin.IsIgnoreTenant(ctx) {
+		return nil, nil
+	}
+	tenantID := plugin.GetCtxTenantID(ctx)
+	if tenantID == "" {
+		return nil, nil
+	}
+	r.mu.Lock()
+	entry, ok := r.entries[tenantID]
+	provider := r.provider
+	if ok && time.Since(entry.loadedAt) < r.conf.MetaTTL {
+		entry.lastUsed = time.Now()
+		entry.refs++
+		r.mu.Unlock()
+		return entry, nil
+	}
+	r.mu.Unlock()
+	if provider == nil {
+		return nil, nil
+	}
+	// 查询租户配置时忽略租户, 避免递归路由
+	iso, err := provider.GetIsolation(actx.BuildIgnoreTenantCtx(ctx), tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if iso == nil {
+		return nil, nil
+	}
+	return r.store(tenantID, iso)
+}
+
+// release 释放引用, 已淘汰的连接池在引用归零后关闭
+func (r *TenantRouter) release(entry *tenantEntry) {
+	r.mu.Lock()
+	entry.refs--
+	var retired *sql.DB
+	if entry.retired && entry.refs <= 0 {
+		retired = entry.pool
+	}
+	r.mu.Unlock()
+	closePools(retired)
+}
+
+// retire 标记淘汰, 没有引用时返回需要关闭的连接池, 调用方持有锁
+func (r *TenantRouter) retire(entry *tenantEntry) *sql.DB {
+	entry.retired = true
+	if entry.refs > 0 {
+		return nil
+	}
+	return entry.pool
+}
+
+func closePools(pools ...*sql.DB) {
+	for _, pool := range pools {
+		if pool != nil {
+			_ = pool.Close()
+		}
+	}
+}
+
+// Provision 初始化租户独立存储: 创建Schema/库并迁移已登记的租户业务表, 可重复执行
+func (r *TenantRouter) Provision(ctx context.Context, main *gorm.DB, iso *TenantIsolation) error {
+	if !iso.IsIsolated() {
+		return nil
+	}
+	r.Evict(iso.TenantID)
+	return r.migrate(ctx, main, iso)
+}
+
+// MigrateAll 迁移全部独立Schema/数据库租户的租户业务表, 启动时在所有仓储登记完表之后调用
+// 单个租户失败不影响其他租户, 返回全部失败原因
+func (r *TenantRouter) MigrateAll(ctx context.Context, main *gorm.DB) error {
+	r.mu.Lock()
+	provider := r.provider
+	r.mu.Unlock()
+	if provider == nil {
+		return nil
+	}
+	isos, err := provider.ListIsolated(actx.BuildIgnoreTenantCtx(ctx))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, iso := range isos {
+		if !iso.IsIsolated() {
+			continue
+		}
+		if err := r.migrate(ctx, main, iso); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *TenantRouter) migrate(ctx context.Context, main *gorm.DB, iso *TenantIsolation) error {
+	if iso.Mode == IsolationSchema {
+		if err := r.createSchema(ctx, main, iso.Schema); err != nil {
+			return err
+		}
+	}
+	entry, err := r.store(iso.TenantID, iso)
+	if err != nil {
+		return err
+	}
+	defer r.release(entry)
+	tdb, err := gorm.Open(r.dialector(entry.pool), &gorm.Config{
+		DisableForeignKeyConstraintWhenMigrating: true,
+		Logger:                                   main.Logger,
+	})
+	if err != nil {
+		return fmt.Errorf("open tenant %s db failed: %w", iso.TenantID, err)
+	}
+	r.mu.Lock()
+	models := append([]interface{}{}, r.models...)
+	r.mu.Unlock()
+	if err := tdb.WithContext(ctx).AutoMigrate(models...); err != nil {
+		return fmt.Errorf("migrate tenant %s tables failed: %w", iso.TenantID, err)
+	}
+	// MySQL 独立库中通过视图访问主库的公共表(权限、租户等), 保证关联查询可用
+	if r.driver != "pgsql" && iso.Mode == IsolationSchema {
+		return r.linkSharedTables(ctx, main, tdb)
+	}
+	return nil
+}
+
+// Deprovision 删除租户独立存储: schema 模式删除Schema/库, database 模式的独立实例由运维回收, 只关闭连接池
+func (r *TenantRouter) Deprovision(ctx context.Context, main *gorm.DB, iso *TenantIsolation) error {
+	r.Evict(iso.TenantID)
+	if iso.Mode != IsolationSchema {
+		return nil
+	}
+	if !schemaNameRegexp.MatchString(iso.Schema) {
+		return fmt.Errorf("invalid tenant schema name: %s", iso.Schema)
+	}
+	stmt := fmt.Sprintf("DROP DATABASE IF EXISTS `%s`", iso.Schema)
+	if r.driver == "pgsql" {
+		stmt = fmt.Sprintf(`DROP SCHEMA IF EXISTS "%s" CASCADE`, iso.Schema)
+	}
+	return main.WithContext(ctx).Exec(stmt).Error
+}
+
+// route 将租户业务表的操作路由到租户连接池
+// 公共表在 schema 模式下可以在租户库中访问(PostgreSQL search_path, MySQL 视图), database 模式只能在主库访问
+func (r *TenantRouter) route(db *gorm.DB) {
+	if db.Statement.Table == "" {
+		return
+	}
+	tenantTables, sharedTables := r.classify(statementTables(db.Statement))
+	inTx := isTransaction(db.Statement.ConnPool)
+	// 事务内只有访问公共表时才需要判断隔离模式, 事务外只有访问租户表时才需要切换连接池
+	if (inTx && sharedTables == 0) || (!inTx && tenantTables == 0) {
+		return
+	}
+	entry, err := r.acquire(db.Statement.Context)
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	if entry == nil {
+		return
+	}
+	if entry.pool == nil {
+		r.release(entry)
+		return
+	}
+	if tenantTables > 0 && sharedTables > 0 && entry.iso.Mode == IsolationDatabase {
+		r.release(entry)
+		_ = db.AddError(ErrCrossDatabaseJoin)
+		return
+	}
+	if inTx {
+		// 租户库事务中访问公共表, database 模式的租户库中没有公共表, 改到主库执行
+		if tenantTables == 0 && entry.iso.Mode == IsolationDatabase {
+			db.Statement.ConnPool = r.main
+		}
+		r.release(entry)
+		return
+	}
+	db.Statement.ConnPool = entry.pool
+	// 语句执行完后在 releaseStatement 中释放
+	db.InstanceSet(entryInstanceKey, entry)
+}
+
+// releaseStatement 释放 route 中获取的连接池引用
+func (r *TenantRouter) releaseStatement(db *gorm.DB) {
+	if v, ok := db.InstanceGet(entryInstanceKey); ok {
+		if entry, ok := v.(*tenantEntry); ok {
+			db.InstanceSet(entryInstanceKey, nil)
+			r.release(entry)
+		}
+	}
+}
+
+// classify 统计语句中的租户表和公共表数量
+func (r *TenantRouter) classify(tables []string) (tenantTables, sharedTables int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, table := range tables {
+		if _, ok := r.tables[table]; ok {
+			tenantTables++
+		} else {
+			sharedTables++
+		}
+	}
+	return
+}
+
+func (r *TenantRouter) isTenantTable(table string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.tables[table]
+	return ok
+}
+
+var joinTableRegexp = regexp.MustCompile("(?i)\\bjoin\\s+[`\"]?([a-zA-Z0-9_]+)")
+
+// statementTables 语句访问的表: 主表和关联的表
+func statementTables(stmt *gorm.Statement) []string {
+	table := stmt.Table
+	// Table("sys_user u") 时 Table 为别名, 表名在 TableExpr 中
+	if stmt.TableExpr != nil {
+		table = stmt.TableExpr.SQL
+	}
+	tables := []string{tableName(table)}
+	for _, join := range stmt.Joins {
+		if stmt.Schema != nil {
+			if rel, ok := stmt.Schema.Relationships.Relations[join.Name]; ok {
+				tables = append(tables, rel.FieldSchema.Table)
+				continue
+			}
+		}
+		for _, m := range joinTableRegexp.FindAllStringSubmatch(join.Name, -1) {
+			tables = append(tables, m[1])
+		}
+	}
+	return tables
+}
+
+// tableName 去掉表别名和引号, 如 "sys_user u" -> sys_user
+func tableName(table string) string {
+	if fields := strings.Fields(table); len(fields) > 0 {
+		table = fields[0]
+	}
+	return strings.Trim(table, "`\"")
+}
+
+// store 缓存租户隔离配置并增加引用, 配置变化时淘汰旧连接池
+func (r *TenantRouter) store(tenantID string, iso *TenantIsolation) (*tenantEntry, error) {
+	r.mu.Lock()
+	now := time.Now()
+	old, ok := r.entries[tenantID]
+	if ok && old.iso != nil && iso != nil && *old.iso == *iso {
+		old.loadedAt, old.lastUsed = now, now
+		old.refs++
+		r.mu.Unlock()
+		return old, nil
+	}
+	var retired []*sql.DB
+	if ok {
+		delete(r.entries, tenantID)
+		retired = append(retired, r.retire(old))
+	}
+	entry := &tenantEntry{iso: iso, loadedAt: now, lastUsed: now, refs: 1}
+	if iso.IsIsolated() {
+		pool, err := r.open(iso)
+		if err != nil {
+			r.mu.Unlock()
+			closePools(retired...)
+			return nil, err
+		}
+		entry.pool = pool
+		retired = append(retired, r.evictIdle(tenantID)...)
+	}
+	r.entries[tenantID] = entry
+	r.mu.Unlock()
+	closePools(retired...)
+	return entry, nil
+}
+
+// evictIdle 连接池数量超出限制时淘汰最久未使用且没有引用的连接池, 返回需要关闭的连接池, 调用方持有锁
+func (r *TenantRouter) evictIdle(keep string) []*sql.DB {
+	count := 0
+	for _, e := range r.entries {
+		if e.pool != nil {
+			count++
+		}
+	}
+	var retired []*sql.DB
+	for count >= r.conf.MaxPools {
+		var victim string
+		var oldest time.Time
+		for id, e := range r.entries {
+			if id == keep || e.pool == nil || e.refs > 0 {
+				continue
+			}
+			if victim == "" || e.lastUsed.Before(oldest) {
+				victim, oldest = id, e.lastUsed
+			}
+		}
+		if victim == "" {
+			hlog.Warnf("tenant pools exceed limit %d, but all pools are in use", r.conf.MaxPools)
+			return retired
+		}
+		retired = append(retired, r.retire(r.entries[victim]))
+		delete(r.entries, victim)
+		count--
+	}
+	return retired
+}
+
+func (r *TenantRouter) open(iso *TenantIsolation) (*sql.DB, error) {
+	source, err := r.tenantSource(iso)
+	if err != nil {
+		return nil, err
+	}
+	driverName := "mysql"
+	if r.driver == "pgsql" {
+		driverName = "pgx"
+	}
+	pool, err := sql.Open(driverName, source)
+	if err != nil {
+		return nil, fmt.Errorf("open tenant %s pool failed: %w", iso.TenantID, err)
+	}
+	pool.SetMaxIdleConns(r.conf.MaxIdleConns)
+	pool.SetMaxOpenConns(r.conf.MaxOpenConns)
+	pool.SetConnMaxLifetime(time.Hour)
+	pool.SetConnMaxIdleTime(10 * time.Minute)
+	return pool, nil
+}
+
+func (r *TenantRouter) tenantSource(iso *TenantIsolation) (string, error) {
+	if iso.Mode == IsolationDatabase {
+		if iso.Source == "" {
+			return "", fmt.Errorf("tenant %s source is empty", iso.TenantID)
+		}
+		return iso.Source, nil
+	}
+	if !schemaNameRegexp.MatchString(iso.Schema) {
+		return "", fmt.Errorf("invalid tenant schema name: %s", iso.Schema)
+	}
+	return SchemaSource(r.driver, r.source, iso.Schema)
+}
+
+func (r *TenantRouter) dialector(pool *sql.DB) gorm.Dialector {
+	if r.driver == "pgsql" {
+		return postgres.New(postgres.Config{Conn: pool})
+	}
+	return gmysql.New(gmysql.Config{Conn: pool})
+}
+
+func (r *TenantRouter) createSchema(ctx context.Context, main *gorm.DB, schema string) error {
+	if !schemaNameRegexp.MatchString(schema) {
+		return fmt.Errorf("invalid tenant schema name: %s", schema)
+	}
+	stmt := fmt.Sprintf("CREATE DATABASE IF NOT EXISTS `%s`", schema)
+	if r.driver == "pgsql" {
+		stmt = fmt.Sprintf(`CREATE SCHEMA IF NOT EXISTS "%s"`, schema)
+	}
+	return main.WithContext(ctx).Exec(stmt).Error
+}
+
+func (r *TenantRouter) linkSharedTables(ctx context.Context, main, tdb *gorm.DB) error {
+	var mainSchema string
+	if err := main.WithContext(ctx).Raw("SELECT DATABASE()").Scan(&mainSchema).Error; err != nil {
+		return err
+	}
+	tables, err := main.WithContext(ctx).Migrator().GetTables()
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if r.isTenantTable(table) {
+			continue
+		}
+		stmt := fmt.Sprintf("CREATE OR REPLACE VIEW `%s` AS SELECT * FROM `%s`.`%s`", table, mainSchema, table)
+		if err := tdb.WithContext(ctx).Exec(stmt).Error; err != nil {
+			return fmt.Errorf("link shared table %s failed: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// SchemaSource 根据主库数据源生成租户Schema数据源
+// PostgreSQL 通过 search_path 指定租户Schema并回落到 public 访问公共表, MySQL 替换库名
+func SchemaSource(driver, source, schema string) (string, error) {
+	if driver == "pgsql" {
+		searchPath := schema + ",public"
+		if strings.HasPrefix(source, "postgres://") || strings.HasPrefix(source, "postgresql://") {
+			u, err := url.Parse(source)
+			if err != nil {
+				return "", err
+			}
+			q := u.Query()
+			q.Set("search_path", searchPath)
+			u.RawQuery = q.Encode()
+			return u.String(), nil
+		}
+		return strings.TrimSpace(source) + " search_path=" + searchPath, nil
+	}
+	cfg, err := mysql.ParseDSN(source)
+	if err != nil {
+		return "", err
+	}
+	cfg.DBName = schema
+	return cfg.FormatDSN(), nil
+}
+
+func isTransaction(connPool gorm.ConnPool) bool {
+	_, ok := connPool.(gorm.TxCommitter)
+	return ok
+}
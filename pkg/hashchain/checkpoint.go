@@ -0,0 +1,108 @@
+package hashchain
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Head 一条链的链尾
+type Head struct {
+	TenantID string `json:"tenant_id"`
+	LogType  string `json:"log_type"`
+	Seq      int64  `json:"seq"`
+	Hash     string `json:"hash"`
+}
+
+// Checkpoint 签名检查点, 导出后保存在系统之外, 校验时用于发现整体重算过的链
+type Checkpoint struct {
+	CreatedAt int64   `json:"created_at"`
+	Heads     []*Head `json:"heads"`
+	PublicKey string  `json:"public_key"` // ed25519 公钥, base64
+	Signature string  `json:"signature"`  // 对 created_at 和 heads 的签名, base64
+}
+
+// signedContent 签名覆盖的内容
+func (c *Checkpoint) signedContent() ([]byte, error) {
+	return Canonical(struct {
+		CreatedAt int64   `json:"created_at"`
+		Heads     []*Head `json:"heads"`
+	}{c.CreatedAt, c.Heads})
+}
+
+// Sign 使用私钥签名
+func (c *Checkpoint) Sign(key ed25519.PrivateKey) error {
+	content, err := c.signedContent()
+	if err != nil {
+		return err
+	}
+	c.PublicKey = base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey))
+	c.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, content))
+	return nil
+}
+
+// Verify 校验签名, trusted 不为空时要求签名公钥与之一致
+func (c *Checkpoint) Verify(trusted ed25519.PublicKey) error {
+	pub, err := base64.StdEncoding.DecodeString(c.PublicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid checkpoint public key")
+	}
+	if trusted != nil && !ed25519.PublicKey(pub).Equal(trusted) {
+		return fmt.Errorf("checkpoint is not signed by the trusted key")
+	}
+	sig, err := base64.StdEncoding.DecodeString(c.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid checkpoint signature")
+	}
+	content, err := c.signedContent()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(pub, content, sig) {
+		return fmt.Errorf("checkpoint signature mismatch")
+	}
+	return nil
+}
+
+// Anchors 检查点中某条链的 序号 -> 哈希
+func Anchors(checkpoints []*Checkpoint, tenantID, logType string) map[int64]string {
+	anchors := make(map[int64]string)
+	for _, cp := range checkpoints {
+		for _, h := range cp.Heads {
+			if h.TenantID == tenantID && h.LogType == logType && h.Seq > 0 {
+				anchors[h.Seq] = h.Hash
+			}
+		}
+	}
+	return anchors
+}
+
+// ParseSigningKey 解析 base64 编码的 ed25519 私钥, 支持32字节种子或64字节私钥
+func ParseSigningKey(s string) (ed25519.PrivateKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key: %w", err)
+	}
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(raw), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(raw), nil
+	}
+	return nil, fmt.Errorf("invalid signing key length %d", len(raw))
+}
+
+// MarshalCheckpoint 检查点导出格式
+func MarshalCheckpoint(c *Checkpoint) ([]byte, error) {
+	return json.MarshalIndent(c, "", "  ")
+}
+
+// UnmarshalCheckpoint 读取导出的检查点
+func UnmarshalCheckpoint(data []byte) (*Checkpoint, error) {
+	var c Checkpoint
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,113 @@
+// Package hashchain 日志哈希链, 每条记录的哈希覆盖上一条记录的哈希和本条记录的规范形式,
+// 修改或删除任一条记录都会使其后的链接校验失败
+package hashchain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// Entry 链上的一条记录
+type Entry struct {
+	ID       int64  // 记录ID, 用于定位
+	Seq      int64  // 链上序号, 从1开始连续递增
+	PrevHash string // 上一条记录的哈希, 第一条为空
+	Hash     string // 本条记录的哈希
+	Content  []byte // 规范形式
+}
+
+// Canonical 记录的规范形式, v 应为字段顺序固定的结构体
+func Canonical(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Hash 计算记录的哈希: sha256(prevHash + "\n" + content), 十六进制
+func Hash(prevHash string, content []byte) string {
+	h := sha256.New()
+	h.Write([]byte(prevHash))
+	h.Write([]byte{'\n'})
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Break 链断裂的位置
+type Break struct {
+	Seq      int64  `json:"seq"`                // 断裂处期望的序号
+	ID       int64  `json:"id,omitempty"`       // 断裂处的记录ID, 记录缺失时为0
+	Reason   string `json:"reason"`             // 断裂原因
+	Expected string `json:"expected,omitempty"` // 期望值
+	Actual   string `json:"actual,omitempty"`   // 实际值
+}
+
+func (b *Break) Error() string {
+	return fmt.Sprintf("hash chain broken at seq %d: %s", b.Seq, b.Reason)
+}
+
+// 断裂原因
+const (
+	ReasonMissing    = "missing"    // 序号不连续, 记录被删除
+	ReasonDuplicate  = "duplicate"  // 序号重复, 记录被复制或插入
+	ReasonPrevHash   = "prev_hash"  // 记录的上一哈希与上一条记录的哈希不一致
+	ReasonHash       = "hash"       // 记录内容与哈希不一致, 记录被修改
+	ReasonCheckpoint = "checkpoint" // 与签名检查点中的哈希不一致, 链被整体重算
+	ReasonHead       = "head"       // 链尾与链头记录的序号或哈希不一致, 末尾记录被删除
+)
+
+// Verifier 按序号顺序校验记录
+type Verifier struct {
+	seq      int64
+	hash     string
+	anchors  map[int64]string
+	verified int64
+}
+
+// NewVerifier 创建校验器, anchors 为检查点中 序号 -> 哈希
+func NewVerifier(anchors map[int64]string) *Verifier {
+	return &Verifier{anchors: anchors}
+}
+
+// Next 校验下一条记录, 返回第一处断裂
+func (v *Verifier) Next(e *Entry) *Break {
+	expected := v.seq + 1
+	if e.Seq < expected {
+		return &Break{Seq: e.Seq, ID: e.ID, Reason: ReasonDuplicate, Expected: fmt.Sprint(expected), Actual: fmt.Sprint(e.Seq)}
+	}
+	if e.Seq != expected {
+		return &Break{Seq: expected, Reason: ReasonMissing, Expected: fmt.Sprint(expected), Actual: fmt.Sprint(e.Seq)}
+	}
+	if e.PrevHash != v.hash {
+		return &Break{Seq: e.Seq, ID: e.ID, Reason: ReasonPrevHash, Expected: v.hash, Actual: e.PrevHash}
+	}
+	if h := Hash(e.PrevHash, e.Content); h != e.Hash {
+		return &Break{Seq: e.Seq, ID: e.ID, Reason: ReasonHash, Expected: h, Actual: e.Hash}
+	}
+	if anchor, ok := v.anchors[e.Seq]; ok && anchor != e.Hash {
+		return &Break{Seq: e.Seq, ID: e.ID, Reason: ReasonCheckpoint, Expected: anchor, Actual: e.Hash}
+	}
+	v.seq, v.hash = e.Seq, e.Hash
+	v.verified++
+	return nil
+}
+
+// Finish 校验链尾是否与链头记录一致
+func (v *Verifier) Finish(headSeq int64, headHash string) *Break {
+	if v.seq != headSeq {
+		return &Break{Seq: v.seq + 1, Reason: ReasonMissing, Expected: fmt.Sprint(headSeq), Actual: fmt.Sprint(v.seq)}
+	}
+	if v.hash != headHash {
+		return &Break{Seq: v.seq, Reason: ReasonHead, Expected: headHash, Actual: v.hash}
+	}
+	return nil
+}
+
+// Verified 已校验通过的记录数
+func (v *Verifier) Verified() int64 {
+	return v.verified
+}
+
+// Expected 下一条记录期望的序号
+func (v *Verifier) Expected() int64 {
+	return v.seq + 1
+}
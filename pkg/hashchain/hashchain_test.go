@@ -0,0 +1,122 @@
+package hashchain
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+)
+
+func buildChain(n int) []*Entry {
+	entries := make([]*Entry, 0, n)
+	prev := ""
+	for i := 1; i <= n; i++ {
+		content := []byte{byte('a' + i)}
+		e := &Entry{ID: int64(100 + i), Seq: int64(i), PrevHash: prev, Content: content}
+		e.Hash = Hash(prev, content)
+		prev = e.Hash
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func verify(entries []*Entry, anchors map[int64]string, headSeq int64, headHash string) *Break {
+	v := NewVerifier(anchors)
+	for _, e := range entries {
+		if b := v.Next(e); b != nil {
+			return b
+		}
+	}
+	return v.Finish(headSeq, headHash)
+}
+
+func TestVerifyIntact(t *testing.T) {
+	entries := buildChain(5)
+	if b := verify(entries, nil, 5, entries[4].Hash); b != nil {
+		t.Fatalf("unexpected break: %v", b)
+	}
+}
+
+func TestVerifyModified(t *testing.T) {
+	entries := buildChain(5)
+	entries[2].Content = []byte("tampered")
+	b := verify(entries, nil, 5, entries[4].Hash)
+	if b == nil || b.Seq != 3 || b.Reason != ReasonHash || b.ID != 103 {
+		t.Fatalf("expected hash break at seq 3, got %v", b)
+	}
+}
+
+func TestVerifyDeleted(t *testing.T) {
+	entries := buildChain(5)
+	b := verify(append(entries[:2], entries[3:]...), nil, 5, entries[4].Hash)
+	if b == nil || b.Seq != 3 || b.Reason != ReasonMissing {
+		t.Fatalf("expected missing break at seq 3, got %v", b)
+	}
+}
+
+func TestVerifyDuplicated(t *testing.T) {
+	entries := buildChain(5)
+	dup := *entries[2]
+	dup.ID = 200
+	b := verify(append(entries[:3:3], append([]*Entry{&dup}, entries[3:]...)...), nil, 5, entries[4].Hash)
+	if b == nil || b.Seq != 3 || b.Reason != ReasonDuplicate || b.ID != 200 {
+		t.Fatalf("expected duplicate break at seq 3, got %v", b)
+	}
+}
+
+func TestVerifyTruncated(t *testing.T) {
+	entries := buildChain(5)
+	b := verify(entries[:4], nil, 5, entries[4].Hash)
+	if b == nil || b.Seq != 5 || b.Reason != ReasonMissing {
+		t.Fatalf("expected missing break at seq 5, got %v", b)
+	}
+}
+
+func TestVerifyRecomputedAgainstCheckpoint(t *testing.T) {
+	entries := buildChain(5)
+	anchors := map[int64]string{3: entries[2].Hash}
+	// 修改第2条后整体重算
+	entries[1].Content = []byte("tampered")
+	prev := entries[0].Hash
+	for _, e := range entries[1:] {
+		e.PrevHash = prev
+		e.Hash = Hash(prev, e.Content)
+		prev = e.Hash
+	}
+	if b := verify(entries, nil, 5, prev); b != nil {
+		t.Fatalf("recomputed chain should be self-consistent: %v", b)
+	}
+	b := verify(entries, anchors, 5, prev)
+	if b == nil || b.Seq != 3 || b.Reason != ReasonCheckpoint {
+		t.Fatalf("expected checkpoint break at seq 3, got %v", b)
+	}
+}
+
+func TestCheckpointSign(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	key, err := ParseSigningKey(base64.StdEncoding.EncodeToString(seed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp := &Checkpoint{CreatedAt: 1700000000, Heads: []*Head{{TenantID: "t1", LogType: "oplog", Seq: 3, Hash: "abc"}}}
+	if err := cp.Sign(key); err != nil {
+		t.Fatal(err)
+	}
+	data, err := MarshalCheckpoint(cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := UnmarshalCheckpoint(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded.Verify(key.Public().(ed25519.PublicKey)); err != nil {
+		t.Fatalf("verify checkpoint: %v", err)
+	}
+	loaded.Heads[0].Hash = "def"
+	if err := loaded.Verify(nil); err == nil {
+		t.Fatal("modified checkpoint should fail verification")
+	}
+	if anchors := Anchors([]*Checkpoint{cp}, "t1", "oplog"); anchors[3] != "abc" {
+		t.Fatalf("unexpected anchors: %v", anchors)
+	}
+}
@@ -27,3 +27,15 @@ func Handler(rootPath string, def language.Tag, tags ...language.Tag) app.Handle
 		}),
 	)
 }
+
+// Translate 按请求语言翻译 key, 未配置翻译或未启用国际化时返回 fallback
+func Translate(ctx context.Context, key, fallback string) string {
+	if key == "" {
+		return fallback
+	}
+	msg, err := hertzI18n.GetMessage(ctx, key)
+	if err != nil || msg == "" {
+		return fallback
+	}
+	return msg
+}
@@ -10,31 +10,38 @@ import (
 	"github.com/casbin/casbin/v2/model"
 )
 
+// CasbinAdapter 加载单个租户的策略
 type CasbinAdapter struct {
 	permRepo IPermissionsRepository
+	tenantID string
+	roles    map[string]string // 已加载的角色ID -> 角色编码
 }
 
-func NewCasbinAdapter(permRepo IPermissionsRepository) *CasbinAdapter {
+func NewCasbinAdapter(permRepo IPermissionsRepository, tenantID string) *CasbinAdapter {
 	return &CasbinAdapter{
 		permRepo: permRepo,
+		tenantID: tenantID,
+		roles:    map[string]string{},
 	}
 }
 
-// LoadPolicy 从数据库加载策略
+// LoadPolicy 从数据库加载租户的策略
 func (a *CasbinAdapter) LoadPolicy(model model.Model) error {
 	ctx := context.Background()
-	roles, err := a.permRepo.FindAllEnabled(ctx)
+	roles, err := a.permRepo.FindEnabledByTenant(ctx, a.tenantID)
 	if err != nil {
 		return err
 	}
 
+	a.roles = make(map[string]string, len(roles))
 	for _, r := range roles {
+		a.roles[r.Id] = r.Code
 		if len(r.Permissions) > 0 {
 			for _, perm := range r.Permissions {
-				// 添加策略: p, roleCode, tenantID, method, path
-				line := fmt.Sprintf("p, %s, %s, %s, %s", r.Code, r.TenantID, perm.Method, perm.Path)
+				// 添加策略: p, roleCode, tenantID, method, path, condition
+				line := fmt.Sprintf("p, %s, %s, %s, %s, %s", r.Code, r.TenantID, perm.Method, perm.Path, perm.Condition)
 				hlog.Debug("Loading policy:", line)
-				err := persist.LoadPolicyArray([]string{"p", r.Code, r.TenantID, perm.Method, perm.Path}, model)
+				err := persist.LoadPolicyArray([]string{"p", r.Code, r.TenantID, perm.Method, perm.Path, perm.Condition}, model)
 				if err != nil {
 					hlog.Errorf("load policy error: %v", err)
 					return err
@@ -0,0 +1,229 @@
+package casbin
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/casbin/govaluate"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// conditionFuncName 模型匹配器中调用的条件函数
+const conditionFuncName = "condMatch"
+
+// 条件表达式可用的变量
+const (
+	VarHour     = "hour"     // 当前小时 0-23
+	VarMinute   = "minute"   // 当前分钟 0-59
+	VarWeekday  = "weekday"  // 星期 0(周日)-6
+	VarDate     = "date"     // 日期 2006-01-02
+	VarClock    = "clock"    // 时间 15:04, 可按字符串比较
+	VarIP       = "ip"       // 客户端IP
+	VarTenant   = "tenant"   // 租户ID
+	VarPlatform = "platform" // 登录平台
+	VarDevice   = "device"   // 设备名称
+	VarMethod   = "method"   // 请求方法
+	VarPath     = "path"     // 请求路径
+)
+
+// 条件表达式可用的租户属性, 由 ITenantAttributeProvider 提供
+const (
+	VarTenantCode      = "tenantCode"      // 租户编码
+	VarTenantName      = "tenantName"      // 租户名称
+	VarTenantDomain    = "tenantDomain"    // 租户自定义域名
+	VarTenantIsolation = "tenantIsolation" // 数据隔离模式
+)
+
+var conditionVars = []string{
+	VarHour, VarMinute, VarWeekday, VarDate, VarClock, VarIP, VarTenant, VarPlatform, VarDevice, VarMethod, VarPath,
+	VarTenantCode, VarTenantName, VarTenantDomain, VarTenantIsolation,
+}
+
+// conditionFunctions 条件表达式可用的函数
+var conditionFunctions = map[string]govaluate.ExpressionFunction{
+	// ipIn(ip, "10.0.0.0/8", "192.168.1.10") IP 是否在任一网段或等于任一地址
+	"ipIn": func(args ...interface{}) (interface{}, error) {
+		if len(args) < 2 {
+			return nil, fmt.Errorf("ipIn requires an ip and at least one cidr")
+		}
+		ip := net.ParseIP(fmt.Sprint(args[0]))
+		for _, arg := range args[1:] {
+			s, ok := arg.(string)
+			if !ok {
+				return nil, fmt.Errorf("ipIn cidr must be a string")
+			}
+			if !strings.Contains(s, "/") {
+				if other := net.ParseIP(s); other == nil {
+					return nil, fmt.Errorf("invalid ip: %s", s)
+				} else if ip != nil && other.Equal(ip) {
+					return true, nil
+				}
+				continue
+			}
+			_, n, err := net.ParseCIDR(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cidr: %s", s)
+			}
+			if ip != nil && n.Contains(ip) {
+				return true, nil
+			}
+		}
+		return false, nil
+	},
+	// hasPrefix(path, "/v1/sys/") 字符串前缀
+	"hasPrefix": func(args ...interface{}) (interface{}, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("hasPrefix requires two arguments")
+		}
+		return strings.HasPrefix(fmt.Sprint(args[0]), fmt.Sprint(args[1])), nil
+	},
+}
+
+// Env 条件表达式的求值环境
+type Env struct {
+	Time     time.Time
+	IP       string
+	TenantID string
+	Tenant   map[string]interface{} // 租户属性, 键为 VarTenantCode 等变量名
+	Platform string
+	Device   string
+	Method   string
+	Path     string
+}
+
+// NewEnv 从请求上下文构建求值环境
+func NewEnv(ctx context.Context, method, path string) *Env {
+	return &Env{
+		Time:     time.Now(),
+		IP:       ctxString(actx.GetIpAddress(ctx)),
+		TenantID: actx.GetTenantId(ctx),
+		Platform: ctxString(actx.GetPlatform(ctx)),
+		Device:   ctxString(actx.GetDeviceName(ctx)),
+		Method:   method,
+		Path:     path,
+	}
+}
+
+// ctxString 上下文中缺失的值会被格式化为 <nil>
+func ctxString(v string) string {
+	if v == "<nil>" {
+		return ""
+	}
+	return v
+}
+
+func (e *Env) params() map[string]interface{} {
+	params := map[string]interface{}{
+		VarHour:     float64(e.Time.Hour()),
+		VarMinute:   float64(e.Time.Minute()),
+		VarWeekday:  float64(e.Time.Weekday()),
+		VarDate:     e.Time.Format("2006-01-02"),
+		VarClock:    e.Time.Format("15:04"),
+		VarIP:       e.IP,
+		VarTenant:   e.TenantID,
+		VarPlatform: e.Platform,
+		VarDevice:   e.Device,
+		VarMethod:   e.Method,
+		VarPath:     e.Path,
+	}
+	// 未加载到的租户属性按空字符串处理
+	for _, v := range []string{VarTenantCode, VarTenantName, VarTenantDomain, VarTenantIsolation} {
+		params[v] = ""
+	}
+	for k, v := range e.Tenant {
+		params[k] = v
+	}
+	return params
+}
+
+// 已编译的条件表达式
+var conditionCache sync.Map
+
+func compileCondition(cond string) (*govaluate.EvaluableExpression, error) {
+	if v, ok := conditionCache.Load(cond); ok {
+		return v.(*govaluate.EvaluableExpression), nil
+	}
+	expr, err := govaluate.NewEvaluableExpressionWithFunctions(cond, conditionFunctions)
+	if err != nil {
+		return nil, err
+	}
+	conditionCache.Store(cond, expr)
+	return expr, nil
+}
+
+// ValidateCondition 校验条件表达式, 只能引用已知变量, 且在示例环境下必须返回布尔值
+func ValidateCondition(cond string) error {
+	cond = strings.TrimSpace(cond)
+	if cond == "" {
+		return nil
+	}
+	expr, err := govaluate.NewEvaluableExpressionWithFunctions(cond, conditionFunctions)
+	if err != nil {
+		return err
+	}
+	allowed := make(map[string]bool, len(conditionVars))
+	for _, v := range conditionVars {
+		allowed[v] = true
+	}
+	for _, v := range expr.Vars() {
+		if !allowed[v] {
+			vars := append([]string{}, conditionVars...)
+			sort.Strings(vars)
+			return fmt.Errorf("unknown variable %q, available: %s", v, strings.Join(vars, ", "))
+		}
+	}
+	sample := &Env{Time: time.Now(), IP: "127.0.0.1"}
+	result, err := expr.Evaluate(sample.params())
+	if err != nil {
+		return err
+	}
+	if _, ok := result.(bool); !ok {
+		return fmt.Errorf("condition must be a boolean expression")
+	}
+	return nil
+}
+
+// EvalCondition 对环境求值条件表达式, 空表达式视为满足
+func EvalCondition(cond string, env *Env) (bool, error) {
+	cond = strings.TrimSpace(cond)
+	if cond == "" {
+		return true, nil
+	}
+	if env == nil {
+		return false, fmt.Errorf("missing condition env")
+	}
+	expr, err := compileCondition(cond)
+	if err != nil {
+		return false, err
+	}
+	result, err := expr.Evaluate(env.params())
+	if err != nil {
+		return false, err
+	}
+	ok, isBool := result.(bool)
+	if !isBool {
+		return false, fmt.Errorf("condition %q is not a boolean expression", cond)
+	}
+	return ok, nil
+}
+
+// conditionMatch 供 casbin 匹配器调用: condMatch(p.cond, r.env), 求值失败视为不满足
+func conditionMatch(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return false, fmt.Errorf("%s requires 2 arguments", conditionFuncName)
+	}
+	cond, _ := args[0].(string)
+	env, _ := args[1].(*Env)
+	ok, err := EvalCondition(cond, env)
+	if err != nil {
+		hlog.Warnf("casbin condition %q eval error: %v", cond, err)
+		return false, nil
+	}
+	return ok, nil
+}
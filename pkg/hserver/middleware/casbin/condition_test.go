@@ -0,0 +1,105 @@
+package casbin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+)
+
+func Test_ValidateCondition(t *testing.T) {
+	valid := []string{
+		"",
+		`ipIn(ip, "10.0.0.0/8", "192.168.1.10")`,
+		`weekday >= 1 && weekday <= 5 && clock >= "09:00" && clock < "18:00"`,
+		`platform IN ("web", "ios") || tenantCode == "demo"`,
+	}
+	for _, c := range valid {
+		if err := ValidateCondition(c); err != nil {
+			t.Errorf("%q: %v", c, err)
+		}
+	}
+	invalid := []string{
+		`hour >`,
+		`unknown == 1`,
+		`hour + 1`,
+		`ipIn(ip, "10.0.0.0/33")`,
+	}
+	for _, c := range invalid {
+		if err := ValidateCondition(c); err == nil {
+			t.Errorf("%q: expected error", c)
+		}
+	}
+}
+
+func Test_EvalCondition(t *testing.T) {
+	env := &Env{
+		Time:     time.Date(2024, 5, 6, 10, 30, 0, 0, time.Local), // 周一
+		IP:       "10.1.2.3",
+		Platform: "web",
+		Tenant:   map[string]interface{}{VarTenantCode: "demo"},
+	}
+	cases := map[string]bool{
+		`ipIn(ip, "10.0.0.0/8")`:                             true,
+		`ipIn(ip, "192.168.0.0/16", "10.1.2.4")`:             false,
+		`weekday == 1 && clock >= "09:00" && hour < 18`:      true,
+		`clock >= "11:00"`:                                   false,
+		`tenantCode == "demo" && platform IN ("web", "ios")`: true,
+		`tenantName == ""`:                                   true,
+	}
+	for c, want := range cases {
+		got, err := EvalCondition(c, env)
+		if err != nil {
+			t.Errorf("%q: %v", c, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%q: got %v, want %v", c, got, want)
+		}
+	}
+}
+
+func Test_ModelCondition(t *testing.T) {
+	conf, err := modelConf.ReadFile("model.conf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := model.NewModelFromString(string(conf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := casbin.NewEnforcer(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.AddFunction(conditionFuncName, conditionMatch)
+	if _, err := e.AddPolicy("admin", "t1", "GET", "/v1/sys/user", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.AddPolicy("admin", "t1", "DELETE", "/v1/sys/user/:id", `ipIn(ip, "10.0.0.0/8")`); err != nil {
+		t.Fatal(err)
+	}
+
+	inside := &Env{Time: time.Now(), IP: "10.0.0.1"}
+	outside := &Env{Time: time.Now(), IP: "8.8.8.8"}
+	cases := []struct {
+		method string
+		path   string
+		env    *Env
+		want   bool
+	}{
+		{"GET", "/v1/sys/user", outside, true},
+		{"DELETE", "/v1/sys/user/1", inside, true},
+		{"DELETE", "/v1/sys/user/1", outside, false},
+	}
+	for _, c := range cases {
+		ok, err := e.Enforce("admin", "t1", c.method, c.path, c.env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != c.want {
+			t.Errorf("%s %s %s: got %v, want %v", c.method, c.path, c.env.IP, ok, c.want)
+		}
+	}
+}
@@ -4,6 +4,8 @@ type ApiPermissions struct {
 	Id     string `json:"id"`
 	Method string `json:"method" ` // HTTP 方法
 	Path   string `json:"path"`    // API 请求路径（例如 /api/v1/users/:id）
+	// Condition 可选的条件表达式, 例如 ipIn(ip, "10.0.0.0/8") && clock >= "09:00"
+	Condition string `json:"condition"`
 }
 
 type Role struct {
@@ -1,6 +1,7 @@
 package casbin
 
 import (
+	"container/list"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -9,137 +10,291 @@ import (
 	"time"
 
 	"github.com/ares-cloud/ares-ddd-admin/pkg/h_redis"
-	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
 	"github.com/cloudwego/hertz/pkg/common/hlog"
 	"github.com/redis/go-redis/v9"
 )
 
 const (
-	// Redis key前缀
-	policyCacheKey = "casbin:policies:"
 	// 权限更新channel
 	policyUpdateChannel = "casbin:policy:update"
-	// 缓存过期时间
-	cacheExpiration = 24 * time.Hour
+	// 租户属性本地缓存时间
+	tenantAttrExpiration = time.Minute
+	// DefaultMaxTenants 默认最多同时加载策略的租户数
+	DefaultMaxTenants = 256
 )
 
+// PolicyUpdate 策略更新消息
+// 租户和角色均为空时重新加载全部租户, 只有租户时重新加载该租户, 有角色时只增量更新该角色的策略
+type PolicyUpdate struct {
+	TenantID string `json:"tenantId,omitempty"`
+	RoleID   string `json:"roleId,omitempty"`
+}
+
+// Enforcer 按租户划分策略, 租户策略在首次访问时加载, 超过上限时淘汰最久未访问的租户
 type Enforcer struct {
-	enforcer *casbin.Enforcer
-	permRepo IPermissionsRepository
-	rdb      *redis.Client
-	mutex    sync.RWMutex
-	basePath string
+	modelText   string
+	permRepo    IPermissionsRepository
+	tenantAttrs ITenantAttributeProvider
+	rdb         *redis.Client
+	basePath    string
+	maxTenants  int
+
+	mutex   sync.Mutex
+	tenants map[string]*list.Element // 租户ID -> lru 元素, 值为 *tenantEnforcer
+	lru     *list.List
+
+	attrCache sync.Map
+}
+
+type tenantAttrEntry struct {
+	attrs    map[string]interface{}
+	expireAt time.Time
 }
 
 // NewEnforcer 创建一个新的enforcer
-func NewEnforcer(permRepo IPermissionsRepository, rdb *h_redis.RedisClient, basePath string) (*Enforcer, error) {
+// tenantAttrs 可为空, 此时条件中的租户属性均为空字符串; maxTenants 不大于0时使用 DefaultMaxTenants
+func NewEnforcer(permRepo IPermissionsRepository, tenantAttrs ITenantAttributeProvider, rdb *h_redis.RedisClient, basePath string, maxTenants int) (*Enforcer, error) {
 	// 从embed读取模型配置
 	modelBytes, err := modelConf.ReadFile("model.conf")
 	if err != nil {
 		return nil, fmt.Errorf("read model config: %w", err)
 	}
+	if maxTenants <= 0 {
+		maxTenants = DefaultMaxTenants
+	}
 
-	// 加载模型
-	m, err := model.NewModelFromString(string(modelBytes))
-	if err != nil {
+	enforcer := &Enforcer{
+		modelText:   string(modelBytes),
+		permRepo:    permRepo,
+		tenantAttrs: tenantAttrs,
+		rdb:         rdb.GetClient(),
+		basePath:    basePath,
+		maxTenants:  maxTenants,
+		tenants:     make(map[string]*list.Element),
+		lru:         list.New(),
+	}
+
+	// 校验模型配置
+	if _, err := model.NewModelFromString(enforcer.modelText); err != nil {
 		return nil, fmt.Errorf("new model: %w", err)
 	}
 
-	// 创建适配器
-	adapter := NewCasbinAdapter(permRepo)
+	// 启动订阅监听
+	go enforcer.subscribeToUpdates()
+
+	return enforcer, nil
+}
 
-	// 创建执行器
-	e, err := casbin.NewEnforcer(m, adapter)
+// Enforce 执行权限检查 roleCode, tenantID, method, path, 带条件的策略按 env 求值
+func (e *Enforcer) Enforce(role string, tenantID string, method string, path string, env *Env) (bool, error) {
+	te, err := e.tenant(tenantID)
 	if err != nil {
-		return nil, fmt.Errorf("new enforcer: %w", err)
+		return false, err
 	}
+	return te.enforce(role, tenantID, method, e.trimPath(path), env)
+}
 
-	enforcer := &Enforcer{
-		enforcer: e,
-		permRepo: permRepo,
-		rdb:      rdb.GetClient(),
-		basePath: basePath,
+// BuildEnv 从请求上下文构建条件求值环境, 仅在租户策略引用租户属性时加载租户属性
+func (e *Enforcer) BuildEnv(ctx context.Context, method, path string) *Env {
+	env := NewEnv(ctx, method, e.trimPath(path))
+	if env.TenantID == "" {
+		return env
+	}
+	te, err := e.tenant(env.TenantID)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "load tenant policy error: %v", err)
+		return env
+	}
+	if te.usesTenantAttrs() {
+		env.Tenant = e.loadTenantAttrs(ctx, env.TenantID)
 	}
+	return env
+}
 
-	// 启动订阅监听
-	go enforcer.subscribeToUpdates()
+func (e *Enforcer) trimPath(path string) string {
+	if e.basePath != "" {
+		return strings.TrimPrefix(path, e.basePath)
+	}
+	return path
+}
 
-	// 初始加载策略
-	if err := enforcer.LoadPolicy(); err != nil {
-		return nil, fmt.Errorf("load policy: %w", err)
+// tenant 获取租户策略, 未加载时从数据库加载
+func (e *Enforcer) tenant(tenantID string) (*tenantEnforcer, error) {
+	e.mutex.Lock()
+	if el, ok := e.tenants[tenantID]; ok {
+		e.lru.MoveToFront(el)
+		e.mutex.Unlock()
+		return el.Value.(*tenantEnforcer).wait()
+	}
+	te := &tenantEnforcer{tenantID: tenantID, ready: make(chan struct{})}
+	e.tenants[tenantID] = e.lru.PushFront(te)
+	// 淘汰最久未访问的租户
+	for e.lru.Len() > e.maxTenants {
+		oldest := e.lru.Back()
+		e.lru.Remove(oldest)
+		delete(e.tenants, oldest.Value.(*tenantEnforcer).tenantID)
 	}
+	e.mutex.Unlock()
 
-	return enforcer, nil
+	loaded, err := newTenantEnforcer(e.modelText, e.permRepo, tenantID)
+	if err == nil {
+		te.enforcer = loaded.enforcer
+		te.roles = loaded.roles
+		te.useTenantAttrs = loaded.useTenantAttrs
+	} else {
+		te.err = fmt.Errorf("load tenant %s policy: %w", tenantID, err)
+		// 加载失败不保留, 下次访问时重试
+		e.evict(tenantID, te)
+	}
+	close(te.ready)
+	return te.wait()
 }
 
-// Enforce 执行权限检查 roleCode, tenantID, method, path
-func (e *Enforcer) Enforce(role string, tenantID string, method string, path string) (bool, error) {
-	e.mutex.RLock()
-	defer e.mutex.RUnlock()
-
-	if e.basePath != "" {
-		path = strings.TrimPrefix(path, e.basePath)
+// evict 移除租户策略, te 不为空时只在当前元素仍为 te 时移除
+func (e *Enforcer) evict(tenantID string, te *tenantEnforcer) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	el, ok := e.tenants[tenantID]
+	if !ok || (te != nil && el.Value.(*tenantEnforcer) != te) {
+		return
 	}
-	return e.enforcer.Enforce(role, tenantID, method, path)
+	e.lru.Remove(el)
+	delete(e.tenants, tenantID)
 }
 
-// LoadPolicy 加载策略(从数据库加载并缓存)
-func (e *Enforcer) LoadPolicy() error {
+// evictLoading 移除正在加载中的租户策略
+func (e *Enforcer) evictLoading(tenantID string) {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
+	el, ok := e.tenants[tenantID]
+	if !ok {
+		return
+	}
+	select {
+	case <-el.Value.(*tenantEnforcer).ready:
+	default:
+		e.lru.Remove(el)
+		delete(e.tenants, tenantID)
+	}
+}
 
-	// 1. 从数据库加载
-	if err := e.enforcer.LoadPolicy(); err != nil {
-		return fmt.Errorf("load from db: %w", err)
+// loaded 返回已加载完成的租户策略
+func (e *Enforcer) loaded() []*tenantEnforcer {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	result := make([]*tenantEnforcer, 0, len(e.tenants))
+	for _, el := range e.tenants {
+		te := el.Value.(*tenantEnforcer)
+		select {
+		case <-te.ready:
+			if te.err == nil {
+				result = append(result, te)
+			}
+		default:
+		}
 	}
+	return result
+}
 
-	// 2. 将策略缓存到Redis
-	ctx := context.Background()
-	if err := e.cachePolicies(ctx); err != nil {
-		hlog.Errorf("cache policies error: %v", err)
+// loadTenantAttrs 加载租户属性, 本地缓存一分钟
+func (e *Enforcer) loadTenantAttrs(ctx context.Context, tenantID string) map[string]interface{} {
+	if e.tenantAttrs == nil {
+		return nil
+	}
+	if v, ok := e.attrCache.Load(tenantID); ok {
+		if entry := v.(*tenantAttrEntry); time.Now().Before(entry.expireAt) {
+			return entry.attrs
+		}
 	}
+	attrs, err := e.tenantAttrs.TenantAttributes(ctx, tenantID)
+	if err != nil {
+		hlog.CtxErrorf(ctx, "load tenant attributes error: %v", err)
+		return nil
+	}
+	e.attrCache.Store(tenantID, &tenantAttrEntry{attrs: attrs, expireAt: time.Now().Add(tenantAttrExpiration)})
+	return attrs
+}
 
+// LoadPolicy 清空已加载的全部租户策略, 各租户在下次访问时重新加载
+func (e *Enforcer) LoadPolicy() error {
+	e.mutex.Lock()
+	e.tenants = make(map[string]*list.Element)
+	e.lru.Init()
+	e.mutex.Unlock()
+	e.attrCache.Range(func(key, _ interface{}) bool {
+		e.attrCache.Delete(key)
+		return true
+	})
 	return nil
 }
 
 // ReloadPolicy 重新加载策略(始终从数据库加载)
 func (e *Enforcer) ReloadPolicy() error {
-	return e.LoadPolicy() // 直接调用LoadPolicy从数据库加载
+	return e.LoadPolicy()
 }
 
-// loadPoliciesFromCache 从缓存加载策略
-func (e *Enforcer) loadPoliciesFromCache(ctx context.Context) ([][]string, error) {
-	data, err := e.rdb.Get(ctx, policyCacheKey).Bytes()
-	if err != nil {
-		return nil, err
-	}
+// ReloadTenant 重新加载租户的全部策略, 未加载的租户在下次访问时加载
+func (e *Enforcer) ReloadTenant(tenantID string) {
+	e.evict(tenantID, nil)
+	e.attrCache.Delete(tenantID)
+}
 
-	var policies [][]string
-	if err := json.Unmarshal(data, &policies); err != nil {
-		return nil, err
+// ReloadRole 增量更新角色的策略, 角色按租户查询, 独立存储的租户从租户库读取
+// tenantID 为空时从共享存储中查找角色所属租户, 并更新已加载该角色的租户
+func (e *Enforcer) ReloadRole(ctx context.Context, tenantID, roleID string) error {
+	if tenantID == "" {
+		role, err := e.permRepo.FindEnabledRole(ctx, "", roleID)
+		if err != nil {
+			return err
+		}
+		if role != nil {
+			tenantID = role.TenantID
+		}
+	}
+	// 正在加载的租户可能读到了更新前的数据, 直接丢弃
+	e.evictLoading(tenantID)
+	for _, te := range e.loaded() {
+		// 角色可能被移出原租户, 已加载该角色的租户都需要更新
+		if te.tenantID != tenantID && !te.hasRole(roleID) {
+			continue
+		}
+		role, err := e.permRepo.FindEnabledRole(ctx, te.tenantID, roleID)
+		if err == nil && role != nil && role.TenantID != te.tenantID {
+			role = nil
+		}
+		if err == nil {
+			err = te.updateRole(roleID, role)
+		}
+		if err != nil {
+			hlog.CtxErrorf(ctx, "update role %s policy of tenant %s error: %v, reload tenant", roleID, te.tenantID, err)
+			e.ReloadTenant(te.tenantID)
+		}
 	}
+	return nil
+}
 
-	return policies, nil
+// PublishUpdate 发布全部策略更新消息, 适用于权限资源变更等影响所有租户的情况
+func (e *Enforcer) PublishUpdate(ctx context.Context) error {
+	return e.publish(ctx, &PolicyUpdate{})
 }
 
-// cachePolicies 缓存策略到Redis
-func (e *Enforcer) cachePolicies(ctx context.Context) error {
-	policies, err := e.enforcer.GetPolicy()
-	if err != nil {
-		return fmt.Errorf("get policy: %w", err)
-	}
-	data, err := json.Marshal(policies)
-	if err != nil {
-		return err
-	}
+// PublishTenantUpdate 发布租户策略更新消息
+func (e *Enforcer) PublishTenantUpdate(ctx context.Context, tenantID string) error {
+	return e.publish(ctx, &PolicyUpdate{TenantID: tenantID})
+}
 
-	return e.rdb.Set(ctx, policyCacheKey, data, cacheExpiration).Err()
+// PublishRoleUpdate 发布角色策略更新消息, tenantID 未知时可为空
+func (e *Enforcer) PublishRoleUpdate(ctx context.Context, tenantID, roleID string) error {
+	return e.publish(ctx, &PolicyUpdate{TenantID: tenantID, RoleID: roleID})
 }
 
-// PublishUpdate 发布策略更新消息
-func (e *Enforcer) PublishUpdate(ctx context.Context) error {
-	return e.rdb.Publish(ctx, policyUpdateChannel, "update").Err()
+func (e *Enforcer) publish(ctx context.Context, msg *PolicyUpdate) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return e.rdb.Publish(ctx, policyUpdateChannel, data).Err()
 }
 
 // subscribeToUpdates 订阅策略更新消息
@@ -154,8 +309,31 @@ func (e *Enforcer) subscribeToUpdates() {
 	}(pubsub)
 
 	ch := pubsub.Channel()
-	for range ch {
-		if err := e.LoadPolicy(); err != nil { // 直接从数据库加载
+	for msg := range ch {
+		e.handleUpdate(ctx, msg.Payload)
+	}
+}
+
+// handleUpdate 处理策略更新消息, 无法解析的消息(包括旧版本的 "update")按全部更新处理
+func (e *Enforcer) handleUpdate(ctx context.Context, payload string) {
+	var update PolicyUpdate
+	if err := json.Unmarshal([]byte(payload), &update); err != nil {
+		update = PolicyUpdate{}
+	}
+	switch {
+	case update.RoleID != "":
+		if err := e.ReloadRole(ctx, update.TenantID, update.RoleID); err != nil {
+			hlog.Errorf("reload role %s policy error: %v", update.RoleID, err)
+			if update.TenantID != "" {
+				e.ReloadTenant(update.TenantID)
+			} else if err := e.LoadPolicy(); err != nil {
+				hlog.Errorf("reload policy error: %v", err)
+			}
+		}
+	case update.TenantID != "":
+		e.ReloadTenant(update.TenantID)
+	default:
+		if err := e.LoadPolicy(); err != nil {
 			hlog.Errorf("reload policy error: %v", err)
 		}
 	}
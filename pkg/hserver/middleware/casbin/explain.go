@@ -0,0 +1,48 @@
+package casbin
+
+import (
+	"github.com/casbin/casbin/v2/util"
+)
+
+// PolicyExplain 与请求匹配的单条策略
+type PolicyExplain struct {
+	Role      string `json:"role"`
+	Tenant    string `json:"tenant"`
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+	Condition string `json:"condition,omitempty"` // 条件表达式, 为空表示无条件
+	Satisfied bool   `json:"satisfied"`           // 条件是否满足
+	Error     string `json:"error,omitempty"`     // 条件求值错误
+}
+
+// Explain 列出角色在租户下与请求方法和路径匹配的策略及条件求值结果
+func (e *Enforcer) Explain(roles []string, tenantID string, method string, path string, env *Env) ([]*PolicyExplain, error) {
+	te, err := e.tenant(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	path = e.trimPath(path)
+	result := make([]*PolicyExplain, 0)
+	for _, role := range roles {
+		policies, err := te.filteredPolicies(0, role, tenantID, method)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range policies {
+			if len(p) < 4 || !util.KeyMatch2(path, p[3]) {
+				continue
+			}
+			item := &PolicyExplain{Role: p[0], Tenant: p[1], Method: p[2], Path: p[3]}
+			if len(p) > 4 {
+				item.Condition = p[4]
+			}
+			ok, err := EvalCondition(item.Condition, env)
+			item.Satisfied = ok
+			if err != nil {
+				item.Error = err.Error()
+			}
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
@@ -3,5 +3,15 @@ package casbin
 import "context"
 
 type IPermissionsRepository interface {
-	FindAllEnabled(context.Context) ([]*Role, error)
+	// FindEnabledByTenant 获取租户下所有启用的角色及其权限
+	FindEnabledByTenant(ctx context.Context, tenantID string) ([]*Role, error)
+	// FindEnabledRole 获取租户下启用的角色及其权限, 角色不存在、已删除或已禁用时返回空
+	// tenantID 为空时只查询共享存储中的角色
+	FindEnabledRole(ctx context.Context, tenantID, roleID string) (*Role, error)
+}
+
+// ITenantAttributeProvider 提供条件表达式中引用的租户属性
+type ITenantAttributeProvider interface {
+	// TenantAttributes 返回以 VarTenantCode 等变量名为键的租户属性
+	TenantAttributes(ctx context.Context, tenantID string) (map[string]interface{}, error)
 }
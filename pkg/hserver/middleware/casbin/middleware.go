@@ -25,8 +25,16 @@ func Handler(enforcer *Enforcer) app.HandlerFunc {
 		if actx.IsSuperAdmin(ctx) {
 			hasPermission = true
 		} else {
+			// 条件表达式的求值环境: 时间、客户端IP、租户属性、登录平台
+			env := enforcer.BuildEnv(ctx, method, path)
+			if env.IP == "" {
+				env.IP = c.ClientIP()
+			}
+			if env.Device == "" {
+				env.Device = c.Request.Header.Get("OS")
+			}
 			for _, role := range roles {
-				allowed, err := enforcer.Enforce(role, tenantID, method, path)
+				allowed, err := enforcer.Enforce(role, tenantID, method, path, env)
 				if err != nil {
 					hlog.CtxErrorf(ctx, "casbin enforce error: %v", err)
 					continue
@@ -0,0 +1,140 @@
+package casbin
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+)
+
+// tenantEnforcer 单个租户的策略
+type tenantEnforcer struct {
+	tenantID string
+	ready    chan struct{} // 加载完成后关闭
+	err      error
+
+	mutex          sync.RWMutex
+	enforcer       *casbin.Enforcer
+	roles          map[string]string // 角色ID -> 角色编码, 用于增量更新
+	useTenantAttrs bool              // 策略条件中是否引用了租户属性
+}
+
+// newTenantEnforcer 从数据库加载租户策略
+func newTenantEnforcer(modelText string, permRepo IPermissionsRepository, tenantID string) (*tenantEnforcer, error) {
+	m, err := model.NewModelFromString(modelText)
+	if err != nil {
+		return nil, err
+	}
+	adapter := NewCasbinAdapter(permRepo, tenantID)
+	e, err := casbin.NewEnforcer(m)
+	if err != nil {
+		return nil, err
+	}
+	// 条件表达式
+	e.AddFunction(conditionFuncName, conditionMatch)
+	// 适配器只读, 增量更新只修改内存中的策略
+	e.SetAdapter(adapter)
+	e.EnableAutoSave(false)
+
+	if err := e.LoadPolicy(); err != nil {
+		return nil, err
+	}
+	return &tenantEnforcer{
+		tenantID:       tenantID,
+		enforcer:       e,
+		roles:          adapter.roles,
+		useTenantAttrs: policiesUseTenantAttrs(e),
+	}, nil
+}
+
+// wait 等待加载完成
+func (t *tenantEnforcer) wait() (*tenantEnforcer, error) {
+	<-t.ready
+	if t.err != nil {
+		return nil, t.err
+	}
+	return t, nil
+}
+
+func (t *tenantEnforcer) enforce(role, tenantID, method, path string, env *Env) (bool, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.enforcer.Enforce(role, tenantID, method, path, env)
+}
+
+func (t *tenantEnforcer) usesTenantAttrs() bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.useTenantAttrs
+}
+
+func (t *tenantEnforcer) hasRole(roleID string) bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	_, ok := t.roles[roleID]
+	return ok
+}
+
+// filteredPolicies 按字段过滤策略
+func (t *tenantEnforcer) filteredPolicies(fieldIndex int, fieldValues ...string) ([][]string, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.enforcer.GetFilteredPolicy(fieldIndex, fieldValues...)
+}
+
+// updateRole 用角色的最新策略替换已加载的策略, role 为空表示角色已删除或禁用
+func (t *tenantEnforcer) updateRole(roleID string, role *Role) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if code, ok := t.roles[roleID]; ok {
+		if _, err := t.enforcer.RemoveFilteredPolicy(0, code); err != nil {
+			return err
+		}
+		delete(t.roles, roleID)
+	}
+	if role != nil {
+		if rules := policyRules(role); len(rules) > 0 {
+			if _, err := t.enforcer.AddPoliciesEx(rules); err != nil {
+				return err
+			}
+		}
+		t.roles[roleID] = role.Code
+	}
+	t.useTenantAttrs = policiesUseTenantAttrs(t.enforcer)
+	return nil
+}
+
+// policyRules 角色的策略: roleCode, tenantID, method, path, condition
+func policyRules(r *Role) [][]string {
+	rules := make([][]string, 0, len(r.Permissions))
+	for _, perm := range r.Permissions {
+		rules = append(rules, []string{r.Code, r.TenantID, perm.Method, perm.Path, perm.Condition})
+	}
+	return rules
+}
+
+// policiesUseTenantAttrs 策略条件中是否引用了租户属性
+func policiesUseTenantAttrs(e *casbin.Enforcer) bool {
+	policies, err := e.GetPolicy()
+	if err != nil {
+		return false
+	}
+	for _, p := range policies {
+		if len(p) < 5 || strings.TrimSpace(p[4]) == "" {
+			continue
+		}
+		expr, err := compileCondition(strings.TrimSpace(p[4]))
+		if err != nil {
+			continue
+		}
+		for _, v := range expr.Vars() {
+			switch v {
+			case VarTenantCode, VarTenantName, VarTenantDomain, VarTenantIsolation:
+				return true
+			}
+		}
+	}
+	return false
+}
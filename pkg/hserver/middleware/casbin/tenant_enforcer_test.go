@@ -0,0 +1,84 @@
+package casbin
+
+import (
+	"context"
+	"testing"
+)
+
+type fakePermRepo struct {
+	roles []*Role
+}
+
+func (f *fakePermRepo) FindEnabledByTenant(_ context.Context, tenantID string) ([]*Role, error) {
+	var result []*Role
+	for _, r := range f.roles {
+		if r.TenantID == tenantID {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakePermRepo) FindEnabledRole(_ context.Context, tenantID, roleID string) (*Role, error) {
+	for _, r := range f.roles {
+		if r.Id == roleID && (tenantID == "" || r.TenantID == tenantID) {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func Test_TenantEnforcerUpdateRole(t *testing.T) {
+	conf, err := modelConf.ReadFile("model.conf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &fakePermRepo{roles: []*Role{
+		{Id: "1", Code: "admin", TenantID: "t1", Permissions: []ApiPermissions{{Method: "GET", Path: "/v1/sys/user"}}},
+		{Id: "2", Code: "admin", TenantID: "t2", Permissions: []ApiPermissions{{Method: "GET", Path: "/v1/sys/role"}}},
+	}}
+	te, err := newTenantEnforcer(string(conf), repo, "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := &Env{}
+	check := func(method, path string, want bool) {
+		t.Helper()
+		ok, err := te.enforce("admin", "t1", method, path, env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != want {
+			t.Errorf("%s %s: got %v, want %v", method, path, ok, want)
+		}
+	}
+	check("GET", "/v1/sys/user", true)
+	check("GET", "/v1/sys/role", false)
+	if !te.hasRole("1") || te.hasRole("2") {
+		t.Fatal("only roles of tenant t1 should be loaded")
+	}
+
+	// 修改权限后增量更新
+	repo.roles[0].Permissions = []ApiPermissions{
+		{Method: "GET", Path: "/v1/sys/role"},
+		{Method: "GET", Path: "/v1/sys/role"},
+		{Method: "DELETE", Path: "/v1/sys/role/:id", Condition: `tenantCode == "demo"`},
+	}
+	if err := te.updateRole("1", repo.roles[0]); err != nil {
+		t.Fatal(err)
+	}
+	check("GET", "/v1/sys/user", false)
+	check("GET", "/v1/sys/role", true)
+	if !te.usesTenantAttrs() {
+		t.Error("condition references tenant attributes")
+	}
+
+	// 角色删除后移除策略
+	if err := te.updateRole("1", nil); err != nil {
+		t.Fatal(err)
+	}
+	check("GET", "/v1/sys/role", false)
+	if te.hasRole("1") || te.usesTenantAttrs() {
+		t.Error("role should be removed")
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/constant"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/hserver/middleware/oplog"
 	"github.com/ares-cloud/ares-ddd-admin/pkg/token"
 
 	"github.com/cloudwego/hertz/pkg/app"
@@ -13,6 +14,7 @@ import (
 
 	"net/http"
 	"strings"
+	"time"
 )
 
 // Handler 校验的处理器
@@ -41,10 +43,37 @@ func Handler(tokenizer token.IToken) app.HandlerFunc {
 			c.Abort()
 			return
 		}
+		// 模拟登录令牌到期后立即失效, 不等待令牌本身过期
+		if accessToken.IsImpersonated() && accessToken.ImpersonateExpiresAt > 0 && time.Now().Unix() > accessToken.ImpersonateExpiresAt {
+			i18Mag := hertzI18n.MustGetMessage(ctx, constant.ReasonTokenVerifyFail)
+			c.JSON(http.StatusOK, utils.H{constant.RespCode: 401, constant.RespMsg: i18Mag, constant.RespReason: constant.ReasonTokenVerifyFail, constant.RespData: utils.H{}})
+			c.Abort()
+			return
+		}
 		accessToken.AccessToken = parts[1]
 		ctx = actx.Store(ctx, accessToken)
 		// 将身份信息缓存到Context
 		c.Set(constant.KeyAccessToken, accessToken)
+		// 模拟登录期间的每个请求都记录操作日志
+		if accessToken.IsImpersonated() {
+			if l := oplog.GetLogger(); l != nil {
+				l.RecordImpersonation(ctx, c)
+				return
+			}
+		}
+		c.Next(ctx)
+	}
+}
+
+// DenyImpersonation 模拟登录期间禁止的敏感操作, 如修改密码、签发令牌
+func DenyImpersonation() app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		if actx.IsImpersonating(ctx) {
+			i18Mag := hertzI18n.MustGetMessage(ctx, constant.ReasonImpersonationForbidden)
+			c.JSON(http.StatusOK, utils.H{constant.RespCode: http.StatusForbidden, constant.RespMsg: i18Mag, constant.RespReason: constant.ReasonImpersonationForbidden, constant.RespData: utils.H{}})
+			c.Abort()
+			return
+		}
 		c.Next(ctx)
 	}
 }
@@ -0,0 +1,219 @@
+package oplog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// ErrQueueFull 队列已满且没有降级写入器, 日志被丢弃
+var ErrQueueFull = errors.New("operation log queue is full")
+
+// ErrWriterClosed 写入器已关闭
+var ErrWriterClosed = errors.New("operation log writer is closed")
+
+// ErrCloseTimeout 关闭时未能在超时前写完队列中的日志
+var ErrCloseTimeout = errors.New("operation log writer close timed out")
+
+const (
+	DefaultQueueSize     = 10000
+	DefaultBatchSize     = 100
+	DefaultFlushInterval = time.Second
+	DefaultCloseTimeout  = 10 * time.Second
+)
+
+// BatchOption 批量写入器选项
+type BatchOption struct {
+	QueueSize     int           // 队列长度, 默认 10000
+	BatchSize     int           // 每批最多写入的条数, 默认 100
+	FlushInterval time.Duration // 未攒满一批时的最长等待时间, 默认 1s
+	CloseTimeout  time.Duration // 关闭时等待写完队列的最长时间, 默认 10s
+	// Fallback 队列已满或批量写入失败时的降级写入器, 如本地文件; 为空时丢弃
+	Fallback LogWriter
+}
+
+// WriterStats 写入器指标
+type WriterStats struct {
+	QueueDepth    int   `json:"queue_depth"`    // 当前队列中的日志数
+	QueueCapacity int   `json:"queue_capacity"` // 队列长度
+	Written       int64 `json:"written"`        // 已写入数据库的日志数
+	Dropped       int64 `json:"dropped"`        // 丢弃的日志数
+	Fallback      int64 `json:"fallback"`       // 写入降级写入器的日志数
+	Failed        int64 `json:"failed"`         // 写入失败的批次数
+}
+
+// IStatsWriter 提供指标的写入器
+type IStatsWriter interface {
+	Stats() WriterStats
+}
+
+// BatchWriter 有界队列的批量写入器, 按条数或时间批量写入数据库, Write 不阻塞请求
+type BatchWriter struct {
+	saver IDbOperationLogWrite
+	opt   BatchOption
+	queue chan *OperationLog
+	done  chan struct{}
+
+	mutex     sync.RWMutex
+	closed    bool
+	closeOnce sync.Once
+	closeErr  error
+
+	written  atomic.Int64
+	dropped  atomic.Int64
+	fallback atomic.Int64
+	failed   atomic.Int64
+}
+
+func NewBatchWriter(saver IDbOperationLogWrite, opt BatchOption) *BatchWriter {
+	if opt.QueueSize <= 0 {
+		opt.QueueSize = DefaultQueueSize
+	}
+	if opt.BatchSize <= 0 {
+		opt.BatchSize = DefaultBatchSize
+	}
+	if opt.FlushInterval <= 0 {
+		opt.FlushInterval = DefaultFlushInterval
+	}
+	if opt.CloseTimeout <= 0 {
+		opt.CloseTimeout = DefaultCloseTimeout
+	}
+	w := &BatchWriter{
+		saver: saver,
+		opt:   opt,
+		queue: make(chan *OperationLog, opt.QueueSize),
+		done:  make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Write 放入队列, 队列已满时写入降级写入器或丢弃; 关闭后降级写入器也已关闭, 直接丢弃
+func (w *BatchWriter) Write(ctx context.Context, log *OperationLog) error {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	if w.closed {
+		w.dropped.Add(1)
+		return ErrWriterClosed
+	}
+	select {
+	case w.queue <- log:
+		return nil
+	default:
+		return w.overflow(ctx, []*OperationLog{log}, ErrQueueFull)
+	}
+}
+
+// overflow 写入降级写入器, 没有降级写入器或写入失败时计为丢弃
+func (w *BatchWriter) overflow(ctx context.Context, logs []*OperationLog, reason error) error {
+	if w.opt.Fallback == nil {
+		w.dropped.Add(int64(len(logs)))
+		return reason
+	}
+	var lastErr error
+	for _, log := range logs {
+		if err := w.opt.Fallback.Write(ctx, log); err != nil {
+			w.dropped.Add(1)
+			lastErr = err
+			continue
+		}
+		w.fallback.Add(1)
+	}
+	return lastErr
+}
+
+func (w *BatchWriter) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.opt.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*OperationLog, 0, w.opt.BatchSize)
+	for {
+		select {
+		case log, ok := <-w.queue:
+			if !ok {
+				w.flush(batch)
+				return
+			}
+			batch = append(batch, log)
+			if len(batch) >= w.opt.BatchSize {
+				w.flush(batch)
+				batch = make([]*OperationLog, 0, w.opt.BatchSize)
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				w.flush(batch)
+				batch = make([]*OperationLog, 0, w.opt.BatchSize)
+			}
+		}
+	}
+}
+
+func (w *BatchWriter) flush(batch []*OperationLog) {
+	if len(batch) == 0 {
+		return
+	}
+	ctx := context.Background()
+	if err := w.saver.SaveBatch(ctx, batch); err != nil {
+		w.failed.Add(1)
+		// 部分写入失败时只有未写入的日志写入降级写入器, 避免重复
+		failed := batch
+		var batchErr *BatchError
+		if errors.As(err, &batchErr) {
+			failed = batchErr.Failed
+		}
+		w.written.Add(int64(len(batch) - len(failed)))
+		hlog.Errorf("write %d of %d operation logs error: %v", len(failed), len(batch), err)
+		if err := w.overflow(ctx, failed, err); err != nil {
+			hlog.Errorf("write operation logs to fallback error: %v", err)
+		}
+		return
+	}
+	w.written.Add(int64(len(batch)))
+}
+
+// Close 停止接收新日志, 写完队列中剩余的日志后返回, 最多等待 CloseTimeout
+func (w *BatchWriter) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), w.opt.CloseTimeout)
+	defer cancel()
+	return w.Shutdown(ctx)
+}
+
+// Shutdown 停止接收新日志, 写完队列中剩余的日志后返回
+// ctx 结束时仍未写完则返回 ErrCloseTimeout, 后台仍在写入, 降级写入器不关闭
+func (w *BatchWriter) Shutdown(ctx context.Context) error {
+	w.mutex.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mutex.Unlock()
+
+	select {
+	case <-w.done:
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %d logs still queued", ErrCloseTimeout, len(w.queue))
+	}
+	w.closeOnce.Do(func() {
+		if w.opt.Fallback != nil {
+			w.closeErr = w.opt.Fallback.Close()
+		}
+	})
+	return w.closeErr
+}
+
+func (w *BatchWriter) Stats() WriterStats {
+	return WriterStats{
+		QueueDepth:    len(w.queue),
+		QueueCapacity: cap(w.queue),
+		Written:       w.written.Load(),
+		Dropped:       w.dropped.Load(),
+		Fallback:      w.fallback.Load(),
+		Failed:        w.failed.Load(),
+	}
+}
@@ -0,0 +1,189 @@
+package oplog
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type memSaver struct {
+	mutex   sync.Mutex
+	batches [][]*OperationLog
+	block   chan struct{}
+	err     error
+	fail    func(log *OperationLog) bool
+}
+
+func (s *memSaver) Save(ctx context.Context, data *OperationLog) error {
+	return s.SaveBatch(ctx, []*OperationLog{data})
+}
+
+func (s *memSaver) SaveBatch(ctx context.Context, data []*OperationLog) error {
+	if s.block != nil {
+		<-s.block
+	}
+	if s.err != nil {
+		return s.err
+	}
+	if s.fail != nil {
+		// 部分写入失败, 返回未写入的日志
+		var failed []*OperationLog
+		for _, log := range data {
+			if s.fail(log) {
+				failed = append(failed, log)
+			}
+		}
+		if len(failed) > 0 {
+			return &BatchError{Failed: failed, Err: errors.New("tenant db down")}
+		}
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.batches = append(s.batches, data)
+	return nil
+}
+
+func (s *memSaver) total() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	n := 0
+	for _, b := range s.batches {
+		n += len(b)
+	}
+	return n
+}
+
+type memWriter struct {
+	mutex sync.Mutex
+	logs  []*OperationLog
+}
+
+func (w *memWriter) Write(ctx context.Context, log *OperationLog) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.logs = append(w.logs, log)
+	return nil
+}
+
+func (w *memWriter) Close() error { return nil }
+
+func TestBatchWriterFlushBySize(t *testing.T) {
+	saver := &memSaver{}
+	w := NewBatchWriter(saver, BatchOption{QueueSize: 10, BatchSize: 3, FlushInterval: time.Hour})
+	for i := 0; i < 6; i++ {
+		if err := w.Write(context.Background(), &OperationLog{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deadline := time.Now().Add(time.Second)
+	for saver.total() < 6 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if saver.total() != 6 || len(saver.batches) != 2 {
+		t.Fatalf("expected 2 batches of 3, got %d logs in %d batches", saver.total(), len(saver.batches))
+	}
+	_ = w.Close()
+}
+
+func TestBatchWriterFlushByInterval(t *testing.T) {
+	saver := &memSaver{}
+	w := NewBatchWriter(saver, BatchOption{QueueSize: 10, BatchSize: 100, FlushInterval: 10 * time.Millisecond})
+	_ = w.Write(context.Background(), &OperationLog{})
+	deadline := time.Now().Add(time.Second)
+	for saver.total() < 1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if saver.total() != 1 {
+		t.Fatal("expected partial batch to be flushed by interval")
+	}
+	_ = w.Close()
+}
+
+func TestBatchWriterQueueFull(t *testing.T) {
+	saver := &memSaver{block: make(chan struct{})}
+	w := NewBatchWriter(saver, BatchOption{QueueSize: 1, BatchSize: 1, FlushInterval: time.Hour})
+	// 第一条被取出后阻塞在写库, 第二条占满队列
+	_ = w.Write(context.Background(), &OperationLog{})
+	deadline := time.Now().Add(time.Second)
+	for len(w.queue) > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	_ = w.Write(context.Background(), &OperationLog{})
+	if err := w.Write(context.Background(), &OperationLog{}); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+	if s := w.Stats(); s.Dropped != 1 || s.QueueDepth != 1 || s.QueueCapacity != 1 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+	close(saver.block)
+	_ = w.Close()
+	if s := w.Stats(); s.Written != 2 {
+		t.Fatalf("expected queued logs to be flushed on close, got %+v", s)
+	}
+}
+
+func TestBatchWriterFallback(t *testing.T) {
+	fallback := &memWriter{}
+	saver := &memSaver{err: errors.New("db down")}
+	w := NewBatchWriter(saver, BatchOption{QueueSize: 10, BatchSize: 2, FlushInterval: time.Hour, Fallback: fallback})
+	_ = w.Write(context.Background(), &OperationLog{})
+	_ = w.Write(context.Background(), &OperationLog{})
+	_ = w.Close()
+	// 关闭后降级写入器也已关闭, 不再写入
+	if err := w.Write(context.Background(), &OperationLog{}); !errors.Is(err, ErrWriterClosed) {
+		t.Fatalf("expected ErrWriterClosed after close, got %v", err)
+	}
+	if s := w.Stats(); s.Failed != 1 || s.Fallback != 2 || s.Dropped != 1 || len(fallback.logs) != 2 {
+		t.Fatalf("unexpected stats: %+v, fallback %d", s, len(fallback.logs))
+	}
+}
+
+type closeCounter struct {
+	memWriter
+	closed int
+}
+
+func (w *closeCounter) Close() error {
+	w.closed++
+	return nil
+}
+
+func TestBatchWriterCloseTimeout(t *testing.T) {
+	fallback := &closeCounter{}
+	saver := &memSaver{block: make(chan struct{})}
+	w := NewBatchWriter(saver, BatchOption{QueueSize: 10, BatchSize: 1, FlushInterval: time.Hour,
+		CloseTimeout: 20 * time.Millisecond, Fallback: fallback})
+	_ = w.Write(context.Background(), &OperationLog{})
+	_ = w.Write(context.Background(), &OperationLog{})
+	if err := w.Close(); !errors.Is(err, ErrCloseTimeout) {
+		t.Fatalf("expected ErrCloseTimeout while the database is blocked, got %v", err)
+	}
+	if fallback.closed != 0 {
+		t.Fatal("fallback must stay open while logs are still being written")
+	}
+	close(saver.block)
+	if err := w.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if s := w.Stats(); s.Written != 2 || fallback.closed != 1 {
+		t.Fatalf("unexpected stats: %+v, fallback closed %d", s, fallback.closed)
+	}
+}
+
+func TestBatchWriterPartialFailure(t *testing.T) {
+	fallback := &memWriter{}
+	saver := &memSaver{fail: func(log *OperationLog) bool { return log.TenantID == "2" }}
+	w := NewBatchWriter(saver, BatchOption{QueueSize: 10, BatchSize: 3, FlushInterval: time.Hour, Fallback: fallback})
+	for _, tenantID := range []string{"1", "2", "1"} {
+		_ = w.Write(context.Background(), &OperationLog{TenantID: tenantID})
+	}
+	_ = w.Close()
+	if s := w.Stats(); s.Failed != 1 || s.Written != 2 || s.Fallback != 1 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+	if len(fallback.logs) != 1 || fallback.logs[0].TenantID != "2" {
+		t.Fatalf("expected only the failed log to fall back, got %d", len(fallback.logs))
+	}
+}
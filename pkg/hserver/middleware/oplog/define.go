@@ -8,6 +8,7 @@ import (
 // OperationLog 操作日志结构
 type OperationLog struct {
 	ID        int64     `json:"id"`
+	RequestID string    `json:"request_id"` // 请求ID, 关联数据变更记录
 	UserID    string    `json:"user_id"`    // 操作人ID
 	Username  string    `json:"username"`   // 操作人用户名
 	TenantID  string    `json:"tenant_id"`  // 租户ID
@@ -23,6 +24,9 @@ type OperationLog struct {
 	CreatedAt time.Time `json:"created_at"` // 创建时间
 	Module    string    `json:"module"`     // 模块名称
 	Action    string    `json:"action"`     // 操作类型
+	// 模拟登录期间 UserID/Username 为实际操作的管理员, 以下为被模拟的用户
+	ImpersonatedUserID   string `json:"impersonated_user_id,omitempty"`
+	ImpersonatedUsername string `json:"impersonated_username,omitempty"`
 }
 
 // LogOption 日志选项
@@ -34,4 +38,20 @@ type LogOption struct {
 
 type IDbOperationLogWrite interface {
 	Save(ctx context.Context, data *OperationLog) error
+	// SaveBatch 批量写入, 供 BatchWriter 使用; 部分写入失败时返回 *BatchError
+	SaveBatch(ctx context.Context, data []*OperationLog) error
+}
+
+// BatchError 批量写入部分失败, 只有 Failed 中的日志未写入
+type BatchError struct {
+	Failed []*OperationLog
+	Err    error
+}
+
+func (e *BatchError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
 }
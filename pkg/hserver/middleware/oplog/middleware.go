@@ -9,57 +9,110 @@ import (
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/common/hlog"
 	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/google/uuid"
 )
 
+// HeaderRequestID 响应头中的请求ID
+const HeaderRequestID = "X-Request-Id"
+
+// keyOption 模拟登录请求中路由声明的日志选项
+const keyOption = "oplog_option"
+
+// impersonationOption 模拟登录期间未声明日志选项的请求
+var impersonationOption = LogOption{
+	IncludeBody: true,
+	Module:      "模拟登录",
+	Action:      "访问",
+}
+
 // Record 记录操作日志的中间件
 func (l *Logger) Record(opt LogOption) app.HandlerFunc {
 	return func(ctx context.Context, c *app.RequestContext) {
-		startTime := time.Now()
-
-		// 获取请求信息
-		log := &OperationLog{
-			UserID:    actx.GetUserId(ctx),
-			Username:  actx.GetUsername(ctx),
-			TenantID:  actx.GetTenantId(ctx),
-			Method:    string(c.Request.Method()),
-			Path:      string(c.Request.URI().Path()),
-			Query:     string(c.Request.URI().QueryString()),
-			IP:        c.ClientIP(),
-			UserAgent: string(c.Request.Header.UserAgent()),
-			CreatedAt: startTime,
-			Module:    opt.Module,
-			Action:    opt.Action,
+		// 模拟登录的请求已由 RecordImpersonation 统一记录, 这里只补充模块和操作
+		if actx.IsImpersonating(ctx) {
+			c.Set(keyOption, opt)
+			c.Next(ctx)
+			return
 		}
+		log := newLog(ctx, c, opt)
+		c.Next(actx.WithRequestId(ctx, log.RequestID))
+		l.write(c, log)
+	}
+}
 
-		// 根据选项记录请求体
-		if opt.IncludeBody {
-			log.Body = string(c.Request.Body())
+// RecordImpersonation 记录模拟登录期间的每个请求, 操作人为实际操作的管理员
+// 由鉴权中间件在识别出模拟登录令牌后调用, 调用后不需要再执行 c.Next
+func (l *Logger) RecordImpersonation(ctx context.Context, c *app.RequestContext) {
+	log := newLog(ctx, c, impersonationOption)
+	c.Next(actx.WithRequestId(ctx, log.RequestID))
+	if v, ok := c.Get(keyOption); ok {
+		if opt, ok := v.(LogOption); ok {
+			log.Module = opt.Module
+			log.Action = opt.Action
 		}
+	}
+	l.write(c, log)
+}
 
-		// 处理请求
-		c.Next(ctx)
+func newLog(ctx context.Context, c *app.RequestContext, opt LogOption) *OperationLog {
+	// 获取请求信息
+	log := &OperationLog{
+		RequestID: requestID(ctx, c),
+		UserID:    actx.GetUserId(ctx),
+		Username:  actx.GetUsername(ctx),
+		TenantID:  actx.GetTenantId(ctx),
+		Method:    string(c.Request.Method()),
+		Path:      string(c.Request.URI().Path()),
+		Query:     string(c.Request.URI().QueryString()),
+		IP:        c.ClientIP(),
+		UserAgent: string(c.Request.Header.UserAgent()),
+		CreatedAt: time.Now(),
+		Module:    opt.Module,
+		Action:    opt.Action,
+	}
+	if actx.IsImpersonating(ctx) {
+		log.ImpersonatedUserID = log.UserID
+		log.ImpersonatedUsername = log.Username
+		log.UserID = actx.GetActorId(ctx)
+		log.Username = actx.GetActorName(ctx)
+	}
 
-		// 记录响应信息
-		log.Duration = time.Since(startTime).Milliseconds()
-		log.Status = c.Response.StatusCode()
+	// 根据选项记录请求体
+	if opt.IncludeBody {
+		log.Body = string(c.Request.Body())
+	}
+	return log
+}
 
-		// 记录错误信息
-		if c.Response.StatusCode() != consts.StatusOK {
-			var resp struct {
-				Code    int    `json:"code"`
-				Message string `json:"message"`
-				Reason  string `json:"reason"`
-			}
-			if err := json.Unmarshal(c.Response.Body(), &resp); err == nil {
-				log.Error = resp.Message
-			}
+// requestID 请求ID, 写入响应头便于前端按请求查询数据变更
+func requestID(ctx context.Context, c *app.RequestContext) string {
+	id := actx.GetRequestId(ctx)
+	if id == "" {
+		id = uuid.NewString()
+	}
+	c.Response.Header.Set(HeaderRequestID, id)
+	return id
+}
+
+func (l *Logger) write(c *app.RequestContext, log *OperationLog) {
+	// 记录响应信息
+	log.Duration = time.Since(log.CreatedAt).Milliseconds()
+	log.Status = c.Response.StatusCode()
+
+	// 记录错误信息
+	if c.Response.StatusCode() != consts.StatusOK {
+		var resp struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+			Reason  string `json:"reason"`
 		}
+		if err := json.Unmarshal(c.Response.Body(), &resp); err == nil {
+			log.Error = resp.Message
+		}
+	}
 
-		// 异步写入日志
-		go func() {
-			if err := l.writer.Write(context.Background(), log); err != nil {
-				hlog.Errorf("write operation log error: %v", err)
-			}
-		}()
+	// 写入器负责异步写入, 不阻塞请求
+	if err := l.writer.Write(context.Background(), log); err != nil {
+		hlog.Errorf("write operation log error: %v", err)
 	}
 }
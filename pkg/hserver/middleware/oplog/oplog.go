@@ -37,3 +37,16 @@ func Record(opt LogOption) app.HandlerFunc {
 	}
 	return defaultLogger.Record(opt)
 }
+
+// Stats 写入器指标, 写入器不提供指标时返回 false
+func (l *Logger) Stats() (WriterStats, bool) {
+	if sw, ok := l.writer.(IStatsWriter); ok {
+		return sw.Stats(), true
+	}
+	return WriterStats{}, false
+}
+
+// Close 关闭写入器, 写完已接收的日志
+func (l *Logger) Close() error {
+	return l.writer.Close()
+}
@@ -44,8 +44,9 @@ func (w *FileWriter) Write(ctx context.Context, log *OperationLog) error {
 	}
 
 	// 格式化日志内容
-	logContent := fmt.Sprintf("[%s] UserID:%s Username:%s TenantID:%s Module:%s Action:%s Method:%s Path:%s Query:%s IP:%s UA:%s Status:%d Duration:%dms Body:%s Error:%s\n",
+	logContent := fmt.Sprintf("[%s] RequestID:%s UserID:%s Username:%s TenantID:%s Module:%s Action:%s Method:%s Path:%s Query:%s IP:%s UA:%s Status:%d Duration:%dms Body:%s Error:%s Impersonated:%s\n",
 		log.CreatedAt.Format("2006-01-02 15:04:05"),
+		log.RequestID,
 		log.UserID,
 		log.Username,
 		log.TenantID,
@@ -60,6 +61,7 @@ func (w *FileWriter) Write(ctx context.Context, log *OperationLog) error {
 		log.Duration,
 		log.Body,
 		log.Error,
+		log.ImpersonatedUserID,
 	)
 
 	_, err := w.file.WriteString(logContent)
@@ -67,6 +69,9 @@ func (w *FileWriter) Write(ctx context.Context, log *OperationLog) error {
 }
 
 func (w *FileWriter) rotateFile(date string) error {
+	if err := os.MkdirAll(w.logDir, 0o755); err != nil {
+		return err
+	}
 	logFile := filepath.Join(w.logDir, fmt.Sprintf("oplog_%s.log", date))
 	f, err := os.OpenFile(logFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
 	if err != nil {
@@ -78,8 +83,12 @@ func (w *FileWriter) rotateFile(date string) error {
 }
 
 func (w *FileWriter) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
 	if w.file != nil {
-		return w.file.Close()
+		err := w.file.Close()
+		w.file = nil
+		return err
 	}
 	return nil
 }
@@ -16,8 +16,16 @@ import (
 )
 
 // PreventSQLInjection 中间件函数
-func PreventSQLInjection() app.HandlerFunc {
+// skipPaths 为不过滤的路径前缀, 例如 SCIM 的 filter 参数本身包含引号等字符, 由接口自行解析并参数化查询
+func PreventSQLInjection(skipPaths ...string) app.HandlerFunc {
 	return func(ctx context.Context, c *app.RequestContext) {
+		path := string(c.Path())
+		for _, prefix := range skipPaths {
+			if strings.HasPrefix(path, prefix) {
+				c.Next(ctx)
+				return
+			}
+		}
 		// 过滤查询参数
 		c.QueryArgs().VisitAll(func(key, value []byte) {
 			if isSQLInjection(string(value)) {
@@ -0,0 +1,126 @@
+package tenant
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultResolveCacheTTL  = 30 * time.Second
+	DefaultResolveCacheSize = 4096
+)
+
+// CachedResolver 带过期时间的租户解析缓存, 未解析到的结果同样缓存, 避免任意 Host 请求逐个查库
+// 租户变更、锁定时调用 Evict 清除, 其他实例依赖过期时间
+type CachedResolver struct {
+	resolver ITenantResolver
+	ttl      time.Duration
+	size     int
+	now      func() time.Time
+
+	mutex   sync.Mutex
+	entries map[string]*list.Element // 缓存键 -> lru 元素, 值为 *cacheEntry
+	lru     *list.List
+}
+
+type cacheEntry struct {
+	key      string
+	info     *TenantInfo
+	expireAt time.Time
+}
+
+// NewCachedResolver ttl、size 不大于0时使用默认值
+func NewCachedResolver(resolver ITenantResolver, ttl time.Duration, size int) *CachedResolver {
+	if ttl <= 0 {
+		ttl = DefaultResolveCacheTTL
+	}
+	if size <= 0 {
+		size = DefaultResolveCacheSize
+	}
+	return &CachedResolver{
+		resolver: resolver,
+		ttl:      ttl,
+		size:     size,
+		now:      time.Now,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// ResolveByCode 根据租户编码解析租户
+func (r *CachedResolver) ResolveByCode(ctx context.Context, code string) (*TenantInfo, error) {
+	return r.load("code:"+code, func() (*TenantInfo, error) {
+		return r.resolver.ResolveByCode(ctx, code)
+	})
+}
+
+// ResolveByDomain 根据自定义域名解析租户
+func (r *CachedResolver) ResolveByDomain(ctx context.Context, domain string) (*TenantInfo, error) {
+	return r.load("domain:"+domain, func() (*TenantInfo, error) {
+		return r.resolver.ResolveByDomain(ctx, domain)
+	})
+}
+
+func (r *CachedResolver) load(key string, fn func() (*TenantInfo, error)) (*TenantInfo, error) {
+	if info, ok := r.get(key); ok {
+		return info, nil
+	}
+	info, err := fn()
+	if err != nil {
+		// 查询失败不缓存
+		return nil, err
+	}
+	r.set(key, info)
+	return info, nil
+}
+
+func (r *CachedResolver) get(key string) (*TenantInfo, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	el, ok := r.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if r.now().After(entry.expireAt) {
+		r.remove(el)
+		return nil, false
+	}
+	r.lru.MoveToFront(el)
+	return entry.info, true
+}
+
+func (r *CachedResolver) set(key string, info *TenantInfo) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	entry := &cacheEntry{key: key, info: info, expireAt: r.now().Add(r.ttl)}
+	if el, ok := r.entries[key]; ok {
+		el.Value = entry
+		r.lru.MoveToFront(el)
+		return
+	}
+	r.entries[key] = r.lru.PushFront(entry)
+	for r.lru.Len() > r.size {
+		r.remove(r.lru.Back())
+	}
+}
+
+func (r *CachedResolver) remove(el *list.Element) {
+	r.lru.Remove(el)
+	delete(r.entries, el.Value.(*cacheEntry).key)
+}
+
+// Evict 清除租户的缓存, 同时清除未解析到的结果, 新建租户或修改编码、域名后可立即解析
+func (r *CachedResolver) Evict(tenantID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for el := r.lru.Front(); el != nil; {
+		next := el.Next()
+		if info := el.Value.(*cacheEntry).info; info == nil || info.ID == tenantID {
+			r.remove(el)
+		}
+		el = next
+	}
+}
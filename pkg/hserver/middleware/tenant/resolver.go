@@ -0,0 +1,41 @@
+package tenant
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// DefaultTenantHeader 默认的租户编码请求头
+	DefaultTenantHeader = "X-Tenant-Code"
+)
+
+// TenantInfo 租户解析结果
+type TenantInfo struct {
+	ID         string // 租户ID
+	Code       string // 租户编码
+	Name       string // 租户名称
+	Locked     bool   // 是否被锁定
+	LockReason string // 锁定原因
+	ExpireTime int64  // 过期时间
+}
+
+// IsExpired 是否已过期
+func (t *TenantInfo) IsExpired() bool {
+	return t.ExpireTime > 0 && time.Now().Unix() > t.ExpireTime
+}
+
+// ITenantResolver 租户解析器
+type ITenantResolver interface {
+	// ResolveByCode 根据租户编码解析租户,不存在时返回 nil
+	ResolveByCode(ctx context.Context, code string) (*TenantInfo, error)
+	// ResolveByDomain 根据自定义域名解析租户,不存在时返回 nil
+	ResolveByDomain(ctx context.Context, domain string) (*TenantInfo, error)
+}
+
+// ResolveConfig 租户解析配置
+type ResolveConfig struct {
+	Header     string // 租户编码请求头, 为空时使用 DefaultTenantHeader
+	BaseDomain string // 平台主域名, 例如 our-saas.com, <code>.our-saas.com 解析为租户编码
+	Required   bool   // 是否必须解析出租户
+}
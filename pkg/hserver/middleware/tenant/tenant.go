@@ -2,9 +2,16 @@ package tenant
 
 import (
 	"context"
+	"net"
+	"net/http"
+	"strings"
 
 	"github.com/ares-cloud/ares-ddd-admin/pkg/actx"
+	"github.com/ares-cloud/ares-ddd-admin/pkg/constant"
 	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/hertz/pkg/common/utils"
+	hertzI18n "github.com/hertz-contrib/i18n"
 )
 
 // IgnoreTenantHandler 租户处理
@@ -14,3 +21,81 @@ func IgnoreTenantHandler() app.HandlerFunc {
 		c.Next(ctx)
 	}
 }
+
+// ResolveHandler 根据请求头或请求域名解析租户
+// 优先使用租户编码请求头, 其次匹配租户自定义域名, 最后匹配 <code>.BaseDomain 形式的子域名
+func ResolveHandler(resolver ITenantResolver, conf *ResolveConfig) app.HandlerFunc {
+	if conf == nil {
+		conf = &ResolveConfig{}
+	}
+	header := conf.Header
+	if header == "" {
+		header = DefaultTenantHeader
+	}
+	baseDomain := strings.ToLower(strings.TrimPrefix(conf.BaseDomain, "."))
+	return func(ctx context.Context, c *app.RequestContext) {
+		info, err := resolve(ctx, resolver, c.Request.Header.Get(header), string(c.Host()), baseDomain)
+		if err != nil {
+			hlog.CtxErrorf(ctx, "resolve tenant error: %v", err)
+			abort(ctx, c, http.StatusInternalServerError, constant.ReasonTenantNotResolved)
+			return
+		}
+		if info == nil {
+			if conf.Required {
+				abort(ctx, c, http.StatusBadRequest, constant.ReasonTenantNotResolved)
+				return
+			}
+			c.Next(ctx)
+			return
+		}
+		if info.Locked {
+			hlog.CtxInfof(ctx, "tenant %s is locked: %s", info.Code, info.LockReason)
+			abort(ctx, c, http.StatusForbidden, constant.ReasonTenantLocked)
+			return
+		}
+		if info.IsExpired() {
+			abort(ctx, c, http.StatusForbidden, constant.ReasonTenantExpired)
+			return
+		}
+		ctx = actx.WithTenantId(ctx, info.ID)
+		c.Next(ctx)
+	}
+}
+
+// resolve 解析租户
+func resolve(ctx context.Context, resolver ITenantResolver, code, host, baseDomain string) (*TenantInfo, error) {
+	if code = strings.TrimSpace(code); code != "" {
+		return resolver.ResolveByCode(ctx, code)
+	}
+	host = stripPort(strings.ToLower(host))
+	if host == "" || host == baseDomain {
+		return nil, nil
+	}
+	info, err := resolver.ResolveByDomain(ctx, host)
+	if err != nil || info != nil {
+		return info, err
+	}
+	if baseDomain != "" && strings.HasSuffix(host, "."+baseDomain) {
+		sub := strings.TrimSuffix(host, "."+baseDomain)
+		// 只取一级子域名作为租户编码
+		if sub != "" && !strings.Contains(sub, ".") {
+			return resolver.ResolveByCode(ctx, sub)
+		}
+	}
+	return nil, nil
+}
+
+// stripPort 去掉host中的端口、IPv6 地址的方括号和末尾的点
+func stripPort(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	return strings.TrimSuffix(host, ".")
+}
+
+func abort(ctx context.Context, c *app.RequestContext, code int, reason string) {
+	i18Mag := hertzI18n.MustGetMessage(ctx, reason)
+	c.JSON(http.StatusOK, utils.H{constant.RespCode: code, constant.RespMsg: i18Mag, constant.RespReason: reason, constant.RespData: utils.H{}})
+	c.Abort()
+}
@@ -0,0 +1,128 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type fakeResolver struct {
+	codes   map[string]*TenantInfo
+	domains map[string]*TenantInfo
+	calls   int
+}
+
+func (f *fakeResolver) ResolveByCode(_ context.Context, code string) (*TenantInfo, error) {
+	f.calls++
+	return f.codes[code], nil
+}
+
+func (f *fakeResolver) ResolveByDomain(_ context.Context, domain string) (*TenantInfo, error) {
+	f.calls++
+	return f.domains[domain], nil
+}
+
+func Test_StripPort(t *testing.T) {
+	cases := []struct {
+		host string
+		want string
+	}{
+		{"acme.example.com", "acme.example.com"},
+		{"acme.example.com:8080", "acme.example.com"},
+		{"acme.example.com.", "acme.example.com"},
+		{"127.0.0.1:80", "127.0.0.1"},
+		{"[::1]:8080", "::1"},
+		{"[::1]", "::1"},
+		{"::1", "::1"},
+		{"", ""},
+	}
+	for _, c := range cases {
+		if got := stripPort(c.host); got != c.want {
+			t.Errorf("stripPort(%q) = %q, want %q", c.host, got, c.want)
+		}
+	}
+}
+
+func Test_Resolve(t *testing.T) {
+	acme := &TenantInfo{ID: "1", Code: "acme"}
+	beta := &TenantInfo{ID: "2", Code: "beta"}
+	custom := &TenantInfo{ID: "3", Code: "custom"}
+	resolver := &fakeResolver{
+		codes:   map[string]*TenantInfo{"acme": acme, "beta": beta},
+		domains: map[string]*TenantInfo{"portal.custom.io": custom},
+	}
+	cases := []struct {
+		name       string
+		code       string
+		host       string
+		baseDomain string
+		want       *TenantInfo
+	}{
+		{"header takes precedence over host", "beta", "acme.example.com", "example.com", beta},
+		{"header is trimmed", "  acme ", "", "", acme},
+		{"unknown header code", "nope", "acme.example.com", "example.com", nil},
+		{"subdomain", "", "acme.example.com", "example.com", acme},
+		{"subdomain with port", "", "ACME.example.com:8443", "example.com", acme},
+		{"nested subdomain is ignored", "", "x.acme.example.com", "example.com", nil},
+		{"base domain itself", "", "example.com:80", "example.com", nil},
+		{"suffix without dot is not a subdomain", "", "acmeexample.com", "example.com", nil},
+		{"custom domain", "", "portal.custom.io", "example.com", custom},
+		{"custom domain without base domain", "", "portal.custom.io:443", "", custom},
+		{"ipv6 host", "", "[::1]:8080", "example.com", nil},
+		{"empty host", "", "", "example.com", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := resolve(context.Background(), resolver, c.code, c.host, c.baseDomain)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != c.want {
+				t.Fatalf("resolve(%q, %q) = %+v, want %+v", c.code, c.host, got, c.want)
+			}
+		})
+	}
+}
+
+func Test_CachedResolver(t *testing.T) {
+	acme := &TenantInfo{ID: "1", Code: "acme"}
+	inner := &fakeResolver{codes: map[string]*TenantInfo{"acme": acme}}
+	r := NewCachedResolver(inner, time.Minute, 2)
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if info, _ := r.ResolveByCode(ctx, "acme"); info != acme {
+			t.Fatalf("unexpected tenant %+v", info)
+		}
+		if info, _ := r.ResolveByDomain(ctx, "unknown.io"); info != nil {
+			t.Fatalf("unexpected tenant %+v", info)
+		}
+	}
+	if inner.calls != 2 {
+		t.Fatalf("expected positive and negative results to be cached, got %d calls", inner.calls)
+	}
+
+	// 锁定后清除缓存, 未解析到的结果一并清除
+	r.Evict("1")
+	_, _ = r.ResolveByCode(ctx, "acme")
+	_, _ = r.ResolveByDomain(ctx, "unknown.io")
+	if inner.calls != 4 {
+		t.Fatalf("expected evicted entries to be reloaded, got %d calls", inner.calls)
+	}
+
+	// 过期后重新查询
+	now = now.Add(2 * time.Minute)
+	_, _ = r.ResolveByCode(ctx, "acme")
+	if inner.calls != 5 {
+		t.Fatalf("expected expired entry to be reloaded, got %d calls", inner.calls)
+	}
+
+	// 超出容量时淘汰最久未访问的结果
+	_, _ = r.ResolveByDomain(ctx, "a.io")
+	_, _ = r.ResolveByDomain(ctx, "b.io")
+	if len(r.entries) != 2 {
+		t.Fatalf("expected cache to be bounded, got %d entries", len(r.entries))
+	}
+}
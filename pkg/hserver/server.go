@@ -28,6 +28,7 @@ type Serve struct {
 	Tokenizer token.IToken
 	config    *ServerConfig
 	hertz     *server.Hertz
+	stops     []func()
 }
 
 // NewServe 创建服务
@@ -67,6 +68,12 @@ func (s *Serve) RegisterRouters(routers ...Router) {
 func (s *Serve) Use(handlers ...app.HandlerFunc) {
 	s.handlers = append(s.handlers, handlers...)
 }
+
+// OnStop 注册停机时执行的清理函数, 在 Hertz 处理完正在执行的请求后按注册顺序执行
+func (s *Serve) OnStop(fns ...func()) {
+	s.stops = append(s.stops, fns...)
+}
+
 func (s *Serve) GetHertz() *server.Hertz {
 	return s.hertz
 }
@@ -82,18 +89,24 @@ func (s *Serve) Run() {
 		r.ConfigRoutes(s.hertz, s.Tokenizer)
 	}
 	// Initializing the server in a goroutine so that
-	// it won't block the graceful shutdown handling below
+	// it won't block the graceful shutdown handling below.
+	// Spin is not used: it handles signals itself and would race the shutdown below
+	errCh := make(chan error, 1)
 	go func() {
-		s.hertz.Spin()
+		errCh <- s.hertz.Run()
 	}()
 	// Wait for interrupt signal to gracefully shutdown the server with
 	// a timeout of 5 seconds.
-	quit := make(chan os.Signal)
+	quit := make(chan os.Signal, 1)
 	// kill (no param) default send syscall.SIGTERM
 	// kill -2 is syscall.SIGINT
 	// kill -9 is syscall.SIGKILL but can't be caught, so don't need to add it
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	select {
+	case <-quit:
+	case err := <-errCh:
+		hlog.Fatal("Server run error:", err)
+	}
 	hlog.Info("Shutting down server...")
 
 	// The context is used to inform the server it has 5 seconds to finish
@@ -101,7 +114,12 @@ func (s *Serve) Run() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := s.hertz.Shutdown(ctx); err != nil {
+	err := s.hertz.Shutdown(ctx)
+	// 请求处理完或超时后再清理, 此时不会再有请求使用这些资源
+	for _, stop := range s.stops {
+		stop()
+	}
+	if err != nil {
 		hlog.Fatal("Server forced to shutdown:", err)
 	}
 	hlog.Fatal("Server exiting")
@@ -0,0 +1,226 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Operator 比较操作符
+type Operator string
+
+const (
+	OpEq Operator = "eq" // 等于
+	OpNe Operator = "ne" // 不等于
+	OpCo Operator = "co" // 包含
+	OpSw Operator = "sw" // 前缀匹配
+	OpEw Operator = "ew" // 后缀匹配
+	OpGt Operator = "gt" // 大于
+	OpGe Operator = "ge" // 大于等于
+	OpLt Operator = "lt" // 小于
+	OpLe Operator = "le" // 小于等于
+	OpPr Operator = "pr" // 存在(非空)
+)
+
+var (
+	ErrEmptyFilter       = errors.New("empty filter")
+	ErrUnsupportedFilter = errors.New("only 'and' of attribute comparisons is supported")
+)
+
+// Comparison 属性比较表达式, 如 userName eq "bjensen"
+type Comparison struct {
+	Attr  string      // 属性路径, 小写, 子属性以 . 连接, 如 emails.value
+	Op    Operator    // 操作符
+	Value interface{} // 比较值: string、bool、float64 或 nil, pr 操作符没有值
+}
+
+// StringValue 比较值的字符串形式
+func (c *Comparison) StringValue() string {
+	switch v := c.Value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// Filter 过滤表达式, 多个比较之间为 and 关系
+// 身份提供方的同步请求只使用单个或 and 连接的比较, 暂不支持 or、not 和分组
+type Filter []*Comparison
+
+// ParseFilter 解析过滤表达式, 空字符串返回 nil
+func ParseFilter(s string) (Filter, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		filter Filter
+		i      int
+	)
+	for {
+		if i >= len(tokens) {
+			return nil, ErrEmptyFilter
+		}
+		cmp, n, err := parseComparison(tokens[i:])
+		if err != nil {
+			return nil, err
+		}
+		filter = append(filter, cmp)
+		i += n
+		if i == len(tokens) {
+			return filter, nil
+		}
+		switch strings.ToLower(tokens[i].text) {
+		case "and":
+			i++
+		case "or", "not":
+			return nil, ErrUnsupportedFilter
+		default:
+			return nil, fmt.Errorf("unexpected token %q", tokens[i].text)
+		}
+	}
+}
+
+// Match 按属性值判断是否匹配, 字符串比较不区分大小写
+func (f Filter) Match(attrs map[string]string) bool {
+	for _, cmp := range f {
+		v, ok := attrs[cmp.Attr]
+		if !cmp.match(v, ok) {
+			return false
+		}
+	}
+	return true
+}
+
+// EqValue 取 attr eq value 形式的比较值
+func (f Filter) EqValue(attr string) (string, bool) {
+	attr = normalizeAttr(attr)
+	for _, cmp := range f {
+		if cmp.Attr == attr && cmp.Op == OpEq {
+			return cmp.StringValue(), true
+		}
+	}
+	return "", false
+}
+
+func (c *Comparison) match(v string, exists bool) bool {
+	if c.Op == OpPr {
+		return exists && v != ""
+	}
+	a, b := strings.ToLower(v), strings.ToLower(c.StringValue())
+	switch c.Op {
+	case OpEq:
+		return exists && a == b
+	case OpNe:
+		return !exists || a != b
+	case OpCo:
+		return exists && strings.Contains(a, b)
+	case OpSw:
+		return exists && strings.HasPrefix(a, b)
+	case OpEw:
+		return exists && strings.HasSuffix(a, b)
+	case OpGt:
+		return exists && a > b
+	case OpGe:
+		return exists && a >= b
+	case OpLt:
+		return exists && a < b
+	case OpLe:
+		return exists && a <= b
+	}
+	return false
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(s); j++ {
+				if s[j] == '\\' {
+					j++
+					continue
+				}
+				if s[j] == '"' {
+					break
+				}
+			}
+			if j >= len(s) {
+				return nil, errors.New("unterminated string")
+			}
+			var v string
+			if err := json.Unmarshal([]byte(s[i:j+1]), &v); err != nil {
+				return nil, fmt.Errorf("invalid string %s", s[i:j+1])
+			}
+			tokens = append(tokens, token{text: v, quoted: true})
+			i = j + 1
+		case c == '(' || c == ')' || c == '[' || c == ']':
+			return nil, ErrUnsupportedFilter
+		default:
+			j := i
+			for j < len(s) && s[j] != ' ' && s[j] != '\t' && s[j] != '(' && s[j] != ')' && s[j] != '[' && s[j] != ']' {
+				j++
+			}
+			tokens = append(tokens, token{text: s[i:j]})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+func parseComparison(tokens []token) (*Comparison, int, error) {
+	if len(tokens) < 2 || tokens[0].quoted {
+		return nil, 0, errors.New("attribute and operator required")
+	}
+	cmp := &Comparison{Attr: normalizeAttr(tokens[0].text), Op: Operator(strings.ToLower(tokens[1].text))}
+	switch cmp.Op {
+	case OpPr:
+		return cmp, 2, nil
+	case OpEq, OpNe, OpCo, OpSw, OpEw, OpGt, OpGe, OpLt, OpLe:
+	default:
+		return nil, 0, fmt.Errorf("unknown operator %q", tokens[1].text)
+	}
+	if len(tokens) < 3 {
+		return nil, 0, fmt.Errorf("value required for operator %q", cmp.Op)
+	}
+	v := tokens[2]
+	if v.quoted {
+		cmp.Value = v.text
+		return cmp, 3, nil
+	}
+	switch strings.ToLower(v.text) {
+	case "true":
+		cmp.Value = true
+	case "false":
+		cmp.Value = false
+	case "null":
+		cmp.Value = nil
+	default:
+		n, err := strconv.ParseFloat(v.text, 64)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid value %q", v.text)
+		}
+		cmp.Value = n
+	}
+	return cmp, 3, nil
+}
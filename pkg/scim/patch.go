@@ -0,0 +1,154 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PATCH 操作类型
+const (
+	PatchAdd     = "add"
+	PatchRemove  = "remove"
+	PatchReplace = "replace"
+)
+
+// PatchRequest PATCH 请求
+type PatchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []*PatchOperation `json:"Operations"`
+}
+
+// Validate 校验操作类型, remove 操作必须指定路径
+func (r *PatchRequest) Validate() error {
+	if len(r.Operations) == 0 {
+		return errors.New("no operations")
+	}
+	for _, op := range r.Operations {
+		switch op.Name() {
+		case PatchAdd, PatchReplace:
+			if len(op.Value) == 0 {
+				return fmt.Errorf("value required for %s operation", op.Op)
+			}
+		case PatchRemove:
+			if op.Path == "" {
+				return errors.New("path required for remove operation")
+			}
+		default:
+			return fmt.Errorf("unknown operation %q", op.Op)
+		}
+	}
+	return nil
+}
+
+// PatchOperation 单个 PATCH 操作
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Name 操作类型, 部分身份提供方使用首字母大写的操作名
+func (o *PatchOperation) Name() string {
+	return strings.ToLower(o.Op)
+}
+
+// ValueMap 未指定路径时, value 为属性到值的对象, 属性名统一为小写
+func (o *PatchOperation) ValueMap() (map[string]json.RawMessage, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(o.Value, &raw); err != nil {
+		return nil, errors.New("value must be an object when path is omitted")
+	}
+	values := make(map[string]json.RawMessage, len(raw))
+	for k, v := range raw {
+		values[normalizeAttr(k)] = v
+	}
+	return values, nil
+}
+
+// Path 属性路径, 如 members[value eq "2819c223"] 或 emails[type eq "work"].value
+type Path struct {
+	Attr    string // 属性名, 小写
+	Filter  Filter // 值过滤
+	SubAttr string // 子属性名, 小写
+}
+
+// ParsePath 解析 PATCH 操作的属性路径
+func ParsePath(s string) (*Path, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, errors.New("empty path")
+	}
+	p := &Path{}
+	if i := strings.Index(s, "["); i >= 0 {
+		j := strings.LastIndex(s, "]")
+		if j < i {
+			return nil, fmt.Errorf("invalid path %q", s)
+		}
+		filter, err := ParseFilter(s[i+1 : j])
+		if err != nil {
+			return nil, err
+		}
+		p.Attr, p.Filter = normalizeAttr(s[:i]), filter
+		if rest := s[j+1:]; rest != "" {
+			if !strings.HasPrefix(rest, ".") {
+				return nil, fmt.Errorf("invalid path %q", s)
+			}
+			p.SubAttr = strings.ToLower(rest[1:])
+		}
+		return p, nil
+	}
+	attr := normalizeAttr(s)
+	if i := strings.LastIndex(attr, "."); i >= 0 && !strings.Contains(attr[i:], ":") {
+		attr, p.SubAttr = attr[:i], attr[i+1:]
+	}
+	p.Attr = attr
+	return p, nil
+}
+
+// String 完整属性名, 如 name.givenname
+func (p *Path) String() string {
+	if p.SubAttr == "" {
+		return p.Attr
+	}
+	return p.Attr + "." + p.SubAttr
+}
+
+// DecodeString 解析字符串值
+func DecodeString(raw json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return "", errors.New("string value expected")
+	}
+	return s, nil
+}
+
+// DecodeBool 解析布尔值, 兼容以字符串传递的 "True"/"False"
+func DecodeBool(raw json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+	return false, errors.New("boolean value expected")
+}
+
+// DecodeMultiValues 解析多值属性, 兼容单个对象
+func DecodeMultiValues(raw json.RawMessage) ([]*MultiValue, error) {
+	var values []*MultiValue
+	if err := json.Unmarshal(raw, &values); err == nil {
+		return values, nil
+	}
+	var v MultiValue
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, errors.New("multi-valued attribute expected")
+	}
+	return []*MultiValue{&v}, nil
+}
@@ -0,0 +1,127 @@
+package scim
+
+// User 用户资源
+type User struct {
+	Schemas      []string      `json:"schemas"`
+	ID           string        `json:"id,omitempty"`
+	ExternalID   string        `json:"externalId,omitempty"`
+	UserName     string        `json:"userName"`
+	Name         *Name         `json:"name,omitempty"`
+	DisplayName  string        `json:"displayName,omitempty"`
+	NickName     string        `json:"nickName,omitempty"`
+	Password     string        `json:"password,omitempty"` // 仅写入, 不会返回
+	Active       *bool         `json:"active,omitempty"`
+	Emails       []*MultiValue `json:"emails,omitempty"`
+	PhoneNumbers []*MultiValue `json:"phoneNumbers,omitempty"`
+	Photos       []*MultiValue `json:"photos,omitempty"`
+	Roles        []*MultiValue `json:"roles,omitempty"`
+	Groups       []*MultiValue `json:"groups,omitempty"` // 只读, 由 Group 的成员关系决定
+	Meta         *Meta         `json:"meta,omitempty"`
+}
+
+// Name 用户姓名
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+// FullName 姓名, 未提供 formatted 时由名和姓拼接
+func (n *Name) FullName() string {
+	if n == nil {
+		return ""
+	}
+	if n.Formatted != "" {
+		return n.Formatted
+	}
+	if n.GivenName != "" && n.FamilyName != "" {
+		return n.GivenName + " " + n.FamilyName
+	}
+	return n.GivenName + n.FamilyName
+}
+
+// MultiValue 多值属性(邮箱、电话、角色、所属组等)
+type MultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// PrimaryValue 取主值, 没有标记主值时取第一个
+func PrimaryValue(values []*MultiValue) string {
+	for _, v := range values {
+		if v != nil && v.Primary {
+			return v.Value
+		}
+	}
+	for _, v := range values {
+		if v != nil {
+			return v.Value
+		}
+	}
+	return ""
+}
+
+// Group 组资源
+type Group struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	ExternalID  string        `json:"externalId,omitempty"`
+	DisplayName string        `json:"displayName"`
+	Members     []*MultiValue `json:"members,omitempty"`
+	Meta        *Meta         `json:"meta,omitempty"`
+}
+
+// ServiceProviderConfig 服务能力说明
+type ServiceProviderConfig struct {
+	Schemas               []string        `json:"schemas"`
+	Patch                 Supported       `json:"patch"`
+	Bulk                  BulkSupported   `json:"bulk"`
+	Filter                FilterSupported `json:"filter"`
+	ChangePassword        Supported       `json:"changePassword"`
+	Sort                  Supported       `json:"sort"`
+	Etag                  Supported       `json:"etag"`
+	AuthenticationSchemes []AuthScheme    `json:"authenticationSchemes"`
+	Meta                  *Meta           `json:"meta,omitempty"`
+}
+
+type Supported struct {
+	Supported bool `json:"supported"`
+}
+
+type BulkSupported struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type FilterSupported struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type AuthScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary,omitempty"`
+}
+
+// DefaultServiceProviderConfig 支持 PATCH 和过滤, 不支持批量、排序和 ETag, 使用 Bearer 令牌认证
+func DefaultServiceProviderConfig() *ServiceProviderConfig {
+	return &ServiceProviderConfig{
+		Schemas:        []string{SchemaServiceProviderConfig},
+		Patch:          Supported{Supported: true},
+		Filter:         FilterSupported{Supported: true, MaxResults: MaxCount},
+		ChangePassword: Supported{Supported: true},
+		AuthenticationSchemes: []AuthScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "OAuth Bearer Token",
+			Description: "Authentication scheme using a tenant scoped SCIM bearer token",
+			Primary:     true,
+		}},
+		Meta: &Meta{ResourceType: "ServiceProviderConfig"},
+	}
+}
@@ -0,0 +1,146 @@
+// Package scim SCIM 2.0 (RFC 7643/7644) 协议的资源结构、过滤表达式和 PATCH 操作
+package scim
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ContentType SCIM 响应的内容类型
+const ContentType = "application/scim+json"
+
+// 资源及消息的 schema
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+// 资源类型
+const (
+	ResourceTypeUser  = "User"
+	ResourceTypeGroup = "Group"
+)
+
+// 错误类型(scimType), 见 RFC 7644 3.12
+const (
+	ErrInvalidFilter = "invalidFilter"
+	ErrInvalidSyntax = "invalidSyntax"
+	ErrInvalidPath   = "invalidPath"
+	ErrInvalidValue  = "invalidValue"
+	ErrNoTarget      = "noTarget"
+	ErrMutability    = "mutability"
+	ErrUniqueness    = "uniqueness"
+	ErrTooMany       = "tooMany"
+)
+
+// 分页参数默认值
+const (
+	DefaultCount = 100
+	MaxCount     = 500
+)
+
+// Meta 资源元数据
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+// ListResponse 列表响应
+type ListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int64       `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// NewListResponse 创建列表响应
+func NewListResponse(total int64, startIndex, items int, resources interface{}) *ListResponse {
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: items,
+		Resources:    resources,
+	}
+}
+
+// Error 错误响应
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// NewError 创建错误响应
+func NewError(status int, scimType, detail string) *Error {
+	return &Error{
+		Schemas:  []string{SchemaError},
+		Status:   fmt.Sprintf("%d", status),
+		ScimType: scimType,
+		Detail:   detail,
+	}
+}
+
+// StatusCode HTTP 状态码
+func (e *Error) StatusCode() int {
+	status, err := strconv.Atoi(e.Status)
+	if err != nil {
+		return http.StatusInternalServerError
+	}
+	return status
+}
+
+// IsErrorType 是否为 SCIM 定义的错误类型
+func IsErrorType(s string) bool {
+	switch s {
+	case ErrInvalidFilter, ErrInvalidSyntax, ErrInvalidPath, ErrInvalidValue, ErrNoTarget, ErrMutability, ErrUniqueness, ErrTooMany:
+		return true
+	}
+	return false
+}
+
+// Pagination 分页参数, startIndex 从1开始
+type Pagination struct {
+	StartIndex int
+	Count      int
+}
+
+// NewPagination 规范化分页参数, count 为负数或未设置时使用默认值, 超过上限时取上限
+func NewPagination(startIndex, count int, countSet bool) Pagination {
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	if !countSet || count < 0 {
+		count = DefaultCount
+	} else if count > MaxCount {
+		count = MaxCount
+	}
+	return Pagination{StartIndex: startIndex, Count: count}
+}
+
+// Offset 查询偏移量
+func (p Pagination) Offset() int {
+	return p.StartIndex - 1
+}
+
+// normalizeAttr 属性名不区分大小写, 统一为小写, 去掉核心 schema 前缀
+func normalizeAttr(attr string) string {
+	attr = strings.ToLower(attr)
+	for _, schema := range []string{SchemaUser, SchemaGroup} {
+		prefix := strings.ToLower(schema) + ":"
+		if strings.HasPrefix(attr, prefix) {
+			return strings.TrimPrefix(attr, prefix)
+		}
+	}
+	return attr
+}
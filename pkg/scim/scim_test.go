@@ -0,0 +1,120 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	f, err := ParseFilter(`userName eq "bjensen@example.com" and active eq true and emails.value pr`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f) != 3 {
+		t.Fatalf("len = %d", len(f))
+	}
+	if f[0].Attr != "username" || f[0].Op != OpEq || f[0].Value != "bjensen@example.com" {
+		t.Errorf("unexpected comparison: %+v", f[0])
+	}
+	if f[1].Value != true {
+		t.Errorf("active value = %v", f[1].Value)
+	}
+	if f[2].Attr != "emails.value" || f[2].Op != OpPr {
+		t.Errorf("unexpected comparison: %+v", f[2])
+	}
+
+	f, err = ParseFilter(`urn:ietf:params:scim:schemas:core:2.0:User:userName sw "a \"b\""`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f[0].Attr != "username" || f[0].Value != `a "b"` {
+		t.Errorf("unexpected comparison: %+v", f[0])
+	}
+
+	for _, s := range []string{
+		`userName eq "a" or userName eq "b"`,
+		`(userName eq "a")`,
+		`userName xx "a"`,
+		`userName eq`,
+		`userName eq "a`,
+		`userName eq "a" and`,
+	} {
+		if _, err := ParseFilter(s); err == nil {
+			t.Errorf("ParseFilter(%q) should fail", s)
+		}
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	f, _ := ParseFilter(`type eq "Work" and value co "@example"`)
+	if !f.Match(map[string]string{"type": "work", "value": "a@example.com"}) {
+		t.Error("should match")
+	}
+	if f.Match(map[string]string{"type": "home", "value": "a@example.com"}) {
+		t.Error("should not match")
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	p, err := ParsePath(`members[value eq "2819c223"]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := p.Filter.EqValue("value"); p.Attr != "members" || !ok || v != "2819c223" {
+		t.Errorf("unexpected path: %+v", p)
+	}
+
+	p, err = ParsePath(`emails[type eq "work"].value`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Attr != "emails" || p.SubAttr != "value" {
+		t.Errorf("unexpected path: %+v", p)
+	}
+
+	p, err = ParsePath(`name.givenName`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.String() != "name.givenname" {
+		t.Errorf("path = %s", p.String())
+	}
+}
+
+func TestPatchRequest(t *testing.T) {
+	var req PatchRequest
+	body := `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"Replace","value":{"active":"False","name.givenName":"Barbara"}}]}`
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		t.Fatal(err)
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	values, err := req.Operations[0].ValueMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if active, err := DecodeBool(values["active"]); err != nil || active {
+		t.Errorf("active = %v, %v", active, err)
+	}
+	if _, ok := values["name.givenname"]; !ok {
+		t.Error("attribute names should be lower case")
+	}
+
+	req = PatchRequest{Operations: []*PatchOperation{{Op: "remove"}}}
+	if err := req.Validate(); err == nil {
+		t.Error("remove without path should fail")
+	}
+}
+
+func TestNewPagination(t *testing.T) {
+	if p := NewPagination(0, 0, false); p.StartIndex != 1 || p.Count != DefaultCount {
+		t.Errorf("unexpected pagination: %+v", p)
+	}
+	if p := NewPagination(11, 1000, true); p.Offset() != 10 || p.Count != MaxCount {
+		t.Errorf("unexpected pagination: %+v", p)
+	}
+	if p := NewPagination(1, 0, true); p.Count != 0 {
+		t.Errorf("count 0 should be kept: %+v", p)
+	}
+}
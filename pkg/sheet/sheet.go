@@ -0,0 +1,161 @@
+// Package sheet 表格文件(CSV/XLSX)的读写, 用于批量导入导出
+package sheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// 文件格式
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// DefaultSheetName XLSX 默认工作表名称
+const DefaultSheetName = "Sheet1"
+
+// utf8BOM Excel 另存的 CSV 文件通常带有 BOM
+const utf8BOM = "\ufeff"
+
+// DetectFormat 根据文件名识别格式, 无法识别时返回空
+func DetectFormat(filename string) string {
+	switch strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), ".")) {
+	case FormatCSV:
+		return FormatCSV
+	case FormatXLSX:
+		return FormatXLSX
+	default:
+		return ""
+	}
+}
+
+// ContentType 格式对应的 Content-Type
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// ReadAll 读取全部行, XLSX 读取第一个工作表, 末尾的空行会被忽略
+func ReadAll(r io.Reader, format string) ([][]string, error) {
+	var rows [][]string
+	switch format {
+	case FormatCSV:
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		content = bytes.TrimPrefix(content, []byte(utf8BOM))
+		cr := csv.NewReader(bytes.NewReader(content))
+		cr.FieldsPerRecord = -1
+		cr.TrimLeadingSpace = true
+		if rows, err = cr.ReadAll(); err != nil {
+			return nil, fmt.Errorf("read csv: %w", err)
+		}
+	case FormatXLSX:
+		f, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("open xlsx: %w", err)
+		}
+		defer f.Close()
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, nil
+		}
+		if rows, err = f.GetRows(sheets[0]); err != nil {
+			return nil, fmt.Errorf("read xlsx: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported sheet format: %s", format)
+	}
+	for len(rows) > 0 && isBlank(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+	return rows, nil
+}
+
+func isBlank(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Writer 按行写入表格
+type Writer interface {
+	// Write 写入一行
+	Write(row []string) error
+	// Close 结束写入, 未调用时输出可能不完整
+	Close() error
+}
+
+// NewWriter 创建写入器
+func NewWriter(w io.Writer, format string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		f := excelize.NewFile()
+		sw, err := f.NewStreamWriter(DefaultSheetName)
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		return &xlsxWriter{out: w, f: f, sw: sw}, nil
+	default:
+		return nil, fmt.Errorf("unsupported sheet format: %s", format)
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// Write 写入一行, csv.Writer 内部缓冲写满后会自动输出, 适合流式响应
+func (c *csvWriter) Write(row []string) error {
+	return c.w.Write(row)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// xlsxWriter XLSX 为 zip 格式, 行数据先写入流式工作表, Close 时整体输出
+type xlsxWriter struct {
+	out  io.Writer
+	f    *excelize.File
+	sw   *excelize.StreamWriter
+	rows int
+}
+
+func (x *xlsxWriter) Write(row []string) error {
+	x.rows++
+	cell, err := excelize.CoordinatesToCellName(1, x.rows)
+	if err != nil {
+		return err
+	}
+	values := make([]interface{}, len(row))
+	for i, v := range row {
+		values[i] = v
+	}
+	return x.sw.SetRow(cell, values)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.f.Close()
+	if err := x.sw.Flush(); err != nil {
+		return err
+	}
+	return x.f.Write(x.out)
+}
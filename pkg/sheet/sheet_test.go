@@ -0,0 +1,60 @@
+package sheet
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_DetectFormat(t *testing.T) {
+	cases := map[string]string{
+		"users.csv":  FormatCSV,
+		"USERS.XLSX": FormatXLSX,
+		"users.xls":  "",
+		"users":      "",
+	}
+	for name, want := range cases {
+		if got := DetectFormat(name); got != want {
+			t.Errorf("DetectFormat(%s) = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func Test_ReadCSV(t *testing.T) {
+	content := "\ufeffusername,role_codes\nalice,\"admin,dev\"\n\n,\n"
+	rows, err := ReadAll(strings.NewReader(content), FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"username", "role_codes"}, {"alice", "admin,dev"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %v, want %v", rows, want)
+	}
+}
+
+func Test_WriteRead(t *testing.T) {
+	data := [][]string{{"username", "email"}, {"alice", "alice@example.com"}, {"bob", ""}}
+	for _, format := range []string{FormatCSV, FormatXLSX} {
+		buf := &bytes.Buffer{}
+		w, err := NewWriter(buf, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range data {
+			if err := w.Write(row); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		rows, err := ReadAll(buf, format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if len(rows) != len(data) || rows[1][1] != "alice@example.com" || rows[2][0] != "bob" {
+			t.Errorf("%s: rows = %v", format, rows)
+		}
+	}
+}
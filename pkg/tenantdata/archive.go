@@ -0,0 +1,129 @@
+package tenantdata
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"path"
+	"time"
+)
+
+// ManifestName 归档清单文件名
+const ManifestName = "manifest.json"
+
+// ManifestEntry 归档文件信息
+type ManifestEntry struct {
+	Section string `json:"section"` // 所属分区
+	Name    string `json:"name"`    // 归档内路径
+	Size    int64  `json:"size"`    // 文件大小
+	SHA256  string `json:"sha256"`  // 文件摘要
+}
+
+// Manifest 归档清单
+type Manifest struct {
+	TenantID   string           `json:"tenantId"`   // 租户ID
+	TenantCode string           `json:"tenantCode"` // 租户编码
+	CreatedAt  int64            `json:"createdAt"`  // 导出时间
+	Sections   map[string]int64 `json:"sections"`   // 各分区导出的记录数
+	Entries    []*ManifestEntry `json:"entries"`    // 归档文件列表
+}
+
+// ArchiveWriter 租户数据归档, zip 格式, 关闭时写入清单
+type ArchiveWriter struct {
+	zw       *zip.Writer
+	manifest *Manifest
+	section  string
+	current  *entryWriter
+}
+
+// NewArchiveWriter 创建归档
+func NewArchiveWriter(w io.Writer, tenantID, tenantCode string) *ArchiveWriter {
+	return &ArchiveWriter{
+		zw: zip.NewWriter(w),
+		manifest: &Manifest{
+			TenantID:   tenantID,
+			TenantCode: tenantCode,
+			CreatedAt:  time.Now().Unix(),
+			Sections:   make(map[string]int64),
+		},
+	}
+}
+
+// Begin 开始写入分区, 之后创建的文件都位于该分区目录下
+func (a *ArchiveWriter) Begin(section string) {
+	a.section = section
+}
+
+// End 结束分区并记录导出的记录数
+func (a *ArchiveWriter) End(rows int64) error {
+	if err := a.flush(); err != nil {
+		return err
+	}
+	a.manifest.Sections[a.section] = rows
+	a.section = ""
+	return nil
+}
+
+// Create 在当前分区下创建文件, 上一个文件自动结束
+func (a *ArchiveWriter) Create(name string) (io.Writer, error) {
+	if a.section == "" {
+		return nil, fmt.Errorf("archive section not begun")
+	}
+	if err := a.flush(); err != nil {
+		return nil, err
+	}
+	entry := &ManifestEntry{Section: a.section, Name: path.Join(a.section, name)}
+	w, err := a.zw.Create(entry.Name)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	a.current = &entryWriter{w: io.MultiWriter(w, h), h: h, entry: entry}
+	return a.current, nil
+}
+
+// Close 写入清单并关闭归档
+func (a *ArchiveWriter) Close() (*Manifest, error) {
+	if err := a.flush(); err != nil {
+		return nil, err
+	}
+	w, err := a.zw.Create(ManifestName)
+	if err != nil {
+		return nil, err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(a.manifest); err != nil {
+		return nil, err
+	}
+	if err := a.zw.Close(); err != nil {
+		return nil, err
+	}
+	return a.manifest, nil
+}
+
+func (a *ArchiveWriter) flush() error {
+	if a.current == nil {
+		return nil
+	}
+	a.current.entry.SHA256 = hex.EncodeToString(a.current.h.Sum(nil))
+	a.manifest.Entries = append(a.manifest.Entries, a.current.entry)
+	a.current = nil
+	return nil
+}
+
+type entryWriter struct {
+	w     io.Writer
+	h     hash.Hash
+	entry *ManifestEntry
+}
+
+func (e *entryWriter) Write(p []byte) (int, error) {
+	n, err := e.w.Write(p)
+	e.entry.Size += int64(n)
+	return n, err
+}
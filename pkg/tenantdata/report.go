@@ -0,0 +1,58 @@
+package tenantdata
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// SectionReport 分区处理结果
+type SectionReport struct {
+	Name string `json:"name"` // 分区名称
+	Rows int64  `json:"rows"` // 导出/删除的记录数
+}
+
+// Report 任务完成报告, 通过 HMAC-SHA256 签名, 作为数据交付或删除的凭证
+type Report struct {
+	JobID         string           `json:"jobId"`                   // 任务ID
+	JobType       string           `json:"jobType"`                 // 任务类型
+	TenantID      string           `json:"tenantId"`                // 租户ID
+	TenantCode    string           `json:"tenantCode"`              // 租户编码
+	StartedAt     int64            `json:"startedAt"`               // 开始时间
+	FinishedAt    int64            `json:"finishedAt"`              // 完成时间
+	Sections      []*SectionReport `json:"sections"`                // 分区结果
+	ArchiveSHA256 string           `json:"archiveSha256,omitempty"` // 归档文件摘要
+	Signature     string           `json:"signature"`               // 签名
+}
+
+// Sign 使用密钥签名
+func (r *Report) Sign(secret string) error {
+	sign, err := r.digest(secret)
+	if err != nil {
+		return err
+	}
+	r.Signature = sign
+	return nil
+}
+
+// Verify 校验签名
+func (r *Report) Verify(secret string) bool {
+	sign, err := r.digest(secret)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(sign), []byte(r.Signature))
+}
+
+func (r *Report) digest(secret string) (string, error) {
+	c := *r
+	c.Signature = ""
+	payload, err := json.Marshal(&c)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
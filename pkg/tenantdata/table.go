@@ -0,0 +1,88 @@
+package tenantdata
+
+import (
+	"context"
+	"encoding/json"
+
+	"gorm.io/gorm"
+)
+
+// Table 导出的数据表
+type Table struct {
+	Model interface{}   // 表模型, 与 Name 二选一
+	Name  string        // 表名, 用于没有模型的表(如按月分表的日志)
+	Where string        // 过滤条件
+	Args  []interface{} // 条件参数
+	Omit  []string      // 不导出的列, 如密码等敏感字段
+}
+
+// ExportTable 将表中租户数据以 JSON Lines 格式写入当前分区的 <表名>.jsonl
+func ExportTable(ctx context.Context, db *gorm.DB, w *ArchiveWriter, t Table) (int64, error) {
+	tx := db.WithContext(ctx)
+	name := t.Name
+	if t.Model != nil {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(t.Model); err != nil {
+			return 0, err
+		}
+		name = stmt.Schema.Table
+		tx = tx.Model(t.Model)
+	} else {
+		tx = tx.Table(t.Name)
+	}
+	if t.Where != "" {
+		tx = tx.Where(t.Where, t.Args...)
+	}
+	rows, err := tx.Rows()
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	omit := make(map[string]struct{}, len(t.Omit))
+	for _, c := range t.Omit {
+		omit[c] = struct{}{}
+	}
+	out, err := w.Create(name + ".jsonl")
+	if err != nil {
+		return 0, err
+	}
+	enc := json.NewEncoder(out)
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	var count int64
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return count, err
+		}
+		record := make(map[string]interface{}, len(columns))
+		for i, c := range columns {
+			if _, ok := omit[c]; ok {
+				continue
+			}
+			if b, ok := values[i].([]byte); ok {
+				record[c] = string(b)
+			} else {
+				record[c] = values[i]
+			}
+		}
+		if err := enc.Encode(record); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, rows.Err()
+}
+
+// PurgeTable 物理删除表中的租户数据
+func PurgeTable(ctx context.Context, db *gorm.DB, model interface{}, where string, args ...interface{}) (int64, error) {
+	res := db.WithContext(ctx).Where(where, args...).Delete(model)
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,240 @@
+package tenantdata
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 模板格式
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// Template 租户开通模板, 声明新租户需要预置的部门、角色、数据权限、字典和存储文件夹
+type Template struct {
+	Departments   []DepartmentSpec `json:"departments,omitempty" yaml:"departments,omitempty"`
+	Roles         []RoleSpec       `json:"roles,omitempty" yaml:"roles,omitempty"`
+	Dictionaries  []DictionarySpec `json:"dictionaries,omitempty" yaml:"dictionaries,omitempty"`
+	DefaultFolder bool             `json:"defaultFolder,omitempty" yaml:"defaultFolder,omitempty"` // 是否创建默认文件夹
+	Folders       []FolderSpec     `json:"folders,omitempty" yaml:"folders,omitempty"`
+}
+
+// DepartmentSpec 部门, 通过编码引用上级部门
+type DepartmentSpec struct {
+	Code        string `json:"code" yaml:"code"`
+	Name        string `json:"name" yaml:"name"`
+	ParentCode  string `json:"parentCode,omitempty" yaml:"parentCode,omitempty"`
+	Sequence    int32  `json:"sequence,omitempty" yaml:"sequence,omitempty"`
+	Leader      string `json:"leader,omitempty" yaml:"leader,omitempty"`
+	Phone       string `json:"phone,omitempty" yaml:"phone,omitempty"`
+	Email       string `json:"email,omitempty" yaml:"email,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// RoleSpec 角色, 权限通过权限编码声明
+type RoleSpec struct {
+	Code        string         `json:"code" yaml:"code"`
+	Name        string         `json:"name" yaml:"name"`
+	Type        int8           `json:"type,omitempty" yaml:"type,omitempty"`
+	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+	Sequence    int            `json:"sequence,omitempty" yaml:"sequence,omitempty"`
+	Permissions []string       `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+	DataScope   *DataScopeSpec `json:"dataScope,omitempty" yaml:"dataScope,omitempty"`
+}
+
+// DataScopeSpec 角色数据权限, 自定义范围通过部门编码引用模板中的部门
+type DataScopeSpec struct {
+	Scope       int8     `json:"scope" yaml:"scope"`
+	Departments []string `json:"departments,omitempty" yaml:"departments,omitempty"`
+}
+
+// DictionarySpec 字典及字典项
+type DictionarySpec struct {
+	Type  string         `json:"type" yaml:"type"`
+	Name  string         `json:"name" yaml:"name"`
+	Items []DictItemSpec `json:"items,omitempty" yaml:"items,omitempty"`
+}
+
+// DictItemSpec 字典项
+type DictItemSpec struct {
+	Label    string `json:"label" yaml:"label"`
+	Value    string `json:"value" yaml:"value"`
+	Sequence int    `json:"sequence,omitempty" yaml:"sequence,omitempty"`
+	Remark   string `json:"remark,omitempty" yaml:"remark,omitempty"`
+}
+
+// FolderSpec 存储文件夹
+type FolderSpec struct {
+	Name     string       `json:"name" yaml:"name"`
+	Children []FolderSpec `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// Provisioner 租户开通时按模板初始化数据, 克隆租户时从租户数据生成模板
+type Provisioner interface {
+	// Name 名称
+	Name() string
+	// Provision 按模板初始化租户数据, ctx 中带有租户及事务信息
+	Provision(ctx context.Context, tenantID, operator string, tpl *Template) error
+	// Capture 读取租户数据填充到模板
+	Capture(ctx context.Context, tenantID string, tpl *Template) error
+}
+
+// ParseTemplate 解析模板, format 为空时根据内容自动识别
+func ParseTemplate(content []byte, format string) (*Template, error) {
+	if format == "" {
+		format = DetectFormat(content)
+	}
+	tpl := new(Template)
+	switch format {
+	case FormatJSON:
+		dec := json.NewDecoder(bytes.NewReader(content))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(tpl); err != nil {
+			return nil, fmt.Errorf("parse json template: %w", err)
+		}
+	case FormatYAML:
+		dec := yaml.NewDecoder(bytes.NewReader(content))
+		dec.KnownFields(true)
+		if err := dec.Decode(tpl); err != nil {
+			return nil, fmt.Errorf("parse yaml template: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported template format: %s", format)
+	}
+	if err := tpl.Validate(); err != nil {
+		return nil, err
+	}
+	return tpl, nil
+}
+
+// DetectFormat 识别模板格式, 以 { 开头的内容视为 JSON
+func DetectFormat(content []byte) string {
+	if strings.HasPrefix(strings.TrimSpace(string(content)), "{") {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+// Marshal 按格式序列化模板
+func (t *Template) Marshal(format string) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return json.MarshalIndent(t, "", "  ")
+	case FormatYAML, "":
+		return yaml.Marshal(t)
+	default:
+		return nil, fmt.Errorf("unsupported template format: %s", format)
+	}
+}
+
+// Validate 校验模板内部引用: 编码唯一, 上级部门和数据权限部门必须在模板中声明
+func (t *Template) Validate() error {
+	depts := make(map[string]struct{}, len(t.Departments))
+	for _, d := range t.Departments {
+		if d.Code == "" || d.Name == "" {
+			return fmt.Errorf("department code and name are required")
+		}
+		if _, ok := depts[d.Code]; ok {
+			return fmt.Errorf("duplicate department code: %s", d.Code)
+		}
+		depts[d.Code] = struct{}{}
+	}
+	for _, d := range t.Departments {
+		if d.ParentCode == "" {
+			continue
+		}
+		if _, ok := depts[d.ParentCode]; !ok {
+			return fmt.Errorf("department %s references unknown parent %s", d.Code, d.ParentCode)
+		}
+	}
+	if _, err := t.SortedDepartments(); err != nil {
+		return err
+	}
+
+	roles := make(map[string]struct{}, len(t.Roles))
+	for _, r := range t.Roles {
+		if r.Code == "" || r.Name == "" {
+			return fmt.Errorf("role code and name are required")
+		}
+		if _, ok := roles[r.Code]; ok {
+			return fmt.Errorf("duplicate role code: %s", r.Code)
+		}
+		roles[r.Code] = struct{}{}
+		if r.DataScope == nil {
+			continue
+		}
+		if r.DataScope.Scope < 1 || r.DataScope.Scope > 5 {
+			return fmt.Errorf("role %s has invalid data scope %d", r.Code, r.DataScope.Scope)
+		}
+		for _, code := range r.DataScope.Departments {
+			if _, ok := depts[code]; !ok {
+				return fmt.Errorf("role %s data scope references unknown department %s", r.Code, code)
+			}
+		}
+	}
+
+	dicts := make(map[string]struct{}, len(t.Dictionaries))
+	for _, d := range t.Dictionaries {
+		if d.Type == "" {
+			return fmt.Errorf("dictionary type is required")
+		}
+		if _, ok := dicts[d.Type]; ok {
+			return fmt.Errorf("duplicate dictionary type: %s", d.Type)
+		}
+		dicts[d.Type] = struct{}{}
+		values := make(map[string]struct{}, len(d.Items))
+		for _, item := range d.Items {
+			if _, ok := values[item.Value]; ok {
+				return fmt.Errorf("dictionary %s has duplicate value %s", d.Type, item.Value)
+			}
+			values[item.Value] = struct{}{}
+		}
+	}
+	return validateFolders(t.Folders)
+}
+
+// SortedDepartments 按层级排序部门, 保证上级部门先于下级创建
+func (t *Template) SortedDepartments() ([]DepartmentSpec, error) {
+	sorted := make([]DepartmentSpec, 0, len(t.Departments))
+	created := make(map[string]struct{}, len(t.Departments))
+	pending := t.Departments
+	for len(pending) > 0 {
+		var next []DepartmentSpec
+		for _, d := range pending {
+			if _, ok := created[d.ParentCode]; d.ParentCode == "" || ok {
+				sorted = append(sorted, d)
+				created[d.Code] = struct{}{}
+				continue
+			}
+			next = append(next, d)
+		}
+		if len(next) == len(pending) {
+			return nil, fmt.Errorf("department hierarchy contains a cycle at %s", next[0].Code)
+		}
+		pending = next
+	}
+	return sorted, nil
+}
+
+func validateFolders(folders []FolderSpec) error {
+	names := make(map[string]struct{}, len(folders))
+	for _, f := range folders {
+		if f.Name == "" {
+			return fmt.Errorf("folder name is required")
+		}
+		if _, ok := names[f.Name]; ok {
+			return fmt.Errorf("duplicate folder name: %s", f.Name)
+		}
+		names[f.Name] = struct{}{}
+		if err := validateFolders(f.Children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
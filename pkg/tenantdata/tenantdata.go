@@ -0,0 +1,106 @@
+// Package tenantdata 租户数据的开通、导出与清除
+// 各业务模块实现 Section 并注册到 Registry, 租户下线时由导出/清除任务按注册顺序处理;
+// 实现 Provisioner 并注册后, 租户开通时按模板初始化数据, 克隆租户时生成模板
+package tenantdata
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Section 租户数据分区
+type Section interface {
+	// Name 分区名称, 作为归档中的目录名
+	Name() string
+	// Export 将租户数据写入归档, 返回导出的记录数
+	Export(ctx context.Context, tenantID string, w *ArchiveWriter) (int64, error)
+	// Purge 物理删除租户数据, 返回删除的记录数
+	Purge(ctx context.Context, tenantID string) (int64, error)
+}
+
+// Registry 租户数据分区注册表
+type Registry struct {
+	mu           sync.RWMutex
+	sections     []Section
+	provisioners []Provisioner
+}
+
+// NewRegistry 创建注册表
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register 注册分区, 同名分区只保留第一个
+func (r *Registry) Register(sections ...Section) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range sections {
+		exists := false
+		for _, old := range r.sections {
+			if old.Name() == s.Name() {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			r.sections = append(r.sections, s)
+		}
+	}
+}
+
+// Sections 获取已注册的分区
+func (r *Registry) Sections() []Section {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Section{}, r.sections...)
+}
+
+// RegisterProvisioner 注册开通处理器, 同名处理器只保留第一个
+func (r *Registry) RegisterProvisioner(provisioners ...Provisioner) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range provisioners {
+		exists := false
+		for _, old := range r.provisioners {
+			if old.Name() == p.Name() {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			r.provisioners = append(r.provisioners, p)
+		}
+	}
+}
+
+// Provisioners 获取已注册的开通处理器
+func (r *Registry) Provisioners() []Provisioner {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Provisioner{}, r.provisioners...)
+}
+
+// Provision 按注册顺序执行模板初始化, 任一处理器失败即返回, 由调用方的事务回滚
+func (r *Registry) Provision(ctx context.Context, tenantID, operator string, tpl *Template) error {
+	if tpl == nil {
+		return nil
+	}
+	for _, p := range r.Provisioners() {
+		if err := p.Provision(ctx, tenantID, operator, tpl); err != nil {
+			return fmt.Errorf("provision %s: %w", p.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Capture 从租户数据生成模板
+func (r *Registry) Capture(ctx context.Context, tenantID string) (*Template, error) {
+	tpl := new(Template)
+	for _, p := range r.Provisioners() {
+		if err := p.Capture(ctx, tenantID, tpl); err != nil {
+			return nil, fmt.Errorf("capture %s: %w", p.Name(), err)
+		}
+	}
+	return tpl, nil
+}
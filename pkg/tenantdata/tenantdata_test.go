@@ -0,0 +1,134 @@
+package tenantdata
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func Test_ReportSign(t *testing.T) {
+	r := &Report{JobID: "1", JobType: "purge", TenantID: "t1", Sections: []*SectionReport{{Name: "users", Rows: 3}}}
+	if err := r.Sign("secret"); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Verify("secret") {
+		t.Error("verify failed")
+	}
+	if r.Verify("other") {
+		t.Error("verify with wrong secret should fail")
+	}
+	r.Sections[0].Rows = 4
+	if r.Verify("secret") {
+		t.Error("verify tampered report should fail")
+	}
+}
+
+func Test_ArchiveManifest(t *testing.T) {
+	buf := &bytes.Buffer{}
+	a := NewArchiveWriter(buf, "t1", "demo")
+	a.Begin("users")
+	w, err := a.Create("sys_user.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.Write([]byte(`{"id":"1"}` + "\n"))
+	if err := a.End(1); err != nil {
+		t.Fatal(err)
+	}
+	m, err := a.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Entries) != 1 || m.Entries[0].Name != "users/sys_user.jsonl" || m.Entries[0].Size != 11 {
+		t.Errorf("unexpected entries: %+v", m.Entries[0])
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for _, f := range zr.File {
+		if f.Name != ManifestName {
+			continue
+		}
+		found = true
+		rc, _ := f.Open()
+		var got Manifest
+		if err := json.NewDecoder(rc).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		_ = rc.Close()
+		if got.Sections["users"] != 1 {
+			t.Errorf("unexpected sections: %v", got.Sections)
+		}
+	}
+	if !found {
+		t.Error("manifest not found")
+	}
+}
+
+func Test_ParseTemplate(t *testing.T) {
+	content := `
+departments:
+  - code: hq
+    name: 总部
+  - code: dev
+    name: 研发部
+    parentCode: hq
+roles:
+  - code: dev-admin
+    name: 研发管理员
+    permissions: [user, user:add]
+    dataScope:
+      scope: 4
+      departments: [dev]
+dictionaries:
+  - type: gender
+    name: 性别
+    items:
+      - {label: 男, value: "1"}
+      - {label: 女, value: "2"}
+defaultFolder: true
+folders:
+  - name: 合同
+    children:
+      - name: 2024
+`
+	tpl, err := ParseTemplate([]byte(content), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tpl.Departments) != 2 || len(tpl.Roles) != 1 || len(tpl.Folders[0].Children) != 1 {
+		t.Fatalf("unexpected template: %+v", tpl)
+	}
+	data, err := tpl.Marshal(FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := ParseTemplate(data, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Roles[0].DataScope.Departments[0] != "dev" {
+		t.Errorf("json round trip lost data scope: %+v", again.Roles[0])
+	}
+}
+
+func Test_TemplateValidate(t *testing.T) {
+	cases := map[string]*Template{
+		"unknown parent": {Departments: []DepartmentSpec{{Code: "a", Name: "a", ParentCode: "b"}}},
+		"cycle": {Departments: []DepartmentSpec{
+			{Code: "a", Name: "a", ParentCode: "b"},
+			{Code: "b", Name: "b", ParentCode: "a"},
+		}},
+		"unknown scope dept": {Roles: []RoleSpec{{Code: "r", Name: "r", DataScope: &DataScopeSpec{Scope: 4, Departments: []string{"x"}}}}},
+		"duplicate folder":   {Folders: []FolderSpec{{Name: "a"}, {Name: "a"}}},
+	}
+	for name, tpl := range cases {
+		if err := tpl.Validate(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -19,12 +19,23 @@ type AccessToken struct {
 	UserName     string   `json:"userName"`                 // 用户账号
 	Platform     string   `json:"platform"`                 // 平台类型
 	TenantId     string   `json:"tenantId"`                 //租户id
+	DeptId       string   `json:"deptId,omitempty"`         // 主部门id
 	AccessToken  string   `json:"access_token,omitempty"`   // 访问 token
 	ExpiresAt    int64    `json:"expires_at,omitempty"`     // 过期时间
 	RefreshToken string   `json:"refresh_token,omitempty"`  // 刷新 token
 	RefExpiresAt int64    `json:"ref_expires_at,omitempty"` // refToken过期时间
 	ServerCode   string   `json:"server_code"`              // 服务码
 	Roles        []string `json:"roles"`                    // 角色CODE列表
+	// 模拟登录, UserId 为被模拟的用户, Actor 为实际操作的管理员
+	ActorId              string `json:"actorId,omitempty"`              // 实际操作人ID
+	ActorName            string `json:"actorName,omitempty"`            // 实际操作人账号
+	ImpersonationId      string `json:"impersonationId,omitempty"`      // 模拟登录会话ID
+	ImpersonateExpiresAt int64  `json:"impersonateExpiresAt,omitempty"` // 模拟登录结束时间
+}
+
+// IsImpersonated 是否为模拟登录令牌
+func (a *AccessToken) IsImpersonated() bool {
+	return a.ActorId != ""
 }
 
 func (a *AccessToken) MarshalBinary() (data []byte, err error) {
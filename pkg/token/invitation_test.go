@@ -0,0 +1,32 @@
+package token
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_InvitationToken(t *testing.T) {
+	tk, err := GenerateInvitationToken("secret", "t1", "u1", "code", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := ParseInvitationToken("secret", tk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.TenantID != "t1" || claims.UserID != "u1" || claims.Code != "code" {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+	if _, err := ParseInvitationToken("other", tk); err == nil {
+		t.Error("parse with wrong key should fail")
+	}
+
+	expired, err := GenerateInvitationToken("secret", "t1", "u1", "code", time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseInvitationToken("secret", expired); !errors.Is(err, ErrExpiredOrNotActive) {
+		t.Errorf("expired token err = %v", err)
+	}
+}